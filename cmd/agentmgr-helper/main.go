@@ -3,6 +3,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"os"
 	"os/signal"
@@ -12,8 +13,10 @@ import (
 	"github.com/kevinelliott/agentmgr/internal/systray"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
 	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/deeplink"
 	"github.com/kevinelliott/agentmgr/pkg/detector"
 	"github.com/kevinelliott/agentmgr/pkg/installer"
+	"github.com/kevinelliott/agentmgr/pkg/ipc"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
@@ -26,13 +29,60 @@ var (
 )
 
 func main() {
-	if err := run(); err != nil {
+	noTray := flag.Bool("no-tray", false, "run headless without a system tray icon")
+	openURL := flag.String("open-url", "", "forward an agentmgr:// deep link to the running helper and exit")
+	flag.Parse()
+
+	if *openURL != "" {
+		if err := forwardOpenURL(*openURL); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(*noTray); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func run() error {
+// forwardOpenURL sends a deep link to the running helper instance over
+// IPC. The OS URL handler launches a fresh process per link, so the link
+// is handed to the long-lived helper rather than handled here.
+func forwardOpenURL(rawURL string) error {
+	if _, err := deeplink.Parse(rawURL); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	client := ipc.NewClient("")
+	if err := client.Connect(ctx); err != nil {
+		return fmt.Errorf("helper is not running: %w", err)
+	}
+	defer func() { _ = client.Disconnect() }()
+
+	msg, err := ipc.NewMessage(ipc.MessageTypeOpenURL, ipc.OpenURLRequest{URL: rawURL})
+	if err != nil {
+		return err
+	}
+	resp, err := client.Send(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if resp.Type == ipc.MessageTypeError {
+		var errResp ipc.ErrorResponse
+		if err := resp.DecodePayload(&errResp); err == nil {
+			return fmt.Errorf("%s", errResp.Message)
+		}
+		return fmt.Errorf("helper rejected the link")
+	}
+	return nil
+}
+
+func run(noTray bool) error {
 	// Get current platform
 	plat := platform.Current()
 
@@ -43,6 +93,9 @@ func run() error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Opt in to the login-shell PATH probe before any detection runs
+	platform.SetLoginShellPath(cfg.Detection.LoginShellPath)
+
 	// Initialize storage
 	dataDir := plat.GetDataDir()
 	store, err := storage.NewSQLiteStore(dataDir)
@@ -61,12 +114,17 @@ func run() error {
 
 	// Initialize detector with strategies
 	det := detector.New(plat)
+	det.SetInstallersConfig(cfg.Installers)
 
 	// Initialize catalog manager
 	cat := catalog.NewManager(cfg, store)
 
 	// Initialize installer manager
 	inst := installer.NewManager(plat)
+	inst.SetInstallersConfig(cfg.Installers)
+	inst.SetHooksConfig(cfg.Hooks, cfg.Agents)
+	inst.SetPolicy(cfg.Policy)
+	inst.SetStore(store)
 
 	// Create systray app
 	app := systray.New(cfg, loader, plat, store, det, cat, inst, version)
@@ -81,6 +139,11 @@ func run() error {
 		app.Quit()
 	}()
 
+	// Headless mode: run only the IPC/REST servers and background loops
+	if noTray {
+		return app.RunHeadless()
+	}
+
 	// Run systray on main thread (required for macOS)
 	// This blocks until systray.Quit() is called
 	return app.Run()
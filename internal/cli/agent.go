@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -14,12 +15,17 @@ import (
 
 	"github.com/kevinelliott/agentmgr/internal/cli/output"
 	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/auth"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
 	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/detector"
 	"github.com/kevinelliott/agentmgr/pkg/installer"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/prefs"
+	"github.com/kevinelliott/agentmgr/pkg/statusfile"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
+	"github.com/kevinelliott/agentmgr/pkg/updater"
 )
 
 // NewAgentCommand creates the agent management command group.
@@ -39,9 +45,18 @@ detailed information about agents.`,
 		newAgentListCommand(cfg),
 		newAgentInstallCommand(cfg),
 		newAgentUpdateCommand(cfg),
+		newAgentDowngradeCommand(cfg),
 		newAgentInfoCommand(cfg),
+		newAgentLoginCommand(cfg),
 		newAgentRemoveCommand(cfg),
 		newAgentRefreshCommand(cfg),
+		newAgentShimCommand(cfg),
+		newAgentFavoriteCommand(cfg),
+		newAgentUnfavoriteCommand(cfg),
+		newAgentHideCommand(cfg),
+		newAgentUnhideCommand(cfg),
+		newAgentSubscribeCommand(cfg),
+		newAgentUnsubscribeCommand(cfg),
 	)
 
 	return cmd
@@ -57,6 +72,7 @@ func newAgentListCommand(cfg *config.Config) *cobra.Command {
 		checkUpdates bool
 		refresh      bool
 		verify       bool
+		scope        string
 	)
 
 	cmd := &cobra.Command{
@@ -71,6 +87,10 @@ method, and update status.
 Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 		Aliases: []string{"ls"},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if scope != "" && scope != string(agent.ScopeUser) && scope != string(agent.ScopeSystem) {
+				return fmt.Errorf("invalid scope %q (must be user or system)", scope)
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
 			defer cancel()
 
@@ -144,6 +164,7 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 
 				// Create detector and detect agents
 				det := detector.New(plat)
+				det.SetInstallersConfig(cfg.Installers)
 				installations, err = det.DetectAll(ctx, agentDefs)
 				if err != nil {
 					spinner.Error("Agent detection failed")
@@ -158,6 +179,7 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 			if needUpdateCheck {
 				// Create installer manager for version checking
 				instMgr := installer.NewManager(plat)
+				instMgr.SetInstallersConfig(cfg.Installers)
 
 				// Update spinner for version checking
 				spinner.UpdateMessage("Checking for updates...")
@@ -170,7 +192,8 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 						if method, ok := agentDef.InstallMethods[methodStr]; ok {
 							// Get latest version from package registry
 							latestVer, err := instMgr.GetLatestVersion(ctx, method)
-							if err == nil {
+							latestVer = schemeVersion(agentDef, latestVer)
+							if err == nil && pinAllowsVersion(cfg, inst.AgentID, latestVer) {
 								inst.LatestVersion = &latestVer
 							}
 						}
@@ -189,14 +212,24 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 				}
 			}
 
+			// Fill in recently-updated and restart-pending state from the
+			// stored update history
+			updater.AnnotateUpdateState(ctx, store, installations, agentDefMap)
+
+			// Publish the statusbar summary file if enabled
+			publishStatusFile(ctx, cfg, plat, store, installations)
+
 			// Stop spinner
 			spinner.Stop()
 
+			// Stored per-agent preferences (favorites, hidden)
+			agentPrefs := prefs.NewManager(store).Get(ctx)
+
 			// Apply filters
 			var filtered []*agent.Installation
 			for _, inst := range installations {
 				// Skip hidden agents unless --hidden flag
-				if !showHidden && cfg.IsAgentHidden(inst.AgentID) {
+				if !showHidden && (cfg.IsAgentHidden(inst.AgentID) || agentPrefs.IsHidden(inst.AgentID)) {
 					continue
 				}
 
@@ -205,14 +238,29 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 					continue
 				}
 
+				// Filter by installation scope if requested
+				if scope != "" && string(inst.Scope) != scope {
+					continue
+				}
+
 				filtered = append(filtered, inst)
 			}
 
-			// Sort agents alphabetically by name (case-insensitive)
+			// Sort agents alphabetically by name (case-insensitive),
+			// with favorites first
 			sort.Slice(filtered, func(i, j int) bool {
+				fi, fj := agentPrefs.IsFavorite(filtered[i].AgentID), agentPrefs.IsFavorite(filtered[j].AgentID)
+				if fi != fj {
+					return fi
+				}
 				return strings.ToLower(filtered[i].AgentName) < strings.ToLower(filtered[j].AgentName)
 			})
 
+			// Fill in sign-in state alongside the health check
+			if verify {
+				auth.Annotate(ctx, filtered, agentDefMap)
+			}
+
 			// Convert to list items
 			items := make([]AgentListItem, 0, len(filtered))
 			for _, inst := range filtered {
@@ -222,14 +270,26 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 				}
 
 				item := AgentListItem{
-					ID:            inst.AgentID,
-					Name:          inst.AgentName,
-					Method:        string(inst.Method),
-					Version:       inst.InstalledVersion.String(),
-					LatestVersion: latestVer,
-					HasUpdate:     inst.HasUpdate(),
-					Path:          inst.ExecutablePath,
-					Status:        string(inst.GetStatus()),
+					ID:              inst.AgentID,
+					Name:            inst.AgentName,
+					Method:          string(inst.Method),
+					Version:         inst.InstalledVersion.String(),
+					LatestVersion:   latestVer,
+					HasUpdate:       inst.HasUpdate(),
+					Path:            inst.ExecutablePath,
+					Scope:           string(inst.Scope),
+					Architecture:    inst.Architecture,
+					Emulated:        inst.RunsEmulated(agent.HostArchitecture()),
+					Status:          string(inst.GetStatus()),
+					RecentlyUpdated: inst.RecentlyUpdated(),
+					RestartPending:  inst.RestartPending,
+					ConfiguredOnly:  inst.ConfiguredOnly,
+					AuthStatus:      inst.AuthStatus,
+				}
+
+				if agentDef, ok := agentDefMap[inst.AgentID]; ok {
+					item.Deprecated = agentDef.Deprecated
+					item.ReplacedBy = agentDef.ReplacedBy
 				}
 
 				// Verify agent health if requested
@@ -259,16 +319,19 @@ Results are cached for 1 hour by default. Use --refresh to force re-detection.`,
 	cmd.Flags().BoolVar(&checkUpdates, "check-updates", false, "check for available updates")
 	cmd.Flags().BoolVarP(&refresh, "refresh", "r", false, "force re-detection (ignore cache)")
 	cmd.Flags().BoolVar(&verify, "verify", false, "verify agents can execute (health check)")
+	cmd.Flags().StringVar(&scope, "scope", "", "filter by installation scope (user, system)")
 
 	return cmd
 }
 
 func newAgentInstallCommand(cfg *config.Config) *cobra.Command {
 	var (
-		method  string
-		version string
-		global  bool
-		force   bool
+		method         string
+		version        string
+		global         bool
+		force          bool
+		installPrereqs bool
+		system         bool
 	)
 
 	cmd := &cobra.Command{
@@ -280,6 +343,10 @@ If no method is specified, the preferred method from the catalog or config
 will be used.`,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Policy.Lockdown {
+				return errors.New(cfg.LockdownReason())
+			}
+
 			agentID := args[0]
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
@@ -288,6 +355,12 @@ will be used.`,
 			// Get current platform
 			plat := platform.Current()
 
+			// System-wide installs write to shared locations, which needs
+			// root/administrator privileges
+			if system && !plat.IsElevated() {
+				return fmt.Errorf("system-wide installation requires administrator privileges (re-run with sudo or from an elevated prompt)")
+			}
+
 			// Create spinner
 			spinner := output.NewSpinner(
 				output.WithMessage("Loading catalog..."),
@@ -344,10 +417,61 @@ will be used.`,
 				return fmt.Errorf("installation method %q not available for %q", method, agentID)
 			}
 
+			// Create installer
+			inst := installer.NewManager(plat)
+			inst.SetInstallersConfig(cfg.Installers)
+			inst.SetHooksConfig(cfg.Hooks, cfg.Agents)
+			inst.SetPolicy(cfg.Policy)
+
+			// Piped curl|sh installs run a remote script; require the
+			// user's consent the first time and again whenever the
+			// script changes
+			if _, piped := providers.ScriptCommandURL(methodDef.Command); piped {
+				spinner.UpdateMessage("Fetching install script...")
+				audit, err := inst.FetchInstallScript(ctx, agentDef, methodDef)
+				if err != nil {
+					spinner.Error("Failed to fetch install script")
+					return fmt.Errorf("failed to fetch install script: %w", err)
+				}
+
+				trust := installer.NewScriptTrust(store)
+				if !trust.IsTrusted(ctx, agentID, methodDef.Method, audit.Hash) && !force {
+					spinner.Stop()
+					if !promptScriptConsent(agentDef, methodDef, audit) {
+						fmt.Println("Canceled")
+						return nil
+					}
+					if err := trust.Remember(ctx, agentID, methodDef.Method, audit.Hash); err != nil {
+						printWarning("Could not record the trust decision: %v", err)
+					}
+					spinner = output.NewSpinner(
+						output.WithMessage("Installing..."),
+						output.WithNoColor(!cfg.UI.UseColors),
+					)
+					spinner.Start()
+				}
+			}
+
+			// Check the agent's declared prerequisites before installing
+			if len(agentDef.Requirements) > 0 {
+				spinner.UpdateMessage("Checking prerequisites...")
+				statuses := inst.CheckRequirements(ctx, agentDef)
+				statuses, err = resolveRequirements(ctx, cmd, inst, statuses, installPrereqs)
+				if err != nil {
+					spinner.Error("Failed to install prerequisites")
+					return err
+				}
+				if unmet := installer.UnmetRequirements(statuses); len(unmet) > 0 && !force {
+					spinner.Error(fmt.Sprintf("Missing prerequisites for %s", agentDef.Name))
+					for _, status := range unmet {
+						fmt.Fprintf(cmd.ErrOrStderr(), "  %s\n", status.Summary())
+					}
+					return fmt.Errorf("%d prerequisite(s) not satisfied (use --install-prereqs or --force)", len(unmet))
+				}
+			}
+
 			spinner.UpdateMessage(fmt.Sprintf("Installing %s via %s...", agentDef.Name, method))
 
-			// Create installer and install
-			inst := installer.NewManager(plat)
 			result, err := inst.Install(ctx, agentDef, methodDef, force)
 			if err != nil {
 				spinner.Error(fmt.Sprintf("Failed to install %s", agentDef.Name))
@@ -355,6 +479,7 @@ will be used.`,
 			}
 
 			spinner.Success(fmt.Sprintf("Installed %s %s successfully", agentDef.Name, result.Version.String()))
+			warnIfShadowed(agentDef.ID, result)
 			return nil
 		},
 	}
@@ -363,15 +488,71 @@ will be used.`,
 	cmd.Flags().StringVarP(&version, "version", "V", "", "specific version to install")
 	cmd.Flags().BoolVarP(&global, "global", "g", true, "install globally")
 	cmd.Flags().BoolVarP(&force, "force", "F", false, "force installation")
+	cmd.Flags().BoolVar(&installPrereqs, "install-prereqs", false, "install missing prerequisites when a fix command is available")
+	cmd.Flags().BoolVar(&system, "system", false, "install system-wide for all users (requires elevation)")
 
 	return cmd
 }
 
+// promptScriptConsent shows the trust-on-first-use dialog for a piped
+// install script: where it comes from, its hash, and the publisher info
+// the catalog carries. It returns true when the user approves running it.
+func promptScriptConsent(agentDef catalog.AgentDef, method catalog.InstallMethodDef, audit *providers.ScriptAudit) bool {
+	fmt.Printf("\n%s installs by running a remote script:\n", agentDef.Name)
+	fmt.Printf("  Source:  %s\n", audit.URL)
+	fmt.Printf("  SHA-256: %s\n", audit.Hash)
+	if publisher := method.Metadata["publisher"]; publisher != "" {
+		fmt.Printf("  Publisher: %s\n", publisher)
+	}
+	if agentDef.Homepage != "" {
+		fmt.Printf("  Homepage: %s\n", agentDef.Homepage)
+	}
+	if agentDef.Repository != "" {
+		fmt.Printf("  Repository: %s\n", agentDef.Repository)
+	}
+	if audit.Changed() {
+		printWarning("The script has changed since it was last fetched")
+		if audit.Diff != "" {
+			fmt.Println(audit.Diff)
+		}
+	}
+
+	fmt.Printf("\nRun this script? [y/N] ")
+	var response string
+	fmt.Scanln(&response)
+	return strings.EqualFold(response, "y")
+}
+
+// resolveRequirements attempts to install unmet prerequisites that declare a
+// fix command, returning the refreshed statuses. When installPrereqs is
+// false the statuses are returned unchanged.
+func resolveRequirements(ctx context.Context, cmd *cobra.Command, inst *installer.Manager, statuses []installer.RequirementStatus, installPrereqs bool) ([]installer.RequirementStatus, error) {
+	if !installPrereqs {
+		return statuses, nil
+	}
+
+	for i, status := range statuses {
+		if status.Satisfied || status.Requirement.FixCmd == "" {
+			continue
+		}
+		fmt.Fprintf(cmd.ErrOrStderr(), "Installing prerequisite %s...\n", status.Requirement.DisplayName())
+		refreshed, err := inst.InstallRequirement(ctx, status.Requirement)
+		if err != nil {
+			return statuses, err
+		}
+		statuses[i] = refreshed
+	}
+
+	return statuses, nil
+}
+
 func newAgentUpdateCommand(cfg *config.Config) *cobra.Command {
 	var (
-		all    bool
-		force  bool
-		dryRun bool
+		all     bool
+		force   bool
+		dryRun  bool
+		yes     bool
+		migrate bool
 	)
 
 	cmd := &cobra.Command{
@@ -383,6 +564,10 @@ When updating, the full changelog is displayed before confirming the update.
 Use --all to update all agents at once.`,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Policy.Lockdown {
+				return errors.New(cfg.LockdownReason())
+			}
+
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 			defer cancel()
 
@@ -420,6 +605,7 @@ Use --all to update all agents at once.`,
 			spinner.UpdateMessage("Detecting agents...")
 
 			det := detector.New(plat)
+			det.SetInstallersConfig(cfg.Installers)
 			installations, err := det.DetectAll(ctx, agentDefs)
 			if err != nil {
 				spinner.Error("Detection failed")
@@ -427,6 +613,10 @@ Use --all to update all agents at once.`,
 			}
 
 			inst := installer.NewManager(plat)
+			inst.SetInstallersConfig(cfg.Installers)
+			inst.SetHooksConfig(cfg.Hooks, cfg.Agents)
+			inst.SetPolicy(cfg.Policy)
+			inst.SetStore(store)
 			cat, err := catMgr.Get(ctx)
 			if err != nil {
 				spinner.Error("Failed to load catalog")
@@ -447,7 +637,8 @@ Use --all to update all agents at once.`,
 					methodStr := string(installation.Method)
 					if method, ok := agentDef.InstallMethods[methodStr]; ok {
 						latestVer, err := inst.GetLatestVersion(ctx, method)
-						if err == nil {
+						latestVer = schemeVersion(agentDef, latestVer)
+						if err == nil && pinAllowsVersion(cfg, installation.AgentID, latestVer) {
 							installation.LatestVersion = &latestVer
 						}
 					}
@@ -457,7 +648,7 @@ Use --all to update all agents at once.`,
 			spinner.Stop()
 
 			if all {
-				return updateAllAgents(ctx, installations, cat, inst, dryRun, printer)
+				return updateAllAgents(ctx, cfg, catMgr, installations, cat, inst, dryRun, yes, printer)
 			}
 
 			if len(args) == 0 {
@@ -465,51 +656,72 @@ Use --all to update all agents at once.`,
 				return fmt.Errorf("agent name required (or use --all)")
 			}
 
-			return updateSingleAgent(ctx, args[0], installations, cat, inst, force, dryRun, printer)
+			return updateSingleAgent(ctx, args[0], installations, cat, inst, force, dryRun, migrate, printer)
 		},
 	}
 
 	cmd.Flags().BoolVar(&all, "all", false, "update all agents")
 	cmd.Flags().BoolVarP(&force, "force", "F", false, "force update")
 	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show what would be updated")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip the update plan confirmation")
+	cmd.Flags().BoolVar(&migrate, "migrate", false, "migrate to the replacement method without prompting when the current one is deprecated")
 
 	return cmd
 }
 
 // updateAllAgents handles the --all flag to update all agents with available updates.
-func updateAllAgents(ctx context.Context, installations []*agent.Installation, cat *catalog.Catalog, inst *installer.Manager, dryRun bool, printer *output.Printer) error {
+// It previews the update plan (versions, changelog summaries, estimated download
+// size, pin conflicts) and asks for confirmation before proceeding.
+func updateAllAgents(ctx context.Context, cfg *config.Config, catMgr *catalog.Manager, installations []*agent.Installation, cat *catalog.Catalog, inst *installer.Manager, dryRun, yes bool, printer *output.Printer) error {
 	styles := printer.Styles()
 
 	spinner := output.NewSpinner(
-		output.WithMessage("Checking for updates..."),
+		output.WithMessage("Planning updates..."),
 		output.WithNoColor(os.Getenv("NO_COLOR") != ""),
 	)
 	spinner.Start()
 
-	var toUpdate []*agent.Installation
-	for _, installation := range installations {
-		if installation.HasUpdate() {
-			toUpdate = append(toUpdate, installation)
-		}
-	}
+	planner := updater.NewPlanner(cfg, catMgr, inst)
+	plan, err := planner.Plan(ctx, installations, cat.Agents)
 
 	spinner.Stop()
 
-	if len(toUpdate) == 0 {
+	if err != nil {
+		return fmt.Errorf("failed to plan updates: %w", err)
+	}
+
+	if len(plan.Items) == 0 {
 		printer.Info("No updates available")
 		return nil
 	}
 
-	printer.Print("\nFound %s with updates:", styles.Bold.Render(fmt.Sprintf("%d agent(s)", len(toUpdate))))
-	for _, installation := range toUpdate {
-		latestVer := "unknown"
-		if installation.LatestVersion != nil {
-			latestVer = installation.LatestVersion.String()
+	printer.Print("\nUpdate plan (%s):", styles.Bold.Render(fmt.Sprintf("%d agent(s)", len(plan.Items))))
+	for _, item := range plan.Items {
+		line := fmt.Sprintf("  - %s: %s -> %s",
+			styles.FormatAgentName(item.AgentName),
+			styles.FormatVersion(item.FromVersion, true),
+			styles.FormatVersion(item.ToVersion, false))
+		if item.EstimatedBytes > 0 {
+			line += fmt.Sprintf(" (~%s)", formatByteSize(item.EstimatedBytes))
 		}
-		printer.Print("  - %s: %s -> %s",
-			styles.FormatAgentName(installation.AgentName),
-			styles.FormatVersion(installation.InstalledVersion.String(), true),
-			styles.FormatVersion(latestVer, false))
+		if item.Blocked {
+			line += fmt.Sprintf(" [blocked: %s]", item.BlockedReason)
+		}
+		printer.Print("%s", line)
+		if item.Changelog != "" {
+			for _, clLine := range strings.Split(item.Changelog, "\n") {
+				printer.Print("      %s", clLine)
+			}
+		}
+	}
+	if plan.TotalEstimatedBytes > 0 {
+		printer.Print("\nEstimated download: ~%s", formatByteSize(plan.TotalEstimatedBytes))
+	}
+
+	updatable := plan.Updatable()
+	if len(updatable) == 0 {
+		printer.Info("\nNothing to update: all available updates are blocked")
+		return nil
 	}
 
 	if dryRun {
@@ -517,8 +729,31 @@ func updateAllAgents(ctx context.Context, installations []*agent.Installation, c
 		return nil
 	}
 
+	if plan.RequiresConfirmation && !yes {
+		fmt.Printf("\nProceed with updating %d agent(s)? [y/N] ", len(updatable))
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			printer.Info("Canceled")
+			return nil
+		}
+	}
+
+	updatableKeys := make(map[string]bool, len(updatable))
+	for _, item := range updatable {
+		updatableKeys[item.AgentID+"/"+item.Method] = true
+	}
+
+	var toUpdate []*agent.Installation
+	for _, installation := range installations {
+		if updatableKeys[installation.AgentID+"/"+string(installation.Method)] {
+			toUpdate = append(toUpdate, installation)
+		}
+	}
+
 	printer.Print("")
 
+	var batch []installer.BatchUpdateItem
 	for _, installation := range toUpdate {
 		agentDef, ok := cat.GetAgent(installation.AgentID)
 		if !ok {
@@ -532,25 +767,51 @@ func updateAllAgents(ctx context.Context, installations []*agent.Installation, c
 			continue
 		}
 
-		spinner := output.NewSpinner(
-			output.WithMessage(fmt.Sprintf("Updating %s via %s...", installation.AgentName, installation.Method)),
-			output.WithNoColor(os.Getenv("NO_COLOR") != ""),
-		)
-		spinner.Start()
+		if replacement, ok := cat.ReplacementFor(agentDef); ok {
+			printer.Warning("%s is deprecated in favor of %s; run 'agentmgr agent update %s --migrate' to switch", installation.AgentName, replacement.Name, installation.AgentID)
+		}
 
-		result, err := inst.Update(ctx, installation, agentDef, methodDef)
-		if err != nil {
-			spinner.Error(fmt.Sprintf("Failed to update %s: %v", installation.AgentName, err))
+		if target, hint := installer.MigrationTarget(agentDef, methodDef); hint {
+			printer.Warning("%s: the %s method is deprecated in favor of %s; run 'agentmgr agent update %s --migrate' to switch", installation.AgentName, methodDef.Method, target.Method, installation.AgentID)
+		}
+
+		batch = append(batch, installer.BatchUpdateItem{
+			Installation: installation,
+			AgentDef:     agentDef,
+			Method:       methodDef,
+		})
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	// Brew-installed agents inside the batch share a single brew upgrade run
+	spinner = output.NewSpinner(
+		output.WithMessage(fmt.Sprintf("Updating %d agent(s)...", len(batch))),
+		output.WithNoColor(os.Getenv("NO_COLOR") != ""),
+	)
+	spinner.Start()
+	outcomes := inst.UpdateBatch(ctx, batch)
+	spinner.Stop()
+
+	for i, outcome := range outcomes {
+		item := batch[i]
+		if outcome.Err != nil {
+			printer.Error("Failed to update %s: %v", item.Installation.AgentName, outcome.Err)
 			continue
 		}
-		spinner.Success(fmt.Sprintf("Updated %s to %s", installation.AgentName, result.Version.String()))
+		printer.Success("Updated %s to %s", item.Installation.AgentName, outcome.Result.Version.String())
 	}
 
 	return nil
 }
 
-// updateSingleAgent handles updating a specific agent by ID.
-func updateSingleAgent(ctx context.Context, agentID string, installations []*agent.Installation, cat *catalog.Catalog, inst *installer.Manager, force, dryRun bool, printer *output.Printer) error {
+// updateSingleAgent handles updating a specific agent by ID. When the
+// installed method carries a migration hint, the user is offered (or, with
+// --migrate, taken straight into) a migration to the replacement method
+// instead of a regular update.
+func updateSingleAgent(ctx context.Context, agentID string, installations []*agent.Installation, cat *catalog.Catalog, inst *installer.Manager, force, dryRun, migrate bool, printer *output.Printer) error {
 	styles := printer.Styles()
 
 	var agentInstallations []*agent.Installation
@@ -571,12 +832,22 @@ func updateSingleAgent(ctx context.Context, agentID string, installations []*age
 		return fmt.Errorf("agent %q not found in catalog", agentID)
 	}
 
-	var hasUpdate bool
+	// A deprecated agent is worth visiting even without a newer version
+	_, agentReplaced := cat.ReplacementFor(agentDef)
+
+	hasUpdate := agentReplaced
 	for _, installation := range agentInstallations {
 		if installation.HasUpdate() || force {
 			hasUpdate = true
 			break
 		}
+		// A deprecated method is worth visiting even without a newer version
+		if methodDef, ok := agentDef.GetInstallMethod(string(installation.Method)); ok {
+			if _, ok := installer.MigrationTarget(agentDef, methodDef); ok {
+				hasUpdate = true
+				break
+			}
+		}
 	}
 
 	if !hasUpdate {
@@ -603,13 +874,35 @@ func updateSingleAgent(ctx context.Context, agentID string, installations []*age
 	}
 
 	for _, installation := range agentInstallations {
-		if !installation.HasUpdate() && !force {
+		methodDef, ok := agentDef.GetInstallMethod(string(installation.Method))
+		if !ok {
+			if installation.HasUpdate() || force {
+				printer.Warning("Skipping %s via %s: method not in catalog", installation.AgentName, installation.Method)
+			}
 			continue
 		}
 
-		methodDef, ok := agentDef.GetInstallMethod(string(installation.Method))
-		if !ok {
-			printer.Warning("Skipping %s via %s: method not in catalog", installation.AgentName, installation.Method)
+		if replacement, ok := cat.ReplacementFor(agentDef); ok {
+			handled, err := offerAgentMigration(ctx, installation, agentDef, methodDef, replacement, inst, migrate, printer)
+			if err != nil {
+				return err
+			}
+			if handled {
+				continue
+			}
+		}
+
+		if target, hint := installer.MigrationTarget(agentDef, methodDef); hint {
+			handled, err := offerMigration(ctx, installation, agentDef, methodDef, target, inst, migrate, printer)
+			if err != nil {
+				return err
+			}
+			if handled {
+				continue
+			}
+		}
+
+		if !installation.HasUpdate() && !force {
 			continue
 		}
 
@@ -625,11 +918,112 @@ func updateSingleAgent(ctx context.Context, agentID string, installations []*age
 			return fmt.Errorf("update failed: %w", err)
 		}
 		spinner.Success(fmt.Sprintf("Updated %s to %s", agentDef.Name, result.Version.String()))
+		warnIfShadowed(agentDef.ID, result)
 	}
 
 	return nil
 }
 
+// warnIfShadowed tells the user when the binary they just installed is
+// not the one PATH resolves, with the shim or a PATH edit as the fix.
+func warnIfShadowed(agentID string, result *providers.Result) {
+	if result == nil || result.ShadowedBy == "" {
+		return
+	}
+	printWarning("%s is shadowed by %s earlier in PATH, so the old binary still runs", result.ExecutablePath, result.ShadowedBy)
+	printInfo("Fix: run 'agentmgr agent shim enable %s', or move the new install's directory ahead of it in PATH", agentID)
+}
+
+// offerMigration guides the user through moving a deprecated install method
+// to its catalog-designated replacement. It returns true when the
+// installation was dealt with (migrated, or the user declined and nothing
+// else should happen to it); a false return falls through to a regular
+// update. On success the agent's preferred method in the config is updated
+// to match.
+func offerMigration(ctx context.Context, installation *agent.Installation, agentDef catalog.AgentDef, from, to catalog.InstallMethodDef, inst *installer.Manager, assumeYes bool, printer *output.Printer) (bool, error) {
+	reason := from.MigrateReason
+	if reason == "" {
+		reason = "deprecated"
+	}
+	printer.Warning("The %s installation of %s is %s; the catalog recommends %s instead", from.Method, agentDef.Name, reason, to.Method)
+
+	if !assumeYes {
+		fmt.Printf("Migrate %s from %s to %s? [y/N] ", agentDef.Name, from.Method, to.Method)
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			printer.Info("Keeping the %s installation (use --migrate to switch later)", from.Method)
+			return false, nil
+		}
+	}
+
+	spinner := output.NewSpinner(
+		output.WithMessage(fmt.Sprintf("Migrating %s from %s to %s...", agentDef.Name, from.Method, to.Method)),
+		output.WithNoColor(os.Getenv("NO_COLOR") != ""),
+	)
+	spinner.Start()
+
+	result, err := inst.Migrate(ctx, installation, agentDef, from, to)
+	if err != nil {
+		spinner.Error(fmt.Sprintf("Failed to migrate %s", agentDef.Name))
+		return true, fmt.Errorf("migration failed: %w", err)
+	}
+	spinner.Success(fmt.Sprintf("Migrated %s to %s (%s)", agentDef.Name, to.Method, result.Version.String()))
+
+	// Carry the choice over to the config so future operations use the
+	// new method by default
+	loader := config.NewLoader()
+	if err := loader.SetAndSave("agents."+agentDef.ID+".preferred_method", to.Method); err != nil {
+		printer.Warning("Could not update preferred method in config: %v", err)
+	}
+	return true, nil
+}
+
+// offerAgentMigration guides the user from a deprecated agent to the
+// catalog agent that replaces it (e.g. after a package rename): the
+// replacement is installed via its preferred supported method, then the
+// old installation is removed. It returns true when the installation was
+// dealt with (migrated, or the user declined and nothing else should
+// happen to it); a false return falls through to a regular update.
+func offerAgentMigration(ctx context.Context, installation *agent.Installation, agentDef catalog.AgentDef, fromMethod catalog.InstallMethodDef, replacement catalog.AgentDef, inst *installer.Manager, assumeYes bool, printer *output.Printer) (bool, error) {
+	reason := agentDef.DeprecationReason
+	if reason == "" {
+		reason = "deprecated"
+	}
+	printer.Warning("%s is %s; the catalog recommends %s instead", agentDef.Name, reason, replacement.Name)
+
+	methods := replacement.GetSupportedMethods(string(platform.Current().ID()))
+	if len(methods) == 0 {
+		printer.Warning("No install method for %s on this platform; keeping %s", replacement.Name, agentDef.Name)
+		return false, nil
+	}
+	toMethod := methods[0]
+
+	if !assumeYes {
+		fmt.Printf("Replace %s with %s (via %s)? [y/N] ", agentDef.Name, replacement.Name, toMethod.Method)
+		var response string
+		fmt.Scanln(&response)
+		if !strings.EqualFold(response, "y") {
+			printer.Info("Keeping %s (use --migrate to switch later)", agentDef.Name)
+			return false, nil
+		}
+	}
+
+	spinner := output.NewSpinner(
+		output.WithMessage(fmt.Sprintf("Replacing %s with %s...", agentDef.Name, replacement.Name)),
+		output.WithNoColor(os.Getenv("NO_COLOR") != ""),
+	)
+	spinner.Start()
+
+	result, err := inst.MigrateAgent(ctx, installation, agentDef, fromMethod, replacement, toMethod)
+	if err != nil {
+		spinner.Error(fmt.Sprintf("Failed to replace %s", agentDef.Name))
+		return true, fmt.Errorf("migration failed: %w", err)
+	}
+	spinner.Success(fmt.Sprintf("Replaced %s with %s (%s)", agentDef.Name, replacement.Name, result.Version.String()))
+	return true, nil
+}
+
 func newAgentInfoCommand(cfg *config.Config) *cobra.Command {
 	var format string
 
@@ -677,6 +1071,7 @@ version information, changelog for available updates, and configuration.`,
 				return fmt.Errorf("failed to get agents: %w", err)
 			}
 			det := detector.New(plat)
+			det.SetInstallersConfig(cfg.Installers)
 			allInstallations, err := det.DetectAll(ctx, agentDefs)
 			if err != nil {
 				return fmt.Errorf("detection failed: %w", err)
@@ -707,6 +1102,16 @@ func outputAgentInfoText(agentDef catalog.AgentDef, installations []*agent.Insta
 	fmt.Printf("Agent: %s\n", agentDef.Name)
 	fmt.Printf("ID: %s\n", agentDef.ID)
 	fmt.Printf("Description: %s\n", agentDef.Description)
+	if agentDef.Deprecated {
+		notice := "this agent has been deprecated"
+		if agentDef.DeprecationReason != "" {
+			notice = agentDef.DeprecationReason
+		}
+		if agentDef.ReplacedBy != "" {
+			notice += fmt.Sprintf("; replaced by %s", agentDef.ReplacedBy)
+		}
+		fmt.Printf("Deprecated: %s\n", notice)
+	}
 	if agentDef.Homepage != "" {
 		fmt.Printf("Homepage: %s\n", agentDef.Homepage)
 	}
@@ -723,6 +1128,27 @@ func outputAgentInfoText(agentDef catalog.AgentDef, installations []*agent.Insta
 		}
 	}
 
+	// Requirement status
+	if len(agentDef.Requirements) > 0 {
+		inst := installer.NewManager(plat)
+		fmt.Printf("\nRequirements:\n")
+		for _, status := range inst.CheckRequirements(context.Background(), agentDef) {
+			fmt.Printf("  - %s\n", status.Summary())
+		}
+	}
+
+	// Sign-in state, for agents that declare an auth check
+	if agentDef.Auth.CheckCmd != "" {
+		switch auth.Check(context.Background(), agentDef) {
+		case agent.AuthSignedIn:
+			fmt.Printf("\nAuth: Signed in\n")
+		case agent.AuthNotSignedIn:
+			fmt.Printf("\nAuth: Not signed in (run 'agentmgr agent login %s')\n", agentDef.ID)
+		default:
+			fmt.Printf("\nAuth: Unknown\n")
+		}
+	}
+
 	// Installed versions
 	if len(installations) > 0 {
 		fmt.Printf("\nInstalled (%d):\n", len(installations))
@@ -737,6 +1163,13 @@ func outputAgentInfoText(agentDef catalog.AgentDef, installations []*agent.Insta
 				inst.ExecutablePath,
 				status,
 			)
+			if inst.Architecture != "" {
+				arch := inst.Architecture
+				if inst.RunsEmulated(agent.HostArchitecture()) {
+					arch += " (runs under emulation; consider a native arm64 build)"
+				}
+				fmt.Printf("    Architecture: %s\n", arch)
+			}
 		}
 	} else {
 		fmt.Printf("\nNot installed\n")
@@ -760,6 +1193,8 @@ func outputAgentInfoJSON(agentDef catalog.AgentDef, installations []*agent.Insta
 		Description   string             `json:"description"`
 		Homepage      string             `json:"homepage,omitempty"`
 		Repository    string             `json:"repository,omitempty"`
+		Deprecated    bool               `json:"deprecated,omitempty"`
+		ReplacedBy    string             `json:"replaced_by,omitempty"`
 		Installations []installationInfo `json:"installations"`
 	}
 
@@ -769,6 +1204,8 @@ func outputAgentInfoJSON(agentDef catalog.AgentDef, installations []*agent.Insta
 		Description: agentDef.Description,
 		Homepage:    agentDef.Homepage,
 		Repository:  agentDef.Repository,
+		Deprecated:  agentDef.Deprecated,
+		ReplacedBy:  agentDef.ReplacedBy,
 	}
 
 	for _, inst := range installations {
@@ -790,20 +1227,99 @@ func outputAgentInfoJSON(agentDef catalog.AgentDef, installations []*agent.Insta
 	return encoder.Encode(info)
 }
 
+func newAgentLoginCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "login <agent-name>",
+		Short: "Sign in to an agent's account",
+		Long: `Run the agent's interactive login flow in the current terminal.
+
+Only agents whose catalog entry declares a login command support this
+(for example those that authenticate via 'claude auth' or 'gh auth').`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID := args[0]
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			plat := platform.Current()
+			store, err := storage.NewSQLiteStore(plat.GetDataDir())
+			if err != nil {
+				return fmt.Errorf("failed to create storage: %w", err)
+			}
+			defer store.Close()
+
+			if err := store.Initialize(ctx); err != nil {
+				return fmt.Errorf("failed to initialize storage: %w", err)
+			}
+
+			catMgr := catalog.NewManager(cfg, store)
+			agentDef, err := catMgr.GetAgent(ctx, agentID)
+			if err != nil || agentDef == nil {
+				return fmt.Errorf("agent %q not found in catalog", agentID)
+			}
+			if agentDef.Auth.LoginCmd == "" {
+				return fmt.Errorf("agent %q does not define a login command", agentID)
+			}
+
+			parts := strings.Fields(agentDef.Auth.LoginCmd)
+
+			// The login flow is interactive (device codes, browser
+			// prompts), so it inherits the terminal and runs unbounded
+			loginCmd := exec.Command(parts[0], parts[1:]...) //nolint:gosec // G204: command comes from the trusted catalog
+			loginCmd.Stdin = os.Stdin
+			loginCmd.Stdout = os.Stdout
+			loginCmd.Stderr = os.Stderr
+			if err := loginCmd.Run(); err != nil {
+				return fmt.Errorf("login command failed: %w", err)
+			}
+			return nil
+		},
+	}
+}
+
+// matchingInstallations filters detected installations down to the
+// agent's copies that satisfy the optional method and path selectors.
+// An empty selector matches everything, so with neither set the agent's
+// installations are returned unfiltered.
+func matchingInstallations(installations []*agent.Installation, agentID, method, execPath string) []*agent.Installation {
+	var matched []*agent.Installation
+	for _, installation := range installations {
+		if installation.AgentID != agentID {
+			continue
+		}
+		if method != "" && string(installation.Method) != method {
+			continue
+		}
+		if execPath != "" && installation.ExecutablePath != execPath {
+			continue
+		}
+		matched = append(matched, installation)
+	}
+	return matched
+}
+
 func newAgentRemoveCommand(cfg *config.Config) *cobra.Command {
 	var (
-		force  bool
-		method string
+		force    bool
+		method   string
+		execPath string
 	)
 
 	cmd := &cobra.Command{
 		Use:   "remove <agent-name>",
 		Short: "Remove an agent installation",
 		Long: `Remove an installed agent. By default, prompts for confirmation.
-Use --method to specify which installation to remove if multiple exist.`,
+Use --method to specify which installation to remove if multiple exist,
+and --path to pick one copy when the same method is installed in several
+locations (e.g. under different Node versions).`,
 		Aliases: []string{"rm", "uninstall"},
 		Args:    cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Policy.Lockdown {
+				return errors.New(cfg.LockdownReason())
+			}
+
 			agentID := args[0]
 
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
@@ -833,6 +1349,7 @@ Use --method to specify which installation to remove if multiple exist.`,
 
 			// Detect current installations
 			det := detector.New(plat)
+			det.SetInstallersConfig(cfg.Installers)
 			installations, err := det.DetectAll(ctx, agentDefs)
 			if err != nil {
 				return fmt.Errorf("detection failed: %w", err)
@@ -845,18 +1362,12 @@ Use --method to specify which installation to remove if multiple exist.`,
 			}
 
 			// Find installations for this agent
-			var agentInstallations []*agent.Installation
-			for _, installation := range installations {
-				if installation.AgentID == agentID {
-					// Filter by method if specified
-					if method != "" && string(installation.Method) != method {
-						continue
-					}
-					agentInstallations = append(agentInstallations, installation)
-				}
-			}
+			agentInstallations := matchingInstallations(installations, agentID, method, execPath)
 
 			if len(agentInstallations) == 0 {
+				if execPath != "" {
+					return fmt.Errorf("agent %q not installed at %s", agentID, execPath)
+				}
 				if method != "" {
 					return fmt.Errorf("agent %q not installed via %s", agentID, method)
 				}
@@ -869,8 +1380,10 @@ Use --method to specify which installation to remove if multiple exist.`,
 				return fmt.Errorf("agent %q not found in catalog", agentID)
 			}
 
-			// If multiple installations and no method specified, list them
-			if len(agentInstallations) > 1 && method == "" {
+			// Several copies can remain after filtering: either no
+			// method was given, or the same method is installed in
+			// several locations. List them rather than guess.
+			if len(agentInstallations) > 1 {
 				fmt.Printf("Multiple installations of %s found:\n", agentDef.Name)
 				for _, installation := range agentInstallations {
 					fmt.Printf("  - %s via %s (%s)\n",
@@ -878,15 +1391,16 @@ Use --method to specify which installation to remove if multiple exist.`,
 						installation.Method,
 						installation.ExecutablePath)
 				}
-				fmt.Println("\nUse --method to specify which installation to remove.")
+				fmt.Println("\nUse --method, or --path for a specific copy, to choose which installation to remove.")
 				return nil
 			}
 
 			installation := agentInstallations[0]
 
 			if !force {
-				fmt.Printf("Are you sure you want to remove %s (%s via %s)? [y/N] ",
-					agentDef.Name, installation.InstalledVersion.String(), installation.Method)
+				fmt.Printf("Are you sure you want to remove %s (%s via %s, %s)? [y/N] ",
+					agentDef.Name, installation.InstalledVersion.String(), installation.Method,
+					installation.ExecutablePath)
 				var response string
 				fmt.Scanln(&response)
 				if !strings.EqualFold(response, "y") {
@@ -903,6 +1417,8 @@ Use --method to specify which installation to remove if multiple exist.`,
 
 			// Create installer and uninstall
 			inst := installer.NewManager(plat)
+			inst.SetInstallersConfig(cfg.Installers)
+			inst.SetPolicy(cfg.Policy)
 			fmt.Printf("Removing %s via %s...\n", agentDef.Name, installation.Method)
 
 			if err := inst.Uninstall(ctx, installation, methodDef); err != nil {
@@ -916,6 +1432,7 @@ Use --method to specify which installation to remove if multiple exist.`,
 
 	cmd.Flags().BoolVarP(&force, "force", "F", false, "skip confirmation")
 	cmd.Flags().StringVarP(&method, "method", "m", "", "specific installation method to remove")
+	cmd.Flags().StringVar(&execPath, "path", "", "executable path of the specific copy to remove")
 
 	return cmd
 }
@@ -980,6 +1497,7 @@ results. The new detection results are then cached for future use.`,
 
 			// Create detector and detect agents
 			det := detector.New(plat)
+			det.SetInstallersConfig(cfg.Installers)
 			installations, err := det.DetectAll(ctx, agentDefs)
 			if err != nil {
 				spinner.Error("Agent detection failed")
@@ -988,6 +1506,7 @@ results. The new detection results are then cached for future use.`,
 
 			// Create installer manager for version checking
 			instMgr := installer.NewManager(plat)
+			instMgr.SetInstallersConfig(cfg.Installers)
 
 			spinner.UpdateMessage("Checking for updates...")
 
@@ -997,7 +1516,8 @@ results. The new detection results are then cached for future use.`,
 					methodStr := string(inst.Method)
 					if method, ok := agentDef.InstallMethods[methodStr]; ok {
 						latestVer, err := instMgr.GetLatestVersion(ctx, method)
-						if err == nil {
+						latestVer = schemeVersion(agentDef, latestVer)
+						if err == nil && pinAllowsVersion(cfg, inst.AgentID, latestVer) {
 							inst.LatestVersion = &latestVer
 						}
 					}
@@ -1012,6 +1532,9 @@ results. The new detection results are then cached for future use.`,
 				}
 			}
 
+			// Publish the statusbar summary file if enabled
+			publishStatusFile(ctx, cfg, plat, store, installations)
+
 			// Count agents with updates
 			updateCount := 0
 			for _, inst := range installations {
@@ -1037,9 +1560,27 @@ type AgentListItem struct {
 	LatestVersion string `json:"latest_version,omitempty"`
 	HasUpdate     bool   `json:"has_update"`
 	Path          string `json:"path"`
+	Scope         string `json:"scope,omitempty"`
+	Architecture  string `json:"architecture,omitempty"`
+	Emulated      bool   `json:"emulated,omitempty"`
 	Status        string `json:"status"`
 	Healthy       *bool  `json:"healthy,omitempty"`
 	HealthError   string `json:"health_error,omitempty"`
+	AuthStatus    string `json:"auth_status,omitempty"`
+
+	// RecentlyUpdated marks agents updated within the last day;
+	// RestartPending marks updates waiting on a dependent app restart.
+	RecentlyUpdated bool `json:"recently_updated,omitempty"`
+	RestartPending  bool `json:"restart_pending,omitempty"`
+
+	// ConfiguredOnly marks agents detected only by a leftover config
+	// directory, with no installation found.
+	ConfiguredOnly bool `json:"configured_only,omitempty"`
+
+	// Deprecated marks agents the catalog has sunset; ReplacedBy names
+	// the suggested replacement agent, when one is defined.
+	Deprecated bool   `json:"deprecated,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
 }
 
 func outputAgentsTable(agents []AgentListItem, printer *output.Printer, showHealth bool) error {
@@ -1063,6 +1604,7 @@ func outputAgentsTable(agents []AgentListItem, printer *output.Printer, showHeal
 			styles.FormatHeader("LATEST"),
 			styles.FormatHeader("STATUS"),
 			styles.FormatHeader("HEALTH"),
+			styles.FormatHeader("AUTH"),
 		)
 	} else {
 		table.SetHeaders(
@@ -1078,11 +1620,28 @@ func outputAgentsTable(agents []AgentListItem, printer *output.Printer, showHeal
 	// Add rows
 	for _, agent := range agents {
 		var statusIcon string
-		if agent.HasUpdate {
+		switch {
+		case agent.ConfiguredOnly:
+			statusIcon = styles.NotInstalledIcon() + " not installed (reinstall with 'agentmgr agent install " + agent.ID + "')"
+		case agent.HasUpdate:
 			statusIcon = styles.UpdateIcon()
-		} else {
+		default:
 			statusIcon = styles.InstalledIcon()
 		}
+		if agent.RestartPending {
+			statusIcon += " restart"
+		} else if agent.RecentlyUpdated {
+			statusIcon += " updated"
+		}
+
+		name := styles.FormatAgentName(agent.Name)
+		if agent.Deprecated {
+			badge := "[deprecated]"
+			if agent.ReplacedBy != "" {
+				badge = "[deprecated: use " + agent.ReplacedBy + "]"
+			}
+			name += " " + styles.Muted.Render(badge)
+		}
 
 		latest := agent.LatestVersion
 		if latest == "" {
@@ -1102,17 +1661,18 @@ func outputAgentsTable(agents []AgentListItem, printer *output.Printer, showHeal
 			}
 			table.AddRow(
 				styles.Info.Render(agent.ID),
-				styles.FormatAgentName(agent.Name),
+				name,
 				styles.FormatMethod(agent.Method),
 				styles.FormatVersion(agent.Version, agent.HasUpdate),
 				latest,
 				statusIcon,
 				healthIcon,
+				formatAuthStatus(agent.AuthStatus, styles),
 			)
 		} else {
 			table.AddRow(
 				styles.Info.Render(agent.ID),
-				styles.FormatAgentName(agent.Name),
+				name,
 				styles.FormatMethod(agent.Method),
 				styles.FormatVersion(agent.Version, agent.HasUpdate),
 				latest,
@@ -1125,6 +1685,18 @@ func outputAgentsTable(agents []AgentListItem, printer *output.Printer, showHeal
 	return nil
 }
 
+// formatAuthStatus renders the AUTH column value for the list table.
+func formatAuthStatus(status string, styles *output.Styles) string {
+	switch status {
+	case agent.AuthSignedIn:
+		return styles.SuccessIcon() + " signed in"
+	case agent.AuthNotSignedIn:
+		return styles.WarningIcon() + " not signed in"
+	default:
+		return "-"
+	}
+}
+
 // verifyAgentHealth checks if an agent executable can run by trying --version or --help.
 func verifyAgentHealth(ctx context.Context, execPath string) (bool, string) {
 	// Try common version/help flags
@@ -1169,6 +1741,66 @@ func verifyAgentHealth(ctx context.Context, execPath string) (bool, string) {
 	return false, err.Error()
 }
 
+// schemeVersion re-parses a registry version using the agent's declared
+// version scheme so it compares correctly against the detected installation
+// for calendar-versioned and build-numbered agents.
+func schemeVersion(agentDef catalog.AgentDef, v agent.Version) agent.Version {
+	if agentDef.VersionScheme == "" {
+		return v
+	}
+	parsed, err := agent.ParseVersionScheme(v.String(), agent.VersionScheme(agentDef.VersionScheme), agentDef.VersionPattern)
+	if err != nil {
+		return v
+	}
+	return parsed
+}
+
+// publishStatusFile writes the statusbar summary file after a refresh
+// when ui.status_file is enabled. Failures are non-fatal: the file is
+// informational and pollers just keep seeing the previous snapshot.
+func publishStatusFile(ctx context.Context, cfg *config.Config, plat platform.Platform, store storage.Store, installations []*agent.Installation) {
+	if !cfg.UI.StatusFile {
+		return
+	}
+	lastCheck, _ := store.GetLastUpdateCheckTime(ctx) //nolint:errcheck // zero time is fine when no check has run
+	path := cfg.UI.StatusFilePath
+	if path == "" {
+		path = statusfile.DefaultPath(plat.GetDataDir())
+	}
+	_ = statusfile.Write(path, statusfile.Build(installations, lastCheck)) //nolint:errcheck // best-effort publish
+}
+
+// pinAllowsVersion reports whether an agent's pinned version constraint,
+// if any, allows offering the given version as an update. The pin can be
+// an exact version or a constraint expression like "~1.2" or "<2.0".
+func pinAllowsVersion(cfg *config.Config, agentID string, v agent.Version) bool {
+	pinned := cfg.GetPinnedVersion(agentID)
+	if pinned == "" {
+		return true
+	}
+
+	constraint, err := agent.ParseConstraint(pinned)
+	if err != nil {
+		// An unparseable pin should not hide updates
+		return true
+	}
+	return constraint.Matches(v)
+}
+
+// formatByteSize renders a byte count in human-readable units.
+func formatByteSize(bytes int64) string {
+	switch {
+	case bytes < 1024:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	case bytes < 1024*1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+	default:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1024*1024*1024))
+	}
+}
+
 func outputAgentsJSON(agents []AgentListItem) error {
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
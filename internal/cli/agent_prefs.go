@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/prefs"
+)
+
+// newAgentFavoriteCommand creates the agent favorite command.
+func newAgentFavoriteCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "favorite <agent-id>",
+		Short: "Mark an agent as a favorite",
+		Long: `Mark an agent as a favorite. Favorite agents are always shown at the
+top of the tray menu.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAgentPref(args[0], func(ctx context.Context, mgr *prefs.Manager) error {
+				return mgr.SetFavorite(ctx, args[0], true)
+			}, "%s marked as a favorite\n")
+		},
+	}
+}
+
+// newAgentUnfavoriteCommand creates the agent unfavorite command.
+func newAgentUnfavoriteCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unfavorite <agent-id>",
+		Short: "Remove an agent from favorites",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAgentPref(args[0], func(ctx context.Context, mgr *prefs.Manager) error {
+				return mgr.SetFavorite(ctx, args[0], false)
+			}, "%s removed from favorites\n")
+		},
+	}
+}
+
+// newAgentHideCommand creates the agent hide command.
+func newAgentHideCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "hide <agent-id>",
+		Short: "Hide an agent from the tray and notifications",
+		Long: `Hide an agent from the tray menu and update notifications. Hidden
+agents are still detected and can be shown in listings with --hidden.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAgentPref(args[0], func(ctx context.Context, mgr *prefs.Manager) error {
+				return mgr.SetHidden(ctx, args[0], true)
+			}, "%s hidden\n")
+		},
+	}
+}
+
+// newAgentUnhideCommand creates the agent unhide command.
+func newAgentUnhideCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unhide <agent-id>",
+		Short: "Show a hidden agent again",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAgentPref(args[0], func(ctx context.Context, mgr *prefs.Manager) error {
+				return mgr.SetHidden(ctx, args[0], false)
+			}, "%s unhidden\n")
+		},
+	}
+}
+
+// newAgentSubscribeCommand creates the agent subscribe command.
+func newAgentSubscribeCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "subscribe <agent-id>",
+		Short: "Subscribe to an agent's release notes",
+		Long: `Subscribe to an agent's release notes. Releases of subscribed agents
+appear in the helper's Atom feed at /api/v1/releases.atom, which any
+feed reader can follow.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAgentPref(args[0], func(ctx context.Context, mgr *prefs.Manager) error {
+				return mgr.SetSubscribed(ctx, args[0], true)
+			}, "Subscribed to %s releases\n")
+		},
+	}
+}
+
+// newAgentUnsubscribeCommand creates the agent unsubscribe command.
+func newAgentUnsubscribeCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "unsubscribe <agent-id>",
+		Short: "Unsubscribe from an agent's release notes",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return setAgentPref(args[0], func(ctx context.Context, mgr *prefs.Manager) error {
+				return mgr.SetSubscribed(ctx, args[0], false)
+			}, "Unsubscribed from %s releases\n")
+		},
+	}
+}
+
+// setAgentPref opens the store, applies a preference change, and prints a
+// confirmation containing the agent ID.
+func setAgentPref(agentID string, apply func(context.Context, *prefs.Manager) error, confirmFormat string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	store, err := openJobStore(ctx)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := apply(ctx, prefs.NewManager(store)); err != nil {
+		return err
+	}
+
+	fmt.Printf(confirmFormat, agentID)
+	return nil
+}
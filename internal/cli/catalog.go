@@ -36,6 +36,7 @@ sources.`,
 		newCatalogRefreshCommand(cfg),
 		newCatalogSearchCommand(cfg),
 		newCatalogShowCommand(cfg),
+		newCatalogValidateCommand(),
 	)
 
 	return cmd
@@ -113,6 +114,8 @@ by platform compatibility.`,
 					Name:        agentDef.Name,
 					Description: agentDef.Description,
 					Methods:     methodNames,
+					Deprecated:  agentDef.Deprecated,
+					ReplacedBy:  agentDef.ReplacedBy,
 				})
 			}
 
@@ -187,6 +190,12 @@ in configuration.`,
 				return fmt.Errorf("failed to get catalog: %w", err)
 			}
 
+			if result.SchemaTooNew {
+				spinner.Success(fmt.Sprintf("Keeping catalog version %s - %d agents available", cat.Version, len(cat.Agents)))
+				printWarning("Remote catalog uses schema version %d (this build supports %d); update AgentManager to get the latest catalog", result.RemoteSchema, catalog.SupportedSchemaVersion)
+				return nil
+			}
+
 			if result.Updated {
 				spinner.Success(fmt.Sprintf("Catalog updated to version %s - %d agents available", cat.Version, len(cat.Agents)))
 			} else {
@@ -271,6 +280,8 @@ func newCatalogSearchCommand(cfg *config.Config) *cobra.Command {
 					Name:        agentDef.Name,
 					Description: agentDef.Description,
 					Methods:     methodNames,
+					Deprecated:  agentDef.Deprecated,
+					ReplacedBy:  agentDef.ReplacedBy,
 				})
 			}
 
@@ -343,6 +354,16 @@ installation methods, detection signatures, and changelog sources.`,
 			fmt.Printf("Agent: %s\n", agentDef.Name)
 			fmt.Printf("ID: %s\n", agentDef.ID)
 			fmt.Printf("Description: %s\n", agentDef.Description)
+			if agentDef.Deprecated {
+				notice := "this agent has been deprecated"
+				if agentDef.DeprecationReason != "" {
+					notice = agentDef.DeprecationReason
+				}
+				if agentDef.ReplacedBy != "" {
+					notice += fmt.Sprintf("; replaced by %s", agentDef.ReplacedBy)
+				}
+				fmt.Printf("Deprecated: %s\n", notice)
+			}
 			if agentDef.Homepage != "" {
 				fmt.Printf("Homepage: %s\n", agentDef.Homepage)
 			}
@@ -381,12 +402,70 @@ installation methods, detection signatures, and changelog sources.`,
 	return cmd
 }
 
+func newCatalogValidateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate <file>",
+		Short: "Validate a catalog file before publishing",
+		Long: `Check a catalog JSON file for problems: structural issues the app
+relies on (agent IDs, install methods, replacement references) plus
+stricter per-agent checks such as unknown platforms, regexes that do
+not compile, and malformed URLs.
+
+All problems are reported at once, and the command exits non-zero when
+any are found, so it can gate catalog changes in CI.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := os.ReadFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to read catalog file: %w", err)
+			}
+
+			var cat catalog.Catalog
+			if err := json.Unmarshal(data, &cat); err != nil {
+				return fmt.Errorf("failed to parse catalog file: %w", err)
+			}
+
+			problems := 0
+			if err := cat.Validate(); err != nil {
+				fmt.Printf("  %v\n", err)
+				problems++
+			}
+
+			ids := make([]string, 0, len(cat.Agents))
+			for id := range cat.Agents {
+				ids = append(ids, id)
+			}
+			sort.Strings(ids)
+			for _, id := range ids {
+				for _, err := range catalog.Validate(cat.Agents[id]) {
+					fmt.Printf("  %s: %v\n", id, err)
+					problems++
+				}
+			}
+
+			if problems > 0 {
+				return fmt.Errorf("catalog has %d problem(s)", problems)
+			}
+
+			printSuccess("Catalog is valid (%d agents)", len(cat.Agents))
+			return nil
+		},
+	}
+
+	return cmd
+}
+
 // CatalogListItem represents an agent in the catalog list output.
 type CatalogListItem struct {
 	ID          string   `json:"id"`
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
 	Methods     []string `json:"methods"`
+
+	// Deprecated marks agents the catalog has sunset; ReplacedBy names
+	// the suggested replacement agent, when one is defined.
+	Deprecated bool   `json:"deprecated,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
 }
 
 func outputCatalogTable(agents []CatalogListItem, printer *output.Printer) error {
@@ -421,9 +500,18 @@ func outputCatalogTable(agents []CatalogListItem, printer *output.Printer) error
 			desc = desc[:37] + "..."
 		}
 
+		name := styles.FormatAgentName(agent.Name)
+		if agent.Deprecated {
+			badge := "[deprecated]"
+			if agent.ReplacedBy != "" {
+				badge = "[deprecated: use " + agent.ReplacedBy + "]"
+			}
+			name += " " + styles.Muted.Render(badge)
+		}
+
 		table.AddRow(
 			styles.Info.Render(agent.ID),
-			styles.FormatAgentName(agent.Name),
+			name,
 			styles.FormatMethod(methods),
 			styles.Muted.Render(desc),
 		)
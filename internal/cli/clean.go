@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/internal/cli/output"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/gc"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// NewCleanCommand creates the clean command for garbage collecting
+// orphaned agent artifacts.
+func NewCleanCommand(cfg *config.Config) *cobra.Command {
+	var (
+		format string
+		yes    bool
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clean",
+		Short: "Find and remove orphaned agent artifacts",
+		Long: `Scan for leftovers from removed agents — dangling npm global packages,
+stale pipx venvs, and broken symlinks in your bin directories — and
+optionally remove them.
+
+By default the command reports what it finds and asks for confirmation
+before cleaning. Use --dry-run to only report, or --yes to skip the
+confirmation prompt.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Policy.Lockdown {
+				return errors.New(cfg.LockdownReason())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			printer := output.NewPrinter(cfg, cmd.Flag("no-color").Changed && cmd.Flag("no-color").Value.String() == "true")
+			plat := platform.Current()
+
+			store, err := storage.NewSQLiteStore(plat.GetDataDir())
+			if err != nil {
+				return fmt.Errorf("failed to create storage: %w", err)
+			}
+			defer store.Close()
+
+			if err := store.Initialize(ctx); err != nil {
+				return fmt.Errorf("failed to initialize storage: %w", err)
+			}
+
+			catMgr := catalog.NewManager(cfg, store)
+			cat, err := catMgr.Get(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load catalog: %w", err)
+			}
+
+			scanner := gc.NewScanner(plat)
+			orphans, err := scanner.Scan(ctx, cat)
+			if err != nil {
+				return fmt.Errorf("scan failed: %w", err)
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(orphans)
+			}
+
+			if len(orphans) == 0 {
+				printer.Success("No orphaned artifacts found")
+				return nil
+			}
+
+			fmt.Printf("Found %d orphaned artifact(s):\n\n", len(orphans))
+			for _, o := range orphans {
+				location := o.Path
+				if location == "" {
+					location = o.Name
+				}
+				fmt.Printf("  [%s] %s — %s\n", o.Type, location, o.Description)
+			}
+			fmt.Println()
+
+			if dryRun {
+				printer.Info("Dry run: nothing removed")
+				return nil
+			}
+
+			if !yes {
+				fmt.Print("Remove these artifacts? [y/N] ")
+				reader := bufio.NewReader(os.Stdin)
+				answer, _ := reader.ReadString('\n')
+				if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "y") {
+					printer.Info("Aborted")
+					return nil
+				}
+			}
+
+			if err := scanner.Clean(ctx, orphans); err != nil {
+				return err
+			}
+
+			printer.Success("Removed %d artifact(s)", len(orphans))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip confirmation prompt")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "report orphans without removing them")
+
+	return cmd
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/config"
 )
 
@@ -142,6 +143,47 @@ func TestNewAgentCommand(t *testing.T) {
 	if removeCmd != nil {
 		assertFlagExists(t, removeCmd, "force")
 		assertFlagExists(t, removeCmd, "method")
+		assertFlagExists(t, removeCmd, "path")
+	}
+}
+
+func TestMatchingInstallations(t *testing.T) {
+	// Two copies of the same agent via the same method plus an
+	// unrelated agent, mirroring an agent installed under several
+	// Node versions.
+	copyA := &agent.Installation{AgentID: "claude-code", Method: agent.MethodNPM, ExecutablePath: "/usr/local/bin/claude"}
+	copyB := &agent.Installation{AgentID: "claude-code", Method: agent.MethodNPM, ExecutablePath: "/home/u/.nvm/versions/node/v20.11.0/bin/claude"}
+	other := &agent.Installation{AgentID: "aider", Method: agent.MethodPip, ExecutablePath: "/usr/local/bin/aider"}
+	installations := []*agent.Installation{copyA, copyB, other}
+
+	tests := []struct {
+		name     string
+		agentID  string
+		method   string
+		execPath string
+		expected []*agent.Installation
+	}{
+		{"no selectors returns all copies", "claude-code", "", "", []*agent.Installation{copyA, copyB}},
+		{"method alone keeps both copies", "claude-code", "npm", "", []*agent.Installation{copyA, copyB}},
+		{"path selects one copy", "claude-code", "", copyB.ExecutablePath, []*agent.Installation{copyB}},
+		{"method and path select one copy", "claude-code", "npm", copyA.ExecutablePath, []*agent.Installation{copyA}},
+		{"wrong method matches nothing", "claude-code", "brew", "", nil},
+		{"wrong path matches nothing", "claude-code", "", "/nonexistent/claude", nil},
+		{"unknown agent matches nothing", "goose", "", "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := matchingInstallations(installations, tt.agentID, tt.method, tt.execPath)
+			if len(result) != len(tt.expected) {
+				t.Fatalf("matched %d installations, want %d: %+v", len(result), len(tt.expected), result)
+			}
+			for i, want := range tt.expected {
+				if result[i] != want {
+					t.Errorf("result[%d] = %s, want %s", i, result[i].ExecutablePath, want.ExecutablePath)
+				}
+			}
+		})
 	}
 }
 
@@ -425,7 +467,11 @@ func TestParseConfigValueAllBoolKeys(t *testing.T) {
 		"ui.compact_mode",
 		"api.enable_grpc",
 		"api.enable_rest",
+		"api.enable_graphql",
+		"api.enable_mdns",
 		"api.require_auth",
+		"stats.enabled",
+		"enrollment.enabled",
 	}
 
 	for _, key := range boolKeys {
@@ -488,7 +534,7 @@ func TestRootCommandSubcommandCount(t *testing.T) {
 
 	// Verify we have exactly the expected number of subcommands
 	// This helps catch if subcommands are accidentally removed
-	expectedCount := 11 // agent, api, catalog, completion, config, doctor, helper, plugin, tui, upgrade, version
+	expectedCount := 21 // agent, api, catalog, clean, completion, config, diagnostics, doctor, export, helper, history, jobs, plugin, prompt, snapshot, stats, tui, upgrade, use, version, watch
 	actualCount := len(cmd.Commands())
 
 	if actualCount != expectedCount {
@@ -504,7 +550,7 @@ func TestAgentCommandSubcommandCount(t *testing.T) {
 	cfg := &config.Config{}
 	cmd := NewAgentCommand(cfg)
 
-	expectedCount := 6 // list, install, update, info, remove, refresh
+	expectedCount := 15 // list, install, update, downgrade, info, login, remove, refresh, shim, favorite, unfavorite, hide, unhide, subscribe, unsubscribe
 	actualCount := len(cmd.Commands())
 
 	if actualCount != expectedCount {
@@ -520,7 +566,7 @@ func TestCatalogCommandSubcommandCount(t *testing.T) {
 	cfg := &config.Config{}
 	cmd := NewCatalogCommand(cfg)
 
-	expectedCount := 4 // list, refresh, search, show
+	expectedCount := 5 // list, refresh, search, show, validate
 	actualCount := len(cmd.Commands())
 
 	if actualCount != expectedCount {
@@ -552,7 +598,7 @@ func TestHelperCommandSubcommandCount(t *testing.T) {
 	cfg := &config.Config{}
 	cmd := NewHelperCommand(cfg)
 
-	expectedCount := 4 // start, stop, status, autostart
+	expectedCount := 5 // start, stop, status, autostart, reload
 	actualCount := len(cmd.Commands())
 
 	if actualCount != expectedCount {
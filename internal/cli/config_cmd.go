@@ -140,7 +140,10 @@ func parseConfigValue(key, value string) interface{} {
 		"catalog.refresh_on_start",
 		"updates.auto_check", "updates.notify", "updates.auto_update",
 		"ui.show_hidden", "ui.use_colors", "ui.compact_mode",
-		"api.enable_grpc", "api.enable_rest", "api.require_auth",
+		"api.enable_grpc", "api.enable_rest", "api.enable_graphql", "api.enable_mdns", "api.require_auth",
+		"api.allow_lan", "api.enable_tls",
+		"stats.enabled",
+		"enrollment.enabled",
 	}
 	for _, k := range boolKeys {
 		if key == k {
@@ -167,6 +170,7 @@ func parseConfigValue(key, value string) interface{} {
 	durationKeys := []string{
 		"catalog.refresh_interval",
 		"updates.check_interval",
+		"enrollment.interval",
 	}
 	for _, k := range durationKeys {
 		if key == k {
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/diagnostics"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// NewDiagnosticsCommand creates the diagnostics command for producing
+// support bundles.
+func NewDiagnosticsCommand(cfg *config.Config, version string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diagnostics",
+		Short: "Collect diagnostics for bug reports",
+	}
+
+	cmd.AddCommand(newDiagnosticsBundleCommand(cfg, version))
+
+	return cmd
+}
+
+// newDiagnosticsBundleCommand creates the diagnostics bundle subcommand.
+func newDiagnosticsBundleCommand(cfg *config.Config, version string) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Write a diagnostics zip with logs, config, and status",
+		Long: `Write a zip archive for attaching to bug reports.
+
+The bundle contains the tail of the log file, the current configuration
+with secrets redacted, and a snapshot of version and platform details.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if output == "" {
+				output = fmt.Sprintf("agentmgr-diagnostics-%s.zip",
+					time.Now().Format("20060102-150405"))
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create bundle: %w", err)
+			}
+
+			if err := diagnostics.WriteBundle(cfg, platform.Current(), version, f); err != nil {
+				f.Close()
+				os.Remove(output)
+				return fmt.Errorf("failed to write bundle: %w", err)
+			}
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("failed to write bundle: %w", err)
+			}
+
+			printSuccess("Diagnostics bundle written to %s", output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output file (default agentmgr-diagnostics-<timestamp>.zip)")
+
+	return cmd
+}
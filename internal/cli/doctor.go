@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -12,7 +13,9 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/kevinelliott/agentmgr/internal/cli/output"
+	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/installer"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
@@ -91,6 +94,14 @@ Examples:
 			printResults(printer, storeResults)
 			printer.Println()
 
+			// PATH checks
+			printer.Print("PATH")
+			printer.Print("----")
+			pathResults := runPathChecks(ctx)
+			results = append(results, pathResults...)
+			printResults(printer, pathResults)
+			printer.Println()
+
 			// Configuration checks
 			printer.Print("Configuration")
 			printer.Print("-------------")
@@ -366,6 +377,79 @@ func runStorageChecks(ctx context.Context, cfg *config.Config, _ bool) []CheckRe
 	return results
 }
 
+// runPathChecks verifies that each detected agent's executable is the
+// one PATH resolves, catching installs shadowed by a stale binary
+// earlier in PATH. Agents with several installations pass as long as
+// PATH finds any of them.
+func runPathChecks(ctx context.Context) []CheckResult {
+	skipped := func(message string) []CheckResult {
+		return []CheckResult{{Name: "PATH Shadowing", Status: CheckSkipped, Message: message}}
+	}
+
+	plat := platform.Current()
+	store, err := storage.NewSQLiteStore(plat.GetDataDir())
+	if err != nil {
+		return skipped("storage unavailable")
+	}
+	defer store.Close()
+	if err := store.Initialize(ctx); err != nil {
+		return skipped("storage unavailable")
+	}
+
+	installations, _, err := store.GetDetectionCache(ctx)
+	if err != nil || len(installations) == 0 {
+		return skipped("no cached agents; run 'agentmgr agent list' first")
+	}
+
+	byAgent := make(map[string][]*agent.Installation)
+	var agentOrder []string
+	for _, inst := range installations {
+		if inst.ExecutablePath == "" {
+			continue
+		}
+		if _, ok := byAgent[inst.AgentID]; !ok {
+			agentOrder = append(agentOrder, inst.AgentID)
+		}
+		byAgent[inst.AgentID] = append(byAgent[inst.AgentID], inst)
+	}
+
+	var results []CheckResult
+	checked := 0
+	for _, agentID := range agentOrder {
+		group := byAgent[agentID]
+		resolved, err := exec.LookPath(filepath.Base(group[0].ExecutablePath))
+		if err != nil {
+			continue
+		}
+		checked++
+
+		found := false
+		for _, inst := range group {
+			if installer.SamePath(resolved, inst.ExecutablePath) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			results = append(results, CheckResult{
+				Name:    group[0].AgentName,
+				Status:  CheckWarning,
+				Message: fmt.Sprintf("%s is shadowed by %s", group[0].ExecutablePath, resolved),
+				Fix:     fmt.Sprintf("Run 'agentmgr agent shim enable %s' or reorder PATH", agentID),
+			})
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, CheckResult{
+			Name:    "PATH Shadowing",
+			Status:  CheckOK,
+			Message: fmt.Sprintf("%d agent executable(s) resolve to their install", checked),
+		})
+	}
+	return results
+}
+
 func runConfigChecks(cfg *config.Config, _ bool) []CheckResult {
 	var results []CheckResult
 
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/internal/cli/output"
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/installer"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+func newAgentDowngradeCommand(cfg *config.Config) *cobra.Command {
+	var method string
+
+	cmd := &cobra.Command{
+		Use:   "downgrade <agent-name> <version>",
+		Short: "Downgrade an agent to a specific previous version",
+		Long: `Downgrade an installed agent to a specific older version.
+
+The target version must be older than the currently installed version.
+Downgrades are supported for npm, pip/pipx/uv and brew installations
+(brew requires a versioned formula to exist).`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Policy.Lockdown {
+				return errors.New(cfg.LockdownReason())
+			}
+
+			agentID := args[0]
+
+			target, err := agent.ParseVersion(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid target version %q: %w", args[1], err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+
+			plat := platform.Current()
+
+			spinner := output.NewSpinner(
+				output.WithMessage("Loading catalog..."),
+				output.WithNoColor(!cfg.UI.UseColors),
+			)
+			spinner.Start()
+
+			store, err := storage.NewSQLiteStore(plat.GetDataDir())
+			if err != nil {
+				spinner.Error("Failed to create storage")
+				return fmt.Errorf("failed to create storage: %w", err)
+			}
+			defer store.Close()
+
+			if err := store.Initialize(ctx); err != nil {
+				spinner.Error("Failed to initialize storage")
+				return fmt.Errorf("failed to initialize storage: %w", err)
+			}
+
+			catMgr := catalog.NewManager(cfg, store)
+			cat, err := catMgr.Get(ctx)
+			if err != nil {
+				spinner.Error("Failed to load catalog")
+				return fmt.Errorf("failed to load catalog: %w", err)
+			}
+
+			agentDef, ok := cat.GetAgent(agentID)
+			if !ok {
+				spinner.Error(fmt.Sprintf("Agent %q not found in catalog", agentID))
+				return fmt.Errorf("agent %q not found in catalog", agentID)
+			}
+
+			// Re-parse the target now that the agent's version scheme is
+			// known, so calendar and build-number versions order correctly
+			target = schemeVersion(agentDef, target)
+
+			spinner.UpdateMessage("Detecting installation...")
+
+			det := detector.New(plat)
+			det.SetInstallersConfig(cfg.Installers)
+			installations, err := det.DetectAgent(ctx, agentDef)
+			if err != nil {
+				spinner.Error("Detection failed")
+				return fmt.Errorf("detection failed: %w", err)
+			}
+
+			var inst *agent.Installation
+			for _, i := range installations {
+				if method == "" || string(i.Method) == method {
+					inst = i
+					break
+				}
+			}
+			if inst == nil {
+				spinner.Error(fmt.Sprintf("%s is not installed", agentDef.Name))
+				return fmt.Errorf("%s is not installed", agentID)
+			}
+
+			methodDef, ok := agentDef.GetInstallMethod(string(inst.Method))
+			if !ok {
+				spinner.Error(fmt.Sprintf("Installation method %q not available", inst.Method))
+				return fmt.Errorf("installation method %q not available for %q", inst.Method, agentID)
+			}
+
+			spinner.UpdateMessage(fmt.Sprintf("Downgrading %s from %s to %s...",
+				agentDef.Name, inst.InstalledVersion, target))
+
+			instMgr := installer.NewManager(plat)
+			instMgr.SetInstallersConfig(cfg.Installers)
+			instMgr.SetHooksConfig(cfg.Hooks, cfg.Agents)
+			instMgr.SetPolicy(cfg.Policy)
+			startedAt := time.Now()
+			result, err := instMgr.Downgrade(ctx, inst, agentDef, methodDef, target)
+
+			// Record the downgrade in update history
+			completedAt := time.Now()
+			event := &storage.UpdateEvent{
+				AgentID:       agentDef.ID,
+				AgentName:     agentDef.Name,
+				InstallMethod: string(inst.Method),
+				FromVersion:   inst.InstalledVersion.String(),
+				ToVersion:     target.String(),
+				Status:        storage.UpdateStatusCompleted,
+				StartedAt:     startedAt,
+				CompletedAt:   &completedAt,
+			}
+			if err != nil {
+				event.Status = storage.UpdateStatusFailed
+				event.ErrorMessage = err.Error()
+			} else {
+				event.Output = result.Output
+			}
+			_ = store.SaveUpdateEvent(ctx, event)
+
+			if err != nil {
+				spinner.Error(fmt.Sprintf("Failed to downgrade %s", agentDef.Name))
+				return fmt.Errorf("downgrade failed: %w", err)
+			}
+
+			spinner.Success(fmt.Sprintf("Downgraded %s from %s to %s",
+				agentDef.Name, result.FromVersion, result.Version))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&method, "method", "m", "", "installation method to downgrade (defaults to detected)")
+
+	return cmd
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/sbom"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// NewExportCommand creates the export command for generating SBOM documents
+// from the detected agent inventory.
+func NewExportCommand(cfg *config.Config, version string) *cobra.Command {
+	var (
+		format string
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the detected agent inventory as an SBOM",
+		Long: `Export the detected agent inventory as a software bill of materials.
+
+Each detected installation is listed with its name, version, install
+method, executable path, and package URL (purl). Supported formats are
+CycloneDX 1.5 JSON and SPDX 2.3 JSON.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			sbomFormat, err := sbom.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			plat := platform.Current()
+
+			store, err := storage.NewSQLiteStore(plat.GetDataDir())
+			if err != nil {
+				return fmt.Errorf("failed to create storage: %w", err)
+			}
+			defer store.Close()
+
+			if err := store.Initialize(ctx); err != nil {
+				return fmt.Errorf("failed to initialize storage: %w", err)
+			}
+
+			catMgr := catalog.NewManager(cfg, store)
+			agentDefs, err := catMgr.GetAgentsForPlatform(ctx, string(plat.ID()))
+			if err != nil {
+				return fmt.Errorf("failed to load catalog: %w", err)
+			}
+
+			det := detector.New(plat)
+			det.SetInstallersConfig(cfg.Installers)
+			installations, err := det.DetectAll(ctx, agentDefs)
+			if err != nil {
+				return fmt.Errorf("detection failed: %w", err)
+			}
+
+			defMap := make(map[string]catalog.AgentDef, len(agentDefs))
+			for _, def := range agentDefs {
+				defMap[def.ID] = def
+			}
+
+			data, err := sbom.Export(sbomFormat, installations, defMap, version)
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+
+			if output != "" {
+				return os.WriteFile(output, data, 0644)
+			}
+			_, err = os.Stdout.Write(data)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "cyclonedx", "SBOM format (cyclonedx, spdx)")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "write to file instead of stdout")
+
+	return cmd
+}
@@ -33,6 +33,7 @@ The helper runs in the background and provides:
 		newHelperStartCommand(cfg),
 		newHelperStopCommand(cfg),
 		newHelperStatusCommand(cfg),
+		newHelperReloadCommand(cfg),
 		newHelperAutoStartCommand(cfg),
 	)
 
@@ -156,6 +157,49 @@ func newHelperStopCommand(cfg *config.Config) *cobra.Command {
 	}
 }
 
+func newHelperReloadCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reload",
+		Short: "Reload the helper configuration",
+		Long:  `Ask the running helper to re-read its config file and apply the changes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isHelperRunning() {
+				printInfo("Helper is not running")
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			client := ipc.NewClient("")
+			if err := client.Connect(ctx); err != nil {
+				return fmt.Errorf("failed to connect to helper: %w", err)
+			}
+			defer func() { _ = client.Disconnect() }()
+
+			msg, err := ipc.NewMessage(ipc.MessageTypeReloadConfig, nil)
+			if err != nil {
+				return fmt.Errorf("failed to create reload message: %w", err)
+			}
+
+			resp, err := client.Send(ctx, msg)
+			if err != nil {
+				return fmt.Errorf("failed to send reload message: %w", err)
+			}
+			if resp != nil && resp.Type == ipc.MessageTypeError {
+				var errResp ipc.ErrorResponse
+				if err := resp.DecodePayload(&errResp); err == nil {
+					return fmt.Errorf("reload rejected: %s", errResp.Message)
+				}
+				return fmt.Errorf("reload rejected")
+			}
+
+			printSuccess("Configuration reloaded")
+			return nil
+		},
+	}
+}
+
 func newHelperStatusCommand(cfg *config.Config) *cobra.Command {
 	return &cobra.Command{
 		Use:   "status",
@@ -213,7 +257,11 @@ func newHelperStatusCommand(cfg *config.Config) *cobra.Command {
 			if err != nil {
 				fmt.Println("  Auto-start: unknown")
 			} else if autoStart {
-				fmt.Println("  Auto-start: enabled")
+				if method, err := plat.GetAutoStartMethod(context.Background()); err == nil && method != platform.AutoStartNone {
+					fmt.Printf("  Auto-start: enabled (%s)\n", method.DisplayName())
+				} else {
+					fmt.Println("  Auto-start: enabled")
+				}
 			} else {
 				fmt.Println("  Auto-start: disabled")
 			}
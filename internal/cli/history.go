@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// NewHistoryCommand creates the history command for showing the recorded
+// install, update, and rollback timeline of an agent.
+func NewHistoryCommand(cfg *config.Config) *cobra.Command {
+	var (
+		format     string
+		limit      int
+		showOutput bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "history <agent-name>",
+		Short: "Show the install and update history of an agent",
+		Long: `Show the recorded timeline of installs, updates, and rollbacks for an
+agent, with versions, durations, and results. Failed entries include the
+error; use --output to also see the full command output.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID := args[0]
+
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			store, err := openJobStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			events, err := store.GetUpdateHistory(ctx, agentID, limit)
+			if err != nil {
+				return fmt.Errorf("failed to get update history: %w", err)
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(events)
+			}
+
+			if len(events) == 0 {
+				fmt.Printf("No history recorded for %s\n", agentID)
+				return nil
+			}
+
+			fmt.Printf("%-20s %-9s %-24s %-9s %s\n", "STARTED", "KIND", "VERSIONS", "DURATION", "RESULT")
+			for _, event := range events {
+				result := string(event.Status)
+				if event.Retries > 0 {
+					result = fmt.Sprintf("%s (%d retries)", result, event.Retries)
+				}
+				fmt.Printf("%-20s %-9s %-24s %-9s %s\n",
+					event.StartedAt.Format("2006-01-02 15:04:05"),
+					event.Kind(),
+					historyVersions(event),
+					historyDuration(event),
+					result)
+
+				if event.Status == storage.UpdateStatusFailed {
+					if event.ErrorMessage != "" {
+						fmt.Printf("    error: %s\n", event.ErrorMessage)
+					}
+					if showOutput && event.Output != "" {
+						for _, line := range strings.Split(strings.TrimRight(event.Output, "\n"), "\n") {
+							fmt.Printf("    %s\n", line)
+						}
+					}
+				}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "maximum number of entries to show")
+	cmd.Flags().BoolVar(&showOutput, "output", false, "include command output for failed entries")
+
+	return cmd
+}
+
+// historyVersions renders the from→to versions of an event for the table.
+func historyVersions(event *storage.UpdateEvent) string {
+	switch {
+	case event.FromVersion == "" && event.ToVersion == "":
+		return "-"
+	case event.FromVersion == "":
+		return event.ToVersion
+	case event.ToVersion == "":
+		return event.FromVersion + " → ?"
+	default:
+		return event.FromVersion + " → " + event.ToVersion
+	}
+}
+
+// historyDuration renders the duration of an event for the table.
+func historyDuration(event *storage.UpdateEvent) string {
+	d := event.Duration()
+	if d <= 0 {
+		return "-"
+	}
+	return d.Round(time.Second).String()
+}
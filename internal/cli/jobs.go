@@ -0,0 +1,174 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/jobs"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// NewJobsCommand creates the jobs command for inspecting tracked
+// install, update, and uninstall operations.
+func NewJobsCommand(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Inspect tracked operations",
+		Long: `Every install, update, downgrade, and uninstall is tracked as a job
+with persistent state. List recent jobs or attach to one to follow it
+to completion.`,
+	}
+
+	cmd.AddCommand(newJobsListCommand(cfg))
+	cmd.AddCommand(newJobsShowCommand(cfg))
+
+	return cmd
+}
+
+// newJobsListCommand creates the jobs list subcommand.
+func newJobsListCommand(cfg *config.Config) *cobra.Command {
+	var (
+		format string
+		state  string
+		limit  int
+	)
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List recent jobs",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+
+			store, err := openJobStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			jobList, err := store.ListJobs(ctx, storage.JobState(state), limit)
+			if err != nil {
+				return fmt.Errorf("failed to list jobs: %w", err)
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(jobList)
+			}
+
+			if len(jobList) == 0 {
+				fmt.Println("No jobs found")
+				return nil
+			}
+
+			fmt.Printf("%-28s %-10s %-20s %-10s %s\n", "ID", "TYPE", "AGENT", "STATE", "CREATED")
+			for _, job := range jobList {
+				fmt.Printf("%-28s %-10s %-20s %-10s %s\n",
+					job.ID, job.Type, job.AgentID, job.State,
+					job.CreatedAt.Format("2006-01-02 15:04:05"))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json)")
+	cmd.Flags().StringVar(&state, "state", "", "filter by state (queued, running, succeeded, failed, cancelled)")
+	cmd.Flags().IntVar(&limit, "limit", 20, "maximum number of jobs to show")
+
+	return cmd
+}
+
+// newJobsShowCommand creates the jobs show subcommand.
+func newJobsShowCommand(cfg *config.Config) *cobra.Command {
+	var (
+		format string
+		wait   bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "show <job-id>",
+		Short: "Show a job, optionally waiting for it to finish",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+			defer cancel()
+
+			store, err := openJobStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			var job *storage.Job
+			if wait {
+				job, err = jobs.Wait(ctx, store, args[0])
+			} else {
+				job, err = store.GetJob(ctx, args[0])
+			}
+			if err != nil {
+				return err
+			}
+			if job == nil {
+				return fmt.Errorf("job %s not found", args[0])
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(job)
+			}
+
+			fmt.Printf("ID:      %s\n", job.ID)
+			fmt.Printf("Type:    %s\n", job.Type)
+			fmt.Printf("Agent:   %s\n", job.AgentID)
+			if job.Method != "" {
+				fmt.Printf("Method:  %s\n", job.Method)
+			}
+			if job.Version != "" {
+				fmt.Printf("Version: %s\n", job.Version)
+			}
+			fmt.Printf("State:   %s\n", job.State)
+			fmt.Printf("Created: %s\n", job.CreatedAt.Format("2006-01-02 15:04:05"))
+			if job.StartedAt != nil {
+				fmt.Printf("Started: %s\n", job.StartedAt.Format("2006-01-02 15:04:05"))
+			}
+			if job.FinishedAt != nil {
+				fmt.Printf("Finished: %s\n", job.FinishedAt.Format("2006-01-02 15:04:05"))
+			}
+			if job.Error != "" {
+				fmt.Printf("Error:   %s\n", job.Error)
+			}
+			if job.Output != "" {
+				fmt.Printf("\n%s\n", job.Output)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "wait until the job reaches a terminal state")
+
+	return cmd
+}
+
+// openJobStore opens and initializes the local store.
+func openJobStore(ctx context.Context) (*storage.SQLiteStore, error) {
+	plat := platform.Current()
+
+	store, err := storage.NewSQLiteStore(plat.GetDataDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage: %w", err)
+	}
+
+	if err := store.Initialize(ctx); err != nil {
+		store.Close()
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	return store, nil
+}
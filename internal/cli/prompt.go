@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/ipc"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/statusfile"
+)
+
+// promptIPCTimeout bounds the fallback helper ping so a slow or wedged
+// helper never delays shell prompt rendering.
+const promptIPCTimeout = 100 * time.Millisecond
+
+// NewPromptCommand creates the prompt command: a near-instant status
+// string for embedding in shell prompts.
+func NewPromptCommand(cfg *config.Config) *cobra.Command {
+	var (
+		promptFormat string
+		maxAge       time.Duration
+		always       bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "prompt",
+		Short: "Print a compact status string for shell prompts",
+		Long: `Print a compact agent status string (e.g. "⬆3") for embedding in a
+shell prompt, such as PS1 or a starship custom module.
+
+Status is read from the status file written when ui.status_file is
+enabled, falling back to a quick helper ping with a strict timeout.
+When no status is available, or no updates are pending, nothing is
+printed and the command exits 0, so a broken helper never breaks the
+prompt.
+
+The format string substitutes {updates} and {agents}.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status, ok := promptStatus(cmd.Context(), cfg, maxAge)
+			if !ok {
+				return nil
+			}
+			if status.UpdatesAvailable == 0 && !always {
+				return nil
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), renderPrompt(promptFormat, status))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&promptFormat, "prompt-format", "⬆{updates}", "status string template ({updates}, {agents})")
+	cmd.Flags().DurationVar(&maxAge, "max-age", 0, "ignore a status file older than this (0 accepts any age)")
+	cmd.Flags().BoolVar(&always, "always", false, "print even when no updates are pending")
+
+	return cmd
+}
+
+// promptStatus loads agent status as cheaply as possible: first from the
+// status file, then from the helper with a strict timeout. Returns false
+// when neither source is available.
+func promptStatus(ctx context.Context, cfg *config.Config, maxAge time.Duration) (statusfile.Status, bool) {
+	if status, ok := readStatusFile(cfg, maxAge); ok {
+		return status, true
+	}
+	return pingHelperStatus(ctx)
+}
+
+// readStatusFile reads and parses the status file, reporting false when
+// it is missing, malformed, or older than maxAge.
+func readStatusFile(cfg *config.Config, maxAge time.Duration) (statusfile.Status, bool) {
+	path := cfg.UI.StatusFilePath
+	if path == "" {
+		path = statusfile.DefaultPath(platform.Current().GetDataDir())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return statusfile.Status{}, false
+	}
+	var status statusfile.Status
+	if err := json.Unmarshal(data, &status); err != nil {
+		return statusfile.Status{}, false
+	}
+	if maxAge > 0 && time.Since(status.GeneratedAt) > maxAge {
+		return statusfile.Status{}, false
+	}
+	return status, true
+}
+
+// pingHelperStatus asks the running helper for its status. Any failure —
+// no helper, slow socket, bad response — reads as "no status".
+func pingHelperStatus(ctx context.Context) (statusfile.Status, bool) {
+	ctx, cancel := context.WithTimeout(ctx, promptIPCTimeout)
+	defer cancel()
+
+	client := ipc.NewClient("")
+	if err := client.Connect(ctx); err != nil {
+		return statusfile.Status{}, false
+	}
+	defer func() { _ = client.Disconnect() }()
+
+	var resp ipc.StatusResponse
+	if err := sendWatchRequest(ctx, client, ipc.MessageTypeGetStatus, nil, &resp); err != nil {
+		return statusfile.Status{}, false
+	}
+	return statusfile.Status{
+		Agents:           resp.AgentCount,
+		UpdatesAvailable: resp.UpdatesAvailable,
+		LastCheck:        resp.LastUpdateCheck,
+		GeneratedAt:      time.Now(),
+	}, true
+}
+
+// renderPrompt substitutes the status counts into the format string.
+func renderPrompt(format string, status statusfile.Status) string {
+	out := strings.ReplaceAll(format, "{updates}", strconv.Itoa(status.UpdatesAvailable))
+	return strings.ReplaceAll(out, "{agents}", strconv.Itoa(status.Agents))
+}
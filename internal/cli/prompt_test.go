@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/statusfile"
+)
+
+// writePromptStatus writes a status file and returns a config pointing
+// the prompt command at it.
+func writePromptStatus(t *testing.T, status statusfile.Status) *config.Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "status.json")
+	if err := statusfile.Write(path, status); err != nil {
+		t.Fatalf("writing status file: %v", err)
+	}
+	cfg := config.Default()
+	cfg.UI.StatusFilePath = path
+	return cfg
+}
+
+func runPromptCommand(t *testing.T, cfg *config.Config, args ...string) string {
+	t.Helper()
+	cmd := NewPromptCommand(cfg)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs(args)
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("prompt command error = %v", err)
+	}
+	return buf.String()
+}
+
+func TestPromptCommandWithUpdates(t *testing.T) {
+	cfg := writePromptStatus(t, statusfile.Status{
+		Agents:           4,
+		UpdatesAvailable: 3,
+		GeneratedAt:      time.Now(),
+	})
+
+	out := strings.TrimSpace(runPromptCommand(t, cfg))
+	if out != "⬆3" {
+		t.Errorf("prompt output = %q, want %q", out, "⬆3")
+	}
+}
+
+func TestPromptCommandSilentWhenCurrent(t *testing.T) {
+	cfg := writePromptStatus(t, statusfile.Status{
+		Agents:      4,
+		GeneratedAt: time.Now(),
+	})
+
+	if out := runPromptCommand(t, cfg); out != "" {
+		t.Errorf("prompt output = %q, want empty with no updates", out)
+	}
+}
+
+func TestPromptCommandAlways(t *testing.T) {
+	cfg := writePromptStatus(t, statusfile.Status{
+		Agents:      4,
+		GeneratedAt: time.Now(),
+	})
+
+	out := strings.TrimSpace(runPromptCommand(t, cfg, "--always", "--prompt-format", "{agents} agents, {updates} updates"))
+	if out != "4 agents, 0 updates" {
+		t.Errorf("prompt output = %q, want %q", out, "4 agents, 0 updates")
+	}
+}
+
+func TestPromptCommandStaleStatusFile(t *testing.T) {
+	cfg := writePromptStatus(t, statusfile.Status{
+		UpdatesAvailable: 3,
+		GeneratedAt:      time.Now().Add(-time.Hour),
+	})
+
+	// A stale file falls back to the helper ping, which fails silently
+	// here since no helper is running
+	if out := runPromptCommand(t, cfg, "--max-age", "10m"); out != "" {
+		t.Errorf("prompt output = %q, want empty for stale status", out)
+	}
+}
+
+func TestRenderPrompt(t *testing.T) {
+	status := statusfile.Status{Agents: 7, UpdatesAvailable: 2}
+	if got := renderPrompt("⬆{updates}", status); got != "⬆2" {
+		t.Errorf("renderPrompt() = %q, want %q", got, "⬆2")
+	}
+	if got := renderPrompt("{agents}/{updates}", status); got != "7/2" {
+		t.Errorf("renderPrompt() = %q, want %q", got, "7/2")
+	}
+}
@@ -8,12 +8,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // NewRootCommand creates the root command for agentmgr.
 func NewRootCommand(cfg *config.Config, version, commit, date string) *cobra.Command {
 	var (
 		configFile string
+		profile    string
 		verbose    bool
 		format     string
 		noColor    bool
@@ -36,12 +38,15 @@ Examples:
 		SilenceUsage:  true,
 		SilenceErrors: true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			// Reload config if custom path specified
-			if configFile != "" {
+			// Reload config if a custom path or profile is specified
+			if configFile != "" || profile != "" {
 				loader := config.NewLoader()
+				if profile != "" {
+					loader.SetProfile(profile)
+				}
 				newCfg, err := loader.Load(configFile)
 				if err != nil {
-					return fmt.Errorf("failed to load config from %s: %w", configFile, err)
+					return fmt.Errorf("failed to load config: %w", err)
 				}
 				*cfg = *newCfg
 			}
@@ -56,12 +61,17 @@ Examples:
 				cfg.UI.UseColors = false
 			}
 
+			// Apply the login-shell PATH probe setting before any
+			// command runs detection
+			platform.SetLoginShellPath(cfg.Detection.LoginShellPath)
+
 			return nil
 		},
 	}
 
 	// Global flags
 	root.PersistentFlags().StringVarP(&configFile, "config", "c", "", "config file path")
+	root.PersistentFlags().StringVarP(&profile, "profile", "p", "", "config profile to layer over the base config")
 	root.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "verbose output")
 	root.PersistentFlags().StringVarP(&format, "format", "f", "table", "output format (table, json, yaml)")
 	root.PersistentFlags().BoolVar(&noColor, "no-color", false, "disable colored output")
@@ -71,14 +81,24 @@ Examples:
 		NewAgentCommand(cfg),
 		NewAPICommand(cfg),
 		NewCatalogCommand(cfg),
+		NewCleanCommand(cfg),
 		NewCompletionCommand(),
 		NewConfigCommand(cfg),
+		NewDiagnosticsCommand(cfg, version),
 		NewDoctorCommand(cfg),
+		NewExportCommand(cfg, version),
 		NewHelperCommand(cfg),
+		NewHistoryCommand(cfg),
+		NewJobsCommand(cfg),
 		NewPluginCommand(cfg),
+		NewPromptCommand(cfg),
+		NewSnapshotCommand(cfg),
+		NewStatsCommand(cfg),
 		NewTUICommand(cfg),
 		NewUpgradeCommand(cfg, version),
+		NewUseCommand(cfg),
 		NewVersionCommand(version, commit, date),
+		NewWatchCommand(cfg),
 	)
 
 	return root
@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/shim"
+)
+
+func newAgentShimCommand(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "shim",
+		Short: "Manage wrapper shims for agent executables",
+		Long: `Manage wrapper shims in the agentmgr bin directory.
+
+A shim is a small wrapper script that records each invocation, warns when
+the installed version violates a configured pin, and forwards to the real
+executable. With the shim directory on PATH ahead of the install
+locations, the PATH entry stays stable while the underlying install
+method or version changes.`,
+	}
+
+	cmd.AddCommand(
+		newShimEnableCommand(cfg),
+		newShimDisableCommand(cfg),
+		newShimListCommand(cfg),
+	)
+
+	return cmd
+}
+
+func newShimEnableCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable <agent-name>",
+		Short: "Install a wrapper shim for an agent",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID := args[0]
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			plat := platform.Current()
+			installations, err := detectInstallations(ctx, cfg, plat)
+			if err != nil {
+				return err
+			}
+
+			inst := findInstallation(installations, agentID, cfg.Agents[agentID].PreferredMethod)
+			if inst == nil {
+				return fmt.Errorf("agent %q is not installed", agentID)
+			}
+
+			mgr := shim.NewManager(plat.GetDataDir())
+			if err := mgr.Enable(inst, cfg.GetPinnedVersion(agentID)); err != nil {
+				return err
+			}
+
+			fmt.Printf("Shim for %s installed in %s (via %s)\n", inst.AgentName, mgr.BinDir(), inst.Method)
+			fmt.Printf("Add %s to the front of your PATH to use it\n", mgr.BinDir())
+			return nil
+		},
+	}
+}
+
+func newShimDisableCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable <agent-name>",
+		Short: "Remove an agent's wrapper shim",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID := args[0]
+
+			plat := platform.Current()
+			mgr := shim.NewManager(plat.GetDataDir())
+			if !mgr.IsEnabled(agentID) {
+				return fmt.Errorf("no shim installed for %q", agentID)
+			}
+			if err := mgr.Disable(agentID); err != nil {
+				return err
+			}
+
+			fmt.Printf("Shim for %s removed\n", agentID)
+			return nil
+		},
+	}
+}
+
+func newShimListCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List installed wrapper shims",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plat := platform.Current()
+			mgr := shim.NewManager(plat.GetDataDir())
+
+			shims, err := mgr.List()
+			if err != nil {
+				return err
+			}
+			if len(shims) == 0 {
+				fmt.Println("No shims installed")
+				return nil
+			}
+
+			fmt.Printf("%-20s  %-20s  %s\n", "AGENT", "LAST USED", "TARGET")
+			for _, s := range shims {
+				lastUsed := "never"
+				if t, ok := mgr.LastUsed(s.AgentID); ok {
+					lastUsed = t.Local().Format("2006-01-02 15:04:05")
+				}
+				fmt.Printf("%-20s  %-20s  %s\n", s.AgentID, lastUsed, s.Target)
+			}
+			return nil
+		},
+	}
+}
+
+// findInstallation picks the installation for an agent, preferring the
+// configured method when the agent is installed more than once.
+func findInstallation(installations []*agent.Installation, agentID, preferredMethod string) *agent.Installation {
+	var found *agent.Installation
+	for _, inst := range installations {
+		if inst.AgentID != agentID {
+			continue
+		}
+		if preferredMethod != "" && string(inst.Method) == preferredMethod {
+			return inst
+		}
+		if found == nil {
+			found = inst
+		}
+	}
+	return found
+}
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/internal/cli/output"
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/installer"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/snapshot"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// NewSnapshotCommand creates the snapshot command for capturing and
+// restoring the full agent environment.
+func NewSnapshotCommand(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Snapshot and restore the agent environment",
+		Long: `Capture the full agent environment — installed agents, their versions
+and install methods, version pins, and per-agent configuration — into a
+timestamped archive, and restore to a snapshot later.
+
+Restoring computes the install/uninstall/downgrade plan needed to bring
+the environment back to the snapshot state and executes it.`,
+	}
+
+	cmd.AddCommand(
+		newSnapshotCreateCommand(cfg),
+		newSnapshotListCommand(cfg),
+		newSnapshotRestoreCommand(cfg),
+	)
+
+	return cmd
+}
+
+func newSnapshotCreateCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create",
+		Short: "Create a snapshot of the current agent environment",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			plat := platform.Current()
+
+			spinner := output.NewSpinner(
+				output.WithMessage("Detecting agents..."),
+				output.WithNoColor(!cfg.UI.UseColors),
+			)
+			spinner.Start()
+
+			installations, err := detectInstallations(ctx, cfg, plat)
+			if err != nil {
+				spinner.Error("Detection failed")
+				return err
+			}
+
+			mgr := snapshot.NewManager(plat.GetDataDir())
+			snap, err := mgr.Create(installations, cfg)
+			if err != nil {
+				spinner.Error("Failed to create snapshot")
+				return err
+			}
+
+			spinner.Success(fmt.Sprintf("Created snapshot %s with %d agent(s)", snap.ID, len(snap.Agents)))
+			return nil
+		},
+	}
+}
+
+func newSnapshotListCommand(cfg *config.Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List stored snapshots",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			plat := platform.Current()
+			mgr := snapshot.NewManager(plat.GetDataDir())
+
+			snapshots, err := mgr.List()
+			if err != nil {
+				return err
+			}
+
+			if format == "json" {
+				return json.NewEncoder(os.Stdout).Encode(snapshots)
+			}
+
+			if len(snapshots) == 0 {
+				fmt.Println("No snapshots found")
+				return nil
+			}
+
+			fmt.Printf("%-17s  %-20s  %s\n", "ID", "CREATED", "AGENTS")
+			for _, snap := range snapshots {
+				fmt.Printf("%-17s  %-20s  %d\n",
+					snap.ID,
+					snap.CreatedAt.Local().Format("2006-01-02 15:04:05"),
+					len(snap.Agents))
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json)")
+
+	return cmd
+}
+
+func newSnapshotRestoreCommand(cfg *config.Config) *cobra.Command {
+	var (
+		yes    bool
+		dryRun bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <snapshot-id>",
+		Short: "Restore the agent environment to a snapshot",
+		Long: `Restore the agent environment to a previously created snapshot.
+
+The current environment is diffed against the snapshot and the resulting
+install/uninstall/downgrade plan is shown before execution. Use --dry-run
+to only show the plan, or --yes to skip the confirmation prompt.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cfg.Policy.Lockdown {
+				return errors.New(cfg.LockdownReason())
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+			defer cancel()
+
+			plat := platform.Current()
+			printer := output.NewPrinter(cfg, !cfg.UI.UseColors)
+
+			mgr := snapshot.NewManager(plat.GetDataDir())
+			snap, err := mgr.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			installations, err := detectInstallations(ctx, cfg, plat)
+			if err != nil {
+				return err
+			}
+
+			plan := snapshot.ComputePlan(snap, installations)
+			if len(plan) == 0 {
+				printer.Success("Environment already matches snapshot %s", snap.ID)
+				return nil
+			}
+
+			fmt.Printf("Restoring snapshot %s requires %d action(s):\n\n", snap.ID, len(plan))
+			for _, action := range plan {
+				fmt.Printf("  %s\n", action)
+			}
+			fmt.Println()
+
+			if dryRun {
+				printer.Info("Dry run: nothing executed")
+				return nil
+			}
+
+			if !yes {
+				fmt.Print("Execute this plan? [y/N] ")
+				reader := bufio.NewReader(os.Stdin)
+				answer, _ := reader.ReadString('\n')
+				if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(answer)), "y") {
+					printer.Info("Aborted")
+					return nil
+				}
+			}
+
+			store, err := storage.NewSQLiteStore(plat.GetDataDir())
+			if err != nil {
+				return fmt.Errorf("failed to create storage: %w", err)
+			}
+			defer store.Close()
+
+			if err := store.Initialize(ctx); err != nil {
+				return fmt.Errorf("failed to initialize storage: %w", err)
+			}
+
+			catMgr := catalog.NewManager(cfg, store)
+			cat, err := catMgr.Get(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load catalog: %w", err)
+			}
+
+			instMgr := installer.NewManager(plat)
+			instMgr.SetInstallersConfig(cfg.Installers)
+			instMgr.SetHooksConfig(cfg.Hooks, cfg.Agents)
+			instMgr.SetPolicy(cfg.Policy)
+
+			if err := snapshot.Restore(ctx, plan, installations, cat, instMgr); err != nil {
+				return err
+			}
+
+			// Restore per-agent configuration overrides (pins etc.) as well.
+			if snap.AgentConfigs != nil {
+				loader := config.NewLoader()
+				if _, err := loader.Load(""); err == nil {
+					cfg.Agents = snap.AgentConfigs
+					if err := loader.SetAndSave("agents", snap.AgentConfigs); err != nil {
+						printer.Warning("Restored agents but failed to restore per-agent config: %v", err)
+					}
+				}
+			}
+
+			printer.Success("Restored snapshot %s (%d action(s) executed)", snap.ID, len(plan))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip confirmation prompt")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "show the plan without executing it")
+
+	return cmd
+}
+
+// detectInstallations detects all installed agents for the current platform.
+func detectInstallations(ctx context.Context, cfg *config.Config, plat platform.Platform) ([]*agent.Installation, error) {
+	store, err := storage.NewSQLiteStore(plat.GetDataDir())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create storage: %w", err)
+	}
+	defer store.Close()
+
+	if err := store.Initialize(ctx); err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+
+	catMgr := catalog.NewManager(cfg, store)
+	agentDefs, err := catMgr.GetAgentsForPlatform(ctx, string(plat.ID()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load catalog: %w", err)
+	}
+
+	det := detector.New(plat)
+	det.SetInstallersConfig(cfg.Installers)
+	installations, err := det.DetectAll(ctx, agentDefs)
+	if err != nil {
+		return nil, fmt.Errorf("detection failed: %w", err)
+	}
+	return installations, nil
+}
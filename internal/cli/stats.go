@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/stats"
+	"github.com/kevinelliott/agentmgr/pkg/usage"
+)
+
+// NewStatsCommand creates the stats command for the opt-in usage
+// statistics report.
+func NewStatsCommand(cfg *config.Config) *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show usage statistics for installed agents",
+		Long: `Show aggregated usage statistics: which agents are used most, how
+quickly updates are applied after release, and an estimate of the time
+automatic updates have saved.
+
+Everything is computed locally from the usage tracker and update
+history; nothing is uploaded anywhere. The report is opt-in:
+
+  agentmgr config set stats.enabled true`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cfg.Stats.Enabled {
+				printInfo("Statistics are disabled. Run 'agentmgr config set stats.enabled true' to enable them.")
+				return nil
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+			defer cancel()
+
+			store, err := openJobStore(ctx)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			installations, _, err := store.GetDetectionCache(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to load detected agents: %w", err)
+			}
+			if len(installations) == 0 {
+				printInfo("No detected agents. Run 'agentmgr agent list' first.")
+				return nil
+			}
+
+			releases := catalog.NewManager(cfg, store)
+			report := stats.NewAggregator(store, releases).Report(ctx, installations)
+
+			switch format {
+			case "json":
+				return json.NewEncoder(os.Stdout).Encode(report)
+			case "csv":
+				data, err := report.CSV()
+				if err != nil {
+					return fmt.Errorf("failed to render CSV: %w", err)
+				}
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+
+			fmt.Printf("%-20s %-16s %-8s %s\n", "AGENT", "LAST USED", "UPDATES", "AVG LAG")
+			for _, s := range report.Agents {
+				lag := "-"
+				if s.UpdateLag > 0 {
+					lag = s.UpdateLag.Round(time.Hour).String()
+				}
+				fmt.Printf("%-20s %-16s %-8d %s\n",
+					s.AgentName,
+					usage.FormatRelative(s.LastUsed),
+					s.Updates,
+					lag)
+			}
+			fmt.Printf("\nUpdates applied: %d  Time saved: ~%s\n", report.TotalUpdates, report.TimeSaved)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "output format (table, json, csv)")
+
+	return cmd
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/shim"
+)
+
+// NewUseCommand creates the use command.
+func NewUseCommand(cfg *config.Config) *cobra.Command {
+	var (
+		method   string
+		execPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "use <agent-name>",
+		Short: "Choose which installed copy of an agent is the default",
+		Long: `Choose which installed copy of an agent is the default by pointing
+its wrapper shim in the agentmgr bin directory at that copy. With the
+bin directory ahead of the install locations on PATH, switching the
+default never touches the installs themselves.
+
+Use --method to pick between install methods, and --path for a specific
+copy when the same method is installed in several locations (e.g. under
+different Node versions).`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			agentID := args[0]
+
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+
+			plat := platform.Current()
+			installations, err := detectInstallations(ctx, cfg, plat)
+			if err != nil {
+				return err
+			}
+
+			var copies []*agent.Installation
+			for _, inst := range installations {
+				if inst.AgentID != agentID {
+					continue
+				}
+				if method != "" && string(inst.Method) != method {
+					continue
+				}
+				if execPath != "" && inst.ExecutablePath != execPath {
+					continue
+				}
+				copies = append(copies, inst)
+			}
+
+			if len(copies) == 0 {
+				if method != "" || execPath != "" {
+					return fmt.Errorf("no matching installation of %q", agentID)
+				}
+				return fmt.Errorf("agent %q is not installed", agentID)
+			}
+
+			if len(copies) > 1 {
+				fmt.Printf("Multiple installations of %s found:\n", agentID)
+				for _, inst := range copies {
+					fmt.Printf("  - %s via %s (%s)\n",
+						inst.InstalledVersion.String(), inst.Method, inst.ExecutablePath)
+				}
+				fmt.Println("\nUse --method, or --path for a specific copy, to choose the default.")
+				return nil
+			}
+
+			chosen := copies[0]
+			mgr := shim.NewManager(plat.GetDataDir())
+			if err := mgr.Enable(chosen, cfg.GetPinnedVersion(agentID)); err != nil {
+				return err
+			}
+
+			printSuccess("%s now defaults to %s via %s (%s)",
+				agentID, chosen.InstalledVersion.String(), chosen.Method, chosen.ExecutablePath)
+			fmt.Printf("Make sure %s is ahead of the install locations on your PATH\n", mgr.BinDir())
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&method, "method", "m", "", "install method of the copy to use")
+	cmd.Flags().StringVar(&execPath, "path", "", "executable path of the specific copy to use")
+
+	return cmd
+}
@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/ipc"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// watchRequestTimeout bounds each snapshot request against the helper so
+// a stalled helper doesn't freeze the view.
+const watchRequestTimeout = 10 * time.Second
+
+// NewWatchCommand creates the watch command: a live, continuously
+// refreshing view of the agents and operations the helper is tracking.
+func NewWatchCommand(cfg *config.Config) *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Watch agents and operations live",
+		Long: `Connect to the running helper and render a continuously updating table
+of agents, versions, and in-progress operations — the tray's view, but
+in a terminal, which is handy over SSH.
+
+The view refreshes whenever the helper pushes an event and on a fixed
+interval. Press Ctrl-C to exit.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !isHelperRunning() {
+				return fmt.Errorf("helper is not running (start it with 'agentmgr helper start')")
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			// One connection polls snapshots; a second, subscribed one
+			// receives pushed events so changes show up immediately
+			poller := ipc.NewClient("")
+			if err := poller.Connect(ctx); err != nil {
+				return fmt.Errorf("failed to connect to helper: %w", err)
+			}
+			defer func() { _ = poller.Disconnect() }()
+
+			events := make(chan struct{}, 1)
+			eventClient := ipc.NewClient("")
+			if err := eventClient.Connect(ctx); err == nil {
+				defer func() { _ = eventClient.Disconnect() }()
+				if sub, err := ipc.NewMessage(ipc.MessageTypeSubscribe, nil); err == nil {
+					if _, err := eventClient.Send(ctx, sub); err == nil {
+						eventClient.Subscribe(func(*ipc.Message) {
+							select {
+							case events <- struct{}{}:
+							default:
+							}
+						})
+						go eventClient.Listen(ctx)
+					}
+				}
+				// Unblock the listener's read when interrupted
+				go func() {
+					<-ctx.Done()
+					_ = eventClient.Disconnect()
+				}()
+			}
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			for {
+				if err := renderWatchFrame(ctx, poller); err != nil {
+					return err
+				}
+
+				select {
+				case <-ctx.Done():
+					fmt.Println()
+					return nil
+				case <-ticker.C:
+				case <-events:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVarP(&interval, "interval", "i", 5*time.Second, "refresh interval between polls")
+
+	return cmd
+}
+
+// renderWatchFrame fetches the current agents and jobs from the helper
+// and redraws the screen.
+func renderWatchFrame(ctx context.Context, client ipc.Client) error {
+	reqCtx, cancel := context.WithTimeout(ctx, watchRequestTimeout)
+	defer cancel()
+
+	var agentsResp ipc.ListAgentsResponse
+	if err := sendWatchRequest(reqCtx, client, ipc.MessageTypeListAgents, nil, &agentsResp); err != nil {
+		return fmt.Errorf("failed to list agents: %w", err)
+	}
+
+	// In-progress operations are best-effort: older helpers without job
+	// tracking still render the agent table
+	var jobsResp ipc.ListJobsResponse
+	_ = sendWatchRequest(reqCtx, client, ipc.MessageTypeListJobs, ipc.ListJobsRequest{Limit: 20}, &jobsResp)
+
+	agents := agentsResp.Agents
+	sort.Slice(agents, func(i, j int) bool {
+		return strings.ToLower(agents[i].AgentName) < strings.ToLower(agents[j].AgentName)
+	})
+
+	var active []storage.Job
+	for _, job := range jobsResp.Jobs {
+		if !job.State.IsTerminal() {
+			active = append(active, job)
+		}
+	}
+
+	// Clear the screen and move the cursor home
+	fmt.Print("\033[H\033[2J")
+	fmt.Printf("Agents (%d) — %s  (Ctrl-C to exit)\n\n", len(agents), time.Now().Format("15:04:05"))
+
+	if len(agents) == 0 {
+		fmt.Println("No agents detected")
+	} else {
+		fmt.Printf("%-24s %-10s %-14s %-14s %s\n", "AGENT", "METHOD", "VERSION", "LATEST", "STATUS")
+		for _, ag := range agents {
+			fmt.Printf("%-24s %-10s %-14s %-14s %s\n",
+				ag.AgentName,
+				string(ag.Method),
+				ag.InstalledVersion.String(),
+				watchLatestVersion(ag),
+				watchStatus(ag))
+		}
+	}
+
+	if len(active) > 0 {
+		fmt.Println("\nIn progress:")
+		for _, job := range active {
+			fmt.Printf("  %-10s %-20s %-10s since %s\n",
+				job.Type, job.AgentID, job.State,
+				job.CreatedAt.Format("15:04:05"))
+		}
+	}
+
+	return nil
+}
+
+// sendWatchRequest sends a request to the helper and decodes the success
+// payload into target, converting error responses into errors.
+func sendWatchRequest(ctx context.Context, client ipc.Client, msgType ipc.MessageType, payload, target interface{}) error {
+	msg, err := ipc.NewMessage(msgType, payload)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Send(ctx, msg)
+	if err != nil {
+		return err
+	}
+	if resp.Type == ipc.MessageTypeError {
+		var errResp ipc.ErrorResponse
+		if err := resp.DecodePayload(&errResp); err == nil && errResp.Message != "" {
+			return fmt.Errorf("%s", errResp.Message)
+		}
+		return fmt.Errorf("helper rejected %s request", msgType)
+	}
+	return resp.DecodePayload(target)
+}
+
+// watchLatestVersion formats the latest-version column.
+func watchLatestVersion(ag agent.Installation) string {
+	if ag.LatestVersion == nil {
+		return "-"
+	}
+	return ag.LatestVersion.String()
+}
+
+// watchStatus formats the status column, including the restart hint.
+func watchStatus(ag agent.Installation) string {
+	status := string(ag.GetStatus())
+	if ag.RestartPending {
+		status += " (restart pending)"
+	}
+	return status
+}
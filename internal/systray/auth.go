@@ -0,0 +1,47 @@
+package systray
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/auth"
+	"github.com/kevinelliott/agentmgr/pkg/terminal"
+)
+
+// authStatusLine builds the "Auth:" line for the agent details dialog.
+// The returned login command is non-empty only when the agent is not
+// signed in and the catalog defines a login command, i.e. when the
+// dialog should offer a Sign In button. Both are empty for agents
+// without an auth check.
+func (a *App) authStatusLine(inst agent.Installation) (line, loginCmd string) {
+	ctx, cancel := context.WithTimeout(a.ctx, 30*time.Second)
+	defer cancel()
+
+	agentDef, err := a.catalog.GetAgent(ctx, inst.AgentID)
+	if err != nil || agentDef == nil || agentDef.Auth.CheckCmd == "" {
+		return "", ""
+	}
+
+	switch auth.Check(ctx, *agentDef) {
+	case agent.AuthSignedIn:
+		return "Auth: Signed in", ""
+	case agent.AuthNotSignedIn:
+		return "Auth: Not signed in", agentDef.Auth.LoginCmd
+	default:
+		return "", ""
+	}
+}
+
+// openLoginTerminal opens a terminal window running the agent's
+// interactive login command. Login flows prompt for device codes or
+// open a browser, so they need a real terminal rather than running
+// silently under the helper.
+func (a *App) openLoginTerminal(loginCmd string) {
+	launcher, err := terminal.Find(a.config.Terminal, string(a.platform.ID()))
+	if err != nil {
+		a.platform.ShowNotification("Error", err.Error())
+		return
+	}
+	_ = launcher.Open(loginCmd)
+}
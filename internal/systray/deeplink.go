@@ -0,0 +1,176 @@
+package systray
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/deeplink"
+	"github.com/kevinelliott/agentmgr/pkg/i18n"
+	"github.com/kevinelliott/agentmgr/pkg/ipc"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// handleOpenURL handles open_url requests carrying an agentmgr:// deep
+// link. The action runs asynchronously because installs and dialogs can
+// take a while; a success response means the link was accepted.
+func (a *App) handleOpenURL(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	var req ipc.OpenURLRequest
+	if err := msg.DecodePayload(&req); err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_payload",
+			Message: err.Error(),
+		})
+	}
+
+	action, err := deeplink.Parse(req.URL)
+	if err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_url",
+			Message: err.Error(),
+		})
+	}
+
+	switch action.Kind {
+	case deeplink.KindDetails:
+		go a.showDeepLinkDetails(action.AgentID)
+	case deeplink.KindInstall:
+		if a.config.Policy.Lockdown {
+			return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+				Code:    "locked_down",
+				Message: a.config.LockdownReason(),
+			})
+		}
+		go a.installFromDeepLink(action)
+	}
+
+	return ipc.NewMessage(ipc.MessageTypeSuccess, nil)
+}
+
+// showDeepLinkDetails shows the details dialog for an installed agent,
+// or a notification when the linked agent is not installed.
+func (a *App) showDeepLinkDetails(agentID string) {
+	a.agentsMu.RLock()
+	var found *agent.Installation
+	for i := range a.agents {
+		if a.agents[i].AgentID == agentID {
+			instCopy := a.agents[i]
+			found = &instCopy
+			break
+		}
+	}
+	a.agentsMu.RUnlock()
+
+	if found != nil {
+		a.showAgentDetails(*found)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(a.ctx, 30*time.Second)
+	defer cancel()
+	if agentDef, err := a.catalog.GetAgent(ctx, agentID); err == nil && agentDef != nil {
+		a.platform.ShowNotification(agentDef.Name,
+			fmt.Sprintf("%s is not installed. %s", agentDef.Name, agentDef.Description))
+		return
+	}
+	a.platform.ShowNotification("Unknown agent",
+		fmt.Sprintf("Agent %s was not found in the catalog", agentID))
+}
+
+// installFromDeepLink installs the linked agent after the user confirms.
+// Deep links come from outside the app, so nothing installs silently: a
+// closed or unavailable confirmation dialog cancels the install.
+func (a *App) installFromDeepLink(action deeplink.Action) {
+	ctx, cancel := context.WithTimeout(a.ctx, 10*time.Minute)
+	defer cancel()
+
+	agentDef, err := a.catalog.GetAgent(ctx, action.AgentID)
+	if err != nil || agentDef == nil {
+		a.platform.ShowNotification("Install failed",
+			fmt.Sprintf("Agent %s was not found in the catalog", action.AgentID))
+		return
+	}
+
+	method := action.Method
+	if method == "" {
+		methods := agentDef.GetSupportedMethods(string(a.platform.ID()))
+		if len(methods) == 0 {
+			a.platform.ShowNotification("Install failed",
+				fmt.Sprintf("%s has no install method for this platform", agentDef.Name))
+			return
+		}
+		method = methods[0].Method
+	}
+	methodDef, ok := agentDef.GetInstallMethod(method)
+	if !ok {
+		a.platform.ShowNotification("Install failed",
+			fmt.Sprintf("Install method %s not available for %s", method, agentDef.Name))
+		return
+	}
+
+	prompt := fmt.Sprintf("A link requested installing %s via %s.\n\n%s\n\nProceed?",
+		agentDef.Name, method, agentDef.Description)
+	if a.platform.ShowChangelogDialog("Install "+agentDef.Name, "", "", prompt) != platform.DialogResultUpdate {
+		return
+	}
+
+	result, err := a.installer.Install(ctx, *agentDef, methodDef, false)
+	if err != nil {
+		a.platform.ShowNotification(
+			i18n.T("notify.update_failed.title"),
+			fmt.Sprintf("Failed to install %s: %v", agentDef.Name, err),
+		)
+		return
+	}
+
+	a.platform.ShowNotification("Install complete",
+		fmt.Sprintf("%s %s installed", agentDef.Name, result.Version.String()))
+	a.refreshAgents(ctx)
+}
+
+// registerURLScheme registers the helper as the handler for agentmgr://
+// links. On macOS, LaunchServices picks the scheme up from the app
+// bundle's Info.plist, so only Linux needs explicit registration here.
+func registerURLScheme() error {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+
+	appsDir := filepath.Join(home, ".local", "share", "applications")
+	if err := os.MkdirAll(appsDir, 0755); err != nil {
+		return err
+	}
+
+	desktopEntry := fmt.Sprintf(`[Desktop Entry]
+Type=Application
+Name=AgentManager URL Handler
+Exec=%s --open-url %%u
+NoDisplay=true
+MimeType=x-scheme-handler/%s;
+`, exe, deeplink.Scheme)
+
+	desktopPath := filepath.Join(appsDir, "agentmgr-url.desktop")
+	if err := os.WriteFile(desktopPath, []byte(desktopEntry), 0644); err != nil {
+		return err
+	}
+
+	if _, err := exec.LookPath("xdg-mime"); err == nil {
+		return exec.Command("xdg-mime", "default", "agentmgr-url.desktop",
+			"x-scheme-handler/"+deeplink.Scheme).Run()
+	}
+	return nil
+}
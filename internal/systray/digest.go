@@ -0,0 +1,123 @@
+package systray
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/digest"
+	"github.com/kevinelliott/agentmgr/pkg/i18n"
+)
+
+// digestEnabled reports whether digest mode is active.
+func (a *App) digestEnabled() bool {
+	_, ok := digest.Interval(a.config.Updates.Digest)
+	return ok
+}
+
+// recordPendingUpdates records a digest event for each agent with an
+// update waiting.
+func (a *App) recordPendingUpdates(ctx context.Context, agents []agent.Installation) {
+	for _, ag := range agents {
+		if !ag.HasUpdate() {
+			continue
+		}
+		detail := ag.InstalledVersion.String()
+		if ag.LatestVersion != nil {
+			detail = ag.InstalledVersion.String() + " → " + ag.LatestVersion.String()
+		}
+		_ = a.digest.Record(ctx, digest.Event{
+			Type:      digest.EventUpdatePending,
+			AgentID:   ag.AgentID,
+			AgentName: ag.AgentName,
+			Detail:    detail,
+		})
+	}
+}
+
+// maybeSendDigest sends the aggregated digest notification if the
+// configured interval has elapsed, and resets the collected events.
+func (a *App) maybeSendDigest(ctx context.Context) {
+	interval, ok := digest.Interval(a.config.Updates.Digest)
+	if !ok {
+		return
+	}
+	if !a.digest.Due(ctx, interval) {
+		return
+	}
+
+	events, err := a.digest.Events(ctx)
+	if err != nil {
+		return
+	}
+
+	summary := digest.Summarize(events)
+	if summary.Empty() {
+		// Nothing happened this period; just restart the clock
+		_ = a.digest.MarkSent(ctx)
+		return
+	}
+
+	if !a.headless && a.config.Updates.Notify {
+		a.notifyRespectingFocus(i18n.T("notify.digest.title"), summary.NotificationBody())
+	}
+
+	// Keep the written report in sync with what was announced
+	_ = a.writeDigestReport(summary)
+
+	_ = a.digest.MarkSent(ctx)
+	_ = a.digest.Clear(ctx)
+}
+
+// digestReportPath returns the path of the HTML digest report.
+func (a *App) digestReportPath() string {
+	return filepath.Join(a.platform.GetDataDir(), "digest-report.html")
+}
+
+// writeDigestReport writes the HTML and markdown digest reports.
+func (a *App) writeDigestReport(summary digest.Summary) error {
+	dir := a.platform.GetDataDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "digest-report.md"), []byte(summary.Markdown()), 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(a.digestReportPath(), []byte(summary.HTML()), 0644)
+}
+
+// openDigestReport regenerates the report from the collected events and
+// opens it in the default browser.
+func (a *App) openDigestReport() {
+	events, err := a.digest.Events(a.ctx)
+	if err != nil {
+		return
+	}
+
+	summary := digest.Summarize(events)
+	if err := a.writeDigestReport(summary); err != nil {
+		return
+	}
+
+	var openCmd string
+	switch runtime.GOOS {
+	case "darwin":
+		openCmd = "open"
+	case "linux":
+		openCmd = "xdg-open"
+	case "windows":
+		openCmd = "rundll32"
+	}
+	if openCmd == "" {
+		return
+	}
+
+	args := []string{a.digestReportPath()}
+	if runtime.GOOS == "windows" {
+		args = []string{"url.dll,FileProtocolHandler", a.digestReportPath()}
+	}
+	_ = exec.Command(openCmd, args...).Start()
+}
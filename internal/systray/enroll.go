@@ -0,0 +1,128 @@
+package systray
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/enroll"
+)
+
+// Enrollment mode: when configured, the helper registers with a central
+// management server, reports its agent inventory on each tick, and
+// executes the server's signed commands.
+
+// enrollmentTick runs one report-and-poll cycle against the management
+// server. Errors are swallowed: the server may be unreachable from a
+// laptop off the office network, and the next tick retries.
+func (a *App) enrollmentTick(ctx context.Context) {
+	cfg := a.config.Enrollment
+	if !cfg.Enabled {
+		return
+	}
+
+	client, err := enroll.NewClient(cfg, a.store, string(a.platform.ID()), a.version)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	defer cancel()
+
+	deviceID, err := client.Register(ctx)
+	if err != nil {
+		return
+	}
+
+	a.agentsMu.RLock()
+	installations := make([]*agent.Installation, len(a.agents))
+	for i := range a.agents {
+		instCopy := a.agents[i]
+		installations[i] = &instCopy
+	}
+	a.agentsMu.RUnlock()
+
+	_ = client.ReportInventory(ctx, deviceID, installations)
+
+	commands, err := client.FetchCommands(ctx, deviceID)
+	if err != nil {
+		return
+	}
+
+	for i := range commands {
+		cmd := commands[i]
+		if err := client.Verify(&cmd); err != nil {
+			_ = client.Ack(ctx, deviceID, cmd.ID, "rejected", "signature verification failed")
+			continue
+		}
+
+		status, message := a.runEnrollCommand(ctx, cmd)
+		_ = client.Ack(ctx, deviceID, cmd.ID, status, message)
+	}
+}
+
+// runEnrollCommand executes one verified server command and reports the
+// outcome for the ack.
+func (a *App) runEnrollCommand(ctx context.Context, cmd enroll.Command) (status, message string) {
+	switch cmd.Type {
+	case enroll.CommandInstall:
+		return a.enrollInstall(ctx, cmd)
+	case enroll.CommandPin:
+		return a.enrollPin(cmd)
+	default:
+		return "rejected", fmt.Sprintf("unknown command type %q", cmd.Type)
+	}
+}
+
+// enrollInstall installs an agent on the server's behalf. Unlike deep
+// links there is no confirmation dialog: the command is signed by the
+// fleet operator, and lockdown policy still applies.
+func (a *App) enrollInstall(ctx context.Context, cmd enroll.Command) (string, string) {
+	if a.config.Policy.Lockdown {
+		return "rejected", a.config.LockdownReason()
+	}
+
+	agentDef, err := a.catalog.GetAgent(ctx, cmd.AgentID)
+	if err != nil || agentDef == nil {
+		return "failed", fmt.Sprintf("agent %s not found in catalog", cmd.AgentID)
+	}
+
+	method := cmd.Method
+	if method == "" {
+		methods := agentDef.GetSupportedMethods(string(a.platform.ID()))
+		if len(methods) == 0 {
+			return "failed", fmt.Sprintf("%s has no install method for this platform", agentDef.Name)
+		}
+		method = methods[0].Method
+	}
+	methodDef, ok := agentDef.GetInstallMethod(method)
+	if !ok {
+		return "failed", fmt.Sprintf("install method %s not available for %s", method, agentDef.Name)
+	}
+
+	result, err := a.installer.Install(ctx, *agentDef, methodDef, false)
+	if err != nil {
+		return "failed", fmt.Sprintf("install failed: %v", err)
+	}
+
+	a.refreshAgents(ctx)
+	return "completed", fmt.Sprintf("installed %s %s", agentDef.Name, result.Version.String())
+}
+
+// enrollPin pins an agent to a version via the config loader, the same
+// path the config set command uses.
+func (a *App) enrollPin(cmd enroll.Command) (string, string) {
+	if a.configLoader == nil {
+		return "failed", "config management not available"
+	}
+	if cmd.Version == "" {
+		return "failed", "pin command missing version"
+	}
+
+	key := fmt.Sprintf("agents.%s.pinned_version", cmd.AgentID)
+	if err := a.configLoader.SetAndSave(key, cmd.Version); err != nil {
+		return "failed", fmt.Sprintf("failed to save pin: %v", err)
+	}
+	return "completed", fmt.Sprintf("pinned %s to %s", cmd.AgentID, cmd.Version)
+}
@@ -0,0 +1,46 @@
+package systray
+
+import (
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/i18n"
+)
+
+// notifyRespectingFocus shows an update notification unless the OS
+// do-not-disturb/Focus mode is active. Suppressed notifications are queued
+// for a summary once Focus ends when updates.queue_during_focus is set;
+// otherwise they are dropped.
+func (a *App) notifyRespectingFocus(title, message string) {
+	if !a.platform.IsDoNotDisturbEnabled() {
+		a.platform.ShowNotification(title, message)
+		return
+	}
+
+	if !a.config.Updates.QueueDuringFocus {
+		return
+	}
+
+	a.focusMu.Lock()
+	a.focusQueue = append(a.focusQueue, title+": "+message)
+	a.focusMu.Unlock()
+}
+
+// flushFocusQueue delivers a summary of the notifications suppressed during
+// Focus once Focus has ended. Called periodically from the background loop.
+func (a *App) flushFocusQueue() {
+	if a.platform.IsDoNotDisturbEnabled() {
+		return
+	}
+
+	a.focusMu.Lock()
+	pending := a.focusQueue
+	a.focusQueue = nil
+	a.focusMu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	body := i18n.T("notify.focus_summary.body", len(pending)) + "\n" + strings.Join(pending, "\n")
+	a.platform.ShowNotification(i18n.T("notify.focus_summary.title"), body)
+}
@@ -0,0 +1,92 @@
+package systray
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hotkey actions configurable via ui.hotkey.action.
+const (
+	hotkeyActionManageAgents = "manage_agents"
+	hotkeyActionCheckUpdates = "check_updates"
+)
+
+// hotkeyChord is a parsed keyboard shortcut.
+type hotkeyChord struct {
+	ctrl  bool
+	shift bool
+	alt   bool
+	cmd   bool
+	key   string // single lowercase character or named key
+}
+
+// parseShortcut parses a shortcut string like "cmd+shift+a" into a chord.
+// Recognized modifiers: ctrl/control, shift, alt/opt/option, cmd/command/super/meta.
+func parseShortcut(shortcut string) (hotkeyChord, error) {
+	var chord hotkeyChord
+	parts := strings.Split(strings.ToLower(strings.TrimSpace(shortcut)), "+")
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		switch part {
+		case "":
+			return chord, fmt.Errorf("invalid shortcut %q", shortcut)
+		case "ctrl", "control":
+			chord.ctrl = true
+		case "shift":
+			chord.shift = true
+		case "alt", "opt", "option":
+			chord.alt = true
+		case "cmd", "command", "super", "meta":
+			chord.cmd = true
+		default:
+			if chord.key != "" {
+				return chord, fmt.Errorf("shortcut %q has multiple keys", shortcut)
+			}
+			chord.key = part
+		}
+	}
+	if chord.key == "" {
+		return chord, fmt.Errorf("shortcut %q has no key", shortcut)
+	}
+	if !chord.ctrl && !chord.alt && !chord.cmd {
+		return chord, fmt.Errorf("shortcut %q needs at least one of ctrl, alt, or cmd", shortcut)
+	}
+	return chord, nil
+}
+
+// String renders the chord in canonical form.
+func (c hotkeyChord) String() string {
+	var parts []string
+	if c.ctrl {
+		parts = append(parts, "ctrl")
+	}
+	if c.alt {
+		parts = append(parts, "alt")
+	}
+	if c.shift {
+		parts = append(parts, "shift")
+	}
+	if c.cmd {
+		parts = append(parts, "cmd")
+	}
+	parts = append(parts, c.key)
+	return strings.Join(parts, "+")
+}
+
+// dispatchHotkeyAction runs the configured hotkey action.
+func (a *App) dispatchHotkeyAction() {
+	switch a.config.UI.Hotkey.Action {
+	case hotkeyActionCheckUpdates:
+		go a.forceRefreshAgents(a.ctx)
+	default: // manage_agents
+		go a.showManageAgentsWindow()
+	}
+}
+
+// reloadGlobalHotkey re-registers the hotkey after a settings change.
+func (a *App) reloadGlobalHotkey() {
+	a.unregisterGlobalHotkey()
+	if a.config.UI.Hotkey.Enabled {
+		_ = a.registerGlobalHotkey()
+	}
+}
@@ -0,0 +1,72 @@
+//go:build darwin
+
+package systray
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/progrium/darwinkit/macos/appkit"
+	"github.com/progrium/darwinkit/objc"
+)
+
+var (
+	hotkeyMu      sync.Mutex
+	hotkeyMonitor objc.Object
+)
+
+// registerGlobalHotkey installs an NSEvent global key-down monitor that
+// matches the configured shortcut. The monitor receives events even when
+// the app is not focused (requires the Accessibility permission).
+func (a *App) registerGlobalHotkey() error {
+	chord, err := parseShortcut(a.config.UI.Hotkey.Shortcut)
+	if err != nil {
+		return err
+	}
+
+	hotkeyMu.Lock()
+	defer hotkeyMu.Unlock()
+
+	if hotkeyMonitor.Ptr() != nil {
+		return nil // already registered
+	}
+
+	hotkeyMonitor = appkit.Event_AddGlobalMonitorForEventsMatchingMaskHandler(
+		appkit.EventMaskKeyDown,
+		func(event appkit.Event) {
+			if eventMatchesChord(event, chord) {
+				a.dispatchHotkeyAction()
+			}
+		},
+	)
+	return nil
+}
+
+// unregisterGlobalHotkey removes the event monitor if one is installed.
+func (a *App) unregisterGlobalHotkey() {
+	hotkeyMu.Lock()
+	defer hotkeyMu.Unlock()
+
+	if hotkeyMonitor.Ptr() != nil {
+		appkit.Event_RemoveMonitor(hotkeyMonitor)
+		hotkeyMonitor = objc.Object{}
+	}
+}
+
+// eventMatchesChord reports whether a key-down event matches the chord.
+func eventMatchesChord(event appkit.Event, chord hotkeyChord) bool {
+	flags := event.ModifierFlags()
+	if chord.ctrl != (flags&appkit.EventModifierFlagControl != 0) {
+		return false
+	}
+	if chord.shift != (flags&appkit.EventModifierFlagShift != 0) {
+		return false
+	}
+	if chord.alt != (flags&appkit.EventModifierFlagOption != 0) {
+		return false
+	}
+	if chord.cmd != (flags&appkit.EventModifierFlagCommand != 0) {
+		return false
+	}
+	return strings.EqualFold(event.CharactersIgnoringModifiers(), chord.key)
+}
@@ -0,0 +1,14 @@
+//go:build !darwin
+
+package systray
+
+import "fmt"
+
+// registerGlobalHotkey is not yet implemented on this platform.
+// Windows would use RegisterHotKey and Linux the desktop portal API.
+func (a *App) registerGlobalHotkey() error {
+	return fmt.Errorf("global hotkey not supported on this platform")
+}
+
+// unregisterGlobalHotkey is a no-op on this platform.
+func (a *App) unregisterGlobalHotkey() {}
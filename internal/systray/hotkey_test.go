@@ -0,0 +1,47 @@
+package systray
+
+import "testing"
+
+func TestParseShortcut(t *testing.T) {
+	tests := []struct {
+		shortcut string
+		expected hotkeyChord
+		wantErr  bool
+	}{
+		{"cmd+shift+a", hotkeyChord{cmd: true, shift: true, key: "a"}, false},
+		{"ctrl+shift+a", hotkeyChord{ctrl: true, shift: true, key: "a"}, false},
+		{"Control+Option+U", hotkeyChord{ctrl: true, alt: true, key: "u"}, false},
+		{"super+m", hotkeyChord{cmd: true, key: "m"}, false},
+		{"ctrl+alt+shift+z", hotkeyChord{ctrl: true, alt: true, shift: true, key: "z"}, false},
+		{"shift+a", hotkeyChord{}, true}, // no primary modifier
+		{"ctrl+shift", hotkeyChord{}, true},
+		{"ctrl+a+b", hotkeyChord{}, true},
+		{"", hotkeyChord{}, true},
+		{"ctrl++a", hotkeyChord{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shortcut, func(t *testing.T) {
+			chord, err := parseShortcut(tt.shortcut)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseShortcut(%q) expected error, got %+v", tt.shortcut, chord)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseShortcut(%q) unexpected error: %v", tt.shortcut, err)
+			}
+			if chord != tt.expected {
+				t.Errorf("parseShortcut(%q) = %+v, want %+v", tt.shortcut, chord, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHotkeyChordString(t *testing.T) {
+	chord := hotkeyChord{ctrl: true, shift: true, key: "a"}
+	if got := chord.String(); got != "ctrl+shift+a" {
+		t.Errorf("String() = %q, want %q", got, "ctrl+shift+a")
+	}
+}
@@ -0,0 +1,146 @@
+package systray
+
+import (
+	"sync"
+
+	"github.com/getlantern/systray"
+)
+
+// menuItemState is the last state applied to a menu item. Fields are
+// pointers so "never set through the diff" is distinguishable from any
+// real value.
+type menuItemState struct {
+	title   *string
+	visible *bool
+	enabled *bool
+}
+
+// menuDiff routes menu refreshes through a diff so only items whose
+// title, visibility, or enabled state actually changed are mutated.
+// getlantern/systray forwards every mutation straight to the OS menu, so
+// unconditionally re-setting thirty unchanged titles per refresh causes
+// visible flicker and menu resizing on macOS. While the tray menu is
+// open, mutations are parked and replayed in one batch on close so rows
+// do not move under the pointer.
+type menuDiff struct {
+	mu    sync.Mutex
+	items map[*systray.MenuItem]*menuItemState
+
+	tooltip      string
+	tooltipKnown bool
+
+	open    bool
+	pending []func()
+}
+
+// newMenuDiff creates an empty diff layer.
+func newMenuDiff() *menuDiff {
+	return &menuDiff{items: make(map[*systray.MenuItem]*menuItemState)}
+}
+
+// setOpen records whether the tray menu is currently being tracked.
+// Closing the menu replays the mutations parked while it was open.
+func (d *menuDiff) setOpen(open bool) {
+	d.mu.Lock()
+	d.open = open
+	var parked []func()
+	if !open {
+		parked = d.pending
+		d.pending = nil
+	}
+	d.mu.Unlock()
+
+	for _, apply := range parked {
+		apply()
+	}
+}
+
+// state returns the tracked state for an item, creating it on first use.
+// Callers must hold d.mu.
+func (d *menuDiff) state(item *systray.MenuItem) *menuItemState {
+	st, ok := d.items[item]
+	if !ok {
+		st = &menuItemState{}
+		d.items[item] = st
+	}
+	return st
+}
+
+// run executes a mutation now, or parks it while the menu is open.
+// Callers must hold d.mu.
+func (d *menuDiff) run(apply func()) {
+	if d.open {
+		d.pending = append(d.pending, apply)
+		return
+	}
+	apply()
+}
+
+// setTitle updates an item's title when it changed.
+func (d *menuDiff) setTitle(item *systray.MenuItem, title string) {
+	if item == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st := d.state(item)
+	if st.title != nil && *st.title == title {
+		return
+	}
+	st.title = &title
+	d.run(func() { item.SetTitle(title) })
+}
+
+// setVisible shows or hides an item when its visibility changed.
+func (d *menuDiff) setVisible(item *systray.MenuItem, visible bool) {
+	if item == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st := d.state(item)
+	if st.visible != nil && *st.visible == visible {
+		return
+	}
+	st.visible = &visible
+	d.run(func() {
+		if visible {
+			item.Show()
+		} else {
+			item.Hide()
+		}
+	})
+}
+
+// setEnabled enables or disables an item when its state changed.
+func (d *menuDiff) setEnabled(item *systray.MenuItem, enabled bool) {
+	if item == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	st := d.state(item)
+	if st.enabled != nil && *st.enabled == enabled {
+		return
+	}
+	st.enabled = &enabled
+	d.run(func() {
+		if enabled {
+			item.Enable()
+		} else {
+			item.Disable()
+		}
+	})
+}
+
+// setTooltip updates the tray tooltip when it changed.
+func (d *menuDiff) setTooltip(tooltip string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.tooltipKnown && d.tooltip == tooltip {
+		return
+	}
+	d.tooltip = tooltip
+	d.tooltipKnown = true
+	d.run(func() { systray.SetTooltip(tooltip) })
+}
@@ -0,0 +1,23 @@
+//go:build darwin
+
+package systray
+
+import (
+	"github.com/progrium/darwinkit/macos/foundation"
+)
+
+// watchMenuTracking observes AppKit menu tracking notifications so menu
+// mutations can be deferred while the tray menu is open. AppKit posts
+// these for every menu in the process, which covers the status item menu
+// getlantern/systray owns but does not expose.
+func (a *App) watchMenuTracking() {
+	center := foundation.NotificationCenter_DefaultCenter()
+	center.AddObserverForNameObjectQueueUsingBlock(
+		foundation.NotificationName("NSMenuDidBeginTrackingNotification"), nil, nil,
+		func(note foundation.Notification) { a.menuDiff.setOpen(true) },
+	)
+	center.AddObserverForNameObjectQueueUsingBlock(
+		foundation.NotificationName("NSMenuDidEndTrackingNotification"), nil, nil,
+		func(note foundation.Notification) { a.menuDiff.setOpen(false) },
+	)
+}
@@ -0,0 +1,7 @@
+//go:build !darwin
+
+package systray
+
+// watchMenuTracking is a no-op on platforms without a reliable signal
+// for the tray menu being open; mutations apply immediately there.
+func (a *App) watchMenuTracking() {}
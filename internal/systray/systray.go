@@ -3,6 +3,7 @@ package systray
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -15,14 +16,23 @@ import (
 	"github.com/getlantern/systray"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/api/discovery"
 	"github.com/kevinelliott/agentmgr/pkg/api/rest"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
 	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/digest"
+	"github.com/kevinelliott/agentmgr/pkg/i18n"
 	"github.com/kevinelliott/agentmgr/pkg/installer"
 	"github.com/kevinelliott/agentmgr/pkg/ipc"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/prefs"
+	"github.com/kevinelliott/agentmgr/pkg/recommend"
+	"github.com/kevinelliott/agentmgr/pkg/shim"
+	"github.com/kevinelliott/agentmgr/pkg/statusfile"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
+	"github.com/kevinelliott/agentmgr/pkg/terminal"
+	"github.com/kevinelliott/agentmgr/pkg/updater"
 )
 
 // String constants for repeated values.
@@ -44,8 +54,11 @@ type App struct {
 	platform     platform.Platform
 	store        storage.Store
 	detector     *detector.Detector
+	coord        *detector.Coordinator
 	catalog      *catalog.Manager
 	installer    *installer.Manager
+	digest       *digest.Collector
+	prefs        *prefs.Manager
 	version      string
 
 	// IPC server
@@ -54,12 +67,16 @@ type App struct {
 	// REST API server (optional)
 	restServer *rest.Server
 
+	// mDNS advertisement of the REST API (optional)
+	mdns *discovery.Advertiser
+
 	// State
 	agents      []agent.Installation
 	agentsMu    sync.RWMutex
 	startTime   time.Time
 	lastRefresh time.Time
 	lastCheck   time.Time
+	headless    bool
 
 	// Menu items
 	mStatus        *systray.MenuItem
@@ -67,22 +84,37 @@ type App struct {
 	mManageAgents  *systray.MenuItem
 	mAgentsLoading *systray.MenuItem
 	agentItems     []*agentMenuItem
+	topUpdateItems []*agentMenuItem
 	agentItemsMu   sync.Mutex
 	mRefresh       *systray.MenuItem
 	mUpdateAll     *systray.MenuItem
+	mDigest        *systray.MenuItem
+	mRecommend     *systray.MenuItem
 	mOpenTUI       *systray.MenuItem
+	mViewLogs      *systray.MenuItem
 	mSettings      *systray.MenuItem
 	mAutoStart     *systray.MenuItem
 	mQuit          *systray.MenuItem
 
+	// Diff layer for menu refreshes; only changed items are mutated
+	menuDiff *menuDiff
+
 	// Track spawned dialog processes to kill on exit
 	dialogProcs   []*exec.Cmd
 	dialogProcsMu sync.Mutex
 
+	// Remote catalog version already warned about as needing a newer app
+	schemaWarnedFor string
+
+	// Notifications held back while the OS Focus mode is active
+	focusQueue []string
+	focusMu    sync.Mutex
+
 	// Channels
-	ctx    context.Context
-	cancel context.CancelFunc
-	done   chan struct{}
+	ctx            context.Context
+	cancel         context.CancelFunc
+	done           chan struct{}
+	configReloaded chan struct{}
 }
 
 // Silence unused warning - reserved for future settings UI
@@ -92,24 +124,67 @@ var _ = (*App).changeCLIPath
 func New(cfg *config.Config, cfgLoader *config.Loader, plat platform.Platform, store storage.Store, det *detector.Detector, cat *catalog.Manager, inst *installer.Manager, version string) *App {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &App{
-		config:       cfg,
-		configLoader: cfgLoader,
-		platform:     plat,
-		store:        store,
-		detector:     det,
-		catalog:      cat,
-		installer:    inst,
-		version:      version,
-		startTime:    time.Now(),
-		ctx:          ctx,
-		cancel:       cancel,
-		done:         make(chan struct{}),
+		config:         cfg,
+		configLoader:   cfgLoader,
+		platform:       plat,
+		store:          store,
+		detector:       det,
+		coord:          detector.NewCoordinator(det, time.Second),
+		catalog:        cat,
+		installer:      inst,
+		digest:         digest.NewCollector(store),
+		prefs:          prefs.NewManager(store),
+		menuDiff:       newMenuDiff(),
+		version:        version,
+		startTime:      time.Now(),
+		ctx:            ctx,
+		cancel:         cancel,
+		done:           make(chan struct{}),
+		configReloaded: make(chan struct{}, 1),
 	}
 }
 
 // Run starts the systray application.
 // This must be called from the main goroutine on macOS.
 func (a *App) Run() error {
+	if err := a.startCore(); err != nil {
+		return err
+	}
+
+	// Run systray (blocks until quit)
+	// On macOS, this must run on the main thread
+	systray.Run(a.onReady, a.onExit)
+	return nil
+}
+
+// RunHeadless runs the helper without a tray icon. Only the IPC/REST
+// servers and background loops run, which makes the helper usable on
+// servers and CI hosts without a GUI session.
+func (a *App) RunHeadless() error {
+	a.headless = true
+
+	if err := a.startCore(); err != nil {
+		return err
+	}
+
+	// Initial refresh and background tasks, same as onReady but without
+	// any menu wiring.
+	go a.refreshAgents(a.ctx)
+	go a.backgroundLoop()
+
+	// Block until Quit or an IPC shutdown cancels the context
+	<-a.ctx.Done()
+	a.stopServers()
+	close(a.done)
+	return nil
+}
+
+// startCore initializes the parts of the app shared by tray and headless
+// modes: localization, the IPC server and the optional REST server.
+func (a *App) startCore() error {
+	// Initialize UI language from config (empty = detect from locale)
+	i18n.Init(a.config.UI.Language)
+
 	// Start IPC server
 	if err := a.startIPCServer(); err != nil {
 		return fmt.Errorf("failed to start IPC server: %w", err)
@@ -122,29 +197,149 @@ func (a *App) Run() error {
 		}
 	}
 
-	// Run systray (blocks until quit)
-	// On macOS, this must run on the main thread
-	systray.Run(a.onReady, a.onExit)
+	// Register as the agentmgr:// deep link handler; best-effort since
+	// links still work once registration succeeds on a later start
+	_ = registerURLScheme()
+
+	// Watch the config file and hot-reload validated changes
+	a.configLoader.Watch(a.applyConfig, func(err error) {
+		if !a.headless {
+			a.platform.ShowNotification(i18n.T("notify.config_invalid.title"), err.Error())
+		}
+	})
+
 	return nil
 }
 
-// Quit triggers a graceful shutdown of the systray application.
+// applyConfig applies a validated configuration at runtime: language,
+// hotkey, REST server enablement and the background loop intervals.
+func (a *App) applyConfig(cfg *config.Config) {
+	oldCfg := a.config
+	a.config = cfg
+
+	if cfg.UI.Language != oldCfg.UI.Language {
+		i18n.Init(cfg.UI.Language)
+	}
+
+	if !a.headless {
+		a.reloadGlobalHotkey()
+	}
+
+	// Re-apply tool overrides to detection and installs
+	a.detector.SetInstallersConfig(cfg.Installers)
+	a.installer.SetInstallersConfig(cfg.Installers)
+	a.installer.SetHooksConfig(cfg.Hooks, cfg.Agents)
+	a.installer.SetPolicy(cfg.Policy)
+	platform.SetLoginShellPath(cfg.Detection.LoginShellPath)
+
+	// Toggle the REST server on or off
+	if cfg.API.EnableREST && a.restServer == nil {
+		_ = a.startRESTServer()
+	} else if !cfg.API.EnableREST && a.restServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		a.restServer.Stop(ctx)
+		cancel()
+		a.restServer = nil
+		a.stopMDNS()
+	}
+
+	// Toggle the mDNS advertisement independently of the server itself
+	if a.restServer != nil && cfg.API.EnableMDNS && a.mdns == nil {
+		if adv, err := discovery.Advertise(cfg.API.MDNSName, cfg.API.RESTPort, a.version); err == nil {
+			a.mdns = adv
+		}
+	} else if !cfg.API.EnableMDNS {
+		a.stopMDNS()
+	}
+
+	// Nudge the background loop to reset its tickers
+	select {
+	case a.configReloaded <- struct{}{}:
+	default:
+	}
+}
+
+// stopServers stops the REST and IPC servers with a shutdown timeout.
+func (a *App) stopServers() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	a.stopMDNS()
+
+	if a.restServer != nil {
+		a.restServer.Stop(ctx)
+	}
+
+	if a.ipcServer != nil {
+		a.ipcServer.Stop(ctx)
+	}
+}
+
+// Quit triggers a graceful shutdown of the application.
 func (a *App) Quit() {
+	if a.headless {
+		a.cancel()
+		return
+	}
 	systray.Quit()
 }
 
 // startRESTServer starts the REST API server.
 func (a *App) startRESTServer() error {
 	a.restServer = rest.NewServer(a.config, a.platform, a.store, a.detector, a.catalog, a.installer)
-	return a.restServer.Start(a.ctx, rest.ServerConfig{
-		Address: fmt.Sprintf(":%d", a.config.API.RESTPort),
+	a.restServer.SetConfigManagement(a.configLoader, func(cfg *config.Config, key string, value interface{}) {
+		a.applyConfig(cfg)
+		a.broadcastConfigChanged(key, value)
 	})
+	address, warning := rest.ListenAddress(a.config.API)
+	if warning != "" {
+		a.platform.ShowNotification("REST API", warning)
+	}
+	serverCfg := rest.ServerConfig{Address: address}
+	if a.config.API.EnableTLS {
+		certFile, keyFile := a.config.API.TLSCertFile, a.config.API.TLSKeyFile
+		if certFile == "" || keyFile == "" {
+			var err error
+			certFile, keyFile, err = rest.EnsureSelfSignedCert(a.platform.GetDataDir())
+			if err != nil {
+				return err
+			}
+		}
+		serverCfg.TLS = true
+		serverCfg.CertFile = certFile
+		serverCfg.KeyFile = keyFile
+	}
+	if err := a.restServer.Start(a.ctx, serverCfg); err != nil {
+		return err
+	}
+
+	// Advertise the API on the LAN if enabled; best-effort since the
+	// server is useful without discovery
+	if a.config.API.EnableMDNS {
+		if adv, err := discovery.Advertise(a.config.API.MDNSName, a.config.API.RESTPort, a.version); err == nil {
+			a.mdns = adv
+		}
+	}
+	return nil
+}
+
+// stopMDNS withdraws the mDNS advertisement if one is active.
+func (a *App) stopMDNS() {
+	if a.mdns != nil {
+		a.mdns.Close()
+		a.mdns = nil
+	}
 }
 
 // startIPCServer starts the IPC server for CLI communication.
 func (a *App) startIPCServer() error {
 	a.ipcServer = ipc.NewServer("")
 	a.ipcServer.SetHandler(ipc.HandlerFunc(a.handleIPCMessage))
+	if group := a.config.Helper.IPCAllowGroup; group != "" {
+		if err := a.ipcServer.SetAllowGroup(group); err != nil {
+			return err
+		}
+	}
 	return a.ipcServer.Start(a.ctx)
 }
 
@@ -161,10 +356,28 @@ func (a *App) handleIPCMessage(ctx context.Context, msg *ipc.Message) (*ipc.Mess
 		return a.handleCheckUpdates(ctx, msg)
 	case ipc.MessageTypeGetStatus:
 		return a.handleGetStatus(ctx, msg)
+	case ipc.MessageTypeRefreshWidget:
+		return a.handleRefreshWidget(ctx, msg)
+	case ipc.MessageTypeOpenURL:
+		return a.handleOpenURL(ctx, msg)
+	case ipc.MessageTypeListJobs:
+		return a.handleListJobs(ctx, msg)
+	case ipc.MessageTypeGetJob:
+		return a.handleGetJob(ctx, msg)
+	case ipc.MessageTypeReloadConfig:
+		return a.handleReloadConfig(ctx, msg)
+	case ipc.MessageTypeGetConfig:
+		return a.handleGetConfig(ctx, msg)
+	case ipc.MessageTypeSetConfig:
+		return a.handleSetConfig(ctx, msg)
+	case ipc.MessageTypeStartDetection:
+		return a.handleStartDetection(ctx, msg)
+	case ipc.MessageTypeGetDetectionStatus:
+		return a.handleGetDetectionStatus(ctx, msg)
 	case ipc.MessageTypeShutdown:
 		go func() {
 			time.Sleep(100 * time.Millisecond)
-			systray.Quit()
+			a.Quit()
 		}()
 		return ipc.NewMessage(ipc.MessageTypeSuccess, nil)
 	default:
@@ -216,6 +429,16 @@ func (a *App) handleGetAgent(ctx context.Context, msg *ipc.Message) (*ipc.Messag
 
 // handleRefreshCatalog handles refresh_catalog requests.
 func (a *App) handleRefreshCatalog(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	// Don't stack a new run behind one that's already detecting; tell
+	// the caller which run to attach to instead
+	if runID, ok := a.coord.InFlight(); ok {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "refresh_in_progress",
+			Message: "refresh already in progress",
+			Details: runID,
+		})
+	}
+
 	if err := a.refreshAgents(ctx); err != nil {
 		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
 			Code:    "refresh_failed",
@@ -225,6 +448,53 @@ func (a *App) handleRefreshCatalog(ctx context.Context, msg *ipc.Message) (*ipc.
 	return ipc.NewMessage(ipc.MessageTypeSuccess, nil)
 }
 
+// handleStartDetection handles start_detection requests: it kicks off a
+// detection run in the background and returns the run ID immediately so
+// the caller can poll get_detection_status for per-strategy progress.
+func (a *App) handleStartDetection(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	agentDefs, err := a.catalog.GetAgentsForPlatform(a.ctx, string(a.platform.ID()))
+	if err != nil {
+		agentDefs = nil
+	}
+
+	runID, started := a.coord.Start(a.ctx, agentDefs, func(detected []*agent.Installation, err error) {
+		if err != nil {
+			return
+		}
+		// Fold the results into the tray state; the coordinator's
+		// debounce window reuses this run instead of detecting again
+		_ = a.refreshAgents(a.ctx)
+	})
+
+	return ipc.NewMessage(ipc.MessageTypeSuccess, ipc.StartDetectionResponse{
+		RunID:          runID,
+		AlreadyRunning: !started,
+	})
+}
+
+// handleGetDetectionStatus handles get_detection_status requests.
+func (a *App) handleGetDetectionStatus(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	var req ipc.GetDetectionStatusRequest
+	if err := msg.DecodePayload(&req); err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_payload",
+			Message: err.Error(),
+		})
+	}
+
+	status, ok := a.coord.Status(req.RunID)
+	if !ok {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "run_not_found",
+			Message: "no matching detection run",
+		})
+	}
+
+	return ipc.NewMessage(ipc.MessageTypeSuccess, ipc.DetectionStatusResponse{
+		Status: status,
+	})
+}
+
 // handleCheckUpdates handles check_updates requests.
 func (a *App) handleCheckUpdates(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
 	if err := a.checkUpdates(ctx); err != nil {
@@ -259,6 +529,255 @@ func (a *App) handleGetStatus(ctx context.Context, msg *ipc.Message) (*ipc.Messa
 	})
 }
 
+// handleRefreshWidget handles refresh_widget requests by rewriting the
+// widget status plist from the current agent state.
+func (a *App) handleRefreshWidget(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	if err := a.publishWidgetStatus(ctx); err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "widget_refresh_failed",
+			Message: err.Error(),
+		})
+	}
+	return ipc.NewMessage(ipc.MessageTypeSuccess, nil)
+}
+
+// publishWidgetStatus writes the agents/updates summary to the shared
+// App Group container, where a macOS WidgetKit extension or Shortcuts
+// action can read it; a no-op on other platforms.
+func (a *App) publishWidgetStatus(ctx context.Context) error {
+	a.agentsMu.RLock()
+	status := statusfile.Status{
+		Agents:      len(a.agents),
+		GeneratedAt: time.Now(),
+	}
+	for _, ag := range a.agents {
+		if ag.HasUpdate() {
+			status.UpdatesAvailable++
+		}
+	}
+	a.agentsMu.RUnlock()
+
+	lastCheck, err := a.store.GetLastUpdateCheckTime(ctx)
+	if err == nil {
+		status.LastCheck = lastCheck
+	}
+	return statusfile.WriteWidget(status)
+}
+
+// handleListJobs handles list_jobs requests.
+func (a *App) handleListJobs(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	var req ipc.ListJobsRequest
+	if err := msg.DecodePayload(&req); err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_payload",
+			Message: err.Error(),
+		})
+	}
+
+	jobList, err := a.store.ListJobs(ctx, storage.JobState(req.State), req.Limit)
+	if err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "storage_error",
+			Message: err.Error(),
+		})
+	}
+
+	jobs := make([]storage.Job, 0, len(jobList))
+	for _, job := range jobList {
+		jobs = append(jobs, *job)
+	}
+
+	return ipc.NewMessage(ipc.MessageTypeSuccess, ipc.ListJobsResponse{
+		Jobs:  jobs,
+		Total: len(jobs),
+	})
+}
+
+// handleGetJob handles get_job requests.
+func (a *App) handleGetJob(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	var req ipc.GetJobRequest
+	if err := msg.DecodePayload(&req); err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_payload",
+			Message: err.Error(),
+		})
+	}
+
+	job, err := a.store.GetJob(ctx, req.ID)
+	if err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "storage_error",
+			Message: err.Error(),
+		})
+	}
+	if job == nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "not_found",
+			Message: fmt.Sprintf("job %s not found", req.ID),
+		})
+	}
+
+	return ipc.NewMessage(ipc.MessageTypeSuccess, ipc.GetJobResponse{Job: job})
+}
+
+// handleReloadConfig handles reload_config requests.
+func (a *App) handleReloadConfig(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	cfg, err := a.configLoader.Reload()
+	if err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_config",
+			Message: err.Error(),
+		})
+	}
+
+	a.applyConfig(cfg)
+	return ipc.NewMessage(ipc.MessageTypeSuccess, nil)
+}
+
+// handleGetConfig handles get_config requests, returning either the
+// whole config or a single key's value.
+func (a *App) handleGetConfig(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	var req ipc.GetConfigRequest
+	if err := msg.DecodePayload(&req); err != nil {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_request",
+			Message: err.Error(),
+		})
+	}
+
+	if req.Key != "" {
+		return ipc.NewMessage(ipc.MessageTypeSuccess, ipc.GetConfigResponse{
+			Key:   req.Key,
+			Value: a.configLoader.Get(req.Key),
+		})
+	}
+	return ipc.NewMessage(ipc.MessageTypeSuccess, ipc.GetConfigResponse{
+		Config: a.config,
+	})
+}
+
+// handleSetConfig handles set_config requests: whitelisted keys only,
+// validated before the file is written, applied immediately, and
+// announced to subscribers.
+func (a *App) handleSetConfig(ctx context.Context, msg *ipc.Message) (*ipc.Message, error) {
+	var req ipc.SetConfigRequest
+	if err := msg.DecodePayload(&req); err != nil || req.Key == "" {
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    "invalid_request",
+			Message: "set_config requires a key",
+		})
+	}
+
+	cfg, err := a.setConfigValue(req.Key, req.Value)
+	if err != nil {
+		code := "invalid_config"
+		if !config.IsMutableKey(req.Key) {
+			code = "immutable_key"
+		}
+		return ipc.NewMessage(ipc.MessageTypeError, ipc.ErrorResponse{
+			Code:    code,
+			Message: err.Error(),
+		})
+	}
+
+	a.applyConfig(cfg)
+	a.broadcastConfigChanged(req.Key, req.Value)
+	return ipc.NewMessage(ipc.MessageTypeSuccess, nil)
+}
+
+// setConfigValue checks the mutable-key whitelist and persists a single
+// config change, returning the validated resulting config.
+func (a *App) setConfigValue(key string, value interface{}) (*config.Config, error) {
+	if !config.IsMutableKey(key) {
+		return nil, fmt.Errorf("config key %q cannot be changed over the management API", key)
+	}
+	return a.configLoader.SetValidated(key, value)
+}
+
+// broadcastConfigChanged pushes a config_changed notification to IPC
+// subscribers.
+func (a *App) broadcastConfigChanged(key string, value interface{}) {
+	if a.ipcServer == nil {
+		return
+	}
+	msg, err := ipc.NewMessage(ipc.MessageTypeConfigChanged, ipc.ConfigChangedNotification{
+		Key:   key,
+		Value: value,
+	})
+	if err != nil {
+		return
+	}
+	a.ipcServer.Broadcast(msg)
+}
+
+// menuEntry describes one root-level tray menu item. The menu is built by
+// walking a list of entries, so the ui.tray config can hide individual
+// items without touching the construction order.
+type menuEntry struct {
+	separator bool
+	title     string
+	tooltip   string
+	disabled  bool
+	hidden    bool
+	assign    func(*systray.MenuItem)
+}
+
+// rootMenuEntries returns the tray menu layout, honoring the ui.tray
+// customization settings.
+func (a *App) rootMenuEntries() []menuEntry {
+	tray := a.config.UI.Tray
+
+	// Status line - use fixed text to avoid menu resizing
+	entries := []menuEntry{
+		{title: i18n.T("menu.loading"), disabled: true, assign: func(m *systray.MenuItem) { a.mStatus = m }},
+		{title: i18n.T("menu.agents"), tooltip: i18n.T("menu.agents.tip"), assign: func(m *systray.MenuItem) { a.mAgentsMenu = m }},
+		{title: i18n.T("menu.updates"), disabled: true, hidden: !tray.ShowQuickActions, assign: func(m *systray.MenuItem) { a.mUpdateAll = m }},
+	}
+
+	// Root-level slots for the top agents with pending updates; they stay
+	// hidden until updateTopUpdates fills them in
+	for i := 0; i < tray.TopUpdates; i++ {
+		entries = append(entries, menuEntry{hidden: true, assign: func(m *systray.MenuItem) {
+			slot := &agentMenuItem{item: m}
+			a.topUpdateItems = append(a.topUpdateItems, slot)
+			go a.handleAgentItemClick(slot)
+		}})
+	}
+
+	return append(entries,
+		menuEntry{title: i18n.T("menu.digest"), tooltip: i18n.T("menu.digest.tip"), hidden: !a.digestEnabled(), assign: func(m *systray.MenuItem) { a.mDigest = m }},
+		menuEntry{title: i18n.T("menu.recommendations"), tooltip: i18n.T("menu.recommendations.tip"), assign: func(m *systray.MenuItem) { a.mRecommend = m }},
+		menuEntry{separator: true},
+		menuEntry{title: i18n.T("menu.open_tui"), tooltip: i18n.T("menu.open_tui.tip"), hidden: !tray.ShowTUI, assign: func(m *systray.MenuItem) { a.mOpenTUI = m }},
+		menuEntry{title: i18n.T("menu.refresh"), tooltip: i18n.T("menu.refresh.tip"), hidden: !tray.ShowQuickActions, assign: func(m *systray.MenuItem) { a.mRefresh = m }},
+		menuEntry{title: i18n.T("menu.autostart"), tooltip: i18n.T("menu.autostart.tip"), assign: func(m *systray.MenuItem) { a.mAutoStart = m }},
+		menuEntry{separator: true},
+		menuEntry{title: i18n.T("menu.view_logs"), tooltip: i18n.T("menu.view_logs.tip"), assign: func(m *systray.MenuItem) { a.mViewLogs = m }},
+		menuEntry{title: i18n.T("menu.settings"), tooltip: i18n.T("menu.settings.tip"), assign: func(m *systray.MenuItem) { a.mSettings = m }},
+		menuEntry{title: i18n.T("menu.quit"), assign: func(m *systray.MenuItem) { a.mQuit = m }},
+	)
+}
+
+// buildMenu constructs the root tray menu from its entry list.
+func (a *App) buildMenu() {
+	for _, e := range a.rootMenuEntries() {
+		if e.separator {
+			systray.AddSeparator()
+			continue
+		}
+		item := systray.AddMenuItem(e.title, e.tooltip)
+		if e.disabled {
+			item.Disable()
+		}
+		if e.hidden {
+			item.Hide()
+		}
+		if e.assign != nil {
+			e.assign(item)
+		}
+	}
+}
+
 // onReady is called when systray is ready.
 func (a *App) onReady() {
 	// Set icon and tooltip
@@ -267,32 +786,23 @@ func (a *App) onReady() {
 	systray.SetTooltip("AgentManager")
 	// Note: Not setting a title keeps it icon-only in menu bar
 
-	// Status line - use fixed text to avoid menu resizing
-	a.mStatus = systray.AddMenuItem("Loading...", "")
-	a.mStatus.Disable()
+	a.buildMenu()
 
 	// Agents submenu
-	a.mAgentsMenu = systray.AddMenuItem("Agents", "View detected agents")
-	a.mManageAgents = a.mAgentsMenu.AddSubMenuItem("Manage Agents", "Manage all agents")
+	a.mManageAgents = a.mAgentsMenu.AddSubMenuItem(i18n.T("menu.manage_agents"), i18n.T("menu.manage_agents.tip"))
 	separatorItem := a.mAgentsMenu.AddSubMenuItem("─────────────────────", "")
 	separatorItem.Disable() // Disable to make it non-clickable
 	// Loading item shown while agents are being detected
-	a.mAgentsLoading = a.mAgentsMenu.AddSubMenuItem("Loading...", "")
+	a.mAgentsLoading = a.mAgentsMenu.AddSubMenuItem(i18n.T("menu.loading"), "")
 	a.mAgentsLoading.Disable()
 
-	a.mUpdateAll = systray.AddMenuItem("Updates", "")
-	a.mUpdateAll.Disable()
-
-	systray.AddSeparator()
-
-	a.mOpenTUI = systray.AddMenuItem("Open TUI", "Launch terminal interface")
-	a.mRefresh = systray.AddMenuItem("Refresh", "Re-detect agents")
-	a.mAutoStart = systray.AddMenuItem("Start at Login", "Toggle auto-start on login")
+	// Defer menu mutations while the menu is open, where supported
+	a.watchMenuTracking()
 
-	systray.AddSeparator()
-
-	a.mSettings = systray.AddMenuItem("Settings", "Configure AgentManager")
-	a.mQuit = systray.AddMenuItem("Quit", "")
+	// Register the global hotkey if enabled
+	if a.config.UI.Hotkey.Enabled {
+		_ = a.registerGlobalHotkey()
+	}
 
 	// Check auto-start status
 	if enabled, err := a.platform.IsAutoStartEnabled(a.ctx); err == nil && enabled {
@@ -319,18 +829,7 @@ func (a *App) onExit() {
 	// Kill any open dialog processes (fallback osascript)
 	a.killAllDialogs()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	// Stop REST server
-	if a.restServer != nil {
-		a.restServer.Stop(ctx)
-	}
-
-	// Stop IPC server
-	if a.ipcServer != nil {
-		a.ipcServer.Stop(ctx)
-	}
+	a.stopServers()
 
 	close(a.done)
 }
@@ -381,8 +880,14 @@ func (a *App) handleMenuClicks() {
 			go a.forceRefreshAgents(a.ctx) // Manual refresh bypasses cache
 		case <-a.mUpdateAll.ClickedCh:
 			go a.updateAllAgents(a.ctx)
+		case <-a.mDigest.ClickedCh:
+			go a.openDigestReport()
+		case <-a.mRecommend.ClickedCh:
+			go a.showRecommendationsWindow()
 		case <-a.mOpenTUI.ClickedCh:
 			go a.openTUI()
+		case <-a.mViewLogs.ClickedCh:
+			go a.showLogsWindow()
 		case <-a.mSettings.ClickedCh:
 			go a.showSettings()
 		case <-a.mAutoStart.ClickedCh:
@@ -404,20 +909,73 @@ func (a *App) backgroundLoop() {
 	checkTicker := time.NewTicker(a.config.Updates.CheckInterval)
 	defer checkTicker.Stop()
 
+	// Digest ticker: checked hourly, sent per the configured interval
+	digestTicker := time.NewTicker(time.Hour)
+	defer digestTicker.Stop()
+
+	// Focus ticker: delivers notifications queued during Focus once it ends
+	focusTicker := time.NewTicker(time.Minute)
+	defer focusTicker.Stop()
+
+	// Enrollment ticker: reports inventory to and polls commands from the
+	// central management server when enrollment is enabled
+	enrollTicker := time.NewTicker(enrollmentInterval(a.config))
+	defer enrollTicker.Stop()
+
 	for {
 		select {
 		case <-a.ctx.Done():
 			return
+		case <-a.configReloaded:
+			refreshTicker.Reset(a.config.Catalog.RefreshInterval)
+			checkTicker.Reset(a.config.Updates.CheckInterval)
+			enrollTicker.Reset(enrollmentInterval(a.config))
 		case <-refreshTicker.C:
+			a.refreshCatalog(a.ctx)
 			a.refreshAgents(a.ctx)
 		case <-checkTicker.C:
 			if a.config.Updates.AutoCheck {
 				a.checkUpdates(a.ctx)
 			}
+		case <-digestTicker.C:
+			a.maybeSendDigest(a.ctx)
+		case <-focusTicker.C:
+			a.flushFocusQueue()
+		case <-enrollTicker.C:
+			if a.config.Enrollment.Enabled {
+				a.enrollmentTick(a.ctx)
+			}
 		}
 	}
 }
 
+// enrollmentInterval returns the enrollment tick interval, with a sane
+// floor so a zero value from an unvalidated config cannot spin the loop.
+func enrollmentInterval(cfg *config.Config) time.Duration {
+	if cfg.Enrollment.Interval < time.Minute {
+		return 15 * time.Minute
+	}
+	return cfg.Enrollment.Interval
+}
+
+// refreshCatalog refreshes the remote catalog. When the remote has moved
+// to a schema this build doesn't understand, the manager keeps the cached
+// catalog and the user is told (once per remote version) to update the app.
+func (a *App) refreshCatalog(ctx context.Context) {
+	result, err := a.catalog.Refresh(ctx)
+	if err != nil || result == nil {
+		return
+	}
+
+	if result.SchemaTooNew && result.RemoteVersion != a.schemaWarnedFor {
+		a.schemaWarnedFor = result.RemoteVersion
+		a.notifyRespectingFocus(
+			i18n.T("notify.schema_too_new.title"),
+			i18n.T("notify.schema_too_new.body"),
+		)
+	}
+}
+
 // refreshAgents refreshes the list of detected agents (uses cache if available).
 func (a *App) refreshAgents(ctx context.Context) error {
 	return a.refreshAgentsWithCache(ctx, false)
@@ -443,6 +1001,15 @@ func (a *App) refreshAgentsWithCache(ctx context.Context, forceRefresh bool) err
 		agentDefMap[def.ID] = def
 	}
 
+	// Track catalog additions for the digest
+	if a.digestEnabled() && len(agentDefs) > 0 {
+		catalogAgents := make(map[string]string, len(agentDefs))
+		for _, def := range agentDefs {
+			catalogAgents[def.ID] = def.Name
+		}
+		_ = a.digest.ObserveCatalog(ctx, catalogAgents)
+	}
+
 	var detected []*agent.Installation
 	var usedDetectionCache bool
 	var needUpdateCheck bool
@@ -472,8 +1039,8 @@ func (a *App) refreshAgentsWithCache(ctx context.Context, forceRefresh bool) err
 			_ = a.store.ClearDetectionCache(ctx)
 		}
 
-		// Detect agents
-		detected, err = a.detector.DetectAll(ctx, agentDefs)
+		// Detect agents, coalescing with any concurrent refresh
+		detected, err = a.coord.Refresh(ctx, agentDefs)
 		if err != nil {
 			return err
 		}
@@ -506,6 +1073,10 @@ func (a *App) refreshAgentsWithCache(ctx context.Context, forceRefresh bool) err
 		}
 	}
 
+	// Fill in recently-updated and restart-pending state from the stored
+	// update history
+	updater.AnnotateUpdateState(ctx, a.store, detected, agentDefMap)
+
 	// Convert []*agent.Installation to []agent.Installation
 	agents := make([]agent.Installation, len(detected))
 	for i, inst := range detected {
@@ -518,6 +1089,10 @@ func (a *App) refreshAgentsWithCache(ctx context.Context, forceRefresh bool) err
 	a.agentsMu.Unlock()
 
 	a.updateMenu()
+
+	// Keep the widget status plist in sync with what the tray shows
+	_ = a.publishWidgetStatus(ctx)
+
 	return nil
 }
 
@@ -535,18 +1110,31 @@ func (a *App) checkUpdates(ctx context.Context) error {
 
 	// Show notification if updates available
 	a.agentsMu.RLock()
+	agents := make([]agent.Installation, len(a.agents))
+	copy(agents, a.agents)
+	a.agentsMu.RUnlock()
+
+	// Hidden agents are excluded from update notifications
+	agents = a.visibleAgents(agents, a.prefs.Get(a.ctx))
+
 	updatesAvailable := 0
-	for _, ag := range a.agents {
+	for _, ag := range agents {
 		if ag.HasUpdate() {
 			updatesAvailable++
 		}
 	}
-	a.agentsMu.RUnlock()
 
-	if updatesAvailable > 0 && a.config.Updates.Notify {
-		a.platform.ShowNotification(
-			"Updates Available",
-			fmt.Sprintf("%d agent update(s) available", updatesAvailable),
+	// In digest mode, collect the pending updates for the next digest
+	// instead of notifying on every check
+	if a.digestEnabled() {
+		a.recordPendingUpdates(ctx, agents)
+		return nil
+	}
+
+	if updatesAvailable > 0 && a.config.Updates.Notify && !a.headless {
+		a.notifyRespectingFocus(
+			i18n.T("notify.updates_available.title"),
+			i18n.T("notify.updates_available.body", updatesAvailable),
 		)
 	}
 
@@ -555,9 +1143,18 @@ func (a *App) checkUpdates(ctx context.Context) error {
 
 // updateMenu updates the systray menu to reflect current state.
 func (a *App) updateMenu() {
+	if a.headless {
+		return
+	}
+
 	a.agentsMu.RLock()
 	agents := make([]agent.Installation, len(a.agents))
 	copy(agents, a.agents)
+	a.agentsMu.RUnlock()
+
+	// Drop hidden agents from the menu; they stay detectable elsewhere
+	agentPrefs := a.prefs.Get(a.ctx)
+	agents = a.visibleAgents(agents, agentPrefs)
 	agentCount := len(agents)
 	updatesAvailable := 0
 	for _, ag := range agents {
@@ -565,92 +1162,115 @@ func (a *App) updateMenu() {
 			updatesAvailable++
 		}
 	}
-	a.agentsMu.RUnlock()
 
-	// Update status line (keep text short to minimize menu resizing)
-	a.mStatus.SetTitle(fmt.Sprintf("%d Agents", agentCount))
+	// Update status line; the diff layer drops no-op mutations so an
+	// unchanged count leaves the menu untouched
+	a.menuDiff.setTitle(a.mStatus, fmt.Sprintf("%d Agents", agentCount))
 
 	// Update agents submenu
-	a.updateAgentsSubmenu(agents)
+	a.updateAgentsSubmenu(agents, agentPrefs)
+
+	// Update the root-level top-updates slots
+	a.updateTopUpdates(agents)
 
 	// Update Agents menu state
-	if agentCount > 0 {
-		a.mAgentsMenu.Enable()
-	} else {
-		a.mAgentsMenu.Disable()
-	}
+	a.menuDiff.setEnabled(a.mAgentsMenu, agentCount > 0)
 
-	// Update updates line (keep text short to minimize menu resizing)
-	if updatesAvailable > 0 {
-		a.mUpdateAll.SetTitle(fmt.Sprintf("⬆ %d Updates", updatesAvailable))
-		a.mUpdateAll.Enable()
-		systray.SetTooltip(fmt.Sprintf("AgentManager (%d updates)", updatesAvailable))
+	a.updateUpdatesLine(updatesAvailable)
+}
+
+// updateUpdatesLine refreshes the Update All entry and tray tooltip.
+func (a *App) updateUpdatesLine(updatesAvailable int) {
+	if updatesAvailable > 0 && !a.config.Policy.Lockdown {
+		a.menuDiff.setTitle(a.mUpdateAll, fmt.Sprintf("⬆ %d Updates", updatesAvailable))
+		a.menuDiff.setEnabled(a.mUpdateAll, true)
+		a.menuDiff.setTooltip(fmt.Sprintf("AgentManager (%d updates)", updatesAvailable))
 	} else {
-		a.mUpdateAll.SetTitle("Up to date")
-		a.mUpdateAll.Disable()
-		systray.SetTooltip("AgentManager")
+		a.menuDiff.setTitle(a.mUpdateAll, "Up to date")
+		a.menuDiff.setEnabled(a.mUpdateAll, false)
+		a.menuDiff.setTooltip("AgentManager")
 	}
 }
 
 // updateMenuCounts updates only the status and update counts without modifying the agents submenu.
 // This is used for background updates to avoid menu jumping.
 func (a *App) updateMenuCounts() {
+	if a.headless {
+		return
+	}
+
 	a.agentsMu.RLock()
-	agentCount := len(a.agents)
+	agents := make([]agent.Installation, len(a.agents))
+	copy(agents, a.agents)
+	a.agentsMu.RUnlock()
+
+	agents = a.visibleAgents(agents, a.prefs.Get(a.ctx))
+	agentCount := len(agents)
 	updatesAvailable := 0
-	for _, ag := range a.agents {
+	for _, ag := range agents {
 		if ag.HasUpdate() {
 			updatesAvailable++
 		}
 	}
-	a.agentsMu.RUnlock()
 
 	// Update status line (keep text short to minimize menu resizing)
-	a.mStatus.SetTitle(fmt.Sprintf("%d Agents", agentCount))
+	a.menuDiff.setTitle(a.mStatus, fmt.Sprintf("%d Agents", agentCount))
 
-	// Update updates line (keep text short to minimize menu resizing)
-	if updatesAvailable > 0 {
-		a.mUpdateAll.SetTitle(fmt.Sprintf("⬆ %d Updates", updatesAvailable))
-		a.mUpdateAll.Enable()
-		systray.SetTooltip(fmt.Sprintf("AgentManager (%d updates)", updatesAvailable))
-	} else {
-		a.mUpdateAll.SetTitle("Up to date")
-		a.mUpdateAll.Disable()
-		systray.SetTooltip("AgentManager")
+	a.updateUpdatesLine(updatesAvailable)
+}
+
+// visibleAgents filters out agents hidden from the tray, either through
+// the agents config section or the stored preferences.
+func (a *App) visibleAgents(agents []agent.Installation, p prefs.AgentPrefs) []agent.Installation {
+	visible := agents[:0]
+	for _, ag := range agents {
+		if a.config.IsAgentHidden(ag.AgentID) || p.IsHidden(ag.AgentID) {
+			continue
+		}
+		visible = append(visible, ag)
 	}
+	return visible
 }
 
 // updateAgentsSubmenu updates the agents submenu with current agents.
-func (a *App) updateAgentsSubmenu(agents []agent.Installation) {
+func (a *App) updateAgentsSubmenu(agents []agent.Installation, p prefs.AgentPrefs) {
 	a.agentItemsMu.Lock()
 	defer a.agentItemsMu.Unlock()
 
 	// Hide loading indicator once agents are loaded
 	if a.mAgentsLoading != nil {
-		a.mAgentsLoading.Hide()
+		a.menuDiff.setVisible(a.mAgentsLoading, false)
 	}
 
-	// Sort agents alphabetically by name (case-insensitive)
+	// Sort agents alphabetically by name (case-insensitive), keeping
+	// favorites at the top
 	sort.Slice(agents, func(i, j int) bool {
+		fi, fj := p.IsFavorite(agents[i].AgentID), p.IsFavorite(agents[j].AgentID)
+		if fi != fj {
+			return fi
+		}
 		return strings.ToLower(agents[i].AgentName) < strings.ToLower(agents[j].AgentName)
 	})
 
 	// Hide existing items that are no longer needed
 	for i, item := range a.agentItems {
 		if i >= len(agents) {
-			item.item.Hide()
+			a.menuDiff.setVisible(item.item, false)
 		}
 	}
 
 	// Create or update items for each agent
 	for i, ag := range agents {
-		// Build the menu item title
+		// Build the menu item title, starring favorites
 		title := a.formatAgentMenuTitle(ag)
+		if p.IsFavorite(ag.AgentID) {
+			title = "★ " + title
+		}
 
 		if i < len(a.agentItems) {
-			// Update existing item
-			a.agentItems[i].item.SetTitle(title)
-			a.agentItems[i].item.Show()
+			// Update existing item; unchanged rows are left alone
+			a.menuDiff.setTitle(a.agentItems[i].item, title)
+			a.menuDiff.setVisible(a.agentItems[i].item, true)
 			a.agentItems[i].agentID = ag.AgentID
 			a.agentItems[i].method = ag.Method
 		} else {
@@ -669,6 +1289,38 @@ func (a *App) updateAgentsSubmenu(agents []agent.Installation) {
 	}
 }
 
+// updateTopUpdates fills the root-level top-updates slots with the agents
+// that currently have updates available. Surplus slots stay hidden.
+func (a *App) updateTopUpdates(agents []agent.Installation) {
+	if len(a.topUpdateItems) == 0 {
+		return
+	}
+
+	var pending []agent.Installation
+	for _, ag := range agents {
+		if ag.HasUpdate() {
+			pending = append(pending, ag)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		return strings.ToLower(pending[i].AgentName) < strings.ToLower(pending[j].AgentName)
+	})
+
+	a.agentItemsMu.Lock()
+	defer a.agentItemsMu.Unlock()
+
+	for i, slot := range a.topUpdateItems {
+		if i >= len(pending) {
+			a.menuDiff.setVisible(slot.item, false)
+			continue
+		}
+		a.menuDiff.setTitle(slot.item, a.formatAgentMenuTitle(pending[i]))
+		a.menuDiff.setVisible(slot.item, true)
+		slot.agentID = pending[i].AgentID
+		slot.method = pending[i].Method
+	}
+}
+
 // formatAgentMenuTitle formats the menu title for an agent.
 // Format: "● Name (method) — version" with em-dash separator
 // Note: Tab-based right-alignment requires NSAttributedString with paragraph styles,
@@ -686,10 +1338,19 @@ func (a *App) formatAgentMenuTitle(ag agent.Installation) string {
 	}
 
 	// Use em-dash separator for clean visual separation
+	if ag.ConfiguredOnly {
+		return fmt.Sprintf("○ %s — %s", ag.AgentName, i18n.T("menu.configured_only"))
+	}
 	if ag.HasUpdate() {
 		return fmt.Sprintf("⬆ %s%s — %s → %s", ag.AgentName, methodStr, version, ag.LatestVersion.String())
 	}
-	return fmt.Sprintf("● %s%s — %s", ag.AgentName, methodStr, version)
+	title := fmt.Sprintf("● %s%s — %s", ag.AgentName, methodStr, version)
+	if ag.RestartPending {
+		title += " ↻ " + i18n.T("menu.restart_pending")
+	} else if ag.RecentlyUpdated() {
+		title += " ✓ " + i18n.T("menu.recently_updated")
+	}
+	return title
 }
 
 // handleAgentItemClick handles clicks on an agent menu item.
@@ -743,18 +1404,33 @@ func (a *App) showAgentDetails(inst agent.Installation) {
 		details += fmt.Sprintf("\nDetected: %s", inst.DetectedAt.Format("2006-01-02 15:04"))
 	}
 
+	if reqLines := a.requirementStatusLines(inst); len(reqLines) > 0 {
+		details += "\n\nRequirements:"
+		for _, line := range reqLines {
+			details += "\n  " + line
+		}
+	}
+
+	authLine, loginCmd := a.authStatusLine(inst)
+	if authLine != "" {
+		details += "\n" + authLine
+	}
+
 	hasUpdate := inst.HasUpdate()
 	if hasUpdate {
 		details += fmt.Sprintf("\n\nUpdate Available: %s → %s",
 			version, inst.LatestVersion.String())
 	}
 
+	// Offer the default-copy switcher only when there is a choice
+	canSetDefault := a.agentCopyCount(inst.AgentID) > 1
+
 	// Show dialog based on platform
 	switch a.platform.ID() {
 	case platform.Darwin:
-		a.showMacOSAgentDialog(inst, details, hasUpdate)
+		a.showMacOSAgentDialog(inst, details, hasUpdate, loginCmd, canSetDefault)
 	case platform.Linux:
-		a.showLinuxAgentDialog(inst, details, hasUpdate)
+		a.showLinuxAgentDialog(inst, details, hasUpdate, loginCmd, canSetDefault)
 	case platform.Windows:
 		a.showWindowsAgentDialog(inst, details, hasUpdate)
 	default:
@@ -763,29 +1439,73 @@ func (a *App) showAgentDetails(inst agent.Installation) {
 	}
 }
 
+// requirementStatusLines checks the agent's declared requirements and
+// returns one status line per requirement, or nil when none are declared.
+func (a *App) requirementStatusLines(inst agent.Installation) []string {
+	ctx, cancel := context.WithTimeout(a.ctx, 30*time.Second)
+	defer cancel()
+
+	agentDef, err := a.catalog.GetAgent(ctx, inst.AgentID)
+	if err != nil || agentDef == nil || len(agentDef.Requirements) == 0 {
+		return nil
+	}
+
+	statuses := a.installer.CheckRequirements(ctx, *agentDef)
+	lines := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		lines = append(lines, status.Summary())
+	}
+	return lines
+}
+
+// agentCopyCount returns how many installed copies of an agent are known.
+func (a *App) agentCopyCount(agentID string) int {
+	count := 0
+	for _, ag := range a.agents {
+		if ag.AgentID == agentID {
+			count++
+		}
+	}
+	return count
+}
+
+// makeDefaultCopy points the agent's wrapper shim at this installation
+// so it becomes the copy that runs, without touching the other copies.
+func (a *App) makeDefaultCopy(inst agent.Installation) {
+	mgr := shim.NewManager(a.platform.GetDataDir())
+	if err := mgr.Enable(&inst, a.config.GetPinnedVersion(inst.AgentID)); err != nil {
+		a.platform.ShowNotification("Error", fmt.Sprintf("Failed to set default: %v", err))
+		return
+	}
+	a.platform.ShowNotification(inst.AgentName,
+		fmt.Sprintf("Now defaults to %s via %s", inst.InstalledVersion.String(), inst.Method))
+}
+
 // showMacOSAgentDialog shows an agent details dialog on macOS using osascript.
-func (a *App) showMacOSAgentDialog(inst agent.Installation, details string, hasUpdate bool) {
-	var script string
+// A non-empty loginCmd adds a Sign In button that opens a terminal running it.
+func (a *App) showMacOSAgentDialog(inst agent.Installation, details string, hasUpdate bool, loginCmd string, canSetDefault bool) {
+	buttons := `"Close"`
+	count := 1
+	if loginCmd != "" {
+		buttons += `, "Sign In"`
+		count++
+	}
 	if hasUpdate {
-		// Dialog with Update and Close buttons
-		script = fmt.Sprintf(`
-tell application "System Events"
-	set dialogResult to display dialog %q with title %q buttons {"Close", "Update"} default button "Close" with icon note
-	if button returned of dialogResult is "Update" then
-		return "update"
-	end if
-end tell
-return "close"
-`, details, inst.AgentName)
-	} else {
-		// Dialog with only Close button
-		script = fmt.Sprintf(`
+		buttons += `, "Update"`
+		count++
+	}
+	// display dialog caps out at three buttons, so the switcher yields
+	// when Sign In and Update are both present
+	if canSetDefault && count < 3 {
+		buttons += `, "Make Default"`
+	}
+
+	script := fmt.Sprintf(`
 tell application "System Events"
-	display dialog %q with title %q buttons {"Close"} default button "Close" with icon note
+	set dialogResult to display dialog %q with title %q buttons {%s} default button "Close" with icon note
+	return button returned of dialogResult
 end tell
-return "close"
-`, details, inst.AgentName)
-	}
+`, details, inst.AgentName, buttons)
 
 	cmd := exec.Command("osascript", "-e", script)
 	output, err := cmd.Output()
@@ -794,31 +1514,52 @@ return "close"
 		return
 	}
 
-	result := string(output)
-	if hasUpdate && (result == "update\n" || result == "update") {
+	switch strings.TrimSpace(string(output)) {
+	case "Update":
 		go a.updateSingleAgent(inst)
+	case "Sign In":
+		a.openLoginTerminal(loginCmd)
+	case "Make Default":
+		a.makeDefaultCopy(inst)
 	}
 }
 
-// showLinuxAgentDialog shows an agent details dialog on Linux using zenity or kdialog.
-func (a *App) showLinuxAgentDialog(inst agent.Installation, details string, hasUpdate bool) {
+// showLinuxAgentDialog shows an agent details dialog on Linux using zenity or
+// kdialog. A non-empty loginCmd adds a Sign In button (zenity only) that opens
+// a terminal running it.
+func (a *App) showLinuxAgentDialog(inst agent.Installation, details string, hasUpdate bool, loginCmd string, canSetDefault bool) {
 	// Try zenity first
 	if _, err := exec.LookPath("zenity"); err == nil {
-		var cmd *exec.Cmd
+		var args []string
 		if hasUpdate {
-			cmd = exec.Command("zenity", "--question", //nolint:gosec // G204: agent names from trusted catalog
-				"--title="+inst.AgentName,
-				"--text="+details+"\n\nDo you want to update?",
+			args = []string{"--question",
+				"--title=" + inst.AgentName,
+				"--text=" + details + "\n\nDo you want to update?",
 				"--ok-label=Update",
-				"--cancel-label=Close")
+				"--cancel-label=Close"}
 		} else {
-			cmd = exec.Command("zenity", "--info", //nolint:gosec // G204: agent names from trusted catalog
-				"--title="+inst.AgentName,
-				"--text="+details)
+			args = []string{"--info",
+				"--title=" + inst.AgentName,
+				"--text=" + details}
 		}
-		err := cmd.Run()
-		if hasUpdate && err == nil {
-			go a.updateSingleAgent(inst)
+		if loginCmd != "" {
+			// The extra button's label is printed to stdout when clicked
+			args = append(args, "--extra-button=Sign In")
+		}
+		if canSetDefault {
+			args = append(args, "--extra-button=Make Default")
+		}
+		cmd := exec.Command("zenity", args...) //nolint:gosec // G204: agent names from trusted catalog
+		output, err := cmd.Output()
+		switch strings.TrimSpace(string(output)) {
+		case "Sign In":
+			a.openLoginTerminal(loginCmd)
+		case "Make Default":
+			a.makeDefaultCopy(inst)
+		default:
+			if hasUpdate && err == nil {
+				go a.updateSingleAgent(inst)
+			}
 		}
 		return
 	}
@@ -873,11 +1614,34 @@ Write-Output 'close'
 	}
 }
 
+// broadcastAgentEvent pushes an agent lifecycle notification to IPC
+// clients that subscribed for events (e.g. `agentmgr watch`).
+func (a *App) broadcastAgentEvent(msgType ipc.MessageType, inst agent.Installation, toVersion string) {
+	if a.ipcServer == nil {
+		return
+	}
+	msg, err := ipc.NewMessage(msgType, ipc.AgentEventNotification{
+		AgentID:     inst.AgentID,
+		AgentName:   inst.AgentName,
+		Method:      string(inst.Method),
+		FromVersion: inst.InstalledVersion.String(),
+		ToVersion:   toVersion,
+	})
+	if err != nil {
+		return
+	}
+	a.ipcServer.Broadcast(msg)
+}
+
 // updateSingleAgent updates a single agent installation.
 func (a *App) updateSingleAgent(inst agent.Installation) {
+	if a.notifyIfLocked() {
+		return
+	}
+
 	a.platform.ShowNotification(
-		"Update Started",
-		fmt.Sprintf("Updating %s...", inst.AgentName),
+		i18n.T("notify.update_started.title"),
+		i18n.T("notify.update_started.body", inst.AgentName),
 	)
 
 	ctx, cancel := context.WithTimeout(a.ctx, 10*time.Minute)
@@ -887,7 +1651,7 @@ func (a *App) updateSingleAgent(inst agent.Installation) {
 	agentDef, err := a.catalog.GetAgent(ctx, inst.AgentID)
 	if err != nil {
 		a.platform.ShowNotification(
-			"Update Failed",
+			i18n.T("notify.update_failed.title"),
 			fmt.Sprintf("Failed to find %s in catalog: %v", inst.AgentName, err),
 		)
 		return
@@ -897,7 +1661,7 @@ func (a *App) updateSingleAgent(inst agent.Installation) {
 	methodDef, ok := agentDef.GetInstallMethod(string(inst.Method))
 	if !ok {
 		a.platform.ShowNotification(
-			"Update Failed",
+			i18n.T("notify.update_failed.title"),
 			fmt.Sprintf("Install method %s not available for %s", inst.Method, inst.AgentName),
 		)
 		return
@@ -907,23 +1671,41 @@ func (a *App) updateSingleAgent(inst agent.Installation) {
 	result, err := a.installer.Update(ctx, &inst, *agentDef, methodDef)
 	if err != nil {
 		a.platform.ShowNotification(
-			"Update Failed",
+			i18n.T("notify.update_failed.title"),
 			fmt.Sprintf("Failed to update %s: %v", inst.AgentName, err),
 		)
 		return
 	}
 
 	a.platform.ShowNotification(
-		"Update Complete",
-		fmt.Sprintf("%s updated to %s", inst.AgentName, result.Version.String()),
+		i18n.T("notify.update_complete.title"),
+		i18n.T("notify.update_complete.body", inst.AgentName, result.Version.String()),
 	)
+	a.broadcastAgentEvent(ipc.MessageTypeAgentUpdated, inst, result.Version.String())
 
 	// Refresh agent list
 	a.refreshAgents(ctx)
 }
 
+// notifyIfLocked reports whether lockdown policy is active, showing the
+// administrator message as a notification when it is.
+func (a *App) notifyIfLocked() bool {
+	if !a.config.Policy.Lockdown {
+		return false
+	}
+	a.platform.ShowNotification(
+		i18n.T("notify.lockdown.title"),
+		a.config.LockdownReason(),
+	)
+	return true
+}
+
 // updateAllAgents updates all agents with available updates.
 func (a *App) updateAllAgents(ctx context.Context) {
+	if a.notifyIfLocked() {
+		return
+	}
+
 	a.agentsMu.RLock()
 	var toUpdate []agent.Installation
 	for _, ag := range a.agents {
@@ -937,21 +1719,33 @@ func (a *App) updateAllAgents(ctx context.Context) {
 		return
 	}
 
+	// Preview the plan and ask for confirmation before touching anything
+	if proceed := a.confirmUpdatePlan(ctx, &toUpdate); !proceed {
+		return
+	}
+	if len(toUpdate) == 0 {
+		return
+	}
+
 	a.platform.ShowNotification(
-		"Updating Agents",
-		fmt.Sprintf("Updating %d agents...", len(toUpdate)),
+		i18n.T("notify.updating_agents.title"),
+		i18n.T("notify.updating_agents.body", len(toUpdate)),
 	)
 
-	// Update each agent sequentially
+	// Build the batch; brew-installed agents share a single brew upgrade
+	// run instead of one brew invocation per agent
 	var succeeded, failed int
-	for _, inst := range toUpdate {
-		updateCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
+	updateCtx, cancel := context.WithTimeout(ctx, time.Duration(len(toUpdate))*10*time.Minute)
+	defer cancel()
+
+	var batch []installer.BatchUpdateItem
+	for i := range toUpdate {
+		inst := &toUpdate[i]
 
 		// Get agent definition from catalog
 		agentDef, err := a.catalog.GetAgent(updateCtx, inst.AgentID)
 		if err != nil {
 			failed++
-			cancel()
 			continue
 		}
 
@@ -959,18 +1753,41 @@ func (a *App) updateAllAgents(ctx context.Context) {
 		methodDef, ok := agentDef.GetInstallMethod(string(inst.Method))
 		if !ok {
 			failed++
-			cancel()
 			continue
 		}
 
-		// Perform the update
-		_, err = a.installer.Update(updateCtx, &inst, *agentDef, methodDef)
-		if err != nil {
+		batch = append(batch, installer.BatchUpdateItem{
+			Installation: inst,
+			AgentDef:     *agentDef,
+			Method:       methodDef,
+		})
+	}
+
+	// Perform the updates
+	for i, outcome := range a.installer.UpdateBatch(updateCtx, batch) {
+		inst := batch[i].Installation
+		if outcome.Err != nil {
 			failed++
-		} else {
-			succeeded++
+			continue
+		}
+		succeeded++
+		toVersion := ""
+		if outcome.Result != nil {
+			toVersion = outcome.Result.Version.String()
+		}
+		a.broadcastAgentEvent(ipc.MessageTypeAgentUpdated, *inst, toVersion)
+		if a.digestEnabled() {
+			detail := inst.InstalledVersion.String()
+			if outcome.Result != nil {
+				detail = inst.InstalledVersion.String() + " → " + toVersion
+			}
+			_ = a.digest.Record(updateCtx, digest.Event{
+				Type:      digest.EventAutoUpdated,
+				AgentID:   inst.AgentID,
+				AgentName: inst.AgentName,
+				Detail:    detail,
+			})
 		}
-		cancel()
 	}
 
 	// Show completion notification
@@ -990,6 +1807,61 @@ func (a *App) updateAllAgents(ctx context.Context) {
 	a.refreshAgents(ctx)
 }
 
+// confirmUpdatePlan builds the update plan for the pending updates, drops
+// items blocked by pins, and (when configured) shows the plan in a
+// confirmation dialog. It returns false when the user cancels.
+func (a *App) confirmUpdatePlan(ctx context.Context, toUpdate *[]agent.Installation) bool {
+	agentDefs, err := a.catalog.GetAgentsForPlatform(ctx, string(a.platform.ID()))
+	if err != nil {
+		// The plan is best-effort; fall back to updating everything
+		return true
+	}
+	defMap := make(map[string]catalog.AgentDef, len(agentDefs))
+	for _, def := range agentDefs {
+		defMap[def.ID] = def
+	}
+
+	installations := make([]*agent.Installation, 0, len(*toUpdate))
+	for i := range *toUpdate {
+		installations = append(installations, &(*toUpdate)[i])
+	}
+
+	planner := updater.NewPlanner(a.config, a.catalog, a.installer)
+	plan, err := planner.Plan(ctx, installations, defMap)
+	if err != nil || len(plan.Items) == 0 {
+		return true
+	}
+
+	// Drop installations whose updates the plan marked as blocked
+	keep := make(map[string]bool)
+	for _, item := range plan.Updatable() {
+		keep[item.AgentID+"/"+item.Method] = true
+	}
+	var filtered []agent.Installation
+	for _, inst := range *toUpdate {
+		if keep[inst.AgentID+"/"+string(inst.Method)] {
+			filtered = append(filtered, inst)
+		}
+	}
+	*toUpdate = filtered
+
+	if !a.config.Updates.ConfirmUpdateAll || len(filtered) == 0 {
+		return true
+	}
+
+	var b strings.Builder
+	for _, item := range plan.Items {
+		fmt.Fprintf(&b, "%s: %s → %s", item.AgentName, item.FromVersion, item.ToVersion)
+		if item.Blocked {
+			fmt.Fprintf(&b, " (blocked: %s)", item.BlockedReason)
+		}
+		b.WriteString("\n")
+	}
+
+	result := a.platform.ShowChangelogDialog("Update All", "", "", b.String())
+	return result == platform.DialogResultUpdate
+}
+
 // openTUI launches the TUI application in a new terminal window.
 func (a *App) openTUI() {
 	// Find the agentmgr binary
@@ -999,54 +1871,13 @@ func (a *App) openTUI() {
 		return
 	}
 
-	// Launch TUI based on platform
-	var cmd *exec.Cmd
-	switch a.platform.ID() {
-	case platform.Darwin:
-		// Use osascript to open Terminal with the TUI command
-		script := fmt.Sprintf(`tell application "Terminal"
-			activate
-			do script "%s tui"
-		end tell`, agentmgrPath)
-		cmd = exec.Command("osascript", "-e", script)
-	case platform.Linux:
-		// Try common terminal emulators in order of preference
-		terminals := []struct {
-			name string
-			args []string
-		}{
-			{"gnome-terminal", []string{"--", agentmgrPath, "tui"}},
-			{"konsole", []string{"-e", agentmgrPath, "tui"}},
-			{"xfce4-terminal", []string{"-e", agentmgrPath + " tui"}},
-			{"xterm", []string{"-e", agentmgrPath, "tui"}},
-		}
-		for _, term := range terminals {
-			if _, err := exec.LookPath(term.name); err == nil {
-				cmd = exec.Command(term.name, term.args...) //nolint:gosec // Safe: iterating hardcoded terminal list
-				break
-			}
-		}
-		if cmd == nil {
-			a.platform.ShowNotification("Error", "No supported terminal emulator found")
-			return
-		}
-	case platform.Windows:
-		// Use cmd.exe to open a new window with the TUI
-		cmd = exec.Command("cmd", "/c", "start", "cmd", "/k", agentmgrPath, "tui")
-	default:
-		a.platform.ShowNotification("Error", "Unsupported platform")
+	launcher, err := terminal.Find(a.config.Terminal, string(a.platform.ID()))
+	if err != nil {
+		a.platform.ShowNotification("Error", err.Error())
 		return
 	}
-
-	// Start the command (don't wait for it)
-	if err := cmd.Start(); err != nil {
+	if err := launcher.Open(agentmgrPath + " tui"); err != nil {
 		a.platform.ShowNotification("Error", fmt.Sprintf("Failed to launch TUI: %v", err))
-		return
-	}
-
-	// Release the process so it runs independently
-	if cmd.Process != nil {
-		cmd.Process.Release()
 	}
 }
 
@@ -1096,20 +1927,36 @@ func findAgentMgrBinary() (string, error) {
 func (a *App) toggleAutoStart() {
 	enabled, err := a.platform.IsAutoStartEnabled(a.ctx)
 	if err != nil {
+		a.notifyAutoStartError(err)
 		return
 	}
 
 	if enabled {
-		if err := a.platform.DisableAutoStart(a.ctx); err == nil {
+		if err := a.platform.DisableAutoStart(a.ctx); err != nil {
+			a.notifyAutoStartError(err)
+		} else {
 			a.mAutoStart.Uncheck()
 		}
 	} else {
-		if err := a.platform.EnableAutoStart(a.ctx); err == nil {
+		if err := a.platform.EnableAutoStart(a.ctx); err != nil {
+			a.notifyAutoStartError(err)
+		} else {
 			a.mAutoStart.Check()
 		}
 	}
 }
 
+// notifyAutoStartError surfaces an auto-start failure as a notification,
+// including remediation steps when the platform provides them.
+func (a *App) notifyAutoStartError(err error) {
+	message := err.Error()
+	var asErr *platform.AutoStartError
+	if errors.As(err, &asErr) && asErr.Remediation != "" {
+		message = fmt.Sprintf("%s %s", asErr.Error(), asErr.Remediation)
+	}
+	a.platform.ShowNotification(i18n.T("notify.autostart_failed.title"), message)
+}
+
 // showManageAgentsWindow displays the agent management window.
 func (a *App) showManageAgentsWindow() {
 	if hasNativeWindowSupport() {
@@ -1134,6 +1981,37 @@ func (a *App) showManageAgentsWindow() {
 	a.platform.ShowNotification("Manage Agents", "Use the TUI for full agent management")
 }
 
+// showRecommendationsWindow analyzes the detected environment and
+// displays the prioritized recommendations with their one-click fixes.
+func (a *App) showRecommendationsWindow() {
+	agentDefs, err := a.catalog.GetAgentsForPlatform(a.ctx, string(a.platform.ID()))
+	if err != nil {
+		a.platform.ShowNotification("Error", "Could not load agent catalog")
+		return
+	}
+
+	a.agentsMu.RLock()
+	installed := make([]*agent.Installation, len(a.agents))
+	for i := range a.agents {
+		inst := a.agents[i]
+		installed[i] = &inst
+	}
+	a.agentsMu.RUnlock()
+
+	report := recommend.Analyze(installed, agentDefs, string(a.platform.ID()))
+
+	if hasNativeWindowSupport() {
+		a.showNativeRecommendationsWindow(report, agentDefs)
+		return
+	}
+
+	// Fallback to a notification summary
+	a.platform.ShowNotification(
+		i18n.T("window.recommend.title"),
+		i18n.T("notify.recommend.body", report.Score, len(report.Recommendations)),
+	)
+}
+
 // showSettings displays the settings dialog.
 func (a *App) showSettings() {
 	// Use native windows if available
@@ -1155,6 +2033,36 @@ func (a *App) showSettings() {
 	}
 }
 
+// showLogsWindow opens the log viewer, falling back to opening the raw
+// log file on platforms without native window support.
+func (a *App) showLogsWindow() {
+	if hasNativeWindowSupport() {
+		a.showNativeLogsWindow()
+		return
+	}
+
+	if a.config.Logging.File == "" {
+		a.platform.ShowNotification("Logs", "No log file configured. Set logging.file to enable the log viewer.")
+		return
+	}
+
+	var openCmd string
+	args := []string{a.config.Logging.File}
+	switch a.platform.ID() {
+	case platform.Darwin:
+		openCmd = "open"
+		args = append([]string{"-t"}, args...)
+	case platform.Linux:
+		openCmd = "xdg-open"
+	case platform.Windows:
+		openCmd = "notepad"
+	default:
+		a.platform.ShowNotification("Logs", "Log viewer not available on this platform")
+		return
+	}
+	_ = exec.Command(openCmd, args...).Start()
+}
+
 // showMacOSSettings shows the settings dialog on macOS using osascript.
 func (a *App) showMacOSSettings() {
 	// Find current CLI path
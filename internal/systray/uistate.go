@@ -0,0 +1,57 @@
+package systray
+
+import "encoding/json"
+
+// UI state is persisted through the storage settings API so native windows
+// reopen where the user left them. Entries are namespaced under uiStatePrefix
+// and stored as JSON.
+const uiStatePrefix = "ui_state."
+
+// Setting keys for the individual windows and views.
+const (
+	uiStateSettingsWindow = "settings_window"
+	uiStateManageWindow   = "manage_window"
+	uiStateManageView     = "manage_view"
+)
+
+// windowFrame is a persisted window origin and size in screen coordinates.
+type windowFrame struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// valid reports whether the frame describes a usable window size.
+func (f windowFrame) valid() bool {
+	return f.Width > 0 && f.Height > 0
+}
+
+// manageViewState captures the Manage Agents window's view preferences:
+// sort order, the selected filter chip, and the search text.
+type manageViewState struct {
+	SortKey string `json:"sort_key,omitempty"`
+	SortAsc bool   `json:"sort_asc"`
+	Filter  int    `json:"filter"`
+	Search  string `json:"search,omitempty"`
+}
+
+// loadUIState reads a persisted UI state entry into target, reporting
+// whether anything usable was stored.
+func (a *App) loadUIState(key string, target interface{}) bool {
+	value, err := a.store.GetSetting(a.ctx, uiStatePrefix+key)
+	if err != nil || value == "" {
+		return false
+	}
+	return json.Unmarshal([]byte(value), target) == nil
+}
+
+// saveUIState persists a UI state entry. Failures are ignored: stale or
+// missing window preferences are never worth surfacing to the user.
+func (a *App) saveUIState(key string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_ = a.store.SetSetting(a.ctx, uiStatePrefix+key, string(data))
+}
@@ -3,10 +3,15 @@
 package systray
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/progrium/darwinkit/dispatch"
 	"github.com/progrium/darwinkit/helper/action"
@@ -16,6 +21,13 @@ import (
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/diagnostics"
+	"github.com/kevinelliott/agentmgr/pkg/i18n"
+	"github.com/kevinelliott/agentmgr/pkg/installer"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+	"github.com/kevinelliott/agentmgr/pkg/ipc"
+	"github.com/kevinelliott/agentmgr/pkg/recommend"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
 
 // Apple HIG-compliant design constants
@@ -31,6 +43,10 @@ const (
 	actionInstall   = "install"
 	actionUninstall = "uninstall"
 	actionUpdate    = "update"
+
+	// Virtual key codes for the keys that activate a focused table row
+	keyCodeReturn = 36
+	keyCodeSpace  = 49
 )
 
 var (
@@ -58,7 +74,7 @@ func (a *App) showNativeSettingsWindow() {
 		windowsMu.Unlock()
 
 		windowWidth := 440.0
-		windowHeight := 460.0
+		windowHeight := 500.0
 
 		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
 			foundation.Rect{
@@ -371,7 +387,7 @@ func (a *App) showNativeSettingsWindow() {
 		y -= 22
 
 		// Preferences box
-		prefBoxHeight := 86.0 // Extra bottom padding
+		prefBoxHeight := 210.0 // Extra bottom padding
 		prefBox := appkit.NewBox()
 		prefBox.SetBoxType(appkit.BoxCustom)
 		prefBox.SetCornerRadius(boxRadius)
@@ -423,13 +439,140 @@ func (a *App) showNativeSettingsWindow() {
 		})
 		contentView.AddSubview(helpText)
 
+		// Global hotkey checkbox
+		hotkeyCheck := appkit.NewButton()
+		hotkeyCheck.SetButtonType(appkit.ButtonTypeSwitch)
+		hotkeyCheck.SetTitle(i18n.T("settings.hotkey"))
+		hotkeyCheck.SetFont(appkit.Font_SystemFontOfSize(13))
+		hotkeyCheck.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + boxInnerPadding, Y: prefBoxTop - 88},
+			Size:   foundation.Size{Width: contentWidth - boxInnerPadding*2 - 160, Height: 18},
+		})
+		if app.config.UI.Hotkey.Enabled {
+			hotkeyCheck.SetState(appkit.ControlStateValueOn)
+		} else {
+			hotkeyCheck.SetState(appkit.ControlStateValueOff)
+		}
+		action.Set(hotkeyCheck, func(sender objc.Object) {
+			btn := appkit.ButtonFrom(sender.Ptr())
+			app.config.UI.Hotkey.Enabled = btn.State() == appkit.ControlStateValueOn
+			if app.configLoader != nil {
+				_ = app.configLoader.SetAndSave("ui.hotkey.enabled", app.config.UI.Hotkey.Enabled)
+			}
+			app.reloadGlobalHotkey()
+		})
+		contentView.AddSubview(hotkeyCheck)
+
+		// Shortcut field (press Enter to apply)
+		hotkeyField := appkit.NewTextField()
+		hotkeyField.SetStringValue(app.config.UI.Hotkey.Shortcut)
+		hotkeyField.SetFont(appkit.Font_MonospacedSystemFontOfSizeWeight(12, appkit.FontWeightRegular))
+		hotkeyField.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + contentWidth - boxInnerPadding - 140, Y: prefBoxTop - 90},
+			Size:   foundation.Size{Width: 140, Height: 22},
+		})
+		action.Set(hotkeyField, func(_ objc.Object) {
+			shortcut := strings.TrimSpace(hotkeyField.StringValue())
+			chord, err := parseShortcut(shortcut)
+			if err != nil {
+				// Revert to the last valid shortcut
+				hotkeyField.SetStringValue(app.config.UI.Hotkey.Shortcut)
+				return
+			}
+			app.config.UI.Hotkey.Shortcut = chord.String()
+			hotkeyField.SetStringValue(chord.String())
+			if app.configLoader != nil {
+				_ = app.configLoader.SetAndSave("ui.hotkey.shortcut", app.config.UI.Hotkey.Shortcut)
+			}
+			app.reloadGlobalHotkey()
+		})
+		contentView.AddSubview(hotkeyField)
+
+		// Help text under hotkey row
+		hotkeyHelp := appkit.NewTextField()
+		hotkeyHelp.SetStringValue(i18n.T("settings.hotkey.help"))
+		hotkeyHelp.SetEditable(false)
+		hotkeyHelp.SetBordered(false)
+		hotkeyHelp.SetDrawsBackground(false)
+		hotkeyHelp.SetFont(appkit.Font_SystemFontOfSize(11))
+		hotkeyHelp.SetTextColor(appkit.Color_TertiaryLabelColor())
+		hotkeyHelp.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + boxInnerPadding + 22, Y: prefBoxTop - 122},
+			Size:   foundation.Size{Width: contentWidth - boxInnerPadding*2 - 22, Height: 28},
+		})
+		contentView.AddSubview(hotkeyHelp)
+
+		// Profile selector row
+		profileLabel := appkit.NewTextField()
+		profileLabel.SetStringValue(i18n.T("settings.profile"))
+		profileLabel.SetEditable(false)
+		profileLabel.SetBordered(false)
+		profileLabel.SetDrawsBackground(false)
+		profileLabel.SetFont(appkit.Font_SystemFontOfSize(13))
+		profileLabel.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + boxInnerPadding, Y: prefBoxTop - 158},
+			Size:   foundation.Size{Width: contentWidth - boxInnerPadding*2 - 160, Height: 18},
+		})
+		contentView.AddSubview(profileLabel)
+
+		profilePopup := appkit.NewPopUpButtonWithFramePullsDown(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + contentWidth - boxInnerPadding - 140, Y: prefBoxTop - 162},
+			Size:   foundation.Size{Width: 140, Height: 26},
+		}, false)
+		defaultProfileTitle := i18n.T("settings.profile.default")
+		profilePopup.AddItemWithTitle(defaultProfileTitle)
+		if app.configLoader != nil {
+			profiles, _ := app.configLoader.ListProfiles()
+			for _, name := range profiles {
+				profilePopup.AddItemWithTitle(name)
+			}
+			if current := app.configLoader.Profile(); current != "" {
+				profilePopup.SelectItemWithTitle(current)
+			}
+		}
+		action.Set(profilePopup, func(_ objc.Object) {
+			if app.configLoader == nil {
+				return
+			}
+			selected := profilePopup.TitleOfSelectedItem()
+			if selected == defaultProfileTitle {
+				selected = ""
+			}
+			cfg, err := app.configLoader.SwitchProfile(selected)
+			if err != nil {
+				// Revert to the active profile
+				if current := app.configLoader.Profile(); current != "" {
+					profilePopup.SelectItemWithTitle(current)
+				} else {
+					profilePopup.SelectItemWithTitle(defaultProfileTitle)
+				}
+				return
+			}
+			app.applyConfig(cfg)
+		})
+		contentView.AddSubview(profilePopup)
+
+		// Help text under profile row
+		profileHelp := appkit.NewTextField()
+		profileHelp.SetStringValue(i18n.T("settings.profile.help"))
+		profileHelp.SetEditable(false)
+		profileHelp.SetBordered(false)
+		profileHelp.SetDrawsBackground(false)
+		profileHelp.SetFont(appkit.Font_SystemFontOfSize(11))
+		profileHelp.SetTextColor(appkit.Color_TertiaryLabelColor())
+		profileHelp.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + boxInnerPadding + 22, Y: prefBoxTop - 192},
+			Size:   foundation.Size{Width: contentWidth - boxInnerPadding*2 - 22, Height: 28},
+		})
+		contentView.AddSubview(profileHelp)
+
 		// ═══════════════════════════════════════════════════════════════
 		// FOOTER
 		// ═══════════════════════════════════════════════════════════════
 
 		footerY := windowPadding + 8 // Extra padding above button
 		closeBtn := appkit.NewButton()
-		closeBtn.SetTitle("Done")
+		closeBtn.SetTitle(i18n.T("action.done"))
 		closeBtn.SetBezelStyle(appkit.BezelStyleRounded)
 		closeBtn.SetKeyEquivalent("\r")
 		closeBtn.SetFrame(foundation.Rect{
@@ -437,6 +580,7 @@ func (a *App) showNativeSettingsWindow() {
 			Size:   foundation.Size{Width: 80, Height: 28},
 		})
 		action.Set(closeBtn, func(_ objc.Object) {
+			a.saveUIState(uiStateSettingsWindow, frameOf(win))
 			windowsMu.Lock()
 			settingsWindowOpen = false
 			windowsMu.Unlock()
@@ -444,9 +588,43 @@ func (a *App) showNativeSettingsWindow() {
 		})
 		contentView.AddSubview(closeBtn)
 
+		// Accessibility labels and identifiers
+		installBtn.SetAccessibilityLabel("Install command line tool")
+		installBtn.SetAccessibilityIdentifier("settings-cli-install")
+		uninstallBtn.SetAccessibilityLabel("Uninstall command line tool")
+		uninstallBtn.SetAccessibilityIdentifier("settings-cli-uninstall")
+		notifyCheck.SetAccessibilityLabel("Update Notifications")
+		notifyCheck.SetAccessibilityIdentifier("settings-notifications")
+		hotkeyCheck.SetAccessibilityLabel(i18n.T("settings.hotkey"))
+		hotkeyCheck.SetAccessibilityIdentifier("settings-hotkey")
+		hotkeyField.SetAccessibilityLabel(i18n.T("settings.hotkey.shortcut"))
+		hotkeyField.SetAccessibilityIdentifier("settings-hotkey-shortcut")
+		profilePopup.SetAccessibilityLabel(i18n.T("settings.profile"))
+		profilePopup.SetAccessibilityIdentifier("settings-profile")
+		closeBtn.SetAccessibilityLabel(i18n.T("action.done"))
+		closeBtn.SetAccessibilityIdentifier("settings-done")
+
+		// Explicit tab order: install -> uninstall -> notifications ->
+		// hotkey -> shortcut -> profile -> done -> back to install
+		win.SetAutorecalculatesKeyViewLoop(false)
+		installBtn.SetNextKeyView(uninstallBtn)
+		uninstallBtn.SetNextKeyView(notifyCheck)
+		notifyCheck.SetNextKeyView(hotkeyCheck)
+		hotkeyCheck.SetNextKeyView(hotkeyField)
+		hotkeyField.SetNextKeyView(profilePopup)
+		profilePopup.SetNextKeyView(closeBtn)
+		closeBtn.SetNextKeyView(installBtn)
+		win.SetInitialFirstResponder(installBtn)
+
 		win.SetContentView(contentView)
 		win.Center()
 
+		// Reopen at the last saved position instead of the center default
+		var savedFrame windowFrame
+		if a.loadUIState(uiStateSettingsWindow, &savedFrame) && savedFrame.valid() {
+			win.SetFrameOrigin(foundation.Point{X: savedFrame.X, Y: savedFrame.Y})
+		}
+
 		// Bring to front
 		nsApp := appkit.Application_SharedApplication()
 		nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
@@ -466,6 +644,29 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 	app := a
 	installation := inst
 
+	// Probe requirements before hopping onto the main queue; the version
+	// checks shell out and must not block the UI thread.
+	reqLines := a.requirementStatusLines(inst)
+
+	// Offer "View Install Script" only for methods that pipe a downloaded
+	// script into a shell
+	var scriptAgentDef *catalog.AgentDef
+	var scriptMethod catalog.InstallMethodDef
+	iconPath := ""
+	if agentDef, err := a.catalog.GetAgent(a.ctx, inst.AgentID); err == nil {
+		// Use the real catalog icon when one is available; the download
+		// happens here, off the UI thread
+		iconPath, _ = catalog.NewIconCache(a.platform.GetCacheDir()).Path(a.ctx, *agentDef)
+
+		if methodDef, ok := agentDef.GetInstallMethod(string(inst.Method)); ok {
+			if _, piped := providers.ScriptCommandURL(methodDef.Command); piped {
+				methodDef.Method = string(inst.Method)
+				scriptAgentDef = agentDef
+				scriptMethod = methodDef
+			}
+		}
+	}
+
 	dispatch.MainQueue().DispatchAsync(func() {
 		hasUpdate := installation.HasUpdate()
 
@@ -474,6 +675,7 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 		if hasUpdate {
 			windowHeight = 420.0
 		}
+		windowHeight += float64(len(reqLines)) * (rowHeight + itemGap)
 
 		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
 			foundation.Rect{
@@ -500,36 +702,54 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 		// ═══════════════════════════════════════════════════════════════
 		iconSize := 64.0
 
-		// Agent icon (rounded square)
-		iconView := appkit.NewBox()
-		iconView.SetBoxType(appkit.BoxCustom)
-		iconView.SetCornerRadius(14)
-		iconView.SetFillColor(appkit.Color_ControlAccentColor())
-		iconView.SetBorderWidth(0)
-		iconView.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: windowPadding, Y: y - iconSize},
-			Size:   foundation.Size{Width: iconSize, Height: iconSize},
-		})
-		contentView.AddSubview(iconView)
+		// Agent icon: the cached catalog icon when available, otherwise
+		// the letter placeholder
+		iconShown := false
+		if iconPath != "" {
+			img := appkit.NewImageWithContentsOfFile(iconPath)
+			if img.Ptr() != nil {
+				imageView := appkit.NewImageView()
+				imageView.SetImage(img)
+				imageView.SetImageScaling(appkit.ImageScaleProportionallyUpOrDown)
+				imageView.SetFrame(foundation.Rect{
+					Origin: foundation.Point{X: windowPadding, Y: y - iconSize},
+					Size:   foundation.Size{Width: iconSize, Height: iconSize},
+				})
+				contentView.AddSubview(imageView)
+				iconShown = true
+			}
+		}
+		if !iconShown {
+			// Rounded square with the first letter of the agent name
+			iconView := appkit.NewBox()
+			iconView.SetBoxType(appkit.BoxCustom)
+			iconView.SetCornerRadius(14)
+			iconView.SetFillColor(appkit.Color_ControlAccentColor())
+			iconView.SetBorderWidth(0)
+			iconView.SetFrame(foundation.Rect{
+				Origin: foundation.Point{X: windowPadding, Y: y - iconSize},
+				Size:   foundation.Size{Width: iconSize, Height: iconSize},
+			})
+			contentView.AddSubview(iconView)
 
-		// Icon letter
-		firstLetter := "A"
-		if len(installation.AgentName) > 0 {
-			firstLetter = string(installation.AgentName[0])
+			firstLetter := "A"
+			if len(installation.AgentName) > 0 {
+				firstLetter = string(installation.AgentName[0])
+			}
+			iconLabel := appkit.NewTextField()
+			iconLabel.SetStringValue(firstLetter)
+			iconLabel.SetEditable(false)
+			iconLabel.SetBordered(false)
+			iconLabel.SetDrawsBackground(false)
+			iconLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(28, appkit.FontWeightMedium))
+			iconLabel.SetTextColor(appkit.Color_WhiteColor())
+			iconLabel.SetAlignment(appkit.TextAlignmentCenter)
+			iconLabel.SetFrame(foundation.Rect{
+				Origin: foundation.Point{X: windowPadding, Y: y - iconSize + 18},
+				Size:   foundation.Size{Width: iconSize, Height: 32},
+			})
+			contentView.AddSubview(iconLabel)
 		}
-		iconLabel := appkit.NewTextField()
-		iconLabel.SetStringValue(firstLetter)
-		iconLabel.SetEditable(false)
-		iconLabel.SetBordered(false)
-		iconLabel.SetDrawsBackground(false)
-		iconLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(28, appkit.FontWeightMedium))
-		iconLabel.SetTextColor(appkit.Color_WhiteColor())
-		iconLabel.SetAlignment(appkit.TextAlignmentCenter)
-		iconLabel.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: windowPadding, Y: y - iconSize + 18},
-			Size:   foundation.Size{Width: iconSize, Height: 32},
-		})
-		contentView.AddSubview(iconLabel)
 
 		// Agent name
 		infoX := windowPadding + iconSize + 16
@@ -667,6 +887,7 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 		if installation.ExecutablePath != "" {
 			detailsBoxHeight += pathRowHeight + itemGap
 		}
+		detailsBoxHeight += float64(len(reqLines)) * (rowHeight + itemGap)
 
 		detailsBox := appkit.NewBox()
 		detailsBox.SetBoxType(appkit.BoxCustom)
@@ -747,6 +968,38 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 		})
 		contentView.AddSubview(detValue)
 
+		// Requirement rows
+		for i, line := range reqLines {
+			rowY -= rowHeight + itemGap
+
+			if i == 0 {
+				reqLabel := appkit.NewTextField()
+				reqLabel.SetStringValue("Requires")
+				reqLabel.SetEditable(false)
+				reqLabel.SetBordered(false)
+				reqLabel.SetDrawsBackground(false)
+				reqLabel.SetFont(appkit.Font_SystemFontOfSize(12))
+				reqLabel.SetTextColor(appkit.Color_SecondaryLabelColor())
+				reqLabel.SetFrame(foundation.Rect{
+					Origin: foundation.Point{X: windowPadding + boxInnerPadding, Y: rowY - 16},
+					Size:   foundation.Size{Width: labelWidth, Height: 16},
+				})
+				contentView.AddSubview(reqLabel)
+			}
+
+			reqValue := appkit.NewTextField()
+			reqValue.SetStringValue(line)
+			reqValue.SetEditable(false)
+			reqValue.SetBordered(false)
+			reqValue.SetDrawsBackground(false)
+			reqValue.SetFont(appkit.Font_SystemFontOfSize(12))
+			reqValue.SetFrame(foundation.Rect{
+				Origin: foundation.Point{X: valueX, Y: rowY - 16},
+				Size:   foundation.Size{Width: contentWidth - boxInnerPadding*2 - labelWidth - 8, Height: 16},
+			})
+			contentView.AddSubview(reqValue)
+		}
+
 		// ═══════════════════════════════════════════════════════════════
 		// FOOTER BUTTONS
 		// ═══════════════════════════════════════════════════════════════
@@ -758,13 +1011,49 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 			Origin: foundation.Point{X: windowWidth - windowPadding - 80, Y: windowPadding},
 			Size:   foundation.Size{Width: 80, Height: 28},
 		})
+		closeBtn.SetAccessibilityLabel("Close")
+		closeBtn.SetAccessibilityIdentifier("details-close")
 		action.Set(closeBtn, func(_ objc.Object) {
 			win.Close()
 		})
 		contentView.AddSubview(closeBtn)
 
+		historyX := windowPadding
+		var scriptBtn appkit.Button
+		if scriptAgentDef != nil {
+			scriptBtn = appkit.NewButton()
+			scriptBtn.SetTitle("View Install Script")
+			scriptBtn.SetBezelStyle(appkit.BezelStyleRounded)
+			scriptBtn.SetFrame(foundation.Rect{
+				Origin: foundation.Point{X: windowPadding, Y: windowPadding},
+				Size:   foundation.Size{Width: 150, Height: 28},
+			})
+			scriptBtn.SetAccessibilityLabel(fmt.Sprintf("View install script for %s", installation.AgentName))
+			scriptBtn.SetAccessibilityIdentifier("details-view-script")
+			action.Set(scriptBtn, func(_ objc.Object) {
+				go app.viewInstallScript(*scriptAgentDef, scriptMethod)
+			})
+			contentView.AddSubview(scriptBtn)
+			historyX += 158
+		}
+
+		historyBtn := appkit.NewButton()
+		historyBtn.SetTitle("History")
+		historyBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		historyBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: historyX, Y: windowPadding},
+			Size:   foundation.Size{Width: 80, Height: 28},
+		})
+		historyBtn.SetAccessibilityLabel(fmt.Sprintf("%s history", installation.AgentName))
+		historyBtn.SetAccessibilityIdentifier("details-history")
+		action.Set(historyBtn, func(_ objc.Object) {
+			go app.showNativeAgentHistoryWindow(installation)
+		})
+		contentView.AddSubview(historyBtn)
+
+		var updateBtn appkit.Button
 		if hasUpdate {
-			updateBtn := appkit.NewButton()
+			updateBtn = appkit.NewButton()
 			updateBtn.SetTitle("Update Now")
 			updateBtn.SetBezelStyle(appkit.BezelStyleRounded)
 			updateBtn.SetKeyEquivalent("\r")
@@ -772,6 +1061,8 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 				Origin: foundation.Point{X: windowWidth - windowPadding - 180, Y: windowPadding},
 				Size:   foundation.Size{Width: 95, Height: 28},
 			})
+			updateBtn.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("action.update"), installation.AgentName))
+			updateBtn.SetAccessibilityIdentifier("details-update")
 			action.Set(updateBtn, func(_ objc.Object) {
 				win.Close()
 				go app.updateSingleAgent(installation)
@@ -779,6 +1070,22 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 			contentView.AddSubview(updateBtn)
 		}
 
+		// Explicit tab order over whichever footer buttons are present:
+		// script -> history -> update -> close -> back to the first
+		ordered := []appkit.Button{historyBtn}
+		if scriptAgentDef != nil {
+			ordered = append([]appkit.Button{scriptBtn}, ordered...)
+		}
+		if hasUpdate {
+			ordered = append(ordered, updateBtn)
+		}
+		ordered = append(ordered, closeBtn)
+		win.SetAutorecalculatesKeyViewLoop(false)
+		for i := range ordered {
+			ordered[i].SetNextKeyView(ordered[(i+1)%len(ordered)])
+		}
+		win.SetInitialFirstResponder(ordered[0])
+
 		win.SetContentView(contentView)
 		win.Center()
 
@@ -794,83 +1101,35 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 	})
 }
 
-// closeAllNativeWindows closes all native windows.
-func closeAllNativeWindows() {
-	dispatch.MainQueue().DispatchAsync(func() {
-		windowsMu.Lock()
-		defer windowsMu.Unlock()
-
-		for _, win := range activeWindows {
-			win.Close()
+// showNativeAgentHistoryWindow displays the recorded install, update,
+// and rollback timeline for an agent.
+func (a *App) showNativeAgentHistoryWindow(inst agent.Installation) {
+	// Load history off the UI thread; only rendering happens on the
+	// main queue
+	var text string
+	if a.store == nil {
+		text = "No history available"
+	} else {
+		ctx, cancel := context.WithTimeout(a.ctx, 10*time.Second)
+		events, err := a.store.GetUpdateHistory(ctx, inst.AgentID, 50)
+		cancel()
+		switch {
+		case err != nil:
+			text = fmt.Sprintf("Failed to load history: %v", err)
+		case len(events) == 0:
+			text = "No installs or updates recorded yet"
+		default:
+			text = formatHistoryEvents(events)
 		}
-		activeWindows = nil
-		settingsWindow = appkit.Window{}
-		settingsWindowOpen = false
-	})
-}
-
-// hasNativeWindowSupport returns true if native windows are available.
-func hasNativeWindowSupport() bool {
-	return true
-}
-
-// manageAgentRow tracks a row in the manage agents window
-type manageAgentRow struct {
-	agentDef         catalog.AgentDef
-	installed        bool
-	hasUpdate        bool
-	version          string
-	latestVer        string
-	checkbox         appkit.Button
-	selected         bool
-	statusLabel      appkit.TextField
-	actionBtn        appkit.Button
-	actionPopup      appkit.PopUpButton
-	installedMethods []agent.Installation       // All installed methods for this agent
-	availableMethods []catalog.InstallMethodDef // Available install methods for platform
-}
-
-var (
-	manageWindowOpen bool
-	manageWindow     appkit.Window
-	manageRows       []*manageAgentRow
-)
-
-// showNativeManageAgentsWindow displays the agent management window.
-//
-//nolint:gocyclo // Complex native UI window construction
-func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, installedAgents []agent.Installation) {
-	app := a
-	defs := agentDefs
-	installed := installedAgents
+	}
 
 	dispatch.MainQueue().DispatchAsync(func() {
-		windowsMu.Lock()
-		if manageWindowOpen {
-			// Bring existing window to foreground
-			nsApp := appkit.Application_SharedApplication()
-			nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
-			nsApp.ActivateIgnoringOtherApps(true)
-			manageWindow.MakeKeyAndOrderFront(nil)
-			windowsMu.Unlock()
-			return
-		}
-		windowsMu.Unlock()
-
-		// Calculate minimum width based on content
-		// Checkbox(32) + Name(200) + Description gap + Status(120) + Button(90) + margins
-		minContentWidth := 32.0 + 200.0 + 150.0 + 120.0 + 90.0 + 40.0 // ~632
-		minWindowWidth := minContentWidth + (windowPadding * 2)
-		if minWindowWidth < 650 {
-			minWindowWidth = 650
-		}
-
-		windowWidth := minWindowWidth
-		windowHeight := 500.0
+		windowWidth := 560.0
+		windowHeight := 380.0
 
 		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
 			foundation.Rect{
-				Origin: foundation.Point{X: 150, Y: 150},
+				Origin: foundation.Point{X: 260, Y: 260},
 				Size:   foundation.Size{Width: windowWidth, Height: windowHeight},
 			},
 			appkit.WindowStyleMaskTitled|
@@ -880,432 +1139,1290 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 			appkit.BackingStoreBuffered,
 			false,
 		)
-		win.SetTitle("Manage Agents")
+		win.SetTitle(inst.AgentName + " History")
 		win.SetReleasedWhenClosed(false)
-		win.SetMinSize(foundation.Size{Width: minWindowWidth, Height: 400})
 
 		contentView := appkit.NewView()
 		contentView.SetFrameSize(foundation.Size{Width: windowWidth, Height: windowHeight})
-		contentView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
 
 		contentWidth := windowWidth - (windowPadding * 2)
+		listHeight := windowHeight - windowPadding*2 - 40 // Leave room for footer
 
-		// ═══════════════════════════════════════════════════════════════
-		// HEADER
-		// ═══════════════════════════════════════════════════════════════
-		headerY := windowHeight - windowPadding
-
-		titleLabel := appkit.NewTextField()
-		titleLabel.SetStringValue("Manage Agents")
-		titleLabel.SetEditable(false)
-		titleLabel.SetBordered(false)
-		titleLabel.SetDrawsBackground(false)
-		titleLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(20, appkit.FontWeightSemibold))
-		titleLabel.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: windowPadding, Y: headerY - 28},
-			Size:   foundation.Size{Width: contentWidth, Height: 28},
-		})
-		titleLabel.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewMinYMargin)
-		contentView.AddSubview(titleLabel)
-
-		subtitleLabel := appkit.NewTextField()
-		subtitleLabel.SetStringValue("Install, update, or remove AI development agents")
-		subtitleLabel.SetEditable(false)
-		subtitleLabel.SetBordered(false)
-		subtitleLabel.SetDrawsBackground(false)
-		subtitleLabel.SetFont(appkit.Font_SystemFontOfSize(13))
-		subtitleLabel.SetTextColor(appkit.Color_SecondaryLabelColor())
-		subtitleLabel.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: windowPadding, Y: headerY - 50},
-			Size:   foundation.Size{Width: contentWidth, Height: 18},
-		})
-		subtitleLabel.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewMinYMargin)
-		contentView.AddSubview(subtitleLabel)
-
-		// ═══════════════════════════════════════════════════════════════
-		// AGENT LIST (Scroll View)
-		// ═══════════════════════════════════════════════════════════════
-		listTop := headerY - 70
-		listHeight := windowHeight - 160 // Leave room for header and footer
-		listY := listTop - listHeight
-
-		// Create scroll view
 		scrollView := appkit.NewScrollView()
 		scrollView.SetHasVerticalScroller(true)
-		scrollView.SetHasHorizontalScroller(false)
+		scrollView.SetHasHorizontalScroller(true)
 		scrollView.SetAutohidesScrollers(true)
 		scrollView.SetBorderType(appkit.BezelBorder)
 		scrollView.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: windowPadding, Y: listY},
+			Origin: foundation.Point{X: windowPadding, Y: windowPadding + 40},
 			Size:   foundation.Size{Width: contentWidth, Height: listHeight},
 		})
-		// Scroll view should resize with window
 		scrollView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
 
-		// Create document view to hold rows
-		rowHeight := 60.0
-		actualContentHeight := float64(len(defs)) * rowHeight
-		docViewHeight := actualContentHeight
-
-		docView := appkit.NewView()
-		// Document view width should resize with scroll view
-		docView.SetAutoresizingMask(appkit.ViewWidthSizable)
-
-		// Position document view: if content is smaller than visible area,
-		// place doc view at TOP of scroll view (higher Y origin in non-flipped coords)
-		if actualContentHeight < listHeight {
-			// Push doc view up so content appears at top of visible area
-			docView.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: 0, Y: listHeight - actualContentHeight},
-				Size:   foundation.Size{Width: contentWidth, Height: docViewHeight},
-			})
-		} else {
-			docView.SetFrameSize(foundation.Size{Width: contentWidth, Height: docViewHeight})
-		}
-
-		// Build lookup map for installed agents - track ALL installations per agent
-		installedMap := make(map[string][]agent.Installation)
-		for _, inst := range installed {
-			installedMap[inst.AgentID] = append(installedMap[inst.AgentID], inst)
-		}
-
-		// Get current platform for method filtering
-		platformID := string(app.platform.ID())
+		historyText := appkit.NewTextField()
+		historyText.SetEditable(false)
+		historyText.SetBordered(false)
+		historyText.SetDrawsBackground(false)
+		historyText.SetSelectable(true)
+		historyText.SetFont(appkit.Font_MonospacedSystemFontOfSizeWeight(11, appkit.FontWeightRegular))
+		historyText.SetStringValue(text)
+		historyText.SizeToFit()
+		scrollView.SetDocumentView(historyText)
+		contentView.AddSubview(scrollView)
 
-		// Sort agents alphabetically by name (case-insensitive)
-		sort.Slice(defs, func(i, j int) bool {
-			return strings.ToLower(defs[i].Name) < strings.ToLower(defs[j].Name)
+		closeBtn := appkit.NewButton()
+		closeBtn.SetTitle("Close")
+		closeBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		closeBtn.SetKeyEquivalent("\x1b")
+		closeBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowWidth - windowPadding - 80, Y: windowPadding},
+			Size:   foundation.Size{Width: 80, Height: 28},
+		})
+		closeBtn.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMaxYMargin)
+		action.Set(closeBtn, func(_ objc.Object) {
+			win.Close()
 		})
+		contentView.AddSubview(closeBtn)
 
-		// Create rows for each agent
-		manageRows = make([]*manageAgentRow, 0, len(defs))
-		for i, def := range defs {
-			row := &manageAgentRow{
-				agentDef: def,
-			}
+		win.SetContentView(contentView)
+		win.Center()
 
-			// Get available install methods for this platform
-			row.availableMethods = def.GetSupportedMethods(platformID)
-
-			// Check if installed (may have multiple installations via different methods)
-			if installations, ok := installedMap[def.ID]; ok && len(installations) > 0 {
-				row.installed = true
-				row.installedMethods = installations
-				// Use first installation for version display
-				row.version = installations[0].InstalledVersion.String()
-				if installations[0].LatestVersion != nil {
-					row.latestVer = installations[0].LatestVersion.String()
-				}
-				// Check if any installation has an update
-				for _, inst := range installations {
-					if inst.HasUpdate() {
-						row.hasUpdate = true
-						break
-					}
-				}
-			}
+		nsApp := appkit.Application_SharedApplication()
+		nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
+		nsApp.ActivateIgnoringOtherApps(true)
+		win.MakeKeyAndOrderFront(nil)
 
-			// Position rows from top (high Y) down to bottom (low Y)
-			// In non-flipped coords: high Y = visual top, low Y = visual bottom
-			rowY := docViewHeight - float64(i+1)*rowHeight
-			rowWidth := contentWidth
-
-			// Layout constants for clean alignment
-			rowPadding := 12.0
-			checkboxSize := 18.0
-			textStartX := rowPadding + checkboxSize + 14.0 // After checkbox with gap
-			buttonWidth := 90.0
-			buttonRightMargin := 10.0
-			versionAreaWidth := 150.0 // INSTALLED/CURRENT labels + values
-			buttonX := rowWidth - buttonRightMargin - buttonWidth
-			versionAreaX := buttonX - versionAreaWidth - 16.0 // Increased gap before button
-
-			// Vertical centering: rowHeight=60, we want name+desc (~34px) centered
-			nameY := rowY + 34
-			descY := rowY + 14
-			checkboxY := rowY + (rowHeight-checkboxSize)/2
-
-			// Row background (alternating)
-			if i%2 == 0 {
-				rowBg := appkit.NewBox()
-				rowBg.SetBoxType(appkit.BoxCustom)
-				rowBg.SetFillColor(appkit.Color_QuaternaryLabelColor().ColorWithAlphaComponent(0.05))
-				rowBg.SetBorderWidth(0)
-				rowBg.SetFrame(foundation.Rect{
-					Origin: foundation.Point{X: 0, Y: rowY},
-					Size:   foundation.Size{Width: rowWidth, Height: rowHeight},
-				})
-				rowBg.SetAutoresizingMask(appkit.ViewWidthSizable)
-				docView.AddSubview(rowBg)
-			}
+		windowsMu.Lock()
+		activeWindows = append(activeWindows, win)
+		windowsMu.Unlock()
+	})
+}
 
-			// Checkbox (vertically centered)
-			checkbox := appkit.NewButton()
-			checkbox.SetButtonType(appkit.ButtonTypeSwitch)
-			checkbox.SetTitle("")
-			checkbox.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: rowPadding, Y: checkboxY},
-				Size:   foundation.Size{Width: checkboxSize, Height: checkboxSize},
-			})
-			row.checkbox = checkbox
-			docView.AddSubview(checkbox)
-
-			// Agent name
-			nameLabel := appkit.NewTextField()
-			nameLabel.SetStringValue(def.Name)
-			nameLabel.SetEditable(false)
-			nameLabel.SetBordered(false)
-			nameLabel.SetDrawsBackground(false)
-			nameLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(13, appkit.FontWeightMedium))
-			nameLabel.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: textStartX, Y: nameY},
-				Size:   foundation.Size{Width: versionAreaX - textStartX - 8, Height: 18},
-			})
-			docView.AddSubview(nameLabel)
-
-			// Description
-			descLabel := appkit.NewTextField()
-			descLabel.SetStringValue(def.Description)
-			descLabel.SetEditable(false)
-			descLabel.SetBordered(false)
-			descLabel.SetDrawsBackground(false)
-			descLabel.SetFont(appkit.Font_SystemFontOfSize(11))
-			descLabel.SetTextColor(appkit.Color_SecondaryLabelColor())
-			descLabel.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: textStartX, Y: descY},
-				Size:   foundation.Size{Width: versionAreaX - textStartX - 8, Height: 16},
-			})
-			descLabel.SetAutoresizingMask(appkit.ViewWidthSizable)
-			docView.AddSubview(descLabel)
-
-			// Version status area - two rows with top padding
-			labelColWidth := 58.0
-			valueColWidth := versionAreaWidth - labelColWidth
-			versionTopY := rowY + 30 // Moved down for top padding
-			versionBotY := rowY + 14 // Moved down for top padding
-
-			// INSTALLED label
-			installedLabel := appkit.NewTextField()
-			installedLabel.SetStringValue("INSTALLED")
-			installedLabel.SetEditable(false)
-			installedLabel.SetBordered(false)
-			installedLabel.SetDrawsBackground(false)
-			installedLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(9, appkit.FontWeightMedium))
-			installedLabel.SetTextColor(appkit.Color_TertiaryLabelColor())
-			installedLabel.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: versionAreaX, Y: versionTopY},
-				Size:   foundation.Size{Width: labelColWidth, Height: 12},
-			})
-			installedLabel.SetAutoresizingMask(appkit.ViewMinXMargin)
-			docView.AddSubview(installedLabel)
+// formatHistoryEvents renders update events as fixed-width text rows,
+// newest first, including the error and command output for failures.
+func formatHistoryEvents(events []*storage.UpdateEvent) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-20s %-9s %-20s %-9s %s\n", "STARTED", "KIND", "VERSIONS", "DURATION", "RESULT")
+	for _, event := range events {
+		versions := event.ToVersion
+		if event.FromVersion != "" {
+			versions = event.FromVersion + " → " + event.ToVersion
+		}
+		if versions == "" {
+			versions = "-"
+		}
+		duration := "-"
+		if d := event.Duration(); d > 0 {
+			duration = d.Round(time.Second).String()
+		}
+		result := string(event.Status)
+		if event.Retries > 0 {
+			result = fmt.Sprintf("%s (%d retries)", result, event.Retries)
+		}
+		fmt.Fprintf(&b, "%-20s %-9s %-20s %-9s %s\n",
+			event.StartedAt.Format("2006-01-02 15:04:05"),
+			event.Kind(), versions, duration, result)
 
-			// Installed version value
-			installedVerLabel := appkit.NewTextField()
-			if row.installed {
-				installedVerLabel.SetStringValue(row.version)
-				installedVerLabel.SetTextColor(appkit.Color_LabelColor())
-			} else {
-				installedVerLabel.SetStringValue("None")
-				installedVerLabel.SetTextColor(appkit.Color_TertiaryLabelColor())
+		if event.Status == storage.UpdateStatusFailed {
+			if event.ErrorMessage != "" {
+				fmt.Fprintf(&b, "    error: %s\n", event.ErrorMessage)
 			}
-			installedVerLabel.SetEditable(false)
-			installedVerLabel.SetBordered(false)
-			installedVerLabel.SetDrawsBackground(false)
-			installedVerLabel.SetFont(appkit.Font_SystemFontOfSize(11))
-			installedVerLabel.SetAlignment(appkit.TextAlignmentRight)
-			installedVerLabel.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: versionAreaX + labelColWidth, Y: versionTopY - 1},
-				Size:   foundation.Size{Width: valueColWidth, Height: 14},
-			})
-			installedVerLabel.SetAutoresizingMask(appkit.ViewMinXMargin)
-			docView.AddSubview(installedVerLabel)
-
-			// CURRENT label
-			currentLabel := appkit.NewTextField()
-			currentLabel.SetStringValue("CURRENT")
-			currentLabel.SetEditable(false)
-			currentLabel.SetBordered(false)
-			currentLabel.SetDrawsBackground(false)
-			currentLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(9, appkit.FontWeightMedium))
-			currentLabel.SetTextColor(appkit.Color_TertiaryLabelColor())
-			currentLabel.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: versionAreaX, Y: versionBotY},
-				Size:   foundation.Size{Width: labelColWidth, Height: 12},
-			})
-			currentLabel.SetAutoresizingMask(appkit.ViewMinXMargin)
-			docView.AddSubview(currentLabel)
-
-			// Current version value
-			currentVerLabel := appkit.NewTextField()
-			currentVer := row.latestVer
-			if currentVer == "" {
-				if row.installed {
-					currentVer = row.version
-				} else {
-					currentVer = "—"
+			if event.Output != "" {
+				for _, line := range strings.Split(strings.TrimRight(event.Output, "\n"), "\n") {
+					fmt.Fprintf(&b, "    %s\n", line)
 				}
 			}
-			currentVerLabel.SetStringValue(currentVer)
-			currentVerLabel.SetEditable(false)
-			currentVerLabel.SetBordered(false)
-			currentVerLabel.SetDrawsBackground(false)
-			currentVerLabel.SetFont(appkit.Font_SystemFontOfSize(11))
-			if row.hasUpdate {
-				currentVerLabel.SetTextColor(appkit.Color_SystemGreenColor())
-			} else if row.installed {
-				currentVerLabel.SetTextColor(appkit.Color_LabelColor())
-			} else {
-				currentVerLabel.SetTextColor(appkit.Color_TertiaryLabelColor())
-			}
-			currentVerLabel.SetAlignment(appkit.TextAlignmentRight)
-			currentVerLabel.SetFrame(foundation.Rect{
-				Origin: foundation.Point{X: versionAreaX + labelColWidth, Y: versionBotY - 1},
-				Size:   foundation.Size{Width: valueColWidth, Height: 14},
+		}
+	}
+	return b.String()
+}
+
+// showNativeRecommendationsWindow displays the environment score and the
+// prioritized recommendations, each with a one-click fix button wired to
+// the installer.
+func (a *App) showNativeRecommendationsWindow(report recommend.Report, defs []catalog.AgentDef) {
+	app := a
+	defMap := make(map[string]catalog.AgentDef, len(defs))
+	for _, def := range defs {
+		defMap[def.ID] = def
+	}
+
+	dispatch.MainQueue().DispatchAsync(func() {
+		windowWidth := 620.0
+		windowHeight := 440.0
+
+		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
+			foundation.Rect{
+				Origin: foundation.Point{X: 220, Y: 220},
+				Size:   foundation.Size{Width: windowWidth, Height: windowHeight},
+			},
+			appkit.WindowStyleMaskTitled|
+				appkit.WindowStyleMaskClosable|
+				appkit.WindowStyleMaskMiniaturizable|
+				appkit.WindowStyleMaskResizable,
+			appkit.BackingStoreBuffered,
+			false,
+		)
+		win.SetTitle(i18n.T("window.recommend.title"))
+		win.SetReleasedWhenClosed(false)
+
+		contentView := appkit.NewView()
+		contentView.SetFrameSize(foundation.Size{Width: windowWidth, Height: windowHeight})
+		contentView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
+
+		contentWidth := windowWidth - (windowPadding * 2)
+
+		// Score header, colored by grade
+		scoreLabel := appkit.NewTextField()
+		scoreLabel.SetStringValue(fmt.Sprintf(i18n.T("window.recommend.score"), report.Score))
+		scoreLabel.SetEditable(false)
+		scoreLabel.SetBordered(false)
+		scoreLabel.SetDrawsBackground(false)
+		scoreLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(16, appkit.FontWeightSemibold))
+		switch {
+		case report.Score >= 80:
+			scoreLabel.SetTextColor(appkit.Color_SystemGreenColor())
+		case report.Score >= 50:
+			scoreLabel.SetTextColor(appkit.Color_SystemOrangeColor())
+		default:
+			scoreLabel.SetTextColor(appkit.Color_SystemRedColor())
+		}
+		scoreLabel.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: windowHeight - windowPadding - 24},
+			Size:   foundation.Size{Width: contentWidth, Height: 24},
+		})
+		scoreLabel.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewMinYMargin)
+		contentView.AddSubview(scoreLabel)
+
+		listTop := windowHeight - windowPadding - 36
+		listHeight := listTop - windowPadding - 40 // Leave room for footer
+
+		if len(report.Recommendations) == 0 {
+			emptyLabel := appkit.NewTextField()
+			emptyLabel.SetStringValue(i18n.T("window.recommend.none"))
+			emptyLabel.SetEditable(false)
+			emptyLabel.SetBordered(false)
+			emptyLabel.SetDrawsBackground(false)
+			emptyLabel.SetFont(appkit.Font_SystemFontOfSize(13))
+			emptyLabel.SetTextColor(appkit.Color_SecondaryLabelColor())
+			emptyLabel.SetFrame(foundation.Rect{
+				Origin: foundation.Point{X: windowPadding, Y: listTop - 24},
+				Size:   foundation.Size{Width: contentWidth, Height: 18},
+			})
+			emptyLabel.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewMinYMargin)
+			contentView.AddSubview(emptyLabel)
+		} else {
+			scrollView := appkit.NewScrollView()
+			scrollView.SetHasVerticalScroller(true)
+			scrollView.SetHasHorizontalScroller(false)
+			scrollView.SetAutohidesScrollers(true)
+			scrollView.SetBorderType(appkit.BezelBorder)
+			scrollView.SetFrame(foundation.Rect{
+				Origin: foundation.Point{X: windowPadding, Y: windowPadding + 40},
+				Size:   foundation.Size{Width: contentWidth, Height: listHeight},
+			})
+			scrollView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
+
+			tableView := appkit.NewTableView()
+			tableView.SetUsesAlternatingRowBackgroundColors(true)
+			tableView.SetRowHeight(28)
+			tableView.SetAllowsEmptySelection(true)
+			tableView.SetColumnAutoresizingStyle(appkit.TableViewUniformColumnAutoresizingStyle)
+			tableView.SetAccessibilityLabel(i18n.T("window.recommend.title"))
+			tableView.SetAccessibilityIdentifier("recommend-table")
+
+			addColumn := func(id, title string, width float64) {
+				col := appkit.NewTableColumn()
+				col.SetIdentifier(appkit.UserInterfaceItemIdentifier(id))
+				col.SetTitle(title)
+				col.SetWidth(width)
+				col.SetMinWidth(width / 2)
+				tableView.AddTableColumn(col)
+			}
+			addColumn("severity", "", 64)
+			addColumn("recommendation", i18n.T("window.recommend.title"), 380)
+			addColumn("fix", "", 96)
+
+			recs := report.Recommendations
+
+			dataSource := &appkit.TableViewDataSource{}
+			dataSource.SetNumberOfRowsInTableView(func(_ appkit.TableView) int {
+				return len(recs)
+			})
+			recommendDataSource = dataSource
+			tableView.SetDataSource(dataSource)
+
+			delegate := &appkit.TableViewDelegate{}
+			delegate.SetTableViewViewForTableColumnRow(func(_ appkit.TableView, tableColumn appkit.TableColumn, rowIdx int) appkit.View {
+				if rowIdx < 0 || rowIdx >= len(recs) {
+					return appkit.NewView()
+				}
+				rec := recs[rowIdx]
+
+				switch string(tableColumn.Identifier()) {
+				case "severity":
+					label := appkit.NewTextField()
+					label.SetStringValue("● " + string(rec.Severity))
+					label.SetEditable(false)
+					label.SetBordered(false)
+					label.SetDrawsBackground(false)
+					label.SetFont(appkit.Font_SystemFontOfSize(11))
+					switch rec.Severity {
+					case recommend.SeverityHigh:
+						label.SetTextColor(appkit.Color_SystemRedColor())
+					case recommend.SeverityMedium:
+						label.SetTextColor(appkit.Color_SystemOrangeColor())
+					case recommend.SeverityLow:
+						label.SetTextColor(appkit.Color_SystemYellowColor())
+					default:
+						label.SetTextColor(appkit.Color_SecondaryLabelColor())
+					}
+					return label.View
+
+				case "recommendation":
+					label := appkit.NewTextField()
+					label.SetStringValue(rec.Title)
+					label.SetEditable(false)
+					label.SetBordered(false)
+					label.SetDrawsBackground(false)
+					label.SetFont(appkit.Font_SystemFontOfSize(12))
+					label.SetToolTip(rec.Detail)
+					return label.View
+
+				case "fix":
+					if rec.Action == nil {
+						return appkit.NewView()
+					}
+					def, ok := defMap[rec.Action.AgentID]
+					if !ok {
+						return appkit.NewView()
+					}
+					fixBtn := appkit.NewButton()
+					fixBtn.SetBezelStyle(appkit.BezelStyleRounded)
+					fixBtn.SetControlSize(appkit.ControlSizeSmall)
+					switch rec.Action.Type {
+					case recommend.ActionInstall:
+						fixBtn.SetTitle(i18n.T("action.install"))
+					case recommend.ActionUpdate:
+						fixBtn.SetTitle(i18n.T("action.update"))
+					case recommend.ActionUninstall:
+						fixBtn.SetTitle(i18n.T("action.uninstall"))
+					default:
+						fixBtn.SetTitle(i18n.T("action.fix"))
+					}
+					fixBtn.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("action.fix"), def.Name))
+					currentRec := rec
+					action.Set(fixBtn, func(_ objc.Object) {
+						go app.performRecommendationFix(currentRec, def, win)
+					})
+					return fixBtn.View
+
+				default:
+					return appkit.NewView()
+				}
+			})
+			recommendDelegate = delegate
+			tableView.SetDelegate(delegate)
+
+			scrollView.SetDocumentView(tableView)
+			contentView.AddSubview(scrollView)
+		}
+
+		closeBtn := appkit.NewButton()
+		closeBtn.SetTitle(i18n.T("action.done"))
+		closeBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		closeBtn.SetKeyEquivalent("\x1b")
+		closeBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowWidth - windowPadding - 80, Y: windowPadding},
+			Size:   foundation.Size{Width: 80, Height: 28},
+		})
+		closeBtn.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMaxYMargin)
+		action.Set(closeBtn, func(_ objc.Object) {
+			win.Close()
+		})
+		contentView.AddSubview(closeBtn)
+
+		win.SetContentView(contentView)
+		win.Center()
+
+		nsApp := appkit.Application_SharedApplication()
+		nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
+		nsApp.ActivateIgnoringOtherApps(true)
+		win.MakeKeyAndOrderFront(nil)
+
+		windowsMu.Lock()
+		activeWindows = append(activeWindows, win)
+		windowsMu.Unlock()
+	})
+}
+
+// performRecommendationFix applies a recommendation's one-click fix
+// through the installer. Migrations install the replacement method and
+// then remove the curl install it supersedes.
+func (a *App) performRecommendationFix(rec recommend.Recommendation, def catalog.AgentDef, parentWin appkit.Window) {
+	if rec.Action == nil {
+		return
+	}
+
+	a.showProgressWindow(fmt.Sprintf("Fixing: %s...", rec.Title), parentWin)
+	ctx, finish := a.beginProgressOperation()
+	defer finish()
+
+	var err error
+	switch rec.Action.Type {
+	case recommend.ActionInstall:
+		_, err = a.installAgentWithMethod(ctx, def, rec.Action.Method)
+	case recommend.ActionUpdate:
+		_, err = a.updateAgentByID(ctx, def.ID)
+	case recommend.ActionUninstall:
+		_, err = a.uninstallAgentWithMethod(ctx, def, rec.Action.Method)
+	case recommend.ActionMigrate:
+		var installed bool
+		installed, err = a.installAgentWithMethod(ctx, def, rec.Action.Method)
+		if installed && err == nil {
+			_, err = a.uninstallAgentWithMethod(ctx, def, string(agent.InstallMethodCurl))
+		}
+	}
+	closeProgressWindow()
+
+	if err != nil {
+		a.platform.ShowNotification(def.Name, err.Error())
+	}
+
+	// Refresh agent list so the tray reflects the fix
+	a.refreshAgents(a.ctx)
+}
+
+// viewInstallScript downloads (or refreshes) the cached copy of an
+// agent's install script and opens it in the default text editor for
+// review.
+func (a *App) viewInstallScript(def catalog.AgentDef, method catalog.InstallMethodDef) {
+	ctx, cancel := context.WithTimeout(a.ctx, 30*time.Second)
+	defer cancel()
+
+	audit, err := a.installer.FetchInstallScript(ctx, def, method)
+	if err != nil {
+		a.platform.ShowNotification(def.Name, fmt.Sprintf("Failed to fetch install script: %v", err))
+		return
+	}
+
+	if err := exec.Command("open", "-t", audit.Path).Run(); err != nil {
+		a.platform.ShowNotification(def.Name, fmt.Sprintf("Failed to open install script: %v", err))
+	}
+}
+
+var (
+	logsWindow     appkit.Window
+	logsWindowOpen bool
+)
+
+// showNativeLogsWindow displays a native macOS log viewer with level
+// filtering, search, and a button that saves a diagnostics bundle.
+func (a *App) showNativeLogsWindow() {
+	app := a
+
+	dispatch.MainQueue().DispatchAsync(func() {
+		windowsMu.Lock()
+		if logsWindowOpen {
+			nsApp := appkit.Application_SharedApplication()
+			nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
+			nsApp.ActivateIgnoringOtherApps(true)
+			logsWindow.MakeKeyAndOrderFront(nil)
+			windowsMu.Unlock()
+			return
+		}
+		windowsMu.Unlock()
+
+		windowWidth := 680.0
+		windowHeight := 480.0
+
+		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
+			foundation.Rect{
+				Origin: foundation.Point{X: 220, Y: 220},
+				Size:   foundation.Size{Width: windowWidth, Height: windowHeight},
+			},
+			appkit.WindowStyleMaskTitled|
+				appkit.WindowStyleMaskClosable|
+				appkit.WindowStyleMaskMiniaturizable|
+				appkit.WindowStyleMaskResizable,
+			appkit.BackingStoreBuffered,
+			false,
+		)
+		win.SetTitle("Logs")
+		win.SetReleasedWhenClosed(false)
+
+		contentView := appkit.NewView()
+		contentView.SetFrameSize(foundation.Size{Width: windowWidth, Height: windowHeight})
+
+		contentWidth := windowWidth - (windowPadding * 2)
+		toolbarY := windowHeight - windowPadding - 24
+
+		// ═══════════════════════════════════════════════════════════════
+		// SEARCH & LEVEL FILTER TOOLBAR
+		// ═══════════════════════════════════════════════════════════════
+
+		searchField := appkit.NewSearchField()
+		searchField.SetPlaceholderString(i18n.T("window.logs.search"))
+		searchField.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: toolbarY},
+			Size:   foundation.Size{Width: 220, Height: 24},
+		})
+		searchField.SetAutoresizingMask(appkit.ViewMaxXMargin | appkit.ViewMinYMargin)
+		searchField.SetAccessibilityLabel(i18n.T("window.logs.search"))
+		searchField.SetAccessibilityIdentifier("logs-search")
+		contentView.AddSubview(searchField)
+
+		// Level chips: All / Info / Warnings / Errors
+		levels := []string{"all", "info", "warn", "error"}
+		levelControl := appkit.NewSegmentedControl()
+		levelControl.SetSegmentCount(4)
+		levelControl.SetLabelForSegment(i18n.T("window.logs.level.all"), 0)
+		levelControl.SetLabelForSegment(i18n.T("window.logs.level.info"), 1)
+		levelControl.SetLabelForSegment(i18n.T("window.logs.level.warn"), 2)
+		levelControl.SetLabelForSegment(i18n.T("window.logs.level.error"), 3)
+		levelControl.SetSelectedSegment(0)
+		levelControl.SetControlSize(appkit.ControlSizeSmall)
+		levelControl.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + 236, Y: toolbarY + 2},
+			Size:   foundation.Size{Width: 280, Height: 22},
+		})
+		levelControl.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMinYMargin)
+		levelControl.SetAccessibilityLabel(i18n.T("window.logs.level.all"))
+		levelControl.SetAccessibilityIdentifier("logs-level")
+		contentView.AddSubview(levelControl)
+
+		// ═══════════════════════════════════════════════════════════════
+		// LOG TAIL (Scroll View)
+		// ═══════════════════════════════════════════════════════════════
+		listTop := toolbarY - 12
+		listHeight := listTop - 70 // Leave room for footer
+		listY := listTop - listHeight
+
+		scrollView := appkit.NewScrollView()
+		scrollView.SetHasVerticalScroller(true)
+		scrollView.SetHasHorizontalScroller(true)
+		scrollView.SetAutohidesScrollers(true)
+		scrollView.SetBorderType(appkit.BezelBorder)
+		scrollView.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: listY},
+			Size:   foundation.Size{Width: contentWidth, Height: listHeight},
+		})
+		scrollView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
+
+		logText := appkit.NewTextField()
+		logText.SetEditable(false)
+		logText.SetBordered(false)
+		logText.SetDrawsBackground(false)
+		logText.SetSelectable(true)
+		logText.SetFont(appkit.Font_MonospacedSystemFontOfSizeWeight(11, appkit.FontWeightRegular))
+		scrollView.SetDocumentView(logText)
+		contentView.AddSubview(scrollView)
+
+		// reload re-reads the log tail and applies the current filters.
+		reload := func() {
+			if app.config.Logging.File == "" {
+				logText.SetStringValue(i18n.T("window.logs.no_file"))
+				return
+			}
+			tail, err := diagnostics.ReadLogTail(app.config.Logging.File, 512<<10)
+			if err != nil {
+				logText.SetStringValue(fmt.Sprintf("Failed to read log file: %v", err))
+				return
+			}
+
+			level := "all"
+			if idx := levelControl.SelectedSegment(); idx >= 0 && int(idx) < len(levels) {
+				level = levels[idx]
+			}
+			lines := diagnostics.FilterLines(
+				strings.Split(strings.TrimRight(string(tail), "\n"), "\n"),
+				level,
+				searchField.StringValue(),
+			)
+
+			text := strings.Join(lines, "\n")
+			if text == "" {
+				text = i18n.T("window.logs.empty")
+			}
+			logText.SetStringValue(text)
+			logText.SizeToFit()
+		}
+		reload()
+
+		action.Set(searchField, func(_ objc.Object) { reload() })
+		action.Set(levelControl, func(_ objc.Object) { reload() })
+
+		// ═══════════════════════════════════════════════════════════════
+		// FOOTER BUTTONS
+		// ═══════════════════════════════════════════════════════════════
+
+		diagBtn := appkit.NewButton()
+		diagBtn.SetTitle(i18n.T("window.logs.copy_diagnostics"))
+		diagBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		diagBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: windowPadding},
+			Size:   foundation.Size{Width: 160, Height: 28},
+		})
+		diagBtn.SetAutoresizingMask(appkit.ViewMaxXMargin | appkit.ViewMaxYMargin)
+		action.Set(diagBtn, func(_ objc.Object) {
+			go app.saveDiagnosticsBundle()
+		})
+		contentView.AddSubview(diagBtn)
+
+		refreshBtn := appkit.NewButton()
+		refreshBtn.SetTitle(i18n.T("menu.refresh"))
+		refreshBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		refreshBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowWidth - windowPadding - 180, Y: windowPadding},
+			Size:   foundation.Size{Width: 90, Height: 28},
+		})
+		refreshBtn.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMaxYMargin)
+		action.Set(refreshBtn, func(_ objc.Object) { reload() })
+		contentView.AddSubview(refreshBtn)
+
+		closeBtn := appkit.NewButton()
+		closeBtn.SetTitle("Close")
+		closeBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		closeBtn.SetKeyEquivalent("\x1b")
+		closeBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowWidth - windowPadding - 80, Y: windowPadding},
+			Size:   foundation.Size{Width: 80, Height: 28},
+		})
+		closeBtn.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMaxYMargin)
+		action.Set(closeBtn, func(_ objc.Object) {
+			win.Close()
+			windowsMu.Lock()
+			logsWindowOpen = false
+			windowsMu.Unlock()
+		})
+		contentView.AddSubview(closeBtn)
+
+		win.SetContentView(contentView)
+		win.Center()
+
+		nsApp := appkit.Application_SharedApplication()
+		nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
+		nsApp.ActivateIgnoringOtherApps(true)
+		win.MakeKeyAndOrderFront(nil)
+
+		windowsMu.Lock()
+		logsWindow = win
+		logsWindowOpen = true
+		activeWindows = append(activeWindows, win)
+		windowsMu.Unlock()
+	})
+}
+
+// saveDiagnosticsBundle writes a support bundle (logs, redacted config,
+// status) to the Downloads folder and reveals it in Finder.
+func (a *App) saveDiagnosticsBundle() {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		a.platform.ShowNotification("Diagnostics", fmt.Sprintf("Failed to write bundle: %v", err))
+		return
+	}
+
+	path := filepath.Join(home, "Downloads",
+		fmt.Sprintf("agentmgr-diagnostics-%s.zip", time.Now().Format("20060102-150405")))
+
+	f, err := os.Create(path)
+	if err != nil {
+		a.platform.ShowNotification("Diagnostics", fmt.Sprintf("Failed to write bundle: %v", err))
+		return
+	}
+	if err := diagnostics.WriteBundle(a.config, a.platform, a.version, f); err != nil {
+		f.Close()
+		os.Remove(path)
+		a.platform.ShowNotification("Diagnostics", fmt.Sprintf("Failed to write bundle: %v", err))
+		return
+	}
+	if err := f.Close(); err != nil {
+		a.platform.ShowNotification("Diagnostics", fmt.Sprintf("Failed to write bundle: %v", err))
+		return
+	}
+
+	a.platform.ShowNotification("Diagnostics", fmt.Sprintf("Bundle saved to %s", path))
+	//nolint:errcheck // best-effort reveal; the notification already names the path
+	_ = exec.Command("open", "-R", path).Run()
+}
+
+// closeAllNativeWindows closes all native windows.
+// frameOf converts a window's current frame into its persisted form.
+func frameOf(win appkit.Window) windowFrame {
+	frame := win.Frame()
+	return windowFrame{
+		X:      frame.Origin.X,
+		Y:      frame.Origin.Y,
+		Width:  frame.Size.Width,
+		Height: frame.Size.Height,
+	}
+}
+
+func closeAllNativeWindows() {
+	dispatch.MainQueue().DispatchAsync(func() {
+		windowsMu.Lock()
+		defer windowsMu.Unlock()
+
+		for _, win := range activeWindows {
+			win.Close()
+		}
+		activeWindows = nil
+		settingsWindow = appkit.Window{}
+		settingsWindowOpen = false
+		logsWindow = appkit.Window{}
+		logsWindowOpen = false
+		if manageKeyMonitor.Ptr() != nil {
+			appkit.Event_RemoveMonitor(manageKeyMonitor)
+			manageKeyMonitor = objc.Object{}
+		}
+	})
+}
+
+// hasNativeWindowSupport returns true if native windows are available.
+func hasNativeWindowSupport() bool {
+	return true
+}
+
+// manageAgentRow tracks a row in the manage agents window
+type manageAgentRow struct {
+	agentDef         catalog.AgentDef
+	installed        bool
+	hasUpdate        bool
+	version          string
+	latestVer        string
+	selected         bool
+	favorite         bool
+	hidden           bool
+	sizeBytes        int64
+	installedMethods []agent.Installation       // All installed methods for this agent
+	availableMethods []catalog.InstallMethodDef // Available install methods for platform
+}
+
+// methodString returns the install method(s) for display.
+func (r *manageAgentRow) methodString() string {
+	if len(r.installedMethods) == 0 {
+		return "—"
+	}
+	methods := make([]string, len(r.installedMethods))
+	for i, inst := range r.installedMethods {
+		methods[i] = string(inst.Method)
+	}
+	return strings.Join(methods, ", ")
+}
+
+var (
+	manageWindowOpen bool
+	manageWindow     appkit.Window
+	manageRows       []*manageAgentRow
+	manageTableView  appkit.TableView
+	manageKeyMonitor objc.Object
+	manageSortKey    = "category"
+	manageSortAsc    = true
+
+	// Retain the table data source and delegate for the window's lifetime
+	manageDataSource *appkit.TableViewDataSource
+	manageDelegate   *appkit.TableViewDelegate
+)
+
+var (
+	// Retained for the recommendations window's lifetime
+	recommendDataSource *appkit.TableViewDataSource
+	recommendDelegate   *appkit.TableViewDelegate
+)
+
+// formatSize renders a byte count for the Size column.
+func formatSize(bytes int64) string {
+	switch {
+	case bytes <= 0:
+		return "—"
+	case bytes < 1024:
+		return fmt.Sprintf("%d B", bytes)
+	case bytes < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/1024)
+	case bytes < 1024*1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1024*1024))
+	default:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1024*1024*1024))
+	}
+}
+
+// reloadManageTable refreshes the table on the main queue.
+func reloadManageTable() {
+	dispatch.MainQueue().DispatchAsync(func() {
+		if manageWindowOpen && manageTableView.Ptr() != nil {
+			manageTableView.ReloadData()
+		}
+	})
+}
+
+// showNativeManageAgentsWindow displays the agent management window.
+//
+//nolint:gocyclo // Complex native UI window construction
+func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, installedAgents []agent.Installation) {
+	app := a
+	defs := agentDefs
+	installed := installedAgents
+
+	// Load stored preferences off the UI thread; the toggle handlers
+	// mutate these sets and persist changes in the background.
+	userPrefs := a.prefs.Get(a.ctx)
+	favSet := make(map[string]bool, len(userPrefs.Favorites))
+	for _, id := range userPrefs.Favorites {
+		favSet[id] = true
+	}
+	hiddenSet := make(map[string]bool, len(userPrefs.Hidden))
+	for _, id := range userPrefs.Hidden {
+		hiddenSet[id] = true
+	}
+
+	dispatch.MainQueue().DispatchAsync(func() {
+		windowsMu.Lock()
+		if manageWindowOpen {
+			// Bring existing window to foreground
+			nsApp := appkit.Application_SharedApplication()
+			nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
+			nsApp.ActivateIgnoringOtherApps(true)
+			manageWindow.MakeKeyAndOrderFront(nil)
+			windowsMu.Unlock()
+			return
+		}
+		windowsMu.Unlock()
+
+		// Calculate minimum width based on content
+		// Checkbox(32) + Name(200) + Description gap + Status(120) + Button(90) + margins
+		minContentWidth := 32.0 + 200.0 + 150.0 + 120.0 + 90.0 + 40.0 // ~632
+		minWindowWidth := minContentWidth + (windowPadding * 2)
+		if minWindowWidth < 650 {
+			minWindowWidth = 650
+		}
+
+		windowWidth := minWindowWidth
+		windowHeight := 500.0
+
+		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
+			foundation.Rect{
+				Origin: foundation.Point{X: 150, Y: 150},
+				Size:   foundation.Size{Width: windowWidth, Height: windowHeight},
+			},
+			appkit.WindowStyleMaskTitled|
+				appkit.WindowStyleMaskClosable|
+				appkit.WindowStyleMaskMiniaturizable|
+				appkit.WindowStyleMaskResizable,
+			appkit.BackingStoreBuffered,
+			false,
+		)
+		win.SetTitle(i18n.T("window.manage.title"))
+		win.SetReleasedWhenClosed(false)
+		win.SetMinSize(foundation.Size{Width: minWindowWidth, Height: 400})
+
+		contentView := appkit.NewView()
+		contentView.SetFrameSize(foundation.Size{Width: windowWidth, Height: windowHeight})
+		contentView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
+
+		contentWidth := windowWidth - (windowPadding * 2)
+
+		// ═══════════════════════════════════════════════════════════════
+		// HEADER
+		// ═══════════════════════════════════════════════════════════════
+		headerY := windowHeight - windowPadding
+
+		titleLabel := appkit.NewTextField()
+		titleLabel.SetStringValue(i18n.T("window.manage.title"))
+		titleLabel.SetEditable(false)
+		titleLabel.SetBordered(false)
+		titleLabel.SetDrawsBackground(false)
+		titleLabel.SetFont(appkit.Font_SystemFontOfSizeWeight(20, appkit.FontWeightSemibold))
+		titleLabel.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: headerY - 28},
+			Size:   foundation.Size{Width: contentWidth, Height: 28},
+		})
+		titleLabel.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewMinYMargin)
+		contentView.AddSubview(titleLabel)
+
+		subtitleLabel := appkit.NewTextField()
+		subtitleLabel.SetStringValue(i18n.T("window.manage.subtitle"))
+		subtitleLabel.SetEditable(false)
+		subtitleLabel.SetBordered(false)
+		subtitleLabel.SetDrawsBackground(false)
+		subtitleLabel.SetFont(appkit.Font_SystemFontOfSize(13))
+		subtitleLabel.SetTextColor(appkit.Color_SecondaryLabelColor())
+		subtitleLabel.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: headerY - 50},
+			Size:   foundation.Size{Width: contentWidth, Height: 18},
+		})
+		subtitleLabel.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewMinYMargin)
+		contentView.AddSubview(subtitleLabel)
+
+		// ═══════════════════════════════════════════════════════════════
+		// SEARCH & FILTER TOOLBAR
+		// ═══════════════════════════════════════════════════════════════
+		toolbarY := headerY - 88
+
+		searchField := appkit.NewSearchField()
+		searchField.SetPlaceholderString(i18n.T("window.manage.search"))
+		searchField.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: toolbarY},
+			Size:   foundation.Size{Width: 220, Height: 24},
+		})
+		searchField.SetAutoresizingMask(appkit.ViewMaxXMargin | appkit.ViewMinYMargin)
+		searchField.SetAccessibilityLabel(i18n.T("window.manage.search"))
+		searchField.SetAccessibilityIdentifier("manage-search")
+		contentView.AddSubview(searchField)
+
+		// Filter chips: All / Installed / Updates / Not Installed
+		filterControl := appkit.NewSegmentedControl()
+		filterControl.SetSegmentCount(4)
+		filterControl.SetLabelForSegment(i18n.T("window.manage.filter.all"), 0)
+		filterControl.SetLabelForSegment(i18n.T("window.manage.filter.installed"), 1)
+		filterControl.SetLabelForSegment(i18n.T("window.manage.filter.updates"), 2)
+		filterControl.SetLabelForSegment(i18n.T("window.manage.filter.not_installed"), 3)
+		filterControl.SetSelectedSegment(0)
+		filterControl.SetControlSize(appkit.ControlSizeSmall)
+		filterControl.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding + 236, Y: toolbarY + 2},
+			Size:   foundation.Size{Width: 320, Height: 22},
+		})
+		filterControl.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMinYMargin)
+		filterControl.SetAccessibilityLabel(i18n.T("window.manage.filter.all"))
+		filterControl.SetAccessibilityIdentifier("manage-filter")
+		contentView.AddSubview(filterControl)
+
+		// ═══════════════════════════════════════════════════════════════
+		// AGENT LIST (Scroll View)
+		// ═══════════════════════════════════════════════════════════════
+		listTop := toolbarY - 12
+		listHeight := listTop - 70 // Leave room for footer
+		listY := listTop - listHeight
+
+		// Create scroll view
+		scrollView := appkit.NewScrollView()
+		scrollView.SetHasVerticalScroller(true)
+		scrollView.SetHasHorizontalScroller(false)
+		scrollView.SetAutohidesScrollers(true)
+		scrollView.SetBorderType(appkit.BezelBorder)
+		scrollView.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: listY},
+			Size:   foundation.Size{Width: contentWidth, Height: listHeight},
+		})
+		// Scroll view should resize with window
+		scrollView.SetAutoresizingMask(appkit.ViewWidthSizable | appkit.ViewHeightSizable)
+
+		// Build lookup map for installed agents - track ALL installations per agent
+		installedMap := make(map[string][]agent.Installation)
+		for _, inst := range installed {
+			installedMap[inst.AgentID] = append(installedMap[inst.AgentID], inst)
+		}
+
+		// Get current platform for method filtering
+		platformID := string(app.platform.ID())
+
+		// Sort agents alphabetically by name (case-insensitive)
+		sort.Slice(defs, func(i, j int) bool {
+			return strings.ToLower(defs[i].Name) < strings.ToLower(defs[j].Name)
+		})
+
+		// ═══════════════════════════════════════════════════════════════
+		// TABLE VIEW
+		// ═══════════════════════════════════════════════════════════════
+		tableView := appkit.NewTableView()
+		tableView.SetUsesAlternatingRowBackgroundColors(true)
+		tableView.SetRowHeight(28)
+		tableView.SetAllowsMultipleSelection(true)
+		tableView.SetAllowsEmptySelection(true)
+		tableView.SetColumnAutoresizingStyle(appkit.TableViewUniformColumnAutoresizingStyle)
+		tableView.SetAccessibilityLabel(i18n.T("window.manage.title"))
+		tableView.SetAccessibilityIdentifier("manage-table")
+
+		addColumn := func(id, title string, width float64) {
+			col := appkit.NewTableColumn()
+			col.SetIdentifier(appkit.UserInterfaceItemIdentifier(id))
+			col.SetTitle(title)
+			col.SetWidth(width)
+			col.SetMinWidth(width / 2)
+			tableView.AddTableColumn(col)
+		}
+
+		addColumn("select", "", 28)
+		addColumn("favorite", "★", 28)
+		addColumn("name", i18n.T("window.manage.col.name"), 180)
+		addColumn("category", i18n.T("window.manage.col.category"), 90)
+		addColumn("installed", i18n.T("window.manage.col.installed"), 80)
+		addColumn("latest", i18n.T("window.manage.col.latest"), 80)
+		addColumn("method", i18n.T("window.manage.col.method"), 90)
+		addColumn("size", i18n.T("window.manage.col.size"), 70)
+		addColumn("action", "", 100)
+		addColumn("hidden", i18n.T("window.manage.col.hidden"), 56)
+
+		// makeCellLabel builds a plain text cell view.
+		makeCellLabel := func(text string) appkit.TextField {
+			label := appkit.NewTextField()
+			label.SetStringValue(text)
+			label.SetEditable(false)
+			label.SetBordered(false)
+			label.SetDrawsBackground(false)
+			label.SetFont(appkit.Font_SystemFontOfSize(12))
+			return label
+		}
+
+		var updateBulkButtons func()
+
+		// sortManageRows orders the visible rows by the active sort column.
+		sortManageRows := func() {
+			key, asc := manageSortKey, manageSortAsc
+			sort.SliceStable(manageRows, func(i, j int) bool {
+				a, b := manageRows[i], manageRows[j]
+				var less bool
+				switch key {
+				case "name":
+					less = strings.ToLower(a.agentDef.Name) < strings.ToLower(b.agentDef.Name)
+				case "installed":
+					less = agent.ParseVersion(a.version).IsOlderThan(agent.ParseVersion(b.version))
+				case "latest":
+					less = agent.ParseVersion(a.latestVer).IsOlderThan(agent.ParseVersion(b.latestVer))
+				case "method":
+					less = a.methodString() < b.methodString()
+				case "size":
+					less = a.sizeBytes < b.sizeBytes
+				default: // category, then name within category
+					ca, cb := a.agentDef.CategoryOrDefault(), b.agentDef.CategoryOrDefault()
+					if ca != cb {
+						less = ca < cb
+					} else {
+						less = strings.ToLower(a.agentDef.Name) < strings.ToLower(b.agentDef.Name)
+					}
+				}
+				if !asc {
+					return !less
+				}
+				return less
 			})
-			currentVerLabel.SetAutoresizingMask(appkit.ViewMinXMargin)
-			row.statusLabel = currentVerLabel
-			docView.AddSubview(currentVerLabel)
+		}
+
+		// rebuildList re-populates the table applying the current search
+		// query, filter chip, and sort order.
+		rebuildList := func() {
+			query := strings.ToLower(strings.TrimSpace(searchField.StringValue()))
+			filterIdx := filterControl.SelectedSegment()
+
+			// Build row models for agents passing the search and filter
+			rows := make([]*manageAgentRow, 0, len(defs))
+			for _, def := range defs {
+				row := &manageAgentRow{
+					agentDef: def,
+					favorite: favSet[def.ID],
+					hidden:   hiddenSet[def.ID],
+				}
 
-			// Action button/popup (fixed right, vertically centered)
+				// Get available install methods for this platform
+				row.availableMethods = def.GetSupportedMethods(platformID)
+
+				// Check if installed (may have multiple installations via different methods)
+				if installations, ok := installedMap[def.ID]; ok && len(installations) > 0 {
+					row.installed = true
+					row.installedMethods = installations
+					// Use first installation for version display
+					row.version = installations[0].InstalledVersion.String()
+					if installations[0].LatestVersion != nil {
+						row.latestVer = installations[0].LatestVersion.String()
+					}
+					// Check if any installation has an update
+					for _, inst := range installations {
+						if inst.HasUpdate() {
+							row.hasUpdate = true
+							break
+						}
+					}
+					// Best-effort size from the executable
+					path := installations[0].ExecutablePath
+					if path == "" {
+						path = installations[0].InstallPath
+					}
+					if path != "" {
+						if info, err := os.Stat(path); err == nil {
+							row.sizeBytes = info.Size()
+						}
+					}
+				}
+
+				if query != "" &&
+					!strings.Contains(strings.ToLower(def.Name), query) &&
+					!strings.Contains(strings.ToLower(def.Description), query) {
+					continue
+				}
+
+				switch filterIdx {
+				case 1: // Installed
+					if !row.installed {
+						continue
+					}
+				case 2: // Updates
+					if !row.hasUpdate {
+						continue
+					}
+				case 3: // Not Installed
+					if row.installed {
+						continue
+					}
+				}
+
+				rows = append(rows, row)
+			}
+
+			manageRows = rows
+			sortManageRows()
+			tableView.ReloadData()
+			if updateBulkButtons != nil {
+				updateBulkButtons()
+			}
+		}
+
+		// Data source: row count only; cells come from the delegate
+		dataSource := &appkit.TableViewDataSource{}
+		dataSource.SetNumberOfRowsInTableView(func(_ appkit.TableView) int {
+			return len(manageRows)
+		})
+		manageDataSource = dataSource
+		tableView.SetDataSource(dataSource)
+
+		delegate := &appkit.TableViewDelegate{}
+		delegate.SetTableViewViewForTableColumnRow(func(_ appkit.TableView, tableColumn appkit.TableColumn, rowIdx int) appkit.View {
+			if rowIdx < 0 || rowIdx >= len(manageRows) {
+				return appkit.NewView()
+			}
+			row := manageRows[rowIdx]
 			currentRow := row
 
-			if row.hasUpdate {
-				// Update button - always regular button
-				actionBtn := appkit.NewButton()
-				actionBtn.SetBezelStyle(appkit.BezelStyleRounded)
-				actionBtn.SetControlSize(appkit.ControlSizeSmall)
-				actionBtn.SetTitle("Update")
-				actionBtn.SetAlignment(appkit.TextAlignmentLeft)
-				actionBtn.SetFrame(foundation.Rect{
-					Origin: foundation.Point{X: buttonX, Y: rowY + 18},
-					Size:   foundation.Size{Width: buttonWidth, Height: 24},
+			switch string(tableColumn.Identifier()) {
+			case "select":
+				checkbox := appkit.NewButton()
+				checkbox.SetButtonType(appkit.ButtonTypeSwitch)
+				checkbox.SetTitle("")
+				if row.selected {
+					checkbox.SetState(appkit.ControlStateValueOn)
+				} else {
+					checkbox.SetState(appkit.ControlStateValueOff)
+				}
+				checkbox.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("action.select"), row.agentDef.Name))
+				action.Set(checkbox, func(sender objc.Object) {
+					btn := appkit.ButtonFrom(sender.Ptr())
+					currentRow.selected = btn.State() == appkit.ControlStateValueOn
+					if updateBulkButtons != nil {
+						updateBulkButtons()
+					}
 				})
-				actionBtn.SetAutoresizingMask(appkit.ViewMinXMargin)
-				row.actionBtn = actionBtn
-				action.Set(actionBtn, func(_ objc.Object) {
-					go app.performAgentAction(currentRow, win)
+				return checkbox.View
+
+			case "favorite":
+				star := appkit.NewButton()
+				star.SetButtonType(appkit.ButtonTypeMomentaryChange)
+				star.SetBordered(false)
+				if row.favorite {
+					star.SetTitle("★")
+					star.SetContentTintColor(appkit.Color_SystemYellowColor())
+				} else {
+					star.SetTitle("☆")
+					star.SetContentTintColor(appkit.Color_TertiaryLabelColor())
+				}
+				star.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("window.manage.col.favorite"), row.agentDef.Name))
+				action.Set(star, func(_ objc.Object) {
+					favorite := !currentRow.favorite
+					currentRow.favorite = favorite
+					favSet[currentRow.agentDef.ID] = favorite
+					tableView.ReloadData()
+					go func() {
+						_ = app.prefs.SetFavorite(app.ctx, currentRow.agentDef.ID, favorite)
+						app.updateMenu()
+					}()
 				})
-				docView.AddSubview(actionBtn)
-			} else if row.installed {
-				// Uninstall - use popup if multiple installations
-				if len(row.installedMethods) > 1 {
-					// Popup button for multiple installed methods
-					popup := appkit.NewPopUpButtonWithFramePullsDown(
-						foundation.Rect{
-							Origin: foundation.Point{X: buttonX, Y: rowY + 18},
-							Size:   foundation.Size{Width: buttonWidth, Height: 24},
-						},
-						true, // pullsDown mode
-					)
-					popup.SetControlSize(appkit.ControlSizeSmall)
-					popup.SetFont(appkit.Font_SystemFontOfSize(11)) // Match regular button text size
-					popup.SetAutoresizingMask(appkit.ViewMinXMargin)
-
-					// First item is the button title
-					popup.AddItemWithTitle("Uninstall")
-					// Add method options
-					for _, inst := range row.installedMethods {
-						popup.AddItemWithTitle(string(inst.Method))
-					}
+				return star.View
 
-					// Set action for menu items
-					for idx := 1; idx < popup.NumberOfItems(); idx++ {
-						methodIdx := idx - 1 // Offset for title item
-						item := popup.ItemAtIndex(idx)
-						item.SetTag(methodIdx)
+			case "name":
+				label := makeCellLabel(row.agentDef.Name)
+				label.SetFont(appkit.Font_SystemFontOfSizeWeight(12, appkit.FontWeightMedium))
+				label.SetToolTip(row.agentDef.Description)
+				return label.View
+
+			case "category":
+				label := makeCellLabel(row.agentDef.CategoryOrDefault().DisplayName())
+				label.SetTextColor(appkit.Color_SecondaryLabelColor())
+				return label.View
+
+			case "installed":
+				text := i18n.T("status.none")
+				if row.installed {
+					text = row.version
+				}
+				label := makeCellLabel(text)
+				if !row.installed {
+					label.SetTextColor(appkit.Color_TertiaryLabelColor())
+				}
+				return label.View
+
+			case "latest":
+				text := row.latestVer
+				if text == "" {
+					if row.installed {
+						text = row.version
+					} else {
+						text = "—"
 					}
+				}
+				label := makeCellLabel(text)
+				if row.hasUpdate {
+					label.SetTextColor(appkit.Color_SystemGreenColor())
+				} else if !row.installed {
+					label.SetTextColor(appkit.Color_TertiaryLabelColor())
+				}
+				return label.View
 
-					row.actionPopup = popup
-					action.Set(popup, func(_ objc.Object) {
-						selectedIdx := popup.IndexOfSelectedItem()
-						if selectedIdx > 0 {
-							methodIdx := selectedIdx - 1
-							if methodIdx < len(currentRow.installedMethods) {
-								go app.performAgentActionWithMethod(currentRow, win, actionUninstall, string(currentRow.installedMethods[methodIdx].Method))
-							}
-						}
+			case "method":
+				label := makeCellLabel(row.methodString())
+				label.SetTextColor(appkit.Color_SecondaryLabelColor())
+				return label.View
+
+			case "size":
+				label := makeCellLabel(formatSize(row.sizeBytes))
+				label.SetTextColor(appkit.Color_SecondaryLabelColor())
+				return label.View
+
+			case "action":
+				if row.hasUpdate {
+					actionBtn := appkit.NewButton()
+					actionBtn.SetBezelStyle(appkit.BezelStyleRounded)
+					actionBtn.SetControlSize(appkit.ControlSizeSmall)
+					actionBtn.SetTitle(i18n.T("action.update"))
+					actionBtn.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("action.update"), row.agentDef.Name))
+					action.Set(actionBtn, func(_ objc.Object) {
+						go app.performAgentAction(currentRow, win)
 					})
-					docView.AddSubview(popup)
-				} else {
-					// Single installation - regular button
+					return actionBtn.View
+				}
+
+				if row.installed {
+					if len(row.installedMethods) > 1 {
+						popup := appkit.NewPopUpButtonWithFramePullsDown(
+							foundation.Rect{Size: foundation.Size{Width: 96, Height: 22}},
+							true, // pullsDown mode
+						)
+						popup.SetControlSize(appkit.ControlSizeSmall)
+						popup.SetFont(appkit.Font_SystemFontOfSize(11))
+						popup.AddItemWithTitle(i18n.T("action.uninstall"))
+						for _, inst := range row.installedMethods {
+							popup.AddItemWithTitle(string(inst.Method))
+						}
+						action.Set(popup, func(_ objc.Object) {
+							selectedIdx := popup.IndexOfSelectedItem()
+							if selectedIdx > 0 {
+								methodIdx := selectedIdx - 1
+								if methodIdx < len(currentRow.installedMethods) {
+									go app.performAgentActionWithMethod(currentRow, win, actionUninstall, string(currentRow.installedMethods[methodIdx].Method))
+								}
+							}
+						})
+						return popup.View
+					}
+
 					actionBtn := appkit.NewButton()
 					actionBtn.SetBezelStyle(appkit.BezelStyleRounded)
 					actionBtn.SetControlSize(appkit.ControlSizeSmall)
-					actionBtn.SetTitle("Uninstall")
-					actionBtn.SetAlignment(appkit.TextAlignmentLeft)
+					actionBtn.SetTitle(i18n.T("action.uninstall"))
 					actionBtn.SetContentTintColor(appkit.Color_SystemRedColor())
-					actionBtn.SetFrame(foundation.Rect{
-						Origin: foundation.Point{X: buttonX, Y: rowY + 18},
-						Size:   foundation.Size{Width: buttonWidth, Height: 24},
-					})
-					actionBtn.SetAutoresizingMask(appkit.ViewMinXMargin)
-					row.actionBtn = actionBtn
+					actionBtn.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("action.uninstall"), row.agentDef.Name))
 					action.Set(actionBtn, func(_ objc.Object) {
 						go app.performAgentAction(currentRow, win)
 					})
-					docView.AddSubview(actionBtn)
+					return actionBtn.View
 				}
-			} else {
-				// Install - use popup if multiple methods available
+
 				if len(row.availableMethods) > 1 {
-					// Popup button for multiple install methods
 					popup := appkit.NewPopUpButtonWithFramePullsDown(
-						foundation.Rect{
-							Origin: foundation.Point{X: buttonX, Y: rowY + 18},
-							Size:   foundation.Size{Width: buttonWidth, Height: 24},
-						},
+						foundation.Rect{Size: foundation.Size{Width: 96, Height: 22}},
 						true, // pullsDown mode
 					)
 					popup.SetControlSize(appkit.ControlSizeSmall)
-					popup.SetFont(appkit.Font_SystemFontOfSize(11)) // Match regular button text size
-					popup.SetAutoresizingMask(appkit.ViewMinXMargin)
-
-					// First item is the button title
-					popup.AddItemWithTitle("Install")
-					// Add method options
+					popup.SetFont(appkit.Font_SystemFontOfSize(11))
+					popup.AddItemWithTitle(i18n.T("action.install"))
 					for _, method := range row.availableMethods {
 						popup.AddItemWithTitle(method.Method)
 					}
-
-					row.actionPopup = popup
 					action.Set(popup, func(_ objc.Object) {
 						selectedIdx := popup.IndexOfSelectedItem()
 						if selectedIdx > 0 {
 							methodIdx := selectedIdx - 1
 							if methodIdx < len(currentRow.availableMethods) {
-								go app.performAgentActionWithMethod(currentRow, win, "install", currentRow.availableMethods[methodIdx].Method)
+								go app.performAgentActionWithMethod(currentRow, win, actionInstall, currentRow.availableMethods[methodIdx].Method)
 							}
 						}
 					})
-					docView.AddSubview(popup)
+					return popup.View
+				}
+
+				actionBtn := appkit.NewButton()
+				actionBtn.SetBezelStyle(appkit.BezelStyleRounded)
+				actionBtn.SetControlSize(appkit.ControlSizeSmall)
+				actionBtn.SetTitle(i18n.T("action.install"))
+				actionBtn.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("action.install"), row.agentDef.Name))
+				action.Set(actionBtn, func(_ objc.Object) {
+					go app.performAgentAction(currentRow, win)
+				})
+				return actionBtn.View
+
+			case "hidden":
+				checkbox := appkit.NewButton()
+				checkbox.SetButtonType(appkit.ButtonTypeSwitch)
+				checkbox.SetTitle("")
+				if row.hidden {
+					checkbox.SetState(appkit.ControlStateValueOn)
 				} else {
-					// Single method - regular button
-					actionBtn := appkit.NewButton()
-					actionBtn.SetBezelStyle(appkit.BezelStyleRounded)
-					actionBtn.SetControlSize(appkit.ControlSizeSmall)
-					actionBtn.SetTitle("Install")
-					actionBtn.SetAlignment(appkit.TextAlignmentLeft)
-					actionBtn.SetFrame(foundation.Rect{
-						Origin: foundation.Point{X: buttonX, Y: rowY + 18},
-						Size:   foundation.Size{Width: buttonWidth, Height: 24},
-					})
-					actionBtn.SetAutoresizingMask(appkit.ViewMinXMargin)
-					row.actionBtn = actionBtn
-					action.Set(actionBtn, func(_ objc.Object) {
-						go app.performAgentAction(currentRow, win)
-					})
-					docView.AddSubview(actionBtn)
+					checkbox.SetState(appkit.ControlStateValueOff)
 				}
+				checkbox.SetAccessibilityLabel(fmt.Sprintf("%s %s", i18n.T("window.manage.col.hidden"), row.agentDef.Name))
+				action.Set(checkbox, func(sender objc.Object) {
+					btn := appkit.ButtonFrom(sender.Ptr())
+					hidden := btn.State() == appkit.ControlStateValueOn
+					currentRow.hidden = hidden
+					hiddenSet[currentRow.agentDef.ID] = hidden
+					go func() {
+						_ = app.prefs.SetHidden(app.ctx, currentRow.agentDef.ID, hidden)
+						app.updateMenu()
+					}()
+				})
+				return checkbox.View
+
+			default:
+				return appkit.NewView()
+			}
+		})
+		// Column header clicks toggle sorting
+		delegate.SetTableViewDidClickTableColumn(func(_ appkit.TableView, tableColumn appkit.TableColumn) {
+			key := string(tableColumn.Identifier())
+			if key == "select" || key == "favorite" || key == "action" || key == "hidden" {
+				return
+			}
+			if manageSortKey == key {
+				manageSortAsc = !manageSortAsc
+			} else {
+				manageSortKey = key
+				manageSortAsc = true
 			}
+			sortManageRows()
+			tableView.ReloadData()
+		})
+		manageDelegate = delegate
+		tableView.SetDelegate(delegate)
 
-			manageRows = append(manageRows, row)
-		}
+		manageTableView = tableView
+		scrollView.SetDocumentView(tableView)
 
-		scrollView.SetDocumentView(docView)
-		contentView.AddSubview(scrollView)
+		// Rebuild the list whenever the search text or filter chip changes
+		action.Set(searchField, func(_ objc.Object) {
+			rebuildList()
+		})
+		action.Set(filterControl, func(_ objc.Object) {
+			rebuildList()
+		})
 
-		// Content is positioned from Y=0 (top in flipped clip view), so it naturally
-		// appears at the top of the scroll view - no manual scrolling needed
+		contentView.AddSubview(scrollView)
 
 		// ═══════════════════════════════════════════════════════════════
 		// FOOTER - Bulk actions
@@ -1328,7 +2445,7 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 
 		// Close button (fixed right, fixed bottom)
 		closeBtn := appkit.NewButton()
-		closeBtn.SetTitle("Done")
+		closeBtn.SetTitle(i18n.T("action.done"))
 		closeBtn.SetBezelStyle(appkit.BezelStyleRounded)
 		closeBtn.SetKeyEquivalent("\x1b") // Escape key
 		closeBtn.SetFrame(foundation.Rect{
@@ -1337,16 +2454,27 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 		})
 		closeBtn.SetAutoresizingMask(appkit.ViewMinXMargin | appkit.ViewMaxYMargin)
 		action.Set(closeBtn, func(_ objc.Object) {
+			app.saveUIState(uiStateManageWindow, frameOf(win))
+			app.saveUIState(uiStateManageView, manageViewState{
+				SortKey: manageSortKey,
+				SortAsc: manageSortAsc,
+				Filter:  int(filterControl.SelectedSegment()),
+				Search:  searchField.StringValue(),
+			})
 			windowsMu.Lock()
 			manageWindowOpen = false
 			windowsMu.Unlock()
+			if manageKeyMonitor.Ptr() != nil {
+				appkit.Event_RemoveMonitor(manageKeyMonitor)
+				manageKeyMonitor = objc.Object{}
+			}
 			win.Close()
 		})
 		contentView.AddSubview(closeBtn)
 
 		// Bulk Uninstall button (fixed right, fixed bottom)
 		bulkUninstallBtn := appkit.NewButton()
-		bulkUninstallBtn.SetTitle("Uninstall Selected")
+		bulkUninstallBtn.SetTitle(i18n.T("action.uninstall_selected"))
 		bulkUninstallBtn.SetBezelStyle(appkit.BezelStyleRounded)
 		bulkUninstallBtn.SetContentTintColor(appkit.Color_SystemRedColor())
 		bulkUninstallBtn.SetFrame(foundation.Rect{
@@ -1362,7 +2490,7 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 
 		// Bulk Install button (fixed right, fixed bottom)
 		bulkInstallBtn := appkit.NewButton()
-		bulkInstallBtn.SetTitle("Install Selected")
+		bulkInstallBtn.SetTitle(i18n.T("action.install_selected"))
 		bulkInstallBtn.SetBezelStyle(appkit.BezelStyleRounded)
 		bulkInstallBtn.SetFrame(foundation.Rect{
 			Origin: foundation.Point{X: windowWidth - windowPadding - 210, Y: footerY},
@@ -1376,10 +2504,10 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 		contentView.AddSubview(bulkInstallBtn)
 
 		// Helper function to update bulk button enabled state
-		updateBulkButtons := func() {
+		updateBulkButtons = func() {
 			hasSelection := false
 			for _, row := range manageRows {
-				if row.checkbox.State() == appkit.ControlStateValueOn {
+				if row.selected {
 					hasSelection = true
 					break
 				}
@@ -1393,7 +2521,7 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 		// Center vertically with buttons (button height 28, checkbox ~20)
 		selectAllCheck := appkit.NewButton()
 		selectAllCheck.SetButtonType(appkit.ButtonTypeSwitch)
-		selectAllCheck.SetTitle("Select All")
+		selectAllCheck.SetTitle(i18n.T("action.select_all"))
 		selectAllCheck.SetFont(appkit.Font_SystemFontOfSize(12))
 		selectAllCheck.SetFrame(foundation.Rect{
 			Origin: foundation.Point{X: windowPadding + 12, Y: footerY + 4},
@@ -1404,28 +2532,84 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 			btn := appkit.ButtonFrom(sender.Ptr())
 			selected := btn.State() == appkit.ControlStateValueOn
 			for _, row := range manageRows {
-				if selected {
-					row.checkbox.SetState(appkit.ControlStateValueOn)
-				} else {
-					row.checkbox.SetState(appkit.ControlStateValueOff)
-				}
 				row.selected = selected
 			}
+			tableView.ReloadData()
 			updateBulkButtons()
 		})
 		contentView.AddSubview(selectAllCheck)
 
-		// Add click handlers to individual checkboxes to update bulk button state
-		for _, row := range manageRows {
-			currentRow := row
-			action.Set(currentRow.checkbox, func(_ objc.Object) {
-				updateBulkButtons()
-			})
+		// Accessibility labels and identifiers for footer controls
+		closeBtn.SetAccessibilityLabel(i18n.T("action.done"))
+		closeBtn.SetAccessibilityIdentifier("manage-done")
+		bulkInstallBtn.SetAccessibilityLabel(i18n.T("action.install_selected"))
+		bulkInstallBtn.SetAccessibilityIdentifier("manage-bulk-install")
+		bulkUninstallBtn.SetAccessibilityLabel(i18n.T("action.uninstall_selected"))
+		bulkUninstallBtn.SetAccessibilityIdentifier("manage-bulk-uninstall")
+		selectAllCheck.SetAccessibilityLabel(i18n.T("action.select_all"))
+		selectAllCheck.SetAccessibilityIdentifier("manage-select-all")
+
+		// Explicit tab order: search -> filter -> table -> select all ->
+		// uninstall selected -> install selected -> done -> back to search
+		win.SetAutorecalculatesKeyViewLoop(false)
+		searchField.SetNextKeyView(filterControl)
+		filterControl.SetNextKeyView(tableView)
+		tableView.SetNextKeyView(selectAllCheck)
+		selectAllCheck.SetNextKeyView(bulkUninstallBtn)
+		bulkUninstallBtn.SetNextKeyView(bulkInstallBtn)
+		bulkInstallBtn.SetNextKeyView(closeBtn)
+		closeBtn.SetNextKeyView(searchField)
+		win.SetInitialFirstResponder(searchField)
+
+		// Space or Return while the table has focus runs the focused
+		// row's primary action; the per-row buttons are not part of the
+		// key view loop and would otherwise be mouse-only.
+		manageKeyMonitor = appkit.Event_AddLocalMonitorForEventsMatchingMaskHandler(appkit.EventMaskKeyDown, func(event appkit.Event) appkit.Event {
+			if event.Window().Ptr() != win.Ptr() || win.FirstResponder().Ptr() != tableView.Ptr() {
+				return event
+			}
+			if code := event.KeyCode(); code != keyCodeReturn && code != keyCodeSpace {
+				return event
+			}
+			rowIdx := tableView.SelectedRow()
+			if rowIdx < 0 || rowIdx >= len(manageRows) {
+				return event
+			}
+			go app.performAgentAction(manageRows[rowIdx], win)
+			return appkit.Event{}
+		})
+
+		// Restore the last-used sort, filter chip, and search text
+		var viewState manageViewState
+		if app.loadUIState(uiStateManageView, &viewState) {
+			if viewState.SortKey != "" {
+				manageSortKey = viewState.SortKey
+				manageSortAsc = viewState.SortAsc
+			}
+			if viewState.Filter >= 0 && viewState.Filter < filterControl.SegmentCount() {
+				filterControl.SetSelectedSegment(viewState.Filter)
+			}
+			searchField.SetStringValue(viewState.Search)
 		}
 
+		// Populate the list
+		rebuildList()
+
 		win.SetContentView(contentView)
 		win.Center()
 
+		// Reopen with the last saved frame instead of the default
+		var savedFrame windowFrame
+		if app.loadUIState(uiStateManageWindow, &savedFrame) && savedFrame.valid() {
+			if savedFrame.Width < minWindowWidth {
+				savedFrame.Width = minWindowWidth
+			}
+			win.SetFrameDisplay(foundation.Rect{
+				Origin: foundation.Point{X: savedFrame.X, Y: savedFrame.Y},
+				Size:   foundation.Size{Width: savedFrame.Width, Height: savedFrame.Height},
+			}, true)
+		}
+
 		// Bring to front
 		nsApp := appkit.Application_SharedApplication()
 		nsApp.SetActivationPolicy(appkit.ApplicationActivationPolicyRegular)
@@ -1440,6 +2624,22 @@ func (a *App) showNativeManageAgentsWindow(agentDefs []catalog.AgentDef, install
 	})
 }
 
+// beginProgressOperation creates a cancellable context for an installer
+// operation and wires progress events to the progress window. The returned
+// finish func must be called when the operation completes.
+func (a *App) beginProgressOperation() (context.Context, func()) {
+	ctx, cancel := context.WithCancel(a.ctx)
+	setProgressCancel(cancel)
+	a.installer.SetProgressHandler(func(ev installer.ProgressEvent) {
+		updateProgressWindow(ev.Fraction, ev.Message, ev.LogLine)
+	})
+	return ctx, func() {
+		a.installer.SetProgressHandler(nil)
+		setProgressCancel(nil)
+		cancel()
+	}
+}
+
 // performAgentAction performs install/update/uninstall on a single agent.
 func (a *App) performAgentAction(row *manageAgentRow, parentWin appkit.Window) {
 	var actionType string
@@ -1453,43 +2653,34 @@ func (a *App) performAgentAction(row *manageAgentRow, parentWin appkit.Window) {
 
 	// Show progress
 	a.showProgressWindow(fmt.Sprintf("%sing %s...", actionType, row.agentDef.Name), parentWin)
+	ctx, finish := a.beginProgressOperation()
+	defer finish()
 
 	var success bool
 	var err error
 
 	switch actionType {
 	case actionInstall:
-		success, err = a.installAgent(row.agentDef)
+		success, err = a.installAgent(ctx, row.agentDef)
 	case actionUpdate:
-		success, err = a.updateAgentByID(row.agentDef.ID)
+		success, err = a.updateAgentByID(ctx, row.agentDef.ID)
 	case actionUninstall:
-		success, err = a.uninstallAgent(row.agentDef)
+		success, err = a.uninstallAgent(ctx, row.agentDef)
 	}
 
-	// Update UI
-	dispatch.MainQueue().DispatchAsync(func() {
-		closeProgressWindow()
-
-		if success && err == nil {
-			// Update row state
-			switch actionType {
-			case actionInstall, actionUpdate:
-				row.installed = true
-				row.hasUpdate = false
-				row.statusLabel.SetStringValue("Installed")
-				row.statusLabel.SetTextColor(appkit.Color_LabelColor())
-				row.actionBtn.SetTitle("Uninstall")
-				row.actionBtn.SetContentTintColor(appkit.Color_SystemRedColor())
-			case actionUninstall:
-				row.installed = false
-				row.hasUpdate = false
-				row.statusLabel.SetStringValue("Not Installed")
-				row.statusLabel.SetTextColor(appkit.Color_TertiaryLabelColor())
-				row.actionBtn.SetTitle("Install")
-				row.actionBtn.SetContentTintColor(appkit.Color_ControlAccentColor())
-			}
+	// Update row state and refresh the table
+	closeProgressWindow()
+	if success && err == nil {
+		switch actionType {
+		case actionInstall, actionUpdate:
+			row.installed = true
+			row.hasUpdate = false
+		case actionUninstall:
+			row.installed = false
+			row.hasUpdate = false
 		}
-	})
+	}
+	reloadManageTable()
 
 	// Refresh agent list
 	a.refreshAgents(a.ctx)
@@ -1499,41 +2690,33 @@ func (a *App) performAgentAction(row *manageAgentRow, parentWin appkit.Window) {
 func (a *App) performAgentActionWithMethod(row *manageAgentRow, parentWin appkit.Window, actionType, method string) {
 	// Show progress
 	a.showProgressWindow(fmt.Sprintf("%sing %s via %s...", actionType, row.agentDef.Name, method), parentWin)
+	ctx, finish := a.beginProgressOperation()
+	defer finish()
 
 	var success bool
 	var err error
 
 	switch actionType {
 	case actionInstall:
-		success, err = a.installAgentWithMethod(row.agentDef, method)
+		success, err = a.installAgentWithMethod(ctx, row.agentDef, method)
 	case actionUninstall:
-		success, err = a.uninstallAgentWithMethod(row.agentDef, method)
+		success, err = a.uninstallAgentWithMethod(ctx, row.agentDef, method)
 	}
 
-	// Update UI
-	dispatch.MainQueue().DispatchAsync(func() {
-		closeProgressWindow()
-
-		if success && err == nil {
-			switch actionType {
-			case actionInstall:
-				row.installed = true
-				row.hasUpdate = false
-				if row.statusLabel.Ptr() != nil {
-					row.statusLabel.SetStringValue("Installed")
-					row.statusLabel.SetTextColor(appkit.Color_LabelColor())
-				}
-			case actionUninstall:
-				// Only mark as not installed if no other methods remain installed
-				row.installed = false
-				row.hasUpdate = false
-				if row.statusLabel.Ptr() != nil {
-					row.statusLabel.SetStringValue("Not Installed")
-					row.statusLabel.SetTextColor(appkit.Color_TertiaryLabelColor())
-				}
-			}
+	// Update row state and refresh the table
+	closeProgressWindow()
+	if success && err == nil {
+		switch actionType {
+		case actionInstall:
+			row.installed = true
+			row.hasUpdate = false
+		case actionUninstall:
+			// Only mark as not installed if no other methods remain installed
+			row.installed = false
+			row.hasUpdate = false
 		}
-	})
+	}
+	reloadManageTable()
 
 	// Refresh agent list
 	a.refreshAgents(a.ctx)
@@ -1544,14 +2727,14 @@ func (a *App) performBulkAction(actionType string, resultLabel appkit.TextField,
 	// Get selected rows
 	var selected []*manageAgentRow
 	for _, row := range manageRows {
-		if row.checkbox.State() == appkit.ControlStateValueOn {
+		if row.selected {
 			selected = append(selected, row)
 		}
 	}
 
 	if len(selected) == 0 {
 		dispatch.MainQueue().DispatchAsync(func() {
-			resultLabel.SetStringValue("No agents selected")
+			resultLabel.SetStringValue(i18n.T("status.no_agents_selected"))
 			resultLabel.SetTextColor(appkit.Color_SystemOrangeColor())
 		})
 		return
@@ -1559,28 +2742,36 @@ func (a *App) performBulkAction(actionType string, resultLabel appkit.TextField,
 
 	// Show progress
 	a.showProgressWindow(fmt.Sprintf("%sing %d agents...", actionType, len(selected)), parentWin)
+	ctx, finish := a.beginProgressOperation()
+	defer finish()
 
 	successCount := 0
-	for _, row := range selected {
+	for i, row := range selected {
+		if ctx.Err() != nil {
+			break
+		}
+		updateProgressWindow(float64(i)/float64(len(selected)),
+			fmt.Sprintf("%sing %s (%d/%d)...", actionType, row.agentDef.Name, i+1, len(selected)), "")
+
 		var success bool
 		var err error
 
 		switch actionType {
 		case actionInstall:
 			if !row.installed {
-				success, err = a.installAgent(row.agentDef)
+				success, err = a.installAgent(ctx, row.agentDef)
 			} else {
 				continue
 			}
 		case actionUpdate:
 			if row.hasUpdate {
-				success, err = a.updateAgentByID(row.agentDef.ID)
+				success, err = a.updateAgentByID(ctx, row.agentDef.ID)
 			} else {
 				continue
 			}
 		case actionUninstall:
 			if row.installed {
-				success, err = a.uninstallAgent(row.agentDef)
+				success, err = a.uninstallAgent(ctx, row.agentDef)
 			} else {
 				continue
 			}
@@ -1594,7 +2785,7 @@ func (a *App) performBulkAction(actionType string, resultLabel appkit.TextField,
 	// Update UI
 	dispatch.MainQueue().DispatchAsync(func() {
 		closeProgressWindow()
-		resultLabel.SetStringValue(fmt.Sprintf("%d agent(s) processed", successCount))
+		resultLabel.SetStringValue(i18n.T("status.agents_processed", successCount))
 		resultLabel.SetTextColor(appkit.Color_SystemGreenColor())
 	})
 
@@ -1607,18 +2798,32 @@ var (
 	progressWindow     appkit.Window
 	progressWindowOpen bool
 	progressLabel      appkit.TextField
+	progressLogLabel   appkit.TextField
+	progressBar        appkit.ProgressIndicator
+	progressCancelMu   sync.Mutex
+	progressCancel     context.CancelFunc
 )
 
-// showProgressWindow displays a progress indicator window.
+// setProgressCancel stores the cancel function invoked by the Cancel button.
+func setProgressCancel(cancel context.CancelFunc) {
+	progressCancelMu.Lock()
+	progressCancel = cancel
+	progressCancelMu.Unlock()
+}
+
+// showProgressWindow displays a progress window with a determinate bar,
+// a live log tail and a Cancel button.
 func (a *App) showProgressWindow(message string, parentWin appkit.Window) {
 	dispatch.MainQueue().DispatchAsync(func() {
 		if progressWindowOpen {
 			progressLabel.SetStringValue(message)
+			progressBar.SetDoubleValue(0)
+			progressLogLabel.SetStringValue("")
 			return
 		}
 
-		windowWidth := 280.0
-		windowHeight := 90.0
+		windowWidth := 360.0
+		windowHeight := 130.0
 
 		win := appkit.NewWindowWithContentRectStyleMaskBackingDefer(
 			foundation.Rect{
@@ -1629,24 +2834,13 @@ func (a *App) showProgressWindow(message string, parentWin appkit.Window) {
 			appkit.BackingStoreBuffered,
 			false,
 		)
-		win.SetTitle("Working...")
+		win.SetTitle(i18n.T("window.progress.title"))
 		win.SetReleasedWhenClosed(false)
 
 		contentView := appkit.NewView()
 		contentView.SetFrameSize(foundation.Size{Width: windowWidth, Height: windowHeight})
 
-		// Progress indicator (left side)
-		progress := appkit.NewProgressIndicator()
-		progress.SetStyle(appkit.ProgressIndicatorStyleSpinning)
-		progress.SetControlSize(appkit.ControlSizeSmall)
-		progress.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: 20, Y: (windowHeight - 20) / 2},
-			Size:   foundation.Size{Width: 20, Height: 20},
-		})
-		progress.StartAnimation(nil)
-		contentView.AddSubview(progress)
-
-		// Message label (right of spinner)
+		// Message label (top)
 		label := appkit.NewTextField()
 		label.SetStringValue(message)
 		label.SetEditable(false)
@@ -1654,12 +2848,63 @@ func (a *App) showProgressWindow(message string, parentWin appkit.Window) {
 		label.SetDrawsBackground(false)
 		label.SetFont(appkit.Font_SystemFontOfSize(13))
 		label.SetFrame(foundation.Rect{
-			Origin: foundation.Point{X: 50, Y: (windowHeight - 18) / 2},
-			Size:   foundation.Size{Width: windowWidth - 70, Height: 18},
+			Origin: foundation.Point{X: windowPadding, Y: windowHeight - 34},
+			Size:   foundation.Size{Width: windowWidth - windowPadding*2, Height: 18},
 		})
 		progressLabel = label
 		contentView.AddSubview(label)
 
+		// Determinate progress bar
+		progress := appkit.NewProgressIndicator()
+		progress.SetStyle(appkit.ProgressIndicatorStyleBar)
+		progress.SetIndeterminate(false)
+		progress.SetMinValue(0)
+		progress.SetMaxValue(1)
+		progress.SetDoubleValue(0)
+		progress.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: windowHeight - 60},
+			Size:   foundation.Size{Width: windowWidth - windowPadding*2, Height: 16},
+		})
+		progress.SetAccessibilityLabel(i18n.T("window.progress.title"))
+		progressBar = progress
+		contentView.AddSubview(progress)
+
+		// Log tail (last line of command output)
+		logLabel := appkit.NewTextField()
+		logLabel.SetStringValue("")
+		logLabel.SetEditable(false)
+		logLabel.SetBordered(false)
+		logLabel.SetDrawsBackground(false)
+		logLabel.SetFont(appkit.Font_MonospacedSystemFontOfSizeWeight(10, appkit.FontWeightRegular))
+		logLabel.SetTextColor(appkit.Color_SecondaryLabelColor())
+		logLabel.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowPadding, Y: windowHeight - 84},
+			Size:   foundation.Size{Width: windowWidth - windowPadding*2, Height: 16},
+		})
+		progressLogLabel = logLabel
+		contentView.AddSubview(logLabel)
+
+		// Cancel button (bottom right)
+		cancelBtn := appkit.NewButton()
+		cancelBtn.SetTitle(i18n.T("action.cancel"))
+		cancelBtn.SetBezelStyle(appkit.BezelStyleRounded)
+		cancelBtn.SetFrame(foundation.Rect{
+			Origin: foundation.Point{X: windowWidth - 100, Y: 10},
+			Size:   foundation.Size{Width: 80, Height: 26},
+		})
+		cancelBtn.SetAccessibilityLabel(i18n.T("action.cancel"))
+		cancelBtn.SetKeyEquivalent("\x1b") // Escape key
+		action.Set(cancelBtn, func(_ objc.Object) {
+			progressCancelMu.Lock()
+			cancel := progressCancel
+			progressCancelMu.Unlock()
+			if cancel != nil {
+				cancel()
+			}
+			progressLabel.SetStringValue(i18n.T("window.progress.cancelling"))
+		})
+		contentView.AddSubview(cancelBtn)
+
 		win.SetContentView(contentView)
 		win.Center()
 
@@ -1671,8 +2916,29 @@ func (a *App) showProgressWindow(message string, parentWin appkit.Window) {
 	})
 }
 
+// updateProgressWindow updates the bar, message and log tail.
+func updateProgressWindow(fraction float64, message, logLine string) {
+	dispatch.MainQueue().DispatchAsync(func() {
+		if !progressWindowOpen {
+			return
+		}
+		if progressBar.Ptr() != nil {
+			progressBar.SetDoubleValue(fraction)
+		}
+		if message != "" && progressLabel.Ptr() != nil {
+			progressLabel.SetStringValue(message)
+		}
+		if logLine != "" && progressLogLabel.Ptr() != nil {
+			// Show only the last non-empty line of output
+			lines := strings.Split(strings.TrimSpace(logLine), "\n")
+			progressLogLabel.SetStringValue(lines[len(lines)-1])
+		}
+	})
+}
+
 // closeProgressWindow closes the progress window.
 func closeProgressWindow() {
+	setProgressCancel(nil)
 	dispatch.MainQueue().DispatchAsync(func() {
 		if progressWindowOpen && progressWindow.Ptr() != nil {
 			progressWindow.Close()
@@ -1682,11 +2948,15 @@ func closeProgressWindow() {
 }
 
 // installAgent installs an agent using the first available method.
-func (a *App) installAgent(def catalog.AgentDef) (bool, error) {
+func (a *App) installAgent(ctx context.Context, def catalog.AgentDef) (bool, error) {
+	if a.config.Policy.Lockdown {
+		return false, fmt.Errorf("%s", a.config.LockdownReason())
+	}
+
 	// Find the first available install method
 	for methodName, methodDef := range def.InstallMethods {
 		methodDef.Method = methodName // Ensure method name is set
-		_, err := a.installer.Install(a.ctx, def, methodDef, false)
+		_, err := a.installer.InstallWithProgress(ctx, def, methodDef, false)
 		if err == nil {
 			return true, nil
 		}
@@ -1695,7 +2965,11 @@ func (a *App) installAgent(def catalog.AgentDef) (bool, error) {
 }
 
 // updateAgentByID updates an agent by its ID.
-func (a *App) updateAgentByID(agentID string) (bool, error) {
+func (a *App) updateAgentByID(ctx context.Context, agentID string) (bool, error) {
+	if a.config.Policy.Lockdown {
+		return false, fmt.Errorf("%s", a.config.LockdownReason())
+	}
+
 	// Find the installed agent
 	a.agentsMu.RLock()
 	var target *agent.Installation
@@ -1713,7 +2987,7 @@ func (a *App) updateAgentByID(agentID string) (bool, error) {
 	}
 
 	// Get the agent definition from catalog
-	agentDef, err := a.catalog.GetAgent(a.ctx, agentID)
+	agentDef, err := a.catalog.GetAgent(ctx, agentID)
 	if err != nil {
 		return false, err
 	}
@@ -1725,12 +2999,23 @@ func (a *App) updateAgentByID(agentID string) (bool, error) {
 	}
 	methodDef.Method = string(target.Method)
 
-	_, err = a.installer.Update(a.ctx, target, *agentDef, methodDef)
+	result, err := a.installer.UpdateWithProgress(ctx, target, *agentDef, methodDef)
+	if err == nil {
+		toVersion := ""
+		if result != nil {
+			toVersion = result.Version.String()
+		}
+		a.broadcastAgentEvent(ipc.MessageTypeAgentUpdated, *target, toVersion)
+	}
 	return err == nil, err
 }
 
 // uninstallAgent uninstalls an agent.
-func (a *App) uninstallAgent(def catalog.AgentDef) (bool, error) {
+func (a *App) uninstallAgent(ctx context.Context, def catalog.AgentDef) (bool, error) {
+	if a.config.Policy.Lockdown {
+		return false, fmt.Errorf("%s", a.config.LockdownReason())
+	}
+
 	// Find the installed agent
 	a.agentsMu.RLock()
 	var target *agent.Installation
@@ -1756,24 +3041,46 @@ func (a *App) uninstallAgent(def catalog.AgentDef) (bool, error) {
 	}
 	methodDef.Method = methodName
 
-	err := a.installer.Uninstall(a.ctx, target, methodDef)
+	err := a.installer.UninstallWithProgress(ctx, target, methodDef)
+	if err == nil {
+		a.broadcastAgentEvent(ipc.MessageTypeAgentRemoved, *target, "")
+	}
 	return err == nil, err
 }
 
 // installAgentWithMethod installs an agent using a specific method.
-func (a *App) installAgentWithMethod(def catalog.AgentDef, method string) (bool, error) {
+func (a *App) installAgentWithMethod(ctx context.Context, def catalog.AgentDef, method string) (bool, error) {
+	if a.config.Policy.Lockdown {
+		return false, fmt.Errorf("%s", a.config.LockdownReason())
+	}
+
 	methodDef, ok := def.InstallMethods[method]
 	if !ok {
 		return false, fmt.Errorf("install method %s not found", method)
 	}
 	methodDef.Method = method
 
-	_, err := a.installer.Install(a.ctx, def, methodDef, false)
+	result, err := a.installer.InstallWithProgress(ctx, def, methodDef, false)
+	if err == nil {
+		toVersion := ""
+		if result != nil {
+			toVersion = result.Version.String()
+		}
+		a.broadcastAgentEvent(ipc.MessageTypeAgentInstalled, agent.Installation{
+			AgentID:   def.ID,
+			AgentName: def.Name,
+			Method:    agent.InstallMethod(method),
+		}, toVersion)
+	}
 	return err == nil, err
 }
 
 // uninstallAgentWithMethod uninstalls an agent using a specific method.
-func (a *App) uninstallAgentWithMethod(def catalog.AgentDef, method string) (bool, error) {
+func (a *App) uninstallAgentWithMethod(ctx context.Context, def catalog.AgentDef, method string) (bool, error) {
+	if a.config.Policy.Lockdown {
+		return false, fmt.Errorf("%s", a.config.LockdownReason())
+	}
+
 	// Find the installed agent with the specific method
 	a.agentsMu.RLock()
 	var target *agent.Installation
@@ -1797,6 +3104,6 @@ func (a *App) uninstallAgentWithMethod(def catalog.AgentDef, method string) (boo
 	}
 	methodDef.Method = method
 
-	err := a.installer.Uninstall(a.ctx, target, methodDef)
+	err := a.installer.UninstallWithProgress(ctx, target, methodDef)
 	return err == nil, err
 }
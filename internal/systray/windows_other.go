@@ -16,6 +16,10 @@ func (a *App) showNativeAgentDetailsWindow(inst agent.Installation) {
 	a.showAgentDetails(inst)
 }
 
+// showNativeLogsWindow is not available on this platform; showLogsWindow
+// falls back to opening the raw log file.
+func (a *App) showNativeLogsWindow() {}
+
 // closeAllNativeWindows is a no-op on non-darwin platforms.
 func closeAllNativeWindows() {}
 
@@ -4,6 +4,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"os"
 	"sort"
 	"strings"
 	"time"
@@ -19,9 +20,14 @@ import (
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
 	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/diagnostics"
 	"github.com/kevinelliott/agentmgr/pkg/installer"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/shim"
+	"github.com/kevinelliott/agentmgr/pkg/stats"
+	"github.com/kevinelliott/agentmgr/pkg/statusfile"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
+	"github.com/kevinelliott/agentmgr/pkg/usage"
 )
 
 // View represents the current view in the TUI.
@@ -33,6 +39,8 @@ const (
 	ViewAgentDetail
 	ViewCatalog
 	ViewSettings
+	ViewLogs
+	ViewStats
 )
 
 // Model is the main TUI model.
@@ -44,6 +52,7 @@ type Model struct {
 	// Data
 	agents      []*agent.Installation
 	catalog     *catalog.Catalog
+	statsReport *stats.Report
 	selectedIdx int
 
 	// UI state
@@ -53,6 +62,13 @@ type Model struct {
 	loading     bool
 	err         error
 
+	// Log viewer state
+	logLines       []string
+	logLevelFilter string
+	logQuery       string
+	logSearching   bool
+	logStatus      string
+
 	// Components
 	list    list.Model
 	spinner spinner.Model
@@ -72,8 +88,12 @@ type keyMap struct {
 	Install key.Binding
 	Update  key.Binding
 	Remove  key.Binding
+	Shim    key.Binding
 	Help    key.Binding
 	Tab     key.Binding
+	Filter  key.Binding
+	Search  key.Binding
+	Bundle  key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings.
@@ -115,6 +135,10 @@ func DefaultKeyMap() keyMap {
 			key.WithKeys("d"),
 			key.WithHelp("d", "remove"),
 		),
+		Shim: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "toggle shim"),
+		),
 		Help: key.NewBinding(
 			key.WithKeys("?"),
 			key.WithHelp("?", "help"),
@@ -123,6 +147,18 @@ func DefaultKeyMap() keyMap {
 			key.WithKeys("tab"),
 			key.WithHelp("tab", "switch view"),
 		),
+		Filter: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter level"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		Bundle: key.NewBinding(
+			key.WithKeys("b"),
+			key.WithHelp("b", "diagnostics bundle"),
+		),
 	}
 }
 
@@ -154,13 +190,14 @@ func New(cfg *config.Config, plat platform.Platform) Model {
 	l.Styles.Title = styles.Title
 
 	return Model{
-		config:      cfg,
-		platform:    plat,
-		currentView: ViewDashboard,
-		keys:        DefaultKeyMap(),
-		spinner:     s,
-		list:        l,
-		loading:     true,
+		config:         cfg,
+		platform:       plat,
+		currentView:    ViewDashboard,
+		keys:           DefaultKeyMap(),
+		spinner:        s,
+		list:           l,
+		loading:        true,
+		logLevelFilter: "all",
 	}
 }
 
@@ -244,6 +281,7 @@ func (m Model) loadDataWithRefresh(forceRefresh bool) tea.Msg {
 
 		// Detect installed agents
 		det := detector.New(m.platform)
+		det.SetInstallersConfig(m.config.Installers)
 		installations, err = det.DetectAll(ctx, agentDefs)
 		if err != nil {
 			return dataLoadedMsg{err: fmt.Errorf("detection failed: %w", err)}
@@ -256,6 +294,8 @@ func (m Model) loadDataWithRefresh(forceRefresh bool) tea.Msg {
 	// Check for updates if needed
 	if needUpdateCheck {
 		instMgr := installer.NewManager(m.platform)
+		instMgr.SetInstallersConfig(m.config.Installers)
+		instMgr.SetHooksConfig(m.config.Hooks, m.config.Agents)
 
 		// Check for latest versions
 		for _, inst := range installations {
@@ -279,9 +319,28 @@ func (m Model) loadDataWithRefresh(forceRefresh bool) tea.Msg {
 		}
 	}
 
+	// Publish the statusbar summary file if enabled; best-effort since
+	// the file is informational
+	if m.config.UI.StatusFile {
+		lastCheck, _ := store.GetLastUpdateCheckTime(ctx) //nolint:errcheck // zero time is fine when no check has run
+		path := m.config.UI.StatusFilePath
+		if path == "" {
+			path = statusfile.DefaultPath(m.platform.GetDataDir())
+		}
+		_ = statusfile.Write(path, statusfile.Build(installations, lastCheck)) //nolint:errcheck // best-effort publish
+	}
+
+	// Aggregate usage statistics when the opt-in view is enabled. No
+	// release source here: the TUI stays offline and skips lag figures.
+	var statsReport *stats.Report
+	if m.config.Stats.Enabled {
+		statsReport = stats.NewAggregator(store, nil).Report(ctx, installations)
+	}
+
 	return dataLoadedMsg{
 		agents:  installations,
 		catalog: cat,
+		stats:   statsReport,
 	}
 }
 
@@ -289,6 +348,7 @@ func (m Model) loadDataWithRefresh(forceRefresh bool) tea.Msg {
 type dataLoadedMsg struct {
 	agents  []*agent.Installation
 	catalog *catalog.Catalog
+	stats   *stats.Report
 	err     error
 }
 
@@ -298,12 +358,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Search entry in the logs view captures keystrokes
+		if m.currentView == ViewLogs && m.logSearching {
+			switch msg.Type {
+			case tea.KeyEnter, tea.KeyEsc:
+				m.logSearching = false
+			case tea.KeyBackspace:
+				if len(m.logQuery) > 0 {
+					m.logQuery = m.logQuery[:len(m.logQuery)-1]
+				}
+			case tea.KeyRunes:
+				m.logQuery += string(msg.Runes)
+			}
+			return m, nil
+		}
+
 		switch {
 		case key.Matches(msg, m.keys.Quit):
 			return m, tea.Quit
 
 		case key.Matches(msg, m.keys.Tab):
-			m.currentView = (m.currentView + 1) % 5
+			m.currentView = (m.currentView + 1) % 7
+			if m.currentView == ViewLogs {
+				return m, m.loadLogs
+			}
 
 		case key.Matches(msg, m.keys.Back):
 			if m.currentView != ViewDashboard {
@@ -311,15 +389,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case key.Matches(msg, m.keys.Refresh):
+			if m.currentView == ViewLogs {
+				return m, m.loadLogs
+			}
 			m.loading = true
 			return m, func() tea.Msg {
 				return m.loadDataWithRefresh(true) // Force refresh, bypass cache
 			}
 
+		case key.Matches(msg, m.keys.Filter):
+			if m.currentView == ViewLogs {
+				m.logLevelFilter = nextLogLevel(m.logLevelFilter)
+			}
+
+		case key.Matches(msg, m.keys.Search):
+			if m.currentView == ViewLogs {
+				m.logSearching = true
+			}
+
+		case key.Matches(msg, m.keys.Bundle):
+			if m.currentView == ViewLogs {
+				return m, m.writeDiagnosticsBundle
+			}
+
 		case key.Matches(msg, m.keys.Enter):
 			if m.currentView == ViewAgentList && len(m.agents) > 0 {
 				m.currentView = ViewAgentDetail
 			}
+
+		case key.Matches(msg, m.keys.Shim):
+			if m.currentView == ViewAgentList {
+				if item, ok := m.list.SelectedItem().(agentItem); ok {
+					m.err = m.toggleShim(item.installation)
+				}
+			}
 		}
 
 	case tea.WindowSizeMsg:
@@ -334,9 +437,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.agents = msg.agents
 			m.catalog = msg.catalog
+			m.statsReport = msg.stats
 			m.updateList()
 		}
 
+	case logsLoadedMsg:
+		if msg.err != nil {
+			m.logStatus = fmt.Sprintf("Failed to read log file: %v", msg.err)
+		} else {
+			m.logLines = msg.lines
+			m.logStatus = ""
+		}
+
+	case bundleWrittenMsg:
+		if msg.err != nil {
+			m.logStatus = fmt.Sprintf("Failed to write bundle: %v", msg.err)
+		} else {
+			m.logStatus = fmt.Sprintf("Diagnostics bundle written to %s", msg.path)
+		}
+
 	case spinner.TickMsg:
 		var cmd tea.Cmd
 		m.spinner, cmd = m.spinner.Update(msg)
@@ -353,6 +472,78 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// logsLoadedMsg is sent when the log file tail has been read.
+type logsLoadedMsg struct {
+	lines []string
+	err   error
+}
+
+// bundleWrittenMsg is sent when a diagnostics bundle has been written.
+type bundleWrittenMsg struct {
+	path string
+	err  error
+}
+
+// maxLogViewBytes caps how much of the log file the logs view reads.
+const maxLogViewBytes = 256 << 10
+
+// loadLogs reads the tail of the configured log file.
+func (m Model) loadLogs() tea.Msg {
+	if m.config.Logging.File == "" {
+		return logsLoadedMsg{}
+	}
+	tail, err := diagnostics.ReadLogTail(m.config.Logging.File, maxLogViewBytes)
+	if err != nil {
+		return logsLoadedMsg{err: err}
+	}
+	text := strings.TrimRight(string(tail), "\n")
+	if text == "" {
+		return logsLoadedMsg{}
+	}
+	return logsLoadedMsg{lines: strings.Split(text, "\n")}
+}
+
+// writeDiagnosticsBundle writes a support bundle to the current directory.
+func (m Model) writeDiagnosticsBundle() tea.Msg {
+	path := fmt.Sprintf("agentmgr-diagnostics-%s.zip", time.Now().Format("20060102-150405"))
+
+	f, err := os.Create(path)
+	if err != nil {
+		return bundleWrittenMsg{err: err}
+	}
+	if err := diagnostics.WriteBundle(m.config, m.platform, "", f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return bundleWrittenMsg{err: err}
+	}
+	if err := f.Close(); err != nil {
+		return bundleWrittenMsg{err: err}
+	}
+	return bundleWrittenMsg{path: path}
+}
+
+// logLevelCycle is the order the logs view cycles through level filters.
+var logLevelCycle = []string{"all", "info", "warn", "error"}
+
+// nextLogLevel returns the level filter after the current one.
+func nextLogLevel(current string) string {
+	for i, level := range logLevelCycle {
+		if level == current {
+			return logLevelCycle[(i+1)%len(logLevelCycle)]
+		}
+	}
+	return logLevelCycle[0]
+}
+
+// toggleShim installs or removes the wrapper shim for an agent.
+func (m *Model) toggleShim(inst *agent.Installation) error {
+	mgr := shim.NewManager(m.platform.GetDataDir())
+	if mgr.IsEnabled(inst.AgentID) {
+		return mgr.Disable(inst.AgentID)
+	}
+	return mgr.Enable(inst, m.config.GetPinnedVersion(inst.AgentID))
+}
+
 // updateList updates the list items from agents.
 func (m *Model) updateList() {
 	// Sort agents alphabetically by name (case-insensitive)
@@ -386,6 +577,10 @@ func (m Model) View() string {
 		content = m.catalogView()
 	case ViewSettings:
 		content = m.settingsView()
+	case ViewLogs:
+		content = m.logsView()
+	case ViewStats:
+		content = m.statsView()
 	}
 
 	// Add header
@@ -401,7 +596,7 @@ func (m Model) View() string {
 func (m Model) headerView() string {
 	title := styles.TitleBar.Render(" AgentManager ")
 
-	tabs := []string{"Dashboard", "Agents", "Detail", "Catalog", "Settings"}
+	tabs := []string{"Dashboard", "Agents", "Detail", "Catalog", "Settings", "Logs", "Stats"}
 	var tabViews []string
 	for i, tab := range tabs {
 		if View(i) == m.currentView {
@@ -431,6 +626,15 @@ func (m Model) footerView() string {
 		helpKeys = append(helpKeys,
 			styles.HelpKey.Render("i")+styles.Help.Render(" install"),
 			styles.HelpKey.Render("u")+styles.Help.Render(" update"),
+			styles.HelpKey.Render("s")+styles.Help.Render(" toggle shim"),
+		)
+	}
+
+	if m.currentView == ViewLogs {
+		helpKeys = append(helpKeys,
+			styles.HelpKey.Render("f")+styles.Help.Render(" filter level"),
+			styles.HelpKey.Render("/")+styles.Help.Render(" search"),
+			styles.HelpKey.Render("b")+styles.Help.Render(" diagnostics bundle"),
 		)
 	}
 
@@ -678,6 +882,110 @@ func (m Model) settingsView() string {
 	return b.String()
 }
 
+// logsView renders the log viewer.
+func (m Model) logsView() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(styles.Title.Render("  Logs"))
+	b.WriteString("\n\n")
+
+	if m.config.Logging.File == "" {
+		b.WriteString(styles.InfoMessage.Render("  No log file configured. Set logging.file to enable the log viewer.\n"))
+		return b.String()
+	}
+
+	// Filter bar
+	query := m.logQuery
+	if m.logSearching {
+		query += "▌"
+	}
+	b.WriteString(fmt.Sprintf("  %s %s   %s %s\n",
+		styles.Help.Render("Level:"),
+		styles.Badge.Render(m.logLevelFilter),
+		styles.Help.Render("Search:"),
+		styles.Version.Render(query),
+	))
+
+	if m.logStatus != "" {
+		b.WriteString(styles.InfoMessage.Render("  " + m.logStatus + "\n"))
+	}
+	b.WriteString("\n")
+
+	filtered := diagnostics.FilterLines(m.logLines, m.logLevelFilter, m.logQuery)
+	if len(filtered) == 0 {
+		b.WriteString(styles.InfoMessage.Render("  No log lines match the current filters.\n"))
+		return b.String()
+	}
+
+	// Show only the lines that fit, newest last
+	visible := m.height - 12
+	if visible < 5 {
+		visible = 5
+	}
+	if len(filtered) > visible {
+		filtered = filtered[len(filtered)-visible:]
+	}
+
+	maxWidth := m.width - 4
+	for _, line := range filtered {
+		if maxWidth > 0 && len(line) > maxWidth {
+			line = line[:maxWidth]
+		}
+		b.WriteString("  " + line + "\n")
+	}
+
+	return b.String()
+}
+
+// statsView renders the opt-in usage statistics screen.
+func (m Model) statsView() string {
+	var b strings.Builder
+	b.WriteString("\n")
+	b.WriteString(styles.Title.Render("  Usage Statistics"))
+	b.WriteString("\n\n")
+
+	if !m.config.Stats.Enabled {
+		b.WriteString(styles.InfoMessage.Render("  Statistics are disabled. Run 'agentmgr config set stats.enabled true' to enable them.\n"))
+		return b.String()
+	}
+
+	if m.loading || m.statsReport == nil {
+		return b.String() + fmt.Sprintf("  %s Aggregating...\n", m.spinner.View())
+	}
+
+	report := m.statsReport
+
+	summary := styles.Box.Render(fmt.Sprintf(
+		"%s\n\n  Updates applied: %s\n  Time saved:      %s",
+		styles.Subtitle.Render("Summary"),
+		styles.Version.Render(fmt.Sprintf("%d", report.TotalUpdates)),
+		styles.Version.Render(report.TimeSaved.String()),
+	))
+	b.WriteString("  ")
+	b.WriteString(summary)
+	b.WriteString("\n\n")
+
+	if len(report.Agents) == 0 {
+		b.WriteString(styles.InfoMessage.Render("  No agents to report on yet.\n"))
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("  %-28s %-16s %s\n",
+		styles.Subtitle.Render("Agent"),
+		styles.Subtitle.Render("Last Used"),
+		styles.Subtitle.Render("Updates"),
+	))
+	for _, s := range report.Agents {
+		b.WriteString(fmt.Sprintf("  %-20s %-16s %d\n",
+			s.AgentName,
+			usage.FormatRelative(s.LastUsed),
+			s.Updates,
+		))
+	}
+
+	return b.String()
+}
+
 // Run starts the TUI.
 func Run(cfg *config.Config, plat platform.Platform) error {
 	p := tea.NewProgram(
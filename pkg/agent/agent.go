@@ -22,6 +22,11 @@ const (
 	InstallMethodNative     InstallMethod = "native"
 	InstallMethodCurl       InstallMethod = "curl"
 	InstallMethodBinary     InstallMethod = "binary"
+	InstallMethodExtension  InstallMethod = "extension"
+	InstallMethodMise       InstallMethod = "mise"
+	InstallMethodFlatpak    InstallMethod = "flatpak"
+	InstallMethodSnap       InstallMethod = "snap"
+	InstallMethodGitHub     InstallMethod = "github"
 
 	// Short form aliases
 	MethodNPM        = InstallMethodNPM
@@ -35,6 +40,11 @@ const (
 	MethodNative     = InstallMethodNative
 	MethodCurl       = InstallMethodCurl
 	MethodBinary     = InstallMethodBinary
+	MethodExtension  = InstallMethodExtension
+	MethodMise       = InstallMethodMise
+	MethodFlatpak    = InstallMethodFlatpak
+	MethodSnap       = InstallMethodSnap
+	MethodGitHub     = InstallMethodGitHub
 )
 
 // String returns the string representation of the install method.
@@ -56,6 +66,10 @@ func (m InstallMethod) DisplayName() string {
 		InstallMethodNative:     "Native Installer",
 		InstallMethodCurl:       "curl",
 		InstallMethodBinary:     "Binary",
+		InstallMethodExtension:  "Editor Extension",
+		InstallMethodFlatpak:    "Flatpak",
+		InstallMethodSnap:       "Snap",
+		InstallMethodGitHub:     "GitHub Releases",
 	}
 	if name, ok := names[m]; ok {
 		return name
@@ -75,19 +89,42 @@ type Agent struct {
 // Installation represents a unique installation instance of an agent.
 // The same agent can have multiple installations via different methods.
 type Installation struct {
-	AgentID          string            `json:"agent_id"`
-	AgentName        string            `json:"agent_name"`
-	Method           InstallMethod     `json:"install_method"`
-	InstalledVersion Version           `json:"installed_version"`
-	LatestVersion    *Version          `json:"latest_version,omitempty"`
-	ExecutablePath   string            `json:"executable_path"`
-	InstallPath      string            `json:"install_path,omitempty"`
-	IsGlobal         bool              `json:"is_global"`
-	DetectedAt       time.Time         `json:"detected_at"`
-	LastChecked      time.Time         `json:"last_checked"`
-	Metadata         map[string]string `json:"metadata,omitempty"`
+	AgentID          string        `json:"agent_id"`
+	AgentName        string        `json:"agent_name"`
+	Method           InstallMethod `json:"install_method"`
+	InstalledVersion Version       `json:"installed_version"`
+	LatestVersion    *Version      `json:"latest_version,omitempty"`
+	ExecutablePath   string        `json:"executable_path"`
+	InstallPath      string        `json:"install_path,omitempty"`
+	IsGlobal         bool          `json:"is_global"`
+	Scope            InstallScope  `json:"scope,omitempty"`
+	Architecture     string        `json:"architecture,omitempty"`
+	DetectedAt       time.Time     `json:"detected_at"`
+	LastChecked      time.Time     `json:"last_checked"`
+	LastUsed         *time.Time    `json:"last_used,omitempty"`
+	UpdatedAt        *time.Time    `json:"updated_at,omitempty"`
+	RestartPending   bool          `json:"restart_pending,omitempty"`
+	// ConfiguredOnly marks agents whose config directory was found but
+	// whose installation was not: a leftover config from a removed
+	// install, suggesting a reinstall.
+	ConfiguredOnly bool `json:"configured_only,omitempty"`
+	// AuthStatus is the agent's sign-in state (AuthSignedIn or
+	// AuthNotSignedIn) when the catalog defines an auth check; empty
+	// when there is no check or it has not been run.
+	AuthStatus string            `json:"auth_status,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
 }
 
+// Sign-in states for Installation.AuthStatus.
+const (
+	AuthSignedIn    = "signed_in"
+	AuthNotSignedIn = "not_signed_in"
+)
+
+// RecentUpdateWindow is how long after an update an installation still
+// counts as recently updated for badges and filters.
+const RecentUpdateWindow = 24 * time.Hour
+
 // Key returns a unique identifier for this installation.
 func (i Installation) Key() string {
 	return fmt.Sprintf("%s:%s:%s", i.AgentID, i.Method, i.ExecutablePath)
@@ -101,6 +138,12 @@ func (i Installation) HasUpdate() bool {
 	return i.LatestVersion.IsNewerThan(i.InstalledVersion)
 }
 
+// RecentlyUpdated returns true if the installation was updated within the
+// recent-update window.
+func (i Installation) RecentlyUpdated() bool {
+	return i.UpdatedAt != nil && time.Since(*i.UpdatedAt) <= RecentUpdateWindow
+}
+
 // Status represents the current status of an installation.
 type Status string
 
@@ -111,10 +154,16 @@ const (
 	StatusError      Status = "error"
 	StatusInstalling Status = "installing"
 	StatusUpdating   Status = "updating"
+	// StatusConfigured marks agents detected only by a leftover config
+	// directory, with no installation found.
+	StatusConfigured Status = "configured"
 )
 
 // GetStatus returns the current status of the installation.
 func (i Installation) GetStatus() Status {
+	if i.ConfiguredOnly {
+		return StatusConfigured
+	}
 	if i.LatestVersion == nil {
 		return StatusUnknown
 	}
@@ -0,0 +1,35 @@
+package agent
+
+import "runtime"
+
+// Canonical architecture names recorded on detected installations.
+const (
+	// ArchAMD64 is a 64-bit x86 binary.
+	ArchAMD64 = "x86_64"
+
+	// ArchARM64 is a 64-bit ARM binary.
+	ArchARM64 = "arm64"
+
+	// ArchUniversal is a macOS universal binary containing both slices.
+	ArchUniversal = "universal"
+)
+
+// HostArchitecture returns the canonical name of the CPU architecture the
+// process runs on.
+func HostArchitecture() string {
+	switch runtime.GOARCH {
+	case "amd64":
+		return ArchAMD64
+	case "arm64":
+		return ArchARM64
+	default:
+		return runtime.GOARCH
+	}
+}
+
+// RunsEmulated reports whether the installation's binary would run under
+// emulation (e.g. Rosetta 2) on the given host architecture: an x86_64
+// binary on an arm64 host. Universal binaries run natively everywhere.
+func (i Installation) RunsEmulated(hostArch string) bool {
+	return i.Architecture == ArchAMD64 && hostArch == ArchARM64
+}
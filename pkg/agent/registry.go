@@ -114,6 +114,9 @@ type Filter struct {
 	// IsGlobal filters to only global or local installations.
 	IsGlobal *bool
 
+	// Scope limits results to user or system installations.
+	Scope InstallScope
+
 	// Query performs a text search across agent names and IDs.
 	Query string
 }
@@ -172,6 +175,11 @@ func (f Filter) Matches(inst Installation) bool {
 		}
 	}
 
+	// Check scope filter
+	if f.Scope != "" && inst.Scope != f.Scope {
+		return false
+	}
+
 	// Check query filter (case-insensitive)
 	if f.Query != "" {
 		query := strings.ToLower(f.Query)
@@ -0,0 +1,68 @@
+package agent
+
+import "strings"
+
+// InstallScope distinguishes per-user installations from system-wide ones.
+type InstallScope string
+
+const (
+	// ScopeUser marks an installation visible only to the current user.
+	ScopeUser InstallScope = "user"
+
+	// ScopeSystem marks an installation shared by all users of the machine.
+	ScopeSystem InstallScope = "system"
+)
+
+// systemPrefixes are directory prefixes that hold system-wide installs.
+// Windows paths are matched case-insensitively after normalization.
+var systemPrefixes = []string{
+	"/usr/",
+	"/opt/",
+	"/etc/",
+	"/snap/",
+	"/var/lib/flatpak/",
+	"/applications/",
+	"/library/",
+	"c:/program files/",
+	"c:/program files (x86)/",
+	"c:/programdata/",
+	"c:/windows/",
+}
+
+// ClassifyScope reports whether an executable path belongs to a per-user or
+// a system-wide installation. Paths under the user's home directory are
+// always user scope; well-known system prefixes are system scope; anything
+// else defaults to user scope.
+func ClassifyScope(execPath, homeDir string) InstallScope {
+	if execPath == "" {
+		return ScopeUser
+	}
+
+	normalized := normalizePath(execPath)
+	if !strings.HasSuffix(normalized, "/") {
+		normalized += "/"
+	}
+
+	if homeDir != "" {
+		home := normalizePath(homeDir)
+		if !strings.HasSuffix(home, "/") {
+			home += "/"
+		}
+		if strings.HasPrefix(normalized, home) {
+			return ScopeUser
+		}
+	}
+
+	for _, prefix := range systemPrefixes {
+		if strings.HasPrefix(normalized, prefix) {
+			return ScopeSystem
+		}
+	}
+	return ScopeUser
+}
+
+// normalizePath lowercases a path and converts both separator styles to
+// forward slashes so prefixes compare consistently across platforms.
+func normalizePath(p string) string {
+	return strings.ToLower(strings.ReplaceAll(p, `\`, "/"))
+}
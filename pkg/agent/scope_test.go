@@ -0,0 +1,48 @@
+package agent
+
+import "testing"
+
+func TestClassifyScope(t *testing.T) {
+	tests := []struct {
+		name     string
+		execPath string
+		homeDir  string
+		want     InstallScope
+	}{
+		{"usr local", "/usr/local/bin/claude", "/home/user", ScopeSystem},
+		{"opt", "/opt/agent/bin/agent", "/home/user", ScopeSystem},
+		{"snap", "/snap/bin/claude", "/home/user", ScopeSystem},
+		{"flatpak", "/var/lib/flatpak/exports/bin/claude", "/home/user", ScopeSystem},
+		{"home dot local", "/home/user/.local/bin/aider", "/home/user", ScopeUser},
+		{"home npm global", "/home/user/.npm-global/bin/claude", "/home/user", ScopeUser},
+		{"applications", "/Applications/Claude.app/Contents/MacOS/claude", "/Users/me", ScopeSystem},
+		{"program files", `C:\Program Files\Agent\agent.exe`, `C:\Users\me`, ScopeSystem},
+		{"windows user profile", `C:\Users\me\AppData\Local\agent.exe`, `C:\Users\me`, ScopeUser},
+		{"home under usr trumps system prefix", "/usr/home/me/bin/tool", "/usr/home/me", ScopeUser},
+		{"unknown location defaults to user", "/srv/tools/bin/agent", "/home/user", ScopeUser},
+		{"empty path", "", "/home/user", ScopeUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ClassifyScope(tt.execPath, tt.homeDir); got != tt.want {
+				t.Errorf("ClassifyScope(%q, %q) = %q, want %q", tt.execPath, tt.homeDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterScope(t *testing.T) {
+	userInst := Installation{AgentID: "claude-code", Scope: ScopeUser}
+	systemInst := Installation{AgentID: "claude-code", Scope: ScopeSystem}
+
+	if !(Filter{Scope: ScopeUser}).Matches(userInst) {
+		t.Error("user scope filter should match user installation")
+	}
+	if (Filter{Scope: ScopeUser}).Matches(systemInst) {
+		t.Error("user scope filter should not match system installation")
+	}
+	if !(Filter{}).Matches(systemInst) {
+		t.Error("empty scope filter should match any installation")
+	}
+}
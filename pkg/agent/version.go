@@ -20,6 +20,23 @@ type Version struct {
 // semverRegex matches semantic versions with optional v prefix.
 var semverRegex = regexp.MustCompile(`^v?(\d+)(?:\.(\d+))?(?:\.(\d+))?(?:-([0-9A-Za-z\-\.]+))?(?:\+([0-9A-Za-z\-\.]+))?$`)
 
+// numRegex extracts an embedded version number from strings like
+// "Cursor 0.42.3 (build 241016)" that are not bare semver.
+var numRegex = regexp.MustCompile(`(\d+(?:\.\d+)*(?:-[a-zA-Z0-9\.\-]+)?)`)
+
+// extractSemver returns the semver submatches for a version string,
+// falling back to extracting an embedded version number. Returns nil if
+// no version number can be found.
+func extractSemver(s string) []string {
+	matches := semverRegex.FindStringSubmatch(s)
+	if matches == nil {
+		if numMatch := numRegex.FindString(s); numMatch != "" {
+			matches = semverRegex.FindStringSubmatch(numMatch)
+		}
+	}
+	return matches
+}
+
 // ParseVersion parses a version string into a Version struct.
 // Handles formats: v1.2.3, 1.2.3, 1.2.3-beta.1, 1.2.3+build.123
 func ParseVersion(s string) (Version, error) {
@@ -28,17 +45,9 @@ func ParseVersion(s string) (Version, error) {
 		return Version{}, fmt.Errorf("empty version string")
 	}
 
-	matches := semverRegex.FindStringSubmatch(s)
+	matches := extractSemver(s)
 	if matches == nil {
-		// Try to extract just a version number from the string
-		numRegex := regexp.MustCompile(`(\d+(?:\.\d+)*(?:-[a-zA-Z0-9\.\-]+)?)`)
-		numMatch := numRegex.FindString(s)
-		if numMatch != "" {
-			matches = semverRegex.FindStringSubmatch(numMatch)
-		}
-		if matches == nil {
-			return Version{Raw: s}, nil // Return raw version if parsing fails
-		}
+		return Version{Raw: s}, nil // Return raw version if parsing fails
 	}
 
 	v := Version{Raw: s}
@@ -104,8 +113,32 @@ func (v Version) IsZero() bool {
 	return v.Major == 0 && v.Minor == 0 && v.Patch == 0 && v.Raw == ""
 }
 
+// IsSemver returns true if this version carries usable numeric components,
+// i.e. it was parsed from a string containing a version number rather than
+// kept as an opaque raw string.
+func (v Version) IsSemver() bool {
+	if v.Raw == "" {
+		return true // Constructed from components
+	}
+	return extractSemver(strings.TrimSpace(v.Raw)) != nil
+}
+
 // Compare returns -1, 0, or 1 for less than, equal, or greater than.
+// Versions whose raw string could not be parsed are ordered below any
+// parseable version and among themselves by natural string order, so
+// comparisons stay total and consistent.
 func (v Version) Compare(other Version) int {
+	vSemver, otherSemver := v.IsSemver(), other.IsSemver()
+	if !vSemver || !otherSemver {
+		if vSemver {
+			return 1
+		}
+		if otherSemver {
+			return -1
+		}
+		return compareNatural(v.Raw, other.Raw)
+	}
+
 	// Compare major version
 	if v.Major != other.Major {
 		return compareInt(v.Major, other.Major)
@@ -142,6 +175,40 @@ func (v Version) Equals(other Version) bool {
 	return v.Compare(other) == 0
 }
 
+// compareNatural compares two strings, treating runs of digits as numbers
+// so that "build-9" sorts before "build-10".
+func compareNatural(a, b string) int {
+	for a != "" && b != "" {
+		aRun, aRest, aNum := splitRun(a)
+		bRun, bRest, bNum := splitRun(b)
+
+		if aNum && bNum {
+			aVal, _ := strconv.Atoi(aRun)
+			bVal, _ := strconv.Atoi(bRun)
+			if cmp := compareInt(aVal, bVal); cmp != 0 {
+				return cmp
+			}
+		} else if cmp := strings.Compare(aRun, bRun); cmp != 0 {
+			return cmp
+		}
+
+		a, b = aRest, bRest
+	}
+	return compareInt(len(a), len(b))
+}
+
+// splitRun splits off the leading run of digits or non-digits from a
+// string and reports whether the run is numeric.
+func splitRun(s string) (run, rest string, numeric bool) {
+	numeric = s[0] >= '0' && s[0] <= '9'
+	for i := 0; i < len(s); i++ {
+		if (s[i] >= '0' && s[i] <= '9') != numeric {
+			return s[:i], s[i:], numeric
+		}
+	}
+	return s, "", numeric
+}
+
 // compareInt compares two integers and returns -1, 0, or 1.
 func compareInt(a, b int) int {
 	if a < b {
@@ -255,3 +322,170 @@ func (c VersionConstraint) Matches(v Version) bool {
 		return cmp == 0
 	}
 }
+
+// Constraints is a set of version constraints that must all match.
+type Constraints []VersionConstraint
+
+// Matches returns true if the given version satisfies every constraint in
+// the set. An empty set matches any version.
+func (cs Constraints) Matches(v Version) bool {
+	for _, c := range cs {
+		if !c.Matches(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseConstraint parses a version constraint expression into a constraint
+// set. Supported forms:
+//
+//	1.2.3            exact match
+//	>=1.2 <2.0       comparison operators, space or comma separated
+//	~1.2.3           patch-level changes (>=1.2.3 <1.3.0)
+//	^1.2             compatible changes (>=1.2.0 <2.0.0)
+//	1.2.x, 1.2.*     wildcard (>=1.2.0 <1.3.0)
+//	*                any version
+//
+// Tilde, caret, wildcard, and partial versions are expanded into >=/< pairs
+// based on how many components were specified, so "~1" and "~1.2" behave
+// like their npm equivalents.
+func ParseConstraint(s string) (Constraints, error) {
+	var cs Constraints
+
+	parts := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("empty constraint")
+	}
+
+	for _, part := range parts {
+		expanded, err := parseConstraintPart(part)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, expanded...)
+	}
+
+	return cs, nil
+}
+
+// parseConstraintPart parses a single constraint token into one or more
+// simple comparison constraints.
+func parseConstraintPart(s string) ([]VersionConstraint, error) {
+	op := ""
+	for _, candidate := range []string{">=", "<=", "==", "=", ">", "<", "~", "^"} {
+		if strings.HasPrefix(s, candidate) {
+			op = candidate
+			s = s[len(candidate):]
+			break
+		}
+	}
+
+	if s == "" {
+		return nil, fmt.Errorf("constraint %q has no version", op+s)
+	}
+
+	// Bare wildcard matches everything
+	if op == "" && (s == "*" || s == "x" || s == "X") {
+		return nil, nil
+	}
+
+	version, specified, err := parsePartialVersion(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid constraint version %q: %w", s, err)
+	}
+
+	switch op {
+	case ">", ">=", "<", "<=":
+		return []VersionConstraint{{Operator: op, Version: version}}, nil
+	case "=", "==":
+		return []VersionConstraint{{Operator: "=", Version: version}}, nil
+	case "~":
+		// ~1.2.3 allows patch changes; ~1 allows minor changes
+		var upper Version
+		if specified >= 2 {
+			upper = Version{Major: version.Major, Minor: version.Minor + 1}
+		} else {
+			upper = Version{Major: version.Major + 1}
+		}
+		return boundedRange(version, upper), nil
+	case "^":
+		// ^1.2.3 allows compatible changes; leading zeros pin the first
+		// non-zero component
+		var upper Version
+		switch {
+		case version.Major > 0 || specified == 1:
+			upper = Version{Major: version.Major + 1}
+		case version.Minor > 0 || specified == 2:
+			upper = Version{Minor: version.Minor + 1}
+		default:
+			upper = Version{Patch: version.Patch + 1}
+		}
+		return boundedRange(version, upper), nil
+	default:
+		// Bare version: partial and wildcard forms match a range,
+		// full versions match exactly
+		if specified >= 3 {
+			return []VersionConstraint{{Operator: "=", Version: version}}, nil
+		}
+		upper := Version{Major: version.Major + 1}
+		if specified == 2 {
+			upper = Version{Major: version.Major, Minor: version.Minor + 1}
+		}
+		return boundedRange(version, upper), nil
+	}
+}
+
+// parsePartialVersion parses a possibly partial version like "1.2",
+// returning the version and how many components were specified. Trailing
+// wildcard components ("1.2.x") are dropped.
+func parsePartialVersion(s string) (Version, int, error) {
+	s = strings.TrimPrefix(s, "v")
+
+	base := s
+	if i := strings.IndexAny(s, "-+"); i >= 0 {
+		base = s[:i]
+	}
+
+	components := strings.Split(base, ".")
+	specified := 0
+	for _, c := range components {
+		if c == "x" || c == "X" || c == "*" {
+			break
+		}
+		specified++
+	}
+	if specified == 0 {
+		return Version{}, 0, fmt.Errorf("no numeric components")
+	}
+
+	version, err := ParseVersion(strings.Join(components[:specified], "."))
+	if err != nil {
+		return Version{}, 0, err
+	}
+	if !version.IsSemver() {
+		return Version{}, 0, fmt.Errorf("not a semantic version")
+	}
+
+	// Re-attach prerelease/build from the original string for full versions
+	if specified >= 3 && base != s {
+		full, err := ParseVersion(s)
+		if err == nil && full.IsSemver() {
+			version = full
+		}
+	}
+	version.Raw = ""
+
+	return version, specified, nil
+}
+
+// boundedRange builds the >=lower <upper constraint pair used by tilde,
+// caret, and wildcard expansions.
+func boundedRange(lower, upper Version) []VersionConstraint {
+	return []VersionConstraint{
+		{Operator: ">=", Version: lower},
+		{Operator: "<", Version: upper},
+	}
+}
@@ -0,0 +1,109 @@
+package agent
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// VersionScheme identifies how an agent's version strings are parsed and
+// ordered. Most agents use semantic versioning, but some ship calendar
+// versions (2024.05.1) or plain build numbers that need different handling.
+type VersionScheme string
+
+const (
+	// SchemeSemver is standard semantic versioning (the default).
+	SchemeSemver VersionScheme = "semver"
+	// SchemeCalver is calendar versioning such as 2024.05.1 or 24-05-01.
+	SchemeCalver VersionScheme = "calver"
+	// SchemeRegex orders versions by the capture groups of an
+	// agent-supplied pattern, most significant group first.
+	SchemeRegex VersionScheme = "regex"
+)
+
+// ParseVersionScheme parses a version string according to the given scheme.
+// An empty or unknown scheme falls back to standard semver parsing, and the
+// pattern is only consulted for the regex scheme.
+func ParseVersionScheme(s string, scheme VersionScheme, pattern string) (Version, error) {
+	switch scheme {
+	case SchemeCalver:
+		return parseCalver(s)
+	case SchemeRegex:
+		return parseRegexVersion(s, pattern)
+	default:
+		return ParseVersion(s)
+	}
+}
+
+// calverSeparators normalizes the separators calendar versions use between
+// date components so they parse as dotted numeric versions.
+var calverSeparators = strings.NewReplacer("-", ".", "_", ".")
+
+// parseCalver parses a calendar version, normalizing separators so that
+// 2024-05-01 and 2024.05.1 order correctly by their date components.
+func parseCalver(s string) (Version, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Version{}, fmt.Errorf("empty version string")
+	}
+
+	v, err := ParseVersion(calverSeparators.Replace(s))
+	if err != nil {
+		return v, err
+	}
+	v.Raw = s
+	return v, nil
+}
+
+// parseRegexVersion parses a version using a custom pattern whose capture
+// groups are ordered from most to least significant. Numeric groups map to
+// major, minor, and patch; any remaining groups become prerelease
+// identifiers so they still participate in ordering.
+func parseRegexVersion(s, pattern string) (Version, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Version{}, fmt.Errorf("empty version string")
+	}
+	if pattern == "" {
+		return Version{}, fmt.Errorf("regex version scheme requires a pattern")
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid version pattern: %w", err)
+	}
+
+	matches := re.FindStringSubmatch(s)
+	if len(matches) < 2 {
+		return Version{Raw: s}, nil // Keep raw if the pattern doesn't match
+	}
+
+	v := Version{Raw: s}
+	groups := matches[1:]
+
+	numeric := [3]*int{&v.Major, &v.Minor, &v.Patch}
+	slot := 0
+	var extra []string
+
+	for _, group := range groups {
+		if group == "" {
+			continue
+		}
+		if slot < len(numeric) {
+			if n, err := strconv.Atoi(group); err == nil {
+				*numeric[slot] = n
+				slot++
+				continue
+			}
+		}
+		extra = append(extra, group)
+	}
+
+	if slot == 0 {
+		return Version{Raw: s}, nil
+	}
+	v.Prerelease = strings.Join(extra, ".")
+
+	return v, nil
+}
@@ -0,0 +1,98 @@
+package agent
+
+import "testing"
+
+func TestParseVersionSchemeCalver(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		major int
+		minor int
+		patch int
+	}{
+		{"dotted calver", "2024.05.1", 2024, 5, 1},
+		{"dashed calver", "2024-05-01", 2024, 5, 1},
+		{"underscore calver", "2024_05_01", 2024, 5, 1},
+		{"short year", "24.05", 24, 5, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ParseVersionScheme(tt.input, SchemeCalver, "")
+			if err != nil {
+				t.Fatalf("ParseVersionScheme(%q) error: %v", tt.input, err)
+			}
+			if v.Major != tt.major || v.Minor != tt.minor || v.Patch != tt.patch {
+				t.Errorf("ParseVersionScheme(%q) = %d.%d.%d, want %d.%d.%d",
+					tt.input, v.Major, v.Minor, v.Patch, tt.major, tt.minor, tt.patch)
+			}
+			if v.Raw != tt.input {
+				t.Errorf("expected raw string preserved, got %q", v.Raw)
+			}
+		})
+	}
+}
+
+func TestCalverOrdering(t *testing.T) {
+	older, _ := ParseVersionScheme("2024-05-01", SchemeCalver, "")
+	newer, _ := ParseVersionScheme("2024-11-02", SchemeCalver, "")
+
+	if !newer.IsNewerThan(older) {
+		t.Errorf("expected %s > %s under calver scheme", newer, older)
+	}
+}
+
+func TestParseVersionSchemeRegex(t *testing.T) {
+	pattern := `build (\d+) rev (\d+)`
+
+	older, err := ParseVersionScheme("build 41 rev 2", SchemeRegex, pattern)
+	if err != nil {
+		t.Fatalf("ParseVersionScheme error: %v", err)
+	}
+	newer, err := ParseVersionScheme("build 42 rev 1", SchemeRegex, pattern)
+	if err != nil {
+		t.Fatalf("ParseVersionScheme error: %v", err)
+	}
+
+	if older.Major != 41 || older.Minor != 2 {
+		t.Errorf("expected groups mapped to 41.2, got %d.%d", older.Major, older.Minor)
+	}
+	if !newer.IsNewerThan(older) {
+		t.Errorf("expected %s > %s under regex scheme", newer, older)
+	}
+}
+
+func TestParseVersionSchemeRegexNoMatch(t *testing.T) {
+	v, err := ParseVersionScheme("unversioned", SchemeRegex, `build (\d+)`)
+	if err != nil {
+		t.Fatalf("ParseVersionScheme error: %v", err)
+	}
+	if v.Raw != "unversioned" || v.IsSemver() {
+		t.Errorf("expected raw fallback for non-matching input, got %+v", v)
+	}
+}
+
+func TestParseVersionSchemeRegexInvalid(t *testing.T) {
+	if _, err := ParseVersionScheme("1.0", SchemeRegex, ""); err == nil {
+		t.Error("expected error for missing pattern")
+	}
+	if _, err := ParseVersionScheme("1.0", SchemeRegex, "("); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestParseVersionSchemeDefault(t *testing.T) {
+	v, err := ParseVersionScheme("v1.2.3", SchemeSemver, "")
+	if err != nil {
+		t.Fatalf("ParseVersionScheme error: %v", err)
+	}
+	if v.Major != 1 || v.Minor != 2 || v.Patch != 3 {
+		t.Errorf("expected 1.2.3, got %s", v)
+	}
+
+	// Empty scheme falls back to semver
+	v, err = ParseVersionScheme("1.2.3", "", "")
+	if err != nil || v.Major != 1 {
+		t.Errorf("expected semver fallback for empty scheme, got %s (err %v)", v, err)
+	}
+}
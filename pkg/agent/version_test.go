@@ -375,3 +375,122 @@ func TestVersionConstraintMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVersionRealWorld(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		major int
+		minor int
+		patch int
+	}{
+		{"npm package version", "2.0.14", 2, 0, 14},
+		{"tagged release", "v0.42.3", 0, 42, 3},
+		{"cli banner", "Cursor 0.42.3 (build 241016)", 0, 42, 3},
+		{"versioned tool output", "aider 0.59.1", 0, 59, 1},
+		{"date-based version", "2024.1.15", 2024, 1, 15},
+		{"nightly prerelease", "1.85.0-nightly.20240101", 1, 85, 0},
+		{"two components", "1.5", 1, 5, 0},
+		{"single component", "7", 7, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := ParseVersion(tt.input)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) error: %v", tt.input, err)
+			}
+			if !v.IsSemver() {
+				t.Errorf("ParseVersion(%q).IsSemver() = false, want true", tt.input)
+			}
+			if v.Major != tt.major || v.Minor != tt.minor || v.Patch != tt.patch {
+				t.Errorf("ParseVersion(%q) = %d.%d.%d, want %d.%d.%d",
+					tt.input, v.Major, v.Minor, v.Patch, tt.major, tt.minor, tt.patch)
+			}
+		})
+	}
+}
+
+func TestCompareRawVersions(t *testing.T) {
+	raw := func(s string) Version { return Version{Raw: s} }
+
+	// Unparseable versions order below any parseable version
+	if raw("unknown").Compare(MustParseVersion("0.0.1")) != -1 {
+		t.Error("expected raw version to compare below parseable version")
+	}
+	if MustParseVersion("0.0.1").Compare(raw("unknown")) != 1 {
+		t.Error("expected parseable version to compare above raw version")
+	}
+
+	// Raw versions compare among themselves in natural order
+	if raw("alpha").Compare(raw("beta")) != -1 {
+		t.Error("expected alpha < beta in natural order")
+	}
+	if raw("abc").Compare(raw("abc")) != 0 {
+		t.Error("expected identical raw versions to compare equal")
+	}
+
+	// Natural ordering treats digit runs as numbers
+	if compareNatural("build-9", "build-10") != -1 {
+		t.Error("expected build-9 < build-10 in natural order")
+	}
+	if compareNatural("build", "build-1") != -1 {
+		t.Error("expected shorter string to compare lower")
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint string
+		version    string
+		expected   bool
+	}{
+		{"exact match", "1.2.3", "1.2.3", true},
+		{"exact mismatch", "1.2.3", "1.2.4", false},
+		{"tilde patch ok", "~1.2.3", "1.2.9", true},
+		{"tilde minor bump blocked", "~1.2.3", "1.3.0", false},
+		{"tilde partial allows minor patch", "~1.2", "1.2.5", true},
+		{"tilde major only allows minor", "~1", "1.9.0", true},
+		{"tilde major only blocks major", "~1", "2.0.0", false},
+		{"caret minor ok", "^1.2", "1.9.0", true},
+		{"caret major bump blocked", "^1.2", "2.0.0", false},
+		{"caret below lower bound", "^1.2", "1.1.9", false},
+		{"caret zero major pins minor", "^0.2.3", "0.3.0", false},
+		{"caret zero major allows patch", "^0.2.3", "0.2.9", true},
+		{"caret zero zero pins patch", "^0.0.3", "0.0.4", false},
+		{"wildcard patch", "1.2.x", "1.2.7", true},
+		{"wildcard patch blocked", "1.2.x", "1.3.0", false},
+		{"wildcard star", "1.2.*", "1.2.7", true},
+		{"bare partial acts as wildcard", "1.2", "1.2.7", true},
+		{"bare partial blocked", "1.2", "1.3.0", false},
+		{"match anything", "*", "99.0.0", true},
+		{"range pair", ">=1.2 <2.0", "1.5.0", true},
+		{"range pair upper excluded", ">=1.2 <2.0", "2.0.0", false},
+		{"comma separated range", ">=1.2, <2.0", "1.5.0", true},
+		{"greater or equal boundary", ">=1.2.3", "1.2.3", true},
+		{"less than with prerelease", "<1.0.0", "1.0.0-alpha", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := ParseConstraint(tt.constraint)
+			if err != nil {
+				t.Fatalf("ParseConstraint(%q) error: %v", tt.constraint, err)
+			}
+			v := MustParseVersion(tt.version)
+			if got := c.Matches(v); got != tt.expected {
+				t.Errorf("ParseConstraint(%q).Matches(%q) = %v, want %v",
+					tt.constraint, tt.version, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseConstraintInvalid(t *testing.T) {
+	for _, input := range []string{"", "   ", ">=", "^not-a-version"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("ParseConstraint(%q) expected error", input)
+		}
+	}
+}
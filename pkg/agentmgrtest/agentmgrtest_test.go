@@ -0,0 +1,118 @@
+package agentmgrtest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+func TestFakePlatform(t *testing.T) {
+	p := NewFakePlatform().AddExecutable("npm", "/usr/local/bin/npm")
+
+	if !p.IsExecutableInPath("npm") {
+		t.Error("expected npm in path")
+	}
+	if path, err := p.FindExecutable("npm"); err != nil || path != "/usr/local/bin/npm" {
+		t.Errorf("FindExecutable = %q, %v", path, err)
+	}
+	if _, err := p.FindExecutable("missing"); err == nil {
+		t.Error("expected error for missing executable")
+	}
+
+	if err := p.ShowNotification("Update", "npm updated"); err != nil {
+		t.Fatalf("ShowNotification: %v", err)
+	}
+	if got := p.Notifications(); len(got) != 1 || got[0] != "Update: npm updated" {
+		t.Errorf("Notifications = %v", got)
+	}
+}
+
+func TestFakeStoreInstallations(t *testing.T) {
+	ctx := context.Background()
+	s := NewFakeStore()
+
+	inst := &agent.Installation{
+		AgentID:        "claude-code",
+		AgentName:      "Claude Code",
+		Method:         agent.MethodNPM,
+		ExecutablePath: "/usr/local/bin/claude",
+	}
+	if err := s.SaveInstallation(ctx, inst); err != nil {
+		t.Fatalf("SaveInstallation: %v", err)
+	}
+
+	got, err := s.GetInstallation(ctx, inst.Key())
+	if err != nil || got == nil || got.AgentID != "claude-code" {
+		t.Fatalf("GetInstallation = %+v, %v", got, err)
+	}
+
+	// Missing keys return nil without error, matching the SQLite store
+	if got, err := s.GetInstallation(ctx, "missing"); err != nil || got != nil {
+		t.Errorf("expected nil for missing key, got %+v, %v", got, err)
+	}
+
+	listed, err := s.ListInstallations(ctx, &agent.Filter{AgentID: "claude-code"})
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("ListInstallations = %v, %v", listed, err)
+	}
+
+	if err := s.DeleteInstallation(ctx, inst.Key()); err != nil {
+		t.Fatalf("DeleteInstallation: %v", err)
+	}
+	if err := s.DeleteInstallation(ctx, inst.Key()); err == nil {
+		t.Error("expected error deleting missing installation")
+	}
+}
+
+func TestFakeStoreUpdateHistory(t *testing.T) {
+	ctx := context.Background()
+	s := NewFakeStore()
+
+	event := &storage.UpdateEvent{
+		AgentID:   "claude-code",
+		AgentName: "Claude Code",
+		Status:    storage.UpdateStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := s.SaveUpdateEvent(ctx, event); err != nil {
+		t.Fatalf("SaveUpdateEvent: %v", err)
+	}
+	if event.ID == 0 {
+		t.Fatal("expected assigned event ID")
+	}
+
+	event.Status = storage.UpdateStatusCompleted
+	if err := s.SaveUpdateEvent(ctx, event); err != nil {
+		t.Fatalf("SaveUpdateEvent update: %v", err)
+	}
+
+	history, err := s.GetUpdateHistory(ctx, "claude-code", 10)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("GetUpdateHistory = %v, %v", history, err)
+	}
+	if history[0].Status != storage.UpdateStatusCompleted {
+		t.Errorf("Status = %s, want completed", history[0].Status)
+	}
+}
+
+func TestFakeStrategy(t *testing.T) {
+	strategy := &FakeStrategy{
+		InstallMethod: agent.MethodNPM,
+		Installations: []*agent.Installation{{AgentID: "claude-code"}},
+	}
+
+	if strategy.Name() != "fake" || strategy.Method() != agent.MethodNPM {
+		t.Errorf("unexpected identity: %s/%s", strategy.Name(), strategy.Method())
+	}
+	if !strategy.IsApplicable(NewFakePlatform()) {
+		t.Error("expected default strategy to be applicable")
+	}
+
+	found, err := strategy.Detect(context.Background(), nil)
+	if err != nil || len(found) != 1 {
+		t.Errorf("Detect = %v, %v", found, err)
+	}
+}
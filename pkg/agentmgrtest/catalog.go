@@ -0,0 +1,37 @@
+package agentmgrtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// NewCatalog builds a minimal valid catalog from agent definitions, for
+// use as a fixture or behind NewCatalogServer.
+func NewCatalog(agents ...catalog.AgentDef) *catalog.Catalog {
+	cat := &catalog.Catalog{
+		Version:       "test",
+		SchemaVersion: 1,
+		LastUpdated:   time.Now().UTC(),
+		Agents:        make(map[string]catalog.AgentDef, len(agents)),
+	}
+	for _, a := range agents {
+		cat.Agents[a.ID] = a
+	}
+	return cat
+}
+
+// NewCatalogServer starts an HTTP server that serves the catalog as JSON,
+// acting as a fake remote catalog source. Point the config's catalog
+// SourceURL at the server's URL and close it when the test finishes.
+func NewCatalogServer(cat *catalog.Catalog) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(cat); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	}))
+}
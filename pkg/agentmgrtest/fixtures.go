@@ -0,0 +1,50 @@
+package agentmgrtest
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+)
+
+// Recorded real-world package manager outputs, one file per tool and
+// version, for golden tests of detection strategy parsing. They are
+// exported so downstream strategy plugins can exercise their parsers
+// against the same inputs.
+//
+//go:embed fixtures
+var fixtureFS embed.FS
+
+// Fixture names for the recorded outputs.
+const (
+	FixtureNPMListV10     = "npm-ls-g-10.json"      // npm 10 `npm ls -g --depth=0 --json`
+	FixtureNPMListV8      = "npm-ls-g-8.json"       // npm 8 `npm ls -g --depth=0 --json`
+	FixturePipListV24     = "pip-list-24.json"      // pip 24 `pip list --format=json`
+	FixturePipxListV1     = "pipx-list-1.json"      // pipx 1.x `pipx list --json`
+	FixtureUVToolListText = "uv-tool-list-0.4.txt"  // uv 0.4 `uv tool list` text output
+	FixtureUVToolListJSON = "uv-tool-list-0.5.json" // uv 0.5 `uv tool list --format=json`
+	FixtureBrewInfoV2     = "brew-info-v2.json"     // `brew info --installed --json=v2`
+)
+
+// Fixture returns a recorded output by name. Unknown names panic: a
+// missing fixture is a bug in the test, not a runtime condition.
+func Fixture(name string) []byte {
+	data, err := fixtureFS.ReadFile("fixtures/" + name)
+	if err != nil {
+		panic(fmt.Sprintf("agentmgrtest: unknown fixture %q", name))
+	}
+	return data
+}
+
+// FixtureNames lists the available fixtures in sorted order.
+func FixtureNames() []string {
+	entries, err := fixtureFS.ReadDir("fixtures")
+	if err != nil {
+		return nil
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,152 @@
+// Package agentmgrtest provides ready-made fakes for the interfaces used
+// throughout agentmgr, so tests in higher layers and downstream consumers
+// do not need to re-implement them.
+package agentmgrtest
+
+import (
+	"context"
+	"os/exec"
+	"sync"
+
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// FakePlatform is a configurable in-memory platform.Platform. The zero
+// value is usable; NewFakePlatform fills in sensible defaults.
+type FakePlatform struct {
+	PlatformID   platform.ID
+	Arch         string
+	PlatformName string
+
+	DataDir    string
+	ConfigDir  string
+	CacheDir   string
+	LogDir     string
+	SocketPath string
+
+	// Executables maps executable names to their full paths; entries here
+	// are reported as present in PATH.
+	Executables map[string]string
+	PathDirs    []string
+
+	Shell    string
+	ShellArg string
+
+	AutoStartEnabled bool
+	AutoStartMethod  platform.AutoStartMethod
+
+	// Dialog is returned from ShowChangelogDialog.
+	Dialog platform.DialogResult
+
+	// DoNotDisturb is returned from IsDoNotDisturbEnabled.
+	DoNotDisturb bool
+	Elevated     bool
+
+	mu            sync.Mutex
+	notifications []string
+}
+
+// NewFakePlatform creates a fake platform resembling a macOS host.
+func NewFakePlatform() *FakePlatform {
+	return &FakePlatform{
+		PlatformID:      platform.Darwin,
+		Arch:            "amd64",
+		PlatformName:    "macOS",
+		DataDir:         "/tmp/data",
+		ConfigDir:       "/tmp/config",
+		CacheDir:        "/tmp/cache",
+		LogDir:          "/tmp/log",
+		SocketPath:      "/tmp/agentmgr.sock",
+		Executables:     make(map[string]string),
+		Shell:           "/bin/bash",
+		ShellArg:        "-c",
+		AutoStartMethod: platform.AutoStartNone,
+	}
+}
+
+// AddExecutable registers an executable as present in PATH.
+func (f *FakePlatform) AddExecutable(name, path string) *FakePlatform {
+	if f.Executables == nil {
+		f.Executables = make(map[string]string)
+	}
+	f.Executables[name] = path
+	return f
+}
+
+func (f *FakePlatform) ID() platform.ID      { return f.PlatformID }
+func (f *FakePlatform) Architecture() string { return f.Arch }
+func (f *FakePlatform) Name() string         { return f.PlatformName }
+
+func (f *FakePlatform) GetDataDir() string       { return f.DataDir }
+func (f *FakePlatform) GetConfigDir() string     { return f.ConfigDir }
+func (f *FakePlatform) GetCacheDir() string      { return f.CacheDir }
+func (f *FakePlatform) GetLogDir() string        { return f.LogDir }
+func (f *FakePlatform) GetIPCSocketPath() string { return f.SocketPath }
+
+func (f *FakePlatform) EnableAutoStart(ctx context.Context) error {
+	f.AutoStartEnabled = true
+	return nil
+}
+
+func (f *FakePlatform) DisableAutoStart(ctx context.Context) error {
+	f.AutoStartEnabled = false
+	return nil
+}
+
+func (f *FakePlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) {
+	return f.AutoStartEnabled, nil
+}
+
+func (f *FakePlatform) GetAutoStartMethod(ctx context.Context) (platform.AutoStartMethod, error) {
+	if !f.AutoStartEnabled {
+		return platform.AutoStartNone, nil
+	}
+	return f.AutoStartMethod, nil
+}
+
+func (f *FakePlatform) FindExecutable(name string) (string, error) {
+	if path, ok := f.Executables[name]; ok {
+		return path, nil
+	}
+	return "", exec.ErrNotFound
+}
+
+func (f *FakePlatform) FindExecutables(name string) ([]string, error) {
+	if path, ok := f.Executables[name]; ok {
+		return []string{path}, nil
+	}
+	return nil, nil
+}
+
+func (f *FakePlatform) IsExecutableInPath(name string) bool {
+	_, ok := f.Executables[name]
+	return ok
+}
+
+func (f *FakePlatform) GetPathDirs() []string { return f.PathDirs }
+
+func (f *FakePlatform) GetShell() string    { return f.Shell }
+func (f *FakePlatform) GetShellArg() string { return f.ShellArg }
+
+// ShowNotification records the notification instead of displaying it.
+func (f *FakePlatform) ShowNotification(title, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notifications = append(f.notifications, title+": "+message)
+	return nil
+}
+
+// IsDoNotDisturbEnabled reports the configured DoNotDisturb state.
+func (f *FakePlatform) IsDoNotDisturbEnabled() bool { return f.DoNotDisturb }
+func (f *FakePlatform) IsElevated() bool            { return f.Elevated }
+
+// Notifications returns the notifications shown so far.
+func (f *FakePlatform) Notifications() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]string(nil), f.notifications...)
+}
+
+func (f *FakePlatform) ShowChangelogDialog(agentName, fromVer, toVer, changelog string) platform.DialogResult {
+	return f.Dialog
+}
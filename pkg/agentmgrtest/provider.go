@@ -0,0 +1,89 @@
+package agentmgrtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// Compile-time checks that the fakes satisfy the interfaces they stand
+// in for.
+var (
+	_ storage.Store      = (*FakeStore)(nil)
+	_ providers.Provider = (*FakeProvider)(nil)
+)
+
+// FakeProvider is a scripted installer provider. Install and Update
+// return InstallResult, GetLatestVersion returns LatestVersion, and any
+// configured Err fails the corresponding call.
+type FakeProvider struct {
+	ProviderName  string
+	InstallMethod agent.InstallMethod
+	Available     bool
+
+	InstallResult *providers.Result
+	LatestVersion agent.Version
+	Err           error
+
+	// Uninstalled records the installations passed to Uninstall.
+	Uninstalled []*agent.Installation
+}
+
+// Name returns the provider name.
+func (p *FakeProvider) Name() string {
+	if p.ProviderName == "" {
+		return "fake"
+	}
+	return p.ProviderName
+}
+
+// Method returns the install method this provider handles.
+func (p *FakeProvider) Method() agent.InstallMethod {
+	return p.InstallMethod
+}
+
+// IsAvailable returns the scripted availability.
+func (p *FakeProvider) IsAvailable() bool {
+	return p.Available
+}
+
+// Install returns the scripted result.
+func (p *FakeProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*providers.Result, error) {
+	return p.result(agentDef)
+}
+
+// Update returns the scripted result.
+func (p *FakeProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*providers.Result, error) {
+	return p.result(agentDef)
+}
+
+// Uninstall records the installation.
+func (p *FakeProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	if p.Err != nil {
+		return p.Err
+	}
+	p.Uninstalled = append(p.Uninstalled, inst)
+	return nil
+}
+
+// GetLatestVersion returns the scripted latest version.
+func (p *FakeProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	if p.Err != nil {
+		return agent.Version{}, p.Err
+	}
+	return p.LatestVersion, nil
+}
+
+func (p *FakeProvider) result(agentDef catalog.AgentDef) (*providers.Result, error) {
+	if p.Err != nil {
+		return nil, p.Err
+	}
+	if p.InstallResult != nil {
+		return p.InstallResult, nil
+	}
+	return nil, fmt.Errorf("no install result configured for %s", agentDef.ID)
+}
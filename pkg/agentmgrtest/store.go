@@ -0,0 +1,276 @@
+package agentmgrtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// FakeStore is an in-memory storage.Store. It mirrors the SQLite store's
+// semantics for missing rows (nil results rather than errors) and is safe
+// for concurrent use.
+type FakeStore struct {
+	mu sync.Mutex
+
+	installations map[string]*agent.Installation
+	events        []*storage.UpdateEvent
+	nextEventID   int64
+	settings      map[string]string
+
+	catalogData     []byte
+	catalogETag     string
+	catalogCachedAt time.Time
+
+	detectionCache    []*agent.Installation
+	detectionCachedAt time.Time
+
+	jobs []*storage.Job
+}
+
+// NewFakeStore creates an empty in-memory store.
+func NewFakeStore() *FakeStore {
+	return &FakeStore{
+		installations: make(map[string]*agent.Installation),
+		settings:      make(map[string]string),
+		nextEventID:   1,
+	}
+}
+
+// Initialize implements storage.Store and is a no-op.
+func (s *FakeStore) Initialize(ctx context.Context) error { return nil }
+
+// Close implements storage.Store and is a no-op.
+func (s *FakeStore) Close() error { return nil }
+
+func (s *FakeStore) SaveInstallation(ctx context.Context, inst *agent.Installation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *inst
+	s.installations[inst.Key()] = &copied
+	return nil
+}
+
+func (s *FakeStore) GetInstallation(ctx context.Context, key string) (*agent.Installation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	inst, ok := s.installations[key]
+	if !ok {
+		return nil, nil
+	}
+	copied := *inst
+	return &copied, nil
+}
+
+func (s *FakeStore) ListInstallations(ctx context.Context, filter *agent.Filter) ([]*agent.Installation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*agent.Installation
+	for _, inst := range s.installations {
+		if filter != nil && !filter.Matches(*inst) {
+			continue
+		}
+		copied := *inst
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Key() < result[j].Key()
+	})
+	return result, nil
+}
+
+func (s *FakeStore) DeleteInstallation(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.installations[key]; !ok {
+		return fmt.Errorf("installation not found: %s", key)
+	}
+	delete(s.installations, key)
+	return nil
+}
+
+func (s *FakeStore) SaveUpdateEvent(ctx context.Context, event *storage.UpdateEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if event.ID == 0 {
+		event.ID = s.nextEventID
+		s.nextEventID++
+		copied := *event
+		s.events = append(s.events, &copied)
+		return nil
+	}
+
+	for i, existing := range s.events {
+		if existing.ID == event.ID {
+			copied := *event
+			s.events[i] = &copied
+			return nil
+		}
+	}
+	return fmt.Errorf("update event not found: %d", event.ID)
+}
+
+func (s *FakeStore) GetUpdateHistory(ctx context.Context, agentID string, limit int) ([]*storage.UpdateEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*storage.UpdateEvent
+	for _, event := range s.events {
+		if event.AgentID != agentID {
+			continue
+		}
+		copied := *event
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].StartedAt.After(result[j].StartedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *FakeStore) SaveCatalogCache(ctx context.Context, data []byte, etag string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.catalogData = append([]byte(nil), data...)
+	s.catalogETag = etag
+	s.catalogCachedAt = time.Now()
+	return nil
+}
+
+func (s *FakeStore) GetCatalogCache(ctx context.Context) ([]byte, string, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.catalogData == nil {
+		return nil, "", time.Time{}, nil
+	}
+	return append([]byte(nil), s.catalogData...), s.catalogETag, s.catalogCachedAt, nil
+}
+
+func (s *FakeStore) SaveDetectionCache(ctx context.Context, installations []*agent.Installation) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.detectionCache = nil
+	for _, inst := range installations {
+		copied := *inst
+		s.detectionCache = append(s.detectionCache, &copied)
+	}
+	s.detectionCachedAt = time.Now()
+	return nil
+}
+
+func (s *FakeStore) GetDetectionCache(ctx context.Context) ([]*agent.Installation, time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.detectionCache == nil {
+		return nil, time.Time{}, nil
+	}
+	result := make([]*agent.Installation, 0, len(s.detectionCache))
+	for _, inst := range s.detectionCache {
+		copied := *inst
+		result = append(result, &copied)
+	}
+	return result, s.detectionCachedAt, nil
+}
+
+func (s *FakeStore) ClearDetectionCache(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.detectionCache = nil
+	s.detectionCachedAt = time.Time{}
+	return nil
+}
+
+func (s *FakeStore) GetDetectionCacheTime(ctx context.Context) (time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.detectionCachedAt, nil
+}
+
+func (s *FakeStore) SetLastUpdateCheckTime(ctx context.Context, t time.Time) error {
+	return s.SetSetting(ctx, "last_update_check_time", t.Format(time.RFC3339))
+}
+
+func (s *FakeStore) GetLastUpdateCheckTime(ctx context.Context) (time.Time, error) {
+	val, err := s.GetSetting(ctx, "last_update_check_time")
+	if err != nil || val == "" {
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, val)
+}
+
+func (s *FakeStore) GetSetting(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.settings[key], nil
+}
+
+func (s *FakeStore) SetSetting(ctx context.Context, key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.settings[key] = value
+	return nil
+}
+
+func (s *FakeStore) DeleteSetting(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.settings, key)
+	return nil
+}
+
+func (s *FakeStore) SaveJob(ctx context.Context, job *storage.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	copied := *job
+	for i, existing := range s.jobs {
+		if existing.ID == job.ID {
+			s.jobs[i] = &copied
+			return nil
+		}
+	}
+	s.jobs = append(s.jobs, &copied)
+	return nil
+}
+
+func (s *FakeStore) GetJob(ctx context.Context, id string) (*storage.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, job := range s.jobs {
+		if job.ID == id {
+			copied := *job
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *FakeStore) ListJobs(ctx context.Context, state storage.JobState, limit int) ([]*storage.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result []*storage.Job
+	for _, job := range s.jobs {
+		if state != "" && job.State != state {
+			continue
+		}
+		copied := *job
+		result = append(result, &copied)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package agentmgrtest
+
+import (
+	"context"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// FakeStrategy is a scripted detection strategy for testing the detector
+// and anything layered on top of it.
+type FakeStrategy struct {
+	// StrategyName defaults to "fake" when empty.
+	StrategyName string
+	// InstallMethod is reported as the method this strategy detects.
+	InstallMethod agent.InstallMethod
+	// Applicable controls IsApplicable; the zero value makes the strategy
+	// applicable everywhere.
+	Applicable func(p platform.Platform) bool
+	// Installations are returned from Detect along with Err.
+	Installations []*agent.Installation
+	Err           error
+}
+
+// Name returns the strategy name.
+func (s *FakeStrategy) Name() string {
+	if s.StrategyName == "" {
+		return "fake"
+	}
+	return s.StrategyName
+}
+
+// Method returns the install method this strategy detects.
+func (s *FakeStrategy) Method() agent.InstallMethod {
+	return s.InstallMethod
+}
+
+// IsApplicable reports whether the strategy runs on the given platform.
+func (s *FakeStrategy) IsApplicable(p platform.Platform) bool {
+	if s.Applicable == nil {
+		return true
+	}
+	return s.Applicable(p)
+}
+
+// Detect returns the scripted installations.
+func (s *FakeStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	return s.Installations, s.Err
+}
@@ -0,0 +1,129 @@
+// Package discovery advertises and discovers AgentManager REST APIs on
+// the local network via mDNS (Bonjour). Helpers that opt in announce
+// their API port under a shared service type so fleet dashboards can
+// find every dev machine running agentmgr without manual host lists.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/libp2p/zeroconf/v2"
+)
+
+// ServiceType is the mDNS service type the REST API is advertised under.
+const ServiceType = "_agentmgr._tcp"
+
+// domain is the mDNS domain; "local." is the conventional LAN domain.
+const domain = "local."
+
+// Advertiser announces the REST API over mDNS until closed.
+type Advertiser struct {
+	server *zeroconf.Server
+}
+
+// Advertise announces the REST API on the given port. The instance name
+// identifies this machine on dashboards; empty defaults to the
+// hostname. The version string is included as a TXT record.
+func Advertise(instance string, port int, version string) (*Advertiser, error) {
+	if instance == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine hostname: %w", err)
+		}
+		instance = hostname
+	}
+
+	text := []string{"api=rest", "path=/api/v1"}
+	if version != "" {
+		text = append(text, "version="+version)
+	}
+
+	server, err := zeroconf.Register(instance, ServiceType, domain, port, text, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register mDNS service: %w", err)
+	}
+
+	return &Advertiser{server: server}, nil
+}
+
+// Close withdraws the advertisement.
+func (a *Advertiser) Close() {
+	if a.server != nil {
+		a.server.Shutdown()
+	}
+}
+
+// Host is a discovered machine advertising an AgentManager REST API.
+type Host struct {
+	// Instance is the advertised instance name, usually the hostname
+	Instance string `json:"instance"`
+
+	// Hostname is the machine's DNS name on the local network
+	Hostname string `json:"hostname"`
+
+	// Addresses lists the machine's IPv4 and IPv6 addresses
+	Addresses []string `json:"addresses"`
+
+	// Port is the REST API port
+	Port int `json:"port"`
+
+	// Version is the advertised agentmgr version, when present
+	Version string `json:"version,omitempty"`
+}
+
+// URL returns the base URL of the host's REST API, preferring the first
+// advertised address.
+func (h Host) URL() string {
+	if len(h.Addresses) == 0 {
+		return fmt.Sprintf("http://%s:%d", strings.TrimSuffix(h.Hostname, "."), h.Port)
+	}
+	return fmt.Sprintf("http://%s", net.JoinHostPort(h.Addresses[0], fmt.Sprintf("%d", h.Port)))
+}
+
+// Discover browses the local network for advertised APIs until the
+// context expires, returning every host seen. Callers bound the scan
+// with a context timeout.
+func Discover(ctx context.Context) ([]Host, error) {
+	entries := make(chan *zeroconf.ServiceEntry)
+
+	var hosts []Host
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for entry := range entries {
+			hosts = append(hosts, entryToHost(entry))
+		}
+	}()
+
+	if err := zeroconf.Browse(ctx, ServiceType, domain, entries); err != nil {
+		return nil, fmt.Errorf("mDNS browse failed: %w", err)
+	}
+	<-done
+
+	return hosts, nil
+}
+
+// entryToHost converts an mDNS service entry into a Host.
+func entryToHost(entry *zeroconf.ServiceEntry) Host {
+	host := Host{
+		Instance: entry.Instance,
+		Hostname: entry.HostName,
+		Port:     entry.Port,
+	}
+	for _, ip := range entry.AddrIPv4 {
+		host.Addresses = append(host.Addresses, ip.String())
+	}
+	for _, ip := range entry.AddrIPv6 {
+		host.Addresses = append(host.Addresses, ip.String())
+	}
+	for _, txt := range entry.Text {
+		if v, ok := strings.CutPrefix(txt, "version="); ok {
+			host.Version = v
+		}
+	}
+	return host
+}
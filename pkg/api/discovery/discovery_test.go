@@ -0,0 +1,66 @@
+package discovery
+
+import (
+	"net"
+	"testing"
+
+	"github.com/libp2p/zeroconf/v2"
+)
+
+func TestHostURL(t *testing.T) {
+	tests := []struct {
+		name string
+		host Host
+		want string
+	}{
+		{
+			name: "prefers first address",
+			host: Host{Hostname: "dev1.local.", Addresses: []string{"192.168.1.10"}, Port: 8080},
+			want: "http://192.168.1.10:8080",
+		},
+		{
+			name: "ipv6 address is bracketed",
+			host: Host{Addresses: []string{"fe80::1"}, Port: 8080},
+			want: "http://[fe80::1]:8080",
+		},
+		{
+			name: "falls back to hostname",
+			host: Host{Hostname: "dev1.local.", Port: 9090},
+			want: "http://dev1.local:9090",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.host.URL(); got != tt.want {
+				t.Errorf("URL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntryToHost(t *testing.T) {
+	entry := &zeroconf.ServiceEntry{
+		HostName: "dev1.local.",
+		Port:     8080,
+		Text:     []string{"api=rest", "version=1.2.3"},
+		AddrIPv4: []net.IP{net.ParseIP("192.168.1.10")},
+		AddrIPv6: []net.IP{net.ParseIP("fe80::1")},
+	}
+	entry.Instance = "dev1"
+
+	host := entryToHost(entry)
+
+	if host.Instance != "dev1" {
+		t.Errorf("Instance = %q, want %q", host.Instance, "dev1")
+	}
+	if host.Port != 8080 {
+		t.Errorf("Port = %d, want 8080", host.Port)
+	}
+	if host.Version != "1.2.3" {
+		t.Errorf("Version = %q, want %q", host.Version, "1.2.3")
+	}
+	if len(host.Addresses) != 2 || host.Addresses[0] != "192.168.1.10" {
+		t.Errorf("Addresses = %v", host.Addresses)
+	}
+}
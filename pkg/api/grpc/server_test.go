@@ -16,17 +16,20 @@ import (
 // mockPlatform implements platform.Platform for testing
 type mockPlatform struct{}
 
-func (m *mockPlatform) ID() platform.ID                                             { return platform.Darwin }
-func (m *mockPlatform) Architecture() string                                        { return "amd64" }
-func (m *mockPlatform) Name() string                                                { return "macOS" }
-func (m *mockPlatform) GetDataDir() string                                          { return "/tmp/data" }
-func (m *mockPlatform) GetConfigDir() string                                        { return "/tmp/config" }
-func (m *mockPlatform) GetCacheDir() string                                         { return "/tmp/cache" }
-func (m *mockPlatform) GetLogDir() string                                           { return "/tmp/log" }
-func (m *mockPlatform) GetIPCSocketPath() string                                    { return "/tmp/agentmgr.sock" }
-func (m *mockPlatform) EnableAutoStart(ctx context.Context) error                   { return nil }
-func (m *mockPlatform) DisableAutoStart(ctx context.Context) error                  { return nil }
-func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error)        { return false, nil }
+func (m *mockPlatform) ID() platform.ID                                      { return platform.Darwin }
+func (m *mockPlatform) Architecture() string                                 { return "amd64" }
+func (m *mockPlatform) Name() string                                         { return "macOS" }
+func (m *mockPlatform) GetDataDir() string                                   { return "/tmp/data" }
+func (m *mockPlatform) GetConfigDir() string                                 { return "/tmp/config" }
+func (m *mockPlatform) GetCacheDir() string                                  { return "/tmp/cache" }
+func (m *mockPlatform) GetLogDir() string                                    { return "/tmp/log" }
+func (m *mockPlatform) GetIPCSocketPath() string                             { return "/tmp/agentmgr.sock" }
+func (m *mockPlatform) EnableAutoStart(ctx context.Context) error            { return nil }
+func (m *mockPlatform) DisableAutoStart(ctx context.Context) error           { return nil }
+func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) { return false, nil }
+func (m *mockPlatform) GetAutoStartMethod(ctx context.Context) (platform.AutoStartMethod, error) {
+	return platform.AutoStartNone, nil
+}
 func (m *mockPlatform) FindExecutable(name string) (string, error)                  { return "", nil }
 func (m *mockPlatform) FindExecutables(name string) ([]string, error)               { return nil, nil }
 func (m *mockPlatform) IsExecutableInPath(name string) bool                         { return false }
@@ -34,6 +37,8 @@ func (m *mockPlatform) GetPathDirs() []string
 func (m *mockPlatform) GetShell() string                                            { return "/bin/bash" }
 func (m *mockPlatform) GetShellArg() string                                         { return "-c" }
 func (m *mockPlatform) ShowNotification(title, message string) error                { return nil }
+func (m *mockPlatform) IsDoNotDisturbEnabled() bool                                 { return false }
+func (m *mockPlatform) IsElevated() bool                                            { return false }
 func (m *mockPlatform) ShowChangelogDialog(a, b, c, d string) platform.DialogResult { return 0 }
 
 // mockStore implements storage.Store for testing
@@ -72,6 +77,13 @@ func (m *mockStore) DeleteSetting(ctx context.Context, key string) error
 func (m *mockStore) SaveDetectionCache(ctx context.Context, installations []*agent.Installation) error {
 	return nil
 }
+func (m *mockStore) SaveJob(ctx context.Context, job *storage.Job) error { return nil }
+func (m *mockStore) GetJob(ctx context.Context, id string) (*storage.Job, error) {
+	return nil, nil
+}
+func (m *mockStore) ListJobs(ctx context.Context, state storage.JobState, limit int) ([]*storage.Job, error) {
+	return nil, nil
+}
 func (m *mockStore) GetDetectionCache(ctx context.Context) ([]*agent.Installation, time.Time, error) {
 	return nil, time.Time{}, nil
 }
@@ -0,0 +1,347 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+// Bulk operation handlers. A bulk job executes a list of actions
+// sequentially as a single tracked unit with per-item results, so fleet
+// tooling and the Manage Agents bulk buttons share one code path instead
+// of looping client-side.
+
+// bulkAction is one requested operation within a bulk job.
+type bulkAction struct {
+	AgentID string `json:"agent_id"`
+	Method  string `json:"method"`
+	Action  string `json:"action"`
+	Version string `json:"version,omitempty"`
+}
+
+// validate checks that the action is well-formed before the job starts.
+func (a bulkAction) validate() error {
+	if a.AgentID == "" {
+		return fmt.Errorf("agent_id is required")
+	}
+	switch a.Action {
+	case "install", "update", "uninstall":
+	case "downgrade":
+		if a.Version == "" {
+			return fmt.Errorf("version is required for downgrade")
+		}
+	default:
+		return fmt.Errorf("unknown action %q", a.Action)
+	}
+	return nil
+}
+
+// bulkItemResult tracks the outcome of one action in a job.
+type bulkItemResult struct {
+	AgentID string `json:"agent_id"`
+	Method  string `json:"method,omitempty"`
+	Action  string `json:"action"`
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Bulk job and item statuses.
+const (
+	bulkStatusPending   = "pending"
+	bulkStatusRunning   = "running"
+	bulkStatusCompleted = "completed"
+	bulkStatusFailed    = "failed"
+)
+
+// bulkJob is a tracked bulk operation. All fields are guarded by the
+// server's bulk mutex; handlers only ever see snapshots.
+type bulkJob struct {
+	ID         string
+	Status     string
+	Items      []bulkItemResult
+	Completed  int
+	Failed     int
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// snapshotBulkJob copies the job state into a response map while holding
+// the bulk mutex.
+func (s *Server) snapshotBulkJob(job *bulkJob) map[string]interface{} {
+	s.bulkMu.Lock()
+	defer s.bulkMu.Unlock()
+
+	items := make([]bulkItemResult, len(job.Items))
+	copy(items, job.Items)
+
+	snapshot := map[string]interface{}{
+		"id":         job.ID,
+		"status":     job.Status,
+		"total":      len(items),
+		"completed":  job.Completed,
+		"failed":     job.Failed,
+		"items":      items,
+		"started_at": job.StartedAt,
+	}
+	if !job.FinishedAt.IsZero() {
+		snapshot["finished_at"] = job.FinishedAt
+	}
+	return snapshot
+}
+
+func (s *Server) handleCreateBulkJob(w http.ResponseWriter, r *http.Request) {
+	if s.config.Policy.Lockdown {
+		s.respondError(w, http.StatusForbidden, s.config.LockdownReason(), nil)
+		return
+	}
+
+	var req struct {
+		Actions []bulkAction `json:"actions"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if len(req.Actions) == 0 {
+		s.respondError(w, http.StatusBadRequest, "No actions provided", nil)
+		return
+	}
+
+	if s.installer == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "Installer not available", nil)
+		return
+	}
+
+	for _, action := range req.Actions {
+		if err := action.validate(); err != nil {
+			s.respondError(w, http.StatusBadRequest, "Invalid action", err)
+			return
+		}
+	}
+
+	s.bulkMu.Lock()
+	s.bulkSeq++
+	job := &bulkJob{
+		ID:        fmt.Sprintf("bulk-%d", s.bulkSeq),
+		Status:    bulkStatusRunning,
+		Items:     make([]bulkItemResult, len(req.Actions)),
+		StartedAt: time.Now(),
+	}
+	for i, action := range req.Actions {
+		job.Items[i] = bulkItemResult{
+			AgentID: action.AgentID,
+			Method:  action.Method,
+			Action:  action.Action,
+			Status:  bulkStatusPending,
+		}
+	}
+	s.bulkJobs[job.ID] = job
+	s.bulkMu.Unlock()
+
+	// The job outlives the request; run it on a background context so
+	// the client disconnecting doesn't abort in-flight installs.
+	go s.runBulkJob(context.Background(), job, req.Actions)
+
+	s.respondJSON(w, http.StatusAccepted, map[string]interface{}{
+		"job": s.snapshotBulkJob(job),
+	})
+}
+
+func (s *Server) handleGetBulkJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	s.bulkMu.Lock()
+	job := s.bulkJobs[id]
+	s.bulkMu.Unlock()
+
+	if job == nil {
+		s.respondError(w, http.StatusNotFound, "Bulk job not found", nil)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"job": s.snapshotBulkJob(job),
+	})
+}
+
+// handleStreamBulkJob streams job progress as server-sent events until
+// the job finishes or the client disconnects.
+func (s *Server) handleStreamBulkJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	s.bulkMu.Lock()
+	job := s.bulkJobs[id]
+	s.bulkMu.Unlock()
+
+	if job == nil {
+		s.respondError(w, http.StatusNotFound, "Bulk job not found", nil)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.respondError(w, http.StatusInternalServerError, "Streaming not supported", nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		snapshot := s.snapshotBulkJob(job)
+		data, _ := json.Marshal(snapshot)
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+
+		status, _ := snapshot["status"].(string)
+		if status != bulkStatusRunning {
+			return
+		}
+
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runBulkJob executes the actions in order, recording per-item results.
+func (s *Server) runBulkJob(ctx context.Context, job *bulkJob, actions []bulkAction) {
+	for i, action := range actions {
+		s.setBulkItem(job, i, bulkStatusRunning, "", nil)
+
+		message, err := s.runBulkAction(ctx, action)
+		if err != nil {
+			s.setBulkItem(job, i, bulkStatusFailed, "", err)
+		} else {
+			s.setBulkItem(job, i, bulkStatusCompleted, message, nil)
+		}
+	}
+
+	s.bulkMu.Lock()
+	if job.Failed > 0 {
+		job.Status = bulkStatusFailed
+	} else {
+		job.Status = bulkStatusCompleted
+	}
+	job.FinishedAt = time.Now()
+	s.bulkMu.Unlock()
+}
+
+// setBulkItem records an item transition and keeps the job counters in
+// step.
+func (s *Server) setBulkItem(job *bulkJob, index int, status, message string, err error) {
+	s.bulkMu.Lock()
+	defer s.bulkMu.Unlock()
+
+	item := &job.Items[index]
+	item.Status = status
+	item.Message = message
+	if err != nil {
+		item.Error = err.Error()
+	}
+
+	switch status {
+	case bulkStatusCompleted:
+		job.Completed++
+	case bulkStatusFailed:
+		job.Failed++
+	}
+}
+
+// runBulkAction dispatches one action to the installer.
+func (s *Server) runBulkAction(ctx context.Context, action bulkAction) (string, error) {
+	agentDef, err := s.catalog.GetAgent(ctx, action.AgentID)
+	if err != nil {
+		return "", fmt.Errorf("agent not found in catalog: %w", err)
+	}
+
+	if action.Action == "install" {
+		methodDef, ok := agentDef.GetInstallMethod(action.Method)
+		if !ok {
+			return "", fmt.Errorf("install method %q not available", action.Method)
+		}
+		result, err := s.installer.Install(ctx, *agentDef, methodDef, false)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Installed version %s", result.Version), nil
+	}
+
+	// The remaining actions operate on an existing installation.
+	inst, err := s.findBulkInstallation(ctx, action)
+	if err != nil {
+		return "", err
+	}
+
+	methodDef, ok := agentDef.GetInstallMethod(string(inst.Method))
+	if !ok {
+		return "", fmt.Errorf("install method %q not available", inst.Method)
+	}
+
+	switch action.Action {
+	case "update":
+		fromVersion := inst.InstalledVersion.String()
+		result, err := s.installer.Update(ctx, inst, *agentDef, methodDef)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Updated from %s to %s", fromVersion, result.Version), nil
+
+	case "downgrade":
+		target, err := agent.ParseVersion(action.Version)
+		if err != nil {
+			return "", fmt.Errorf("invalid version %q: %w", action.Version, err)
+		}
+		result, err := s.installer.Downgrade(ctx, inst, *agentDef, methodDef, target)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Downgraded to %s", result.Version), nil
+
+	case "uninstall":
+		if err := s.installer.Uninstall(ctx, inst, methodDef); err != nil {
+			return "", err
+		}
+		return "Uninstalled", nil
+	}
+
+	return "", fmt.Errorf("unknown action %q", action.Action)
+}
+
+// findBulkInstallation locates the installation an action targets,
+// matching on agent ID and, when given, install method.
+func (s *Server) findBulkInstallation(ctx context.Context, action bulkAction) (*agent.Installation, error) {
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	agents, err := s.detector.DetectAll(ctx, agentDefs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect agents: %w", err)
+	}
+
+	for _, inst := range agents {
+		if inst.AgentID != action.AgentID {
+			continue
+		}
+		if action.Method != "" && string(inst.Method) != action.Method {
+			continue
+		}
+		return inst, nil
+	}
+
+	return nil, fmt.Errorf("agent %s is not installed", action.AgentID)
+}
@@ -0,0 +1,138 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBulkActionValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		action  bulkAction
+		wantErr bool
+	}{
+		{name: "install", action: bulkAction{AgentID: "claude-code", Method: "npm", Action: "install"}},
+		{name: "update", action: bulkAction{AgentID: "claude-code", Action: "update"}},
+		{name: "uninstall", action: bulkAction{AgentID: "claude-code", Action: "uninstall"}},
+		{name: "downgrade with version", action: bulkAction{AgentID: "claude-code", Action: "downgrade", Version: "1.0.0"}},
+		{name: "downgrade without version", action: bulkAction{AgentID: "claude-code", Action: "downgrade"}, wantErr: true},
+		{name: "missing agent", action: bulkAction{Action: "install"}, wantErr: true},
+		{name: "unknown action", action: bulkAction{AgentID: "claude-code", Action: "reinstall"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.action.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateBulkJobValidation(t *testing.T) {
+	server := setupTestServer()
+
+	tests := []struct {
+		name     string
+		body     string
+		wantCode int
+	}{
+		{name: "invalid body", body: "{", wantCode: http.StatusBadRequest},
+		{name: "no actions", body: `{"actions": []}`, wantCode: http.StatusBadRequest},
+		// The test server has no installer wired up
+		{name: "installer unavailable", body: `{"actions": [{"agent_id": "claude-code", "action": "update"}]}`, wantCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("POST", "/api/v1/bulk", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			server.router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Errorf("Status = %d, want %d", w.Code, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestGetBulkJob(t *testing.T) {
+	server := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/bulk/bulk-1", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("Status = %d, want %d for unknown job", w.Code, http.StatusNotFound)
+	}
+
+	server.bulkMu.Lock()
+	server.bulkJobs["bulk-1"] = &bulkJob{
+		ID:     "bulk-1",
+		Status: bulkStatusRunning,
+		Items: []bulkItemResult{
+			{AgentID: "claude-code", Action: "update", Status: bulkStatusCompleted},
+			{AgentID: "aider", Action: "update", Status: bulkStatusPending},
+		},
+		Completed: 1,
+	}
+	server.bulkMu.Unlock()
+
+	req = httptest.NewRequest("GET", "/api/v1/bulk/bulk-1", nil)
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		Job struct {
+			ID        string `json:"id"`
+			Status    string `json:"status"`
+			Total     int    `json:"total"`
+			Completed int    `json:"completed"`
+		} `json:"job"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Job.ID != "bulk-1" || resp.Job.Status != bulkStatusRunning {
+		t.Errorf("job = %+v", resp.Job)
+	}
+	if resp.Job.Total != 2 || resp.Job.Completed != 1 {
+		t.Errorf("progress = %d/%d, want 1/2", resp.Job.Completed, resp.Job.Total)
+	}
+}
+
+func TestStreamBulkJob(t *testing.T) {
+	server := setupTestServer()
+
+	server.bulkMu.Lock()
+	server.bulkJobs["bulk-2"] = &bulkJob{
+		ID:     "bulk-2",
+		Status: bulkStatusCompleted,
+		Items:  []bulkItemResult{{AgentID: "claude-code", Action: "update", Status: bulkStatusCompleted}},
+	}
+	server.bulkMu.Unlock()
+
+	req := httptest.NewRequest("GET", "/api/v1/bulk/bulk-2/events", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	if !strings.HasPrefix(w.Body.String(), "data: ") {
+		t.Errorf("body = %q, want SSE data frame", w.Body.String())
+	}
+}
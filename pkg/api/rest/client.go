@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client is an HTTP client for the REST API list endpoints, handling
+// pagination, filtering, and sparse field selection.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewClient creates a REST API client for the given base URL, e.g.
+// "http://127.0.0.1:8080".
+func NewClient(baseURL string) *Client {
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// SetHTTPClient overrides the underlying HTTP client.
+func (c *Client) SetHTTPClient(httpClient *http.Client) {
+	c.httpClient = httpClient
+}
+
+// ListOptions controls pagination, filtering, and field selection for the
+// list endpoints. The zero value requests everything.
+type ListOptions struct {
+	Limit  int
+	Offset int
+
+	// Method filters installations by install method (agents endpoint).
+	Method string
+	// HasUpdate filters installations by update availability (agents
+	// endpoint).
+	HasUpdate *bool
+	// Query performs a text search across names and IDs.
+	Query string
+
+	// Fields selects which fields each result should include.
+	Fields []string
+}
+
+// values encodes the options as query parameters.
+func (o ListOptions) values() url.Values {
+	values := url.Values{}
+	if o.Limit > 0 {
+		values.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Offset > 0 {
+		values.Set("offset", strconv.Itoa(o.Offset))
+	}
+	if o.Method != "" {
+		values.Set("method", o.Method)
+	}
+	if o.HasUpdate != nil {
+		values.Set("has_update", strconv.FormatBool(*o.HasUpdate))
+	}
+	if o.Query != "" {
+		values.Set("query", o.Query)
+	}
+	if len(o.Fields) > 0 {
+		values.Set("fields", strings.Join(o.Fields, ","))
+	}
+	return values
+}
+
+// Page is one page of list results. Items are maps because field
+// selection makes the shape request-dependent; Total is the count before
+// pagination so callers can page through.
+type Page struct {
+	Items []map[string]interface{} `json:"items"`
+	Total int                      `json:"total"`
+}
+
+// ListAgents lists detected agent installations.
+func (c *Client) ListAgents(ctx context.Context, opts ListOptions) (*Page, error) {
+	return c.list(ctx, "/api/v1/agents", opts)
+}
+
+// ListCatalog lists catalog agent definitions.
+func (c *Client) ListCatalog(ctx context.Context, opts ListOptions) (*Page, error) {
+	return c.list(ctx, "/api/v1/catalog", opts)
+}
+
+// list performs a GET against a list endpoint and decodes the page.
+func (c *Client) list(ctx context.Context, path string, opts ListOptions) (*Page, error) {
+	endpoint := c.baseURL + path
+	if query := opts.values().Encode(); query != "" {
+		endpoint += "?" + query
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Agents []map[string]interface{} `json:"agents"`
+		Total  int                      `json:"total"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &Page{Items: body.Agents, Total: body.Total}, nil
+}
@@ -0,0 +1,43 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/kevinelliott/agentmgr/pkg/digest"
+)
+
+// Update digest handlers
+
+func (s *Server) handleGetDigest(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	collector := digest.NewCollector(s.store)
+	events, err := collector.Events(ctx)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to load digest events", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, digest.Summarize(events))
+}
+
+func (s *Server) handleGetDigestReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	collector := digest.NewCollector(s.store)
+	events, err := collector.Events(ctx)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to load digest events", err)
+		return
+	}
+
+	summary := digest.Summarize(events)
+	switch r.URL.Query().Get("format") {
+	case "markdown", "md":
+		w.Header().Set("Content-Type", "text/markdown; charset=utf-8")
+		_, _ = w.Write([]byte(summary.Markdown()))
+	default:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(summary.HTML()))
+	}
+}
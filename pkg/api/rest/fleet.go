@@ -0,0 +1,33 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/kevinelliott/agentmgr/pkg/fleet"
+)
+
+// Fleet handlers
+
+func (s *Server) handleListFleetHosts(w http.ResponseWriter, r *http.Request) {
+	m := fleet.NewManager(s.config.Fleet)
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"hosts": m.Hosts(),
+		"total": len(m.Hosts()),
+	})
+}
+
+func (s *Server) handleFleetDetect(w http.ResponseWriter, r *http.Request) {
+	m := fleet.NewManager(s.config.Fleet)
+	if len(m.Hosts()) == 0 {
+		s.respondError(w, http.StatusBadRequest, "No fleet hosts configured", nil)
+		return
+	}
+
+	results := m.DetectAll(r.Context())
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"results": results,
+		"summary": fleet.Summarize(results),
+	})
+}
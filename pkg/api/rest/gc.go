@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/kevinelliott/agentmgr/pkg/gc"
+)
+
+// Garbage collection handlers
+
+func (s *Server) handleGCScan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	cat, err := s.catalog.Get(ctx)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to load catalog", err)
+		return
+	}
+
+	scanner := gc.NewScanner(s.platform)
+	orphans, err := scanner.Scan(ctx, cat)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Scan failed", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"orphans": orphans,
+		"total":   len(orphans),
+	})
+}
+
+func (s *Server) handleGCClean(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// Cleaning runs package manager uninstalls, so lockdown applies here
+	// just as it does to the agent endpoints.
+	if s.config.Policy.Lockdown {
+		s.respondError(w, http.StatusForbidden, s.config.LockdownReason(), nil)
+		return
+	}
+
+	var req struct {
+		Orphans []gc.Orphan `json:"orphans"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if len(req.Orphans) == 0 {
+		s.respondError(w, http.StatusBadRequest, "No orphans specified", nil)
+		return
+	}
+
+	scanner := gc.NewScanner(s.platform)
+	if err := scanner.Clean(ctx, req.Orphans); err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Cleanup failed", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"cleaned": len(req.Orphans),
+	})
+}
@@ -0,0 +1,494 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+	"github.com/kevinelliott/agentmgr/pkg/updater"
+)
+
+// GraphQL endpoint. Exposes the same data layer as the REST routes, but
+// lets clients resolve nested queries — an agent with its latest job and
+// changelog, for example — in a single round trip. Enabled with
+// api.enable_graphql; subscriptions are delivered as server-sent events
+// when the client asks for text/event-stream.
+
+// graphqlPollInterval is how often the events subscription checks the
+// job store for changes.
+const graphqlPollInterval = 2 * time.Second
+
+// buildGraphQLSchema constructs the schema over the server's store,
+// catalog, and detector. Field names match the snake_case keys used by
+// the REST payloads so clients can share field lists between the two.
+func (s *Server) buildGraphQLSchema() (graphql.Schema, error) {
+	jobType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Job",
+		Fields: graphql.Fields{
+			"id":          &graphql.Field{Type: graphql.String},
+			"type":        &graphql.Field{Type: graphql.String},
+			"agent_id":    &graphql.Field{Type: graphql.String},
+			"agent_name":  &graphql.Field{Type: graphql.String},
+			"method":      &graphql.Field{Type: graphql.String},
+			"version":     &graphql.Field{Type: graphql.String},
+			"state":       &graphql.Field{Type: graphql.String},
+			"error":       &graphql.Field{Type: graphql.String},
+			"output":      &graphql.Field{Type: graphql.String},
+			"created_at":  &graphql.Field{Type: graphql.DateTime},
+			"started_at":  &graphql.Field{Type: graphql.DateTime},
+			"finished_at": &graphql.Field{Type: graphql.DateTime},
+		},
+	})
+
+	eventType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "UpdateEvent",
+		Fields: graphql.Fields{
+			"kind":         &graphql.Field{Type: graphql.String},
+			"method":       &graphql.Field{Type: graphql.String},
+			"from_version": &graphql.Field{Type: graphql.String},
+			"to_version":   &graphql.Field{Type: graphql.String},
+			"status":       &graphql.Field{Type: graphql.String},
+			"started_at":   &graphql.Field{Type: graphql.DateTime},
+			"completed_at": &graphql.Field{Type: graphql.DateTime},
+			"retries":      &graphql.Field{Type: graphql.Int},
+			"error":        &graphql.Field{Type: graphql.String},
+		},
+	})
+
+	installMethodType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "InstallMethod",
+		Fields: graphql.Fields{
+			"method":    &graphql.Field{Type: graphql.String},
+			"package":   &graphql.Field{Type: graphql.String},
+			"command":   &graphql.Field{Type: graphql.String},
+			"platforms": &graphql.Field{Type: graphql.NewList(graphql.String)},
+		},
+	})
+
+	catalogAgentType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "CatalogAgent",
+		Fields: graphql.Fields{
+			"id":              &graphql.Field{Type: graphql.String},
+			"name":            &graphql.Field{Type: graphql.String},
+			"description":     &graphql.Field{Type: graphql.String},
+			"category":        &graphql.Field{Type: graphql.String},
+			"tags":            &graphql.Field{Type: graphql.NewList(graphql.String)},
+			"homepage":        &graphql.Field{Type: graphql.String},
+			"repository":      &graphql.Field{Type: graphql.String},
+			"install_methods": &graphql.Field{Type: graphql.NewList(installMethodType)},
+		},
+	})
+
+	agentType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Agent",
+		Fields: graphql.Fields{
+			"key":               &graphql.Field{Type: graphql.String},
+			"agent_id":          &graphql.Field{Type: graphql.String},
+			"agent_name":        &graphql.Field{Type: graphql.String},
+			"install_method":    &graphql.Field{Type: graphql.String},
+			"installed_version": &graphql.Field{Type: graphql.String},
+			"latest_version":    &graphql.Field{Type: graphql.String},
+			"executable_path":   &graphql.Field{Type: graphql.String},
+			"install_path":      &graphql.Field{Type: graphql.String},
+			"is_global":         &graphql.Field{Type: graphql.Boolean},
+			"has_update":        &graphql.Field{Type: graphql.Boolean},
+			"status":            &graphql.Field{Type: graphql.String},
+			"last_used":         &graphql.Field{Type: graphql.DateTime},
+			"latest_job": &graphql.Field{
+				Type:    jobType,
+				Resolve: s.resolveLatestJob,
+			},
+			"history": &graphql.Field{
+				Type: graphql.NewList(eventType),
+				Args: graphql.FieldConfigArgument{
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+				},
+				Resolve: s.resolveAgentHistory,
+			},
+			"changelog": &graphql.Field{
+				Type: graphql.String,
+				Args: graphql.FieldConfigArgument{
+					"from": &graphql.ArgumentConfig{Type: graphql.String},
+					"to":   &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: s.resolveAgentChangelog,
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"agents": &graphql.Field{
+				Type:    graphql.NewList(agentType),
+				Resolve: s.resolveAgents,
+			},
+			"agent": &graphql.Field{
+				Type: agentType,
+				Args: graphql.FieldConfigArgument{
+					"key": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: s.resolveAgent,
+			},
+			"catalog": &graphql.Field{
+				Type: graphql.NewList(catalogAgentType),
+				Args: graphql.FieldConfigArgument{
+					"query": &graphql.ArgumentConfig{Type: graphql.String},
+				},
+				Resolve: s.resolveCatalog,
+			},
+			"jobs": &graphql.Field{
+				Type: graphql.NewList(jobType),
+				Args: graphql.FieldConfigArgument{
+					"state": &graphql.ArgumentConfig{Type: graphql.String},
+					"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+				},
+				Resolve: s.resolveJobs,
+			},
+			"history": &graphql.Field{
+				Type: graphql.NewList(eventType),
+				Args: graphql.FieldConfigArgument{
+					"agent_id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+					"limit":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 20},
+				},
+				Resolve: s.resolveHistory,
+			},
+		},
+	})
+
+	subscriptionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Subscription",
+		Fields: graphql.Fields{
+			"events": &graphql.Field{
+				Type: jobType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source, nil
+				},
+				Subscribe: s.subscribeJobEvents,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query:        queryType,
+		Subscription: subscriptionType,
+	})
+}
+
+// Resolvers
+
+func (s *Server) resolveAgents(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+	agents, err := s.detectAgents(ctx, agentDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	agentDefMap := make(map[string]catalog.AgentDef, len(agentDefs))
+	for _, def := range agentDefs {
+		agentDefMap[def.ID] = def
+	}
+	updater.AnnotateUpdateState(ctx, s.store, agents, agentDefMap)
+
+	result := make([]map[string]interface{}, len(agents))
+	for i, inst := range agents {
+		result[i] = s.installationToMap(inst)
+	}
+	return result, nil
+}
+
+func (s *Server) resolveAgent(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	key, _ := p.Args["key"].(string)
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+	agents, err := s.detectAgents(ctx, agentDefs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, inst := range agents {
+		if inst.Key() == key {
+			return s.installationToMap(inst), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) resolveCatalog(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+
+	var agents []catalog.AgentDef
+	var err error
+	if query, _ := p.Args["query"].(string); query != "" {
+		agents, err = s.catalog.Search(ctx, query)
+	} else {
+		var cat *catalog.Catalog
+		cat, err = s.catalog.Get(ctx)
+		if err == nil {
+			agents = cat.GetAgents()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, len(agents))
+	for i := range agents {
+		result[i] = s.catalogAgentToMap(&agents[i])
+	}
+	return result, nil
+}
+
+func (s *Server) resolveJobs(p graphql.ResolveParams) (interface{}, error) {
+	state, _ := p.Args["state"].(string)
+	limit, _ := p.Args["limit"].(int)
+
+	jobList, err := s.store.ListJobs(p.Context, storage.JobState(state), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, len(jobList))
+	for i, job := range jobList {
+		result[i] = s.jobToMap(job)
+	}
+	return result, nil
+}
+
+func (s *Server) resolveHistory(p graphql.ResolveParams) (interface{}, error) {
+	agentID, _ := p.Args["agent_id"].(string)
+	limit, _ := p.Args["limit"].(int)
+	return s.historyEvents(p, agentID, limit)
+}
+
+// resolveLatestJob finds the most recent job recorded for the agent the
+// field is nested under.
+func (s *Server) resolveLatestJob(p graphql.ResolveParams) (interface{}, error) {
+	source, _ := p.Source.(map[string]interface{})
+	agentID, _ := source["agent_id"].(string)
+	if agentID == "" {
+		return nil, nil
+	}
+
+	jobList, err := s.store.ListJobs(p.Context, "", 50)
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobList {
+		if job.AgentID == agentID {
+			return s.jobToMap(job), nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Server) resolveAgentHistory(p graphql.ResolveParams) (interface{}, error) {
+	source, _ := p.Source.(map[string]interface{})
+	agentID, _ := source["agent_id"].(string)
+	limit, _ := p.Args["limit"].(int)
+	return s.historyEvents(p, agentID, limit)
+}
+
+// resolveAgentChangelog fetches the changelog between two versions,
+// defaulting to the installed and latest versions of the enclosing
+// agent. Missing changelogs resolve to an empty string rather than an
+// error so one agent without release notes does not fail the query.
+func (s *Server) resolveAgentChangelog(p graphql.ResolveParams) (interface{}, error) {
+	source, _ := p.Source.(map[string]interface{})
+	agentID, _ := source["agent_id"].(string)
+
+	from, _ := p.Args["from"].(string)
+	if from == "" {
+		from, _ = source["installed_version"].(string)
+	}
+	to, _ := p.Args["to"].(string)
+	if to == "" {
+		to, _ = source["latest_version"].(string)
+	}
+	if agentID == "" || from == "" || to == "" {
+		return "", nil
+	}
+
+	fromVer, err := agent.ParseVersion(from)
+	if err != nil {
+		return "", nil
+	}
+	toVer, err := agent.ParseVersion(to)
+	if err != nil {
+		return "", nil
+	}
+
+	changelog, err := s.catalog.GetChangelog(p.Context, agentID, fromVer, toVer)
+	if err != nil {
+		return "", nil
+	}
+	return changelog, nil
+}
+
+// historyEvents loads an agent's update history in the shape the
+// UpdateEvent type expects.
+func (s *Server) historyEvents(p graphql.ResolveParams, agentID string, limit int) (interface{}, error) {
+	if agentID == "" {
+		return nil, nil
+	}
+	if i := strings.Index(agentID, ":"); i >= 0 {
+		agentID = agentID[:i]
+	}
+
+	events, err := s.store.GetUpdateHistory(p.Context, agentID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]map[string]interface{}, len(events))
+	for i, event := range events {
+		entry := map[string]interface{}{
+			"kind":         event.Kind(),
+			"method":       event.InstallMethod,
+			"from_version": event.FromVersion,
+			"to_version":   event.ToVersion,
+			"status":       string(event.Status),
+			"started_at":   event.StartedAt,
+			"retries":      event.Retries,
+			"error":        event.ErrorMessage,
+		}
+		if event.CompletedAt != nil {
+			entry["completed_at"] = *event.CompletedAt
+		}
+		result[i] = entry
+	}
+	return result, nil
+}
+
+// subscribeJobEvents emits a job each time one appears or changes state,
+// polling the job store until the client disconnects.
+func (s *Server) subscribeJobEvents(p graphql.ResolveParams) (interface{}, error) {
+	ctx := p.Context
+	out := make(chan interface{})
+
+	go func() {
+		defer close(out)
+
+		seen := make(map[string]storage.JobState)
+		ticker := time.NewTicker(graphqlPollInterval)
+		defer ticker.Stop()
+
+		for {
+			jobList, err := s.store.ListJobs(ctx, "", 50)
+			if err == nil {
+				for i := len(jobList) - 1; i >= 0; i-- {
+					job := jobList[i]
+					if state, ok := seen[job.ID]; ok && state == job.State {
+						continue
+					}
+					seen[job.ID] = job.State
+
+					select {
+					case out <- s.jobToMap(job):
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// HTTP handler
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// handleGraphQL executes a GraphQL request. Queries respond with the
+// usual JSON envelope; clients that accept text/event-stream get a
+// subscription stream instead.
+func (s *Server) handleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+
+	if r.Method == http.MethodGet {
+		req.Query = r.URL.Query().Get("query")
+		req.OperationName = r.URL.Query().Get("operationName")
+		if vars := r.URL.Query().Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				s.respondError(w, http.StatusBadRequest, "Invalid variables parameter", err)
+				return
+			}
+		}
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			s.respondError(w, http.StatusBadRequest, "Invalid request body", err)
+			return
+		}
+	}
+
+	if req.Query == "" {
+		s.respondError(w, http.StatusBadRequest, "Missing GraphQL query", nil)
+		return
+	}
+
+	params := graphql.Params{
+		Schema:         s.graphqlSchema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        r.Context(),
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		s.streamGraphQL(w, r, params)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, graphql.Do(params))
+}
+
+// streamGraphQL runs a subscription and writes each result as a
+// server-sent event until the client disconnects.
+func (s *Server) streamGraphQL(w http.ResponseWriter, r *http.Request, params graphql.Params) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		s.respondError(w, http.StatusInternalServerError, "Streaming not supported", nil)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for result := range graphql.Subscribe(params) {
+		data, err := json.Marshal(result)
+		if err != nil {
+			continue
+		}
+		fmt.Fprintf(w, "data: %s\n\n", data)
+		flusher.Flush()
+
+		select {
+		case <-r.Context().Done():
+			return
+		default:
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func setupGraphQLTestServer() *Server {
+	cat := createTestCatalog()
+	catalogJSON, _ := json.Marshal(cat)
+
+	cfg := newTestConfig()
+	cfg.API.EnableGraphQL = true
+	store := &mockStore{catalogData: catalogJSON}
+	plat := &mockPlatform{}
+	catMgr := catalog.NewManager(cfg, store)
+
+	return NewServer(cfg, plat, store, nil, catMgr, nil)
+}
+
+func TestGraphQLCatalogQuery(t *testing.T) {
+	server := setupGraphQLTestServer()
+
+	body := strings.NewReader(`{"query": "{ catalog { id name install_methods { method } } }"}`)
+	req := httptest.NewRequest("POST", "/api/v1/graphql", body)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+
+	var resp struct {
+		Data struct {
+			Catalog []struct {
+				ID             string `json:"id"`
+				Name           string `json:"name"`
+				InstallMethods []struct {
+					Method string `json:"method"`
+				} `json:"install_methods"`
+			} `json:"catalog"`
+		} `json:"data"`
+		Errors []interface{} `json:"errors"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Errors) > 0 {
+		t.Fatalf("errors = %v", resp.Errors)
+	}
+	if len(resp.Data.Catalog) != 2 {
+		t.Fatalf("catalog agents = %d, want 2", len(resp.Data.Catalog))
+	}
+	for _, a := range resp.Data.Catalog {
+		if a.ID == "" || a.Name == "" {
+			t.Errorf("agent missing id or name: %+v", a)
+		}
+		if len(a.InstallMethods) == 0 {
+			t.Errorf("agent %s missing install methods", a.ID)
+		}
+	}
+}
+
+func TestGraphQLInvalidQuery(t *testing.T) {
+	server := setupGraphQLTestServer()
+
+	body := strings.NewReader(`{"query": "{ nonexistent }"}`)
+	req := httptest.NewRequest("POST", "/api/v1/graphql", body)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	// GraphQL reports resolution errors in the envelope, not the status
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		Errors []interface{} `json:"errors"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Errors) == 0 {
+		t.Error("expected errors for unknown field")
+	}
+}
+
+func TestGraphQLMissingQuery(t *testing.T) {
+	server := setupGraphQLTestServer()
+
+	req := httptest.NewRequest("POST", "/api/v1/graphql", strings.NewReader(`{}`))
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
+
+func TestGraphQLDisabledByDefault(t *testing.T) {
+	server := setupTestServer()
+
+	body := strings.NewReader(`{"query": "{ catalog { id } }"}`)
+	req := httptest.NewRequest("POST", "/api/v1/graphql", body)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code == http.StatusOK {
+		t.Errorf("Status = %d, want non-200 when GraphQL is disabled", w.Code)
+	}
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// Job handlers. Install, update, and uninstall operations are persisted
+// as jobs; these endpoints expose their state.
+
+func (s *Server) handleListJobs(w http.ResponseWriter, r *http.Request) {
+	params := parseListParams(r.URL.Query())
+	state := storage.JobState(r.URL.Query().Get("state"))
+
+	jobList, err := s.store.ListJobs(r.Context(), state, params.Limit)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to list jobs", err)
+		return
+	}
+
+	results := make([]map[string]interface{}, 0, len(jobList))
+	for _, job := range jobList {
+		results = append(results, selectFields(s.jobToMap(job), params.Fields))
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"jobs":  results,
+		"total": len(results),
+	})
+}
+
+func (s *Server) handleGetJob(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	job, err := s.store.GetJob(r.Context(), id)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to get job", err)
+		return
+	}
+	if job == nil {
+		s.respondError(w, http.StatusNotFound, "Job not found", nil)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"job": s.jobToMap(job),
+	})
+}
+
+func (s *Server) jobToMap(job *storage.Job) map[string]interface{} {
+	var startedAt, finishedAt interface{}
+	if job.StartedAt != nil {
+		startedAt = *job.StartedAt
+	}
+	if job.FinishedAt != nil {
+		finishedAt = *job.FinishedAt
+	}
+
+	return map[string]interface{}{
+		"id":          job.ID,
+		"type":        job.Type,
+		"agent_id":    job.AgentID,
+		"agent_name":  job.AgentName,
+		"method":      job.Method,
+		"version":     job.Version,
+		"state":       string(job.State),
+		"error":       job.Error,
+		"output":      job.Output,
+		"created_at":  job.CreatedAt,
+		"started_at":  startedAt,
+		"finished_at": finishedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package rest
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+// ListenAddress resolves the REST server's listen address from the API
+// configuration. Binding beyond loopback is an explicit opt-in via
+// api.allow_lan; without it a non-loopback bind address is forced back
+// to 127.0.0.1. The returned warning, when non-empty, should be
+// surfaced to the user.
+func ListenAddress(cfg config.APIConfig) (addr, warning string) {
+	host := cfg.RESTBindAddress
+	if host == "" {
+		host = "127.0.0.1"
+	}
+
+	if !isLoopbackHost(host) {
+		if !cfg.AllowLAN {
+			warning = fmt.Sprintf(
+				"rest_bind_address %q would expose the API beyond this machine; set api.allow_lan to allow it (listening on 127.0.0.1 instead)",
+				host)
+			host = "127.0.0.1"
+		} else {
+			warning = fmt.Sprintf("REST API is reachable from the network on %s:%d", host, cfg.RESTPort)
+		}
+	}
+
+	return net.JoinHostPort(host, fmt.Sprint(cfg.RESTPort)), warning
+}
+
+// isLoopbackHost reports whether host only accepts connections from
+// this machine. An empty host or a wildcard address binds every
+// interface and is not loopback.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func TestListenAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         config.APIConfig
+		wantAddr    string
+		wantWarning bool
+	}{
+		{
+			name:     "default loopback",
+			cfg:      config.APIConfig{RESTPort: 8080},
+			wantAddr: "127.0.0.1:8080",
+		},
+		{
+			name:     "explicit loopback",
+			cfg:      config.APIConfig{RESTPort: 9000, RESTBindAddress: "localhost"},
+			wantAddr: "localhost:9000",
+		},
+		{
+			name:     "ipv6 loopback",
+			cfg:      config.APIConfig{RESTPort: 8080, RESTBindAddress: "::1"},
+			wantAddr: "[::1]:8080",
+		},
+		{
+			name:        "lan bind without opt-in falls back to loopback",
+			cfg:         config.APIConfig{RESTPort: 8080, RESTBindAddress: "0.0.0.0"},
+			wantAddr:    "127.0.0.1:8080",
+			wantWarning: true,
+		},
+		{
+			name:        "lan bind with opt-in warns but binds",
+			cfg:         config.APIConfig{RESTPort: 8080, RESTBindAddress: "0.0.0.0", AllowLAN: true},
+			wantAddr:    "0.0.0.0:8080",
+			wantWarning: true,
+		},
+		{
+			name:        "specific interface with opt-in",
+			cfg:         config.APIConfig{RESTPort: 8080, RESTBindAddress: "192.168.1.10", AllowLAN: true},
+			wantAddr:    "192.168.1.10:8080",
+			wantWarning: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, warning := ListenAddress(tt.cfg)
+			if addr != tt.wantAddr {
+				t.Errorf("addr = %q, want %q", addr, tt.wantAddr)
+			}
+			if (warning != "") != tt.wantWarning {
+				t.Errorf("warning = %q, wantWarning %v", warning, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestListenAddressWarningMentionsOptIn(t *testing.T) {
+	_, warning := ListenAddress(config.APIConfig{RESTPort: 8080, RESTBindAddress: "0.0.0.0"})
+	if !strings.Contains(warning, "allow_lan") {
+		t.Errorf("warning %q should point at the allow_lan option", warning)
+	}
+}
@@ -0,0 +1,106 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kevinelliott/agentmgr/pkg/mcp"
+)
+
+// MCP configuration handlers
+
+func (s *Server) handleListMCPConfigs(w http.ResponseWriter, r *http.Request) {
+	configs := mcp.DetectConfigs()
+
+	result := make([]map[string]interface{}, len(configs))
+	for i, loc := range configs {
+		servers, _ := s.mcp.ListServers(loc)
+		result[i] = map[string]interface{}{
+			"agent_id":     loc.AgentID,
+			"name":         loc.Name,
+			"path":         loc.Path,
+			"server_count": len(servers),
+		}
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"configs": result,
+		"total":   len(result),
+	})
+}
+
+func (s *Server) handleListMCPServers(w http.ResponseWriter, r *http.Request) {
+	loc, ok := s.findMCPLocation(chi.URLParam(r, "agentID"))
+	if !ok {
+		s.respondError(w, http.StatusNotFound, "No MCP config known for agent", nil)
+		return
+	}
+
+	servers, err := s.mcp.ListServers(loc)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to read MCP config", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"agent_id": loc.AgentID,
+		"path":     loc.Path,
+		"servers":  servers,
+		"total":    len(servers),
+	})
+}
+
+func (s *Server) handleAddMCPServer(w http.ResponseWriter, r *http.Request) {
+	loc, ok := s.findMCPLocation(chi.URLParam(r, "agentID"))
+	if !ok {
+		s.respondError(w, http.StatusNotFound, "No MCP config known for agent", nil)
+		return
+	}
+
+	var server mcp.Server
+	if err := json.NewDecoder(r.Body).Decode(&server); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := s.mcp.AddServer(loc, server); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Failed to add MCP server", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "MCP server added",
+	})
+}
+
+func (s *Server) handleRemoveMCPServer(w http.ResponseWriter, r *http.Request) {
+	loc, ok := s.findMCPLocation(chi.URLParam(r, "agentID"))
+	if !ok {
+		s.respondError(w, http.StatusNotFound, "No MCP config known for agent", nil)
+		return
+	}
+
+	name := chi.URLParam(r, "name")
+	if err := s.mcp.RemoveServer(loc, name); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Failed to remove MCP server", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"message": "MCP server removed",
+	})
+}
+
+// findMCPLocation returns the MCP config location for the given agent ID.
+func (s *Server) findMCPLocation(agentID string) (mcp.ConfigLocation, bool) {
+	for _, loc := range s.mcp.Locations() {
+		if loc.AgentID == agentID {
+			return loc, true
+		}
+	}
+	return mcp.ConfigLocation{}, false
+}
@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// listParams holds the pagination and field-selection query parameters
+// shared by the REST list endpoints.
+type listParams struct {
+	Limit  int
+	Offset int
+	Fields []string
+}
+
+// parseListParams extracts limit, offset, and the comma-separated fields
+// parameter from a query string. Negative values are ignored.
+func parseListParams(query url.Values) listParams {
+	var p listParams
+
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 {
+		p.Limit = limit
+	}
+	if offset, err := strconv.Atoi(query.Get("offset")); err == nil && offset > 0 {
+		p.Offset = offset
+	}
+
+	if fields := query.Get("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				p.Fields = append(p.Fields, field)
+			}
+		}
+	}
+
+	return p
+}
+
+// parseAgentFilter builds an installation filter from the method,
+// has_update, and query parameters.
+func parseAgentFilter(query url.Values) *agent.Filter {
+	filter := &agent.Filter{
+		Method: agent.InstallMethod(query.Get("method")),
+		Scope:  agent.InstallScope(query.Get("scope")),
+		Query:  query.Get("query"),
+	}
+
+	if raw := query.Get("has_update"); raw != "" {
+		if hasUpdate, err := strconv.ParseBool(raw); err == nil {
+			filter.HasUpdate = &hasUpdate
+		}
+	}
+
+	return filter
+}
+
+// filterInstallations returns the installations matching the filter.
+func filterInstallations(installations []*agent.Installation, filter *agent.Filter) []*agent.Installation {
+	filtered := make([]*agent.Installation, 0, len(installations))
+	for _, inst := range installations {
+		if filter.Matches(*inst) {
+			filtered = append(filtered, inst)
+		}
+	}
+	return filtered
+}
+
+// matchesCatalogQuery mirrors catalog search: a case-insensitive substring
+// match against the agent's name, description, or ID.
+func matchesCatalogQuery(def catalog.AgentDef, query string) bool {
+	if query == "" {
+		return true
+	}
+	query = strings.ToLower(query)
+	return strings.Contains(strings.ToLower(def.Name), query) ||
+		strings.Contains(strings.ToLower(def.Description), query) ||
+		strings.Contains(strings.ToLower(def.ID), query)
+}
+
+// paginate applies offset and limit to a slice length, returning the
+// bounds to keep.
+func paginate(total, limit, offset int) (start, end int) {
+	start = offset
+	if start > total {
+		start = total
+	}
+	end = total
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	return start, end
+}
+
+// selectFields reduces a result map to the requested fields. An empty
+// field list keeps everything; unknown fields are ignored.
+func selectFields(m map[string]interface{}, fields []string) map[string]interface{} {
+	if len(fields) == 0 {
+		return m
+	}
+	selected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if value, ok := m[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected
+}
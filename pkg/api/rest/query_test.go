@@ -0,0 +1,218 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func TestParseListParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		wantLimit  int
+		wantOffset int
+		wantFields []string
+	}{
+		{name: "empty", query: ""},
+		{name: "limit and offset", query: "limit=10&offset=5", wantLimit: 10, wantOffset: 5},
+		{name: "negative ignored", query: "limit=-1&offset=-5"},
+		{name: "non-numeric ignored", query: "limit=abc&offset=xyz"},
+		{name: "fields", query: "fields=agent_id,%20status", wantFields: []string{"agent_id", "status"}},
+		{name: "empty field entries dropped", query: "fields=agent_id,,", wantFields: []string{"agent_id"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p := parseListParams(values)
+			if p.Limit != tt.wantLimit {
+				t.Errorf("Limit = %d, want %d", p.Limit, tt.wantLimit)
+			}
+			if p.Offset != tt.wantOffset {
+				t.Errorf("Offset = %d, want %d", p.Offset, tt.wantOffset)
+			}
+			if len(p.Fields) != len(tt.wantFields) {
+				t.Fatalf("Fields = %v, want %v", p.Fields, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if p.Fields[i] != field {
+					t.Errorf("Fields[%d] = %q, want %q", i, p.Fields[i], field)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAgentFilter(t *testing.T) {
+	values, _ := url.ParseQuery("method=npm&has_update=true&query=claude")
+	filter := parseAgentFilter(values)
+
+	if filter.Method != agent.MethodNPM {
+		t.Errorf("Method = %s, want npm", filter.Method)
+	}
+	if filter.HasUpdate == nil || !*filter.HasUpdate {
+		t.Error("expected HasUpdate = true")
+	}
+	if filter.Query != "claude" {
+		t.Errorf("Query = %q, want claude", filter.Query)
+	}
+
+	values, _ = url.ParseQuery("has_update=maybe")
+	if filter := parseAgentFilter(values); filter.HasUpdate != nil {
+		t.Error("expected invalid has_update to be ignored")
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, limit, offset int
+		wantStart, wantEnd   int
+	}{
+		{name: "no bounds", total: 10, wantStart: 0, wantEnd: 10},
+		{name: "limit only", total: 10, limit: 3, wantStart: 0, wantEnd: 3},
+		{name: "offset only", total: 10, offset: 4, wantStart: 4, wantEnd: 10},
+		{name: "limit and offset", total: 10, limit: 3, offset: 4, wantStart: 4, wantEnd: 7},
+		{name: "offset past end", total: 10, offset: 20, wantStart: 10, wantEnd: 10},
+		{name: "limit past end", total: 10, limit: 20, offset: 5, wantStart: 5, wantEnd: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := paginate(tt.total, tt.limit, tt.offset)
+			if start != tt.wantStart || end != tt.wantEnd {
+				t.Errorf("paginate = %d, %d, want %d, %d", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	m := map[string]interface{}{"agent_id": "claude-code", "status": "ok", "metadata": nil}
+
+	if got := selectFields(m, nil); len(got) != 3 {
+		t.Errorf("empty fields should keep everything, got %v", got)
+	}
+
+	got := selectFields(m, []string{"agent_id", "unknown"})
+	if len(got) != 1 || got["agent_id"] != "claude-code" {
+		t.Errorf("selectFields = %v", got)
+	}
+}
+
+func TestMatchesCatalogQuery(t *testing.T) {
+	def := catalog.AgentDef{ID: "claude-code", Name: "Claude Code", Description: "Agentic coding tool"}
+
+	for _, query := range []string{"", "claude", "CODE", "agentic"} {
+		if !matchesCatalogQuery(def, query) {
+			t.Errorf("expected %q to match", query)
+		}
+	}
+	if matchesCatalogQuery(def, "aider") {
+		t.Error("expected no match for aider")
+	}
+}
+
+func TestListCatalogPagination(t *testing.T) {
+	server := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/catalog?limit=1&offset=1", nil)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		Agents []map[string]interface{} `json:"agents"`
+		Total  float64                  `json:"total"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Agents) != 1 {
+		t.Errorf("agents count = %d, want 1", len(resp.Agents))
+	}
+	if resp.Total != 2 {
+		t.Errorf("total = %v, want 2 (count before pagination)", resp.Total)
+	}
+}
+
+func TestListCatalogQueryAndFields(t *testing.T) {
+	server := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/catalog?query=claude&fields=id,name", nil)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		Agents []map[string]interface{} `json:"agents"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resp.Agents) != 1 {
+		t.Fatalf("agents count = %d, want 1", len(resp.Agents))
+	}
+	if len(resp.Agents[0]) != 2 {
+		t.Errorf("fields = %v, want only id and name", resp.Agents[0])
+	}
+	if resp.Agents[0]["id"] != "claude-code" {
+		t.Errorf("id = %v, want claude-code", resp.Agents[0]["id"])
+	}
+}
+
+func TestClientListCatalog(t *testing.T) {
+	server := setupTestServer()
+	ts := httptest.NewServer(server.router)
+	defer ts.Close()
+
+	client := NewClient(ts.URL)
+	page, err := client.ListCatalog(context.Background(), ListOptions{
+		Query:  "claude",
+		Limit:  5,
+		Fields: []string{"id"},
+	})
+	if err != nil {
+		t.Fatalf("ListCatalog: %v", err)
+	}
+
+	if page.Total != 1 {
+		t.Errorf("Total = %d, want 1", page.Total)
+	}
+	if len(page.Items) != 1 || page.Items[0]["id"] != "claude-code" {
+		t.Errorf("Items = %v", page.Items)
+	}
+}
+
+func TestClientListAgentsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL)
+	if _, err := client.ListAgents(context.Background(), ListOptions{}); err == nil {
+		t.Error("expected error for 500 response")
+	}
+}
@@ -0,0 +1,66 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/feed"
+	"github.com/kevinelliott/agentmgr/pkg/prefs"
+)
+
+// releasesPerAgent caps how many releases of each subscribed agent make
+// it into the feed.
+const releasesPerAgent = 10
+
+// handleReleasesFeed serves an Atom feed of release notes for the agents
+// the user has subscribed to, for consumption in a feed reader.
+func (s *Server) handleReleasesFeed(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	subscribed := prefs.NewManager(s.store).Get(ctx).Subscribed
+
+	f := feed.Feed{
+		Title:   "AgentManager Releases",
+		ID:      "urn:agentmgr:releases",
+		Updated: time.Now(),
+	}
+
+	for _, agentID := range subscribed {
+		agentDef, err := s.catalog.GetAgent(ctx, agentID)
+		if err != nil || agentDef == nil {
+			continue
+		}
+
+		// Agents whose changelog source is unsupported or unreachable
+		// are skipped so one bad source doesn't break the whole feed
+		releases, err := s.catalog.GetReleases(ctx, agentID, releasesPerAgent)
+		if err != nil {
+			continue
+		}
+
+		for _, release := range releases {
+			f.Entries = append(f.Entries, feed.Entry{
+				Title:   fmt.Sprintf("%s %s", agentDef.Name, release.Version),
+				ID:      fmt.Sprintf("urn:agentmgr:release:%s:%s", agentID, release.Version),
+				Link:    release.URL,
+				Content: release.Notes,
+				Updated: release.PublishedAt,
+			})
+		}
+	}
+
+	sort.Slice(f.Entries, func(i, j int) bool {
+		return f.Entries[i].Updated.After(f.Entries[j].Updated)
+	})
+
+	body, err := f.Atom()
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to render feed", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	_, _ = w.Write(body)
+}
@@ -4,21 +4,32 @@ package rest
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/graphql-go/graphql"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
 	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/diagnostics"
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 	"github.com/kevinelliott/agentmgr/pkg/installer"
+	"github.com/kevinelliott/agentmgr/pkg/jobs"
+	"github.com/kevinelliott/agentmgr/pkg/mcp"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/recommend"
+	"github.com/kevinelliott/agentmgr/pkg/sbom"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
+	"github.com/kevinelliott/agentmgr/pkg/updater"
 )
 
 // Server is the REST API server.
@@ -27,14 +38,27 @@ type Server struct {
 	platform  platform.Platform
 	store     storage.Store
 	detector  *detector.Detector
+	coord     *detector.Coordinator
 	catalog   *catalog.Manager
 	installer *installer.Manager
+	mcp       *mcp.Manager
+	icons     *catalog.IconCache
 
-	router     chi.Router
-	httpServer *http.Server
+	// Config management (optional; see SetConfigManagement)
+	configLoader   *config.Loader
+	onConfigChange func(cfg *config.Config, key string, value interface{})
+
+	router        chi.Router
+	httpServer    *http.Server
+	graphqlSchema graphql.Schema
 
 	// State
 	startTime time.Time
+
+	// Bulk job tracking
+	bulkMu   sync.Mutex
+	bulkJobs map[string]*bulkJob
+	bulkSeq  int
 }
 
 // ServerConfig configures the REST server.
@@ -62,7 +86,14 @@ func NewServer(
 		detector:  det,
 		catalog:   cat,
 		installer: inst,
+		mcp:       mcp.NewManager(),
+		icons:     catalog.NewIconCache(plat.GetCacheDir()),
 		startTime: time.Now(),
+		bulkJobs:  make(map[string]*bulkJob),
+	}
+
+	if det != nil {
+		s.coord = detector.NewCoordinator(det, time.Second)
 	}
 
 	s.setupRoutes()
@@ -90,23 +121,104 @@ func (s *Server) setupRoutes() {
 		// Agents
 		r.Route("/agents", func(r chi.Router) {
 			r.Get("/", s.handleListAgents)
+			r.Post("/refresh", s.handleRefreshAgents)
 			r.Get("/{key}", s.handleGetAgent)
+			r.Get("/{key}/history", s.handleAgentHistory)
+			r.Get("/{key}/icon", s.handleAgentIcon)
 			r.Post("/", s.handleInstallAgent)
 			r.Put("/{key}", s.handleUpdateAgent)
 			r.Delete("/{key}", s.handleUninstallAgent)
 		})
 
+		// Detection runs
+		r.Route("/detection", func(r chi.Router) {
+			r.Post("/", s.handleStartDetection)
+			r.Get("/status", s.handleGetDetectionStatus)
+		})
+
+		// Bulk operations
+		r.Route("/bulk", func(r chi.Router) {
+			r.Post("/", s.handleCreateBulkJob)
+			r.Get("/{id}", s.handleGetBulkJob)
+			r.Get("/{id}/events", s.handleStreamBulkJob)
+		})
+
+		// Jobs
+		r.Route("/jobs", func(r chi.Router) {
+			r.Get("/", s.handleListJobs)
+			r.Get("/{id}", s.handleGetJob)
+		})
+
 		// Catalog
 		r.Route("/catalog", func(r chi.Router) {
 			r.Get("/", s.handleListCatalog)
 			r.Get("/{agentID}", s.handleGetCatalogAgent)
+			r.Get("/{agentID}/versions", s.handleListAgentVersions)
 			r.Post("/refresh", s.handleRefreshCatalog)
 			r.Get("/search", s.handleSearchCatalog)
 		})
 
+		// MCP server configurations
+		r.Route("/mcp", func(r chi.Router) {
+			r.Get("/", s.handleListMCPConfigs)
+			r.Get("/{agentID}", s.handleListMCPServers)
+			r.Post("/{agentID}", s.handleAddMCPServer)
+			r.Delete("/{agentID}/{name}", s.handleRemoveMCPServer)
+		})
+
+		// Config management
+		r.Route("/config", func(r chi.Router) {
+			r.Get("/", s.handleGetConfig)
+			r.Put("/", s.handleSetConfig)
+		})
+
+		// Garbage collection
+		r.Route("/gc", func(r chi.Router) {
+			r.Get("/scan", s.handleGCScan)
+			r.Post("/clean", s.handleGCClean)
+		})
+
+		// Snapshots
+		r.Route("/snapshots", func(r chi.Router) {
+			r.Get("/", s.handleListSnapshots)
+			r.Post("/", s.handleCreateSnapshot)
+			r.Post("/{id}/restore", s.handleRestoreSnapshot)
+		})
+
+		// Fleet
+		r.Route("/fleet", func(r chi.Router) {
+			r.Get("/", s.handleListFleetHosts)
+			r.Post("/detect", s.handleFleetDetect)
+		})
+
 		// Updates
+		// Update digest
+		r.Route("/digest", func(r chi.Router) {
+			r.Get("/", s.handleGetDigest)
+			r.Get("/report", s.handleGetDigestReport)
+		})
+
 		r.Get("/updates", s.handleCheckUpdates)
+		r.Get("/updates/plan", s.handleUpdatePlan)
+
+		// Recommendations
+		r.Get("/recommendations", s.handleRecommendations)
 		r.Get("/changelog/{agentID}", s.handleGetChangelog)
+
+		// Atom feed of subscribed agents' release notes
+		r.Get("/releases.atom", s.handleReleasesFeed)
+
+		// Optional GraphQL endpoint for complex nested queries
+		if s.config.API.EnableGraphQL {
+			if schema, err := s.buildGraphQLSchema(); err == nil {
+				s.graphqlSchema = schema
+				r.Get("/graphql", s.handleGraphQL)
+				r.Post("/graphql", s.handleGraphQL)
+			}
+		}
+
+		// SBOM export
+		r.Get("/export", s.handleExportSBOM)
 	})
 
 	// Health check
@@ -196,7 +308,7 @@ func (s *Server) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
 
 	// Detect agents
-	agents, _ := s.detector.DetectAll(ctx, agentDefs)
+	agents, _ := s.detectAgents(ctx, agentDefs)
 
 	agentCount := len(agents)
 	updatesAvailable := 0
@@ -206,7 +318,7 @@ func (s *Server) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+	status := map[string]interface{}{
 		"running":              true,
 		"uptime_seconds":       int64(time.Since(s.startTime).Seconds()),
 		"agent_count":          agentCount,
@@ -214,7 +326,18 @@ func (s *Server) handleGetStatus(w http.ResponseWriter, r *http.Request) {
 		"last_catalog_refresh": time.Time{},
 		"last_update_check":    time.Time{},
 		"version":              "dev",
-	})
+	}
+	if s.installer != nil {
+		stats := s.installer.VersionCacheStats()
+		status["version_cache"] = map[string]interface{}{
+			"hits":      stats.Hits,
+			"misses":    stats.Misses,
+			"coalesced": stats.Coalesced,
+			"entries":   stats.Entries,
+			"hit_rate":  stats.HitRate(),
+		}
+	}
+	s.respondJSON(w, http.StatusOK, status)
 }
 
 func (s *Server) handleListAgents(w http.ResponseWriter, r *http.Request) {
@@ -222,32 +345,36 @@ func (s *Server) handleListAgents(w http.ResponseWriter, r *http.Request) {
 
 	// Parse query parameters
 	query := r.URL.Query()
-	limit, _ := strconv.Atoi(query.Get("limit"))
-	offset, _ := strconv.Atoi(query.Get("offset"))
+	params := parseListParams(query)
+	filter := parseAgentFilter(query)
 
 	// Get agent definitions from catalog
 	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
 
 	// Detect agents
-	agents, err := s.detector.DetectAll(ctx, agentDefs)
+	agents, err := s.detectAgents(ctx, agentDefs)
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
 		return
 	}
 
-	// Apply pagination
-	total := len(agents)
-	if offset > 0 && offset < len(agents) {
-		agents = agents[offset:]
-	}
-	if limit > 0 && limit < len(agents) {
-		agents = agents[:limit]
+	// Fill in recently-updated and restart-pending state
+	agentDefMap := make(map[string]catalog.AgentDef, len(agentDefs))
+	for _, def := range agentDefs {
+		agentDefMap[def.ID] = def
 	}
+	updater.AnnotateUpdateState(ctx, s.store, agents, agentDefMap)
+
+	// Apply filters, then paginate over the filtered set
+	agents = filterInstallations(agents, filter)
+	total := len(agents)
+	start, end := paginate(total, params.Limit, params.Offset)
+	agents = agents[start:end]
 
 	// Convert to API format
 	result := make([]map[string]interface{}, len(agents))
 	for i, inst := range agents {
-		result[i] = s.installationToMap(inst)
+		result[i] = selectFields(s.installationToMap(inst), params.Fields)
 	}
 
 	s.respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -256,6 +383,93 @@ func (s *Server) handleListAgents(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// detectAgents runs detection through the coordinator so overlapping
+// requests share one DetectAll run.
+func (s *Server) detectAgents(ctx context.Context, agentDefs []catalog.AgentDef) ([]*agent.Installation, error) {
+	if s.coord == nil {
+		return s.detector.DetectAll(ctx, agentDefs)
+	}
+	return s.coord.Refresh(ctx, agentDefs)
+}
+
+// handleRefreshAgents triggers a fresh detection run. If one is already
+// in flight the request is rejected with its run ID rather than stacking
+// another run behind it.
+func (s *Server) handleRefreshAgents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	var agents []*agent.Installation
+	var err error
+	if s.coord != nil {
+		agents, err = s.coord.TryRefresh(ctx, agentDefs)
+	} else {
+		agents, err = s.detector.DetectAll(ctx, agentDefs)
+	}
+
+	var inProgress *detector.InProgressError
+	if errors.As(err, &inProgress) {
+		s.respondJSON(w, http.StatusConflict, map[string]interface{}{
+			"success": false,
+			"error":   "Refresh already in progress",
+			"run_id":  inProgress.RunID,
+		})
+		return
+	}
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+		"total":   len(agents),
+	})
+}
+
+// handleStartDetection kicks off a background detection run and returns
+// its run ID immediately so clients can poll per-strategy progress.
+func (s *Server) handleStartDetection(w http.ResponseWriter, r *http.Request) {
+	if s.coord == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "Detection is not available", nil)
+		return
+	}
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(r.Context(), string(s.platform.ID()))
+
+	// The run outlives this request, so it cannot run under the request
+	// context
+	runID, started := s.coord.Start(context.Background(), agentDefs, nil)
+	status := http.StatusAccepted
+	if !started {
+		status = http.StatusConflict
+	}
+	s.respondJSON(w, status, map[string]interface{}{
+		"run_id":          runID,
+		"already_running": !started,
+	})
+}
+
+// handleGetDetectionStatus reports the state and per-strategy progress of
+// a detection run. Without a run_id query parameter it describes the
+// active run, falling back to the most recently finished one.
+func (s *Server) handleGetDetectionStatus(w http.ResponseWriter, r *http.Request) {
+	if s.coord == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "Detection is not available", nil)
+		return
+	}
+
+	status, ok := s.coord.Status(r.URL.Query().Get("run_id"))
+	if !ok {
+		s.respondError(w, http.StatusNotFound, "No matching detection run", nil)
+		return
+	}
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"status": status,
+	})
+}
+
 func (s *Server) handleGetAgent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	key := chi.URLParam(r, "key")
@@ -264,7 +478,7 @@ func (s *Server) handleGetAgent(w http.ResponseWriter, r *http.Request) {
 	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
 
 	// Detect agents
-	agents, err := s.detector.DetectAll(ctx, agentDefs)
+	agents, err := s.detectAgents(ctx, agentDefs)
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
 		return
@@ -282,9 +496,97 @@ func (s *Server) handleGetAgent(w http.ResponseWriter, r *http.Request) {
 	s.respondError(w, http.StatusNotFound, "Agent not found", nil)
 }
 
+// handleAgentHistory returns the recorded install, update, and rollback
+// timeline for an agent.
+func (s *Server) handleAgentHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	agentID := chi.URLParam(r, "key")
+
+	// History is stored per agent ID; accept full installation keys too
+	if i := strings.Index(agentID, ":"); i >= 0 {
+		agentID = agentID[:i]
+	}
+
+	limit := 20
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	events, err := s.store.GetUpdateHistory(ctx, agentID, limit)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to get update history", err)
+		return
+	}
+
+	entries := make([]map[string]interface{}, 0, len(events))
+	for _, event := range events {
+		entry := map[string]interface{}{
+			"kind":         event.Kind(),
+			"method":       event.InstallMethod,
+			"from_version": event.FromVersion,
+			"to_version":   event.ToVersion,
+			"status":       event.Status,
+			"started_at":   event.StartedAt,
+		}
+		if event.CompletedAt != nil {
+			entry["completed_at"] = event.CompletedAt
+			entry["duration_seconds"] = event.Duration().Seconds()
+		}
+		if event.Retries > 0 {
+			entry["retries"] = event.Retries
+		}
+		if event.Status == storage.UpdateStatusFailed {
+			entry["error"] = event.ErrorMessage
+			if event.Output != "" {
+				entry["output"] = event.Output
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"agent_id": agentID,
+		"events":   entries,
+		"total":    len(entries),
+	})
+}
+
+// handleAgentIcon serves the cached catalog icon for an agent so web
+// clients can show real icons instead of a letter placeholder.
+func (s *Server) handleAgentIcon(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	agentID := chi.URLParam(r, "key")
+	if i := strings.Index(agentID, ":"); i >= 0 {
+		agentID = agentID[:i]
+	}
+
+	agentDef, err := s.catalog.GetAgent(ctx, agentID)
+	if err != nil {
+		s.respondError(w, http.StatusNotFound, "Agent not found in catalog", err)
+		return
+	}
+
+	iconPath, err := s.icons.Path(ctx, *agentDef)
+	if err != nil || iconPath == "" {
+		s.respondError(w, http.StatusNotFound, "No icon available", err)
+		return
+	}
+
+	// Icons rarely change; let clients cache for a day
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	http.ServeFile(w, r, iconPath)
+}
+
 func (s *Server) handleInstallAgent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if s.config.Policy.Lockdown {
+		s.respondError(w, http.StatusForbidden, s.config.LockdownReason(), nil)
+		return
+	}
+
 	var req struct {
 		AgentID string `json:"agent_id"`
 		Method  string `json:"method"`
@@ -315,8 +617,22 @@ func (s *Server) handleInstallAgent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Install the agent
-	result, err := s.installer.Install(ctx, *agentDef, methodDef, req.Global)
+	// Install the agent, tracked as a job
+	var version agent.Version
+	job := &storage.Job{
+		Type:      jobs.TypeInstall,
+		AgentID:   req.AgentID,
+		AgentName: agentDef.Name,
+		Method:    req.Method,
+	}
+	job, err = jobs.Track(ctx, s.store, job, func(ctx context.Context) (string, error) {
+		result, err := s.installer.Install(ctx, *agentDef, methodDef, req.Global)
+		if err != nil {
+			return "", err
+		}
+		version = result.Version
+		return result.Output, nil
+	})
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Installation failed", err)
 		return
@@ -324,8 +640,9 @@ func (s *Server) handleInstallAgent(w http.ResponseWriter, r *http.Request) {
 
 	s.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
-		"message": fmt.Sprintf("Installed version %s", result.Version),
-		"version": result.Version,
+		"message": fmt.Sprintf("Installed version %s", version),
+		"version": version,
+		"job_id":  job.ID,
 	})
 }
 
@@ -333,6 +650,11 @@ func (s *Server) handleUpdateAgent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	key := chi.URLParam(r, "key")
 
+	if s.config.Policy.Lockdown {
+		s.respondError(w, http.StatusForbidden, s.config.LockdownReason(), nil)
+		return
+	}
+
 	if s.installer == nil {
 		s.respondError(w, http.StatusServiceUnavailable, "Installer not available", nil)
 		return
@@ -342,7 +664,7 @@ func (s *Server) handleUpdateAgent(w http.ResponseWriter, r *http.Request) {
 	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
 
 	// Detect agents to find the installation
-	agents, err := s.detector.DetectAll(ctx, agentDefs)
+	agents, err := s.detectAgents(ctx, agentDefs)
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
 		return
@@ -377,8 +699,22 @@ func (s *Server) handleUpdateAgent(w http.ResponseWriter, r *http.Request) {
 
 	fromVersion := inst.InstalledVersion.String()
 
-	// Update the agent
-	result, err := s.installer.Update(ctx, inst, *agentDef, methodDef)
+	// Update the agent, tracked as a job
+	var toVersion agent.Version
+	job := &storage.Job{
+		Type:      jobs.TypeUpdate,
+		AgentID:   inst.AgentID,
+		AgentName: inst.AgentName,
+		Method:    string(inst.Method),
+	}
+	job, err = jobs.Track(ctx, s.store, job, func(ctx context.Context) (string, error) {
+		result, err := s.installer.Update(ctx, inst, *agentDef, methodDef)
+		if err != nil {
+			return "", err
+		}
+		toVersion = result.Version
+		return result.Output, nil
+	})
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Update failed", err)
 		return
@@ -386,9 +722,10 @@ func (s *Server) handleUpdateAgent(w http.ResponseWriter, r *http.Request) {
 
 	s.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success":      true,
-		"message":      fmt.Sprintf("Updated from %s to %s", fromVersion, result.Version),
+		"message":      fmt.Sprintf("Updated from %s to %s", fromVersion, toVersion),
 		"from_version": fromVersion,
-		"to_version":   result.Version,
+		"to_version":   toVersion,
+		"job_id":       job.ID,
 	})
 }
 
@@ -396,6 +733,11 @@ func (s *Server) handleUninstallAgent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	key := chi.URLParam(r, "key")
 
+	if s.config.Policy.Lockdown {
+		s.respondError(w, http.StatusForbidden, s.config.LockdownReason(), nil)
+		return
+	}
+
 	if s.installer == nil {
 		s.respondError(w, http.StatusServiceUnavailable, "Installer not available", nil)
 		return
@@ -405,7 +747,7 @@ func (s *Server) handleUninstallAgent(w http.ResponseWriter, r *http.Request) {
 	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
 
 	// Detect agents to find the installation
-	agents, err := s.detector.DetectAll(ctx, agentDefs)
+	agents, err := s.detectAgents(ctx, agentDefs)
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
 		return
@@ -438,8 +780,17 @@ func (s *Server) handleUninstallAgent(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Uninstall the agent
-	if err := s.installer.Uninstall(ctx, inst, methodDef); err != nil {
+	// Uninstall the agent, tracked as a job
+	job := &storage.Job{
+		Type:      jobs.TypeUninstall,
+		AgentID:   inst.AgentID,
+		AgentName: inst.AgentName,
+		Method:    string(inst.Method),
+	}
+	job, err = jobs.Track(ctx, s.store, job, func(ctx context.Context) (string, error) {
+		return "", s.installer.Uninstall(ctx, inst, methodDef)
+	})
+	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Uninstallation failed", err)
 		return
 	}
@@ -447,12 +798,14 @@ func (s *Server) handleUninstallAgent(w http.ResponseWriter, r *http.Request) {
 	s.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"success": true,
 		"message": "Agent uninstalled successfully",
+		"job_id":  job.ID,
 	})
 }
 
 func (s *Server) handleListCatalog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	platformID := r.URL.Query().Get("platform")
+	params := parseListParams(r.URL.Query())
 
 	var agents []catalog.AgentDef
 	var err error
@@ -473,14 +826,28 @@ func (s *Server) handleListCatalog(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Apply the query filter, then paginate over the filtered set
+	if q := r.URL.Query().Get("query"); q != "" {
+		filtered := make([]catalog.AgentDef, 0, len(agents))
+		for _, a := range agents {
+			if matchesCatalogQuery(a, q) {
+				filtered = append(filtered, a)
+			}
+		}
+		agents = filtered
+	}
+	total := len(agents)
+	start, end := paginate(total, params.Limit, params.Offset)
+	agents = agents[start:end]
+
 	result := make([]map[string]interface{}, len(agents))
 	for i, a := range agents {
-		result[i] = s.catalogAgentToMap(&a)
+		result[i] = selectFields(s.catalogAgentToMap(&a), params.Fields)
 	}
 
 	s.respondJSON(w, http.StatusOK, map[string]interface{}{
 		"agents": result,
-		"total":  len(result),
+		"total":  total,
 	})
 }
 
@@ -522,13 +889,75 @@ func (s *Server) handleRefreshCatalog(w http.ResponseWriter, r *http.Request) {
 	if result.Updated {
 		message = "Catalog updated successfully"
 	}
+	if result.SchemaTooNew {
+		message = "Remote catalog requires a newer app version; kept the cached catalog"
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":        true,
+		"updated":        result.Updated,
+		"schema_too_new": result.SchemaTooNew,
+		"message":        message,
+		"version":        cat.Version,
+		"agent_count":    len(cat.Agents),
+	})
+}
+
+// SetConfigManagement wires a config loader (and an optional change
+// callback) into the /config endpoints so remote callers can modify
+// whitelisted settings. Without a loader the set endpoint reports config
+// management as unavailable.
+func (s *Server) SetConfigManagement(loader *config.Loader, onChange func(cfg *config.Config, key string, value interface{})) {
+	s.configLoader = loader
+	s.onConfigChange = onChange
+}
+
+func (s *Server) handleGetConfig(w http.ResponseWriter, r *http.Request) {
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":      true,
+		"config":       diagnostics.RedactConfig(s.config),
+		"mutable_keys": config.MutableKeys(),
+	})
+}
+
+func (s *Server) handleSetConfig(w http.ResponseWriter, r *http.Request) {
+	if s.configLoader == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "Config management not available", nil)
+		return
+	}
+
+	var req struct {
+		Key   string      `json:"key"`
+		Value interface{} `json:"value"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+	if req.Key == "" {
+		s.respondError(w, http.StatusBadRequest, "Missing config key", nil)
+		return
+	}
+	if !config.IsMutableKey(req.Key) {
+		s.respondError(w, http.StatusForbidden, "Config key cannot be changed over the API", nil)
+		return
+	}
+
+	cfg, err := s.configLoader.SetValidated(req.Key, req.Value)
+	if err != nil {
+		s.respondError(w, http.StatusUnprocessableEntity, "Invalid config value", err)
+		return
+	}
+
+	if s.onConfigChange != nil {
+		s.onConfigChange(cfg, req.Key, req.Value)
+	}
 
 	s.respondJSON(w, http.StatusOK, map[string]interface{}{
-		"success":     true,
-		"updated":     result.Updated,
-		"message":     message,
-		"version":     cat.Version,
-		"agent_count": len(cat.Agents),
+		"success": true,
+		"key":     req.Key,
+		"value":   req.Value,
 	})
 }
 
@@ -569,7 +998,7 @@ func (s *Server) handleCheckUpdates(w http.ResponseWriter, r *http.Request) {
 	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
 
 	// Detect agents
-	agents, err := s.detector.DetectAll(ctx, agentDefs)
+	agents, err := s.detectAgents(ctx, agentDefs)
 	if err != nil {
 		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
 		return
@@ -592,6 +1021,98 @@ func (s *Server) handleCheckUpdates(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// handleRecommendations analyzes the detected environment and returns
+// the environment score with prioritized suggestions. Each entry carries
+// an action the client can feed straight into the agent endpoints.
+func (s *Server) handleRecommendations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	agents, err := s.detectAgents(ctx, agentDefs)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
+		return
+	}
+
+	report := recommend.Analyze(agents, agentDefs, string(s.platform.ID()))
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"score":           report.Score,
+		"recommendations": report.Recommendations,
+		"total":           len(report.Recommendations),
+	})
+}
+
+// handleUpdatePlan previews what Update All would do: which agents would be
+// updated, from and to which versions, changelog summaries, estimated
+// download sizes, and any pin conflicts.
+func (s *Server) handleUpdatePlan(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	agents, err := s.detectAgents(ctx, agentDefs)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
+		return
+	}
+
+	defMap := make(map[string]catalog.AgentDef, len(agentDefs))
+	for _, def := range agentDefs {
+		defMap[def.ID] = def
+	}
+
+	planner := updater.NewPlanner(s.config, s.catalog, s.installer)
+	plan, err := planner.Plan(ctx, agents, defMap)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to plan updates", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, plan)
+}
+
+// handleExportSBOM exports the detected agent inventory as a software bill
+// of materials. The format query parameter selects cyclonedx (default) or
+// spdx output.
+func (s *Server) handleExportSBOM(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = string(sbom.FormatCycloneDX)
+	}
+	sbomFormat, err := sbom.ParseFormat(format)
+	if err != nil {
+		s.respondError(w, http.StatusBadRequest, "Invalid SBOM format", err)
+		return
+	}
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	agents, err := s.detectAgents(ctx, agentDefs)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
+		return
+	}
+
+	defMap := make(map[string]catalog.AgentDef, len(agentDefs))
+	for _, def := range agentDefs {
+		defMap[def.ID] = def
+	}
+
+	data, err := sbom.Export(sbomFormat, agents, defMap, "dev")
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to build SBOM", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
 func (s *Server) handleGetChangelog(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	agentID := chi.URLParam(r, "agentID")
@@ -642,6 +1163,14 @@ func (s *Server) respondError(w http.ResponseWriter, status int, message string,
 	}
 	if err != nil {
 		response["details"] = err.Error()
+		// Coded errors carry a stable machine-readable code and may
+		// refine the HTTP status (e.g. a catalog miss is a 404, not a 500)
+		if code := errcode.Of(err); code != "" {
+			response["code"] = string(code)
+			if status == http.StatusInternalServerError {
+				status = errcode.HTTPStatus(code)
+			}
+		}
 	}
 	w.WriteHeader(status)
 	json.NewEncoder(w).Encode(response)
@@ -653,8 +1182,19 @@ func (s *Server) installationToMap(inst *agent.Installation) map[string]interfac
 		latestVer = inst.LatestVersion.String()
 	}
 
+	var lastUsed interface{}
+	if inst.LastUsed != nil {
+		lastUsed = *inst.LastUsed
+	}
+
+	var updatedAt interface{}
+	if inst.UpdatedAt != nil {
+		updatedAt = *inst.UpdatedAt
+	}
+
 	return map[string]interface{}{
 		"key":               inst.Key(),
+		"last_used":         lastUsed,
 		"agent_id":          inst.AgentID,
 		"agent_name":        inst.AgentName,
 		"install_method":    string(inst.Method),
@@ -663,11 +1203,16 @@ func (s *Server) installationToMap(inst *agent.Installation) map[string]interfac
 		"executable_path":   inst.ExecutablePath,
 		"install_path":      inst.InstallPath,
 		"is_global":         inst.IsGlobal,
+		"scope":             string(inst.Scope),
 		"detected_at":       inst.DetectedAt,
 		"last_checked":      inst.LastChecked,
 		"metadata":          inst.Metadata,
 		"has_update":        inst.HasUpdate(),
 		"status":            string(inst.GetStatus()),
+		"updated_at":        updatedAt,
+		"recently_updated":  inst.RecentlyUpdated(),
+		"restart_pending":   inst.RestartPending,
+		"configured_only":   inst.ConfiguredOnly,
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
 	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
@@ -19,17 +21,20 @@ import (
 // mockPlatform implements platform.Platform for testing
 type mockPlatform struct{}
 
-func (m *mockPlatform) ID() platform.ID                                             { return platform.Darwin }
-func (m *mockPlatform) Architecture() string                                        { return "amd64" }
-func (m *mockPlatform) Name() string                                                { return "macOS" }
-func (m *mockPlatform) GetDataDir() string                                          { return "/tmp/data" }
-func (m *mockPlatform) GetConfigDir() string                                        { return "/tmp/config" }
-func (m *mockPlatform) GetCacheDir() string                                         { return "/tmp/cache" }
-func (m *mockPlatform) GetLogDir() string                                           { return "/tmp/log" }
-func (m *mockPlatform) GetIPCSocketPath() string                                    { return "/tmp/agentmgr.sock" }
-func (m *mockPlatform) EnableAutoStart(ctx context.Context) error                   { return nil }
-func (m *mockPlatform) DisableAutoStart(ctx context.Context) error                  { return nil }
-func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error)        { return false, nil }
+func (m *mockPlatform) ID() platform.ID                                      { return platform.Darwin }
+func (m *mockPlatform) Architecture() string                                 { return "amd64" }
+func (m *mockPlatform) Name() string                                         { return "macOS" }
+func (m *mockPlatform) GetDataDir() string                                   { return "/tmp/data" }
+func (m *mockPlatform) GetConfigDir() string                                 { return "/tmp/config" }
+func (m *mockPlatform) GetCacheDir() string                                  { return "/tmp/cache" }
+func (m *mockPlatform) GetLogDir() string                                    { return "/tmp/log" }
+func (m *mockPlatform) GetIPCSocketPath() string                             { return "/tmp/agentmgr.sock" }
+func (m *mockPlatform) EnableAutoStart(ctx context.Context) error            { return nil }
+func (m *mockPlatform) DisableAutoStart(ctx context.Context) error           { return nil }
+func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) { return false, nil }
+func (m *mockPlatform) GetAutoStartMethod(ctx context.Context) (platform.AutoStartMethod, error) {
+	return platform.AutoStartNone, nil
+}
 func (m *mockPlatform) FindExecutable(name string) (string, error)                  { return "", nil }
 func (m *mockPlatform) FindExecutables(name string) ([]string, error)               { return nil, nil }
 func (m *mockPlatform) IsExecutableInPath(name string) bool                         { return false }
@@ -37,11 +42,14 @@ func (m *mockPlatform) GetPathDirs() []string
 func (m *mockPlatform) GetShell() string                                            { return "/bin/bash" }
 func (m *mockPlatform) GetShellArg() string                                         { return "-c" }
 func (m *mockPlatform) ShowNotification(title, message string) error                { return nil }
+func (m *mockPlatform) IsDoNotDisturbEnabled() bool                                 { return false }
+func (m *mockPlatform) IsElevated() bool                                            { return false }
 func (m *mockPlatform) ShowChangelogDialog(a, b, c, d string) platform.DialogResult { return 0 }
 
 // mockStore implements storage.Store for testing
 type mockStore struct {
 	catalogData []byte
+	history     []*storage.UpdateEvent
 }
 
 func (m *mockStore) Initialize(ctx context.Context) error { return nil }
@@ -60,7 +68,7 @@ func (m *mockStore) SaveUpdateEvent(ctx context.Context, event *storage.UpdateEv
 	return nil
 }
 func (m *mockStore) GetUpdateHistory(ctx context.Context, agentID string, limit int) ([]*storage.UpdateEvent, error) {
-	return nil, nil
+	return m.history, nil
 }
 func (m *mockStore) GetCatalogCache(ctx context.Context) ([]byte, string, time.Time, error) {
 	return m.catalogData, "", time.Now(), nil
@@ -75,6 +83,13 @@ func (m *mockStore) DeleteSetting(ctx context.Context, key string) error
 func (m *mockStore) SaveDetectionCache(ctx context.Context, installations []*agent.Installation) error {
 	return nil
 }
+func (m *mockStore) SaveJob(ctx context.Context, job *storage.Job) error { return nil }
+func (m *mockStore) GetJob(ctx context.Context, id string) (*storage.Job, error) {
+	return nil, nil
+}
+func (m *mockStore) ListJobs(ctx context.Context, state storage.JobState, limit int) ([]*storage.Job, error) {
+	return nil, nil
+}
 func (m *mockStore) GetDetectionCache(ctx context.Context) ([]*agent.Installation, time.Time, error) {
 	return nil, time.Time{}, nil
 }
@@ -287,6 +302,14 @@ func TestGetCatalogAgentEndpoint(t *testing.T) {
 		if w.Code != http.StatusNotFound {
 			t.Errorf("Status = %d, want %d", w.Code, http.StatusNotFound)
 		}
+
+		var body map[string]interface{}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("Failed to parse error body: %v", err)
+		}
+		if body["code"] != string(errcode.CodeNotFound) {
+			t.Errorf("code = %v, want %q", body["code"], errcode.CodeNotFound)
+		}
 	})
 }
 
@@ -332,6 +355,220 @@ func TestSearchCatalogEndpoint(t *testing.T) {
 	}
 }
 
+func TestAgentHistoryEndpoint(t *testing.T) {
+	cat := createTestCatalog()
+	catalogJSON, _ := json.Marshal(cat)
+	cfg := newTestConfig()
+
+	completed := time.Date(2026, 3, 1, 12, 0, 42, 0, time.UTC)
+	store := &mockStore{
+		catalogData: catalogJSON,
+		history: []*storage.UpdateEvent{
+			{
+				AgentID:       "claude-code",
+				InstallMethod: "npm",
+				FromVersion:   "1.0.0",
+				ToVersion:     "1.2.0",
+				Status:        storage.UpdateStatusCompleted,
+				StartedAt:     completed.Add(-30 * time.Second),
+				CompletedAt:   &completed,
+			},
+			{
+				AgentID:       "claude-code",
+				InstallMethod: "npm",
+				FromVersion:   "1.0.0",
+				Status:        storage.UpdateStatusFailed,
+				ErrorMessage:  "npm exited with status 1",
+				Output:        "npm ERR! network",
+				StartedAt:     completed.Add(-time.Hour),
+			},
+		},
+	}
+	server := NewServer(cfg, &mockPlatform{}, store, nil, catalog.NewManager(cfg, store), nil)
+
+	req := httptest.NewRequest("GET", "/api/v1/agents/claude-code/history", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	var resp struct {
+		AgentID string                   `json:"agent_id"`
+		Events  []map[string]interface{} `json:"events"`
+		Total   int                      `json:"total"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.AgentID != "claude-code" {
+		t.Errorf("agent_id = %q, want claude-code", resp.AgentID)
+	}
+	if resp.Total != 2 || len(resp.Events) != 2 {
+		t.Fatalf("total = %d, events = %d, want 2 each", resp.Total, len(resp.Events))
+	}
+
+	first := resp.Events[0]
+	if first["kind"] != "update" {
+		t.Errorf("kind = %v, want update", first["kind"])
+	}
+	if first["duration_seconds"] != 30.0 {
+		t.Errorf("duration_seconds = %v, want 30", first["duration_seconds"])
+	}
+	if _, ok := first["error"]; ok {
+		t.Error("successful events should not include an error")
+	}
+
+	second := resp.Events[1]
+	if second["error"] != "npm exited with status 1" {
+		t.Errorf("error = %v, want the recorded failure", second["error"])
+	}
+	if second["output"] != "npm ERR! network" {
+		t.Errorf("output = %v, want the recorded command output", second["output"])
+	}
+}
+
+func TestLockdownBlocksAgentActions(t *testing.T) {
+	server := setupTestServer()
+	server.config.Policy.Lockdown = true
+
+	requests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"POST", "/api/v1/agents", `{"agent_id":"claude-code","method":"npm"}`},
+		{"PUT", "/api/v1/agents/claude-code", ""},
+		{"DELETE", "/api/v1/agents/claude-code", ""},
+		{"POST", "/api/v1/bulk", `{"actions":[{"action":"install","agent_id":"claude-code","method":"npm"}]}`},
+		{"POST", "/api/v1/snapshots/snap-1/restore", ""},
+		{"POST", "/api/v1/gc/clean", `{"orphans":[{"kind":"npm_package","name":"leftover"}]}`},
+	}
+
+	for _, tt := range requests {
+		var body *strings.Reader
+		if tt.body != "" {
+			body = strings.NewReader(tt.body)
+		} else {
+			body = strings.NewReader("")
+		}
+		req := httptest.NewRequest(tt.method, tt.path, body)
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusForbidden {
+			t.Errorf("%s %s status = %d, want %d", tt.method, tt.path, w.Code, http.StatusForbidden)
+		}
+		if !strings.Contains(w.Body.String(), "administrator") {
+			t.Errorf("%s %s should explain the machine is administrator-managed", tt.method, tt.path)
+		}
+	}
+
+	// Read-only endpoints keep working
+	req := httptest.NewRequest("GET", "/api/v1/catalog", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("GET /api/v1/catalog status = %d, want %d under lockdown", w.Code, http.StatusOK)
+	}
+}
+
+func TestGetConfigEndpoint(t *testing.T) {
+	server := setupTestServer()
+	server.config.Catalog.GitHubToken = "ghp_secret"
+
+	req := httptest.NewRequest("GET", "/api/v1/config", nil)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+
+	body := w.Body.String()
+	if strings.Contains(body, "ghp_secret") {
+		t.Error("config response leaks the GitHub token")
+	}
+
+	var resp map[string]interface{}
+	if err := json.NewDecoder(strings.NewReader(body)).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := resp["config"]; !ok {
+		t.Error("response should include the config")
+	}
+	keys, ok := resp["mutable_keys"].([]interface{})
+	if !ok || len(keys) == 0 {
+		t.Error("response should list the mutable keys")
+	}
+}
+
+func TestSetConfigEndpointWithoutLoader(t *testing.T) {
+	server := setupTestServer()
+
+	req := httptest.NewRequest("PUT", "/api/v1/config", strings.NewReader(`{"key":"ui.theme","value":"dark"}`))
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Status = %d, want %d without a config loader", w.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestSetConfigEndpoint(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := tmpDir + "/config.yaml"
+	if err := os.WriteFile(configPath, []byte("ui:\n  page_size: 20\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	loader := config.NewLoader()
+	if _, err := loader.Load(configPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	server := setupTestServer()
+	var changedKey string
+	var changedCfg *config.Config
+	server.SetConfigManagement(loader, func(cfg *config.Config, key string, value interface{}) {
+		changedCfg = cfg
+		changedKey = key
+	})
+
+	req := httptest.NewRequest("PUT", "/api/v1/config", strings.NewReader(`{"key":"ui.page_size","value":50}`))
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	if changedKey != "ui.page_size" {
+		t.Errorf("change callback key = %q, want ui.page_size", changedKey)
+	}
+	if changedCfg == nil || changedCfg.UI.PageSize != 50 {
+		t.Error("change callback should receive the updated config")
+	}
+
+	// Keys outside the whitelist are refused
+	req = httptest.NewRequest("PUT", "/api/v1/config", strings.NewReader(`{"key":"api.rest_port","value":1}`))
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Status = %d, want %d for non-whitelisted key", w.Code, http.StatusForbidden)
+	}
+
+	// Values failing validation are refused
+	req = httptest.NewRequest("PUT", "/api/v1/config", strings.NewReader(`{"key":"ui.page_size","value":0}`))
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Status = %d, want %d for invalid value", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
 func TestCORSMiddleware(t *testing.T) {
 	server := setupTestServer()
 
@@ -516,6 +753,26 @@ func TestGetChangelogEndpoint(t *testing.T) {
 	})
 }
 
+func TestReleasesFeedEndpoint(t *testing.T) {
+	server := setupTestServer()
+
+	req := httptest.NewRequest("GET", "/api/v1/releases.atom", nil)
+	w := httptest.NewRecorder()
+
+	server.router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Status = %d, want %d", w.Code, http.StatusOK)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.Contains(ct, "application/atom+xml") {
+		t.Errorf("Content-Type = %q, want atom+xml", ct)
+	}
+	// No subscriptions in the test store, so the feed is valid but empty
+	if body := w.Body.String(); !strings.Contains(body, `<feed xmlns="http://www.w3.org/2005/Atom">`) {
+		t.Errorf("body is not an Atom feed:\n%s", body)
+	}
+}
+
 func TestServerStartStop(t *testing.T) {
 	server := setupTestServer()
 
@@ -0,0 +1,109 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kevinelliott/agentmgr/pkg/snapshot"
+)
+
+// Snapshot handlers
+
+func (s *Server) handleListSnapshots(w http.ResponseWriter, r *http.Request) {
+	mgr := snapshot.NewManager(s.platform.GetDataDir())
+
+	snapshots, err := mgr.List()
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to list snapshots", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"snapshots": snapshots,
+		"total":     len(snapshots),
+	})
+}
+
+func (s *Server) handleCreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	installations, err := s.detector.DetectAll(ctx, agentDefs)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
+		return
+	}
+
+	mgr := snapshot.NewManager(s.platform.GetDataDir())
+	snap, err := mgr.Create(installations, s.config)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to create snapshot", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"snapshot": snap,
+	})
+}
+
+func (s *Server) handleRestoreSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := chi.URLParam(r, "id")
+
+	if s.config.Policy.Lockdown {
+		s.respondError(w, http.StatusForbidden, s.config.LockdownReason(), nil)
+		return
+	}
+
+	if s.installer == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "Installer not available", nil)
+		return
+	}
+
+	mgr := snapshot.NewManager(s.platform.GetDataDir())
+	snap, err := mgr.Get(id)
+	if err != nil {
+		s.respondError(w, http.StatusNotFound, "Snapshot not found", err)
+		return
+	}
+
+	agentDefs, _ := s.catalog.GetAgentsForPlatform(ctx, string(s.platform.ID()))
+
+	installations, err := s.detector.DetectAll(ctx, agentDefs)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to detect agents", err)
+		return
+	}
+
+	plan := snapshot.ComputePlan(snap, installations)
+
+	dryRun := r.URL.Query().Get("dry_run") == "true"
+	if dryRun || len(plan) == 0 {
+		s.respondJSON(w, http.StatusOK, map[string]interface{}{
+			"success":  true,
+			"executed": false,
+			"plan":     plan,
+		})
+		return
+	}
+
+	cat, err := s.catalog.Get(ctx)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to load catalog", err)
+		return
+	}
+
+	if err := snapshot.Restore(ctx, plan, installations, cat, s.installer); err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Restore failed", err)
+		return
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"success":  true,
+		"executed": true,
+		"plan":     plan,
+	})
+}
@@ -0,0 +1,67 @@
+package rest
+
+import (
+	"crypto/tls"
+	"os"
+	"testing"
+)
+
+func TestEnsureSelfSignedCert(t *testing.T) {
+	dir := t.TempDir()
+
+	certPath, keyPath, err := EnsureSelfSignedCert(dir)
+	if err != nil {
+		t.Fatalf("EnsureSelfSignedCert() error = %v", err)
+	}
+
+	// The pair must load as a usable TLS certificate.
+	if _, err := tls.LoadX509KeyPair(certPath, keyPath); err != nil {
+		t.Fatalf("LoadX509KeyPair() error = %v", err)
+	}
+
+	// The private key must not be world-readable.
+	info, err := os.Stat(keyPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("key mode = %o, want 600", perm)
+	}
+
+	// A second call reuses the existing pair instead of regenerating.
+	first, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := EnsureSelfSignedCert(dir); err != nil {
+		t.Fatalf("second EnsureSelfSignedCert() error = %v", err)
+	}
+	second, err := os.ReadFile(certPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(first) != string(second) {
+		t.Error("certificate was regenerated on second call")
+	}
+}
+
+func TestEnsureSelfSignedCertReplacesCorrupt(t *testing.T) {
+	dir := t.TempDir()
+
+	certPath, _, err := EnsureSelfSignedCert(dir)
+	if err != nil {
+		t.Fatalf("EnsureSelfSignedCert() error = %v", err)
+	}
+
+	if err := os.WriteFile(certPath, []byte("not a certificate"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	certPath, keyPath, err := EnsureSelfSignedCert(dir)
+	if err != nil {
+		t.Fatalf("EnsureSelfSignedCert() after corruption error = %v", err)
+	}
+	if _, err := tls.LoadX509KeyPair(certPath, keyPath); err != nil {
+		t.Fatalf("LoadX509KeyPair() error = %v", err)
+	}
+}
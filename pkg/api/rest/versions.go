@@ -0,0 +1,98 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+// versionListCacheTTL is how long cached version listings stay fresh.
+const versionListCacheTTL = time.Hour
+
+// cachedVersionList is the storage format for cached version listings.
+type cachedVersionList struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Versions  []agent.Version `json:"versions"`
+}
+
+func (s *Server) handleListAgentVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	agentID := chi.URLParam(r, "agentID")
+	method := r.URL.Query().Get("method")
+
+	agentDef, err := s.catalog.GetAgent(ctx, agentID)
+	if err != nil {
+		s.respondError(w, http.StatusNotFound, "Agent not found in catalog", err)
+		return
+	}
+
+	if method == "" {
+		methods := agentDef.GetSupportedMethods(string(s.platform.ID()))
+		if len(methods) == 0 {
+			s.respondError(w, http.StatusBadRequest, "No install methods available", nil)
+			return
+		}
+		method = methods[0].Method
+	}
+
+	methodDef, ok := agentDef.GetInstallMethod(method)
+	if !ok {
+		s.respondError(w, http.StatusBadRequest, "Install method not available for this agent", nil)
+		return
+	}
+
+	cacheKey := fmt.Sprintf("versions.%s.%s", agentID, method)
+
+	// Serve from cache when fresh
+	if s.store != nil {
+		if value, err := s.store.GetSetting(ctx, cacheKey); err == nil && value != "" {
+			var cached cachedVersionList
+			if err := json.Unmarshal([]byte(value), &cached); err == nil &&
+				time.Since(cached.FetchedAt) < versionListCacheTTL {
+				s.respondVersionList(w, agentID, method, cached.Versions, true)
+				return
+			}
+		}
+	}
+
+	if s.installer == nil {
+		s.respondError(w, http.StatusServiceUnavailable, "Installer not available", nil)
+		return
+	}
+
+	versions, err := s.installer.ListAvailableVersions(ctx, methodDef)
+	if err != nil {
+		s.respondError(w, http.StatusInternalServerError, "Failed to list versions", err)
+		return
+	}
+
+	// Cache the result
+	if s.store != nil {
+		cached := cachedVersionList{FetchedAt: time.Now(), Versions: versions}
+		if data, err := json.Marshal(cached); err == nil {
+			_ = s.store.SetSetting(ctx, cacheKey, string(data))
+		}
+	}
+
+	s.respondVersionList(w, agentID, method, versions, false)
+}
+
+func (s *Server) respondVersionList(w http.ResponseWriter, agentID, method string, versions []agent.Version, fromCache bool) {
+	result := make([]string, len(versions))
+	for i, v := range versions {
+		result[i] = v.String()
+	}
+
+	s.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"agent_id": agentID,
+		"method":   method,
+		"versions": result,
+		"total":    len(result),
+		"cached":   fromCache,
+	})
+}
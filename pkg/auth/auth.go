@@ -0,0 +1,64 @@
+// Package auth checks the sign-in state of agents that require an
+// account, using the auth commands declared in the catalog.
+package auth
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// checkTimeout bounds the auth check command. Checks are expected to
+// read local credential state, not hit the network.
+const checkTimeout = 10 * time.Second
+
+// Check runs the agent's auth check command and reports the sign-in
+// state: agent.AuthSignedIn when the command exits zero,
+// agent.AuthNotSignedIn when it exits non-zero. It returns "" when the
+// agent declares no check or the command could not be run at all.
+func Check(ctx context.Context, agentDef catalog.AgentDef) string {
+	parts := strings.Fields(agentDef.Auth.CheckCmd)
+	if len(parts) == 0 {
+		return ""
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(cmdCtx, parts[0], parts[1:]...) //nolint:gosec // G204: command comes from the trusted catalog
+	err := cmd.Run()
+	if err == nil {
+		return agent.AuthSignedIn
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return agent.AuthNotSignedIn
+	}
+	// The command did not run (binary missing, timeout): state unknown
+	return ""
+}
+
+// Annotate fills AuthStatus on each installation whose catalog entry
+// declares an auth check. The check runs once per agent, not once per
+// installation.
+func Annotate(ctx context.Context, installations []*agent.Installation, agentDefs map[string]catalog.AgentDef) {
+	checked := make(map[string]string)
+	for _, inst := range installations {
+		agentDef, ok := agentDefs[inst.AgentID]
+		if !ok || agentDef.Auth.CheckCmd == "" {
+			continue
+		}
+		status, ok := checked[inst.AgentID]
+		if !ok {
+			status = Check(ctx, agentDef)
+			checked[inst.AgentID] = status
+		}
+		inst.AuthStatus = status
+	}
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// writeScript creates an executable shell script with the given body.
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestCheck(t *testing.T) {
+	signedIn := writeScript(t, `exit 0`)
+	signedOut := writeScript(t, `exit 1`)
+
+	tests := []struct {
+		name     string
+		checkCmd string
+		want     string
+	}{
+		{name: "signed in", checkCmd: signedIn + " auth status", want: agent.AuthSignedIn},
+		{name: "not signed in", checkCmd: signedOut + " auth status", want: agent.AuthNotSignedIn},
+		{name: "no check declared", checkCmd: "", want: ""},
+		{name: "binary missing", checkCmd: "/nonexistent/tool auth status", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			agentDef := catalog.AgentDef{Auth: catalog.AuthDef{CheckCmd: tt.checkCmd}}
+			if got := Check(context.Background(), agentDef); got != tt.want {
+				t.Errorf("Check() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	signedIn := writeScript(t, `exit 0`)
+
+	installations := []*agent.Installation{
+		{AgentID: "with-auth", Method: "npm"},
+		{AgentID: "with-auth", Method: "brew"},
+		{AgentID: "no-auth"},
+	}
+	agentDefs := map[string]catalog.AgentDef{
+		"with-auth": {ID: "with-auth", Auth: catalog.AuthDef{CheckCmd: signedIn}},
+		"no-auth":   {ID: "no-auth"},
+	}
+
+	Annotate(context.Background(), installations, agentDefs)
+
+	if installations[0].AuthStatus != agent.AuthSignedIn {
+		t.Errorf("AuthStatus = %q, want %q", installations[0].AuthStatus, agent.AuthSignedIn)
+	}
+	if installations[1].AuthStatus != agent.AuthSignedIn {
+		t.Errorf("second installation AuthStatus = %q, want %q", installations[1].AuthStatus, agent.AuthSignedIn)
+	}
+	if installations[2].AuthStatus != "" {
+		t.Errorf("agent without auth check got AuthStatus = %q", installations[2].AuthStatus)
+	}
+}
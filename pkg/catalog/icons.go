@@ -0,0 +1,147 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// iconMaxAge is how long a downloaded icon is used before it is
+	// re-fetched from its URL.
+	iconMaxAge = 7 * 24 * time.Hour
+
+	// iconMaxBytes caps the size of a downloaded icon file.
+	iconMaxBytes = 1 << 20
+)
+
+// IconCache downloads agent icons from the catalog and keeps them on
+// disk so the UI can show real icons instead of the generic one-letter
+// placeholder.
+type IconCache struct {
+	dir        string
+	httpClient *http.Client
+}
+
+// NewIconCache creates an icon cache storing files under an "icons"
+// directory inside cacheDir.
+func NewIconCache(cacheDir string) *IconCache {
+	return &IconCache{
+		dir:        filepath.Join(cacheDir, "icons"),
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Path returns the local file path of the agent's icon, downloading or
+// materializing it when needed. It returns an empty path without error
+// when the agent has no icon.
+func (c *IconCache) Path(ctx context.Context, def AgentDef) (string, error) {
+	switch {
+	case isIconURL(def.Icon):
+		return c.fetch(ctx, def)
+	case def.IconSVG != "":
+		return c.writeSVG(def)
+	default:
+		return "", nil
+	}
+}
+
+// isIconURL reports whether the catalog icon value is a downloadable URL
+// rather than an emoji or symbol name.
+func isIconURL(icon string) bool {
+	return strings.HasPrefix(icon, "http://") || strings.HasPrefix(icon, "https://")
+}
+
+// fetch downloads the icon URL into the cache unless a fresh copy is
+// already present.
+func (c *IconCache) fetch(ctx context.Context, def AgentDef) (string, error) {
+	ext := ".png"
+	if u, err := url.Parse(def.Icon); err == nil {
+		if e := path.Ext(u.Path); e != "" {
+			ext = e
+		}
+	}
+	dest := filepath.Join(c.dir, def.ID+ext)
+
+	if info, err := os.Stat(dest); err == nil && time.Since(info.ModTime()) < iconMaxAge {
+		return dest, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, def.Icon, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create icon request: %w", err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Keep serving a stale cached copy over a network error
+		if _, statErr := os.Stat(dest); statErr == nil {
+			return dest, nil
+		}
+		return "", fmt.Errorf("failed to fetch icon: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		if _, statErr := os.Stat(dest); statErr == nil {
+			return dest, nil
+		}
+		return "", fmt.Errorf("failed to fetch icon: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, iconMaxBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read icon: %w", err)
+	}
+
+	if err := c.write(dest, data); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// writeSVG materializes an embedded catalog SVG into the cache.
+func (c *IconCache) writeSVG(def AgentDef) (string, error) {
+	dest := filepath.Join(c.dir, def.ID+".svg")
+
+	// Rewrite only when the embedded document changed
+	if existing, err := os.ReadFile(dest); err == nil && string(existing) == def.IconSVG {
+		return dest, nil
+	}
+
+	if err := c.write(dest, []byte(def.IconSVG)); err != nil {
+		return "", err
+	}
+	return dest, nil
+}
+
+// write atomically writes an icon file via a temp file rename.
+func (c *IconCache) write(dest string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create icon cache directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(c.dir, ".icon-*")
+	if err != nil {
+		return fmt.Errorf("failed to write icon: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write icon: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write icon: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dest); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write icon: %w", err)
+	}
+	return nil
+}
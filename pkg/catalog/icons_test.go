@@ -0,0 +1,76 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIconCacheFetch(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte("png-bytes"))
+	}))
+	defer server.Close()
+
+	cache := NewIconCache(t.TempDir())
+	def := AgentDef{ID: "claude-code", Icon: server.URL + "/icons/claude.png"}
+
+	path, err := cache.Path(context.Background(), def)
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if filepath.Base(path) != "claude-code.png" {
+		t.Errorf("icon file = %q, want claude-code.png", filepath.Base(path))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("cached icon unreadable: %v", err)
+	}
+	if string(data) != "png-bytes" {
+		t.Errorf("icon content = %q, want downloaded bytes", data)
+	}
+
+	// A fresh cached copy must not be re-fetched
+	if _, err := cache.Path(context.Background(), def); err != nil {
+		t.Fatalf("Path() second call error = %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1 (cache hit)", requests)
+	}
+}
+
+func TestIconCacheEmbeddedSVG(t *testing.T) {
+	cache := NewIconCache(t.TempDir())
+	def := AgentDef{ID: "aider", IconSVG: "<svg><rect/></svg>"}
+
+	path, err := cache.Path(context.Background(), def)
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if !strings.HasSuffix(path, "aider.svg") {
+		t.Errorf("icon file = %q, want aider.svg", path)
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != def.IconSVG {
+		t.Errorf("icon content = %q, want embedded SVG", data)
+	}
+}
+
+func TestIconCacheNoIcon(t *testing.T) {
+	cache := NewIconCache(t.TempDir())
+
+	// Emoji icons have no file representation
+	path, err := cache.Path(context.Background(), AgentDef{ID: "goose", Icon: "🪿"})
+	if err != nil {
+		t.Fatalf("Path() error = %v", err)
+	}
+	if path != "" {
+		t.Errorf("Path() = %q, want empty for emoji icon", path)
+	}
+}
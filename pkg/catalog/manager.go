@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
 
@@ -22,8 +24,28 @@ type Manager struct {
 	catalog *Catalog
 	mu      sync.RWMutex
 
+	// Filtered view of the catalog (see filterCatalog)
+	filtered       *Catalog
+	filteredSource *Catalog
+	filterMu       sync.Mutex
+
 	// HTTP client for fetching remote catalog
 	httpClient *http.Client
+
+	// Refresh metrics
+	metrics   RefreshMetrics
+	metricsMu sync.Mutex
+}
+
+// RefreshMetrics tracks catalog refresh transfer sizes and latency.
+type RefreshMetrics struct {
+	LastRefreshAt    time.Time
+	LastDuration     time.Duration
+	LastBytes        int64
+	TotalBytes       int64
+	FullRefreshes    int
+	DeltaRefreshes   int
+	NotModifiedCount int
 }
 
 // NewManager creates a new catalog manager.
@@ -37,8 +59,20 @@ func NewManager(cfg *config.Config, store storage.Store) *Manager {
 	}
 }
 
-// Get returns the current catalog, loading from cache or embedded if needed.
+// Get returns the current catalog, loading from cache or embedded if
+// needed, with the catalog.exclude and catalog.include_only config
+// lists applied so a reduced catalog flows to every consumer.
 func (m *Manager) Get(ctx context.Context) (*Catalog, error) {
+	full, err := m.getFull(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return m.filterCatalog(full), nil
+}
+
+// getFull returns the unfiltered catalog. Refresh paths use it so the
+// cache and delta merges always operate on the complete agent set.
+func (m *Manager) getFull(ctx context.Context) (*Catalog, error) {
 	m.mu.RLock()
 	if m.catalog != nil {
 		defer m.mu.RUnlock()
@@ -70,20 +104,133 @@ func (m *Manager) Get(ctx context.Context) (*Catalog, error) {
 	return nil, fmt.Errorf("no catalog available")
 }
 
+// filterCatalog applies the configured include/exclude lists. The
+// filtered view is cached per source catalog so repeated Gets don't
+// rebuild it.
+func (m *Manager) filterCatalog(full *Catalog) *Catalog {
+	include := m.config.Catalog.IncludeOnly
+	exclude := m.config.Catalog.Exclude
+	if len(include) == 0 && len(exclude) == 0 {
+		return full
+	}
+
+	m.filterMu.Lock()
+	defer m.filterMu.Unlock()
+	if m.filteredSource == full && m.filtered != nil {
+		return m.filtered
+	}
+
+	included := make(map[string]bool, len(include))
+	for _, id := range include {
+		included[id] = true
+	}
+	excluded := make(map[string]bool, len(exclude))
+	for _, id := range exclude {
+		excluded[id] = true
+	}
+
+	view := *full
+	view.Agents = make(map[string]AgentDef, len(full.Agents))
+	for id, def := range full.Agents {
+		if len(included) > 0 && !included[id] {
+			continue
+		}
+		if excluded[id] {
+			continue
+		}
+		view.Agents[id] = def
+	}
+
+	m.filtered = &view
+	m.filteredSource = full
+	return m.filtered
+}
+
 // RefreshResult contains the result of a catalog refresh operation.
 type RefreshResult struct {
 	Updated        bool   // Whether the catalog was updated
 	CurrentVersion string // The current catalog version after refresh
 	RemoteVersion  string // The remote catalog version that was fetched
+	NotModified    bool   // Whether the remote reported no change
+	Delta          bool   // Whether the refresh was served by the delta endpoint
+	SchemaTooNew   bool   // Whether the remote catalog needs a newer app version
+	RemoteSchema   int    // The remote schema version when SchemaTooNew is set
+}
+
+// Metrics returns a snapshot of the refresh metrics.
+func (m *Manager) Metrics() RefreshMetrics {
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+	return m.metrics
+}
+
+// recordRefresh updates the refresh metrics for one completed attempt.
+func (m *Manager) recordRefresh(start time.Time, bytes int64, delta, notModified bool) {
+	m.metricsMu.Lock()
+	defer m.metricsMu.Unlock()
+
+	m.metrics.LastRefreshAt = time.Now()
+	m.metrics.LastDuration = time.Since(start)
+	m.metrics.LastBytes = bytes
+	m.metrics.TotalBytes += bytes
+	switch {
+	case notModified:
+		m.metrics.NotModifiedCount++
+	case delta:
+		m.metrics.DeltaRefreshes++
+	default:
+		m.metrics.FullRefreshes++
+	}
 }
 
 // Refresh fetches the latest catalog from the remote source.
-// It only updates if the remote version is newer than the current version.
-// Returns a RefreshResult indicating whether an update occurred.
+// It sends conditional request headers so an unchanged catalog costs a
+// 304 rather than a full transfer, uses the delta endpoint when one is
+// configured, and only updates if the remote version is newer than the
+// current version. Returns a RefreshResult indicating what happened.
 func (m *Manager) Refresh(ctx context.Context) (*RefreshResult, error) {
-	remoteCatalog, err := m.fetchRemote(ctx)
+	start := time.Now()
+
+	// Get current catalog (if available) for delta sync and version comparison
+	currentCatalog, _ := m.getFull(ctx) //nolint:errcheck // best-effort; nil catalog is handled below
+
+	// Try the delta endpoint first; fall back to a full fetch if the
+	// server can't produce a delta from our version
+	if m.config.Catalog.DeltaURL != "" && currentCatalog != nil && currentCatalog.Version != "" {
+		if result, ok := m.refreshDelta(ctx, currentCatalog, start); ok {
+			return result, nil
+		}
+	}
+
+	remoteCatalog, etag, bytes, notModified, err := m.fetchRemote(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch remote catalog: %w", err)
+		return nil, errcode.Errorf(errcode.CodeNetwork, "failed to fetch remote catalog: %w", err)
+	}
+
+	if notModified {
+		m.recordRefresh(start, bytes, false, true)
+		result := &RefreshResult{NotModified: true}
+		if currentCatalog != nil {
+			result.CurrentVersion = currentCatalog.Version
+			result.RemoteVersion = currentCatalog.Version
+		}
+		return result, nil
+	}
+
+	m.recordRefresh(start, bytes, false, false)
+
+	// A schema version beyond what this build understands means the app
+	// itself needs updating; keep serving the cached compatible catalog
+	if remoteCatalog.SchemaVersion > SupportedSchemaVersion {
+		result := &RefreshResult{
+			RemoteVersion: remoteCatalog.Version,
+			SchemaTooNew:  true,
+			RemoteSchema:  remoteCatalog.SchemaVersion,
+		}
+		if currentCatalog != nil {
+			result.CurrentVersion = currentCatalog.Version
+		}
+		return result, nil
 	}
 
 	// Validate the remote catalog
@@ -95,8 +242,7 @@ func (m *Manager) Refresh(ctx context.Context) (*RefreshResult, error) {
 		RemoteVersion: remoteCatalog.Version,
 	}
 
-	// Get current catalog (if available) and compare versions
-	currentCatalog, _ := m.Get(ctx) //nolint:errcheck // best-effort; nil catalog is handled below
+	// Compare versions against the current catalog
 	if currentCatalog != nil {
 		result.CurrentVersion = currentCatalog.Version
 
@@ -116,7 +262,7 @@ func (m *Manager) Refresh(ctx context.Context) (*RefreshResult, error) {
 	}
 
 	// Save to cache
-	if err := m.saveToCache(ctx, remoteCatalog); err != nil {
+	if err := m.saveToCache(ctx, remoteCatalog, etag); err != nil {
 		// Log but don't fail - we have the catalog in memory
 	}
 
@@ -129,6 +275,57 @@ func (m *Manager) Refresh(ctx context.Context) (*RefreshResult, error) {
 	return result, nil
 }
 
+// refreshDelta fetches and applies a catalog delta. It reports false
+// when the delta endpoint can't serve our version, so the caller falls
+// back to a full fetch.
+func (m *Manager) refreshDelta(ctx context.Context, currentCatalog *Catalog, start time.Time) (*RefreshResult, bool) {
+	delta, bytes, err := m.fetchDelta(ctx, currentCatalog.Version)
+	if err != nil || delta == nil {
+		return nil, false
+	}
+
+	result := &RefreshResult{
+		CurrentVersion: currentCatalog.Version,
+		RemoteVersion:  delta.Version,
+		Delta:          true,
+	}
+
+	// Deltas carrying a newer schema can't be applied by this build;
+	// keep the current catalog and let the caller surface the mismatch
+	if delta.SchemaVersion > SupportedSchemaVersion {
+		m.recordRefresh(start, bytes, true, false)
+		result.SchemaTooNew = true
+		result.RemoteSchema = delta.SchemaVersion
+		return result, true
+	}
+
+	if delta.Version == currentCatalog.Version || (len(delta.Updated) == 0 && len(delta.Removed) == 0) {
+		m.recordRefresh(start, bytes, false, true)
+		result.NotModified = true
+		return result, true
+	}
+
+	merged := delta.Apply(currentCatalog)
+	if err := merged.Validate(); err != nil {
+		// A bad delta shouldn't poison the catalog; do a full fetch
+		return nil, false
+	}
+
+	m.recordRefresh(start, bytes, true, false)
+
+	if err := m.saveToCache(ctx, merged, ""); err != nil {
+		// Log but don't fail - we have the catalog in memory
+	}
+
+	m.mu.Lock()
+	m.catalog = merged
+	m.mu.Unlock()
+
+	result.Updated = true
+	result.CurrentVersion = merged.Version
+	return result, true
+}
+
 // GetAgent returns a specific agent definition.
 func (m *Manager) GetAgent(ctx context.Context, id string) (*AgentDef, error) {
 	catalog, err := m.Get(ctx)
@@ -138,7 +335,7 @@ func (m *Manager) GetAgent(ctx context.Context, id string) (*AgentDef, error) {
 
 	agent, ok := catalog.GetAgent(id)
 	if !ok {
-		return nil, fmt.Errorf("agent not found: %s", id)
+		return nil, errcode.Errorf(errcode.CodeNotFound, "agent not found: %s", id)
 	}
 
 	return &agent, nil
@@ -212,15 +409,18 @@ func (m *Manager) loadFromCache(ctx context.Context) (*Catalog, error) {
 	return &catalog, nil
 }
 
-// saveToCache saves the catalog to storage cache.
-func (m *Manager) saveToCache(ctx context.Context, catalog *Catalog) error {
+// saveToCache saves the catalog to storage cache with the server's ETag,
+// falling back to the catalog version when the server didn't send one.
+func (m *Manager) saveToCache(ctx context.Context, catalog *Catalog, etag string) error {
 	data, err := json.Marshal(catalog)
 	if err != nil {
 		return err
 	}
 
-	// Use version as etag for cache validation
-	return m.store.SaveCatalogCache(ctx, data, catalog.Version)
+	if etag == "" {
+		etag = catalog.Version
+	}
+	return m.store.SaveCatalogCache(ctx, data, etag)
 }
 
 // loadEmbedded loads the embedded default catalog.
@@ -257,21 +457,35 @@ func (m *Manager) loadEmbedded() (*Catalog, error) {
 	return nil, fmt.Errorf("no embedded catalog found")
 }
 
-// fetchRemote fetches the catalog from the remote URL.
-func (m *Manager) fetchRemote(ctx context.Context) (*Catalog, error) {
+// fetchRemote fetches the catalog from the remote URL, sending
+// conditional request headers based on the cached copy. It returns the
+// response ETag and transfer size; notModified is true when the server
+// answered 304.
+func (m *Manager) fetchRemote(ctx context.Context) (catalog *Catalog, etag string, bytes int64, notModified bool, err error) {
 	url := m.config.Catalog.SourceURL
 	if url == "" {
-		return nil, fmt.Errorf("no catalog source URL configured")
+		return nil, "", 0, false, fmt.Errorf("no catalog source URL configured")
 	}
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return nil, err
+		return nil, "", 0, false, err
 	}
 
 	req.Header.Set("User-Agent", "AgentManager/1.0")
 	req.Header.Set("Accept", "application/json")
 
+	// Conditional headers from the cached copy; a stored version string
+	// doubles as a weak validator when the server never sent an ETag
+	if data, cachedETag, cachedAt, cacheErr := m.store.GetCatalogCache(ctx); cacheErr == nil && data != nil {
+		if cachedETag != "" {
+			req.Header.Set("If-None-Match", cachedETag)
+		}
+		if !cachedAt.IsZero() {
+			req.Header.Set("If-Modified-Since", cachedAt.UTC().Format(http.TimeFormat))
+		}
+	}
+
 	// Add GitHub token if configured
 	if m.config.Catalog.GitHubToken != "" {
 		req.Header.Set("Authorization", "token "+m.config.Catalog.GitHubToken)
@@ -279,25 +493,74 @@ func (m *Manager) fetchRemote(ctx context.Context) (*Catalog, error) {
 
 	resp, err := m.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, "", 0, false, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, "", 0, true, nil
+	}
+
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		return nil, "", 0, false, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return nil, "", 0, false, err
 	}
 
-	var catalog Catalog
-	if err := json.Unmarshal(body, &catalog); err != nil {
-		return nil, err
+	var cat Catalog
+	if err := json.Unmarshal(body, &cat); err != nil {
+		return nil, "", int64(len(body)), false, err
 	}
 
-	return &catalog, nil
+	return &cat, resp.Header.Get("ETag"), int64(len(body)), false, nil
+}
+
+// fetchDelta fetches the changes since the given catalog version from
+// the delta endpoint.
+func (m *Manager) fetchDelta(ctx context.Context, since string) (*CatalogDelta, int64, error) {
+	url := m.config.Catalog.DeltaURL + "?since=" + neturl.QueryEscape(since)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("User-Agent", "AgentManager/1.0")
+	req.Header.Set("Accept", "application/json")
+
+	if m.config.Catalog.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+m.config.Catalog.GitHubToken)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var delta CatalogDelta
+	if err := json.Unmarshal(body, &delta); err != nil {
+		return nil, int64(len(body)), err
+	}
+
+	// A delta computed from a different base would corrupt the merge
+	if delta.Since != "" && delta.Since != since {
+		return nil, int64(len(body)), fmt.Errorf("delta base %s does not match catalog version %s", delta.Since, since)
+	}
+
+	return &delta, int64(len(body)), nil
 }
 
 // getLatestGitHubVersion fetches the latest version from GitHub releases.
@@ -334,7 +597,7 @@ func (m *Manager) getLatestGitHubVersion(ctx context.Context, apiURL string) (*a
 	}
 
 	if len(releases) == 0 {
-		return nil, fmt.Errorf("no releases found")
+		return nil, errcode.Errorf(errcode.CodeNotFound, "no releases found")
 	}
 
 	// Parse the latest release tag
@@ -352,6 +615,86 @@ func (m *Manager) getLatestGitHubVersion(ctx context.Context, apiURL string) (*a
 	return &version, nil
 }
 
+// Release is a single published release of an agent, as reported by its
+// changelog source.
+type Release struct {
+	Version     string    `json:"version"`
+	Name        string    `json:"name"`
+	Notes       string    `json:"notes"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// GetReleases returns the agent's most recent releases, newest first, up
+// to limit entries. Only agents with a github_releases changelog source
+// are supported.
+func (m *Manager) GetReleases(ctx context.Context, agentID string, limit int) ([]Release, error) {
+	agentDef, err := m.GetAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if agentDef.Changelog.Type != "github_releases" {
+		return nil, fmt.Errorf("unsupported changelog type: %s", agentDef.Changelog.Type)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", agentDef.Changelog.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", "AgentManager/1.0")
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+
+	if m.config.Catalog.GitHubToken != "" {
+		req.Header.Set("Authorization", "token "+m.config.Catalog.GitHubToken)
+	}
+
+	resp, err := m.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	var ghReleases []struct {
+		TagName     string    `json:"tag_name"`
+		Name        string    `json:"name"`
+		Body        string    `json:"body"`
+		HTMLURL     string    `json:"html_url"`
+		PublishedAt time.Time `json:"published_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&ghReleases); err != nil {
+		return nil, err
+	}
+
+	releases := make([]Release, 0, len(ghReleases))
+	for _, gh := range ghReleases {
+		if limit > 0 && len(releases) == limit {
+			break
+		}
+		version := gh.TagName
+		if len(version) > 0 && version[0] == 'v' {
+			version = version[1:]
+		}
+		name := gh.Name
+		if name == "" {
+			name = gh.TagName
+		}
+		releases = append(releases, Release{
+			Version:     version,
+			Name:        name,
+			Notes:       gh.Body,
+			URL:         gh.HTMLURL,
+			PublishedAt: gh.PublishedAt,
+		})
+	}
+	return releases, nil
+}
+
 // getGitHubChangelog fetches changelog from GitHub releases between two versions.
 func (m *Manager) getGitHubChangelog(ctx context.Context, apiURL string, from, to agent.Version) (string, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", apiURL, nil)
@@ -64,6 +64,13 @@ func (m *mockStore) DeleteSetting(ctx context.Context, key string) error
 func (m *mockStore) SaveDetectionCache(ctx context.Context, installations []*agent.Installation) error {
 	return nil
 }
+func (m *mockStore) SaveJob(ctx context.Context, job *storage.Job) error { return nil }
+func (m *mockStore) GetJob(ctx context.Context, id string) (*storage.Job, error) {
+	return nil, nil
+}
+func (m *mockStore) ListJobs(ctx context.Context, state storage.JobState, limit int) ([]*storage.Job, error) {
+	return nil, nil
+}
 func (m *mockStore) GetDetectionCache(ctx context.Context) ([]*agent.Installation, time.Time, error) {
 	return nil, time.Time{}, nil
 }
@@ -521,3 +528,412 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestManagerRefreshNotModified(t *testing.T) {
+	catalog := createTestCatalog()
+	catalogJSON, _ := json.Marshal(catalog)
+
+	var gotETag, gotModifiedSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotETag = r.Header.Get("If-None-Match")
+		gotModifiedSince = r.Header.Get("If-Modified-Since")
+		if gotETag == `"abc123"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(catalogJSON)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.Catalog.SourceURL = server.URL + "/catalog.json"
+	store := &mockStore{catalogData: catalogJSON, catalogEtag: `"abc123"`}
+	mgr := NewManager(cfg, store)
+
+	result, err := mgr.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if gotETag != `"abc123"` {
+		t.Errorf("If-None-Match = %q, want cached etag", gotETag)
+	}
+	if gotModifiedSince == "" {
+		t.Error("expected If-Modified-Since header")
+	}
+	if !result.NotModified || result.Updated {
+		t.Errorf("result = %+v, want not modified", result)
+	}
+
+	metrics := mgr.Metrics()
+	if metrics.NotModifiedCount != 1 {
+		t.Errorf("NotModifiedCount = %d, want 1", metrics.NotModifiedCount)
+	}
+}
+
+func TestManagerRefreshStoresETag(t *testing.T) {
+	catalog := createTestCatalog()
+	catalogJSON, _ := json.Marshal(catalog)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1-etag"`)
+		w.Write(catalogJSON)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.Catalog.SourceURL = server.URL + "/catalog.json"
+	store := &mockStore{}
+	mgr := NewManager(cfg, store)
+
+	if _, err := mgr.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if store.catalogEtag != `"v1-etag"` {
+		t.Errorf("stored etag = %q, want server ETag", store.catalogEtag)
+	}
+
+	metrics := mgr.Metrics()
+	if metrics.FullRefreshes != 1 {
+		t.Errorf("FullRefreshes = %d, want 1", metrics.FullRefreshes)
+	}
+	if metrics.LastBytes != int64(len(catalogJSON)) || metrics.TotalBytes != int64(len(catalogJSON)) {
+		t.Errorf("bytes = %d/%d, want %d", metrics.LastBytes, metrics.TotalBytes, len(catalogJSON))
+	}
+}
+
+func TestManagerRefreshDelta(t *testing.T) {
+	catalog := createTestCatalog()
+	catalogJSON, _ := json.Marshal(catalog)
+
+	updated := catalog.Agents["claude-code"]
+	updated.Description = "Updated description"
+	delta := CatalogDelta{
+		Since:   catalog.Version,
+		Version: "1.1.0",
+		Updated: map[string]AgentDef{"claude-code": updated},
+		Removed: []string{"aider"},
+	}
+	deltaJSON, _ := json.Marshal(delta)
+
+	var gotSince string
+	var fullFetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/delta" {
+			gotSince = r.URL.Query().Get("since")
+			w.Write(deltaJSON)
+			return
+		}
+		fullFetches++
+		w.Write(catalogJSON)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.Catalog.SourceURL = server.URL + "/catalog.json"
+	cfg.Catalog.DeltaURL = server.URL + "/delta"
+	store := &mockStore{catalogData: catalogJSON}
+	mgr := NewManager(cfg, store)
+
+	result, err := mgr.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if gotSince != catalog.Version {
+		t.Errorf("since = %q, want %q", gotSince, catalog.Version)
+	}
+	if fullFetches != 0 {
+		t.Errorf("full fetches = %d, want delta only", fullFetches)
+	}
+	if !result.Updated || !result.Delta {
+		t.Errorf("result = %+v, want delta update", result)
+	}
+
+	merged, err := mgr.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.Version != "1.1.0" {
+		t.Errorf("Version = %q, want 1.1.0", merged.Version)
+	}
+	if _, ok := merged.Agents["aider"]; ok {
+		t.Error("aider should be removed by the delta")
+	}
+	if merged.Agents["claude-code"].Description != "Updated description" {
+		t.Errorf("Description = %q, not updated", merged.Agents["claude-code"].Description)
+	}
+
+	if metrics := mgr.Metrics(); metrics.DeltaRefreshes != 1 {
+		t.Errorf("DeltaRefreshes = %d, want 1", metrics.DeltaRefreshes)
+	}
+}
+
+func TestManagerRefreshDeltaFallback(t *testing.T) {
+	catalog := createTestCatalog()
+	catalogJSON, _ := json.Marshal(catalog)
+
+	newer := createTestCatalog()
+	newer.Version = "2.0.0"
+	newerJSON, _ := json.Marshal(newer)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/delta" {
+			// The server can't produce a delta from this base version
+			http.Error(w, "unknown base version", http.StatusGone)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(newerJSON)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.Catalog.SourceURL = server.URL + "/catalog.json"
+	cfg.Catalog.DeltaURL = server.URL + "/delta"
+	store := &mockStore{catalogData: catalogJSON}
+	mgr := NewManager(cfg, store)
+
+	result, err := mgr.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if !result.Updated || result.Delta {
+		t.Errorf("result = %+v, want full-fetch update", result)
+	}
+	if result.CurrentVersion != "2.0.0" {
+		t.Errorf("CurrentVersion = %q, want 2.0.0", result.CurrentVersion)
+	}
+}
+
+func TestManagerRefreshSchemaTooNew(t *testing.T) {
+	cached := createTestCatalog()
+	cachedJSON, _ := json.Marshal(cached)
+
+	remote := createTestCatalog()
+	remote.Version = "9.0.0"
+	remote.SchemaVersion = SupportedSchemaVersion + 1
+	remoteJSON, _ := json.Marshal(remote)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(remoteJSON)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.Catalog.SourceURL = server.URL + "/catalog.json"
+	store := &mockStore{catalogData: cachedJSON}
+	mgr := NewManager(cfg, store)
+
+	result, err := mgr.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if !result.SchemaTooNew {
+		t.Error("result.SchemaTooNew = false, want true")
+	}
+	if result.Updated {
+		t.Error("result.Updated = true, want false")
+	}
+	if result.RemoteSchema != SupportedSchemaVersion+1 {
+		t.Errorf("RemoteSchema = %d, want %d", result.RemoteSchema, SupportedSchemaVersion+1)
+	}
+	if result.RemoteVersion != "9.0.0" {
+		t.Errorf("RemoteVersion = %q, want 9.0.0", result.RemoteVersion)
+	}
+	if result.CurrentVersion != cached.Version {
+		t.Errorf("CurrentVersion = %q, want %q", result.CurrentVersion, cached.Version)
+	}
+
+	// The cached compatible catalog stays in use
+	got, err := mgr.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Version != cached.Version {
+		t.Errorf("Get() version = %q, want cached %q", got.Version, cached.Version)
+	}
+
+	// The incompatible catalog must not overwrite the cache
+	var stored Catalog
+	if err := json.Unmarshal(store.catalogData, &stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored.Version != cached.Version {
+		t.Errorf("cached version = %q, want %q", stored.Version, cached.Version)
+	}
+}
+
+func TestManagerRefreshDeltaSchemaTooNew(t *testing.T) {
+	cached := createTestCatalog()
+	cachedJSON, _ := json.Marshal(cached)
+
+	delta := CatalogDelta{
+		Since:         cached.Version,
+		Version:       "9.0.0",
+		SchemaVersion: SupportedSchemaVersion + 1,
+		Updated:       map[string]AgentDef{"claude-code": cached.Agents["claude-code"]},
+	}
+	deltaJSON, _ := json.Marshal(delta)
+
+	var fullFetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/delta" {
+			w.Write(deltaJSON)
+			return
+		}
+		fullFetches++
+		w.Write(cachedJSON)
+	}))
+	defer server.Close()
+
+	cfg := newTestConfig()
+	cfg.Catalog.SourceURL = server.URL + "/catalog.json"
+	cfg.Catalog.DeltaURL = server.URL + "/delta"
+	store := &mockStore{catalogData: cachedJSON}
+	mgr := NewManager(cfg, store)
+
+	result, err := mgr.Refresh(context.Background())
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+
+	if !result.SchemaTooNew {
+		t.Error("result.SchemaTooNew = false, want true")
+	}
+	if result.Updated {
+		t.Error("result.Updated = true, want false")
+	}
+	if fullFetches != 0 {
+		t.Errorf("full fetches = %d, want 0 (no point retrying an incompatible catalog)", fullFetches)
+	}
+
+	got, err := mgr.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if got.Version != cached.Version {
+		t.Errorf("Get() version = %q, want cached %q", got.Version, cached.Version)
+	}
+}
+
+func TestCatalogDeltaApply(t *testing.T) {
+	base := createTestCatalog()
+
+	added := AgentDef{
+		ID:   "new-agent",
+		Name: "New Agent",
+		InstallMethods: map[string]InstallMethodDef{
+			"npm": {Method: "npm", Package: "new-agent"},
+		},
+		Detection: DetectionDef{Executables: []string{"new-agent"}},
+	}
+	delta := &CatalogDelta{
+		Version: "1.2.0",
+		Updated: map[string]AgentDef{"new-agent": added},
+		Removed: []string{"aider", "not-present"},
+	}
+
+	merged := delta.Apply(base)
+
+	if merged.Version != "1.2.0" {
+		t.Errorf("Version = %q, want 1.2.0", merged.Version)
+	}
+	if _, ok := merged.Agents["new-agent"]; !ok {
+		t.Error("new-agent should be added")
+	}
+	if _, ok := merged.Agents["aider"]; ok {
+		t.Error("aider should be removed")
+	}
+	if _, ok := merged.Agents["claude-code"]; !ok {
+		t.Error("claude-code should be untouched")
+	}
+
+	// The base catalog is not mutated
+	if _, ok := base.Agents["aider"]; !ok {
+		t.Error("Apply must not mutate the base catalog")
+	}
+}
+
+func TestManagerFilterLists(t *testing.T) {
+	catalog := createTestCatalog()
+	data, _ := json.Marshal(catalog)
+
+	t.Run("exclude hides agents", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Catalog.Exclude = []string{"copilot"}
+		mgr := NewManager(cfg, &mockStore{catalogData: data})
+
+		ctx := context.Background()
+		result, err := mgr.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if _, ok := result.GetAgent("copilot"); ok {
+			t.Error("excluded agent should not be in the catalog")
+		}
+		if _, ok := result.GetAgent("claude-code"); !ok {
+			t.Error("non-excluded agent should remain in the catalog")
+		}
+		if _, err := mgr.GetAgent(ctx, "copilot"); err == nil {
+			t.Error("GetAgent() should not find an excluded agent")
+		}
+	})
+
+	t.Run("include_only restricts the catalog", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Catalog.IncludeOnly = []string{"claude-code", "aider"}
+		mgr := NewManager(cfg, &mockStore{catalogData: data})
+
+		ctx := context.Background()
+		result, err := mgr.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(result.Agents) != 2 {
+			t.Errorf("Agents count = %d, want 2", len(result.Agents))
+		}
+		if _, ok := result.GetAgent("copilot"); ok {
+			t.Error("agent outside include_only should not be in the catalog")
+		}
+	})
+
+	t.Run("exclude applies on top of include_only", func(t *testing.T) {
+		cfg := newTestConfig()
+		cfg.Catalog.IncludeOnly = []string{"claude-code", "aider"}
+		cfg.Catalog.Exclude = []string{"aider"}
+		mgr := NewManager(cfg, &mockStore{catalogData: data})
+
+		result, err := mgr.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(result.Agents) != 1 {
+			t.Errorf("Agents count = %d, want 1", len(result.Agents))
+		}
+		if _, ok := result.GetAgent("claude-code"); !ok {
+			t.Error("claude-code should remain in the catalog")
+		}
+	})
+
+	t.Run("no lists returns the full catalog", func(t *testing.T) {
+		cfg := newTestConfig()
+		mgr := NewManager(cfg, &mockStore{catalogData: data})
+
+		result, err := mgr.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if len(result.Agents) != len(catalog.Agents) {
+			t.Errorf("Agents count = %d, want %d", len(result.Agents), len(catalog.Agents))
+		}
+	})
+}
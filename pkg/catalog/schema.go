@@ -8,6 +8,11 @@ import (
 	"time"
 )
 
+// SupportedSchemaVersion is the newest catalog schema version this build
+// understands. Remote catalogs with a higher schema_version are ignored
+// in favor of the cached compatible copy until the app itself is updated.
+const SupportedSchemaVersion = 1
+
 // Catalog represents the complete agent catalog.
 type Catalog struct {
 	Version       string              `json:"version"`
@@ -27,10 +32,52 @@ type AgentDef struct {
 	Repository     string                      `json:"repository,omitempty"`
 	Documentation  string                      `json:"documentation,omitempty"`
 	Icon           string                      `json:"icon,omitempty"`
+	IconSVG        string                      `json:"icon_svg,omitempty"`
 	InstallMethods map[string]InstallMethodDef `json:"install_methods"`
 	Detection      DetectionDef                `json:"detection"`
 	Changelog      ChangelogDef                `json:"changelog,omitempty"`
 	Metadata       map[string]string           `json:"metadata,omitempty"`
+
+	// Requirements lists tools the agent depends on, such as Node.js >= 18
+	// or git. They are checked before install and shown alongside the
+	// agent's details.
+	Requirements []RequirementDef `json:"requirements,omitempty"`
+
+	// VersionScheme selects how this agent's versions are parsed and
+	// ordered: "semver" (the default), "calver" for calendar versions,
+	// or "regex" to order by the capture groups of VersionPattern.
+	VersionScheme string `json:"version_scheme,omitempty"`
+	// VersionPattern is the pattern for the regex version scheme, with
+	// capture groups ordered from most to least significant.
+	VersionPattern string `json:"version_pattern,omitempty"`
+
+	// SmokeTestCmd is run after install or update to verify the agent
+	// actually works (e.g. "claude doctor"). When empty, the detection
+	// version command is used and its reported version is compared
+	// against the version that was just installed.
+	SmokeTestCmd string `json:"smoke_test_cmd,omitempty"`
+
+	// Auth describes how to check and establish the agent's sign-in
+	// state, for agents that require an account.
+	Auth AuthDef `json:"auth,omitempty"`
+
+	// Deprecated marks an agent the catalog has sunset: it is badged as
+	// deprecated in listings and should no longer be newly installed.
+	// ReplacedBy optionally names the catalog agent that supersedes it
+	// (e.g. after a package rename), and DeprecationReason optionally
+	// explains why for user-facing messages.
+	Deprecated        bool   `json:"deprecated,omitempty"`
+	ReplacedBy        string `json:"replaced_by,omitempty"`
+	DeprecationReason string `json:"deprecation_reason,omitempty"`
+}
+
+// AuthDef describes the authentication commands of an agent that
+// requires signing in to an account. CheckCmd exits zero when the
+// agent is signed in (e.g. "gh auth status"); LoginCmd starts the
+// agent's interactive login flow (e.g. "gh auth login").
+type AuthDef struct {
+	CheckCmd string `json:"check_cmd,omitempty"`
+	LoginCmd string `json:"login_cmd,omitempty"`
 }
 
 // AgentCategory represents a category for grouping agents.
@@ -45,17 +92,63 @@ const (
 	CategoryOther        AgentCategory = "other"
 )
 
+// CategoryOrder returns the known categories in display order.
+func CategoryOrder() []AgentCategory {
+	return []AgentCategory{
+		CategoryCoding,
+		CategoryDevOps,
+		CategoryTerminal,
+		CategoryResearch,
+		CategoryProductivity,
+		CategoryOther,
+	}
+}
+
+// DisplayName returns a human-readable name for the category.
+func (c AgentCategory) DisplayName() string {
+	switch c {
+	case CategoryCoding:
+		return "Coding"
+	case CategoryDevOps:
+		return "DevOps"
+	case CategoryTerminal:
+		return "Terminal"
+	case CategoryResearch:
+		return "Research"
+	case CategoryProductivity:
+		return "Productivity"
+	case CategoryOther:
+		return "Other"
+	default:
+		return string(c)
+	}
+}
+
 // InstallMethodDef defines how to install via a specific method.
+// Command fields and metadata values may reference the template variables
+// {version}, {arch}, {os}, and {prefix}, which the installer expands with
+// validated values before running the method.
 type InstallMethodDef struct {
-	Method       string            `json:"method"`
-	Package      string            `json:"package,omitempty"`
-	Command      string            `json:"command"`
-	UpdateCmd    string            `json:"update_cmd,omitempty"`
-	UninstallCmd string            `json:"uninstall_cmd,omitempty"`
-	Platforms    []string          `json:"platforms"`
-	GlobalFlag   string            `json:"global_flag,omitempty"`
-	PreReqs      []string          `json:"prereqs,omitempty"`
-	Metadata     map[string]string `json:"metadata,omitempty"`
+	Method       string `json:"method"`
+	Package      string `json:"package,omitempty"`
+	Command      string `json:"command"`
+	UpdateCmd    string `json:"update_cmd,omitempty"`
+	UninstallCmd string `json:"uninstall_cmd,omitempty"`
+	// MigrateTo names a preferred replacement method when this method is
+	// deprecated (e.g. the npm distribution superseded by a native
+	// installer). MigrateReason optionally explains why.
+	MigrateTo     string `json:"migrate_to,omitempty"`
+	MigrateReason string `json:"migrate_reason,omitempty"`
+	// RestartRequired marks methods whose updates only take effect after a
+	// dependent application restarts (e.g. an IDE hosting an extension).
+	// RestartTarget optionally names that application for user-facing
+	// messages.
+	RestartRequired bool              `json:"restart_required,omitempty"`
+	RestartTarget   string            `json:"restart_target,omitempty"`
+	Platforms       []string          `json:"platforms"`
+	GlobalFlag      string            `json:"global_flag,omitempty"`
+	PreReqs         []string          `json:"prereqs,omitempty"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
 }
 
 // DetectionDef defines how to detect an agent.
@@ -64,6 +157,16 @@ type DetectionDef struct {
 	VersionCmd   string                  `json:"version_cmd"`
 	VersionRegex string                  `json:"version_regex,omitempty"`
 	Signatures   map[string]SignatureDef `json:"signatures,omitempty"`
+
+	// BundleIdentifiers lists macOS application bundle identifiers for
+	// agents shipped as GUI apps (e.g. "com.anthropic.claudefordesktop").
+	BundleIdentifiers []string `json:"bundle_identifiers,omitempty"`
+
+	// ConfigDirs lists directories whose presence hints the agent was
+	// installed at some point (e.g. "~/.claude"); "~" expands to the
+	// user's home directory. Agents found only this way are reported as
+	// configured but not installed.
+	ConfigDirs []string `json:"config_dirs,omitempty"`
 }
 
 // SignatureDef defines detection signatures for a specific install method.
@@ -73,6 +176,38 @@ type SignatureDef struct {
 	Paths       []string `json:"paths,omitempty"`
 }
 
+// RequirementDef declares a prerequisite tool an agent depends on, such as
+// Node.js >= 18 or git.
+type RequirementDef struct {
+	// Name is the human-readable name of the dependency (e.g. "Node.js").
+	Name string `json:"name"`
+	// Command is the executable looked up on PATH (e.g. "node").
+	Command string `json:"command"`
+	// MinVersion is the minimum acceptable version, if any (e.g. "18.0.0").
+	MinVersion string `json:"min_version,omitempty"`
+	// VersionCmd queries the installed version (e.g. "node --version").
+	// Defaults to "<command> --version".
+	VersionCmd string `json:"version_cmd,omitempty"`
+	// VersionRegex optionally extracts the version from the command output
+	// via its first capture group.
+	VersionRegex string `json:"version_regex,omitempty"`
+	// Fix is an actionable hint for installing or upgrading the dependency.
+	Fix string `json:"fix,omitempty"`
+	// FixCmd is a shell-free command that installs the dependency, run when
+	// the user opts in to installing missing prerequisites.
+	FixCmd string `json:"fix_cmd,omitempty"`
+	// Optional requirements produce warnings instead of blocking install.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// DisplayName returns the requirement's name, falling back to its command.
+func (r RequirementDef) DisplayName() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return r.Command
+}
+
 // ChangelogDef defines where to fetch changelogs.
 type ChangelogDef struct {
 	Type       string `json:"type"` // "github_releases", "file", "api"
@@ -80,6 +215,14 @@ type ChangelogDef struct {
 	FileFormat string `json:"file_format,omitempty"` // "markdown", "json", "plain"
 }
 
+// CategoryOrDefault returns the agent's category, or CategoryOther when unset.
+func (a AgentDef) CategoryOrDefault() AgentCategory {
+	if a.Category == "" {
+		return CategoryOther
+	}
+	return AgentCategory(a.Category)
+}
+
 // IsSupported returns true if the agent is supported on the given platform.
 func (a AgentDef) IsSupported(platformID string) bool {
 	for _, method := range a.InstallMethods {
@@ -170,6 +313,16 @@ func (c *Catalog) GetAgent(id string) (AgentDef, bool) {
 	return agent, ok
 }
 
+// ReplacementFor resolves a deprecated agent's replacement. It returns
+// false when the agent is not deprecated, names no replacement, or the
+// replacement is missing from the catalog.
+func (c *Catalog) ReplacementFor(a AgentDef) (AgentDef, bool) {
+	if !a.Deprecated || a.ReplacedBy == "" || a.ReplacedBy == a.ID {
+		return AgentDef{}, false
+	}
+	return c.GetAgent(a.ReplacedBy)
+}
+
 // GetAgentsByPlatform returns all agents supported on the given platform.
 func (c *Catalog) GetAgentsByPlatform(platformID string) []AgentDef {
 	var agents []AgentDef
@@ -224,6 +377,14 @@ func (c *Catalog) Validate() error {
 		if !hasExecutables && !hasSignatures {
 			return fmt.Errorf("agent %s has no executables or signatures defined", id)
 		}
+		if agent.ReplacedBy != "" {
+			if agent.ReplacedBy == id {
+				return fmt.Errorf("agent %s names itself as its replacement", id)
+			}
+			if _, ok := c.Agents[agent.ReplacedBy]; !ok {
+				return fmt.Errorf("agent %s names unknown replacement %s", id, agent.ReplacedBy)
+			}
+		}
 	}
 
 	return nil
@@ -315,3 +476,44 @@ func (c *Catalog) GroupByCategory() map[string][]AgentDef {
 
 	return groups
 }
+
+// CatalogDelta describes the changes between two catalog versions, as
+// served by a delta endpoint. Updated holds new or changed agent
+// definitions keyed by ID; Removed lists IDs deleted since the base
+// version.
+type CatalogDelta struct {
+	Since         string              `json:"since,omitempty"`
+	Version       string              `json:"version"`
+	SchemaVersion int                 `json:"schema_version,omitempty"`
+	LastUpdated   time.Time           `json:"last_updated,omitempty"`
+	Updated       map[string]AgentDef `json:"updated,omitempty"`
+	Removed       []string            `json:"removed,omitempty"`
+}
+
+// Apply returns a copy of the base catalog with the delta applied.
+func (d *CatalogDelta) Apply(base *Catalog) *Catalog {
+	merged := &Catalog{
+		Version:       d.Version,
+		SchemaVersion: base.SchemaVersion,
+		LastUpdated:   d.LastUpdated,
+		Agents:        make(map[string]AgentDef, len(base.Agents)+len(d.Updated)),
+	}
+	if d.SchemaVersion != 0 {
+		merged.SchemaVersion = d.SchemaVersion
+	}
+	if merged.LastUpdated.IsZero() {
+		merged.LastUpdated = base.LastUpdated
+	}
+
+	for id, agent := range base.Agents {
+		merged.Agents[id] = agent
+	}
+	for id, agent := range d.Updated {
+		merged.Agents[id] = agent
+	}
+	for _, id := range d.Removed {
+		delete(merged.Agents, id)
+	}
+
+	return merged
+}
@@ -502,3 +502,79 @@ func TestSignatureDef(t *testing.T) {
 		t.Errorf("Paths count = %d, want 2", len(sig.Paths))
 	}
 }
+
+func TestRequirementDefDisplayName(t *testing.T) {
+	tests := []struct {
+		name string
+		req  RequirementDef
+		want string
+	}{
+		{"uses name", RequirementDef{Name: "Node.js", Command: "node"}, "Node.js"},
+		{"falls back to command", RequirementDef{Command: "git"}, "git"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.req.DisplayName(); got != tt.want {
+				t.Errorf("DisplayName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReplacementFor(t *testing.T) {
+	cat := createTestCatalog()
+
+	old := AgentDef{ID: "copilot-cli", Deprecated: true, ReplacedBy: "copilot"}
+	replacement, ok := cat.ReplacementFor(old)
+	if !ok {
+		t.Fatal("expected a replacement for the deprecated agent")
+	}
+	if replacement.ID != "copilot" {
+		t.Errorf("replacement ID = %q, want %q", replacement.ID, "copilot")
+	}
+
+	// Not deprecated: the hint is ignored
+	if _, ok := cat.ReplacementFor(AgentDef{ID: "copilot-cli", ReplacedBy: "copilot"}); ok {
+		t.Error("agent that is not deprecated should have no replacement")
+	}
+
+	// Deprecated without a named replacement
+	if _, ok := cat.ReplacementFor(AgentDef{ID: "copilot-cli", Deprecated: true}); ok {
+		t.Error("deprecated agent without replaced_by should have no replacement")
+	}
+
+	// Replacement missing from the catalog
+	if _, ok := cat.ReplacementFor(AgentDef{ID: "copilot-cli", Deprecated: true, ReplacedBy: "gone"}); ok {
+		t.Error("replacement missing from the catalog should resolve to nothing")
+	}
+
+	// Self-referencing hint is ignored
+	if _, ok := cat.ReplacementFor(AgentDef{ID: "copilot", Deprecated: true, ReplacedBy: "copilot"}); ok {
+		t.Error("self-referencing replacement should resolve to nothing")
+	}
+}
+
+func TestCatalogValidateReplacedBy(t *testing.T) {
+	cat := createTestCatalog()
+
+	agent := cat.Agents["aider"]
+	agent.Deprecated = true
+	agent.ReplacedBy = "copilot"
+	cat.Agents["aider"] = agent
+	if err := cat.Validate(); err != nil {
+		t.Errorf("valid replaced_by should pass validation: %v", err)
+	}
+
+	agent.ReplacedBy = "does-not-exist"
+	cat.Agents["aider"] = agent
+	if err := cat.Validate(); err == nil {
+		t.Error("expected validation to fail for unknown replacement")
+	}
+
+	agent.ReplacedBy = "aider"
+	cat.Agents["aider"] = agent
+	if err := cat.Validate(); err == nil {
+		t.Error("expected validation to fail for self-referencing replacement")
+	}
+}
@@ -0,0 +1,108 @@
+package catalog
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+// knownPlatforms are the platform IDs a method's platforms list may name.
+var knownPlatforms = map[string]bool{
+	"darwin":  true,
+	"linux":   true,
+	"windows": true,
+}
+
+// Validate runs comprehensive checks on a single agent definition and
+// returns every problem found, so catalog authors can fix a definition
+// in one pass. It is stricter than Catalog.Validate, which only guards
+// the fields the app itself depends on.
+func Validate(def AgentDef) []error {
+	var errs []error
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf(format, args...))
+	}
+
+	if def.ID == "" {
+		fail("agent has no id")
+	}
+	if def.Name == "" {
+		fail("agent has no name")
+	}
+
+	validateURL := func(field, raw string) {
+		if raw == "" {
+			return
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fail("%s %q is not a valid http(s) URL", field, raw)
+		}
+	}
+	validateURL("homepage", def.Homepage)
+	validateURL("repository", def.Repository)
+	validateURL("changelog url", def.Changelog.URL)
+
+	if len(def.InstallMethods) == 0 {
+		fail("agent has no install methods")
+	}
+	for name, method := range def.InstallMethods {
+		// The map key is just a unique label; variants like "brew-cask"
+		// legitimately point at another method's provider.
+		if method.Method == "" {
+			fail("install method %q declares no method name", name)
+		}
+		// Binary methods install from a download URL in metadata instead
+		// of a package or command.
+		if method.Package == "" && method.Command == "" && method.Metadata["download_url"] == "" {
+			fail("install method %q has no package, command, or download_url", name)
+		}
+		if len(method.Platforms) == 0 {
+			fail("install method %q lists no platforms", name)
+		}
+		for _, p := range method.Platforms {
+			if !knownPlatforms[p] {
+				fail("install method %q names unknown platform %q", name, p)
+			}
+		}
+	}
+
+	if len(def.Detection.Executables) == 0 && len(def.Detection.Signatures) == 0 {
+		fail("detection has no executables or signatures")
+	}
+	if def.Detection.VersionRegex != "" {
+		if _, err := regexp.Compile(def.Detection.VersionRegex); err != nil {
+			fail("detection version_regex does not compile: %v", err)
+		}
+	}
+	for method, sig := range def.Detection.Signatures {
+		if sig.CheckCmd == "" && sig.PathPattern == "" && len(sig.Paths) == 0 {
+			fail("signature for method %q is empty", method)
+		}
+	}
+
+	if def.VersionScheme == "regex" {
+		if def.VersionPattern == "" {
+			fail("version scheme %q requires a version_pattern", def.VersionScheme)
+		} else if _, err := regexp.Compile(def.VersionPattern); err != nil {
+			fail("version_pattern does not compile: %v", err)
+		}
+	}
+
+	for _, req := range def.Requirements {
+		if req.Command == "" {
+			fail("requirement %q has no command", req.DisplayName())
+		}
+		if req.VersionRegex != "" {
+			if _, err := regexp.Compile(req.VersionRegex); err != nil {
+				fail("requirement %q version_regex does not compile: %v", req.DisplayName(), err)
+			}
+		}
+	}
+
+	if def.ReplacedBy != "" && def.ReplacedBy == def.ID {
+		fail("agent names itself as its replacement")
+	}
+
+	return errs
+}
@@ -0,0 +1,149 @@
+package catalog
+
+import (
+	"strings"
+	"testing"
+)
+
+func validTestAgentDef() AgentDef {
+	return AgentDef{
+		ID:         "test-agent",
+		Name:       "Test Agent",
+		Homepage:   "https://example.com",
+		Repository: "https://github.com/example/test-agent",
+		InstallMethods: map[string]InstallMethodDef{
+			"npm": {
+				Method:    "npm",
+				Package:   "test-agent",
+				Command:   "npm install -g test-agent",
+				Platforms: []string{"darwin", "linux", "windows"},
+			},
+		},
+		Detection: DetectionDef{
+			Executables:  []string{"test-agent"},
+			VersionCmd:   "test-agent --version",
+			VersionRegex: `([\d.]+)`,
+		},
+	}
+}
+
+func assertValidationError(t *testing.T, def AgentDef, want string) {
+	t.Helper()
+	errs := Validate(def)
+	for _, err := range errs {
+		if strings.Contains(err.Error(), want) {
+			return
+		}
+	}
+	t.Errorf("Validate() = %v, want an error containing %q", errs, want)
+}
+
+func TestValidateAgentDef(t *testing.T) {
+	if errs := Validate(validTestAgentDef()); len(errs) != 0 {
+		t.Errorf("Validate() on a valid definition = %v, want none", errs)
+	}
+}
+
+func TestValidateAgentDefMissingFields(t *testing.T) {
+	def := validTestAgentDef()
+	def.ID = ""
+	def.Name = ""
+	def.InstallMethods = nil
+	def.Detection = DetectionDef{}
+
+	errs := Validate(def)
+	if len(errs) < 4 {
+		t.Errorf("Validate() reported %d errors, want at least 4: %v", len(errs), errs)
+	}
+	assertValidationError(t, def, "no id")
+	assertValidationError(t, def, "no name")
+	assertValidationError(t, def, "no install methods")
+	assertValidationError(t, def, "no executables or signatures")
+}
+
+func TestValidateAgentDefBadMethod(t *testing.T) {
+	def := validTestAgentDef()
+	def.InstallMethods["npm"] = InstallMethodDef{
+		Platforms: []string{"darwin", "beos"},
+	}
+
+	assertValidationError(t, def, "declares no method name")
+	assertValidationError(t, def, "no package, command, or download_url")
+	assertValidationError(t, def, `unknown platform "beos"`)
+
+	def.InstallMethods["npm"] = InstallMethodDef{
+		Method:  "npm",
+		Package: "test-agent",
+	}
+	assertValidationError(t, def, "lists no platforms")
+
+	// Binary methods may carry only a download URL in metadata.
+	def = validTestAgentDef()
+	def.InstallMethods["binary"] = InstallMethodDef{
+		Method:    "binary",
+		Platforms: []string{"darwin", "linux"},
+		Metadata:  map[string]string{"download_url": "https://example.com/releases"},
+	}
+	if errs := Validate(def); len(errs) != 0 {
+		t.Errorf("Validate() = %v, want none", errs)
+	}
+}
+
+func TestValidateAgentDefBadRegex(t *testing.T) {
+	def := validTestAgentDef()
+	def.Detection.VersionRegex = "([unclosed"
+	assertValidationError(t, def, "version_regex does not compile")
+
+	def = validTestAgentDef()
+	def.Requirements = []RequirementDef{{
+		Name:         "node",
+		Command:      "node",
+		VersionRegex: "([unclosed",
+	}}
+	assertValidationError(t, def, `requirement "node" version_regex does not compile`)
+}
+
+func TestValidateAgentDefBadURL(t *testing.T) {
+	def := validTestAgentDef()
+	def.Homepage = "not a url"
+	def.Repository = "ftp://example.com/repo"
+	def.Changelog.URL = "https://"
+
+	assertValidationError(t, def, `homepage "not a url"`)
+	assertValidationError(t, def, `repository "ftp://example.com/repo"`)
+	assertValidationError(t, def, `changelog url "https://"`)
+}
+
+func TestValidateAgentDefVersionPattern(t *testing.T) {
+	def := validTestAgentDef()
+	def.VersionScheme = "regex"
+	assertValidationError(t, def, "requires a version_pattern")
+
+	def.VersionPattern = "([unclosed"
+	assertValidationError(t, def, "version_pattern does not compile")
+
+	def.VersionPattern = `(\d+)\.(\d+)`
+	if errs := Validate(def); len(errs) != 0 {
+		t.Errorf("Validate() = %v, want none", errs)
+	}
+}
+
+func TestValidateAgentDefEmptySignature(t *testing.T) {
+	def := validTestAgentDef()
+	def.Detection.Signatures = map[string]SignatureDef{
+		"npm": {},
+	}
+	assertValidationError(t, def, `signature for method "npm" is empty`)
+}
+
+func TestValidateAgentDefRequirementCommand(t *testing.T) {
+	def := validTestAgentDef()
+	def.Requirements = []RequirementDef{{Name: "git"}}
+	assertValidationError(t, def, `requirement "git" has no command`)
+}
+
+func TestValidateAgentDefSelfReplacement(t *testing.T) {
+	def := validTestAgentDef()
+	def.ReplacedBy = def.ID
+	assertValidationError(t, def, "itself as its replacement")
+}
@@ -2,6 +2,8 @@
 package config
 
 import (
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -25,6 +27,27 @@ type Config struct {
 	// Helper/Systray settings
 	Helper HelperConfig `yaml:"helper" json:"helper" mapstructure:"helper"`
 
+	// Terminal settings for actions that open a terminal window
+	Terminal TerminalConfig `yaml:"terminal" json:"terminal" mapstructure:"terminal"`
+
+	// Installers settings pinning the package manager tools to use
+	Installers InstallersConfig `yaml:"installers" json:"installers" mapstructure:"installers"`
+
+	// Hooks are user scripts run around install and update operations
+	Hooks HooksConfig `yaml:"hooks" json:"hooks" mapstructure:"hooks"`
+
+	// Fleet settings for managing agents on remote machines
+	Fleet FleetConfig `yaml:"fleet" json:"fleet" mapstructure:"fleet"`
+
+	// Policy settings for centrally managed machines
+	Policy PolicyConfig `yaml:"policy" json:"policy" mapstructure:"policy"`
+
+	// Stats settings for the opt-in usage statistics view
+	Stats StatsConfig `yaml:"stats" json:"stats" mapstructure:"stats"`
+
+	// Enrollment settings for central fleet management
+	Enrollment EnrollmentConfig `yaml:"enrollment" json:"enrollment" mapstructure:"enrollment"`
+
 	// Logging settings
 	Logging LoggingConfig `yaml:"logging" json:"logging" mapstructure:"logging"`
 
@@ -42,6 +65,12 @@ type DetectionConfig struct {
 
 	// CacheEnabled enables caching of detected agents
 	CacheEnabled bool `yaml:"cache_enabled" json:"cache_enabled" mapstructure:"cache_enabled"`
+
+	// LoginShellPath probes the login shell for PATH entries only added
+	// in shell rc files (pyenv shims, nvm) and includes them in
+	// detection. Off by default: the probe runs the user's shell, which
+	// is not safe in untrusted environments.
+	LoginShellPath bool `yaml:"login_shell_path" json:"login_shell_path" mapstructure:"login_shell_path"`
 }
 
 // CatalogConfig contains catalog-related settings.
@@ -49,6 +78,12 @@ type CatalogConfig struct {
 	// SourceURL is the URL to fetch the catalog from
 	SourceURL string `yaml:"source_url" json:"source_url" mapstructure:"source_url"`
 
+	// DeltaURL is an optional endpoint serving catalog deltas; when set,
+	// refreshes fetch only the agent definitions changed since the
+	// cached catalog version, falling back to SourceURL when the server
+	// cannot produce a delta
+	DeltaURL string `yaml:"delta_url" json:"delta_url" mapstructure:"delta_url"`
+
 	// RefreshInterval is how often to refresh in background
 	RefreshInterval time.Duration `yaml:"refresh_interval" json:"refresh_interval" mapstructure:"refresh_interval"`
 
@@ -57,6 +92,13 @@ type CatalogConfig struct {
 
 	// GitHubToken is an optional token for higher API rate limits
 	GitHubToken string `yaml:"github_token" json:"github_token" mapstructure:"github_token"`
+
+	// Exclude lists catalog agent IDs hidden from the whole product
+	Exclude []string `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
+
+	// IncludeOnly, when non-empty, restricts the catalog to these
+	// agent IDs; Exclude still applies on top
+	IncludeOnly []string `yaml:"include_only" json:"include_only" mapstructure:"include_only"`
 }
 
 // UpdateConfig contains update-related settings.
@@ -75,6 +117,19 @@ type UpdateConfig struct {
 
 	// ExcludeAgents lists agents to exclude from auto-update
 	ExcludeAgents []string `yaml:"exclude_agents" json:"exclude_agents" mapstructure:"exclude_agents"`
+
+	// Digest aggregates update notifications into a periodic summary:
+	// "off" (notify per check), "daily" or "weekly"
+	Digest string `yaml:"digest" json:"digest" mapstructure:"digest"`
+
+	// ConfirmUpdateAll requires an explicit confirmation of the update
+	// plan before Update All proceeds
+	ConfirmUpdateAll bool `yaml:"confirm_update_all" json:"confirm_update_all" mapstructure:"confirm_update_all"`
+
+	// QueueDuringFocus queues update notifications suppressed while the
+	// OS do-not-disturb/Focus mode is active and delivers a summary when
+	// Focus ends; when false, suppressed notifications are dropped
+	QueueDuringFocus bool `yaml:"queue_during_focus" json:"queue_during_focus" mapstructure:"queue_during_focus"`
 }
 
 // UIConfig contains UI-related settings.
@@ -93,6 +148,52 @@ type UIConfig struct {
 
 	// CompactMode reduces whitespace in output
 	CompactMode bool `yaml:"compact_mode" json:"compact_mode" mapstructure:"compact_mode"`
+
+	// Language overrides the detected UI language (e.g. "en", "es").
+	// Empty means detect from the system locale.
+	Language string `yaml:"language" json:"language" mapstructure:"language"`
+
+	// StatusFile enables writing a small status JSON (agent count,
+	// updates available, last check time) on every refresh, so
+	// statusbars and shell prompts can read it without the IPC socket.
+	// Written to status.json in the data directory unless
+	// StatusFilePath overrides the location.
+	StatusFile bool `yaml:"status_file" json:"status_file" mapstructure:"status_file"`
+
+	// StatusFilePath overrides where the status file is written; a
+	// leading "~" expands to the home directory
+	StatusFilePath string `yaml:"status_file_path" json:"status_file_path" mapstructure:"status_file_path"`
+
+	// Hotkey configures the global keyboard shortcut
+	Hotkey HotkeyConfig `yaml:"hotkey" json:"hotkey" mapstructure:"hotkey"`
+
+	// Tray customizes which items appear in the tray menu
+	Tray TrayConfig `yaml:"tray" json:"tray" mapstructure:"tray"`
+}
+
+// TrayConfig customizes which items appear in the tray menu.
+type TrayConfig struct {
+	// ShowTUI toggles the "Open TUI" entry
+	ShowTUI bool `yaml:"show_tui" json:"show_tui" mapstructure:"show_tui"`
+
+	// ShowQuickActions toggles the Update All and Check Now entries
+	ShowQuickActions bool `yaml:"show_quick_actions" json:"show_quick_actions" mapstructure:"show_quick_actions"`
+
+	// TopUpdates lists up to this many agents with pending updates
+	// directly at the root of the menu. Zero disables the section.
+	TopUpdates int `yaml:"top_updates" json:"top_updates" mapstructure:"top_updates"`
+}
+
+// HotkeyConfig configures the global keyboard shortcut.
+type HotkeyConfig struct {
+	// Enabled turns the global hotkey on
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+
+	// Shortcut is the key combination, e.g. "cmd+shift+a"
+	Shortcut string `yaml:"shortcut" json:"shortcut" mapstructure:"shortcut"`
+
+	// Action is what the hotkey triggers: "manage_agents" or "check_updates"
+	Action string `yaml:"action" json:"action" mapstructure:"action"`
 }
 
 // APIConfig contains API server settings.
@@ -109,6 +210,34 @@ type APIConfig struct {
 	// RESTPort is the port for the REST server
 	RESTPort int `yaml:"rest_port" json:"rest_port" mapstructure:"rest_port"`
 
+	// RESTBindAddress is the address the REST server listens on.
+	// Loopback by default; any other address requires AllowLAN.
+	RESTBindAddress string `yaml:"rest_bind_address" json:"rest_bind_address" mapstructure:"rest_bind_address"`
+
+	// AllowLAN opts in to exposing the REST API beyond loopback, which
+	// the fleet and mDNS discovery features need to reach this machine
+	AllowLAN bool `yaml:"allow_lan" json:"allow_lan" mapstructure:"allow_lan"`
+
+	// EnableTLS serves the REST API over HTTPS. Without cert and key
+	// paths a self-signed certificate is generated in the data dir
+	EnableTLS bool `yaml:"enable_tls" json:"enable_tls" mapstructure:"enable_tls"`
+
+	// TLSCertFile and TLSKeyFile point to a PEM certificate and key
+	TLSCertFile string `yaml:"tls_cert_file" json:"tls_cert_file" mapstructure:"tls_cert_file"`
+	TLSKeyFile  string `yaml:"tls_key_file" json:"tls_key_file" mapstructure:"tls_key_file"`
+
+	// EnableGraphQL exposes a GraphQL endpoint on the REST server so
+	// clients can resolve nested queries in a single round trip
+	EnableGraphQL bool `yaml:"enable_graphql" json:"enable_graphql" mapstructure:"enable_graphql"`
+
+	// EnableMDNS advertises the REST API on the local network over mDNS
+	// so fleet dashboards can discover this machine
+	EnableMDNS bool `yaml:"enable_mdns" json:"enable_mdns" mapstructure:"enable_mdns"`
+
+	// MDNSName is the advertised mDNS instance name; empty uses the
+	// hostname
+	MDNSName string `yaml:"mdns_name" json:"mdns_name" mapstructure:"mdns_name"`
+
 	// RequireAuth requires authentication for API calls
 	RequireAuth bool `yaml:"require_auth" json:"require_auth" mapstructure:"require_auth"`
 
@@ -129,6 +258,94 @@ type HelperConfig struct {
 
 	// NotifyOnStartup shows a notification when the helper starts
 	NotifyOnStartup bool `yaml:"notify_on_startup" json:"notify_on_startup" mapstructure:"notify_on_startup"`
+
+	// IPCAllowGroup names a group whose members may connect to the
+	// helper's IPC socket in addition to the owning user. Empty
+	// restricts the socket to the owner.
+	IPCAllowGroup string `yaml:"ipc_allow_group" json:"ipc_allow_group" mapstructure:"ipc_allow_group"`
+}
+
+// TerminalConfig selects the terminal emulator used by actions that
+// need a terminal window (opening the TUI, agent sign-in flows).
+type TerminalConfig struct {
+	// Preferred names the terminal emulator to use (e.g. "iterm2",
+	// "kitty", "wezterm", "wt"). Empty auto-detects one.
+	Preferred string `yaml:"preferred" json:"preferred" mapstructure:"preferred"`
+
+	// Args overrides the argument template for the preferred terminal.
+	// Each "{cmd}" expands to the shell command to run.
+	Args []string `yaml:"args" json:"args" mapstructure:"args"`
+}
+
+// FleetConfig contains settings for remote agent management over SSH.
+type FleetConfig struct {
+	// Hosts lists the remote machines to manage
+	Hosts []FleetHost `yaml:"hosts" json:"hosts" mapstructure:"hosts"`
+
+	// ConnectTimeout is the SSH connection timeout
+	ConnectTimeout time.Duration `yaml:"connect_timeout" json:"connect_timeout" mapstructure:"connect_timeout"`
+
+	// RemoteBinary is the agentmgr binary path on remote hosts
+	RemoteBinary string `yaml:"remote_binary" json:"remote_binary" mapstructure:"remote_binary"`
+}
+
+// FleetHost describes a single remote machine.
+type FleetHost struct {
+	// Name is a friendly label for the host
+	Name string `yaml:"name" json:"name" mapstructure:"name"`
+
+	// Address is the hostname or IP to connect to
+	Address string `yaml:"address" json:"address" mapstructure:"address"`
+
+	// User is the SSH user (defaults to the current user)
+	User string `yaml:"user" json:"user" mapstructure:"user"`
+
+	// Port is the SSH port (defaults to 22)
+	Port int `yaml:"port" json:"port" mapstructure:"port"`
+
+	// IdentityFile is an optional SSH private key path
+	IdentityFile string `yaml:"identity_file" json:"identity_file" mapstructure:"identity_file"`
+}
+
+// PolicyConfig contains administrative policy settings for shared or
+// IT-managed machines.
+type PolicyConfig struct {
+	// Lockdown disables install, uninstall and update actions across the
+	// CLI, APIs and helper while keeping detection and status available
+	Lockdown bool `yaml:"lockdown" json:"lockdown" mapstructure:"lockdown"`
+
+	// LockdownMessage overrides the error shown when an action is blocked
+	LockdownMessage string `yaml:"lockdown_message" json:"lockdown_message" mapstructure:"lockdown_message"`
+}
+
+// StatsConfig controls the opt-in usage statistics view. All aggregation
+// happens locally from the usage tracker and update history; nothing is
+// uploaded anywhere.
+type StatsConfig struct {
+	// Enabled turns the statistics aggregation on
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+}
+
+// EnrollmentConfig configures registration with a central management
+// server. Enrolled helpers periodically report their agent inventory and
+// poll for signed commands (install an agent, pin a version) so a fleet
+// can be driven from one dashboard.
+type EnrollmentConfig struct {
+	// Enabled turns enrollment mode on
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+
+	// ServerURL is the base URL of the management server
+	ServerURL string `yaml:"server_url" json:"server_url" mapstructure:"server_url"`
+
+	// Token authenticates this machine with the server
+	Token string `yaml:"token" json:"token" mapstructure:"token"`
+
+	// Interval is how often inventory is reported and commands polled
+	Interval time.Duration `yaml:"interval" json:"interval" mapstructure:"interval"`
+
+	// PublicKey is the server's base64-encoded Ed25519 public key.
+	// Commands whose signature does not verify against it are rejected.
+	PublicKey string `yaml:"public_key" json:"public_key" mapstructure:"public_key"`
 }
 
 // LoggingConfig contains logging settings.
@@ -149,6 +366,34 @@ type LoggingConfig struct {
 	MaxAge int `yaml:"max_age" json:"max_age" mapstructure:"max_age"`
 }
 
+// HooksConfig lists user-defined scripts run around install and update
+// operations. Scripts run through the system shell with event context
+// (agent, event, versions) passed via AGENTMGR_* environment variables.
+// A failing pre hook aborts the operation; post hook failures are only
+// recorded in the hook audit log.
+type HooksConfig struct {
+	// PreInstall scripts run before an agent is installed
+	PreInstall []string `yaml:"pre_install" json:"pre_install" mapstructure:"pre_install"`
+
+	// PostInstall scripts run after an agent is installed
+	PostInstall []string `yaml:"post_install" json:"post_install" mapstructure:"post_install"`
+
+	// PreUpdate scripts run before an agent is updated
+	PreUpdate []string `yaml:"pre_update" json:"pre_update" mapstructure:"pre_update"`
+
+	// PostUpdate scripts run after an agent is updated
+	PostUpdate []string `yaml:"post_update" json:"post_update" mapstructure:"post_update"`
+
+	// Timeout bounds each hook script run
+	Timeout time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+}
+
+// Empty reports whether no hook scripts are configured.
+func (h HooksConfig) Empty() bool {
+	return len(h.PreInstall) == 0 && len(h.PostInstall) == 0 &&
+		len(h.PreUpdate) == 0 && len(h.PostUpdate) == 0
+}
+
 // AgentConfig contains per-agent configuration overrides.
 type AgentConfig struct {
 	// PreferredMethod is the preferred installation method
@@ -165,6 +410,9 @@ type AgentConfig struct {
 
 	// Disabled prevents detection and management
 	Disabled bool `yaml:"disabled" json:"disabled" mapstructure:"disabled"`
+
+	// Hooks are agent-specific hook scripts, run after the global hooks
+	Hooks HooksConfig `yaml:"hooks" json:"hooks" mapstructure:"hooks"`
 }
 
 // Default returns the default configuration.
@@ -182,11 +430,14 @@ func Default() *Config {
 			CacheEnabled:             true,
 		},
 		Updates: UpdateConfig{
-			AutoCheck:     true,
-			CheckInterval: 6 * time.Hour,
-			Notify:        true,
-			AutoUpdate:    false,
-			ExcludeAgents: []string{},
+			AutoCheck:        true,
+			CheckInterval:    6 * time.Hour,
+			Notify:           true,
+			AutoUpdate:       false,
+			ExcludeAgents:    []string{},
+			Digest:           "off",
+			ConfirmUpdateAll: true,
+			QueueDuringFocus: true,
 		},
 		UI: UIConfig{
 			Theme:       "default",
@@ -194,20 +445,65 @@ func Default() *Config {
 			PageSize:    20,
 			UseColors:   true,
 			CompactMode: false,
+			Language:    "",
+			StatusFile:  false,
+			Hotkey: HotkeyConfig{
+				Enabled:  false,
+				Shortcut: "ctrl+shift+a",
+				Action:   "manage_agents",
+			},
+			Tray: TrayConfig{
+				ShowTUI:          true,
+				ShowQuickActions: true,
+				TopUpdates:       0,
+			},
 		},
 		API: APIConfig{
-			EnableGRPC:  false,
-			GRPCPort:    50051,
-			EnableREST:  false,
-			RESTPort:    8080,
-			RequireAuth: false,
-			AuthToken:   "",
+			EnableGRPC:      false,
+			GRPCPort:        50051,
+			EnableREST:      false,
+			RESTPort:        8080,
+			RESTBindAddress: "127.0.0.1",
+			AllowLAN:        false,
+			EnableTLS:       false,
+			EnableGraphQL:   false,
+			EnableMDNS:      false,
+			MDNSName:        "",
+			RequireAuth:     false,
+			AuthToken:       "",
 		},
 		Helper: HelperConfig{
 			CLIPath:         "", // Empty means auto-detect
 			ShowAgentCount:  false,
 			RefreshOnClick:  false,
 			NotifyOnStartup: false,
+			IPCAllowGroup:   "", // Empty restricts the socket to the owner
+		},
+		Terminal: TerminalConfig{
+			Preferred: "", // Empty means auto-detect
+		},
+		Installers: InstallersConfig{
+			MaxAttempts:  3,
+			RetryBackoff: 2 * time.Second,
+		},
+		Hooks: HooksConfig{
+			Timeout: time.Minute,
+		},
+		Fleet: FleetConfig{
+			Hosts:          []FleetHost{},
+			ConnectTimeout: 10 * time.Second,
+			RemoteBinary:   "agentmgr",
+		},
+		Policy: PolicyConfig{
+			Lockdown:        false,
+			LockdownMessage: "",
+		},
+		Stats: StatsConfig{
+			Enabled: false,
+		},
+		Enrollment: EnrollmentConfig{
+			Enabled:  false,
+			Interval: 15 * time.Minute,
 		},
 		Logging: LoggingConfig{
 			Level:   "info",
@@ -220,6 +516,105 @@ func Default() *Config {
 	}
 }
 
+// ValidationError describes a single invalid configuration field.
+type ValidationError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface.
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationErrors is a collection of validation errors.
+type ValidationErrors []ValidationError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// ValidateStrict checks the configuration without modifying it and
+// returns every violation. Unlike Validate, out-of-range values are
+// reported rather than clamped, which makes it suitable for rejecting
+// a hot-reloaded config file.
+func (c *Config) ValidateStrict() ValidationErrors {
+	var errs ValidationErrors
+	if c.Catalog.RefreshInterval < time.Minute {
+		errs = append(errs, ValidationError{"catalog.refresh_interval", "must be at least 1m"})
+	}
+	if c.Detection.CacheDuration < time.Minute {
+		errs = append(errs, ValidationError{"detection.cache_duration", "must be at least 1m"})
+	}
+	if c.Detection.UpdateCheckCacheDuration < time.Minute {
+		errs = append(errs, ValidationError{"detection.update_check_cache_duration", "must be at least 1m"})
+	}
+	if c.Updates.CheckInterval < time.Minute {
+		errs = append(errs, ValidationError{"updates.check_interval", "must be at least 1m"})
+	}
+	switch c.Updates.Digest {
+	case "", "off", "daily", "weekly":
+	default:
+		errs = append(errs, ValidationError{"updates.digest", "must be one of off, daily, weekly"})
+	}
+	if c.UI.PageSize < 1 {
+		errs = append(errs, ValidationError{"ui.page_size", "must be at least 1"})
+	}
+	switch c.Installers.NodeManager {
+	case "", NodeManagerNVM, NodeManagerFNM:
+	default:
+		errs = append(errs, ValidationError{"installers.node_manager", "must be one of nvm, fnm"})
+	}
+	switch c.Installers.PipIsolation {
+	case "", PipIsolationPipx, PipIsolationUV:
+	default:
+		errs = append(errs, ValidationError{"installers.pip_isolation", "must be one of pipx, uv"})
+	}
+	for scope := range c.Installers.NPMScopeRegistries {
+		if !strings.HasPrefix(scope, "@") {
+			errs = append(errs, ValidationError{"installers.npm_scope_registries", fmt.Sprintf("scope %q must start with @", scope)})
+		}
+	}
+	if c.Installers.MaxAttempts < 0 {
+		errs = append(errs, ValidationError{"installers.max_attempts", "must not be negative"})
+	}
+	if c.Installers.RetryBackoff < 0 {
+		errs = append(errs, ValidationError{"installers.retry_backoff", "must not be negative"})
+	}
+	if c.Installers.CommandTimeout < 0 {
+		errs = append(errs, ValidationError{"installers.command_timeout", "must not be negative"})
+	}
+	if c.Installers.NPMTimeout < 0 {
+		errs = append(errs, ValidationError{"installers.npm_timeout", "must not be negative"})
+	}
+	if c.Installers.PipTimeout < 0 {
+		errs = append(errs, ValidationError{"installers.pip_timeout", "must not be negative"})
+	}
+	if c.Installers.BrewTimeout < 0 {
+		errs = append(errs, ValidationError{"installers.brew_timeout", "must not be negative"})
+	}
+	if c.API.GRPCPort < 1 || c.API.GRPCPort > 65535 {
+		errs = append(errs, ValidationError{"api.grpc_port", "must be between 1 and 65535"})
+	}
+	if c.API.RESTPort < 1 || c.API.RESTPort > 65535 {
+		errs = append(errs, ValidationError{"api.rest_port", "must be between 1 and 65535"})
+	}
+	if c.Enrollment.Enabled {
+		if c.Enrollment.ServerURL == "" {
+			errs = append(errs, ValidationError{"enrollment.server_url", "required when enrollment is enabled"})
+		}
+		if c.Enrollment.PublicKey == "" {
+			errs = append(errs, ValidationError{"enrollment.public_key", "required when enrollment is enabled"})
+		}
+	}
+	return errs
+}
+
 // Validate validates the configuration.
 func (c *Config) Validate() error {
 	if c.Catalog.RefreshInterval < time.Minute {
@@ -234,15 +629,33 @@ func (c *Config) Validate() error {
 	if c.Updates.CheckInterval < time.Minute {
 		c.Updates.CheckInterval = time.Minute
 	}
+	switch c.Updates.Digest {
+	case "", "off", "daily", "weekly":
+	default:
+		c.Updates.Digest = "off"
+	}
 	if c.UI.PageSize < 1 {
 		c.UI.PageSize = 20
 	}
+	switch c.Installers.NodeManager {
+	case "", NodeManagerNVM, NodeManagerFNM:
+	default:
+		c.Installers.NodeManager = ""
+	}
+	switch c.Installers.PipIsolation {
+	case "", PipIsolationPipx, PipIsolationUV:
+	default:
+		c.Installers.PipIsolation = ""
+	}
 	if c.API.GRPCPort < 1 || c.API.GRPCPort > 65535 {
 		c.API.GRPCPort = 50051
 	}
 	if c.API.RESTPort < 1 || c.API.RESTPort > 65535 {
 		c.API.RESTPort = 8080
 	}
+	if c.Enrollment.Interval < time.Minute {
+		c.Enrollment.Interval = 15 * time.Minute
+	}
 	return nil
 }
 
@@ -277,3 +690,17 @@ func (c *Config) GetPinnedVersion(agentID string) string {
 	}
 	return ""
 }
+
+// DefaultLockdownMessage is shown when lockdown blocks an action and no
+// custom message is configured.
+const DefaultLockdownMessage = "agent management on this machine is managed by your administrator"
+
+// LockdownReason returns the message to show when an install, uninstall
+// or update action is blocked by policy. It is only meaningful when
+// Policy.Lockdown is set.
+func (c *Config) LockdownReason() string {
+	if c.Policy.LockdownMessage != "" {
+		return c.Policy.LockdownMessage
+	}
+	return DefaultLockdownMessage
+}
@@ -203,6 +203,49 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateStrict(t *testing.T) {
+	cfg := Default()
+	if errs := cfg.ValidateStrict(); len(errs) != 0 {
+		t.Errorf("default config should be valid, got %v", errs)
+	}
+
+	cfg.Catalog.RefreshInterval = time.Second
+	cfg.UI.PageSize = 0
+	cfg.API.RESTPort = 99999
+
+	errs := cfg.ValidateStrict()
+	if len(errs) != 3 {
+		t.Fatalf("expected 3 validation errors, got %d: %v", len(errs), errs)
+	}
+
+	fields := make(map[string]bool)
+	for _, e := range errs {
+		fields[e.Field] = true
+	}
+	for _, field := range []string{"catalog.refresh_interval", "ui.page_size", "api.rest_port"} {
+		if !fields[field] {
+			t.Errorf("expected validation error for %s, got %v", field, errs)
+		}
+	}
+
+	// ValidateStrict must not mutate the config
+	if cfg.UI.PageSize != 0 {
+		t.Errorf("ValidateStrict should not modify the config, PageSize = %d", cfg.UI.PageSize)
+	}
+}
+
+func TestValidationErrorsError(t *testing.T) {
+	errs := ValidationErrors{
+		{Field: "ui.page_size", Message: "must be at least 1"},
+		{Field: "api.rest_port", Message: "must be between 1 and 65535"},
+	}
+	got := errs.Error()
+	want := "ui.page_size: must be at least 1; api.rest_port: must be between 1 and 65535"
+	if got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
 func TestGetAgentConfig(t *testing.T) {
 	cfg := Default()
 	cfg.Agents = map[string]AgentConfig{
@@ -504,3 +547,18 @@ func TestLoggingConfig(t *testing.T) {
 		t.Errorf("MaxAge = %d, want 30", cfg.MaxAge)
 	}
 }
+
+func TestLockdownReason(t *testing.T) {
+	cfg := Default()
+	if cfg.Policy.Lockdown {
+		t.Error("Lockdown should be off by default")
+	}
+	if got := cfg.LockdownReason(); got != DefaultLockdownMessage {
+		t.Errorf("LockdownReason() = %q, want default message", got)
+	}
+
+	cfg.Policy.LockdownMessage = "contact it-support@example.com"
+	if got := cfg.LockdownReason(); got != "contact it-support@example.com" {
+		t.Errorf("LockdownReason() = %q, want the configured message", got)
+	}
+}
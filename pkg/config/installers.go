@@ -0,0 +1,284 @@
+package config
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Node version managers selectable via installers.node_manager.
+const (
+	NodeManagerNVM = "nvm"
+	NodeManagerFNM = "fnm"
+)
+
+// Isolation tools selectable via installers.pip_isolation.
+const (
+	PipIsolationPipx = "pipx"
+	PipIsolationUV   = "uv"
+)
+
+// InstallersConfig pins the package manager tooling used by detection
+// and installation instead of relying on whatever is first on PATH.
+type InstallersConfig struct {
+	// NPMPrefix overrides the npm global prefix (sets NPM_CONFIG_PREFIX)
+	NPMPrefix string `yaml:"npm_prefix" json:"npm_prefix" mapstructure:"npm_prefix"`
+
+	// NodeManager selects a Node version manager whose npm binary should
+	// be used: "nvm" or "fnm". Empty uses npm from PATH.
+	NodeManager string `yaml:"node_manager" json:"node_manager" mapstructure:"node_manager"`
+
+	// NodeVersion is the installed Node version to use with the selected
+	// manager, e.g. "v20.11.0". Empty falls back to npm from PATH.
+	NodeVersion string `yaml:"node_version" json:"node_version" mapstructure:"node_version"`
+
+	// NPMRegistry overrides the default npm registry for installs,
+	// updates, and version checks, e.g. an internal mirror.
+	NPMRegistry string `yaml:"npm_registry" json:"npm_registry" mapstructure:"npm_registry"`
+
+	// NPMScopeRegistries maps npm scopes to the registry serving them,
+	// e.g. "@company" -> "https://npm.company.internal". Scoped packages
+	// resolve through their scope registry; everything else uses
+	// NPMRegistry or the npm default.
+	NPMScopeRegistries map[string]string `yaml:"npm_scope_registries" json:"npm_scope_registries" mapstructure:"npm_scope_registries"`
+
+	// NPMAuthToken authenticates against NPMRegistry and the scope
+	// registries. Redacted from diagnostics bundles.
+	NPMAuthToken string `yaml:"npm_auth_token" json:"npm_auth_token" mapstructure:"npm_auth_token"`
+
+	// PipxHome overrides the pipx home directory (sets PIPX_HOME)
+	PipxHome string `yaml:"pipx_home" json:"pipx_home" mapstructure:"pipx_home"`
+
+	// UVToolDir overrides the uv tool directory (sets UV_TOOL_DIR)
+	UVToolDir string `yaml:"uv_tool_dir" json:"uv_tool_dir" mapstructure:"uv_tool_dir"`
+
+	// PipIndexURL overrides the default PyPI index for pip, pipx, and uv
+	// installs, e.g. an Artifactory or Nexus proxy.
+	PipIndexURL string `yaml:"pip_index_url" json:"pip_index_url" mapstructure:"pip_index_url"`
+
+	// PipExtraIndexURLs are additional indexes consulted after the
+	// primary one.
+	PipExtraIndexURLs []string `yaml:"pip_extra_index_urls" json:"pip_extra_index_urls" mapstructure:"pip_extra_index_urls"`
+
+	// PipIndexUsername and PipIndexPassword authenticate against the
+	// configured indexes; they are embedded into the index URLs the way
+	// pip and uv expect. The password is redacted from diagnostics
+	// bundles.
+	PipIndexUsername string `yaml:"pip_index_username" json:"pip_index_username" mapstructure:"pip_index_username"`
+	PipIndexPassword string `yaml:"pip_index_password" json:"pip_index_password" mapstructure:"pip_index_password"`
+
+	// PipIsolation redirects plain pip install methods to an isolated
+	// environment manager: "pipx" or "uv". Empty keeps plain pip, which
+	// installs into the system or user site-packages.
+	PipIsolation string `yaml:"pip_isolation" json:"pip_isolation" mapstructure:"pip_isolation"`
+
+	// BrewPrefix is the Homebrew prefix whose bin/brew should be used,
+	// e.g. "/opt/homebrew"
+	BrewPrefix string `yaml:"brew_prefix" json:"brew_prefix" mapstructure:"brew_prefix"`
+
+	// CommandTimeout bounds every package manager command the installer
+	// providers run, so a hung command cannot stall a job forever. Zero
+	// leaves commands bounded only by the caller's context.
+	CommandTimeout time.Duration `yaml:"command_timeout" json:"command_timeout" mapstructure:"command_timeout"`
+
+	// NPMTimeout, PipTimeout, and BrewTimeout override CommandTimeout
+	// for the corresponding provider.
+	NPMTimeout  time.Duration `yaml:"npm_timeout" json:"npm_timeout" mapstructure:"npm_timeout"`
+	PipTimeout  time.Duration `yaml:"pip_timeout" json:"pip_timeout" mapstructure:"pip_timeout"`
+	BrewTimeout time.Duration `yaml:"brew_timeout" json:"brew_timeout" mapstructure:"brew_timeout"`
+
+	// MaxAttempts is how many times a failed install or update is tried
+	// when the failure looks transient (network errors and the like).
+	// Zero or one disables retries.
+	MaxAttempts int `yaml:"max_attempts" json:"max_attempts" mapstructure:"max_attempts"`
+
+	// RetryBackoff is the delay before the first retry; each further
+	// retry doubles it
+	RetryBackoff time.Duration `yaml:"retry_backoff" json:"retry_backoff" mapstructure:"retry_backoff"`
+}
+
+// NPMCommand returns the npm executable to use. When a Node version
+// manager and version are configured it resolves the npm binary inside
+// that manager's installation; otherwise npm from PATH is used.
+func (c InstallersConfig) NPMCommand() string {
+	if c.NodeVersion == "" {
+		return "npm"
+	}
+
+	switch c.NodeManager {
+	case NodeManagerNVM:
+		dir := os.Getenv("NVM_DIR")
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "npm"
+			}
+			dir = filepath.Join(home, ".nvm")
+		}
+		return filepath.Join(dir, "versions", "node", c.NodeVersion, "bin", "npm")
+
+	case NodeManagerFNM:
+		dir := os.Getenv("FNM_DIR")
+		if dir == "" {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "npm"
+			}
+			dir = filepath.Join(home, ".local", "share", "fnm")
+		}
+		return filepath.Join(dir, "node-versions", c.NodeVersion, "installation", "bin", "npm")
+
+	default:
+		return "npm"
+	}
+}
+
+// NPMEnv returns environment overrides for npm invocations. Registry
+// overrides use npm_config_* variables, which take precedence over
+// .npmrc without touching the user's file.
+func (c InstallersConfig) NPMEnv() []string {
+	var env []string
+	if c.NPMPrefix != "" {
+		env = append(env, "NPM_CONFIG_PREFIX="+c.NPMPrefix)
+	}
+
+	registries := make([]string, 0, len(c.NPMScopeRegistries)+1)
+	if c.NPMRegistry != "" {
+		env = append(env, "npm_config_registry="+c.NPMRegistry)
+		registries = append(registries, c.NPMRegistry)
+	}
+
+	scopes := make([]string, 0, len(c.NPMScopeRegistries))
+	for scope := range c.NPMScopeRegistries {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	for _, scope := range scopes {
+		registry := c.NPMScopeRegistries[scope]
+		env = append(env, "npm_config_"+scope+":registry="+registry)
+		registries = append(registries, registry)
+	}
+
+	if c.NPMAuthToken != "" {
+		seen := make(map[string]bool, len(registries))
+		for _, registry := range registries {
+			key := npmRegistryAuthKey(registry)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			env = append(env, "npm_config_"+key+"="+c.NPMAuthToken)
+		}
+	}
+
+	return env
+}
+
+// npmRegistryAuthKey converts a registry URL to the .npmrc key npm reads
+// the auth token from: "//host/path/:_authToken".
+func npmRegistryAuthKey(registry string) string {
+	trimmed := registry
+	if idx := strings.Index(trimmed, "//"); idx >= 0 {
+		trimmed = trimmed[idx+2:]
+	}
+	if trimmed == "" {
+		return ""
+	}
+	if !strings.HasSuffix(trimmed, "/") {
+		trimmed += "/"
+	}
+	return "//" + trimmed + ":_authToken"
+}
+
+// PipEnv returns environment overrides for plain pip invocations.
+func (c InstallersConfig) PipEnv() []string {
+	return c.pipIndexEnv("PIP_INDEX_URL", "PIP_EXTRA_INDEX_URL")
+}
+
+// PipxEnv returns environment overrides for pipx invocations. Index
+// overrides use PIP_* variables, which pipx forwards to its inner pip.
+func (c InstallersConfig) PipxEnv() []string {
+	var env []string
+	if c.PipxHome != "" {
+		env = append(env, "PIPX_HOME="+c.PipxHome)
+	}
+	return append(env, c.pipIndexEnv("PIP_INDEX_URL", "PIP_EXTRA_INDEX_URL")...)
+}
+
+// UVEnv returns environment overrides for uv invocations.
+func (c InstallersConfig) UVEnv() []string {
+	var env []string
+	if c.UVToolDir != "" {
+		env = append(env, "UV_TOOL_DIR="+c.UVToolDir)
+	}
+	return append(env, c.pipIndexEnv("UV_INDEX_URL", "UV_EXTRA_INDEX_URL")...)
+}
+
+// pipIndexEnv builds the index override variables shared by the Python
+// package managers. Multiple extra indexes are space separated, the
+// format both pip and uv accept.
+func (c InstallersConfig) pipIndexEnv(indexVar, extraVar string) []string {
+	var env []string
+	if c.PipIndexURL != "" {
+		env = append(env, indexVar+"="+c.pipIndexWithAuth(c.PipIndexURL))
+	}
+	if len(c.PipExtraIndexURLs) > 0 {
+		extras := make([]string, 0, len(c.PipExtraIndexURLs))
+		for _, extra := range c.PipExtraIndexURLs {
+			extras = append(extras, c.pipIndexWithAuth(extra))
+		}
+		env = append(env, extraVar+"="+strings.Join(extras, " "))
+	}
+	return env
+}
+
+// pipIndexWithAuth embeds the configured credentials into an index URL,
+// the form pip and uv accept for authenticated indexes. URLs that
+// already carry credentials are left alone.
+func (c InstallersConfig) pipIndexWithAuth(rawURL string) string {
+	if c.PipIndexUsername == "" {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" || u.User != nil {
+		return rawURL
+	}
+	if c.PipIndexPassword != "" {
+		u.User = url.UserPassword(c.PipIndexUsername, c.PipIndexPassword)
+	} else {
+		u.User = url.User(c.PipIndexUsername)
+	}
+	return u.String()
+}
+
+// TimeoutFor returns the command timeout for a provider, preferring the
+// provider-specific override over CommandTimeout. Zero means no bound
+// beyond the caller's context.
+func (c InstallersConfig) TimeoutFor(provider string) time.Duration {
+	switch provider {
+	case "npm":
+		if c.NPMTimeout > 0 {
+			return c.NPMTimeout
+		}
+	case "pip", "pip3", "pipx", "uv":
+		if c.PipTimeout > 0 {
+			return c.PipTimeout
+		}
+	case "brew":
+		if c.BrewTimeout > 0 {
+			return c.BrewTimeout
+		}
+	}
+	return c.CommandTimeout
+}
+
+// BrewCommand returns the brew executable to use.
+func (c InstallersConfig) BrewCommand() string {
+	if c.BrewPrefix == "" {
+		return "brew"
+	}
+	return filepath.Join(c.BrewPrefix, "bin", "brew")
+}
@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/viper"
 
 	"github.com/kevinelliott/agentmgr/pkg/platform"
@@ -16,6 +19,13 @@ const (
 
 	// EnvPrefix is the prefix for environment variables
 	EnvPrefix = "AGENTMGR"
+
+	// ProfilesDirName is the directory (next to the config file) that
+	// holds per-profile overlay files, e.g. profiles/work.yaml.
+	ProfilesDirName = "profiles"
+
+	// activeProfileFileName stores the persisted profile selection.
+	activeProfileFileName = "active_profile"
 )
 
 // Loader handles configuration loading and saving.
@@ -23,6 +33,7 @@ type Loader struct {
 	v        *viper.Viper
 	platform platform.Platform
 	filePath string
+	profile  string
 }
 
 // NewLoader creates a new configuration loader.
@@ -67,6 +78,16 @@ func (l *Loader) Load(customPath string) (*Config, error) {
 		}
 	}
 
+	// Layer the active profile (if any) over the base config
+	if l.profile == "" {
+		l.profile = l.resolveProfile()
+	}
+	if l.profile != "" {
+		if err := l.mergeProfile(l.profile); err != nil {
+			return nil, err
+		}
+	}
+
 	// Unmarshal into struct
 	cfg := Default()
 	if err := l.v.Unmarshal(cfg); err != nil {
@@ -81,6 +102,153 @@ func (l *Loader) Load(customPath string) (*Config, error) {
 	return cfg, nil
 }
 
+// SetProfile selects the named profile for subsequent loads. It takes
+// precedence over the AGENTMGR_PROFILE environment variable and the
+// persisted selection.
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
+// Profile returns the active profile name; empty means base config only.
+func (l *Loader) Profile() string {
+	return l.profile
+}
+
+// ListProfiles returns the names of the available profiles, sorted.
+func (l *Loader) ListProfiles() ([]string, error) {
+	entries, err := os.ReadDir(l.profilesDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles directory: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SwitchProfile persists the given profile as the active selection and
+// returns the reloaded, layered config. An empty name switches back to
+// the base config.
+func (l *Loader) SwitchProfile(name string) (*Config, error) {
+	if name != "" {
+		if _, err := os.Stat(l.profilePath(name)); err != nil {
+			return nil, fmt.Errorf("profile %q not found at %s", name, l.profilePath(name))
+		}
+	}
+
+	statePath := filepath.Join(filepath.Dir(l.filePath), activeProfileFileName)
+	if name == "" {
+		if err := os.Remove(statePath); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to clear profile selection: %w", err)
+		}
+	} else {
+		if err := os.WriteFile(statePath, []byte(name+"\n"), 0644); err != nil {
+			return nil, fmt.Errorf("failed to save profile selection: %w", err)
+		}
+	}
+
+	l.profile = name
+	return l.Reload()
+}
+
+// resolveProfile determines the active profile from the environment or
+// the persisted selection.
+func (l *Loader) resolveProfile() string {
+	if name := os.Getenv(EnvPrefix + "_PROFILE"); name != "" {
+		return name
+	}
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(l.filePath), activeProfileFileName))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// profilesDir returns the directory holding profile overlay files.
+func (l *Loader) profilesDir() string {
+	return filepath.Join(filepath.Dir(l.filePath), ProfilesDirName)
+}
+
+// profilePath returns the overlay file path for a named profile.
+func (l *Loader) profilePath(name string) string {
+	return filepath.Join(l.profilesDir(), name+".yaml")
+}
+
+// mergeProfile layers the named profile file over the current settings.
+func (l *Loader) mergeProfile(name string) error {
+	path := l.profilePath(name)
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("profile %q not found at %s", name, path)
+	}
+
+	profileViper := viper.New()
+	profileViper.SetConfigFile(path)
+	if err := profileViper.ReadInConfig(); err != nil {
+		return fmt.Errorf("error reading profile %q: %w", name, err)
+	}
+
+	if err := l.v.MergeConfigMap(profileViper.AllSettings()); err != nil {
+		return fmt.Errorf("error merging profile %q: %w", name, err)
+	}
+	return nil
+}
+
+// Reload re-reads the config file and returns the validated result.
+// An invalid file is rejected with the validation errors so callers can
+// keep the previous config in effect.
+func (l *Loader) Reload() (*Config, error) {
+	if err := l.v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("error reading config file: %w", err)
+		}
+	}
+
+	if l.profile != "" {
+		if err := l.mergeProfile(l.profile); err != nil {
+			return nil, err
+		}
+	}
+
+	cfg := Default()
+	if err := l.v.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+
+	if errs := cfg.ValidateStrict(); len(errs) > 0 {
+		return nil, errs
+	}
+
+	return cfg, nil
+}
+
+// Watch starts watching the config file for changes. On each change the
+// file is re-read and validated; valid configs are passed to onChange,
+// invalid ones to onError (and the previous config stays in effect).
+func (l *Loader) Watch(onChange func(*Config), onError func(error)) {
+	l.v.OnConfigChange(func(fsnotify.Event) {
+		cfg, err := l.Reload()
+		if err != nil {
+			if onError != nil {
+				onError(err)
+			}
+			return
+		}
+		onChange(cfg)
+	})
+	l.v.WatchConfig()
+}
+
 // Save saves the configuration to file.
 func (l *Loader) Save(cfg *Config) error {
 	// Ensure directory exists
@@ -134,6 +302,37 @@ func (l *Loader) SetAndSave(key string, value interface{}) error {
 	return nil
 }
 
+// SetValidated sets a configuration value, validates the resulting
+// config, and saves it to file. On failure the previous value is
+// restored and nothing is written, so an invalid change over the
+// management API can't corrupt the config file.
+func (l *Loader) SetValidated(key string, value interface{}) (*Config, error) {
+	old := l.v.Get(key)
+	l.v.Set(key, value)
+
+	cfg := Default()
+	if err := l.v.Unmarshal(cfg); err != nil {
+		l.v.Set(key, old)
+		return nil, fmt.Errorf("error parsing config: %w", err)
+	}
+	if errs := cfg.ValidateStrict(); len(errs) > 0 {
+		l.v.Set(key, old)
+		return nil, errs
+	}
+
+	dir := filepath.Dir(l.filePath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		l.v.Set(key, old)
+		return nil, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := l.v.WriteConfigAs(l.filePath); err != nil {
+		l.v.Set(key, old)
+		return nil, fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return cfg, nil
+}
+
 // Get gets a configuration value by key path.
 func (l *Loader) Get(key string) interface{} {
 	return l.v.Get(key)
@@ -160,9 +359,12 @@ func (l *Loader) setDefaults() {
 
 	// Catalog defaults
 	l.v.SetDefault("catalog.source_url", defaults.Catalog.SourceURL)
+	l.v.SetDefault("catalog.delta_url", defaults.Catalog.DeltaURL)
 	l.v.SetDefault("catalog.refresh_interval", defaults.Catalog.RefreshInterval)
 	l.v.SetDefault("catalog.refresh_on_start", defaults.Catalog.RefreshOnStart)
 	l.v.SetDefault("catalog.github_token", defaults.Catalog.GitHubToken)
+	l.v.SetDefault("catalog.exclude", defaults.Catalog.Exclude)
+	l.v.SetDefault("catalog.include_only", defaults.Catalog.IncludeOnly)
 
 	// Update defaults
 	l.v.SetDefault("updates.auto_check", defaults.Updates.AutoCheck)
@@ -170,6 +372,9 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("updates.notify", defaults.Updates.Notify)
 	l.v.SetDefault("updates.auto_update", defaults.Updates.AutoUpdate)
 	l.v.SetDefault("updates.exclude_agents", defaults.Updates.ExcludeAgents)
+	l.v.SetDefault("updates.digest", defaults.Updates.Digest)
+	l.v.SetDefault("updates.confirm_update_all", defaults.Updates.ConfirmUpdateAll)
+	l.v.SetDefault("updates.queue_during_focus", defaults.Updates.QueueDuringFocus)
 
 	// UI defaults
 	l.v.SetDefault("ui.theme", defaults.UI.Theme)
@@ -177,20 +382,54 @@ func (l *Loader) setDefaults() {
 	l.v.SetDefault("ui.page_size", defaults.UI.PageSize)
 	l.v.SetDefault("ui.use_colors", defaults.UI.UseColors)
 	l.v.SetDefault("ui.compact_mode", defaults.UI.CompactMode)
+	l.v.SetDefault("ui.tray.show_tui", defaults.UI.Tray.ShowTUI)
+	l.v.SetDefault("ui.tray.show_quick_actions", defaults.UI.Tray.ShowQuickActions)
+	l.v.SetDefault("ui.tray.top_updates", defaults.UI.Tray.TopUpdates)
 
 	// API defaults
 	l.v.SetDefault("api.enable_grpc", defaults.API.EnableGRPC)
 	l.v.SetDefault("api.grpc_port", defaults.API.GRPCPort)
 	l.v.SetDefault("api.enable_rest", defaults.API.EnableREST)
 	l.v.SetDefault("api.rest_port", defaults.API.RESTPort)
+	l.v.SetDefault("api.rest_bind_address", defaults.API.RESTBindAddress)
+	l.v.SetDefault("api.allow_lan", defaults.API.AllowLAN)
+	l.v.SetDefault("api.enable_tls", defaults.API.EnableTLS)
+	l.v.SetDefault("api.tls_cert_file", defaults.API.TLSCertFile)
+	l.v.SetDefault("api.tls_key_file", defaults.API.TLSKeyFile)
+	l.v.SetDefault("api.enable_graphql", defaults.API.EnableGraphQL)
+	l.v.SetDefault("api.enable_mdns", defaults.API.EnableMDNS)
+	l.v.SetDefault("api.mdns_name", defaults.API.MDNSName)
 	l.v.SetDefault("api.require_auth", defaults.API.RequireAuth)
 	l.v.SetDefault("api.auth_token", defaults.API.AuthToken)
 
+	// Enrollment defaults
+	l.v.SetDefault("enrollment.enabled", defaults.Enrollment.Enabled)
+	l.v.SetDefault("enrollment.server_url", defaults.Enrollment.ServerURL)
+	l.v.SetDefault("enrollment.token", defaults.Enrollment.Token)
+	l.v.SetDefault("enrollment.interval", defaults.Enrollment.Interval)
+	l.v.SetDefault("enrollment.public_key", defaults.Enrollment.PublicKey)
+
+	// Terminal defaults
+	l.v.SetDefault("terminal.preferred", defaults.Terminal.Preferred)
+	l.v.SetDefault("terminal.args", defaults.Terminal.Args)
+
 	// Helper defaults
 	l.v.SetDefault("helper.cli_path", defaults.Helper.CLIPath)
 	l.v.SetDefault("helper.show_agent_count", defaults.Helper.ShowAgentCount)
 	l.v.SetDefault("helper.refresh_on_click", defaults.Helper.RefreshOnClick)
 	l.v.SetDefault("helper.notify_on_startup", defaults.Helper.NotifyOnStartup)
+	l.v.SetDefault("helper.ipc_allow_group", defaults.Helper.IPCAllowGroup)
+
+	// Installer tooling defaults
+	l.v.SetDefault("installers.npm_prefix", defaults.Installers.NPMPrefix)
+	l.v.SetDefault("installers.node_manager", defaults.Installers.NodeManager)
+	l.v.SetDefault("installers.node_version", defaults.Installers.NodeVersion)
+	l.v.SetDefault("installers.pipx_home", defaults.Installers.PipxHome)
+	l.v.SetDefault("installers.uv_tool_dir", defaults.Installers.UVToolDir)
+	l.v.SetDefault("installers.brew_prefix", defaults.Installers.BrewPrefix)
+
+	// Hook defaults
+	l.v.SetDefault("hooks.timeout", defaults.Hooks.Timeout)
 
 	// Logging defaults
 	l.v.SetDefault("logging.level", defaults.Logging.Level)
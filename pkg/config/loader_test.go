@@ -219,6 +219,50 @@ func TestLoaderSetAndGet(t *testing.T) {
 	}
 }
 
+func TestLoaderSetValidated(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "agentmgr-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("ui:\n  page_size: 20\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewLoader()
+	if _, err := loader.Load(configPath); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	cfg, err := loader.SetValidated("ui.page_size", 50)
+	if err != nil {
+		t.Fatalf("SetValidated() error = %v", err)
+	}
+	if cfg.UI.PageSize != 50 {
+		t.Errorf("PageSize = %d, want 50", cfg.UI.PageSize)
+	}
+
+	// The change must be persisted
+	fresh := NewLoader()
+	reloaded, err := fresh.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() after SetValidated error = %v", err)
+	}
+	if reloaded.UI.PageSize != 50 {
+		t.Errorf("persisted PageSize = %d, want 50", reloaded.UI.PageSize)
+	}
+
+	// An invalid value is rejected and the previous value restored
+	if _, err := loader.SetValidated("ui.page_size", 0); err == nil {
+		t.Error("SetValidated() should reject ui.page_size = 0")
+	}
+	if v := loader.GetInt("ui.page_size"); v != 50 {
+		t.Errorf("page_size after rejected set = %d, want 50", v)
+	}
+}
+
 func TestLoaderLoadInvalidYAML(t *testing.T) {
 	tmpDir, err := os.MkdirTemp("", "agentmgr-test-*")
 	if err != nil {
@@ -379,3 +423,178 @@ agents:
 		t.Errorf("aider.CustomPaths = %v, want [/custom/aider/path]", aiderCfg.CustomPaths)
 	}
 }
+
+func TestLoaderProfileMerge(t *testing.T) {
+	t.Setenv("AGENTMGR_PROFILE", "")
+
+	tmpDir, err := os.MkdirTemp("", "agentmgr-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	baseYAML := `catalog:
+  source_url: https://base.example.com/catalog.json
+api:
+  rest_port: 8080
+`
+	if err := os.WriteFile(configPath, []byte(baseYAML), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	profilesDir := filepath.Join(tmpDir, ProfilesDirName)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatalf("Failed to create profiles dir: %v", err)
+	}
+	workYAML := `catalog:
+  source_url: https://work.example.com/catalog.json
+`
+	if err := os.WriteFile(filepath.Join(profilesDir, "work.yaml"), []byte(workYAML), 0644); err != nil {
+		t.Fatalf("Failed to write profile file: %v", err)
+	}
+
+	loader := NewLoader()
+	loader.SetProfile("work")
+	cfg, err := loader.Load(configPath)
+	if err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	// Profile overrides the base value
+	if cfg.Catalog.SourceURL != "https://work.example.com/catalog.json" {
+		t.Errorf("SourceURL = %q, want profile override", cfg.Catalog.SourceURL)
+	}
+	// Base values not overridden by the profile survive
+	if cfg.API.RESTPort != 8080 {
+		t.Errorf("RESTPort = %d, want 8080 from base config", cfg.API.RESTPort)
+	}
+}
+
+func TestLoaderProfileNotFound(t *testing.T) {
+	t.Setenv("AGENTMGR_PROFILE", "")
+
+	tmpDir, err := os.MkdirTemp("", "agentmgr-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("# empty\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewLoader()
+	loader.SetProfile("missing")
+	if _, err := loader.Load(configPath); err == nil {
+		t.Error("Load() should fail for a missing profile")
+	}
+}
+
+func TestLoaderListProfiles(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "agentmgr-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("# empty\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	loader := NewLoader()
+	loader.filePath = configPath
+
+	// No profiles directory yet
+	profiles, err := loader.ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() returned error: %v", err)
+	}
+	if len(profiles) != 0 {
+		t.Errorf("expected no profiles, got %v", profiles)
+	}
+
+	profilesDir := filepath.Join(tmpDir, ProfilesDirName)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatalf("Failed to create profiles dir: %v", err)
+	}
+	for _, name := range []string{"work.yaml", "personal.yaml", "notes.txt"} {
+		if err := os.WriteFile(filepath.Join(profilesDir, name), []byte("# profile\n"), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	profiles, err = loader.ListProfiles()
+	if err != nil {
+		t.Fatalf("ListProfiles() returned error: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0] != "personal" || profiles[1] != "work" {
+		t.Errorf("ListProfiles() = %v, want [personal work]", profiles)
+	}
+}
+
+func TestLoaderSwitchProfile(t *testing.T) {
+	t.Setenv("AGENTMGR_PROFILE", "")
+
+	tmpDir, err := os.MkdirTemp("", "agentmgr-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	if err := os.WriteFile(configPath, []byte("# empty\n"), 0644); err != nil {
+		t.Fatalf("Failed to write config file: %v", err)
+	}
+
+	profilesDir := filepath.Join(tmpDir, ProfilesDirName)
+	if err := os.MkdirAll(profilesDir, 0755); err != nil {
+		t.Fatalf("Failed to create profiles dir: %v", err)
+	}
+	workYAML := `ui:
+  page_size: 50
+`
+	if err := os.WriteFile(filepath.Join(profilesDir, "work.yaml"), []byte(workYAML), 0644); err != nil {
+		t.Fatalf("Failed to write profile file: %v", err)
+	}
+
+	loader := NewLoader()
+	if _, err := loader.Load(configPath); err != nil {
+		t.Fatalf("Load() returned error: %v", err)
+	}
+
+	cfg, err := loader.SwitchProfile("work")
+	if err != nil {
+		t.Fatalf("SwitchProfile() returned error: %v", err)
+	}
+	if cfg.UI.PageSize != 50 {
+		t.Errorf("PageSize = %d, want 50 from profile", cfg.UI.PageSize)
+	}
+	if loader.Profile() != "work" {
+		t.Errorf("Profile() = %q, want %q", loader.Profile(), "work")
+	}
+
+	// The selection is persisted for subsequent loads
+	fresh := NewLoader()
+	if _, err := fresh.Load(configPath); err != nil {
+		t.Fatalf("fresh Load() returned error: %v", err)
+	}
+	if fresh.Profile() != "work" {
+		t.Errorf("fresh Profile() = %q, want persisted %q", fresh.Profile(), "work")
+	}
+
+	// Switching to a missing profile fails
+	if _, err := loader.SwitchProfile("missing"); err == nil {
+		t.Error("SwitchProfile() should fail for a missing profile")
+	}
+
+	// Switching back to the base config clears the selection
+	if _, err := loader.SwitchProfile(""); err != nil {
+		t.Fatalf("SwitchProfile(\"\") returned error: %v", err)
+	}
+	if loader.Profile() != "" {
+		t.Errorf("Profile() = %q, want empty", loader.Profile())
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// mutableKeys is the whitelist of keys the management API (IPC and REST)
+// may change. It deliberately excludes anything that alters the API
+// surface itself (ports, auth) and secrets, which must be edited in the
+// config file directly.
+var mutableKeys = map[string]bool{
+	"catalog.refresh_interval": true,
+	"catalog.refresh_on_start": true,
+
+	"updates.auto_check":     true,
+	"updates.auto_update":    true,
+	"updates.check_interval": true,
+	"updates.notify":         true,
+
+	"ui.compact_mode": true,
+	"ui.page_size":    true,
+	"ui.show_hidden":  true,
+	"ui.theme":        true,
+	"ui.use_colors":   true,
+
+	"logging.format":   true,
+	"logging.level":    true,
+	"logging.max_age":  true,
+	"logging.max_size": true,
+}
+
+// IsMutableKey reports whether the management API is allowed to change
+// the given config key.
+func IsMutableKey(key string) bool {
+	return mutableKeys[strings.ToLower(key)]
+}
+
+// MutableKeys returns the sorted whitelist of keys the management API
+// may change.
+func MutableKeys() []string {
+	keys := make([]string, 0, len(mutableKeys))
+	for key := range mutableKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
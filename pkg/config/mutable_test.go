@@ -0,0 +1,52 @@
+package config
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestIsMutableKey(t *testing.T) {
+	tests := []struct {
+		key  string
+		want bool
+	}{
+		{key: "ui.theme", want: true},
+		{key: "UI.Theme", want: true},
+		{key: "updates.auto_check", want: true},
+		{key: "logging.level", want: true},
+		{key: "catalog.refresh_interval", want: true},
+		// API surface and secrets stay file-only
+		{key: "api.rest_port", want: false},
+		{key: "api.require_auth", want: false},
+		{key: "api.auth_token", want: false},
+		{key: "catalog.github_token", want: false},
+		{key: "catalog.source_url", want: false},
+		{key: "nonsense", want: false},
+		{key: "", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsMutableKey(tt.key); got != tt.want {
+			t.Errorf("IsMutableKey(%q) = %v, want %v", tt.key, got, tt.want)
+		}
+	}
+}
+
+func TestMutableKeys(t *testing.T) {
+	keys := MutableKeys()
+	if len(keys) == 0 {
+		t.Fatal("MutableKeys() should not be empty")
+	}
+	if !sort.StringsAreSorted(keys) {
+		t.Error("MutableKeys() should be sorted")
+	}
+	for _, key := range keys {
+		if !IsMutableKey(key) {
+			t.Errorf("MutableKeys() returned %q, but IsMutableKey rejects it", key)
+		}
+		if strings.HasPrefix(key, "api.") {
+			t.Errorf("MutableKeys() must not expose API settings, got %q", key)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Package deeplink parses agentmgr:// URLs, which let catalog websites
+// and docs deep-link into helper actions such as one-click installs.
+// The helper validates every link and confirms with the user before
+// acting on it; parsing here is deliberately strict so a malformed or
+// hostile URL is rejected rather than reinterpreted.
+package deeplink
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Scheme is the URL scheme registered for helper deep links.
+const Scheme = "agentmgr"
+
+// Kind identifies the action a deep link requests.
+type Kind string
+
+const (
+	// KindInstall installs an agent: agentmgr://install?agent=X&method=Y
+	KindInstall Kind = "install"
+
+	// KindDetails shows an agent's details: agentmgr://details?agent=X
+	KindDetails Kind = "details"
+)
+
+// Action is a parsed and validated deep link.
+type Action struct {
+	Kind    Kind
+	AgentID string
+
+	// Method optionally names the install method; when empty the
+	// platform's default method is used. Only meaningful for installs.
+	Method string
+}
+
+// slugRE matches agent and method identifiers as the catalog defines
+// them; anything else in a link is rejected.
+var slugRE = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// Parse validates a deep link such as
+// agentmgr://install?agent=claude-code&method=npm.
+func Parse(raw string) (Action, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return Action{}, fmt.Errorf("invalid deep link: %w", err)
+	}
+	if u.Scheme != Scheme {
+		return Action{}, fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+
+	// Both agentmgr://install?... and agentmgr:install?... forms are
+	// accepted; browsers and launchers disagree on which they produce
+	name := u.Host
+	if name == "" {
+		name = strings.Trim(u.Opaque, "/")
+	}
+	if name == "" {
+		name = strings.Trim(u.Path, "/")
+	}
+
+	kind := Kind(name)
+	switch kind {
+	case KindInstall, KindDetails:
+	default:
+		return Action{}, fmt.Errorf("unsupported action %q", name)
+	}
+
+	query := u.Query()
+	agentID := query.Get("agent")
+	if agentID == "" {
+		return Action{}, fmt.Errorf("deep link is missing the agent parameter")
+	}
+	if !slugRE.MatchString(agentID) {
+		return Action{}, fmt.Errorf("invalid agent id %q", agentID)
+	}
+
+	method := query.Get("method")
+	if method != "" && !slugRE.MatchString(method) {
+		return Action{}, fmt.Errorf("invalid install method %q", method)
+	}
+
+	return Action{Kind: kind, AgentID: agentID, Method: method}, nil
+}
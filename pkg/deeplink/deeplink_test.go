@@ -0,0 +1,86 @@
+package deeplink
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		want    Action
+		wantErr bool
+	}{
+		{
+			name: "install with method",
+			url:  "agentmgr://install?agent=claude-code&method=npm",
+			want: Action{Kind: KindInstall, AgentID: "claude-code", Method: "npm"},
+		},
+		{
+			name: "install without method",
+			url:  "agentmgr://install?agent=aider",
+			want: Action{Kind: KindInstall, AgentID: "aider"},
+		},
+		{
+			name: "details",
+			url:  "agentmgr://details?agent=aider",
+			want: Action{Kind: KindDetails, AgentID: "aider"},
+		},
+		{
+			name: "opaque form without slashes",
+			url:  "agentmgr:install?agent=goose",
+			want: Action{Kind: KindInstall, AgentID: "goose"},
+		},
+		{
+			name: "path form with extra slash",
+			url:  "agentmgr:///details?agent=goose",
+			want: Action{Kind: KindDetails, AgentID: "goose"},
+		},
+		{
+			name:    "wrong scheme",
+			url:     "https://install?agent=aider",
+			wantErr: true,
+		},
+		{
+			name:    "unknown action",
+			url:     "agentmgr://uninstall?agent=aider",
+			wantErr: true,
+		},
+		{
+			name:    "missing agent",
+			url:     "agentmgr://install",
+			wantErr: true,
+		},
+		{
+			name:    "agent id with shell metacharacters",
+			url:     "agentmgr://install?agent=aider%3Brm%20-rf",
+			wantErr: true,
+		},
+		{
+			name:    "invalid method",
+			url:     "agentmgr://install?agent=aider&method=npm%20--evil",
+			wantErr: true,
+		},
+		{
+			name:    "not a url",
+			url:     "://",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.url)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.url, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.url, err)
+			}
+			if got != tt.want {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.url, got, tt.want)
+			}
+		})
+	}
+}
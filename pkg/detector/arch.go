@@ -0,0 +1,152 @@
+package detector
+
+import (
+	"encoding/binary"
+	"os"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+// Executable format magic numbers.
+const (
+	machoMagic64    = 0xfeedfacf
+	machoFatMagic   = 0xcafebabe
+	machoCPUX86_64  = 0x01000007
+	machoCPUARM64   = 0x0100000c
+	elfMachineX86   = 0x3e
+	elfMachineARM64 = 0xb7
+	peMachineX86    = 0x8664
+	peMachineARM64  = 0xaa64
+)
+
+// binaryArchitecture inspects an executable's header and reports its CPU
+// architecture: x86_64, arm64, or universal for macOS fat binaries holding
+// both slices. Scripts and unrecognized formats report "".
+func binaryArchitecture(path string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	header := make([]byte, 64)
+	n, err := f.Read(header)
+	if err != nil || n < 8 {
+		return ""
+	}
+	header = header[:n]
+
+	switch {
+	case binary.LittleEndian.Uint32(header) == machoMagic64:
+		return machoArch(binary.LittleEndian.Uint32(header[4:]))
+	case binary.BigEndian.Uint32(header) == machoFatMagic:
+		return fatArch(f, header)
+	case header[0] == 0x7f && header[1] == 'E' && header[2] == 'L' && header[3] == 'F':
+		return elfArch(header)
+	case header[0] == 'M' && header[1] == 'Z':
+		return peArch(f, header)
+	default:
+		return ""
+	}
+}
+
+// machoArch maps a Mach-O cputype to its canonical name.
+func machoArch(cputype uint32) string {
+	switch cputype {
+	case machoCPUX86_64:
+		return agent.ArchAMD64
+	case machoCPUARM64:
+		return agent.ArchARM64
+	default:
+		return ""
+	}
+}
+
+// fatArch reads the slices of a macOS fat binary. Binaries carrying both
+// x86_64 and arm64 slices are universal; single-arch fat files report that
+// architecture.
+func fatArch(f *os.File, header []byte) string {
+	if len(header) < 8 {
+		return ""
+	}
+	count := binary.BigEndian.Uint32(header[4:])
+	if count == 0 || count > 16 {
+		return ""
+	}
+
+	// Each fat_arch entry is 20 bytes, following the 8-byte fat header
+	entries := make([]byte, count*20)
+	if _, err := f.ReadAt(entries, 8); err != nil {
+		return ""
+	}
+
+	var hasAMD64, hasARM64 bool
+	for i := uint32(0); i < count; i++ {
+		switch binary.BigEndian.Uint32(entries[i*20:]) {
+		case machoCPUX86_64:
+			hasAMD64 = true
+		case machoCPUARM64:
+			hasARM64 = true
+		}
+	}
+
+	switch {
+	case hasAMD64 && hasARM64:
+		return agent.ArchUniversal
+	case hasARM64:
+		return agent.ArchARM64
+	case hasAMD64:
+		return agent.ArchAMD64
+	default:
+		return ""
+	}
+}
+
+// elfArch reads the e_machine field of an ELF header.
+func elfArch(header []byte) string {
+	if len(header) < 20 {
+		return ""
+	}
+	// EI_DATA at offset 5 selects the encoding; 1 is little endian
+	var machine uint16
+	if header[5] == 1 {
+		machine = binary.LittleEndian.Uint16(header[18:])
+	} else {
+		machine = binary.BigEndian.Uint16(header[18:])
+	}
+	switch machine {
+	case elfMachineX86:
+		return agent.ArchAMD64
+	case elfMachineARM64:
+		return agent.ArchARM64
+	default:
+		return ""
+	}
+}
+
+// peArch follows the e_lfanew pointer of a PE executable to its COFF
+// machine field.
+func peArch(f *os.File, header []byte) string {
+	if len(header) < 64 {
+		return ""
+	}
+	peOffset := binary.LittleEndian.Uint32(header[60:])
+
+	// "PE\0\0" signature followed by the COFF header
+	sig := make([]byte, 6)
+	if _, err := f.ReadAt(sig, int64(peOffset)); err != nil {
+		return ""
+	}
+	if sig[0] != 'P' || sig[1] != 'E' || sig[2] != 0 || sig[3] != 0 {
+		return ""
+	}
+
+	switch binary.LittleEndian.Uint16(sig[4:]) {
+	case peMachineX86:
+		return agent.ArchAMD64
+	case peMachineARM64:
+		return agent.ArchARM64
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,102 @@
+package detector
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+func writeBinary(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o755); err != nil {
+		t.Fatalf("writing %s: %v", name, err)
+	}
+	return path
+}
+
+func machoHeader(cputype uint32) []byte {
+	data := make([]byte, 32)
+	binary.LittleEndian.PutUint32(data, machoMagic64)
+	binary.LittleEndian.PutUint32(data[4:], cputype)
+	return data
+}
+
+func fatHeader(cputypes ...uint32) []byte {
+	data := make([]byte, 8+len(cputypes)*20)
+	binary.BigEndian.PutUint32(data, machoFatMagic)
+	binary.BigEndian.PutUint32(data[4:], uint32(len(cputypes)))
+	for i, cputype := range cputypes {
+		binary.BigEndian.PutUint32(data[8+i*20:], cputype)
+	}
+	return data
+}
+
+func elfHeader(machine uint16) []byte {
+	data := make([]byte, 32)
+	copy(data, []byte{0x7f, 'E', 'L', 'F'})
+	data[5] = 1 // little endian
+	binary.LittleEndian.PutUint16(data[18:], machine)
+	return data
+}
+
+func peHeader(machine uint16) []byte {
+	data := make([]byte, 128)
+	data[0], data[1] = 'M', 'Z'
+	binary.LittleEndian.PutUint32(data[60:], 80) // e_lfanew
+	copy(data[80:], []byte{'P', 'E', 0, 0})
+	binary.LittleEndian.PutUint16(data[84:], machine)
+	return data
+}
+
+func TestBinaryArchitecture(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"macho arm64", machoHeader(machoCPUARM64), agent.ArchARM64},
+		{"macho x86_64", machoHeader(machoCPUX86_64), agent.ArchAMD64},
+		{"universal", fatHeader(machoCPUX86_64, machoCPUARM64), agent.ArchUniversal},
+		{"fat single slice", fatHeader(machoCPUARM64), agent.ArchARM64},
+		{"elf x86_64", elfHeader(elfMachineX86), agent.ArchAMD64},
+		{"elf arm64", elfHeader(elfMachineARM64), agent.ArchARM64},
+		{"pe x86_64", peHeader(peMachineX86), agent.ArchAMD64},
+		{"pe arm64", peHeader(peMachineARM64), agent.ArchARM64},
+		{"shell script", []byte("#!/bin/sh\nexec node cli.js\n"), ""},
+		{"truncated", []byte{0x7f, 'E'}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeBinary(t, "bin", tt.data)
+			if got := binaryArchitecture(path); got != tt.want {
+				t.Errorf("binaryArchitecture() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBinaryArchitectureMissingFile(t *testing.T) {
+	if got := binaryArchitecture(filepath.Join(t.TempDir(), "missing")); got != "" {
+		t.Errorf("binaryArchitecture() = %q, want empty", got)
+	}
+}
+
+func TestRunsEmulated(t *testing.T) {
+	inst := agent.Installation{Architecture: agent.ArchAMD64}
+	if !inst.RunsEmulated(agent.ArchARM64) {
+		t.Error("x86_64 binary on arm64 host should run emulated")
+	}
+	if inst.RunsEmulated(agent.ArchAMD64) {
+		t.Error("x86_64 binary on x86_64 host should run natively")
+	}
+
+	inst.Architecture = agent.ArchUniversal
+	if inst.RunsEmulated(agent.ArchARM64) {
+		t.Error("universal binary should never run emulated")
+	}
+}
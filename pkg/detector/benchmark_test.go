@@ -2,9 +2,15 @@ package detector
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
@@ -83,6 +89,9 @@ func (p *benchPlatform) DisableAutoStart(_ context.Context) error {
 func (p *benchPlatform) IsAutoStartEnabled(_ context.Context) (bool, error) {
 	return false, nil
 }
+func (p *benchPlatform) GetAutoStartMethod(_ context.Context) (platform.AutoStartMethod, error) {
+	return platform.AutoStartNone, nil
+}
 func (p *benchPlatform) FindExecutable(_ string) (string, error)    { return "", nil }
 func (p *benchPlatform) FindExecutables(_ string) ([]string, error) { return nil, nil }
 func (p *benchPlatform) IsExecutableInPath(_ string) bool           { return false }
@@ -90,6 +99,146 @@ func (p *benchPlatform) GetPathDirs() []string                      { return nil
 func (p *benchPlatform) GetShell() string                           { return "/bin/zsh" }
 func (p *benchPlatform) GetShellArg() string                        { return "-c" }
 func (p *benchPlatform) ShowNotification(_, _ string) error         { return nil }
+func (p *benchPlatform) IsDoNotDisturbEnabled() bool                { return false }
+func (p *benchPlatform) IsElevated() bool                           { return false }
 func (p *benchPlatform) ShowChangelogDialog(_, _, _, _ string) platform.DialogResult {
 	return platform.DialogResultCancel
 }
+
+// latencyPlatform reports npm as available and a fixed set of installed
+// executables so detection exercises real command paths.
+type latencyPlatform struct {
+	benchPlatform
+	installed map[string]string
+}
+
+func (p *latencyPlatform) IsExecutableInPath(name string) bool { return name == "npm" }
+
+func (p *latencyPlatform) FindExecutable(name string) (string, error) {
+	if path, ok := p.installed[name]; ok {
+		return path, nil
+	}
+	return "", os.ErrNotExist
+}
+
+// benchAgentDefsN builds n catalog agents alternating npm and binary
+// install methods, mirroring the shape of the real catalog.
+func benchAgentDefsN(n int) []catalog.AgentDef {
+	defs := make([]catalog.AgentDef, 0, n)
+	for i := 0; i < n; i++ {
+		id := fmt.Sprintf("agent-%d", i)
+		if i%2 == 0 {
+			defs = append(defs, catalog.AgentDef{
+				ID:   id,
+				Name: id,
+				InstallMethods: map[string]catalog.InstallMethodDef{
+					"npm": {Package: "@bench/" + id},
+				},
+			})
+			continue
+		}
+		defs = append(defs, catalog.AgentDef{
+			ID:   id,
+			Name: id,
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"binary": {},
+			},
+			Detection: catalog.DetectionDef{
+				Executables: []string{id},
+				VersionCmd:  id + " --version",
+			},
+		})
+	}
+	return defs
+}
+
+// benchDetectSetup wires a detector against a fake command runner that
+// simulates typical npm and version-command latencies. A handful of the
+// catalog agents are "installed": five npm packages and up to ten
+// binaries, roughly what a busy developer machine looks like.
+func benchDetectSetup(tb testing.TB, agents []catalog.AgentDef, npmLatency, versionLatency time.Duration) *Detector {
+	tb.Helper()
+
+	// Keep the run hermetic: node version manager roots under the real
+	// home would add an npm listing per installed Node version.
+	tb.Setenv("HOME", tb.TempDir())
+
+	plat := &latencyPlatform{installed: map[string]string{}}
+	deps := make(map[string]map[string]string)
+	installedBinaries := 0
+	for _, def := range agents {
+		if m, ok := def.InstallMethods["npm"]; ok {
+			if len(deps) < 5 {
+				deps[m.Package] = map[string]string{"version": "1.2.3"}
+			}
+			continue
+		}
+		if installedBinaries < 10 {
+			exe := def.Detection.Executables[0]
+			plat.installed[exe] = "/opt/bench/bin/" + exe
+			installedBinaries++
+		}
+	}
+	listJSON, err := json.Marshal(map[string]interface{}{"dependencies": deps})
+	if err != nil {
+		tb.Fatalf("marshal npm list: %v", err)
+	}
+
+	fake := &execx.Fake{Handler: func(name string, args []string) (*execx.Result, error) {
+		if name == "npm" || strings.HasSuffix(name, "/npm") {
+			time.Sleep(npmLatency)
+			return &execx.Result{Stdout: string(listJSON)}, nil
+		}
+		time.Sleep(versionLatency)
+		return &execx.Result{Stdout: "9.9.9\n"}, nil
+	}}
+
+	orig := execx.Default
+	execx.Default = fake
+	tb.Cleanup(func() { execx.Default = orig })
+
+	return New(plat)
+}
+
+// BenchmarkDetectAllCatalogSize measures full detection latency as the
+// catalog grows, with simulated command latencies.
+func BenchmarkDetectAllCatalogSize(b *testing.B) {
+	for _, size := range []int{10, 50, 200} {
+		b.Run(fmt.Sprintf("agents-%d", size), func(b *testing.B) {
+			agents := benchAgentDefsN(size)
+			d := benchDetectSetup(b, agents, 30*time.Millisecond, 5*time.Millisecond)
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := d.DetectAll(ctx, agents); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// detectAllBudget is the performance budget for one full detection pass
+// over a large catalog with typical command latencies. Catalog size must
+// not multiply command invocations: package listings run once per pass
+// and only installed binaries are probed.
+const detectAllBudget = time.Second
+
+func TestDetectAllWithinBudget(t *testing.T) {
+	agents := benchAgentDefsN(200)
+	d := benchDetectSetup(t, agents, 150*time.Millisecond, 20*time.Millisecond)
+
+	start := time.Now()
+	installations, err := d.DetectAll(context.Background(), agents)
+	elapsed := time.Since(start)
+	if err != nil {
+		t.Fatalf("DetectAll: %v", err)
+	}
+	if len(installations) == 0 {
+		t.Fatal("expected simulated installations to be detected")
+	}
+	if elapsed > detectAllBudget {
+		t.Errorf("DetectAll took %v, budget is %v", elapsed, detectAllBudget)
+	}
+}
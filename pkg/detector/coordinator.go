@@ -0,0 +1,253 @@
+package detector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// Coordinator coalesces concurrent detection runs. UI refresh buttons,
+// IPC triggers, and background tickers can all request detection at the
+// same time; the coordinator runs DetectAll once, shares the results
+// with everyone waiting, and debounces rapid back-to-back triggers.
+type Coordinator struct {
+	detector *Detector
+	debounce time.Duration
+
+	mu       sync.Mutex
+	seq      int
+	current  *detectionRun
+	finished *detectionRun
+	lastDone time.Time
+	lastRun  []*agent.Installation
+}
+
+// detectionRun is one in-flight DetectAll execution.
+type detectionRun struct {
+	id     string
+	done   chan struct{}
+	agents []*agent.Installation
+	err    error
+
+	started      time.Time
+	completedAt  time.Time
+	total        int
+	completed    int
+	lastStrategy string
+	found        int
+}
+
+// RunStatus is a point-in-time snapshot of a detection run, suitable for
+// UIs polling over IPC or REST.
+type RunStatus struct {
+	RunID       string    `json:"run_id"`
+	State       string    `json:"state"` // "running", "completed", "failed"
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+
+	// StrategiesDone and StrategiesTotal count finished vs. applicable
+	// detection strategies; LastStrategy names the most recent one.
+	StrategiesDone  int    `json:"strategies_done"`
+	StrategiesTotal int    `json:"strategies_total"`
+	LastStrategy    string `json:"last_strategy,omitempty"`
+
+	AgentsFound int    `json:"agents_found"`
+	Error       string `json:"error,omitempty"`
+}
+
+// InProgressError is returned by TryRefresh when a detection run is
+// already active. It carries the in-flight run's ID so callers can
+// report or attach to it.
+type InProgressError struct {
+	RunID string
+}
+
+func (e *InProgressError) Error() string {
+	return fmt.Sprintf("refresh already in progress (run %s)", e.RunID)
+}
+
+// NewCoordinator creates a coordinator around the detector. Runs that
+// finish within the debounce window satisfy subsequent refreshes without
+// re-detecting; a zero debounce disables that.
+func NewCoordinator(det *Detector, debounce time.Duration) *Coordinator {
+	return &Coordinator{
+		detector: det,
+		debounce: debounce,
+	}
+}
+
+// Refresh returns detection results, joining any in-flight run and
+// reusing the results of a run that finished within the debounce window.
+func (c *Coordinator) Refresh(ctx context.Context, agentDefs []catalog.AgentDef) ([]*agent.Installation, error) {
+	c.mu.Lock()
+
+	if run := c.current; run != nil {
+		c.mu.Unlock()
+		return c.join(ctx, run)
+	}
+
+	if c.debounce > 0 && !c.lastDone.IsZero() && time.Since(c.lastDone) < c.debounce {
+		agents := cloneInstallations(c.lastRun)
+		c.mu.Unlock()
+		return agents, nil
+	}
+
+	run := c.begin()
+	c.mu.Unlock()
+
+	return c.execute(ctx, run, agentDefs)
+}
+
+// TryRefresh starts a detection run unless one is already active, in
+// which case it fails with an *InProgressError naming the running one.
+func (c *Coordinator) TryRefresh(ctx context.Context, agentDefs []catalog.AgentDef) ([]*agent.Installation, error) {
+	c.mu.Lock()
+
+	if run := c.current; run != nil {
+		c.mu.Unlock()
+		return nil, &InProgressError{RunID: run.id}
+	}
+
+	run := c.begin()
+	c.mu.Unlock()
+
+	return c.execute(ctx, run, agentDefs)
+}
+
+// Start kicks off a detection run in the background and returns its run ID
+// immediately so callers can poll Status. If a run is already active its ID
+// is returned with started=false instead of stacking a new one. onDone, if
+// non-nil, receives the run's results when it finishes.
+func (c *Coordinator) Start(ctx context.Context, agentDefs []catalog.AgentDef, onDone func([]*agent.Installation, error)) (runID string, started bool) {
+	c.mu.Lock()
+
+	if run := c.current; run != nil {
+		c.mu.Unlock()
+		return run.id, false
+	}
+
+	run := c.begin()
+	c.mu.Unlock()
+
+	go func() {
+		agents, err := c.execute(ctx, run, agentDefs)
+		if onDone != nil {
+			onDone(agents, err)
+		}
+	}()
+	return run.id, true
+}
+
+// Status reports the state of a detection run. An empty runID selects the
+// active run, falling back to the most recently finished one.
+func (c *Coordinator) Status(runID string) (RunStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	run := c.current
+	if run == nil || (runID != "" && run.id != runID) {
+		run = c.finished
+	}
+	if run == nil || (runID != "" && run.id != runID) {
+		return RunStatus{}, false
+	}
+
+	status := RunStatus{
+		RunID:           run.id,
+		State:           "running",
+		StartedAt:       run.started,
+		CompletedAt:     run.completedAt,
+		StrategiesDone:  run.completed,
+		StrategiesTotal: run.total,
+		LastStrategy:    run.lastStrategy,
+		AgentsFound:     run.found,
+	}
+	if !run.completedAt.IsZero() {
+		status.State = "completed"
+		status.AgentsFound = len(run.agents)
+		if run.err != nil {
+			status.State = "failed"
+			status.Error = run.err.Error()
+		}
+	}
+	return status, true
+}
+
+// InFlight returns the active run's ID, if any.
+func (c *Coordinator) InFlight() (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.current == nil {
+		return "", false
+	}
+	return c.current.id, true
+}
+
+// begin registers a new run. The caller must hold the mutex.
+func (c *Coordinator) begin() *detectionRun {
+	c.seq++
+	run := &detectionRun{
+		id:      fmt.Sprintf("refresh-%d", c.seq),
+		done:    make(chan struct{}),
+		started: time.Now(),
+	}
+	c.current = run
+	return run
+}
+
+// execute performs the detection for a run the caller started and
+// publishes the results to any joined waiters.
+func (c *Coordinator) execute(ctx context.Context, run *detectionRun, agentDefs []catalog.AgentDef) ([]*agent.Installation, error) {
+	agents, err := c.detector.DetectAllProgress(ctx, agentDefs, func(p StrategyProgress) {
+		c.mu.Lock()
+		run.completed = p.Completed
+		run.total = p.Total
+		run.lastStrategy = p.Strategy
+		run.found += p.Found
+		c.mu.Unlock()
+	})
+
+	c.mu.Lock()
+	run.agents = agents
+	run.err = err
+	run.completedAt = time.Now()
+	c.current = nil
+	c.finished = run
+	if err == nil {
+		c.lastDone = time.Now()
+		c.lastRun = agents
+	}
+	c.mu.Unlock()
+
+	close(run.done)
+	return agents, err
+}
+
+// join waits for an in-flight run and shares its outcome.
+func (c *Coordinator) join(ctx context.Context, run *detectionRun) ([]*agent.Installation, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-run.done:
+	}
+
+	if run.err != nil {
+		return nil, run.err
+	}
+	return cloneInstallations(run.agents), nil
+}
+
+// cloneInstallations copies the installation records so concurrent
+// consumers don't share mutable state.
+func cloneInstallations(agents []*agent.Installation) []*agent.Installation {
+	cloned := make([]*agent.Installation, len(agents))
+	for i, inst := range agents {
+		copied := *inst
+		cloned[i] = &copied
+	}
+	return cloned
+}
@@ -0,0 +1,223 @@
+package detector
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// blockingStrategy counts Detect calls and blocks until released.
+type blockingStrategy struct {
+	calls   atomic.Int32
+	release chan struct{}
+}
+
+func (b *blockingStrategy) Name() string                          { return "blocking" }
+func (b *blockingStrategy) Method() agent.InstallMethod           { return agent.MethodNPM }
+func (b *blockingStrategy) IsApplicable(p platform.Platform) bool { return true }
+
+func (b *blockingStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	b.calls.Add(1)
+	if b.release != nil {
+		<-b.release
+	}
+	return []*agent.Installation{{AgentID: "claude-code"}}, nil
+}
+
+func newCoordinatorForTest(strategy Strategy, debounce time.Duration) *Coordinator {
+	d := &Detector{
+		platform:   platform.Current(),
+		strategies: []Strategy{strategy},
+	}
+	return NewCoordinator(d, debounce)
+}
+
+func TestCoordinatorCoalescesConcurrentRefreshes(t *testing.T) {
+	strategy := &blockingStrategy{release: make(chan struct{})}
+	coord := newCoordinatorForTest(strategy, 0)
+
+	const callers = 5
+	results := make([][]*agent.Installation, callers)
+	errs := make([]error, callers)
+
+	var started, finished sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		started.Add(1)
+		finished.Add(1)
+		go func(i int) {
+			started.Done()
+			defer finished.Done()
+			results[i], errs[i] = coord.Refresh(context.Background(), nil)
+		}(i)
+	}
+
+	started.Wait()
+	// Give the goroutines time to either start the run or join it
+	time.Sleep(50 * time.Millisecond)
+	close(strategy.release)
+	finished.Wait()
+
+	for i := 0; i < callers; i++ {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: %v", i, errs[i])
+		}
+		if len(results[i]) != 1 || results[i][0].AgentID != "claude-code" {
+			t.Errorf("caller %d results = %v", i, results[i])
+		}
+	}
+
+	if calls := strategy.calls.Load(); calls >= callers {
+		t.Errorf("Detect calls = %d, want coalesced below %d", calls, callers)
+	}
+}
+
+func TestCoordinatorDebounce(t *testing.T) {
+	strategy := &blockingStrategy{}
+	coord := newCoordinatorForTest(strategy, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if _, err := coord.Refresh(context.Background(), nil); err != nil {
+			t.Fatalf("Refresh %d: %v", i, err)
+		}
+	}
+
+	if calls := strategy.calls.Load(); calls != 1 {
+		t.Errorf("Detect calls = %d, want 1 within the debounce window", calls)
+	}
+}
+
+func TestCoordinatorTryRefreshReportsInFlight(t *testing.T) {
+	strategy := &blockingStrategy{release: make(chan struct{})}
+	coord := newCoordinatorForTest(strategy, 0)
+
+	go coord.Refresh(context.Background(), nil)
+
+	// Wait for the run to register
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, ok := coord.InFlight(); ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("run never registered as in flight")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	_, err := coord.TryRefresh(context.Background(), nil)
+	var inProgress *InProgressError
+	if !errors.As(err, &inProgress) {
+		t.Fatalf("err = %v, want InProgressError", err)
+	}
+	if inProgress.RunID == "" {
+		t.Error("expected the in-flight run ID")
+	}
+
+	close(strategy.release)
+}
+
+func TestCoordinatorStartReportsStatus(t *testing.T) {
+	strategy := &blockingStrategy{release: make(chan struct{})}
+	coord := newCoordinatorForTest(strategy, 0)
+
+	done := make(chan struct{})
+	runID, started := coord.Start(context.Background(), nil, func(agents []*agent.Installation, err error) {
+		close(done)
+	})
+	if !started || runID == "" {
+		t.Fatalf("Start() = %q, %v; want a new run", runID, started)
+	}
+
+	// Starting again while the run is active returns the same ID
+	sameID, startedAgain := coord.Start(context.Background(), nil, nil)
+	if startedAgain || sameID != runID {
+		t.Errorf("second Start() = %q, %v; want %q, false", sameID, startedAgain, runID)
+	}
+
+	status, ok := coord.Status(runID)
+	if !ok {
+		t.Fatal("expected status for the active run")
+	}
+	if status.State != "running" {
+		t.Errorf("State = %q, want %q", status.State, "running")
+	}
+
+	close(strategy.release)
+	<-done
+
+	status, ok = coord.Status(runID)
+	if !ok {
+		t.Fatal("expected status for the finished run")
+	}
+	if status.State != "completed" {
+		t.Errorf("State = %q, want %q", status.State, "completed")
+	}
+	if status.StrategiesDone != 1 || status.StrategiesTotal != 1 {
+		t.Errorf("strategies = %d/%d, want 1/1", status.StrategiesDone, status.StrategiesTotal)
+	}
+	if status.LastStrategy != "blocking" {
+		t.Errorf("LastStrategy = %q, want %q", status.LastStrategy, "blocking")
+	}
+	if status.AgentsFound != 1 {
+		t.Errorf("AgentsFound = %d, want 1", status.AgentsFound)
+	}
+
+	// An empty run ID resolves to the most recent run
+	if status, ok := coord.Status(""); !ok || status.RunID != runID {
+		t.Errorf("Status(\"\") = %+v, %v; want the finished run", status, ok)
+	}
+
+	if _, ok := coord.Status("refresh-999"); ok {
+		t.Error("expected no status for an unknown run ID")
+	}
+}
+
+func TestDetectAllProgressCallback(t *testing.T) {
+	strategy := &blockingStrategy{}
+	d := &Detector{
+		platform:   platform.Current(),
+		strategies: []Strategy{strategy},
+	}
+
+	var events []StrategyProgress
+	if _, err := d.DetectAllProgress(context.Background(), nil, func(p StrategyProgress) {
+		events = append(events, p)
+	}); err != nil {
+		t.Fatalf("DetectAllProgress: %v", err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("events = %d, want 1", len(events))
+	}
+	if events[0].Strategy != "blocking" || events[0].Completed != 1 || events[0].Total != 1 || events[0].Found != 1 {
+		t.Errorf("event = %+v", events[0])
+	}
+}
+
+func TestCoordinatorJoinHonorsContext(t *testing.T) {
+	strategy := &blockingStrategy{release: make(chan struct{})}
+	defer close(strategy.release)
+	coord := newCoordinatorForTest(strategy, 0)
+
+	go coord.Refresh(context.Background(), nil)
+	for {
+		if _, ok := coord.InFlight(); ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := coord.Refresh(ctx, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+}
@@ -4,11 +4,14 @@ package detector
 import (
 	"context"
 	"fmt"
+	"os"
 	"sync"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
@@ -48,6 +51,12 @@ func New(p platform.Platform) *Detector {
 	d.RegisterStrategy(NewNPMStrategy(p))
 	d.RegisterStrategy(NewPipStrategy(p))
 	d.RegisterStrategy(NewBrewStrategy(p))
+	d.RegisterStrategy(NewExtensionStrategy(p))
+	d.RegisterStrategy(NewMiseStrategy(p))
+	d.RegisterStrategy(NewAppBundleStrategy(p))
+	d.RegisterStrategy(NewFlatpakStrategy(p))
+	d.RegisterStrategy(NewSnapStrategy(p))
+	d.RegisterStrategy(NewConfigDirStrategy(p))
 
 	return d
 }
@@ -71,6 +80,18 @@ func (d *Detector) LoadPlugins(dir string) error {
 	return nil
 }
 
+// SetInstallersConfig forwards tool overrides from the installers
+// configuration to the strategies that honor them.
+func (d *Detector) SetInstallersConfig(cfg config.InstallersConfig) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, s := range d.strategies {
+		if c, ok := s.(interface{ SetInstallers(config.InstallersConfig) }); ok {
+			c.SetInstallers(cfg)
+		}
+	}
+}
+
 // RegisterStrategy adds a detection strategy.
 func (d *Detector) RegisterStrategy(s Strategy) {
 	d.mu.Lock()
@@ -85,26 +106,67 @@ func (d *Detector) GetStrategies() []Strategy {
 	return d.strategies
 }
 
+// StrategyProgress reports one strategy finishing during a detection run.
+type StrategyProgress struct {
+	// Strategy is the name of the strategy that just completed.
+	Strategy string
+	// Completed and Total count finished vs. applicable strategies.
+	Completed int
+	Total     int
+	// Found is how many installations the strategy reported.
+	Found int
+}
+
+// ProgressFunc receives per-strategy progress during DetectAllProgress.
+type ProgressFunc func(StrategyProgress)
+
 // DetectAll runs all applicable strategies and returns found installations.
 func (d *Detector) DetectAll(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	return d.DetectAllProgress(ctx, agents, nil)
+}
+
+// DetectAllProgress is DetectAll with a progress callback invoked as each
+// strategy completes, so UIs can report "scanning npm (3/7)" instead of a
+// generic spinner. The callback may be nil and is called from detection
+// goroutines, one strategy at a time.
+func (d *Detector) DetectAllProgress(ctx context.Context, agents []catalog.AgentDef, progress ProgressFunc) ([]*agent.Installation, error) {
 	d.mu.RLock()
 	strategies := d.strategies
 	d.mu.RUnlock()
 
-	var wg sync.WaitGroup
-	resultsChan := make(chan []*agent.Installation, len(strategies))
-	errorsChan := make(chan error, len(strategies))
-
+	applicable := make([]Strategy, 0, len(strategies))
 	for _, s := range strategies {
-		if !s.IsApplicable(d.platform) {
-			continue
+		if s.IsApplicable(d.platform) {
+			applicable = append(applicable, s)
 		}
+	}
+
+	var wg sync.WaitGroup
+	resultsChan := make(chan []*agent.Installation, len(applicable))
+	errorsChan := make(chan error, len(applicable))
 
+	var progressMu sync.Mutex
+	completed := 0
+
+	for _, s := range applicable {
 		wg.Add(1)
 		go func(strategy Strategy) {
 			defer wg.Done()
 
 			installations, err := strategy.Detect(ctx, agents)
+
+			if progress != nil {
+				progressMu.Lock()
+				completed++
+				progress(StrategyProgress{
+					Strategy:  strategy.Name(),
+					Completed: completed,
+					Total:     len(applicable),
+					Found:     len(installations),
+				})
+				progressMu.Unlock()
+			}
+
 			if err != nil {
 				errorsChan <- fmt.Errorf("%s detection failed: %w", strategy.Name(), err)
 				return
@@ -132,16 +194,26 @@ func (d *Detector) DetectAll(ctx context.Context, agents []catalog.AgentDef) ([]
 		// Errors from individual strategies are logged but don't fail detection
 	}
 
-	// Deduplicate installations by key
+	// Deduplicate installations by key, and drop configured-only hints
+	// for agents another strategy found actually installed
 	allInstallations = deduplicateInstallations(allInstallations)
+	allInstallations = dropShadowedConfigured(allInstallations)
 
-	// Set detection timestamp
+	// Set detection timestamp, classify installation scope, and record
+	// the binary's CPU architecture
 	now := time.Now()
+	homeDir, _ := os.UserHomeDir()
 	for _, inst := range allInstallations {
 		if inst.DetectedAt.IsZero() {
 			inst.DetectedAt = now
 		}
 		inst.LastChecked = now
+		if inst.Scope == "" {
+			inst.Scope = agent.ClassifyScope(inst.ExecutablePath, homeDir)
+		}
+		if inst.Architecture == "" {
+			inst.Architecture = binaryArchitecture(inst.ExecutablePath)
+		}
 	}
 
 	return allInstallations, nil
@@ -172,7 +244,7 @@ func (d *Detector) DetectByMethod(ctx context.Context, method agent.InstallMetho
 		}
 	}
 
-	return nil, fmt.Errorf("no strategy available for method: %s", method)
+	return nil, errcode.Errorf(errcode.CodeUnavailable, "no strategy available for method: %s", method)
 }
 
 // DetectAgent detects a specific agent using all applicable strategies.
@@ -196,6 +268,27 @@ func deduplicateInstallations(installations []*agent.Installation) []*agent.Inst
 	return result
 }
 
+// dropShadowedConfigured removes configured-only hints for agents that
+// also have a real installation: a leftover config dir is only worth
+// reporting when nothing else found the agent.
+func dropShadowedConfigured(installations []*agent.Installation) []*agent.Installation {
+	installed := make(map[string]bool)
+	for _, inst := range installations {
+		if !inst.ConfiguredOnly {
+			installed[inst.AgentID] = true
+		}
+	}
+
+	var result []*agent.Installation
+	for _, inst := range installations {
+		if inst.ConfiguredOnly && installed[inst.AgentID] {
+			continue
+		}
+		result = append(result, inst)
+	}
+	return result
+}
+
 // Result represents the result of a detection run.
 type Result struct {
 	Installations []*agent.Installation
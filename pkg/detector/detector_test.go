@@ -305,6 +305,38 @@ func TestDeduplicateInstallations(t *testing.T) {
 	}
 }
 
+func TestDropShadowedConfigured(t *testing.T) {
+	installed := &agent.Installation{
+		AgentID:        "claude-code",
+		Method:         agent.InstallMethodNPM,
+		ExecutablePath: "/usr/local/bin/claude",
+	}
+	shadowed := &agent.Installation{
+		AgentID:        "claude-code",
+		Method:         agent.InstallMethodNative,
+		ConfiguredOnly: true,
+	}
+	orphaned := &agent.Installation{
+		AgentID:        "aider",
+		Method:         agent.InstallMethodNative,
+		ConfiguredOnly: true,
+	}
+
+	result := dropShadowedConfigured([]*agent.Installation{installed, shadowed, orphaned})
+
+	if len(result) != 2 {
+		t.Fatalf("dropShadowedConfigured() returned %d, want 2", len(result))
+	}
+	for _, inst := range result {
+		if inst.AgentID == "claude-code" && inst.ConfiguredOnly {
+			t.Error("configured-only hint should be dropped when the agent is installed")
+		}
+	}
+	if result[1].AgentID != "aider" || !result[1].ConfiguredOnly {
+		t.Error("configured-only hint without a real install should be kept")
+	}
+}
+
 func TestResultNewInstallations(t *testing.T) {
 	existing := []*agent.Installation{
 		{
@@ -402,16 +402,19 @@ func (m *mockPlatform) ID() platform.ID {
 	return m.id
 }
 
-func (m *mockPlatform) Architecture() string                                        { return "amd64" }
-func (m *mockPlatform) Name() string                                                { return "Test" }
-func (m *mockPlatform) GetDataDir() string                                          { return "/tmp/data" }
-func (m *mockPlatform) GetConfigDir() string                                        { return "/tmp/config" }
-func (m *mockPlatform) GetCacheDir() string                                         { return "/tmp/cache" }
-func (m *mockPlatform) GetLogDir() string                                           { return "/tmp/log" }
-func (m *mockPlatform) GetIPCSocketPath() string                                    { return "/tmp/agentmgr.sock" }
-func (m *mockPlatform) EnableAutoStart(ctx context.Context) error                   { return nil }
-func (m *mockPlatform) DisableAutoStart(ctx context.Context) error                  { return nil }
-func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error)        { return false, nil }
+func (m *mockPlatform) Architecture() string                                 { return "amd64" }
+func (m *mockPlatform) Name() string                                         { return "Test" }
+func (m *mockPlatform) GetDataDir() string                                   { return "/tmp/data" }
+func (m *mockPlatform) GetConfigDir() string                                 { return "/tmp/config" }
+func (m *mockPlatform) GetCacheDir() string                                  { return "/tmp/cache" }
+func (m *mockPlatform) GetLogDir() string                                    { return "/tmp/log" }
+func (m *mockPlatform) GetIPCSocketPath() string                             { return "/tmp/agentmgr.sock" }
+func (m *mockPlatform) EnableAutoStart(ctx context.Context) error            { return nil }
+func (m *mockPlatform) DisableAutoStart(ctx context.Context) error           { return nil }
+func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) { return false, nil }
+func (m *mockPlatform) GetAutoStartMethod(ctx context.Context) (platform.AutoStartMethod, error) {
+	return platform.AutoStartNone, nil
+}
 func (m *mockPlatform) FindExecutable(name string) (string, error)                  { return "", nil }
 func (m *mockPlatform) FindExecutables(name string) ([]string, error)               { return nil, nil }
 func (m *mockPlatform) IsExecutableInPath(name string) bool                         { return false }
@@ -419,4 +422,6 @@ func (m *mockPlatform) GetPathDirs() []string
 func (m *mockPlatform) GetShell() string                                            { return "/bin/sh" }
 func (m *mockPlatform) GetShellArg() string                                         { return "-c" }
 func (m *mockPlatform) ShowNotification(title, message string) error                { return nil }
+func (m *mockPlatform) IsDoNotDisturbEnabled() bool                                 { return false }
+func (m *mockPlatform) IsElevated() bool                                            { return false }
 func (m *mockPlatform) ShowChangelogDialog(a, b, c, d string) platform.DialogResult { return 0 }
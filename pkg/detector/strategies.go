@@ -24,3 +24,33 @@ func NewPipStrategy(p platform.Platform) Strategy {
 func NewBrewStrategy(p platform.Platform) Strategy {
 	return strategies.NewBrewStrategy(p)
 }
+
+// NewExtensionStrategy creates a new editor extension detection strategy.
+func NewExtensionStrategy(p platform.Platform) Strategy {
+	return strategies.NewExtensionStrategy(p)
+}
+
+// NewMiseStrategy creates a new mise/asdf detection strategy.
+func NewMiseStrategy(p platform.Platform) Strategy {
+	return strategies.NewMiseStrategy(p)
+}
+
+// NewAppBundleStrategy creates a new macOS app bundle detection strategy.
+func NewAppBundleStrategy(p platform.Platform) Strategy {
+	return strategies.NewAppBundleStrategy(p)
+}
+
+// NewFlatpakStrategy creates a new Flatpak detection strategy.
+func NewFlatpakStrategy(p platform.Platform) Strategy {
+	return strategies.NewFlatpakStrategy(p)
+}
+
+// NewSnapStrategy creates a new Snap detection strategy.
+func NewSnapStrategy(p platform.Platform) Strategy {
+	return strategies.NewSnapStrategy(p)
+}
+
+// NewConfigDirStrategy creates a new config-directory detection strategy.
+func NewConfigDirStrategy(p platform.Platform) Strategy {
+	return strategies.NewConfigDirStrategy(p)
+}
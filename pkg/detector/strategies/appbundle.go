@@ -0,0 +1,192 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// AppBundleStrategy detects GUI agents installed as macOS application
+// bundles, matching the bundle identifiers declared in the catalog against
+// Info.plist files under /Applications and ~/Applications.
+type AppBundleStrategy struct {
+	platform platform.Platform
+
+	// appDirs overrides the application scan directories (for testing).
+	appDirs []string
+}
+
+// NewAppBundleStrategy creates a new macOS app bundle detection strategy.
+func NewAppBundleStrategy(p platform.Platform) *AppBundleStrategy {
+	return &AppBundleStrategy{platform: p}
+}
+
+// Name returns the strategy name.
+func (s *AppBundleStrategy) Name() string {
+	return "appbundle"
+}
+
+// Method returns the install method this strategy detects. App bundles are
+// reported as native installs unless the agent has a brew cask method, in
+// which case updates flow through the brew provider.
+func (s *AppBundleStrategy) Method() agent.InstallMethod {
+	return agent.MethodNative
+}
+
+// IsApplicable returns true on macOS.
+func (s *AppBundleStrategy) IsApplicable(p platform.Platform) bool {
+	return len(s.appDirs) > 0 || p.ID() == platform.Darwin
+}
+
+// SetAppDirs overrides the application scan directories. Primarily for
+// testing.
+func (s *AppBundleStrategy) SetAppDirs(dirs []string) {
+	s.appDirs = dirs
+}
+
+// searchDirs returns the application directories to scan.
+func (s *AppBundleStrategy) searchDirs() []string {
+	if len(s.appDirs) > 0 {
+		return s.appDirs
+	}
+
+	dirs := []string{"/Applications"}
+	if home, err := os.UserHomeDir(); err == nil {
+		dirs = append(dirs, filepath.Join(home, "Applications"))
+	}
+	return dirs
+}
+
+// foundBundle records an application bundle discovered during the scan.
+type foundBundle struct {
+	path    string
+	version string
+}
+
+// Detect scans the application directories for bundles whose identifiers
+// match catalog agents.
+func (s *AppBundleStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	// Only scan if some agent declares bundle identifiers
+	declared := false
+	for _, agentDef := range agents {
+		if len(agentDef.Detection.BundleIdentifiers) > 0 {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return nil, nil
+	}
+
+	bundles := s.scanBundles(ctx)
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+
+	var installations []*agent.Installation
+
+	for _, agentDef := range agents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, identifier := range agentDef.Detection.BundleIdentifiers {
+			found, ok := bundles[strings.ToLower(identifier)]
+			if !ok {
+				continue
+			}
+
+			version := parseAgentVersion(agentDef, found.version)
+
+			installations = append(installations, &agent.Installation{
+				AgentID:          agentDef.ID,
+				AgentName:        agentDef.Name,
+				Method:           appBundleMethod(agentDef),
+				InstalledVersion: version,
+				InstallPath:      found.path,
+				Metadata: map[string]string{
+					"detected_by":       "appbundle",
+					"bundle_identifier": identifier,
+					"app_bundle":        found.path,
+				},
+			})
+			break
+		}
+	}
+
+	return installations, nil
+}
+
+// scanBundles reads the Info.plist of every app bundle in the search
+// directories and returns a map of lowercase bundle identifier to bundle.
+func (s *AppBundleStrategy) scanBundles(ctx context.Context) map[string]foundBundle {
+	bundles := make(map[string]foundBundle)
+
+	for _, dir := range s.searchDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if ctx.Err() != nil {
+				return bundles
+			}
+			if !entry.IsDir() || !strings.HasSuffix(entry.Name(), ".app") {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			data, err := os.ReadFile(filepath.Join(path, "Contents", "Info.plist"))
+			if err != nil {
+				continue
+			}
+
+			identifier := readPlistString(data, "CFBundleIdentifier")
+			if identifier == "" {
+				continue
+			}
+
+			bundles[strings.ToLower(identifier)] = foundBundle{
+				path:    path,
+				version: readPlistString(data, "CFBundleShortVersionString"),
+			}
+		}
+	}
+
+	return bundles
+}
+
+// appBundleMethod returns the install method to report for a GUI agent: brew
+// when the agent has a cask method so updates go through the brew provider,
+// native (dmg download) otherwise.
+func appBundleMethod(agentDef catalog.AgentDef) agent.InstallMethod {
+	if method, ok := agentDef.InstallMethods["brew"]; ok && brewMethodIsCask(method) {
+		return agent.MethodBrew
+	}
+	return agent.MethodNative
+}
+
+// readPlistString extracts a top-level string value from an XML plist.
+// Binary plists are not supported and yield an empty string.
+func readPlistString(data []byte, key string) string {
+	marker := "<key>" + key + "</key>"
+	idx := strings.Index(string(data), marker)
+	if idx < 0 {
+		return ""
+	}
+
+	rest := string(data)[idx+len(marker):]
+	start := strings.Index(rest, "<string>")
+	end := strings.Index(rest, "</string>")
+	if start < 0 || end < 0 || end <= start {
+		return ""
+	}
+
+	return strings.TrimSpace(rest[start+len("<string>") : end])
+}
@@ -0,0 +1,144 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// writeAppBundle creates a minimal .app bundle with the given identifier
+// and version in dir and returns its path.
+func writeAppBundle(t *testing.T, dir, name, identifier, version string) string {
+	t.Helper()
+
+	appPath := filepath.Join(dir, name)
+	if err := os.MkdirAll(filepath.Join(appPath, "Contents"), 0o755); err != nil {
+		t.Fatalf("failed to create app bundle: %v", err)
+	}
+
+	plist := `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>` + identifier + `</string>
+	<key>CFBundleShortVersionString</key>
+	<string>` + version + `</string>
+</dict>
+</plist>`
+	if err := os.WriteFile(filepath.Join(appPath, "Contents", "Info.plist"), []byte(plist), 0o644); err != nil {
+		t.Fatalf("failed to write Info.plist: %v", err)
+	}
+	return appPath
+}
+
+func TestAppBundleDetect(t *testing.T) {
+	dir := t.TempDir()
+	appPath := writeAppBundle(t, dir, "Claude Desktop.app", "com.anthropic.claudefordesktop", "1.4.2")
+	writeAppBundle(t, dir, "Other.app", "com.example.other", "9.9.9")
+
+	s := NewAppBundleStrategy(platform.Current())
+	s.SetAppDirs([]string{dir})
+
+	agents := []catalog.AgentDef{
+		{
+			ID:   "claude-desktop",
+			Name: "Claude Desktop",
+			Detection: catalog.DetectionDef{
+				BundleIdentifiers: []string{"com.anthropic.claudefordesktop"},
+			},
+		},
+		{
+			ID:   "aider",
+			Name: "Aider",
+			Detection: catalog.DetectionDef{
+				Executables: []string{"aider"},
+			},
+		},
+	}
+
+	installations, err := s.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	if len(installations) != 1 {
+		t.Fatalf("expected 1 installation, got %d", len(installations))
+	}
+
+	inst := installations[0]
+	if inst.AgentID != "claude-desktop" {
+		t.Errorf("AgentID = %q, want claude-desktop", inst.AgentID)
+	}
+	if inst.InstallPath != appPath {
+		t.Errorf("InstallPath = %q, want %q", inst.InstallPath, appPath)
+	}
+	if got := inst.InstalledVersion.String(); got != "1.4.2" {
+		t.Errorf("InstalledVersion = %q, want 1.4.2", got)
+	}
+	if inst.Method != agent.MethodNative {
+		t.Errorf("Method = %q, want native", inst.Method)
+	}
+}
+
+func TestAppBundleDetectNoDeclaredIdentifiers(t *testing.T) {
+	dir := t.TempDir()
+	writeAppBundle(t, dir, "Cursor.app", "com.todesktop.230313mzl4w4u92", "0.42.0")
+
+	s := NewAppBundleStrategy(platform.Current())
+	s.SetAppDirs([]string{dir})
+
+	agents := []catalog.AgentDef{
+		{ID: "aider", Detection: catalog.DetectionDef{Executables: []string{"aider"}}},
+	}
+
+	installations, err := s.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(installations) != 0 {
+		t.Fatalf("expected no installations, got %d", len(installations))
+	}
+}
+
+func TestAppBundleMethod(t *testing.T) {
+	caskAgent := catalog.AgentDef{
+		InstallMethods: map[string]catalog.InstallMethodDef{
+			"brew": {Method: "brew", Metadata: map[string]string{"type": "cask"}},
+		},
+	}
+	if got := appBundleMethod(caskAgent); got != agent.MethodBrew {
+		t.Errorf("appBundleMethod(cask) = %q, want brew", got)
+	}
+
+	nativeAgent := catalog.AgentDef{
+		InstallMethods: map[string]catalog.InstallMethodDef{
+			"native": {Method: "native", Command: "curl -o app.dmg https://example.com/app.dmg"},
+		},
+	}
+	if got := appBundleMethod(nativeAgent); got != agent.MethodNative {
+		t.Errorf("appBundleMethod(native) = %q, want native", got)
+	}
+}
+
+func TestReadPlistString(t *testing.T) {
+	plist := []byte(`<?xml version="1.0"?>
+<dict>
+	<key>CFBundleIdentifier</key>
+	<string>com.example.app</string>
+</dict>`)
+
+	if got := readPlistString(plist, "CFBundleIdentifier"); got != "com.example.app" {
+		t.Errorf("readPlistString() = %q, want com.example.app", got)
+	}
+	if got := readPlistString(plist, "CFBundleShortVersionString"); got != "" {
+		t.Errorf("readPlistString() for missing key = %q, want empty", got)
+	}
+	if got := readPlistString([]byte("bplist00..."), "CFBundleIdentifier"); got != "" {
+		t.Errorf("readPlistString() for binary plist = %q, want empty", got)
+	}
+}
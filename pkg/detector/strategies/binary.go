@@ -3,13 +3,13 @@ package strategies
 
 import (
 	"context"
-	"os/exec"
 	"regexp"
 	"runtime"
 	"strings"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
@@ -46,6 +46,10 @@ var binaryMethods = []string{"native", "binary", "curl"}
 func (s *BinaryStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
 	var installations []*agent.Installation
 
+	// Several catalog agents can resolve to the same executable; probe
+	// each distinct version command only once per pass.
+	versionOutputs := make(map[string]string)
+
 	for _, agentDef := range agents {
 		// Check if this agent has a binary-based install method defined in the catalog.
 		// This mirrors how NPMStrategy checks for "npm" before reporting.
@@ -75,7 +79,7 @@ func (s *BinaryStrategy) Detect(ctx context.Context, agents []catalog.AgentDef)
 			}
 
 			// Get version
-			version := s.getVersion(ctx, agentDef, path)
+			version := s.getVersion(ctx, agentDef, path, versionOutputs)
 
 			inst := &agent.Installation{
 				AgentID:          agentDef.ID,
@@ -97,8 +101,9 @@ func (s *BinaryStrategy) Detect(ctx context.Context, agents []catalog.AgentDef)
 	return installations, nil
 }
 
-// getVersion extracts the version from the executable.
-func (s *BinaryStrategy) getVersion(ctx context.Context, agentDef catalog.AgentDef, path string) agent.Version {
+// getVersion extracts the version from the executable, reusing any
+// output already captured for the same command in this pass.
+func (s *BinaryStrategy) getVersion(ctx context.Context, agentDef catalog.AgentDef, path string, outputs map[string]string) agent.Version {
 	if agentDef.Detection.VersionCmd == "" {
 		return agent.Version{}
 	}
@@ -112,14 +117,21 @@ func (s *BinaryStrategy) getVersion(ctx context.Context, agentDef catalog.AgentD
 	// Replace the executable name with the full path
 	parts[0] = path
 
-	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
-	output, err := cmd.CombinedOutput()
-	if err != nil {
+	key := strings.Join(parts, " ")
+	output, probed := outputs[key]
+	if !probed {
+		res, err := execx.Run(ctx, parts[0], parts[1:])
+		if err == nil {
+			output = res.Stdout + res.Stderr
+		}
+		outputs[key] = output
+	}
+	if output == "" {
 		return agent.Version{}
 	}
 
 	// Extract version using regex if provided
-	versionStr := strings.TrimSpace(string(output))
+	versionStr := strings.TrimSpace(output)
 	if agentDef.Detection.VersionRegex != "" {
 		re, err := regexp.Compile(agentDef.Detection.VersionRegex)
 		if err == nil {
@@ -133,21 +145,33 @@ func (s *BinaryStrategy) getVersion(ctx context.Context, agentDef catalog.AgentD
 		versionStr = extractVersionFromOutput(versionStr)
 	}
 
-	version, _ := agent.ParseVersion(versionStr)
-	return version
+	return parseAgentVersion(agentDef, versionStr)
 }
 
+// Common version patterns, compiled once: --version output is untrusted
+// and may be fed through these on every detection pass.
+var versionOutputPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`v?(\d+\.\d+\.\d+(?:-[a-zA-Z0-9.]+)?)`),
+	regexp.MustCompile(`version\s+v?(\d+\.\d+\.\d+)`),
+	regexp.MustCompile(`(\d+\.\d+\.\d+)`),
+}
+
+// ansiEscapeRe matches ANSI escape sequences (colors, cursor movement)
+// that some tools emit even when not attached to a terminal.
+var ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// maxVersionOutputScan bounds how much command output is scanned for a
+// version number; real version banners fit comfortably within this.
+const maxVersionOutputScan = 64 * 1024
+
 // extractVersionFromOutput tries to extract a version number from command output.
 func extractVersionFromOutput(output string) string {
-	// Common version patterns
-	patterns := []string{
-		`v?(\d+\.\d+\.\d+(?:-[a-zA-Z0-9.]+)?)`,
-		`version\s+v?(\d+\.\d+\.\d+)`,
-		`(\d+\.\d+\.\d+)`,
+	if len(output) > maxVersionOutputScan {
+		output = output[:maxVersionOutputScan]
 	}
+	output = ansiEscapeRe.ReplaceAllString(output, "")
 
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
+	for _, re := range versionOutputPatterns {
 		matches := re.FindStringSubmatch(output)
 		if len(matches) > 1 {
 			return matches[1]
@@ -3,17 +3,24 @@ package strategies
 import (
 	"context"
 	"encoding/json"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // BrewStrategy detects agents installed via Homebrew.
 type BrewStrategy struct {
-	platform platform.Platform
+	platform   platform.Platform
+	installers config.InstallersConfig
+
+	// appDirs overrides the application bundle scan directories (for testing).
+	appDirs []string
 }
 
 // NewBrewStrategy creates a new Homebrew detection strategy.
@@ -21,6 +28,11 @@ func NewBrewStrategy(p platform.Platform) *BrewStrategy {
 	return &BrewStrategy{platform: p}
 }
 
+// SetInstallers applies tool overrides from the installers configuration.
+func (s *BrewStrategy) SetInstallers(c config.InstallersConfig) {
+	s.installers = c
+}
+
 // Name returns the strategy name.
 func (s *BrewStrategy) Name() string {
 	return "brew"
@@ -33,7 +45,14 @@ func (s *BrewStrategy) Method() agent.InstallMethod {
 
 // IsApplicable returns true if brew is available (macOS/Linux).
 func (s *BrewStrategy) IsApplicable(p platform.Platform) bool {
-	return p.ID() != platform.Windows && p.IsExecutableInPath("brew")
+	if p.ID() == platform.Windows {
+		return false
+	}
+	if brewCmd := s.installers.BrewCommand(); brewCmd != "brew" {
+		_, err := os.Stat(brewCmd)
+		return err == nil
+	}
+	return p.IsExecutableInPath("brew")
 }
 
 // brewFormula represents a Homebrew formula from list output.
@@ -56,13 +75,15 @@ type brewCask struct {
 
 // Detect scans for brew-installed agents.
 func (s *BrewStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
-	var installations []*agent.Installation
+	// Nothing to match against: don't bother spawning brew
+	if !catalogUsesMethod(agents, "brew") {
+		return nil, nil
+	}
 
-	// Get installed formulae
-	formulae := s.getInstalledFormulae(ctx)
+	var installations []*agent.Installation
 
-	// Get installed casks
-	casks := s.getInstalledCasks(ctx)
+	// Get installed formulae and casks in one pass
+	formulae, casks := s.getInventory(ctx)
 
 	for _, agentDef := range agents {
 		// Check brew method
@@ -82,7 +103,7 @@ func (s *BrewStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([
 			if len(formula.InstalledVersions) > 0 {
 				versionStr = formula.InstalledVersions[0]
 			}
-			version, _ := agent.ParseVersion(versionStr)
+			version := parseAgentVersion(agentDef, versionStr)
 
 			installations = append(installations, &agent.Installation{
 				AgentID:          agentDef.ID,
@@ -101,7 +122,7 @@ func (s *BrewStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([
 
 		// Check if it's a cask
 		if cask, found := casks[strings.ToLower(packageName)]; found {
-			version, _ := agent.ParseVersion(cask.InstalledVersion)
+			version := parseAgentVersion(agentDef, cask.InstalledVersion)
 
 			installations = append(installations, &agent.Installation{
 				AgentID:          agentDef.ID,
@@ -115,22 +136,137 @@ func (s *BrewStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([
 					"package_type": "cask",
 				},
 			})
+			continue
+		}
+
+		// Cask apps may predate brew's records (or brew may be briefly
+		// unavailable); fall back to the application bundle itself.
+		if inst := s.detectCaskApp(agentDef, brewMethod, packageName); inst != nil {
+			installations = append(installations, inst)
 		}
 	}
 
 	return installations, nil
 }
 
-// getInstalledFormulae retrieves installed Homebrew formulae.
-func (s *BrewStrategy) getInstalledFormulae(ctx context.Context) map[string]brewFormula {
-	formulae := make(map[string]brewFormula)
+// SetAppDirs overrides the application bundle scan directories. Primarily
+// for testing.
+func (s *BrewStrategy) SetAppDirs(dirs []string) {
+	s.appDirs = dirs
+}
+
+// detectCaskApp looks for a cask-installed application bundle in the
+// applications directories and maps it back to the agent.
+func (s *BrewStrategy) detectCaskApp(agentDef catalog.AgentDef, method catalog.InstallMethodDef, packageName string) *agent.Installation {
+	if !brewMethodIsCask(method) {
+		return nil
+	}
+
+	dirs := s.appDirs
+	if len(dirs) == 0 {
+		if s.platform.ID() != platform.Darwin {
+			return nil
+		}
+		dirs = []string{"/Applications"}
+		if home, err := os.UserHomeDir(); err == nil {
+			dirs = append(dirs, filepath.Join(home, "Applications"))
+		}
+	}
+
+	for _, dir := range dirs {
+		for _, name := range caskAppNames(method, packageName) {
+			appPath := filepath.Join(dir, name)
+			if info, err := os.Stat(appPath); err != nil || !info.IsDir() {
+				continue
+			}
 
-	cmd := exec.CommandContext(ctx, "brew", "info", "--installed", "--json=v2")
+			return &agent.Installation{
+				AgentID:          agentDef.ID,
+				AgentName:        agentDef.Name,
+				Method:           agent.MethodBrew,
+				InstalledVersion: readAppBundleVersion(appPath),
+				InstallPath:      appPath,
+				Metadata: map[string]string{
+					"detected_by":  "brew",
+					"package":      packageName,
+					"package_type": "cask",
+					"app_bundle":   appPath,
+				},
+			}
+		}
+	}
+
+	return nil
+}
+
+// brewMethodIsCask reports whether an install method refers to a cask.
+func brewMethodIsCask(method catalog.InstallMethodDef) bool {
+	if method.Metadata != nil && method.Metadata["type"] == "cask" {
+		return true
+	}
+	return strings.Contains(method.Command, "--cask")
+}
+
+// caskAppNames returns candidate application bundle names for a cask: an
+// explicit "app" metadata entry if present, otherwise the cask token with
+// each dash-separated word title-cased ("claude-desktop" -> "Claude
+// Desktop.app").
+func caskAppNames(method catalog.InstallMethodDef, packageName string) []string {
+	var names []string
+	if method.Metadata != nil && method.Metadata["app"] != "" {
+		name := method.Metadata["app"]
+		if !strings.HasSuffix(name, ".app") {
+			name += ".app"
+		}
+		names = append(names, name)
+	}
+
+	// Strip any tap prefix from the token
+	if idx := strings.LastIndex(packageName, "/"); idx >= 0 {
+		packageName = packageName[idx+1:]
+	}
+
+	words := strings.Split(packageName, "-")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + word[1:]
+		}
+	}
+	names = append(names, strings.Join(words, " ")+".app")
+
+	return names
+}
+
+// readAppBundleVersion reads CFBundleShortVersionString from an application
+// bundle's Info.plist. Only XML plists are supported; binary plists yield a
+// zero version.
+func readAppBundleVersion(appPath string) agent.Version {
+	data, err := os.ReadFile(filepath.Join(appPath, "Contents", "Info.plist"))
+	if err != nil {
+		return agent.Version{}
+	}
+
+	version, _ := agent.ParseVersion(readPlistString(data, "CFBundleShortVersionString"))
+	return version
+}
+
+// getInventory retrieves installed Homebrew formulae and casks with a
+// single brew invocation; the v2 JSON schema reports both.
+func (s *BrewStrategy) getInventory(ctx context.Context) (map[string]brewFormula, map[string]brewCask) {
+	cmd := exec.CommandContext(ctx, s.installers.BrewCommand(), "info", "--installed", "--json=v2")
 	output, err := cmd.Output()
 	if err != nil {
-		return formulae
+		return map[string]brewFormula{}, map[string]brewCask{}
 	}
 
+	return parseBrewFormulaeJSON(output), parseBrewCasksJSON(output)
+}
+
+// parseBrewFormulaeJSON parses `brew info --json=v2` output into a map
+// keyed by lowercase formula name and full name.
+func parseBrewFormulaeJSON(output []byte) map[string]brewFormula {
+	formulae := make(map[string]brewFormula)
+
 	var result struct {
 		Formulae []brewFormula `json:"formulae"`
 	}
@@ -148,16 +284,11 @@ func (s *BrewStrategy) getInstalledFormulae(ctx context.Context) map[string]brew
 	return formulae
 }
 
-// getInstalledCasks retrieves installed Homebrew casks.
-func (s *BrewStrategy) getInstalledCasks(ctx context.Context) map[string]brewCask {
+// parseBrewCasksJSON parses `brew info --json=v2` output into a map
+// keyed by lowercase cask token.
+func parseBrewCasksJSON(output []byte) map[string]brewCask {
 	casks := make(map[string]brewCask)
 
-	cmd := exec.CommandContext(ctx, "brew", "info", "--cask", "--installed", "--json=v2")
-	output, err := cmd.Output()
-	if err != nil {
-		return casks
-	}
-
 	var result struct {
 		Casks []brewCask `json:"casks"`
 	}
@@ -0,0 +1,136 @@
+package strategies
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestCaskAppNames(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      catalog.InstallMethodDef
+		packageName string
+		expected    []string
+	}{
+		{
+			name:        "derived from token",
+			method:      catalog.InstallMethodDef{},
+			packageName: "claude-desktop",
+			expected:    []string{"Claude Desktop.app"},
+		},
+		{
+			name:        "single word token",
+			method:      catalog.InstallMethodDef{},
+			packageName: "cursor",
+			expected:    []string{"Cursor.app"},
+		},
+		{
+			name:        "tap prefix stripped",
+			method:      catalog.InstallMethodDef{},
+			packageName: "someuser/tap/my-agent",
+			expected:    []string{"My Agent.app"},
+		},
+		{
+			name: "explicit app metadata first",
+			method: catalog.InstallMethodDef{
+				Metadata: map[string]string{"app": "Visual Studio Code"},
+			},
+			packageName: "visual-studio-code",
+			expected:    []string{"Visual Studio Code.app", "Visual Studio Code.app"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			names := caskAppNames(tt.method, tt.packageName)
+			if len(names) != len(tt.expected) {
+				t.Fatalf("caskAppNames() = %v, want %v", names, tt.expected)
+			}
+			for i, want := range tt.expected {
+				if names[i] != want {
+					t.Errorf("caskAppNames()[%d] = %q, want %q", i, names[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestBrewMethodIsCask(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   catalog.InstallMethodDef
+		expected bool
+	}{
+		{"formula", catalog.InstallMethodDef{Command: "brew install aider"}, false},
+		{"cask flag in command", catalog.InstallMethodDef{Command: "brew install --cask cursor"}, true},
+		{"cask metadata", catalog.InstallMethodDef{Metadata: map[string]string{"type": "cask"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := brewMethodIsCask(tt.method); got != tt.expected {
+				t.Errorf("brewMethodIsCask() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectCaskApp(t *testing.T) {
+	dir := t.TempDir()
+
+	appPath := filepath.Join(dir, "Claude Desktop.app")
+	if err := os.MkdirAll(filepath.Join(appPath, "Contents"), 0o755); err != nil {
+		t.Fatalf("failed to create app bundle: %v", err)
+	}
+
+	plist := `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>CFBundleShortVersionString</key>
+	<string>1.4.2</string>
+</dict>
+</plist>`
+	if err := os.WriteFile(filepath.Join(appPath, "Contents", "Info.plist"), []byte(plist), 0o644); err != nil {
+		t.Fatalf("failed to write Info.plist: %v", err)
+	}
+
+	s := NewBrewStrategy(platform.Current())
+	s.SetAppDirs([]string{dir})
+
+	agentDef := catalog.AgentDef{
+		ID:   "claude-desktop",
+		Name: "Claude Desktop",
+	}
+	method := catalog.InstallMethodDef{
+		Command:  "brew install --cask claude-desktop",
+		Metadata: map[string]string{"type": "cask"},
+	}
+
+	inst := s.detectCaskApp(agentDef, method, "claude-desktop")
+	if inst == nil {
+		t.Fatal("expected an installation, got nil")
+	}
+	if inst.InstallPath != appPath {
+		t.Errorf("InstallPath = %q, want %q", inst.InstallPath, appPath)
+	}
+	if got := inst.InstalledVersion.String(); got != "1.4.2" {
+		t.Errorf("InstalledVersion = %q, want 1.4.2", got)
+	}
+	if inst.Metadata["package_type"] != "cask" {
+		t.Errorf("package_type = %q, want cask", inst.Metadata["package_type"])
+	}
+
+	// A formula method must not match app bundles
+	if inst := s.detectCaskApp(agentDef, catalog.InstallMethodDef{Command: "brew install claude-desktop"}, "claude-desktop"); inst != nil {
+		t.Errorf("expected nil for formula method, got %+v", inst)
+	}
+
+	// Missing bundles are not detected
+	if inst := s.detectCaskApp(agentDef, method, "missing-app"); inst != nil {
+		t.Errorf("expected nil for missing bundle, got %+v", inst)
+	}
+}
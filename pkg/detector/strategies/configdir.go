@@ -0,0 +1,97 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// ConfigDirStrategy detects agents by the config directories they leave
+// behind (e.g. ~/.claude). An agent found only this way was likely
+// installed at some point and removed, so it is reported as configured
+// but not installed; the detector drops these hints for agents that any
+// other strategy finds installed.
+type ConfigDirStrategy struct {
+	platform platform.Platform
+
+	// homeDirOverride overrides the home directory (for testing).
+	homeDirOverride string
+}
+
+// NewConfigDirStrategy creates a new config-directory detection strategy.
+func NewConfigDirStrategy(p platform.Platform) *ConfigDirStrategy {
+	return &ConfigDirStrategy{platform: p}
+}
+
+// Name returns the strategy name.
+func (s *ConfigDirStrategy) Name() string {
+	return "configdir"
+}
+
+// Method returns the install method this strategy detects. Config-only
+// hits have no real installation; native is the closest classification.
+func (s *ConfigDirStrategy) Method() agent.InstallMethod {
+	return agent.MethodNative
+}
+
+// IsApplicable returns true; config directories exist on all platforms.
+func (s *ConfigDirStrategy) IsApplicable(p platform.Platform) bool {
+	return true
+}
+
+// Detect reports agents whose declared config directories exist.
+func (s *ConfigDirStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	var installations []*agent.Installation
+
+	for _, agentDef := range agents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, dir := range agentDef.Detection.ConfigDirs {
+			path := s.expandPath(dir)
+			if path == "" {
+				continue
+			}
+			info, err := os.Stat(path)
+			if err != nil || !info.IsDir() {
+				continue
+			}
+
+			installations = append(installations, &agent.Installation{
+				AgentID:        agentDef.ID,
+				AgentName:      agentDef.Name,
+				Method:         agent.MethodNative,
+				InstallPath:    path,
+				ConfiguredOnly: true,
+				Metadata: map[string]string{
+					"detected_by": "configdir",
+					"config_dir":  path,
+				},
+			})
+			break // One config dir is enough, move to next agent
+		}
+	}
+
+	return installations, nil
+}
+
+// expandPath expands a leading "~" to the user's home directory.
+func (s *ConfigDirStrategy) expandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		home := s.homeDirOverride
+		if home == "" {
+			var err error
+			if home, err = os.UserHomeDir(); err != nil {
+				return ""
+			}
+		}
+		return filepath.Join(home, strings.TrimPrefix(path, "~"))
+	}
+	return path
+}
@@ -0,0 +1,112 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestConfigDirStrategyDetect(t *testing.T) {
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".mytool"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewConfigDirStrategy(platform.Current())
+	s.homeDirOverride = home
+
+	agents := []catalog.AgentDef{
+		{
+			ID:   "mytool",
+			Name: "My Tool",
+			Detection: catalog.DetectionDef{
+				ConfigDirs: []string{"~/.mytool"},
+			},
+		},
+		{
+			ID:   "othertool",
+			Name: "Other Tool",
+			Detection: catalog.DetectionDef{
+				ConfigDirs: []string{"~/.othertool"},
+			},
+		},
+		{
+			ID:   "nohints",
+			Name: "No Hints",
+		},
+	}
+
+	installations, err := s.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+
+	if len(installations) != 1 {
+		t.Fatalf("Detect() found %d installations, want 1", len(installations))
+	}
+	inst := installations[0]
+	if inst.AgentID != "mytool" {
+		t.Errorf("AgentID = %q, want %q", inst.AgentID, "mytool")
+	}
+	if !inst.ConfiguredOnly {
+		t.Error("expected installation to be marked configured-only")
+	}
+	if want := filepath.Join(home, ".mytool"); inst.InstallPath != want {
+		t.Errorf("InstallPath = %q, want %q", inst.InstallPath, want)
+	}
+	if inst.Metadata["detected_by"] != "configdir" {
+		t.Errorf("detected_by = %q, want %q", inst.Metadata["detected_by"], "configdir")
+	}
+}
+
+func TestConfigDirStrategyIgnoresFiles(t *testing.T) {
+	home := t.TempDir()
+	// A plain file with the config dir's name is not a config dir
+	if err := os.WriteFile(filepath.Join(home, ".mytool"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewConfigDirStrategy(platform.Current())
+	s.homeDirOverride = home
+
+	agents := []catalog.AgentDef{
+		{
+			ID: "mytool",
+			Detection: catalog.DetectionDef{
+				ConfigDirs: []string{"~/.mytool"},
+			},
+		},
+	}
+
+	installations, err := s.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(installations) != 0 {
+		t.Errorf("Detect() found %d installations, want 0", len(installations))
+	}
+}
+
+func TestConfigDirExpandPath(t *testing.T) {
+	s := NewConfigDirStrategy(platform.Current())
+	s.homeDirOverride = "/home/user"
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "~/.mytool", want: filepath.Join("/home/user", ".mytool")},
+		{path: "~", want: "/home/user"},
+		{path: "/etc/mytool", want: "/etc/mytool"},
+	}
+
+	for _, tt := range tests {
+		if got := s.expandPath(tt.path); got != tt.want {
+			t.Errorf("expandPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package strategies
+
+import (
+	"os"
+	"os/exec"
+)
+
+// applyEnvOverrides appends environment overrides from the installers
+// configuration to a command, leaving the inherited environment
+// untouched when there are none.
+func applyEnvOverrides(cmd *exec.Cmd, overrides []string) {
+	if len(overrides) == 0 {
+		return
+	}
+	cmd.Env = append(os.Environ(), overrides...)
+}
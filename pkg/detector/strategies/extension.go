@@ -0,0 +1,204 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// ExtensionStrategy detects agents shipped as editor extensions
+// (VS Code extensions and JetBrains plugins).
+type ExtensionStrategy struct {
+	platform platform.Platform
+
+	// extensionDirs overrides the default search directories (for testing).
+	extensionDirs []string
+}
+
+// NewExtensionStrategy creates a new editor extension detection strategy.
+func NewExtensionStrategy(p platform.Platform) *ExtensionStrategy {
+	return &ExtensionStrategy{platform: p}
+}
+
+// Name returns the strategy name.
+func (s *ExtensionStrategy) Name() string {
+	return "extension"
+}
+
+// Method returns the install method this strategy detects.
+func (s *ExtensionStrategy) Method() agent.InstallMethod {
+	return agent.MethodExtension
+}
+
+// IsApplicable returns true if any known extension directory exists.
+func (s *ExtensionStrategy) IsApplicable(p platform.Platform) bool {
+	for _, dir := range s.searchDirs() {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetExtensionDirs overrides the search directories. Primarily for testing.
+func (s *ExtensionStrategy) SetExtensionDirs(dirs []string) {
+	s.extensionDirs = dirs
+}
+
+// searchDirs returns the extension directories to scan.
+func (s *ExtensionStrategy) searchDirs() []string {
+	if len(s.extensionDirs) > 0 {
+		return s.extensionDirs
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	dirs := []string{
+		filepath.Join(home, ".vscode", "extensions"),
+		filepath.Join(home, ".vscode-insiders", "extensions"),
+		filepath.Join(home, ".vscode-oss", "extensions"),
+		filepath.Join(home, ".cursor", "extensions"),
+	}
+
+	// JetBrains plugin directories are nested per-product per-version.
+	switch runtime.GOOS {
+	case "darwin":
+		dirs = append(dirs, filepath.Join(home, "Library", "Application Support", "JetBrains"))
+	case "windows":
+		if appData := os.Getenv("APPDATA"); appData != "" {
+			dirs = append(dirs, filepath.Join(appData, "JetBrains"))
+		}
+	default:
+		dirs = append(dirs, filepath.Join(home, ".local", "share", "JetBrains"))
+	}
+
+	return dirs
+}
+
+// Detect scans extension directories for catalog agents with an
+// "extension" install method.
+func (s *ExtensionStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	installed := s.scanInstalledExtensions()
+	if len(installed) == 0 {
+		return nil, nil
+	}
+
+	var installations []*agent.Installation
+
+	for _, agentDef := range agents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		method, hasExtension := agentDef.InstallMethods["extension"]
+		if !hasExtension {
+			continue
+		}
+
+		extensionID := strings.ToLower(method.Package)
+		if extensionID == "" {
+			continue
+		}
+
+		found, ok := installed[extensionID]
+		if !ok {
+			continue
+		}
+
+		version := parseAgentVersion(agentDef, found.version)
+
+		inst := &agent.Installation{
+			AgentID:          agentDef.ID,
+			AgentName:        agentDef.Name,
+			Method:           agent.MethodExtension,
+			InstalledVersion: version,
+			InstallPath:      found.path,
+			Metadata: map[string]string{
+				"detected_by":  "extension",
+				"extension_id": method.Package,
+			},
+		}
+
+		installations = append(installations, inst)
+	}
+
+	return installations, nil
+}
+
+// installedExtension records a found extension directory.
+type installedExtension struct {
+	version string
+	path    string
+}
+
+// scanInstalledExtensions walks the extension directories and returns a map
+// of lowercase extension ID to install info.
+func (s *ExtensionStrategy) scanInstalledExtensions() map[string]installedExtension {
+	found := make(map[string]installedExtension)
+
+	for _, dir := range s.searchDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+
+			id, version, ok := parseExtensionDirName(entry.Name())
+			if !ok {
+				continue
+			}
+
+			// Prefer the highest version when multiple copies exist
+			if existing, dup := found[id]; dup {
+				existingVer, _ := agent.ParseVersion(existing.version)
+				newVer, _ := agent.ParseVersion(version)
+				if !newVer.IsNewerThan(existingVer) {
+					continue
+				}
+			}
+
+			found[id] = installedExtension{
+				version: version,
+				path:    filepath.Join(dir, entry.Name()),
+			}
+		}
+	}
+
+	return found
+}
+
+// parseExtensionDirName parses a VS Code extension directory name of the
+// form "publisher.name-1.2.3" into its ID and version.
+func parseExtensionDirName(name string) (id, version string, ok bool) {
+	// Extension IDs contain a dot separating publisher and name
+	if !strings.Contains(name, ".") {
+		return "", "", false
+	}
+
+	// The version starts at the last dash followed by a digit
+	idx := -1
+	for i := len(name) - 1; i > 0; i-- {
+		if name[i] == '-' && i+1 < len(name) && name[i+1] >= '0' && name[i+1] <= '9' {
+			idx = i
+			break
+		}
+	}
+	if idx <= 0 {
+		return "", "", false
+	}
+
+	return strings.ToLower(name[:idx]), name[idx+1:], true
+}
@@ -0,0 +1,99 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func TestParseExtensionDirName(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantID      string
+		wantVersion string
+		wantOK      bool
+	}{
+		{"github.copilot-1.156.0", "github.copilot", "1.156.0", true},
+		{"Continue.continue-0.8.52", "continue.continue", "0.8.52", true},
+		{"sourcegraph.cody-ai-1.2.3", "sourcegraph.cody-ai", "1.2.3", true},
+		{"ms-python.python-2024.0.1", "ms-python.python", "2024.0.1", true},
+		{"extensions.json", "", "", false},
+		{"no-dot-here", "", "", false},
+		{".obsolete", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, version, ok := parseExtensionDirName(tt.name)
+			if ok != tt.wantOK {
+				t.Fatalf("parseExtensionDirName(%q) ok = %v, want %v", tt.name, ok, tt.wantOK)
+			}
+			if id != tt.wantID {
+				t.Errorf("id = %q, want %q", id, tt.wantID)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("version = %q, want %q", version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestExtensionStrategyDetect(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "github.copilot-1.156.0"), 0o755); err != nil {
+		t.Fatalf("failed to create extension dir: %v", err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "github.copilot-1.155.0"), 0o755); err != nil {
+		t.Fatalf("failed to create extension dir: %v", err)
+	}
+
+	s := NewExtensionStrategy(newMockPlatform())
+	s.SetExtensionDirs([]string{dir})
+
+	agents := []catalog.AgentDef{
+		{
+			ID:   "copilot-extension",
+			Name: "GitHub Copilot",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"extension": {
+					Method:    "extension",
+					Package:   "GitHub.copilot",
+					Platforms: []string{"darwin", "linux", "windows"},
+				},
+			},
+		},
+		{
+			ID:   "not-installed",
+			Name: "Not Installed",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"extension": {
+					Method:    "extension",
+					Package:   "example.missing",
+					Platforms: []string{"darwin", "linux", "windows"},
+				},
+			},
+		},
+	}
+
+	installations, err := s.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(installations) != 1 {
+		t.Fatalf("expected 1 installation, got %d", len(installations))
+	}
+
+	inst := installations[0]
+	if inst.AgentID != "copilot-extension" {
+		t.Errorf("expected agent ID copilot-extension, got %s", inst.AgentID)
+	}
+	if inst.InstalledVersion.String() != "1.156.0" {
+		t.Errorf("expected newest version 1.156.0, got %s", inst.InstalledVersion)
+	}
+	if inst.Metadata["extension_id"] != "GitHub.copilot" {
+		t.Errorf("expected extension_id metadata, got %v", inst.Metadata)
+	}
+}
@@ -0,0 +1,197 @@
+package strategies
+
+import (
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+)
+
+// Golden tests parsing recorded package manager outputs from
+// pkg/agentmgrtest/fixtures. These pin the parsers to real-world output
+// shapes across tool versions; a failure here means a parser regression,
+// not a fixture problem.
+
+func TestParseNPMListJSONFixtures(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected map[string]npmPackage
+	}{
+		{
+			name:    "npm 10",
+			fixture: agentmgrtest.FixtureNPMListV10,
+			expected: map[string]npmPackage{
+				"@anthropic-ai/claude-code": {Version: "1.0.24"},
+				"@google/gemini-cli":        {Version: "0.1.9"},
+				"corepack":                  {Version: "0.29.3"},
+				"npm":                       {Version: "10.8.2"},
+			},
+		},
+		{
+			name:    "npm 8",
+			fixture: agentmgrtest.FixtureNPMListV8,
+			expected: map[string]npmPackage{
+				"@anthropic-ai/claude-code": {Version: "0.2.41"},
+				"npm":                       {Version: "8.19.4"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packages, err := parseNPMListJSON(agentmgrtest.Fixture(tt.fixture))
+			if err != nil {
+				t.Fatalf("parseNPMListJSON: %v", err)
+			}
+			if len(packages) != len(tt.expected) {
+				t.Fatalf("expected %d packages, got %d", len(tt.expected), len(packages))
+			}
+			for name, want := range tt.expected {
+				got, ok := packages[name]
+				if !ok {
+					t.Errorf("missing package %q", name)
+					continue
+				}
+				if got.Version != want.Version {
+					t.Errorf("package %q: expected version %q, got %q", name, want.Version, got.Version)
+				}
+			}
+		})
+	}
+}
+
+func TestParsePipListJSONFixture(t *testing.T) {
+	packages := parsePipListJSON(agentmgrtest.Fixture(agentmgrtest.FixturePipListV24))
+
+	expected := map[string]string{
+		"aider-chat":       "0.45.1",
+		"open-interpreter": "0.3.7",
+		"pip":              "24.2",
+		"setuptools":       "72.1.0",
+	}
+	if len(packages) != len(expected) {
+		t.Fatalf("expected %d packages, got %d", len(expected), len(packages))
+	}
+	for name, version := range expected {
+		pkg, ok := packages[name]
+		if !ok {
+			t.Errorf("missing package %q", name)
+			continue
+		}
+		if pkg.Version != version {
+			t.Errorf("package %q: expected version %q, got %q", name, version, pkg.Version)
+		}
+	}
+}
+
+func TestParsePipxListJSONFixture(t *testing.T) {
+	packages := parsePipxListJSON(agentmgrtest.Fixture(agentmgrtest.FixturePipxListV1))
+
+	expected := map[string]string{
+		"aider-chat":   "0.59.0",
+		"gpt-engineer": "0.3.1",
+	}
+	if len(packages) != len(expected) {
+		t.Fatalf("expected %d venvs, got %d", len(expected), len(packages))
+	}
+	for name, version := range expected {
+		pkg, ok := packages[name]
+		if !ok {
+			t.Errorf("missing venv %q (names should be lowercased)", name)
+			continue
+		}
+		if pkg.Metadata.MainPackage.PackageVersion != version {
+			t.Errorf("venv %q: expected version %q, got %q", name, version, pkg.Metadata.MainPackage.PackageVersion)
+		}
+	}
+}
+
+func TestParseUVToolListFixtures(t *testing.T) {
+	expected := map[string]string{
+		"aider-chat": "0.60.1",
+		"ruff":       "0.6.8",
+	}
+
+	check := func(t *testing.T, packages map[string]pipPackage) {
+		t.Helper()
+		if len(packages) != len(expected) {
+			t.Fatalf("expected %d tools, got %d", len(expected), len(packages))
+		}
+		for name, version := range expected {
+			pkg, ok := packages[name]
+			if !ok {
+				t.Errorf("missing tool %q", name)
+				continue
+			}
+			if pkg.Version != version {
+				t.Errorf("tool %q: expected version %q, got %q", name, version, pkg.Version)
+			}
+		}
+	}
+
+	t.Run("json format", func(t *testing.T) {
+		check(t, parseUVToolListJSON(agentmgrtest.Fixture(agentmgrtest.FixtureUVToolListJSON)))
+	})
+
+	t.Run("text format", func(t *testing.T) {
+		s := &PipStrategy{}
+		check(t, s.parseUVTextOutput(string(agentmgrtest.Fixture(agentmgrtest.FixtureUVToolListText))))
+	})
+}
+
+func TestParseBrewInfoV2Fixture(t *testing.T) {
+	fixture := agentmgrtest.Fixture(agentmgrtest.FixtureBrewInfoV2)
+
+	formulae := parseBrewFormulaeJSON(fixture)
+	// goose is keyed under both its name and its tap-qualified full name.
+	if len(formulae) != 3 {
+		t.Fatalf("expected 3 formula keys, got %d", len(formulae))
+	}
+	goose, ok := formulae["goose"]
+	if !ok {
+		t.Fatal("missing formula goose")
+	}
+	if len(goose.InstalledVersions) != 1 || goose.InstalledVersions[0] != "1.0.4" {
+		t.Errorf("goose: expected installed [1.0.4], got %v", goose.InstalledVersions)
+	}
+	if goose.Versions.Stable != "1.0.7" {
+		t.Errorf("goose: expected stable 1.0.7, got %q", goose.Versions.Stable)
+	}
+	if tapped, ok := formulae["block-goose/tap/goose"]; !ok {
+		t.Error("missing full-name key block-goose/tap/goose")
+	} else if tapped.Name != "goose" {
+		t.Errorf("full-name key: expected formula goose, got %q", tapped.Name)
+	}
+	if gh, ok := formulae["gh"]; !ok {
+		t.Error("missing formula gh")
+	} else if gh.Versions.Stable != "2.55.0" {
+		t.Errorf("gh: expected stable 2.55.0, got %q", gh.Versions.Stable)
+	}
+
+	casks := parseBrewCasksJSON(fixture)
+	if len(casks) != 1 {
+		t.Fatalf("expected 1 cask, got %d", len(casks))
+	}
+	cursor, ok := casks["cursor"]
+	if !ok {
+		t.Fatal("missing cask cursor")
+	}
+	if cursor.InstalledVersion != "0.42.3" {
+		t.Errorf("cursor: expected installed 0.42.3, got %q", cursor.InstalledVersion)
+	}
+	if cursor.Version != "0.42.5" {
+		t.Errorf("cursor: expected version 0.42.5, got %q", cursor.Version)
+	}
+}
+
+func TestFixtureNamesComplete(t *testing.T) {
+	names := agentmgrtest.FixtureNames()
+	if len(names) == 0 {
+		t.Fatal("expected fixtures to be embedded")
+	}
+	for _, name := range names {
+		if len(agentmgrtest.Fixture(name)) == 0 {
+			t.Errorf("fixture %q is empty", name)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package strategies
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// FlatpakStrategy detects agents installed via Flatpak.
+type FlatpakStrategy struct {
+	platform platform.Platform
+}
+
+// NewFlatpakStrategy creates a new Flatpak detection strategy.
+func NewFlatpakStrategy(p platform.Platform) *FlatpakStrategy {
+	return &FlatpakStrategy{platform: p}
+}
+
+// Name returns the strategy name.
+func (s *FlatpakStrategy) Name() string {
+	return "flatpak"
+}
+
+// Method returns the install method this strategy detects.
+func (s *FlatpakStrategy) Method() agent.InstallMethod {
+	return agent.MethodFlatpak
+}
+
+// IsApplicable returns true if flatpak is available (Linux).
+func (s *FlatpakStrategy) IsApplicable(p platform.Platform) bool {
+	return p.ID() == platform.Linux && p.IsExecutableInPath("flatpak")
+}
+
+// Detect scans for flatpak-installed agents.
+func (s *FlatpakStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	installed := s.listInstalled(ctx)
+	if len(installed) == 0 {
+		return nil, nil
+	}
+
+	var installations []*agent.Installation
+
+	for _, agentDef := range agents {
+		method, hasFlatpak := agentDef.InstallMethods["flatpak"]
+		if !hasFlatpak || method.Package == "" {
+			continue
+		}
+
+		versionStr, found := installed[strings.ToLower(method.Package)]
+		if !found {
+			continue
+		}
+
+		version := parseAgentVersion(agentDef, versionStr)
+
+		installations = append(installations, &agent.Installation{
+			AgentID:          agentDef.ID,
+			AgentName:        agentDef.Name,
+			Method:           agent.MethodFlatpak,
+			InstalledVersion: version,
+			Metadata: map[string]string{
+				"detected_by":    "flatpak",
+				"application_id": method.Package,
+			},
+		})
+	}
+
+	return installations, nil
+}
+
+// listInstalled returns installed flatpak applications as a map of
+// lowercase application ID to version.
+func (s *FlatpakStrategy) listInstalled(ctx context.Context) map[string]string {
+	output, err := exec.CommandContext(ctx, "flatpak", "list", "--app", "--columns=application,version").Output()
+	if err != nil {
+		return nil
+	}
+	return parseFlatpakList(string(output))
+}
+
+// parseFlatpakList parses the tab-separated output of
+// `flatpak list --app --columns=application,version`.
+func parseFlatpakList(output string) map[string]string {
+	installed := make(map[string]string)
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, "\t")
+		appID := strings.TrimSpace(fields[0])
+		if appID == "" {
+			continue
+		}
+
+		var version string
+		if len(fields) > 1 {
+			version = strings.TrimSpace(fields[1])
+		}
+		installed[strings.ToLower(appID)] = version
+	}
+
+	return installed
+}
@@ -0,0 +1,28 @@
+package strategies
+
+import "testing"
+
+func TestParseFlatpakList(t *testing.T) {
+	output := "com.example.Agent\t1.2.3\n" +
+		"org.gnome.Calculator\t46.1\n" +
+		"io.github.NoVersion\t\n" +
+		"\n"
+
+	installed := parseFlatpakList(output)
+
+	if len(installed) != 3 {
+		t.Fatalf("expected 3 apps, got %d: %v", len(installed), installed)
+	}
+	if installed["com.example.agent"] != "1.2.3" {
+		t.Errorf("expected com.example.agent 1.2.3, got %q", installed["com.example.agent"])
+	}
+	if version, ok := installed["io.github.noversion"]; !ok || version != "" {
+		t.Errorf("expected io.github.noversion with empty version, got %q (ok=%v)", version, ok)
+	}
+}
+
+func TestParseFlatpakListEmpty(t *testing.T) {
+	if installed := parseFlatpakList(""); len(installed) != 0 {
+		t.Errorf("expected no apps, got %v", installed)
+	}
+}
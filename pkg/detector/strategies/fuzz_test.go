@@ -0,0 +1,90 @@
+package strategies
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// These parsers chew on untrusted command output; the fuzz targets check
+// they never panic and that anything they do return is well-formed.
+
+var fuzzVersionShape = regexp.MustCompile(`^\d+\.\d+\.\d+(?:-[a-zA-Z0-9.]+)?$`)
+
+func FuzzExtractVersionFromOutput(f *testing.F) {
+	seeds := []string{
+		"v1.2.3",
+		"mytool version 2.10.0",
+		"\x1b[32mtool 1.0.0\x1b[0m ready",
+		"\x1b[38;5;214mcolored\x1b[0m",
+		"no version here",
+		"1.2.3-beta.1 (build 42)",
+		"версия 3.4.5 — こんにちは",
+		strings.Repeat("x", 100_000) + " 9.9.9",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, output string) {
+		got := extractVersionFromOutput(output)
+		if got == "" {
+			return
+		}
+		if !fuzzVersionShape.MatchString(got) {
+			t.Errorf("extractVersionFromOutput(%q) = %q, not a version", output, got)
+		}
+	})
+}
+
+func FuzzExtractNPMPackageName(f *testing.F) {
+	seeds := []string{
+		"npm install -g @scope/package",
+		"npm i -g package@1.2.3",
+		"npm install --global --force pkg",
+		"npm install -g",
+		"npm install -g \x1b[1m@weird/name\x1b[0m",
+		"npm i -g " + strings.Repeat("@", 10_000),
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, command string) {
+		got := extractNPMPackageName(command)
+		if got == "" {
+			return
+		}
+		if strings.ContainsAny(got, " \t\n") {
+			t.Errorf("extractNPMPackageName(%q) = %q, contains whitespace", command, got)
+		}
+		if strings.HasPrefix(got, "-") {
+			t.Errorf("extractNPMPackageName(%q) = %q, looks like a flag", command, got)
+		}
+	})
+}
+
+func FuzzExtractPipPackageName(f *testing.F) {
+	seeds := []struct{ packageField, command string }{
+		{"aider-chat", ""},
+		{"", "pip install aider-chat"},
+		{"", "pipx install gpt-engineer==0.3.1"},
+		{"", "uv tool install ruff>=0.6"},
+		{"", "pip install --upgrade"},
+		{"", "pip install \x1b[31mred\x1b[0m"},
+		{"", strings.Repeat("install ", 50_000)},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.packageField, seed.command)
+	}
+
+	f.Fuzz(func(t *testing.T, packageField, command string) {
+		got := extractPipPackageName(packageField, command)
+		if packageField != "" && got != packageField {
+			t.Errorf("extractPipPackageName(%q, %q) = %q, expected the explicit package field", packageField, command, got)
+		}
+		if got != "" && strings.ContainsAny(got, " \t\n") {
+			t.Errorf("extractPipPackageName(%q, %q) = %q, contains whitespace", packageField, command, got)
+		}
+	})
+}
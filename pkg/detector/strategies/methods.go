@@ -0,0 +1,17 @@
+package strategies
+
+import "github.com/kevinelliott/agentmgr/pkg/catalog"
+
+// catalogUsesMethod reports whether any catalog agent declares one of the
+// named install methods. Strategies use it to skip package-manager
+// inventory commands nothing in the catalog would match against.
+func catalogUsesMethod(agents []catalog.AgentDef, names ...string) bool {
+	for _, agentDef := range agents {
+		for _, name := range names {
+			if _, ok := agentDef.InstallMethods[name]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
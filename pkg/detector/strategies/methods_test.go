@@ -0,0 +1,82 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
+)
+
+func TestCatalogUsesMethod(t *testing.T) {
+	agents := []catalog.AgentDef{
+		{
+			ID: "npm-agent",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"npm": {Package: "some-package"},
+			},
+		},
+		{
+			ID: "pip-agent",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"pip": {Package: "some-package"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		methods  []string
+		expected bool
+	}{
+		{"single match", []string{"npm"}, true},
+		{"any of several", []string{"pipx", "pip"}, true},
+		{"no match", []string{"brew", "uv"}, false},
+		{"empty names", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := catalogUsesMethod(agents, tt.methods...); got != tt.expected {
+				t.Errorf("catalogUsesMethod(%v) = %v, want %v", tt.methods, got, tt.expected)
+			}
+		})
+	}
+
+	if catalogUsesMethod(nil, "npm") {
+		t.Error("catalogUsesMethod with no agents should be false")
+	}
+}
+
+func TestNPMStrategySkipsListWithoutNPMAgents(t *testing.T) {
+	fake := &execx.Fake{Handler: func(name string, args []string) (*execx.Result, error) {
+		return &execx.Result{Stdout: "{}"}, nil
+	}}
+	orig := execx.Default
+	execx.Default = fake
+	defer func() { execx.Default = orig }()
+
+	plat := newMockPlatform()
+	plat.executables["npm"] = "/usr/local/bin/npm"
+	strategy := NewNPMStrategy(plat)
+
+	agents := []catalog.AgentDef{
+		{
+			ID: "brew-only",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"brew": {Package: "some-formula"},
+			},
+		},
+	}
+
+	installations, err := strategy.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(installations) != 0 {
+		t.Errorf("expected no installations, got %d", len(installations))
+	}
+	if calls := fake.Calls(); len(calls) != 0 {
+		t.Errorf("expected no npm invocations, got %d", len(calls))
+	}
+}
@@ -0,0 +1,228 @@
+package strategies
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// MiseStrategy detects agents whose CLIs are managed by the mise or asdf
+// version managers. Both keep one install directory per tool version, so a
+// single tool can be reported at several versions.
+type MiseStrategy struct {
+	platform platform.Platform
+}
+
+// NewMiseStrategy creates a new mise/asdf detection strategy.
+func NewMiseStrategy(p platform.Platform) *MiseStrategy {
+	return &MiseStrategy{platform: p}
+}
+
+// Name returns the strategy name.
+func (s *MiseStrategy) Name() string {
+	return "mise"
+}
+
+// Method returns the install method this strategy detects.
+func (s *MiseStrategy) Method() agent.InstallMethod {
+	return agent.MethodMise
+}
+
+// IsApplicable returns true if mise or asdf is available.
+func (s *MiseStrategy) IsApplicable(p platform.Platform) bool {
+	return p.IsExecutableInPath("mise") || p.IsExecutableInPath("asdf")
+}
+
+// managedTool records one installed version of a mise/asdf-managed tool.
+type managedTool struct {
+	version     string
+	installPath string
+	manager     string // "mise" or "asdf"
+}
+
+// Detect scans mise and asdf for installed tool versions matching catalog
+// agents.
+func (s *MiseStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	tools := make(map[string][]managedTool)
+	if s.platform.IsExecutableInPath("mise") {
+		mergeManagedTools(tools, s.listMiseTools(ctx))
+	}
+	if s.platform.IsExecutableInPath("asdf") {
+		mergeManagedTools(tools, s.listAsdfTools(ctx))
+	}
+	if len(tools) == 0 {
+		return nil, nil
+	}
+
+	var installations []*agent.Installation
+
+	for _, agentDef := range agents {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		name := toolNameForAgent(agentDef)
+		if name == "" {
+			continue
+		}
+
+		for _, tool := range tools[name] {
+			version := parseAgentVersion(agentDef, tool.version)
+
+			installations = append(installations, &agent.Installation{
+				AgentID:          agentDef.ID,
+				AgentName:        agentDef.Name,
+				Method:           agent.MethodMise,
+				InstalledVersion: version,
+				InstallPath:      tool.installPath,
+				Metadata: map[string]string{
+					"detected_by": "mise",
+					"manager":     tool.manager,
+					"tool":        name,
+				},
+			})
+		}
+	}
+
+	return installations, nil
+}
+
+// listMiseTools returns installed tool versions reported by `mise ls`.
+func (s *MiseStrategy) listMiseTools(ctx context.Context) map[string][]managedTool {
+	output, err := exec.CommandContext(ctx, "mise", "ls", "--json").Output()
+	if err != nil {
+		return nil
+	}
+	return parseMiseList(output)
+}
+
+// listAsdfTools returns installed tool versions reported by `asdf list`,
+// resolving each version to its install directory under the asdf data dir.
+func (s *MiseStrategy) listAsdfTools(ctx context.Context) map[string][]managedTool {
+	output, err := exec.CommandContext(ctx, "asdf", "list").Output()
+	if err != nil {
+		return nil
+	}
+	return parseAsdfList(string(output), asdfDataDir())
+}
+
+// parseMiseList parses the JSON output of `mise ls --json`, a map of tool
+// name to installed version entries.
+func parseMiseList(output []byte) map[string][]managedTool {
+	var listed map[string][]struct {
+		Version     string `json:"version"`
+		InstallPath string `json:"install_path"`
+		Installed   *bool  `json:"installed"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil {
+		return nil
+	}
+
+	tools := make(map[string][]managedTool)
+	for name, versions := range listed {
+		for _, v := range versions {
+			if v.Version == "" || (v.Installed != nil && !*v.Installed) {
+				continue
+			}
+			tools[strings.ToLower(name)] = append(tools[strings.ToLower(name)], managedTool{
+				version:     v.Version,
+				installPath: v.InstallPath,
+				manager:     "mise",
+			})
+		}
+	}
+	return tools
+}
+
+// parseAsdfList parses the plain-text output of `asdf list`: plugin names
+// at the start of a line, installed versions indented beneath them (the
+// currently selected version is prefixed with an asterisk).
+func parseAsdfList(output, dataDir string) map[string][]managedTool {
+	tools := make(map[string][]managedTool)
+
+	var plugin string
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		// Unindented lines name the plugin; indented lines are versions.
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			plugin = strings.ToLower(trimmed)
+			continue
+		}
+		if plugin == "" {
+			continue
+		}
+
+		version := strings.TrimSpace(strings.TrimPrefix(trimmed, "*"))
+		if version == "" || strings.HasPrefix(version, "No ") {
+			continue // "No versions installed" placeholder
+		}
+
+		var installPath string
+		if dataDir != "" {
+			installPath = filepath.Join(dataDir, "installs", plugin, version)
+		}
+
+		tools[plugin] = append(tools[plugin], managedTool{
+			version:     version,
+			installPath: installPath,
+			manager:     "asdf",
+		})
+	}
+
+	return tools
+}
+
+// asdfDataDir returns the asdf data directory holding per-version installs.
+func asdfDataDir() string {
+	if dir := os.Getenv("ASDF_DATA_DIR"); dir != "" {
+		return dir
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".asdf")
+}
+
+// mergeManagedTools merges found tools into dst, skipping versions dst
+// already has for a tool.
+func mergeManagedTools(dst map[string][]managedTool, src map[string][]managedTool) {
+	for name, found := range src {
+		for _, tool := range found {
+			duplicate := false
+			for _, existing := range dst[name] {
+				if existing.version == tool.version {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				dst[name] = append(dst[name], tool)
+			}
+		}
+	}
+}
+
+// toolNameForAgent returns the mise/asdf tool name for a catalog agent: the
+// package of its "mise" install method if defined, otherwise its first
+// detection executable.
+func toolNameForAgent(agentDef catalog.AgentDef) string {
+	if method, ok := agentDef.InstallMethods["mise"]; ok && method.Package != "" {
+		return strings.ToLower(method.Package)
+	}
+	if len(agentDef.Detection.Executables) > 0 {
+		return strings.ToLower(agentDef.Detection.Executables[0])
+	}
+	return ""
+}
@@ -0,0 +1,98 @@
+package strategies
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestParseMiseList(t *testing.T) {
+	output := []byte(`{
+		"node": [
+			{"version": "20.11.0", "install_path": "/home/u/.local/share/mise/installs/node/20.11.0"},
+			{"version": "22.0.0", "install_path": "/home/u/.local/share/mise/installs/node/22.0.0"}
+		],
+		"aider": [
+			{"version": "0.50.1", "install_path": "/home/u/.local/share/mise/installs/aider/0.50.1"}
+		]
+	}`)
+
+	tools := parseMiseList(output)
+
+	if len(tools["node"]) != 2 {
+		t.Fatalf("expected 2 node versions, got %d", len(tools["node"]))
+	}
+	if tools["node"][0].version != "20.11.0" {
+		t.Errorf("expected version 20.11.0, got %s", tools["node"][0].version)
+	}
+	if tools["node"][0].manager != "mise" {
+		t.Errorf("expected manager mise, got %s", tools["node"][0].manager)
+	}
+
+	aider := tools["aider"]
+	if len(aider) != 1 || aider[0].installPath != "/home/u/.local/share/mise/installs/aider/0.50.1" {
+		t.Errorf("unexpected aider entries: %+v", aider)
+	}
+}
+
+func TestParseMiseListInvalid(t *testing.T) {
+	if tools := parseMiseList([]byte("not json")); tools != nil {
+		t.Errorf("expected nil for invalid JSON, got %v", tools)
+	}
+}
+
+func TestParseAsdfList(t *testing.T) {
+	output := `nodejs
+  20.11.0
+ *22.0.0
+aider
+  0.50.1
+golang
+  No versions installed
+`
+
+	dataDir := filepath.Join("home", ".asdf")
+	tools := parseAsdfList(output, dataDir)
+
+	if len(tools["nodejs"]) != 2 {
+		t.Fatalf("expected 2 nodejs versions, got %d", len(tools["nodejs"]))
+	}
+	// The asterisk marking the selected version is stripped
+	if tools["nodejs"][1].version != "22.0.0" {
+		t.Errorf("expected version 22.0.0, got %s", tools["nodejs"][1].version)
+	}
+	if tools["nodejs"][0].manager != "asdf" {
+		t.Errorf("expected manager asdf, got %s", tools["nodejs"][0].manager)
+	}
+
+	wantPath := filepath.Join(dataDir, "installs", "aider", "0.50.1")
+	if got := tools["aider"][0].installPath; got != wantPath {
+		t.Errorf("expected install path %s, got %s", wantPath, got)
+	}
+
+	if _, ok := tools["golang"]; ok {
+		t.Error("expected golang with no installed versions to be skipped")
+	}
+}
+
+func TestMergeManagedTools(t *testing.T) {
+	dst := map[string][]managedTool{
+		"node": {{version: "20.0.0", manager: "mise"}},
+	}
+	src := map[string][]managedTool{
+		"node":  {{version: "20.0.0", manager: "asdf"}, {version: "18.0.0", manager: "asdf"}},
+		"aider": {{version: "0.50.1", manager: "asdf"}},
+	}
+
+	mergeManagedTools(dst, src)
+
+	if len(dst["node"]) != 2 {
+		t.Fatalf("expected 2 node versions after merge, got %d", len(dst["node"]))
+	}
+	// The duplicate 20.0.0 keeps its original manager
+	if dst["node"][0].manager != "mise" {
+		t.Errorf("expected duplicate version to keep mise manager, got %s", dst["node"][0].manager)
+	}
+	if len(dst["aider"]) != 1 {
+		t.Errorf("expected aider to be merged in, got %v", dst["aider"])
+	}
+}
@@ -0,0 +1,79 @@
+package strategies
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// nodeRoot is one Node installation managed by a version manager.
+type nodeRoot struct {
+	manager string
+	version string
+	npmPath string
+}
+
+// nodeVersionRoots enumerates the Node installations managed by nvm,
+// fnm and volta on this machine.
+func nodeVersionRoots() []nodeRoot {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+
+	dirFor := func(envVar, fallback string) string {
+		if dir := os.Getenv(envVar); dir != "" {
+			return dir
+		}
+		if home == "" {
+			return ""
+		}
+		return filepath.Join(home, fallback)
+	}
+
+	var roots []nodeRoot
+
+	// nvm: $NVM_DIR/versions/node/<version>/bin/npm
+	if dir := dirFor("NVM_DIR", ".nvm"); dir != "" {
+		roots = append(roots, scanNodeRoot("nvm", filepath.Join(dir, "versions", "node"), "bin")...)
+	}
+
+	// fnm: $FNM_DIR/node-versions/<version>/installation/bin/npm
+	if dir := dirFor("FNM_DIR", filepath.Join(".local", "share", "fnm")); dir != "" {
+		roots = append(roots, scanNodeRoot("fnm", filepath.Join(dir, "node-versions"), filepath.Join("installation", "bin"))...)
+	}
+
+	// volta: $VOLTA_HOME/tools/image/node/<version>/bin/npm
+	if dir := dirFor("VOLTA_HOME", ".volta"); dir != "" {
+		roots = append(roots, scanNodeRoot("volta", filepath.Join(dir, "tools", "image", "node"), "bin")...)
+	}
+
+	return roots
+}
+
+// scanNodeRoot lists version directories under base and keeps the ones
+// with an npm binary.
+func scanNodeRoot(manager, base, binSubdir string) []nodeRoot {
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return nil
+	}
+
+	var roots []nodeRoot
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		npmPath := filepath.Join(base, entry.Name(), binSubdir, "npm")
+		if _, err := os.Stat(npmPath); err != nil {
+			continue
+		}
+
+		roots = append(roots, nodeRoot{
+			manager: manager,
+			version: entry.Name(),
+			npmPath: npmPath,
+		})
+	}
+	return roots
+}
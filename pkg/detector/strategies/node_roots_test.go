@@ -0,0 +1,200 @@
+package strategies
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
+)
+
+// writeNPMStub creates a fake npm binary inside dir.
+func writeNPMStub(t *testing.T, dir string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create %s: %v", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "npm"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write npm stub: %v", err)
+	}
+}
+
+func TestNodeVersionRoots(t *testing.T) {
+	nvmDir := t.TempDir()
+	fnmDir := t.TempDir()
+	voltaDir := t.TempDir()
+	t.Setenv("NVM_DIR", nvmDir)
+	t.Setenv("FNM_DIR", fnmDir)
+	t.Setenv("VOLTA_HOME", voltaDir)
+
+	writeNPMStub(t, filepath.Join(nvmDir, "versions", "node", "v20.11.0", "bin"))
+	writeNPMStub(t, filepath.Join(nvmDir, "versions", "node", "v18.19.0", "bin"))
+	writeNPMStub(t, filepath.Join(fnmDir, "node-versions", "v21.6.0", "installation", "bin"))
+	writeNPMStub(t, filepath.Join(voltaDir, "tools", "image", "node", "20.11.0", "bin"))
+
+	// A version dir without npm is skipped
+	if err := os.MkdirAll(filepath.Join(nvmDir, "versions", "node", "v16.0.0", "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	roots := nodeVersionRoots()
+	if len(roots) != 4 {
+		t.Fatalf("expected 4 roots, got %d: %+v", len(roots), roots)
+	}
+
+	byManager := make(map[string][]nodeRoot)
+	for _, root := range roots {
+		byManager[root.manager] = append(byManager[root.manager], root)
+		if _, err := os.Stat(root.npmPath); err != nil {
+			t.Errorf("root %+v has missing npm binary", root)
+		}
+	}
+
+	if len(byManager["nvm"]) != 2 {
+		t.Errorf("expected 2 nvm roots, got %+v", byManager["nvm"])
+	}
+	if len(byManager["fnm"]) != 1 || byManager["fnm"][0].version != "v21.6.0" {
+		t.Errorf("unexpected fnm roots: %+v", byManager["fnm"])
+	}
+	if len(byManager["volta"]) != 1 || byManager["volta"][0].version != "20.11.0" {
+		t.Errorf("unexpected volta roots: %+v", byManager["volta"])
+	}
+}
+
+// writeAgentStub creates a fake agent binary next to a root's npm.
+func writeAgentStub(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write %s stub: %v", name, err)
+	}
+	return path
+}
+
+// nodeRootsTestSetup builds two nvm version roots with npm and agent
+// stubs, fakes npm list output for every npm binary, and returns the
+// strategy with the agent's catalog entry. Callers set the active-PATH
+// copy of the agent via the returned mock platform.
+func nodeRootsTestSetup(t *testing.T) (*NPMStrategy, *mockPlatform, []catalog.AgentDef, [2]string) {
+	t.Helper()
+
+	nvmDir := t.TempDir()
+	t.Setenv("NVM_DIR", nvmDir)
+	t.Setenv("FNM_DIR", t.TempDir())
+	t.Setenv("VOLTA_HOME", t.TempDir())
+
+	binA := filepath.Join(nvmDir, "versions", "node", "v18.19.0", "bin")
+	binB := filepath.Join(nvmDir, "versions", "node", "v20.11.0", "bin")
+	writeNPMStub(t, binA)
+	writeNPMStub(t, binB)
+	pathA := writeAgentStub(t, binA, "claude")
+	pathB := writeAgentStub(t, binB, "claude")
+
+	// Every npm binary reports the same global package
+	fake := &execx.Fake{Handler: func(name string, args []string) (*execx.Result, error) {
+		return &execx.Result{Stdout: `{"dependencies":{"@anthropic-ai/claude-code":{"version":"1.0.24"}}}`}, nil
+	}}
+	orig := execx.Default
+	execx.Default = fake
+	t.Cleanup(func() { execx.Default = orig })
+
+	plat := newMockPlatform()
+	plat.executables["npm"] = "/usr/local/bin/npm"
+
+	agents := []catalog.AgentDef{{
+		ID:   "claude-code",
+		Name: "Claude Code",
+		InstallMethods: map[string]catalog.InstallMethodDef{
+			"npm": {Package: "@anthropic-ai/claude-code"},
+		},
+		Detection: catalog.DetectionDef{Executables: []string{"claude"}},
+	}}
+
+	return NewNPMStrategy(plat), plat, agents, [2]string{pathA, pathB}
+}
+
+func TestNPMDetectKeepsCopiesPerNodeRoot(t *testing.T) {
+	strategy, plat, agents, rootPaths := nodeRootsTestSetup(t)
+	plat.executablePaths["claude"] = "/usr/local/bin/claude"
+
+	installations, err := strategy.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	// One copy under the active npm plus one per nvm root
+	if len(installations) != 3 {
+		t.Fatalf("expected 3 installations, got %d: %+v", len(installations), installations)
+	}
+
+	byPath := make(map[string]int)
+	for _, inst := range installations {
+		if inst.AgentID != "claude-code" {
+			t.Errorf("unexpected agent %q", inst.AgentID)
+		}
+		byPath[inst.ExecutablePath]++
+	}
+	for _, path := range []string{"/usr/local/bin/claude", rootPaths[0], rootPaths[1]} {
+		if byPath[path] != 1 {
+			t.Errorf("expected exactly 1 installation at %s, got %d", path, byPath[path])
+		}
+	}
+
+	for _, inst := range installations {
+		if inst.ExecutablePath == "/usr/local/bin/claude" {
+			continue
+		}
+		if inst.Metadata["node_manager"] != "nvm" {
+			t.Errorf("copy at %s: node_manager = %q, want nvm", inst.ExecutablePath, inst.Metadata["node_manager"])
+		}
+		if inst.Metadata["npm_path"] == "" {
+			t.Errorf("copy at %s has no npm_path metadata", inst.ExecutablePath)
+		}
+	}
+}
+
+func TestNPMDetectSkipsCopyMatchingActivePath(t *testing.T) {
+	// The active npm's copy resolves to the same binary as the first
+	// nvm root; that root must not yield a duplicate installation.
+	strategy, plat, agents, rootPaths := nodeRootsTestSetup(t)
+	plat.executablePaths["claude"] = rootPaths[0]
+
+	installations, err := strategy.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+
+	if len(installations) != 2 {
+		t.Fatalf("expected 2 installations, got %d: %+v", len(installations), installations)
+	}
+
+	seen := make(map[string]bool)
+	for _, inst := range installations {
+		if seen[inst.Key()] {
+			t.Errorf("duplicate installation for key %s", inst.Key())
+		}
+		seen[inst.Key()] = true
+	}
+	byPath := make(map[string]int)
+	for _, inst := range installations {
+		byPath[inst.ExecutablePath]++
+	}
+	if byPath[rootPaths[0]] != 1 {
+		t.Errorf("expected exactly 1 installation at %s, got %d", rootPaths[0], byPath[rootPaths[0]])
+	}
+	if byPath[rootPaths[1]] != 1 {
+		t.Errorf("expected exactly 1 installation at %s, got %d", rootPaths[1], byPath[rootPaths[1]])
+	}
+}
+
+func TestNodeVersionRootsEmpty(t *testing.T) {
+	t.Setenv("NVM_DIR", t.TempDir())
+	t.Setenv("FNM_DIR", t.TempDir())
+	t.Setenv("VOLTA_HOME", t.TempDir())
+
+	if roots := nodeVersionRoots(); len(roots) != 0 {
+		t.Errorf("expected no roots for empty dirs, got %+v", roots)
+	}
+}
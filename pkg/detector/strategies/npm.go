@@ -3,17 +3,21 @@ package strategies
 import (
 	"context"
 	"encoding/json"
-	"os/exec"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // NPMStrategy detects agents installed via npm.
 type NPMStrategy struct {
-	platform platform.Platform
+	platform   platform.Platform
+	installers config.InstallersConfig
 }
 
 // NewNPMStrategy creates a new NPM detection strategy.
@@ -21,6 +25,11 @@ func NewNPMStrategy(p platform.Platform) *NPMStrategy {
 	return &NPMStrategy{platform: p}
 }
 
+// SetInstallers applies tool overrides from the installers configuration.
+func (s *NPMStrategy) SetInstallers(c config.InstallersConfig) {
+	s.installers = c
+}
+
 // Name returns the strategy name.
 func (s *NPMStrategy) Name() string {
 	return "npm"
@@ -33,6 +42,10 @@ func (s *NPMStrategy) Method() agent.InstallMethod {
 
 // IsApplicable returns true if npm is available.
 func (s *NPMStrategy) IsApplicable(p platform.Platform) bool {
+	if npmCmd := s.installers.NPMCommand(); npmCmd != "npm" {
+		_, err := os.Stat(npmCmd)
+		return err == nil
+	}
 	return p.IsExecutableInPath("npm")
 }
 
@@ -48,6 +61,11 @@ type npmListOutput struct {
 
 // Detect scans for npm-installed agents.
 func (s *NPMStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	// Nothing to match against: don't bother spawning npm
+	if !catalogUsesMethod(agents, "npm") {
+		return nil, nil
+	}
+
 	// Get list of globally installed packages
 	globalPackages, err := s.getGlobalPackages(ctx)
 	if err != nil {
@@ -80,7 +98,7 @@ func (s *NPMStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]
 		}
 
 		// Parse version
-		version, _ := agent.ParseVersion(pkg.Version)
+		version := parseAgentVersion(agentDef, pkg.Version)
 
 		// Get executable path
 		execPath := s.findExecutable(agentDef)
@@ -100,26 +118,119 @@ func (s *NPMStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]
 		installations = append(installations, inst)
 	}
 
+	// Scan Node version manager roots so agents installed under
+	// inactive Node versions are found too. Copies are kept per
+	// executable path: the same agent at several Node versions is
+	// several installations, each managed on its own.
+	seen := make(map[string]bool, len(installations))
+	for _, inst := range installations {
+		seen[inst.Key()] = true
+	}
+	installations = append(installations, s.detectUnderNodeRoots(ctx, agents, seen)...)
+
 	return installations, nil
 }
 
+// detectUnderNodeRoots scans the npm binary of every Node installation
+// managed by nvm, fnm or volta, skipping agents already detected under
+// the active npm.
+func (s *NPMStrategy) detectUnderNodeRoots(ctx context.Context, agents []catalog.AgentDef, seen map[string]bool) []*agent.Installation {
+	activeNPM := s.installers.NPMCommand()
+
+	var installations []*agent.Installation
+	for _, root := range nodeVersionRoots() {
+		if root.npmPath == activeNPM {
+			continue
+		}
+
+		packages, err := s.getGlobalPackagesWith(ctx, root.npmPath)
+		if err != nil {
+			continue
+		}
+
+		for _, agentDef := range agents {
+			npmMethod, hasNPM := agentDef.InstallMethods["npm"]
+			if !hasNPM {
+				continue
+			}
+
+			packageName := npmMethod.Package
+			if packageName == "" {
+				packageName = extractNPMPackageName(npmMethod.Command)
+			}
+			if packageName == "" {
+				continue
+			}
+
+			pkg, found := packages[packageName]
+			if !found {
+				continue
+			}
+
+			version := parseAgentVersion(agentDef, pkg.Version)
+
+			// The agent binary lives next to the root's npm
+			execPath := ""
+			for _, exe := range agentDef.Detection.Executables {
+				candidate := filepath.Join(filepath.Dir(root.npmPath), exe)
+				if _, err := os.Stat(candidate); err == nil {
+					execPath = candidate
+					break
+				}
+			}
+
+			inst := &agent.Installation{
+				AgentID:          agentDef.ID,
+				AgentName:        agentDef.Name,
+				Method:           agent.MethodNPM,
+				InstalledVersion: version,
+				ExecutablePath:   execPath,
+				Metadata: map[string]string{
+					"detected_by":  "npm",
+					"package":      packageName,
+					"node_manager": root.manager,
+					"node_version": root.version,
+					"npm_path":     root.npmPath,
+				},
+			}
+			if seen[inst.Key()] {
+				continue
+			}
+			seen[inst.Key()] = true
+			installations = append(installations, inst)
+		}
+	}
+
+	return installations
+}
+
 // getGlobalPackages retrieves globally installed npm packages.
 func (s *NPMStrategy) getGlobalPackages(ctx context.Context) (map[string]npmPackage, error) {
-	cmd := exec.CommandContext(ctx, "npm", "list", "-g", "--depth=0", "--json")
-	output, err := cmd.Output()
+	return s.getGlobalPackagesWith(ctx, s.installers.NPMCommand())
+}
+
+// getGlobalPackagesWith retrieves global packages using a specific npm binary.
+func (s *NPMStrategy) getGlobalPackagesWith(ctx context.Context, npmCmd string) (map[string]npmPackage, error) {
+	res, err := execx.Run(ctx, npmCmd, []string{"list", "-g", "--depth=0", "--json"},
+		execx.WithEnv(s.installers.NPMEnv()...))
 	if err != nil {
 		// npm list returns exit code 1 if there are peer dependency issues
 		// but still outputs valid JSON, so we continue
-		if len(output) == 0 {
+		if res == nil || res.Stdout == "" {
 			return nil, err
 		}
 	}
 
+	return parseNPMListJSON([]byte(res.Stdout))
+}
+
+// parseNPMListJSON parses `npm ls -g --json` output into the global
+// package map.
+func parseNPMListJSON(output []byte) (map[string]npmPackage, error) {
 	var result npmListOutput
 	if err := json.Unmarshal(output, &result); err != nil {
 		return nil, err
 	}
-
 	return result.Dependencies, nil
 }
 
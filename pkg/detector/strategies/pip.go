@@ -8,12 +8,14 @@ import (
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // PipStrategy detects agents installed via pip, pipx, or uv.
 type PipStrategy struct {
-	platform platform.Platform
+	platform   platform.Platform
+	installers config.InstallersConfig
 }
 
 // NewPipStrategy creates a new pip detection strategy.
@@ -21,6 +23,11 @@ func NewPipStrategy(p platform.Platform) *PipStrategy {
 	return &PipStrategy{platform: p}
 }
 
+// SetInstallers applies tool overrides from the installers configuration.
+func (s *PipStrategy) SetInstallers(c config.InstallersConfig) {
+	s.installers = c
+}
+
 // Name returns the strategy name.
 func (s *PipStrategy) Name() string {
 	return "pip"
@@ -58,21 +65,35 @@ type pipxPackage struct {
 func (s *PipStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
 	var installations []*agent.Installation
 
-	// Get pip packages
-	pipPackages := s.getPipPackages(ctx)
+	// Fetch only the inventories something in the catalog can match.
+	// Agents with just a pip method are still looked up in the pipx and
+	// uv inventories (see the isolation fallback below), so a pip method
+	// implies all three.
+	needPip := catalogUsesMethod(agents, "pip")
+	needPipx := needPip || catalogUsesMethod(agents, "pipx")
+	needUV := needPip || catalogUsesMethod(agents, "uv")
+
+	pipPackages := map[string]pipPackage{}
+	if needPip {
+		pipPackages = s.getPipPackages(ctx)
+	}
 
-	// Get pipx packages
-	pipxPackages := s.getPipxPackages(ctx)
+	pipxPackages := map[string]pipxPackage{}
+	if needPipx {
+		pipxPackages = s.getPipxPackages(ctx)
+	}
 
-	// Get uv packages
-	uvPackages := s.getUVPackages(ctx)
+	uvPackages := map[string]pipPackage{}
+	if needUV {
+		uvPackages = s.getUVPackages(ctx)
+	}
 
 	for _, agentDef := range agents {
 		// Check pip method
 		if pipMethod, ok := agentDef.InstallMethods["pip"]; ok {
 			packageName := extractPipPackageName(pipMethod.Package, pipMethod.Command)
 			if pkg, found := pipPackages[strings.ToLower(packageName)]; found {
-				version, _ := agent.ParseVersion(pkg.Version)
+				version := parseAgentVersion(agentDef, pkg.Version)
 				installations = append(installations, &agent.Installation{
 					AgentID:          agentDef.ID,
 					AgentName:        agentDef.Name,
@@ -88,11 +109,17 @@ func (s *PipStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]
 			}
 		}
 
-		// Check pipx method
-		if pipxMethod, ok := agentDef.InstallMethods["pipx"]; ok {
+		// Check pipx method. Agents with only a pip method are also
+		// looked up here: installers.pip_isolation may have redirected
+		// the install into a pipx-managed environment.
+		pipxMethod, hasPipx := agentDef.InstallMethods["pipx"]
+		if !hasPipx {
+			pipxMethod, hasPipx = agentDef.InstallMethods["pip"]
+		}
+		if hasPipx {
 			packageName := extractPipPackageName(pipxMethod.Package, pipxMethod.Command)
 			if pkg, found := pipxPackages[strings.ToLower(packageName)]; found {
-				version, _ := agent.ParseVersion(pkg.Metadata.MainPackage.PackageVersion)
+				version := parseAgentVersion(agentDef, pkg.Metadata.MainPackage.PackageVersion)
 				installations = append(installations, &agent.Installation{
 					AgentID:          agentDef.ID,
 					AgentName:        agentDef.Name,
@@ -108,11 +135,15 @@ func (s *PipStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]
 			}
 		}
 
-		// Check uv method
-		if uvMethod, ok := agentDef.InstallMethods["uv"]; ok {
+		// Check uv method, with the same pip fallback as above
+		uvMethod, hasUV := agentDef.InstallMethods["uv"]
+		if !hasUV {
+			uvMethod, hasUV = agentDef.InstallMethods["pip"]
+		}
+		if hasUV {
 			packageName := extractPipPackageName(uvMethod.Package, uvMethod.Command)
 			if pkg, found := uvPackages[strings.ToLower(packageName)]; found {
-				version, _ := agent.ParseVersion(pkg.Version)
+				version := parseAgentVersion(agentDef, pkg.Version)
 				installations = append(installations, &agent.Installation{
 					AgentID:          agentDef.ID,
 					AgentName:        agentDef.Name,
@@ -150,6 +181,14 @@ func (s *PipStrategy) getPipPackages(ctx context.Context) map[string]pipPackage
 		return packages
 	}
 
+	return parsePipListJSON(output)
+}
+
+// parsePipListJSON parses `pip list --format=json` output into a map
+// keyed by lowercase package name.
+func parsePipListJSON(output []byte) map[string]pipPackage {
+	packages := make(map[string]pipPackage)
+
 	var pkgList []pipPackage
 	if err := json.Unmarshal(output, &pkgList); err != nil {
 		return packages
@@ -171,11 +210,20 @@ func (s *PipStrategy) getPipxPackages(ctx context.Context) map[string]pipxPackag
 	}
 
 	cmd := exec.CommandContext(ctx, "pipx", "list", "--json")
+	applyEnvOverrides(cmd, s.installers.PipxEnv())
 	output, err := cmd.Output()
 	if err != nil {
 		return packages
 	}
 
+	return parsePipxListJSON(output)
+}
+
+// parsePipxListJSON parses `pipx list --json` output into a map keyed by
+// lowercase venv name.
+func parsePipxListJSON(output []byte) map[string]pipxPackage {
+	packages := make(map[string]pipxPackage)
+
 	var result struct {
 		Venvs map[string]pipxPackage `json:"venvs"`
 	}
@@ -200,10 +248,12 @@ func (s *PipStrategy) getUVPackages(ctx context.Context) map[string]pipPackage {
 
 	// uv tool list shows installed tools
 	cmd := exec.CommandContext(ctx, "uv", "tool", "list", "--format=json")
+	applyEnvOverrides(cmd, s.installers.UVEnv())
 	output, err := cmd.Output()
 	if err != nil {
 		// Try alternative format
 		cmd = exec.CommandContext(ctx, "uv", "tool", "list")
+		applyEnvOverrides(cmd, s.installers.UVEnv())
 		output, err = cmd.Output()
 		if err != nil {
 			return packages
@@ -212,6 +262,14 @@ func (s *PipStrategy) getUVPackages(ctx context.Context) map[string]pipPackage {
 		return s.parseUVTextOutput(string(output))
 	}
 
+	return parseUVToolListJSON(output)
+}
+
+// parseUVToolListJSON parses `uv tool list --format=json` output into a
+// map keyed by lowercase tool name.
+func parseUVToolListJSON(output []byte) map[string]pipPackage {
+	packages := make(map[string]pipPackage)
+
 	var tools []struct {
 		Name    string `json:"name"`
 		Version string `json:"version"`
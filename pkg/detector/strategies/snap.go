@@ -0,0 +1,116 @@
+package strategies
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// SnapStrategy detects agents installed via Snap.
+type SnapStrategy struct {
+	platform platform.Platform
+}
+
+// NewSnapStrategy creates a new Snap detection strategy.
+func NewSnapStrategy(p platform.Platform) *SnapStrategy {
+	return &SnapStrategy{platform: p}
+}
+
+// Name returns the strategy name.
+func (s *SnapStrategy) Name() string {
+	return "snap"
+}
+
+// Method returns the install method this strategy detects.
+func (s *SnapStrategy) Method() agent.InstallMethod {
+	return agent.MethodSnap
+}
+
+// IsApplicable returns true if snap is available (Linux).
+func (s *SnapStrategy) IsApplicable(p platform.Platform) bool {
+	return p.ID() == platform.Linux && p.IsExecutableInPath("snap")
+}
+
+// Detect scans for snap-installed agents.
+func (s *SnapStrategy) Detect(ctx context.Context, agents []catalog.AgentDef) ([]*agent.Installation, error) {
+	installed := s.listInstalled(ctx)
+	if len(installed) == 0 {
+		return nil, nil
+	}
+
+	var installations []*agent.Installation
+
+	for _, agentDef := range agents {
+		method, hasSnap := agentDef.InstallMethods["snap"]
+		if !hasSnap || method.Package == "" {
+			continue
+		}
+
+		versionStr, found := installed[strings.ToLower(method.Package)]
+		if !found {
+			continue
+		}
+
+		version := parseAgentVersion(agentDef, versionStr)
+
+		installations = append(installations, &agent.Installation{
+			AgentID:          agentDef.ID,
+			AgentName:        agentDef.Name,
+			Method:           agent.MethodSnap,
+			InstalledVersion: version,
+			ExecutablePath:   s.findExecutable(agentDef),
+			Metadata: map[string]string{
+				"detected_by": "snap",
+				"snap":        method.Package,
+			},
+		})
+	}
+
+	return installations, nil
+}
+
+// listInstalled returns installed snaps as a map of lowercase snap name to
+// version.
+func (s *SnapStrategy) listInstalled(ctx context.Context) map[string]string {
+	output, err := exec.CommandContext(ctx, "snap", "list").Output()
+	if err != nil {
+		return nil
+	}
+	return parseSnapList(string(output))
+}
+
+// findExecutable attempts to find the executable for an agent.
+func (s *SnapStrategy) findExecutable(agentDef catalog.AgentDef) string {
+	for _, exec := range agentDef.Detection.Executables {
+		if path, err := s.platform.FindExecutable(exec); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// parseSnapList parses the columnar output of `snap list`, skipping the
+// header line.
+func parseSnapList(output string) map[string]string {
+	installed := make(map[string]string)
+
+	lines := strings.Split(output, "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || i == 0 { // First line is the column header
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		installed[strings.ToLower(fields[0])] = fields[1]
+	}
+
+	return installed
+}
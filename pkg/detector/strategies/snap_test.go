@@ -0,0 +1,28 @@
+package strategies
+
+import "testing"
+
+func TestParseSnapList(t *testing.T) {
+	output := `Name      Version  Rev    Tracking       Publisher   Notes
+my-agent  1.2.3    1234   latest/stable  example     classic
+core22    20240111 1122   latest/stable  canonical   base
+`
+
+	installed := parseSnapList(output)
+
+	if len(installed) != 2 {
+		t.Fatalf("expected 2 snaps, got %d: %v", len(installed), installed)
+	}
+	if installed["my-agent"] != "1.2.3" {
+		t.Errorf("expected my-agent 1.2.3, got %q", installed["my-agent"])
+	}
+	if _, ok := installed["name"]; ok {
+		t.Error("expected header line to be skipped")
+	}
+}
+
+func TestParseSnapListEmpty(t *testing.T) {
+	if installed := parseSnapList(""); len(installed) != 0 {
+		t.Errorf("expected no snaps, got %v", installed)
+	}
+}
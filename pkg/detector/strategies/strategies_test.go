@@ -7,6 +7,7 @@ import (
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
@@ -34,6 +35,9 @@ func (m *mockPlatform) GetIPCSocketPath() string                             { r
 func (m *mockPlatform) EnableAutoStart(ctx context.Context) error            { return nil }
 func (m *mockPlatform) DisableAutoStart(ctx context.Context) error           { return nil }
 func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) { return false, nil }
+func (m *mockPlatform) GetAutoStartMethod(ctx context.Context) (platform.AutoStartMethod, error) {
+	return platform.AutoStartNone, nil
+}
 func (m *mockPlatform) FindExecutable(name string) (string, error) {
 	if path, ok := m.executablePaths[name]; ok {
 		return path, nil
@@ -51,6 +55,8 @@ func (m *mockPlatform) GetPathDirs() []string
 func (m *mockPlatform) GetShell() string                                            { return "/bin/bash" }
 func (m *mockPlatform) GetShellArg() string                                         { return "-c" }
 func (m *mockPlatform) ShowNotification(title, message string) error                { return nil }
+func (m *mockPlatform) IsDoNotDisturbEnabled() bool                                 { return false }
+func (m *mockPlatform) IsElevated() bool                                            { return false }
 func (m *mockPlatform) ShowChangelogDialog(a, b, c, d string) platform.DialogResult { return 0 }
 
 // ========== NPM Strategy Tests ==========
@@ -165,6 +171,51 @@ func TestBinaryStrategyIsApplicable(t *testing.T) {
 	}
 }
 
+func TestBinaryStrategyVersionProbeDeduped(t *testing.T) {
+	plat := newMockPlatform()
+	plat.executablePaths["shared"] = "/usr/local/bin/shared"
+	strategy := NewBinaryStrategy(plat)
+
+	fake := &execx.Fake{Handler: func(name string, args []string) (*execx.Result, error) {
+		return &execx.Result{Stdout: "shared version 4.5.6\n"}, nil
+	}}
+	orig := execx.Default
+	execx.Default = fake
+	defer func() { execx.Default = orig }()
+
+	// Two agents resolve to the same executable and version command
+	agents := []catalog.AgentDef{}
+	for _, id := range []string{"first", "second"} {
+		agents = append(agents, catalog.AgentDef{
+			ID:   id,
+			Name: id,
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"binary": {},
+			},
+			Detection: catalog.DetectionDef{
+				Executables: []string{"shared"},
+				VersionCmd:  "shared --version",
+			},
+		})
+	}
+
+	installations, err := strategy.Detect(context.Background(), agents)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if len(installations) != 2 {
+		t.Fatalf("expected 2 installations, got %d", len(installations))
+	}
+	for _, inst := range installations {
+		if inst.InstalledVersion.String() != "4.5.6" {
+			t.Errorf("installation %s: expected version 4.5.6, got %s", inst.AgentID, inst.InstalledVersion.String())
+		}
+	}
+	if calls := fake.Calls(); len(calls) != 1 {
+		t.Errorf("expected the shared version command to run once, ran %d times", len(calls))
+	}
+}
+
 func TestExtractVersionFromOutput(t *testing.T) {
 	tests := []struct {
 		output   string
@@ -1349,7 +1400,7 @@ func TestBinaryStrategy_getVersion_EmptyVersionCmd(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	version := strategy.getVersion(ctx, agentDef, "/usr/local/bin/test")
+	version := strategy.getVersion(ctx, agentDef, "/usr/local/bin/test", map[string]string{})
 
 	if version.Major != 0 || version.Minor != 0 || version.Patch != 0 {
 		t.Errorf("getVersion() with empty cmd should return empty version, got %v", version)
@@ -1367,7 +1418,7 @@ func TestBinaryStrategy_getVersion_InvalidCommand(t *testing.T) {
 	}
 
 	ctx := context.Background()
-	version := strategy.getVersion(ctx, agentDef, "/path/to/nonexistent")
+	version := strategy.getVersion(ctx, agentDef, "/path/to/nonexistent", map[string]string{})
 
 	// Should return empty version when command fails
 	if version.Major != 0 || version.Minor != 0 || version.Patch != 0 {
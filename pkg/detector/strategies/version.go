@@ -0,0 +1,14 @@
+package strategies
+
+import (
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// parseAgentVersion parses a detected version string using the agent's
+// declared version scheme, so calendar-versioned and build-numbered agents
+// order correctly alongside semver ones.
+func parseAgentVersion(agentDef catalog.AgentDef, s string) agent.Version {
+	v, _ := agent.ParseVersionScheme(s, agent.VersionScheme(agentDef.VersionScheme), agentDef.VersionPattern)
+	return v
+}
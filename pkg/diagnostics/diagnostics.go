@@ -0,0 +1,209 @@
+// Package diagnostics builds support bundles for bug reports: logs, the
+// redacted configuration, and basic system status zipped together.
+package diagnostics
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// maxLogBytes caps how much of the log file a bundle includes; only the
+// tail matters for bug reports.
+const maxLogBytes = 2 << 20
+
+// redactedPlaceholder replaces secret values in the bundled config.
+const redactedPlaceholder = "[redacted]"
+
+// Status is the system snapshot included in a bundle.
+type Status struct {
+	Version     string    `json:"version"`
+	Platform    string    `json:"platform"`
+	Arch        string    `json:"arch"`
+	GoVersion   string    `json:"go_version"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// WriteBundle writes a diagnostics zip to w: the tail of the log file,
+// the configuration with secrets redacted, and a status snapshot.
+func WriteBundle(cfg *config.Config, plat platform.Platform, version string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	status := Status{
+		Version:     version,
+		Platform:    string(plat.ID()),
+		Arch:        runtime.GOARCH,
+		GoVersion:   runtime.Version(),
+		GeneratedAt: time.Now().UTC(),
+	}
+	if err := writeJSONEntry(zw, "status.json", status); err != nil {
+		return err
+	}
+
+	if err := writeJSONEntry(zw, "config.json", RedactConfig(cfg)); err != nil {
+		return err
+	}
+
+	if cfg.Logging.File != "" {
+		tail, err := ReadLogTail(cfg.Logging.File, maxLogBytes)
+		if err == nil && len(tail) > 0 {
+			f, err := zw.Create("agentmgr.log")
+			if err != nil {
+				return fmt.Errorf("failed to add log to bundle: %w", err)
+			}
+			if _, err := f.Write(tail); err != nil {
+				return fmt.Errorf("failed to add log to bundle: %w", err)
+			}
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeJSONEntry adds an indented JSON file to the zip.
+func writeJSONEntry(zw *zip.Writer, name string, v interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to bundle: %w", name, err)
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to add %s to bundle: %w", name, err)
+	}
+	return nil
+}
+
+// RedactConfig returns a copy of the configuration with secrets replaced
+// so the bundle is safe to attach to a public bug report.
+func RedactConfig(cfg *config.Config) *config.Config {
+	redacted := *cfg
+	if redacted.Catalog.GitHubToken != "" {
+		redacted.Catalog.GitHubToken = redactedPlaceholder
+	}
+	if redacted.API.AuthToken != "" {
+		redacted.API.AuthToken = redactedPlaceholder
+	}
+	if redacted.Installers.NPMAuthToken != "" {
+		redacted.Installers.NPMAuthToken = redactedPlaceholder
+	}
+	if redacted.Installers.PipIndexPassword != "" {
+		redacted.Installers.PipIndexPassword = redactedPlaceholder
+	}
+	if redacted.Enrollment.Token != "" {
+		redacted.Enrollment.Token = redactedPlaceholder
+	}
+	return &redacted
+}
+
+// ReadLogTail returns up to maxBytes from the end of a log file, starting
+// at a line boundary.
+func ReadLogTail(path string, maxBytes int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	offset := int64(0)
+	if info.Size() > maxBytes {
+		offset = info.Size() - maxBytes
+	}
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	// Drop the partial first line when the tail starts mid-file
+	if offset > 0 {
+		if idx := strings.IndexByte(string(data), '\n'); idx >= 0 {
+			data = data[idx+1:]
+		}
+	}
+	return data, nil
+}
+
+// logLevels orders the filterable levels from most to least verbose.
+var logLevels = []string{"debug", "info", "warn", "error"}
+
+// LineMatchesLevel reports whether a log line is at or above the given
+// minimum level. JSON-formatted lines are matched on their "level" field,
+// text lines on a level token; lines without a recognizable level always
+// match so nothing silently disappears from the view.
+func LineMatchesLevel(line, minLevel string) bool {
+	minIdx := levelIndex(minLevel)
+	if minIdx <= 0 {
+		return true
+	}
+
+	lineLevel := extractLevel(line)
+	if lineLevel < 0 {
+		return true
+	}
+	return lineLevel >= minIdx
+}
+
+// extractLevel finds the level of a log line, returning -1 when none is
+// recognizable.
+func extractLevel(line string) int {
+	lower := strings.ToLower(line)
+	for i := len(logLevels) - 1; i >= 0; i-- {
+		level := logLevels[i]
+		if strings.Contains(lower, `"level":"`+level+`"`) ||
+			strings.Contains(lower, "level="+level) ||
+			strings.Contains(lower, strings.ToUpper(level)) ||
+			strings.Contains(line, "["+level+"]") {
+			return i
+		}
+	}
+	return -1
+}
+
+// levelIndex returns the position of a level name, or -1 for unknown
+// levels (including "all").
+func levelIndex(level string) int {
+	for i, l := range logLevels {
+		if strings.EqualFold(level, l) {
+			return i
+		}
+	}
+	return -1
+}
+
+// FilterLines filters log lines by minimum level and a case-insensitive
+// search query. Empty filters pass everything through.
+func FilterLines(lines []string, minLevel, query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var out []string
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		if !LineMatchesLevel(line, minLevel) {
+			continue
+		}
+		if query != "" && !strings.Contains(strings.ToLower(line), query) {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
@@ -0,0 +1,176 @@
+package diagnostics
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestRedactConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Catalog.GitHubToken = "ghp_secret"
+	cfg.API.AuthToken = "api-secret"
+	cfg.Installers.NPMAuthToken = "npm-secret"
+	cfg.Installers.PipIndexPassword = "pip-secret"
+	cfg.Enrollment.Token = "enroll-secret"
+
+	redacted := RedactConfig(cfg)
+
+	if redacted.Catalog.GitHubToken != redactedPlaceholder {
+		t.Errorf("GitHubToken = %q, want %q", redacted.Catalog.GitHubToken, redactedPlaceholder)
+	}
+	if redacted.API.AuthToken != redactedPlaceholder {
+		t.Errorf("AuthToken = %q, want %q", redacted.API.AuthToken, redactedPlaceholder)
+	}
+	if redacted.Installers.NPMAuthToken != redactedPlaceholder {
+		t.Errorf("NPMAuthToken = %q, want %q", redacted.Installers.NPMAuthToken, redactedPlaceholder)
+	}
+	if redacted.Installers.PipIndexPassword != redactedPlaceholder {
+		t.Errorf("PipIndexPassword = %q, want %q", redacted.Installers.PipIndexPassword, redactedPlaceholder)
+	}
+	if redacted.Enrollment.Token != redactedPlaceholder {
+		t.Errorf("Enrollment.Token = %q, want %q", redacted.Enrollment.Token, redactedPlaceholder)
+	}
+
+	// The original config must not be modified
+	if cfg.Catalog.GitHubToken != "ghp_secret" {
+		t.Error("RedactConfig modified the original config")
+	}
+}
+
+func TestReadLogTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agentmgr.log")
+	content := "line one\nline two\nline three\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Small enough to fit: everything comes back
+	tail, err := ReadLogTail(path, 1024)
+	if err != nil {
+		t.Fatalf("ReadLogTail() error = %v", err)
+	}
+	if string(tail) != content {
+		t.Errorf("tail = %q, want %q", tail, content)
+	}
+
+	// Truncated reads start at a line boundary
+	tail, err = ReadLogTail(path, 12)
+	if err != nil {
+		t.Fatalf("ReadLogTail() error = %v", err)
+	}
+	if string(tail) != "line three\n" {
+		t.Errorf("tail = %q, want %q", tail, "line three\n")
+	}
+}
+
+func TestFilterLines(t *testing.T) {
+	lines := []string{
+		`{"level":"debug","msg":"probing npm"}`,
+		`{"level":"info","msg":"detected claude-code"}`,
+		`{"level":"warn","msg":"catalog refresh slow"}`,
+		`{"level":"error","msg":"install failed"}`,
+		"plain line without a level",
+	}
+
+	tests := []struct {
+		name  string
+		level string
+		query string
+		want  int
+	}{
+		{name: "no filters", level: "", query: "", want: 5},
+		{name: "min warn", level: "warn", query: "", want: 3},
+		{name: "min error", level: "error", query: "", want: 2},
+		{name: "query", level: "", query: "claude", want: 1},
+		{name: "level and query", level: "warn", query: "install", want: 1},
+		{name: "query no match", level: "", query: "zzz", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterLines(lines, tt.level, tt.query)
+			if len(got) != tt.want {
+				t.Errorf("FilterLines(level=%q, query=%q) returned %d lines, want %d",
+					tt.level, tt.query, len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLineMatchesLevelTextFormat(t *testing.T) {
+	if !LineMatchesLevel("2026-01-02 ERROR install failed", "warn") {
+		t.Error("uppercase text level should match")
+	}
+	if LineMatchesLevel("level=debug probing npm", "info") {
+		t.Error("debug line should not pass an info filter")
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "agentmgr.log")
+	if err := os.WriteFile(logPath, []byte(`{"level":"info","msg":"hello"}`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := config.Default()
+	cfg.Logging.File = logPath
+	cfg.Catalog.GitHubToken = "ghp_secret"
+
+	var buf bytes.Buffer
+	if err := WriteBundle(cfg, platform.Current(), "1.2.3", &buf); err != nil {
+		t.Fatalf("WriteBundle() error = %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("failed to open bundle: %v", err)
+	}
+
+	entries := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"status.json", "config.json", "agentmgr.log"} {
+		if _, ok := entries[name]; !ok {
+			t.Errorf("bundle is missing %s", name)
+		}
+	}
+
+	var status Status
+	if err := json.Unmarshal([]byte(entries["status.json"]), &status); err != nil {
+		t.Fatalf("failed to parse status.json: %v", err)
+	}
+	if status.Version != "1.2.3" {
+		t.Errorf("status version = %q, want %q", status.Version, "1.2.3")
+	}
+
+	if strings.Contains(entries["config.json"], "ghp_secret") {
+		t.Error("bundled config leaks the GitHub token")
+	}
+	if !strings.Contains(entries["config.json"], redactedPlaceholder) {
+		t.Error("bundled config is missing the redaction placeholder")
+	}
+
+	if !strings.Contains(entries["agentmgr.log"], "hello") {
+		t.Error("bundled log is missing the log content")
+	}
+}
@@ -0,0 +1,337 @@
+// Package digest aggregates update activity into periodic summary
+// notifications and reports, as an alternative to per-check notifications.
+package digest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// Storage settings keys for the collected events and send state.
+const (
+	eventsKey    = "digest.events"
+	lastSentKey  = "digest.last_sent"
+	catalogIDKey = "digest.catalog_ids"
+)
+
+// Digest modes configurable via updates.digest.
+const (
+	ModeOff    = "off"
+	ModeDaily  = "daily"
+	ModeWeekly = "weekly"
+)
+
+// EventType classifies a digest event.
+type EventType string
+
+const (
+	// EventAutoUpdated records an agent that was updated automatically.
+	EventAutoUpdated EventType = "auto_updated"
+	// EventUpdatePending records an agent with an update waiting.
+	EventUpdatePending EventType = "update_pending"
+	// EventCatalogAdded records an agent newly added to the catalog.
+	EventCatalogAdded EventType = "catalog_added"
+)
+
+// Event is a single recorded change.
+type Event struct {
+	Type      EventType `json:"type"`
+	AgentID   string    `json:"agent_id,omitempty"`
+	AgentName string    `json:"agent_name,omitempty"`
+	Detail    string    `json:"detail,omitempty"`
+	Time      time.Time `json:"time"`
+}
+
+// Interval returns the digest period for a mode, or false for modes
+// that disable the digest.
+func Interval(mode string) (time.Duration, bool) {
+	switch mode {
+	case ModeDaily:
+		return 24 * time.Hour, true
+	case ModeWeekly:
+		return 7 * 24 * time.Hour, true
+	default:
+		return 0, false
+	}
+}
+
+// Collector records events between digests and decides when one is due.
+type Collector struct {
+	store storage.Store
+	mu    sync.Mutex
+}
+
+// NewCollector creates a collector backed by the given store.
+func NewCollector(store storage.Store) *Collector {
+	return &Collector{store: store}
+}
+
+// Record appends an event, skipping duplicates (same type, agent and
+// detail) so repeated update checks do not inflate the digest.
+func (c *Collector) Record(ctx context.Context, ev Event) error {
+	if c.store == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	events, err := c.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, existing := range events {
+		if existing.Type == ev.Type && existing.AgentID == ev.AgentID && existing.Detail == ev.Detail {
+			return nil
+		}
+	}
+
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+	events = append(events, ev)
+	return c.save(ctx, events)
+}
+
+// Events returns the events recorded since the last digest.
+func (c *Collector) Events(ctx context.Context) ([]Event, error) {
+	if c.store == nil {
+		return nil, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.load(ctx)
+}
+
+// Clear discards all recorded events.
+func (c *Collector) Clear(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.save(ctx, nil)
+}
+
+// Due reports whether the digest interval has elapsed since the last
+// send. A digest that was never sent is due immediately.
+func (c *Collector) Due(ctx context.Context, interval time.Duration) bool {
+	if c.store == nil {
+		return false
+	}
+
+	value, err := c.store.GetSetting(ctx, lastSentKey)
+	if err != nil || value == "" {
+		return true
+	}
+
+	lastSent, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return true
+	}
+	return time.Since(lastSent) >= interval
+}
+
+// MarkSent records the current time as the last digest send.
+func (c *Collector) MarkSent(ctx context.Context) error {
+	if c.store == nil {
+		return nil
+	}
+	return c.store.SetSetting(ctx, lastSentKey, time.Now().Format(time.RFC3339))
+}
+
+// load reads the stored event list.
+func (c *Collector) load(ctx context.Context) ([]Event, error) {
+	value, err := c.store.GetSetting(ctx, eventsKey)
+	if err != nil || value == "" {
+		return nil, nil
+	}
+
+	var events []Event
+	if err := json.Unmarshal([]byte(value), &events); err != nil {
+		return nil, fmt.Errorf("failed to parse digest events: %w", err)
+	}
+	return events, nil
+}
+
+// save writes the event list back to storage.
+func (c *Collector) save(ctx context.Context, events []Event) error {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("failed to encode digest events: %w", err)
+	}
+	return c.store.SetSetting(ctx, eventsKey, string(data))
+}
+
+// ObserveCatalog diffs the given catalog agents (ID to name) against
+// the last observed set and records catalog_added events for new
+// entries. The first observation only seeds the known set.
+func (c *Collector) ObserveCatalog(ctx context.Context, agents map[string]string) error {
+	if c.store == nil || len(agents) == 0 {
+		return nil
+	}
+
+	value, err := c.store.GetSetting(ctx, catalogIDKey)
+	if err != nil {
+		return err
+	}
+
+	known := make(map[string]bool)
+	if value != "" {
+		var ids []string
+		if err := json.Unmarshal([]byte(value), &ids); err == nil {
+			for _, id := range ids {
+				known[id] = true
+			}
+		}
+	}
+
+	if len(known) > 0 {
+		for id, name := range agents {
+			if !known[id] {
+				_ = c.Record(ctx, Event{
+					Type:      EventCatalogAdded,
+					AgentID:   id,
+					AgentName: name,
+				})
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(agents))
+	for id := range agents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return c.store.SetSetting(ctx, catalogIDKey, string(data))
+}
+
+// Summary groups recorded events for notification and report rendering.
+type Summary struct {
+	GeneratedAt  time.Time `json:"generated_at"`
+	AutoUpdated  []Event   `json:"auto_updated,omitempty"`
+	Pending      []Event   `json:"pending,omitempty"`
+	CatalogAdded []Event   `json:"catalog_added,omitempty"`
+}
+
+// Summarize groups events by type, sorted by agent name within each group.
+func Summarize(events []Event) Summary {
+	s := Summary{GeneratedAt: time.Now()}
+	for _, ev := range events {
+		switch ev.Type {
+		case EventAutoUpdated:
+			s.AutoUpdated = append(s.AutoUpdated, ev)
+		case EventUpdatePending:
+			s.Pending = append(s.Pending, ev)
+		case EventCatalogAdded:
+			s.CatalogAdded = append(s.CatalogAdded, ev)
+		}
+	}
+	for _, group := range [][]Event{s.AutoUpdated, s.Pending, s.CatalogAdded} {
+		sort.Slice(group, func(i, j int) bool {
+			return strings.ToLower(group[i].AgentName) < strings.ToLower(group[j].AgentName)
+		})
+	}
+	return s
+}
+
+// Empty reports whether the summary contains no events.
+func (s Summary) Empty() bool {
+	return len(s.AutoUpdated) == 0 && len(s.Pending) == 0 && len(s.CatalogAdded) == 0
+}
+
+// NotificationBody renders a one-line summary for a desktop notification.
+func (s Summary) NotificationBody() string {
+	var parts []string
+	if n := len(s.AutoUpdated); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d updated", n))
+	}
+	if n := len(s.Pending); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d update(s) pending", n))
+	}
+	if n := len(s.CatalogAdded); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d new in catalog", n))
+	}
+	if len(parts) == 0 {
+		return "No changes"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Markdown renders the summary as a markdown report.
+func (s Summary) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Agent Update Digest\n\nGenerated %s\n", s.GeneratedAt.Format("2006-01-02 15:04"))
+
+	writeSection := func(title string, events []Event) {
+		if len(events) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n", title)
+		for _, ev := range events {
+			if ev.Detail != "" {
+				fmt.Fprintf(&b, "- %s (%s)\n", ev.AgentName, ev.Detail)
+			} else {
+				fmt.Fprintf(&b, "- %s\n", ev.AgentName)
+			}
+		}
+	}
+
+	writeSection("Updated Automatically", s.AutoUpdated)
+	writeSection("Updates Pending", s.Pending)
+	writeSection("New in Catalog", s.CatalogAdded)
+
+	if s.Empty() {
+		b.WriteString("\nNo changes in this period.\n")
+	}
+	return b.String()
+}
+
+// HTML renders the summary as a standalone HTML report.
+func (s Summary) HTML() string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Agent Update Digest</title>\n")
+	b.WriteString("<style>body{font-family:-apple-system,sans-serif;max-width:640px;margin:2em auto;color:#222}h2{border-bottom:1px solid #ddd;padding-bottom:4px}small{color:#888}</style>\n")
+	b.WriteString("</head>\n<body>\n<h1>Agent Update Digest</h1>\n")
+	fmt.Fprintf(&b, "<small>Generated %s</small>\n", s.GeneratedAt.Format("2006-01-02 15:04"))
+
+	writeSection := func(title string, events []Event) {
+		if len(events) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "<h2>%s</h2>\n<ul>\n", html.EscapeString(title))
+		for _, ev := range events {
+			if ev.Detail != "" {
+				fmt.Fprintf(&b, "<li>%s <small>(%s)</small></li>\n", html.EscapeString(ev.AgentName), html.EscapeString(ev.Detail))
+			} else {
+				fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(ev.AgentName))
+			}
+		}
+		b.WriteString("</ul>\n")
+	}
+
+	writeSection("Updated Automatically", s.AutoUpdated)
+	writeSection("Updates Pending", s.Pending)
+	writeSection("New in Catalog", s.CatalogAdded)
+
+	if s.Empty() {
+		b.WriteString("<p>No changes in this period.</p>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
@@ -0,0 +1,226 @@
+package digest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// settingsStore is a minimal in-memory settings store for tests. The
+// embedded interface panics on any method the collector does not use.
+type settingsStore struct {
+	storage.Store
+	settings map[string]string
+}
+
+func newSettingsStore() *settingsStore {
+	return &settingsStore{settings: make(map[string]string)}
+}
+
+func (s *settingsStore) GetSetting(ctx context.Context, key string) (string, error) {
+	return s.settings[key], nil
+}
+
+func (s *settingsStore) SetSetting(ctx context.Context, key, value string) error {
+	s.settings[key] = value
+	return nil
+}
+
+func TestInterval(t *testing.T) {
+	tests := []struct {
+		mode     string
+		expected time.Duration
+		ok       bool
+	}{
+		{ModeDaily, 24 * time.Hour, true},
+		{ModeWeekly, 7 * 24 * time.Hour, true},
+		{ModeOff, 0, false},
+		{"", 0, false},
+		{"hourly", 0, false},
+	}
+
+	for _, tt := range tests {
+		interval, ok := Interval(tt.mode)
+		if interval != tt.expected || ok != tt.ok {
+			t.Errorf("Interval(%q) = (%v, %v), want (%v, %v)", tt.mode, interval, ok, tt.expected, tt.ok)
+		}
+	}
+}
+
+func TestRecordDeduplicates(t *testing.T) {
+	ctx := context.Background()
+	c := NewCollector(newSettingsStore())
+
+	ev := Event{Type: EventUpdatePending, AgentID: "aider", AgentName: "Aider", Detail: "1.0.0 → 1.1.0"}
+	for i := 0; i < 3; i++ {
+		if err := c.Record(ctx, ev); err != nil {
+			t.Fatalf("Record() returned error: %v", err)
+		}
+	}
+
+	events, err := c.Events(ctx)
+	if err != nil {
+		t.Fatalf("Events() returned error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected 1 event after duplicate records, got %d", len(events))
+	}
+
+	// A different detail is a new event
+	ev.Detail = "1.0.0 → 1.2.0"
+	if err := c.Record(ctx, ev); err != nil {
+		t.Fatalf("Record() returned error: %v", err)
+	}
+	events, _ = c.Events(ctx)
+	if len(events) != 2 {
+		t.Errorf("expected 2 events, got %d", len(events))
+	}
+}
+
+func TestClear(t *testing.T) {
+	ctx := context.Background()
+	c := NewCollector(newSettingsStore())
+
+	_ = c.Record(ctx, Event{Type: EventCatalogAdded, AgentID: "new-agent", AgentName: "New Agent"})
+	if err := c.Clear(ctx); err != nil {
+		t.Fatalf("Clear() returned error: %v", err)
+	}
+
+	events, _ := c.Events(ctx)
+	if len(events) != 0 {
+		t.Errorf("expected no events after Clear, got %d", len(events))
+	}
+}
+
+func TestDueAndMarkSent(t *testing.T) {
+	ctx := context.Background()
+	store := newSettingsStore()
+	c := NewCollector(store)
+
+	// Never sent: due immediately
+	if !c.Due(ctx, 24*time.Hour) {
+		t.Error("expected digest to be due when never sent")
+	}
+
+	if err := c.MarkSent(ctx); err != nil {
+		t.Fatalf("MarkSent() returned error: %v", err)
+	}
+	if c.Due(ctx, 24*time.Hour) {
+		t.Error("expected digest not to be due right after MarkSent")
+	}
+
+	// Backdate the last send past the interval
+	store.settings[lastSentKey] = time.Now().Add(-25 * time.Hour).Format(time.RFC3339)
+	if !c.Due(ctx, 24*time.Hour) {
+		t.Error("expected digest to be due after the interval elapsed")
+	}
+}
+
+func TestNilStoreCollector(t *testing.T) {
+	ctx := context.Background()
+	c := NewCollector(nil)
+
+	if err := c.Record(ctx, Event{Type: EventAutoUpdated, AgentID: "x"}); err != nil {
+		t.Errorf("Record() with nil store returned error: %v", err)
+	}
+	if events, err := c.Events(ctx); err != nil || len(events) != 0 {
+		t.Errorf("Events() with nil store = (%v, %v)", events, err)
+	}
+	if c.Due(ctx, time.Hour) {
+		t.Error("Due() with nil store should be false")
+	}
+}
+
+func TestObserveCatalog(t *testing.T) {
+	ctx := context.Background()
+	c := NewCollector(newSettingsStore())
+
+	// First observation only seeds the known set
+	if err := c.ObserveCatalog(ctx, map[string]string{"aider": "Aider"}); err != nil {
+		t.Fatalf("ObserveCatalog() returned error: %v", err)
+	}
+	events, _ := c.Events(ctx)
+	if len(events) != 0 {
+		t.Errorf("first observation should not record events, got %d", len(events))
+	}
+
+	// A new agent in the catalog is recorded
+	if err := c.ObserveCatalog(ctx, map[string]string{"aider": "Aider", "goose": "Goose"}); err != nil {
+		t.Fatalf("ObserveCatalog() returned error: %v", err)
+	}
+	events, _ = c.Events(ctx)
+	if len(events) != 1 || events[0].Type != EventCatalogAdded || events[0].AgentID != "goose" {
+		t.Errorf("expected one catalog_added event for goose, got %+v", events)
+	}
+
+	// Observing the same set again records nothing new
+	if err := c.ObserveCatalog(ctx, map[string]string{"aider": "Aider", "goose": "Goose"}); err != nil {
+		t.Fatalf("ObserveCatalog() returned error: %v", err)
+	}
+	events, _ = c.Events(ctx)
+	if len(events) != 1 {
+		t.Errorf("expected still one event, got %d", len(events))
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	events := []Event{
+		{Type: EventUpdatePending, AgentID: "b", AgentName: "Beta"},
+		{Type: EventAutoUpdated, AgentID: "a", AgentName: "Alpha", Detail: "1.0.0 → 1.1.0"},
+		{Type: EventUpdatePending, AgentID: "a2", AgentName: "Aardvark"},
+		{Type: EventCatalogAdded, AgentID: "c", AgentName: "Gamma"},
+	}
+
+	s := Summarize(events)
+	if len(s.AutoUpdated) != 1 || len(s.Pending) != 2 || len(s.CatalogAdded) != 1 {
+		t.Fatalf("unexpected grouping: %+v", s)
+	}
+	if s.Pending[0].AgentName != "Aardvark" {
+		t.Errorf("expected pending events sorted by name, got %q first", s.Pending[0].AgentName)
+	}
+	if s.Empty() {
+		t.Error("summary with events should not be empty")
+	}
+	if !Summarize(nil).Empty() {
+		t.Error("summary of no events should be empty")
+	}
+}
+
+func TestNotificationBody(t *testing.T) {
+	s := Summarize([]Event{
+		{Type: EventAutoUpdated, AgentName: "Alpha"},
+		{Type: EventUpdatePending, AgentName: "Beta"},
+		{Type: EventUpdatePending, AgentName: "Gamma"},
+	})
+	body := s.NotificationBody()
+	if !strings.Contains(body, "1 updated") || !strings.Contains(body, "2 update(s) pending") {
+		t.Errorf("NotificationBody() = %q", body)
+	}
+
+	if Summarize(nil).NotificationBody() != "No changes" {
+		t.Errorf("empty NotificationBody() = %q", Summarize(nil).NotificationBody())
+	}
+}
+
+func TestReports(t *testing.T) {
+	s := Summarize([]Event{
+		{Type: EventAutoUpdated, AgentName: "Alpha", Detail: "1.0.0 → 1.1.0"},
+		{Type: EventCatalogAdded, AgentName: "<script>"},
+	})
+
+	md := s.Markdown()
+	if !strings.Contains(md, "## Updated Automatically") || !strings.Contains(md, "- Alpha (1.0.0 → 1.1.0)") {
+		t.Errorf("Markdown() missing expected content:\n%s", md)
+	}
+
+	htmlOut := s.HTML()
+	if !strings.Contains(htmlOut, "<h2>New in Catalog</h2>") {
+		t.Errorf("HTML() missing section:\n%s", htmlOut)
+	}
+	if strings.Contains(htmlOut, "<script>") {
+		t.Error("HTML() should escape agent names")
+	}
+}
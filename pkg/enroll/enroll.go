@@ -0,0 +1,249 @@
+// Package enroll turns the helper into the endpoint of a simple fleet
+// management system. An enrolled machine registers with a central server
+// using a token, periodically reports its agent inventory, and polls for
+// commands (install an agent, pin a version). Commands carry an Ed25519
+// signature that must verify against the server's configured public key
+// before anything is executed.
+package enroll
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// settingDeviceID is the settings key holding the server-assigned device
+// ID once registration has succeeded.
+const settingDeviceID = "enrollment_device_id"
+
+// Command types the server may issue.
+const (
+	CommandInstall = "install"
+	CommandPin     = "pin"
+)
+
+// Command is a signed instruction from the management server.
+type Command struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	AgentID string `json:"agent_id"`
+
+	// Method selects the install method for install commands; empty
+	// lets the endpoint pick the first supported method
+	Method string `json:"method,omitempty"`
+
+	// Version is the pin target for pin commands
+	Version string `json:"version,omitempty"`
+
+	// Signature is the base64-encoded Ed25519 signature of the
+	// command's signing payload
+	Signature string `json:"signature"`
+}
+
+// SigningPayload is the byte sequence the server signs: the command
+// fields in declaration order, each prefixed with its big-endian uint32
+// length, without the signature itself. The length prefixes make the
+// encoding unambiguous — bytes cannot be moved between adjacent fields
+// without invalidating the signature.
+func (c *Command) SigningPayload() []byte {
+	var buf bytes.Buffer
+	for _, field := range []string{c.ID, c.Type, c.AgentID, c.Method, c.Version} {
+		var length [4]byte
+		binary.BigEndian.PutUint32(length[:], uint32(len(field)))
+		buf.Write(length[:])
+		buf.WriteString(field)
+	}
+	return buf.Bytes()
+}
+
+// Client talks to the central management server.
+type Client struct {
+	cfg        config.EnrollmentConfig
+	store      storage.Store
+	httpClient *http.Client
+	platformID string
+	version    string
+	publicKey  ed25519.PublicKey
+}
+
+// NewClient creates an enrollment client. The configured public key must
+// be a base64-encoded Ed25519 key; without a valid key no command can
+// ever verify, so this fails early instead.
+func NewClient(cfg config.EnrollmentConfig, store storage.Store, platformID, version string) (*Client, error) {
+	key, err := base64.StdEncoding.DecodeString(cfg.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid enrollment public key: %w", err)
+	}
+	if len(key) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid enrollment public key: got %d bytes, want %d", len(key), ed25519.PublicKeySize)
+	}
+
+	return &Client{
+		cfg:        cfg,
+		store:      store,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		platformID: platformID,
+		version:    version,
+		publicKey:  ed25519.PublicKey(key),
+	}, nil
+}
+
+// Register returns the device ID for this machine, registering with the
+// server on first use and persisting the assigned ID.
+func (c *Client) Register(ctx context.Context) (string, error) {
+	if id, err := c.store.GetSetting(ctx, settingDeviceID); err == nil && id != "" {
+		return id, nil
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+
+	req := map[string]string{
+		"token":    c.cfg.Token,
+		"hostname": hostname,
+		"platform": c.platformID,
+		"version":  c.version,
+	}
+
+	var resp struct {
+		DeviceID string `json:"device_id"`
+	}
+	if err := c.post(ctx, "/api/v1/enroll", req, &resp); err != nil {
+		return "", fmt.Errorf("enrollment failed: %w", err)
+	}
+	if resp.DeviceID == "" {
+		return "", fmt.Errorf("enrollment failed: server returned no device ID")
+	}
+
+	if err := c.store.SetSetting(ctx, settingDeviceID, resp.DeviceID); err != nil {
+		return "", fmt.Errorf("failed to save device ID: %w", err)
+	}
+	return resp.DeviceID, nil
+}
+
+// ReportInventory sends the current agent inventory to the server.
+func (c *Client) ReportInventory(ctx context.Context, deviceID string, installations []*agent.Installation) error {
+	inventory := make([]map[string]interface{}, len(installations))
+	for i, inst := range installations {
+		entry := map[string]interface{}{
+			"agent_id":          inst.AgentID,
+			"agent_name":        inst.AgentName,
+			"install_method":    string(inst.Method),
+			"installed_version": inst.InstalledVersion.String(),
+			"has_update":        inst.HasUpdate(),
+		}
+		if inst.LatestVersion != nil {
+			entry["latest_version"] = inst.LatestVersion.String()
+		}
+		inventory[i] = entry
+	}
+
+	body := map[string]interface{}{
+		"reported_at": time.Now().UTC(),
+		"agents":      inventory,
+	}
+	return c.post(ctx, "/api/v1/devices/"+deviceID+"/inventory", body, nil)
+}
+
+// FetchCommands retrieves the server's pending commands for this device.
+// Signatures are not checked here; callers verify each command before
+// acting on it.
+func (c *Client) FetchCommands(ctx context.Context, deviceID string) ([]Command, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.ServerURL+"/api/v1/devices/"+deviceID+"/commands", nil)
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Commands []Command `json:"commands"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode commands: %w", err)
+	}
+	return result.Commands, nil
+}
+
+// Verify checks a command's signature against the server's public key.
+func (c *Client) Verify(cmd *Command) error {
+	sig, err := base64.StdEncoding.DecodeString(cmd.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if !ed25519.Verify(c.publicKey, cmd.SigningPayload(), sig) {
+		return fmt.Errorf("signature verification failed for command %s", cmd.ID)
+	}
+	return nil
+}
+
+// Ack reports the outcome of a command back to the server.
+func (c *Client) Ack(ctx context.Context, deviceID, commandID, status, message string) error {
+	body := map[string]string{
+		"status":  status,
+		"message": message,
+	}
+	return c.post(ctx, "/api/v1/devices/"+deviceID+"/commands/"+commandID+"/ack", body, nil)
+}
+
+// post sends a JSON request and optionally decodes a JSON response.
+func (c *Client) post(ctx context.Context, path string, body, out interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.cfg.ServerURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("server returned status %d", resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// setHeaders applies the shared request headers.
+func (c *Client) setHeaders(req *http.Request) {
+	req.Header.Set("User-Agent", "AgentManager/1.0")
+	if c.cfg.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	}
+}
@@ -0,0 +1,208 @@
+package enroll
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func testKeys(t *testing.T) (ed25519.PublicKey, ed25519.PrivateKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pub, priv
+}
+
+func testClient(t *testing.T, serverURL string, pub ed25519.PublicKey) *Client {
+	t.Helper()
+	cfg := config.EnrollmentConfig{
+		Enabled:   true,
+		ServerURL: serverURL,
+		Token:     "enroll-token",
+		PublicKey: base64.StdEncoding.EncodeToString(pub),
+	}
+	client, err := NewClient(cfg, agentmgrtest.NewFakeStore(), "linux", "1.0.0")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	return client
+}
+
+func TestNewClientRejectsBadKey(t *testing.T) {
+	cfg := config.EnrollmentConfig{PublicKey: "not-base64!"}
+	if _, err := NewClient(cfg, agentmgrtest.NewFakeStore(), "linux", "1.0.0"); err == nil {
+		t.Error("expected error for invalid public key")
+	}
+
+	cfg.PublicKey = base64.StdEncoding.EncodeToString([]byte("short"))
+	if _, err := NewClient(cfg, agentmgrtest.NewFakeStore(), "linux", "1.0.0"); err == nil {
+		t.Error("expected error for wrong-size public key")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	pub, _ := testKeys(t)
+
+	var enrollCalls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/enroll" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer enroll-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		enrollCalls++
+
+		var req map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		if req["platform"] != "linux" {
+			t.Errorf("platform = %q", req["platform"])
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"device_id": "dev-42"})
+	}))
+	defer server.Close()
+
+	client := testClient(t, server.URL, pub)
+	ctx := context.Background()
+
+	id, err := client.Register(ctx)
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if id != "dev-42" {
+		t.Errorf("device ID = %q, want %q", id, "dev-42")
+	}
+
+	// A second call reuses the stored ID without hitting the server
+	id, err = client.Register(ctx)
+	if err != nil {
+		t.Fatalf("Register() second call error = %v", err)
+	}
+	if id != "dev-42" {
+		t.Errorf("device ID = %q, want %q", id, "dev-42")
+	}
+	if enrollCalls != 1 {
+		t.Errorf("enroll calls = %d, want 1", enrollCalls)
+	}
+}
+
+func TestReportInventory(t *testing.T) {
+	pub, _ := testKeys(t)
+
+	var payload struct {
+		Agents []map[string]interface{} `json:"agents"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/devices/dev-42/inventory" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := testClient(t, server.URL, pub)
+
+	installations := []*agent.Installation{
+		{AgentID: "aider", AgentName: "Aider", Method: "pip"},
+	}
+	if err := client.ReportInventory(context.Background(), "dev-42", installations); err != nil {
+		t.Fatalf("ReportInventory() error = %v", err)
+	}
+
+	if len(payload.Agents) != 1 || payload.Agents[0]["agent_id"] != "aider" {
+		t.Errorf("reported agents = %v", payload.Agents)
+	}
+}
+
+func TestFetchAndVerifyCommands(t *testing.T) {
+	pub, priv := testKeys(t)
+
+	valid := Command{ID: "cmd-1", Type: CommandInstall, AgentID: "aider", Method: "pip"}
+	valid.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, valid.SigningPayload()))
+
+	tampered := Command{ID: "cmd-2", Type: CommandPin, AgentID: "goose", Version: "2.0.0"}
+	tampered.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(priv, tampered.SigningPayload()))
+	tampered.Version = "9.9.9" // modified after signing
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"commands": []Command{valid, tampered},
+		})
+	}))
+	defer server.Close()
+
+	client := testClient(t, server.URL, pub)
+
+	commands, err := client.FetchCommands(context.Background(), "dev-42")
+	if err != nil {
+		t.Fatalf("FetchCommands() error = %v", err)
+	}
+	if len(commands) != 2 {
+		t.Fatalf("commands = %d, want 2", len(commands))
+	}
+
+	if err := client.Verify(&commands[0]); err != nil {
+		t.Errorf("Verify(valid) error = %v", err)
+	}
+	if err := client.Verify(&commands[1]); err == nil {
+		t.Error("Verify(tampered) should fail")
+	}
+}
+
+func TestAck(t *testing.T) {
+	pub, _ := testKeys(t)
+
+	var acked map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/devices/dev-42/commands/cmd-1/ack" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&acked); err != nil {
+			t.Fatal(err)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := testClient(t, server.URL, pub)
+
+	if err := client.Ack(context.Background(), "dev-42", "cmd-1", "completed", "installed"); err != nil {
+		t.Fatalf("Ack() error = %v", err)
+	}
+	if acked["status"] != "completed" || acked["message"] != "installed" {
+		t.Errorf("ack payload = %v", acked)
+	}
+}
+
+func TestSigningPayloadUnambiguous(t *testing.T) {
+	// With a delimiter-based payload these two commands would sign
+	// identically, letting an intermediary move bytes between fields.
+	a := Command{ID: "cmd-1", Type: CommandInstall, AgentID: "foo\nbrew"}
+	b := Command{ID: "cmd-1", Type: CommandInstall, AgentID: "foo", Method: "brew"}
+
+	if bytes.Equal(a.SigningPayload(), b.SigningPayload()) {
+		t.Error("SigningPayload() is ambiguous across field boundaries")
+	}
+
+	// The payload is a pure function of the fields
+	if !bytes.Equal(a.SigningPayload(), a.SigningPayload()) {
+		t.Error("SigningPayload() is not deterministic")
+	}
+}
@@ -0,0 +1,89 @@
+// Package errcode defines the stable error codes shared by the CLI,
+// the IPC protocol and the REST API, and a typed error carrying one.
+// Wrapping errors with a code lets callers distinguish "network down"
+// from "not found" from "permission denied" without parsing messages,
+// and keeps the codes in API error bodies stable across releases.
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Code classifies an error for programmatic handling. Codes overlap
+// with the ones the IPC protocol already uses in ErrorResponse.Code.
+type Code string
+
+const (
+	// CodeNotFound means the requested agent, job, or resource does not exist.
+	CodeNotFound Code = "not_found"
+	// CodeNetwork means a remote endpoint could not be reached; retrying may help.
+	CodeNetwork Code = "network_error"
+	// CodePermission means the operation was denied by the OS or a remote service.
+	CodePermission Code = "permission_denied"
+	// CodeUnavailable means required tooling (npm, brew, ...) is not installed.
+	CodeUnavailable Code = "tool_unavailable"
+	// CodeInvalid means the request or input was malformed.
+	CodeInvalid Code = "invalid_request"
+	// CodeStorage means the local database failed.
+	CodeStorage Code = "storage_error"
+	// CodeLockedDown means policy forbids the operation on this machine.
+	CodeLockedDown Code = "locked_down"
+	// CodeInternal is the fallback for unclassified failures.
+	CodeInternal Code = "internal_error"
+)
+
+// Error is an error with a stable code attached. The message comes
+// from the wrapped error, so it reads like any other error string.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Errorf builds a coded error; the format behaves like fmt.Errorf,
+// including %w wrapping.
+func Errorf(code Code, format string, args ...interface{}) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Of returns the code attached to an error, walking the wrap chain,
+// or "" when the error carries none.
+func Of(err error) Code {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ""
+}
+
+// Is reports whether the error carries the given code.
+func Is(err error, code Code) bool {
+	return Of(err) == code
+}
+
+// HTTPStatus maps a code to the HTTP status the REST API responds with.
+func HTTPStatus(code Code) int {
+	switch code {
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeInvalid:
+		return http.StatusBadRequest
+	case CodePermission, CodeLockedDown:
+		return http.StatusForbidden
+	case CodeUnavailable:
+		return http.StatusServiceUnavailable
+	case CodeNetwork:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
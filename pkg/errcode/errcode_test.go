@@ -0,0 +1,73 @@
+package errcode
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestErrorfCarriesCode(t *testing.T) {
+	err := Errorf(CodeNotFound, "agent %s not found", "aider")
+
+	if Of(err) != CodeNotFound {
+		t.Errorf("Of() = %q, want %q", Of(err), CodeNotFound)
+	}
+	if err.Error() != "agent aider not found" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !Is(err, CodeNotFound) {
+		t.Error("Is(CodeNotFound) = false")
+	}
+	if Is(err, CodeNetwork) {
+		t.Error("Is(CodeNetwork) = true")
+	}
+}
+
+func TestOfWalksWrapChain(t *testing.T) {
+	inner := Errorf(CodeNetwork, "connection refused")
+	outer := fmt.Errorf("catalog refresh failed: %w", inner)
+
+	if Of(outer) != CodeNetwork {
+		t.Errorf("Of() = %q, want %q", Of(outer), CodeNetwork)
+	}
+}
+
+func TestOfUntypedError(t *testing.T) {
+	if Of(errors.New("plain")) != "" {
+		t.Errorf("Of() = %q for an untyped error, want empty", Of(errors.New("plain")))
+	}
+	if Of(nil) != "" {
+		t.Error("Of(nil) should be empty")
+	}
+}
+
+func TestErrorfWrapping(t *testing.T) {
+	cause := errors.New("underlying")
+	err := Errorf(CodeStorage, "save failed: %w", cause)
+
+	if !errors.Is(err, cause) {
+		t.Error("errors.Is should find the wrapped cause")
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		code Code
+		want int
+	}{
+		{CodeNotFound, http.StatusNotFound},
+		{CodeInvalid, http.StatusBadRequest},
+		{CodePermission, http.StatusForbidden},
+		{CodeLockedDown, http.StatusForbidden},
+		{CodeUnavailable, http.StatusServiceUnavailable},
+		{CodeNetwork, http.StatusBadGateway},
+		{CodeInternal, http.StatusInternalServerError},
+		{Code(""), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := HTTPStatus(tt.code); got != tt.want {
+			t.Errorf("HTTPStatus(%q) = %d, want %d", tt.code, got, tt.want)
+		}
+	}
+}
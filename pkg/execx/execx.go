@@ -0,0 +1,220 @@
+// Package execx provides a shared command runner for strategies and
+// providers with consistent context timeouts, environment injection,
+// bounded output capture, and retry policies for flaky commands.
+package execx
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// DefaultMaxOutput caps captured stdout and stderr per stream so runaway
+// commands cannot exhaust memory.
+const DefaultMaxOutput = 1 << 20 // 1 MiB
+
+// Result holds the captured outcome of a command run.
+type Result struct {
+	Stdout    string        `json:"stdout"`
+	Stderr    string        `json:"stderr"`
+	ExitCode  int           `json:"exit_code"`
+	Duration  time.Duration `json:"duration"`
+	Attempts  int           `json:"attempts"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// Runner executes commands. The default implementation shells out via
+// os/exec; tests can substitute a fake.
+type Runner interface {
+	Run(ctx context.Context, name string, args []string, opts ...Option) (*Result, error)
+}
+
+// Option configures a single command run.
+type Option func(*settings)
+
+type settings struct {
+	timeout   time.Duration
+	dir       string
+	env       []string
+	stdin     []byte
+	maxOutput int
+	retries   int
+	retryWait time.Duration
+	retryIf   func(error) bool
+}
+
+// WithTimeout bounds the run with a deadline on top of the caller's context.
+func WithTimeout(d time.Duration) Option {
+	return func(s *settings) { s.timeout = d }
+}
+
+// WithDir sets the working directory for the command.
+func WithDir(dir string) Option {
+	return func(s *settings) { s.dir = dir }
+}
+
+// WithEnv appends KEY=value pairs to the inherited environment.
+func WithEnv(env ...string) Option {
+	return func(s *settings) { s.env = append(s.env, env...) }
+}
+
+// WithStdin supplies input to the command.
+func WithStdin(input []byte) Option {
+	return func(s *settings) { s.stdin = input }
+}
+
+// WithMaxOutput overrides the per-stream output capture limit.
+func WithMaxOutput(n int) Option {
+	return func(s *settings) { s.maxOutput = n }
+}
+
+// WithRetries re-runs a failed command up to n additional times, waiting
+// between attempts. Context cancellation is never retried.
+func WithRetries(n int, wait time.Duration) Option {
+	return func(s *settings) {
+		s.retries = n
+		s.retryWait = wait
+	}
+}
+
+// WithRetryIf restricts which errors are retried.
+func WithRetryIf(f func(error) bool) Option {
+	return func(s *settings) { s.retryIf = f }
+}
+
+// runner is the default os/exec-backed Runner.
+type runner struct{}
+
+// New creates the default command runner.
+func New() Runner {
+	return &runner{}
+}
+
+// Default is the runner used by the package-level Run helper. Tests may
+// replace it to intercept command execution.
+var Default Runner = New()
+
+// Run executes a command with the Default runner.
+func Run(ctx context.Context, name string, args []string, opts ...Option) (*Result, error) {
+	return Default.Run(ctx, name, args, opts...)
+}
+
+// Run executes the command, applying timeout, retry, and capture settings.
+func (r *runner) Run(ctx context.Context, name string, args []string, opts ...Option) (*Result, error) {
+	s := settings{maxOutput: DefaultMaxOutput}
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	if s.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.timeout)
+		defer cancel()
+	}
+
+	var result *Result
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		result, err = r.runOnce(ctx, name, args, &s)
+		result.Attempts = attempt
+
+		if err == nil || attempt > s.retries {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if s.retryIf != nil && !s.retryIf(err) {
+			break
+		}
+
+		select {
+		case <-time.After(s.retryWait):
+		case <-ctx.Done():
+			return result, err
+		}
+	}
+
+	return result, err
+}
+
+// runOnce performs a single command execution.
+func (r *runner) runOnce(ctx context.Context, name string, args []string, s *settings) (*Result, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	setProcessGroup(cmd)
+	cmd.Dir = s.dir
+	if len(s.env) > 0 {
+		cmd.Env = append(cmd.Environ(), s.env...)
+	}
+	if s.stdin != nil {
+		cmd.Stdin = bytes.NewReader(s.stdin)
+	}
+
+	stdout := newLimitBuffer(s.maxOutput)
+	stderr := newLimitBuffer(s.maxOutput)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+
+	result := &Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  time.Since(start),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.ExitCode = -1
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return result, fmt.Errorf("%s timed out: %w", name, ctxErr)
+		}
+		return result, fmt.Errorf("%s failed: %w\n%s", name, err, result.Stderr)
+	}
+
+	return result, nil
+}
+
+// limitBuffer captures output up to a byte limit, discarding the rest.
+type limitBuffer struct {
+	buf       bytes.Buffer
+	remaining int
+	truncated bool
+}
+
+func newLimitBuffer(limit int) *limitBuffer {
+	return &limitBuffer{remaining: limit}
+}
+
+// Write implements io.Writer, silently dropping bytes past the limit so
+// the command is never blocked on a full pipe.
+func (b *limitBuffer) Write(p []byte) (int, error) {
+	n := len(p)
+	if b.remaining <= 0 {
+		b.truncated = b.truncated || n > 0
+		return n, nil
+	}
+	if n > b.remaining {
+		b.buf.Write(p[:b.remaining])
+		b.remaining = 0
+		b.truncated = true
+		return n, nil
+	}
+	b.buf.Write(p)
+	b.remaining -= n
+	return n, nil
+}
+
+func (b *limitBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,158 @@
+package execx
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sh runs a shell snippet through the default runner; tests that need a
+// real process are skipped on Windows.
+func sh(t *testing.T, script string, opts ...Option) (*Result, error) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("shell-based test not supported on windows")
+	}
+	return New().Run(context.Background(), "sh", []string{"-c", script}, opts...)
+}
+
+func TestRunCapturesOutput(t *testing.T) {
+	result, err := sh(t, "echo out; echo err >&2")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if strings.TrimSpace(result.Stdout) != "out" {
+		t.Errorf("Stdout = %q, want %q", result.Stdout, "out")
+	}
+	if strings.TrimSpace(result.Stderr) != "err" {
+		t.Errorf("Stderr = %q, want %q", result.Stderr, "err")
+	}
+	if result.ExitCode != 0 || result.Attempts != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	result, err := sh(t, "echo broken >&2; exit 3")
+	if err == nil {
+		t.Fatal("expected error for failing command")
+	}
+	if result.ExitCode != 3 {
+		t.Errorf("ExitCode = %d, want 3", result.ExitCode)
+	}
+	if !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected stderr in error, got %v", err)
+	}
+}
+
+func TestRunRetries(t *testing.T) {
+	counter := filepath.Join(t.TempDir(), "attempts")
+	script := fmt.Sprintf(
+		`n=$(cat %[1]q 2>/dev/null || echo 0); n=$((n+1)); echo $n > %[1]q; [ $n -ge 3 ]`,
+		counter)
+
+	result, err := sh(t, script, WithRetries(5, time.Millisecond))
+	if err != nil {
+		t.Fatalf("expected retries to eventually succeed: %v", err)
+	}
+	if result.Attempts != 3 {
+		t.Errorf("Attempts = %d, want 3", result.Attempts)
+	}
+}
+
+func TestRunRetryIf(t *testing.T) {
+	_, err := sh(t, "exit 1",
+		WithRetries(5, time.Millisecond),
+		WithRetryIf(func(error) bool { return false }))
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	_, err := sh(t, "sleep 5", WithTimeout(50*time.Millisecond))
+	if err == nil {
+		t.Fatal("expected timeout error")
+	}
+	if !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("expected timeout in error, got %v", err)
+	}
+}
+
+func TestRunEnvAndDir(t *testing.T) {
+	dir := t.TempDir()
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		resolved = dir
+	}
+
+	result, runErr := sh(t, "echo $EXECX_TEST; pwd",
+		WithDir(dir), WithEnv("EXECX_TEST=hello"))
+	if runErr != nil {
+		t.Fatalf("Run failed: %v", runErr)
+	}
+	lines := strings.Split(strings.TrimSpace(result.Stdout), "\n")
+	if len(lines) != 2 || lines[0] != "hello" {
+		t.Fatalf("unexpected output: %q", result.Stdout)
+	}
+	gotDir, err := filepath.EvalSymlinks(lines[1])
+	if err != nil || gotDir != resolved {
+		t.Errorf("working dir = %q, want %q", lines[1], resolved)
+	}
+}
+
+func TestRunMissingCommand(t *testing.T) {
+	_, err := New().Run(context.Background(), "definitely-not-a-command-xyz", nil)
+	if err == nil {
+		t.Fatal("expected error for missing command")
+	}
+}
+
+func TestLimitBuffer(t *testing.T) {
+	b := newLimitBuffer(5)
+
+	if n, _ := b.Write([]byte("abc")); n != 3 {
+		t.Errorf("Write returned %d, want 3", n)
+	}
+	if n, _ := b.Write([]byte("defgh")); n != 5 {
+		t.Errorf("Write returned %d, want 5", n)
+	}
+	if b.String() != "abcde" {
+		t.Errorf("String() = %q, want %q", b.String(), "abcde")
+	}
+	if !b.truncated {
+		t.Error("expected buffer to report truncation")
+	}
+}
+
+func TestFakeRunner(t *testing.T) {
+	fake := &Fake{
+		Handler: func(name string, args []string) (*Result, error) {
+			if name == "npm" {
+				return &Result{Stdout: "1.2.3\n"}, nil
+			}
+			return nil, fmt.Errorf("%s failed: not scripted", name)
+		},
+	}
+
+	result, err := fake.Run(context.Background(), "npm", []string{"view", "pkg", "version"})
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if result.Stdout != "1.2.3\n" || result.Attempts != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if _, err := fake.Run(context.Background(), "brew", nil); err == nil {
+		t.Error("expected unscripted command to fail")
+	}
+
+	calls := fake.Calls()
+	if len(calls) != 2 || calls[0].Name != "npm" || calls[1].Name != "brew" {
+		t.Errorf("unexpected calls: %+v", calls)
+	}
+}
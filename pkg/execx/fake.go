@@ -0,0 +1,52 @@
+package execx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Call records a single command invocation made against a Fake runner.
+type Call struct {
+	Name string
+	Args []string
+}
+
+// Fake is a scripted Runner for tests. Each run is dispatched to Handler
+// and recorded; if Handler is nil every command fails.
+type Fake struct {
+	Handler func(name string, args []string) (*Result, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+// Run implements Runner by invoking the scripted handler.
+func (f *Fake) Run(ctx context.Context, name string, args []string, opts ...Option) (*Result, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Name: name, Args: args})
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return &Result{ExitCode: -1, Attempts: 1}, err
+	}
+	if f.Handler == nil {
+		return &Result{ExitCode: -1, Attempts: 1}, fmt.Errorf("%s failed: no handler configured", name)
+	}
+
+	result, err := f.Handler(name, args)
+	if result == nil {
+		result = &Result{}
+	}
+	if result.Attempts == 0 {
+		result.Attempts = 1
+	}
+	return result, err
+}
+
+// Calls returns the recorded invocations in order.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
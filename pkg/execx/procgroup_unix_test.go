@@ -0,0 +1,80 @@
+//go:build !windows
+
+package execx
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRunCancellationKillsProcessGroup(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The shell backgrounds a long sleep and records its pid; killing
+	// only the shell would leave the sleep running.
+	done := make(chan error, 1)
+	go func() {
+		_, err := Run(ctx, "sh", []string{"-c", "sleep 60 & echo $! > " + pidFile + "; wait"})
+		done <- err
+	}()
+
+	var pid int
+	deadline := time.Now().Add(5 * time.Second)
+	for pid == 0 {
+		if data, err := os.ReadFile(pidFile); err == nil {
+			if parsed, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && parsed > 0 {
+				pid = parsed
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("background child pid never appeared")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error from the cancelled run")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled run did not return")
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for processAlive(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("background child %d survived cancellation", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// processAlive reports whether pid is still running; zombies awaiting a
+// reaper count as dead.
+func processAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		// No procfs (e.g. macOS); the signal reached something, assume alive
+		return true
+	}
+	// The state letter follows the parenthesised command name
+	if idx := strings.LastIndexByte(string(data), ')'); idx >= 0 && idx+2 < len(data) {
+		return data[idx+2] != 'Z'
+	}
+	return true
+}
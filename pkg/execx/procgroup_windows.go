@@ -0,0 +1,9 @@
+//go:build windows
+
+package execx
+
+import "os/exec"
+
+// setProcessGroup is a no-op on Windows; exec.CommandContext already
+// terminates the process on cancellation.
+func setProcessGroup(cmd *exec.Cmd) {}
@@ -0,0 +1,90 @@
+// Package feed renders agent release notes as an Atom feed, so users who
+// subscribe to agents can follow their releases in a feed reader. Atom is
+// used over RSS because its schema is stricter and every mainstream
+// reader accepts it.
+package feed
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Entry is a single feed item, typically one agent release.
+type Entry struct {
+	Title   string
+	ID      string
+	Link    string
+	Content string
+	Updated time.Time
+}
+
+// Feed is an Atom feed ready to be rendered.
+type Feed struct {
+	Title   string
+	ID      string
+	Updated time.Time
+	Entries []Entry
+}
+
+// atomFeed mirrors the Atom 1.0 document structure for XML encoding.
+type atomFeed struct {
+	XMLName xml.Name    `xml:"feed"`
+	XMLNS   string      `xml:"xmlns,attr"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Link    *atomLink   `xml:"link,omitempty"`
+	Updated string      `xml:"updated"`
+	Content atomContent `xml:"content"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomContent struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// Atom renders the feed as an Atom 1.0 document.
+func (f Feed) Atom() ([]byte, error) {
+	doc := atomFeed{
+		XMLNS:   "http://www.w3.org/2005/Atom",
+		Title:   f.Title,
+		ID:      f.ID,
+		Updated: atomTime(f.Updated),
+	}
+	for _, entry := range f.Entries {
+		ae := atomEntry{
+			Title:   entry.Title,
+			ID:      entry.ID,
+			Updated: atomTime(entry.Updated),
+			Content: atomContent{Type: "text", Body: entry.Content},
+		}
+		if entry.Link != "" {
+			ae.Link = &atomLink{Href: entry.Link}
+		}
+		doc.Entries = append(doc.Entries, ae)
+	}
+
+	body, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// atomTime formats a timestamp as Atom requires (RFC 3339).
+func atomTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Unix(0, 0)
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAtom(t *testing.T) {
+	f := Feed{
+		Title:   "AgentManager Releases",
+		ID:      "urn:agentmgr:releases",
+		Updated: time.Date(2026, 8, 10, 9, 0, 0, 0, time.UTC),
+		Entries: []Entry{
+			{
+				Title:   "Aider 1.2.3",
+				ID:      "urn:agentmgr:release:aider:1.2.3",
+				Link:    "https://example.com/releases/1.2.3",
+				Content: "Bug fixes & improvements",
+				Updated: time.Date(2026, 8, 9, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	out, err := f.Atom()
+	if err != nil {
+		t.Fatalf("Atom() error = %v", err)
+	}
+	doc := string(out)
+
+	for _, want := range []string{
+		`<feed xmlns="http://www.w3.org/2005/Atom">`,
+		"<title>AgentManager Releases</title>",
+		"<id>urn:agentmgr:release:aider:1.2.3</id>",
+		`<link href="https://example.com/releases/1.2.3">`,
+		"<updated>2026-08-09T12:00:00Z</updated>",
+		"Bug fixes &amp; improvements",
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("feed missing %q:\n%s", want, doc)
+		}
+	}
+
+	// The document must stay well-formed XML
+	var parsed atomFeed
+	if err := xml.Unmarshal(out, &parsed); err != nil {
+		t.Fatalf("feed is not valid XML: %v", err)
+	}
+	if len(parsed.Entries) != 1 {
+		t.Errorf("parsed entries = %d, want 1", len(parsed.Entries))
+	}
+}
+
+func TestAtomEmptyFeed(t *testing.T) {
+	out, err := Feed{Title: "Empty", ID: "urn:empty"}.Atom()
+	if err != nil {
+		t.Fatalf("Atom() error = %v", err)
+	}
+	if !strings.Contains(string(out), "<title>Empty</title>") {
+		t.Errorf("empty feed missing title:\n%s", out)
+	}
+}
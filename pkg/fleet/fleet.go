@@ -0,0 +1,175 @@
+// Package fleet provides remote agent management over SSH for teams
+// standardizing agent versions across multiple machines.
+package fleet
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+// HostResult is the outcome of running an operation on a single host.
+type HostResult struct {
+	Host          config.FleetHost      `json:"host"`
+	Installations []*agent.Installation `json:"installations,omitempty"`
+	Output        string                `json:"output,omitempty"`
+	Error         string                `json:"error,omitempty"`
+	Duration      time.Duration         `json:"duration"`
+}
+
+// Succeeded returns true if the operation completed without error.
+func (r HostResult) Succeeded() bool {
+	return r.Error == ""
+}
+
+// Manager runs agentmgr operations on remote hosts over SSH.
+type Manager struct {
+	hosts       []config.FleetHost
+	connTimeout time.Duration
+	remoteBin   string
+}
+
+// NewManager creates a fleet manager from the fleet configuration.
+func NewManager(cfg config.FleetConfig) *Manager {
+	connTimeout := cfg.ConnectTimeout
+	if connTimeout <= 0 {
+		connTimeout = 10 * time.Second
+	}
+
+	remoteBin := cfg.RemoteBinary
+	if remoteBin == "" {
+		remoteBin = "agentmgr"
+	}
+
+	return &Manager{
+		hosts:       cfg.Hosts,
+		connTimeout: connTimeout,
+		remoteBin:   remoteBin,
+	}
+}
+
+// Hosts returns the configured fleet hosts.
+func (m *Manager) Hosts() []config.FleetHost {
+	return m.hosts
+}
+
+// DetectAll runs agent detection on every configured host concurrently and
+// returns per-host results.
+func (m *Manager) DetectAll(ctx context.Context) []HostResult {
+	results := make([]HostResult, len(m.hosts))
+
+	var wg sync.WaitGroup
+	for i, host := range m.hosts {
+		wg.Add(1)
+		go func(idx int, h config.FleetHost) {
+			defer wg.Done()
+			results[idx] = m.detectHost(ctx, h)
+		}(i, host)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// detectHost runs remote detection on a single host.
+func (m *Manager) detectHost(ctx context.Context, host config.FleetHost) HostResult {
+	start := time.Now()
+	result := HostResult{Host: host}
+
+	output, err := m.Run(ctx, host, m.remoteBin, "agent", "list", "--format", "json")
+	result.Duration = time.Since(start)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	var installations []*agent.Installation
+	if err := json.Unmarshal([]byte(output), &installations); err != nil {
+		result.Error = fmt.Sprintf("failed to parse remote output: %v", err)
+		result.Output = output
+		return result
+	}
+
+	result.Installations = installations
+	return result
+}
+
+// Run executes a command on the given host and returns its stdout.
+// SSH connection multiplexing (ControlMaster) is used so repeated commands
+// to the same host reuse one connection.
+func (m *Manager) Run(ctx context.Context, host config.FleetHost, command ...string) (string, error) {
+	args := m.sshArgs(host)
+	args = append(args, command...)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "ssh", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("ssh to %s failed: %w\n%s", host.Name, err, stderr.String())
+	}
+
+	return stdout.String(), nil
+}
+
+// sshArgs builds the ssh argument list for a host.
+func (m *Manager) sshArgs(host config.FleetHost) []string {
+	args := []string{
+		"-o", "BatchMode=yes",
+		"-o", fmt.Sprintf("ConnectTimeout=%d", int(m.connTimeout.Seconds())),
+		// Reuse connections across commands to the same host
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=~/.ssh/agentmgr-%r@%h:%p",
+		"-o", "ControlPersist=60s",
+	}
+
+	if host.Port > 0 {
+		args = append(args, "-p", strconv.Itoa(host.Port))
+	}
+	if host.IdentityFile != "" {
+		args = append(args, "-i", host.IdentityFile)
+	}
+
+	target := host.Address
+	if host.User != "" {
+		target = host.User + "@" + host.Address
+	}
+	args = append(args, target)
+
+	return args
+}
+
+// Summary aggregates results across all hosts into a single view.
+type Summary struct {
+	Hosts            int `json:"hosts"`
+	Reachable        int `json:"reachable"`
+	TotalAgents      int `json:"total_agents"`
+	UpdatesAvailable int `json:"updates_available"`
+}
+
+// Summarize computes an aggregate summary from per-host results.
+func Summarize(results []HostResult) Summary {
+	s := Summary{Hosts: len(results)}
+	for _, r := range results {
+		if !r.Succeeded() {
+			continue
+		}
+		s.Reachable++
+		s.TotalAgents += len(r.Installations)
+		for _, inst := range r.Installations {
+			if inst.HasUpdate() {
+				s.UpdatesAvailable++
+			}
+		}
+	}
+	return s
+}
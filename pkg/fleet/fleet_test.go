@@ -0,0 +1,89 @@
+package fleet
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func TestSSHArgs(t *testing.T) {
+	m := NewManager(config.FleetConfig{ConnectTimeout: 5 * time.Second})
+
+	host := config.FleetHost{
+		Name:         "devbox",
+		Address:      "10.0.0.5",
+		User:         "kevin",
+		Port:         2222,
+		IdentityFile: "/home/kevin/.ssh/id_ed25519",
+	}
+
+	args := m.sshArgs(host)
+	joined := strings.Join(args, " ")
+
+	if !strings.Contains(joined, "kevin@10.0.0.5") {
+		t.Errorf("expected user@address target, got %s", joined)
+	}
+	if !strings.Contains(joined, "-p 2222") {
+		t.Errorf("expected port flag, got %s", joined)
+	}
+	if !strings.Contains(joined, "-i /home/kevin/.ssh/id_ed25519") {
+		t.Errorf("expected identity file flag, got %s", joined)
+	}
+	if !strings.Contains(joined, "ConnectTimeout=5") {
+		t.Errorf("expected connect timeout, got %s", joined)
+	}
+	if !strings.Contains(joined, "ControlMaster=auto") {
+		t.Errorf("expected connection multiplexing, got %s", joined)
+	}
+}
+
+func TestSSHArgsDefaults(t *testing.T) {
+	m := NewManager(config.FleetConfig{})
+
+	args := m.sshArgs(config.FleetHost{Name: "plain", Address: "example.com"})
+	joined := strings.Join(args, " ")
+
+	if !strings.HasSuffix(joined, "example.com") {
+		t.Errorf("expected bare address target, got %s", joined)
+	}
+	if strings.Contains(joined, "-p ") {
+		t.Errorf("expected no port flag, got %s", joined)
+	}
+	if !strings.Contains(joined, "ConnectTimeout=10") {
+		t.Errorf("expected default connect timeout, got %s", joined)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	latest := agent.MustParseVersion("2.0.0")
+	results := []HostResult{
+		{
+			Host: config.FleetHost{Name: "a"},
+			Installations: []*agent.Installation{
+				{AgentID: "aider", InstalledVersion: agent.MustParseVersion("1.0.0"), LatestVersion: &latest},
+				{AgentID: "claude-code", InstalledVersion: agent.MustParseVersion("2.0.0")},
+			},
+		},
+		{
+			Host:  config.FleetHost{Name: "b"},
+			Error: "connection refused",
+		},
+	}
+
+	s := Summarize(results)
+	if s.Hosts != 2 {
+		t.Errorf("expected 2 hosts, got %d", s.Hosts)
+	}
+	if s.Reachable != 1 {
+		t.Errorf("expected 1 reachable, got %d", s.Reachable)
+	}
+	if s.TotalAgents != 2 {
+		t.Errorf("expected 2 agents, got %d", s.TotalAgents)
+	}
+	if s.UpdatesAvailable != 1 {
+		t.Errorf("expected 1 update available, got %d", s.UpdatesAvailable)
+	}
+}
@@ -0,0 +1,245 @@
+// Package gc finds and removes orphaned artifacts left behind by removed
+// agents: dangling npm global packages, stale pipx venvs, and broken
+// symlinks in common bin directories.
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// OrphanType categorizes an orphaned artifact.
+type OrphanType string
+
+const (
+	OrphanNPMPackage    OrphanType = "npm_package"
+	OrphanPipxVenv      OrphanType = "pipx_venv"
+	OrphanBrokenSymlink OrphanType = "broken_symlink"
+)
+
+// Orphan represents a leftover artifact that can be cleaned up.
+type Orphan struct {
+	Type        OrphanType `json:"type"`
+	Name        string     `json:"name"`
+	Path        string     `json:"path,omitempty"`
+	Description string     `json:"description"`
+}
+
+// Scanner finds orphaned agent artifacts on the system.
+type Scanner struct {
+	platform platform.Platform
+
+	// binDirs overrides the symlink scan directories (for testing).
+	binDirs []string
+}
+
+// NewScanner creates a new garbage collection scanner.
+func NewScanner(p platform.Platform) *Scanner {
+	return &Scanner{platform: p}
+}
+
+// SetBinDirs overrides the symlink scan directories. Primarily for testing.
+func (s *Scanner) SetBinDirs(dirs []string) {
+	s.binDirs = dirs
+}
+
+// Scan finds all orphaned artifacts. The catalog is used to decide which
+// packages are agent-related: only packages that appear in some catalog
+// agent's install method but have no matching catalog entry installed via
+// agentmgr-known names are never flagged; unknown packages are left alone.
+func (s *Scanner) Scan(ctx context.Context, cat *catalog.Catalog) ([]Orphan, error) {
+	var orphans []Orphan
+
+	orphans = append(orphans, s.scanBrokenSymlinks()...)
+	orphans = append(orphans, s.scanNPMPackages(ctx, cat)...)
+	orphans = append(orphans, s.scanPipxVenvs(ctx, cat)...)
+
+	return orphans, nil
+}
+
+// Clean removes the given orphans. Returns the orphans that could not be
+// removed, with errors joined.
+func (s *Scanner) Clean(ctx context.Context, orphans []Orphan) error {
+	var failures []string
+
+	for _, o := range orphans {
+		var err error
+		switch o.Type {
+		case OrphanBrokenSymlink:
+			err = os.Remove(o.Path)
+		case OrphanNPMPackage:
+			err = exec.CommandContext(ctx, "npm", "uninstall", "-g", o.Name).Run()
+		case OrphanPipxVenv:
+			err = exec.CommandContext(ctx, "pipx", "uninstall", o.Name).Run()
+		default:
+			err = fmt.Errorf("unknown orphan type: %s", o.Type)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s %s: %v", o.Type, o.Name, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to clean %d artifact(s):\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// scanBrokenSymlinks finds dangling symlinks in common bin directories.
+func (s *Scanner) scanBrokenSymlinks() []Orphan {
+	dirs := s.binDirs
+	if len(dirs) == 0 {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		dirs = []string{
+			filepath.Join(home, ".local", "bin"),
+			filepath.Join(home, "bin"),
+		}
+	}
+
+	var orphans []Orphan
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if entry.Type()&os.ModeSymlink == 0 {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+			if _, err := os.Stat(path); err == nil {
+				continue // Target exists
+			}
+
+			target, _ := os.Readlink(path)
+			orphans = append(orphans, Orphan{
+				Type:        OrphanBrokenSymlink,
+				Name:        entry.Name(),
+				Path:        path,
+				Description: fmt.Sprintf("broken symlink to %s", target),
+			})
+		}
+	}
+
+	return orphans
+}
+
+// scanNPMPackages finds globally installed npm packages that the catalog
+// knows about but whose executables are gone.
+func (s *Scanner) scanNPMPackages(ctx context.Context, cat *catalog.Catalog) []Orphan {
+	if cat == nil || !s.platform.IsExecutableInPath("npm") {
+		return nil
+	}
+
+	output, err := exec.CommandContext(ctx, "npm", "list", "-g", "--depth=0", "--json").Output()
+	if err != nil && len(output) == 0 {
+		return nil
+	}
+
+	var listed struct {
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil {
+		return nil
+	}
+
+	var orphans []Orphan
+	for pkg := range listed.Dependencies {
+		agentDef, ok := agentForPackage(cat, "npm", pkg)
+		if !ok {
+			continue // Not an agent package; leave it alone
+		}
+
+		// If none of the agent's executables resolve, the install is stale
+		if s.anyExecutableExists(agentDef) {
+			continue
+		}
+
+		orphans = append(orphans, Orphan{
+			Type:        OrphanNPMPackage,
+			Name:        pkg,
+			Description: fmt.Sprintf("npm package for %s with no working executable", agentDef.Name),
+		})
+	}
+
+	return orphans
+}
+
+// scanPipxVenvs finds pipx venvs for catalog agents whose executables are gone.
+func (s *Scanner) scanPipxVenvs(ctx context.Context, cat *catalog.Catalog) []Orphan {
+	if cat == nil || !s.platform.IsExecutableInPath("pipx") {
+		return nil
+	}
+
+	output, err := exec.CommandContext(ctx, "pipx", "list", "--json").Output()
+	if err != nil {
+		return nil
+	}
+
+	var listed struct {
+		Venvs map[string]json.RawMessage `json:"venvs"`
+	}
+	if err := json.Unmarshal(output, &listed); err != nil {
+		return nil
+	}
+
+	var orphans []Orphan
+	for venv := range listed.Venvs {
+		agentDef, ok := agentForPackage(cat, "pipx", venv)
+		if !ok {
+			continue
+		}
+
+		if s.anyExecutableExists(agentDef) {
+			continue
+		}
+
+		orphans = append(orphans, Orphan{
+			Type:        OrphanPipxVenv,
+			Name:        venv,
+			Description: fmt.Sprintf("pipx venv for %s with no working executable", agentDef.Name),
+		})
+	}
+
+	return orphans
+}
+
+// anyExecutableExists returns true if any of the agent's executables are in PATH.
+func (s *Scanner) anyExecutableExists(agentDef catalog.AgentDef) bool {
+	for _, exe := range agentDef.Detection.Executables {
+		if s.platform.IsExecutableInPath(exe) {
+			return true
+		}
+	}
+	return false
+}
+
+// agentForPackage finds the catalog agent that installs the given package
+// via the given method.
+func agentForPackage(cat *catalog.Catalog, method, pkg string) (catalog.AgentDef, bool) {
+	for _, agentDef := range cat.Agents {
+		m, ok := agentDef.InstallMethods[method]
+		if !ok {
+			continue
+		}
+		if m.Package == pkg {
+			return agentDef, true
+		}
+	}
+	return catalog.AgentDef{}, false
+}
@@ -0,0 +1,92 @@
+package gc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestScanBrokenSymlinks(t *testing.T) {
+	dir := t.TempDir()
+
+	// A valid symlink
+	target := filepath.Join(dir, "real-binary")
+	if err := os.WriteFile(target, []byte("bin"), 0o755); err != nil {
+		t.Fatalf("failed to write target: %v", err)
+	}
+	if err := os.Symlink(target, filepath.Join(dir, "good-link")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	// A broken symlink
+	if err := os.Symlink(filepath.Join(dir, "missing"), filepath.Join(dir, "bad-link")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	s := NewScanner(platform.Current())
+	s.SetBinDirs([]string{dir})
+
+	orphans := s.scanBrokenSymlinks()
+	if len(orphans) != 1 {
+		t.Fatalf("expected 1 orphan, got %d", len(orphans))
+	}
+	if orphans[0].Type != OrphanBrokenSymlink {
+		t.Errorf("expected broken_symlink type, got %s", orphans[0].Type)
+	}
+	if orphans[0].Name != "bad-link" {
+		t.Errorf("expected bad-link, got %s", orphans[0].Name)
+	}
+}
+
+func TestCleanBrokenSymlink(t *testing.T) {
+	dir := t.TempDir()
+	link := filepath.Join(dir, "stale")
+	if err := os.Symlink(filepath.Join(dir, "missing"), link); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	s := NewScanner(platform.Current())
+	err := s.Clean(context.Background(), []Orphan{
+		{Type: OrphanBrokenSymlink, Name: "stale", Path: link},
+	})
+	if err != nil {
+		t.Fatalf("Clean failed: %v", err)
+	}
+
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Error("expected symlink to be removed")
+	}
+}
+
+func TestAgentForPackage(t *testing.T) {
+	cat := &catalog.Catalog{
+		Agents: map[string]catalog.AgentDef{
+			"aider": {
+				ID:   "aider",
+				Name: "Aider",
+				InstallMethods: map[string]catalog.InstallMethodDef{
+					"pipx": {Method: "pipx", Package: "aider-chat"},
+				},
+			},
+		},
+	}
+
+	agentDef, ok := agentForPackage(cat, "pipx", "aider-chat")
+	if !ok {
+		t.Fatal("expected to find agent for aider-chat")
+	}
+	if agentDef.ID != "aider" {
+		t.Errorf("expected aider, got %s", agentDef.ID)
+	}
+
+	if _, ok := agentForPackage(cat, "pipx", "unrelated"); ok {
+		t.Error("expected no agent for unrelated package")
+	}
+	if _, ok := agentForPackage(cat, "npm", "aider-chat"); ok {
+		t.Error("expected no agent for wrong method")
+	}
+}
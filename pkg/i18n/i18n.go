@@ -0,0 +1,148 @@
+// Package i18n provides localization for user-facing strings.
+//
+// Translation catalogs are embedded JSON files keyed by message ID. The
+// active language is chosen from the config override when set, otherwise
+// detected from the system locale, falling back to English. Lookups that
+// miss in the active catalog fall back to English, then to the key itself,
+// so untranslated strings degrade gracefully.
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// DefaultLanguage is the fallback language.
+const DefaultLanguage = "en"
+
+var (
+	mu       sync.RWMutex
+	language = DefaultLanguage
+	catalogs = map[string]map[string]string{}
+)
+
+// Init sets the active language. An empty lang detects the system locale.
+// Unknown languages fall back to English.
+func Init(lang string) {
+	if lang == "" {
+		lang = DetectLocale()
+	}
+	lang = normalizeLang(lang)
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := loadCatalog(lang); err != nil {
+		lang = DefaultLanguage
+	}
+	language = lang
+}
+
+// Language returns the active language code.
+func Language() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return language
+}
+
+// Available returns the language codes with embedded catalogs.
+func Available() []string {
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		return []string{DefaultLanguage}
+	}
+	var langs []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasSuffix(name, ".json") {
+			langs = append(langs, strings.TrimSuffix(name, ".json"))
+		}
+	}
+	return langs
+}
+
+// T returns the translation for key in the active language, formatted with
+// args when provided. Missing keys fall back to English, then to the key.
+func T(key string, args ...interface{}) string {
+	mu.RLock()
+	lang := language
+	mu.RUnlock()
+
+	msg := lookup(lang, key)
+	if msg == "" && lang != DefaultLanguage {
+		msg = lookup(DefaultLanguage, key)
+	}
+	if msg == "" {
+		msg = key
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
+
+// DetectLocale returns the language code from the environment
+// (LC_ALL, LC_MESSAGES, LANG), or English when none is set.
+func DetectLocale() string {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" && value != "C" && value != "POSIX" {
+			return normalizeLang(value)
+		}
+	}
+	return DefaultLanguage
+}
+
+// normalizeLang reduces a locale string like "en_US.UTF-8" to "en".
+func normalizeLang(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "_-."); i > 0 {
+		locale = locale[:i]
+	}
+	if locale == "" {
+		return DefaultLanguage
+	}
+	return locale
+}
+
+// lookup returns the message for key in lang, loading the catalog on demand.
+// Returns "" on any miss. Callers hold no locks.
+func lookup(lang, key string) string {
+	mu.RLock()
+	catalog, ok := catalogs[lang]
+	mu.RUnlock()
+
+	if !ok {
+		mu.Lock()
+		var err error
+		catalog, err = loadCatalog(lang)
+		mu.Unlock()
+		if err != nil {
+			return ""
+		}
+	}
+	return catalog[key]
+}
+
+// loadCatalog loads and caches the catalog for lang. Callers hold mu.
+func loadCatalog(lang string) (map[string]string, error) {
+	if catalog, ok := catalogs[lang]; ok {
+		return catalog, nil
+	}
+	data, err := localeFS.ReadFile("locales/" + lang + ".json")
+	if err != nil {
+		return nil, err
+	}
+	catalog := map[string]string{}
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, err
+	}
+	catalogs[lang] = catalog
+	return catalog, nil
+}
@@ -0,0 +1,91 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestNormalizeLang(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"en_US.UTF-8", "en"},
+		{"es_ES.UTF-8", "es"},
+		{"fr-FR", "fr"},
+		{"de", "de"},
+		{"EN", "en"},
+		{"", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := normalizeLang(tt.locale); got != tt.expected {
+				t.Errorf("normalizeLang(%q) = %q, want %q", tt.locale, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDetectLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+	t.Setenv("LANG", "es_ES.UTF-8")
+	if got := DetectLocale(); got != "es" {
+		t.Errorf("DetectLocale() = %q, want %q", got, "es")
+	}
+
+	t.Setenv("LC_ALL", "fr_FR.UTF-8")
+	if got := DetectLocale(); got != "fr" {
+		t.Errorf("DetectLocale() = %q, want %q", got, "fr")
+	}
+
+	t.Setenv("LC_ALL", "C")
+	t.Setenv("LANG", "C")
+	if got := DetectLocale(); got != "en" {
+		t.Errorf("DetectLocale() = %q, want %q", got, "en")
+	}
+}
+
+func TestTranslation(t *testing.T) {
+	Init("es")
+	if Language() != "es" {
+		t.Fatalf("Language() = %q, want %q", Language(), "es")
+	}
+	if got := T("action.install"); got != "Instalar" {
+		t.Errorf("T(action.install) = %q, want %q", got, "Instalar")
+	}
+
+	// Unknown key falls back to the key itself
+	if got := T("no.such.key"); got != "no.such.key" {
+		t.Errorf("T(no.such.key) = %q, want the key", got)
+	}
+
+	// Formatting args
+	if got := T("status.agents_processed", 3); got != "3 agente(s) procesados" {
+		t.Errorf("T(status.agents_processed, 3) = %q", got)
+	}
+
+	// Unknown language falls back to English
+	Init("xx")
+	if Language() != "en" {
+		t.Errorf("Language() = %q after Init(xx), want %q", Language(), "en")
+	}
+	if got := T("action.install"); got != "Install" {
+		t.Errorf("T(action.install) = %q, want %q", got, "Install")
+	}
+
+	Init("en")
+}
+
+func TestAvailable(t *testing.T) {
+	langs := Available()
+	found := map[string]bool{}
+	for _, lang := range langs {
+		found[lang] = true
+	}
+	for _, want := range []string{"en", "es", "fr"} {
+		if !found[want] {
+			t.Errorf("Available() missing %q: %v", want, langs)
+		}
+	}
+}
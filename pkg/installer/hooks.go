@@ -0,0 +1,177 @@
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+// HookEvent identifies the point in an operation at which hooks run.
+type HookEvent string
+
+const (
+	HookPreInstall  HookEvent = "pre_install"
+	HookPostInstall HookEvent = "post_install"
+	HookPreUpdate   HookEvent = "pre_update"
+	HookPostUpdate  HookEvent = "post_update"
+)
+
+// defaultHookTimeout bounds a hook script run when no timeout is configured.
+const defaultHookTimeout = time.Minute
+
+// hookAuditEntry is one line of the hook audit log, recording what ran and
+// what it printed.
+type hookAuditEntry struct {
+	Time     time.Time `json:"time"`
+	Event    HookEvent `json:"event"`
+	AgentID  string    `json:"agent_id"`
+	Script   string    `json:"script"`
+	ExitCode int       `json:"exit_code"`
+	Duration string    `json:"duration"`
+	Output   string    `json:"output,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// SetHooksConfig configures the global and per-agent hook scripts run
+// around install and update operations.
+func (m *Manager) SetHooksConfig(global config.HooksConfig, agents map[string]config.AgentConfig) {
+	m.hooks = global
+	m.agentHooks = make(map[string]config.HooksConfig, len(agents))
+	for id, agentCfg := range agents {
+		if !agentCfg.Hooks.Empty() {
+			m.agentHooks[id] = agentCfg.Hooks
+		}
+	}
+}
+
+// hookScripts returns the scripts to run for an event: global hooks first,
+// then the agent's own.
+func (m *Manager) hookScripts(event HookEvent, agentID string) []string {
+	pick := func(h config.HooksConfig) []string {
+		switch event {
+		case HookPreInstall:
+			return h.PreInstall
+		case HookPostInstall:
+			return h.PostInstall
+		case HookPreUpdate:
+			return h.PreUpdate
+		case HookPostUpdate:
+			return h.PostUpdate
+		}
+		return nil
+	}
+
+	scripts := append([]string{}, pick(m.hooks)...)
+	if agentCfg, ok := m.agentHooks[agentID]; ok {
+		scripts = append(scripts, pick(agentCfg)...)
+	}
+	return scripts
+}
+
+// runHooks executes the hook scripts for an event sequentially, passing the
+// operation context through AGENTMGR_* environment variables and recording
+// each run in the audit log. The first failing script stops the remaining
+// ones and is returned as an error; callers decide whether that aborts the
+// operation (pre hooks) or is merely reported (post hooks).
+func (m *Manager) runHooks(ctx context.Context, event HookEvent, agentDef catalog.AgentDef, fromVersion, toVersion string) error {
+	scripts := m.hookScripts(event, agentDef.ID)
+	if len(scripts) == 0 {
+		return nil
+	}
+
+	timeout := m.hooks.Timeout
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+
+	env := append(os.Environ(),
+		"AGENTMGR_EVENT="+string(event),
+		"AGENTMGR_AGENT_ID="+agentDef.ID,
+		"AGENTMGR_AGENT_NAME="+agentDef.Name,
+		"AGENTMGR_VERSION="+toVersion,
+		"AGENTMGR_PREVIOUS_VERSION="+fromVersion,
+	)
+
+	for _, script := range scripts {
+		entry := hookAuditEntry{
+			Time:    time.Now().UTC(),
+			Event:   event,
+			AgentID: agentDef.ID,
+			Script:  script,
+		}
+
+		hookCtx, cancel := context.WithTimeout(ctx, timeout)
+		cmd := hookCommand(hookCtx, script)
+		cmd.Env = env
+		// Don't wait on grandchildren holding the output pipes open after
+		// the script itself has been killed on timeout
+		cmd.WaitDelay = time.Second
+
+		start := time.Now()
+		out, err := cmd.CombinedOutput()
+		cancel()
+
+		entry.Duration = time.Since(start).Round(time.Millisecond).String()
+		entry.Output = strings.TrimSpace(string(out))
+		if err != nil {
+			if cmd.ProcessState != nil {
+				entry.ExitCode = cmd.ProcessState.ExitCode()
+			}
+			entry.Error = err.Error()
+		}
+		m.appendHookAudit(entry)
+
+		if err != nil {
+			return fmt.Errorf("%s hook %q failed: %w: %s", event, script, err, entry.Output)
+		}
+	}
+	return nil
+}
+
+// hookCommand builds the shell invocation for a hook script.
+func hookCommand(ctx context.Context, script string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", script)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", script)
+}
+
+// appendHookAudit appends one JSON line to the hook audit log in the data
+// directory. Audit failures never fail the operation.
+func (m *Manager) appendHookAudit(entry hookAuditEntry) {
+	dir := m.plat.GetDataDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, "hooks.log"), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_, _ = f.Write(append(line, '\n'))
+}
+
+// versionString formats a version for hook environment variables, mapping
+// the zero value to the empty string.
+func versionString(v agent.Version) string {
+	if v.String() == "0.0.0" && v.Raw == "" {
+		return ""
+	}
+	return v.String()
+}
@@ -0,0 +1,147 @@
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func hookTestManager(t *testing.T) *Manager {
+	t.Helper()
+	plat := agentmgrtest.NewFakePlatform()
+	plat.DataDir = t.TempDir()
+	return NewManager(plat)
+}
+
+func TestHookScriptsOrdering(t *testing.T) {
+	mgr := hookTestManager(t)
+	mgr.SetHooksConfig(
+		config.HooksConfig{PostUpdate: []string{"global-one", "global-two"}},
+		map[string]config.AgentConfig{
+			"claude-code": {Hooks: config.HooksConfig{PostUpdate: []string{"agent-one"}}},
+			"aider":       {Hooks: config.HooksConfig{PostInstall: []string{"other-event"}}},
+		},
+	)
+
+	scripts := mgr.hookScripts(HookPostUpdate, "claude-code")
+	want := []string{"global-one", "global-two", "agent-one"}
+	if len(scripts) != len(want) {
+		t.Fatalf("hookScripts() = %v, want %v", scripts, want)
+	}
+	for i := range want {
+		if scripts[i] != want[i] {
+			t.Errorf("hookScripts()[%d] = %q, want %q", i, scripts[i], want[i])
+		}
+	}
+
+	if scripts := mgr.hookScripts(HookPostUpdate, "aider"); len(scripts) != 2 {
+		t.Errorf("hookScripts() for agent without matching hooks = %v, want globals only", scripts)
+	}
+}
+
+func TestRunHooksPassesEventContext(t *testing.T) {
+	mgr := hookTestManager(t)
+	outFile := filepath.Join(t.TempDir(), "env.txt")
+	mgr.SetHooksConfig(config.HooksConfig{
+		PostUpdate: []string{`echo "$AGENTMGR_EVENT $AGENTMGR_AGENT_ID $AGENTMGR_PREVIOUS_VERSION $AGENTMGR_VERSION" > ` + outFile},
+	}, nil)
+
+	agentDef := catalog.AgentDef{ID: "claude-code", Name: "Claude Code"}
+	if err := mgr.runHooks(context.Background(), HookPostUpdate, agentDef, "1.0.0", "2.0.0"); err != nil {
+		t.Fatalf("runHooks() error = %v", err)
+	}
+
+	data, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	if got, want := strings.TrimSpace(string(data)), "post_update claude-code 1.0.0 2.0.0"; got != want {
+		t.Errorf("hook env = %q, want %q", got, want)
+	}
+}
+
+func TestRunHooksFailureStopsRemaining(t *testing.T) {
+	mgr := hookTestManager(t)
+	outFile := filepath.Join(t.TempDir(), "ran.txt")
+	mgr.SetHooksConfig(config.HooksConfig{
+		PreInstall: []string{"echo oops; exit 1", "touch " + outFile},
+	}, nil)
+
+	err := mgr.runHooks(context.Background(), HookPreInstall, catalog.AgentDef{ID: "claude-code"}, "", "")
+	if err == nil {
+		t.Fatal("runHooks() error = nil, want failure")
+	}
+	if !strings.Contains(err.Error(), "pre_install hook") || !strings.Contains(err.Error(), "oops") {
+		t.Errorf("runHooks() error = %v, want hook failure with captured output", err)
+	}
+	if _, statErr := os.Stat(outFile); !os.IsNotExist(statErr) {
+		t.Error("second hook ran after the first failed")
+	}
+}
+
+func TestRunHooksWritesAuditLog(t *testing.T) {
+	mgr := hookTestManager(t)
+	mgr.SetHooksConfig(config.HooksConfig{
+		PostInstall: []string{"echo installed ok"},
+	}, nil)
+
+	agentDef := catalog.AgentDef{ID: "claude-code", Name: "Claude Code"}
+	if err := mgr.runHooks(context.Background(), HookPostInstall, agentDef, "", "1.2.3"); err != nil {
+		t.Fatalf("runHooks() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(mgr.plat.GetDataDir(), "hooks.log"))
+	if err != nil {
+		t.Fatalf("audit log not written: %v", err)
+	}
+
+	var entry hookAuditEntry
+	if err := json.Unmarshal([]byte(strings.TrimSpace(string(data))), &entry); err != nil {
+		t.Fatalf("audit log is not JSON lines: %v", err)
+	}
+	if entry.Event != HookPostInstall || entry.AgentID != "claude-code" {
+		t.Errorf("audit entry = %+v, want post_install for claude-code", entry)
+	}
+	if entry.Output != "installed ok" {
+		t.Errorf("audit output = %q, want captured script output", entry.Output)
+	}
+	if entry.ExitCode != 0 || entry.Error != "" {
+		t.Errorf("audit entry = %+v, want success", entry)
+	}
+}
+
+func TestRunHooksTimeout(t *testing.T) {
+	mgr := hookTestManager(t)
+	mgr.SetHooksConfig(config.HooksConfig{
+		PreUpdate: []string{"sleep 5"},
+		Timeout:   50 * time.Millisecond,
+	}, nil)
+
+	start := time.Now()
+	err := mgr.runHooks(context.Background(), HookPreUpdate, catalog.AgentDef{ID: "claude-code"}, "1.0.0", "")
+	if err == nil {
+		t.Fatal("runHooks() error = nil, want timeout failure")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("hook ran for %v, want timeout near 50ms", elapsed)
+	}
+}
+
+func TestRunHooksNoScriptsIsNoop(t *testing.T) {
+	mgr := hookTestManager(t)
+
+	if err := mgr.runHooks(context.Background(), HookPreInstall, catalog.AgentDef{ID: "claude-code"}, "", ""); err != nil {
+		t.Fatalf("runHooks() error = %v, want nil with no hooks configured", err)
+	}
+	if _, err := os.Stat(filepath.Join(mgr.plat.GetDataDir(), "hooks.log")); !os.IsNotExist(err) {
+		t.Error("audit log written with no hooks configured")
+	}
+}
@@ -4,115 +4,549 @@ package installer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
 
 // Manager orchestrates installation operations.
 type Manager struct {
-	npm    *providers.NPMProvider
-	pip    *providers.PipProvider
-	brew   *providers.BrewProvider
-	native *providers.NativeProvider
-	plat   platform.Platform
+	npm       *providers.NPMProvider
+	pip       *providers.PipProvider
+	brew      *providers.BrewProvider
+	native    *providers.NativeProvider
+	extension *providers.ExtensionProvider
+	mise      *providers.MiseProvider
+	flatpak   *providers.FlatpakProvider
+	snap      *providers.SnapProvider
+	download  *providers.DownloadProvider
+	github    *providers.GitHubProvider
+	plat      platform.Platform
+
+	hooks      config.HooksConfig
+	agentHooks map[string]config.HooksConfig
+	installers config.InstallersConfig
+	policy     config.PolicyConfig
+	store      storage.Store
+	versions   *versionCache
+	progressState
 }
 
 // NewManager creates a new installation manager.
 func NewManager(p platform.Platform) *Manager {
 	return &Manager{
-		npm:    providers.NewNPMProvider(p),
-		pip:    providers.NewPipProvider(p),
-		brew:   providers.NewBrewProvider(p),
-		native: providers.NewNativeProvider(p),
-		plat:   p,
+		npm:       providers.NewNPMProvider(p),
+		pip:       providers.NewPipProvider(p),
+		brew:      providers.NewBrewProvider(p),
+		native:    providers.NewNativeProvider(p),
+		extension: providers.NewExtensionProvider(p),
+		mise:      providers.NewMiseProvider(p),
+		flatpak:   providers.NewFlatpakProvider(p),
+		snap:      providers.NewSnapProvider(p),
+		download:  providers.NewDownloadProvider(p),
+		github:    providers.NewGitHubProvider(p),
+		plat:      p,
+		versions:  newVersionCache(),
+	}
+}
+
+// SetInstallersConfig forwards tool overrides from the installers
+// configuration to the providers that honor them.
+func (m *Manager) SetInstallersConfig(cfg config.InstallersConfig) {
+	m.installers = cfg
+	m.npm.SetInstallers(cfg)
+	m.pip.SetInstallers(cfg)
+	m.brew.SetInstallers(cfg)
+}
+
+// SetStore attaches a storage backend so updates are recorded in the
+// update history. Without a store, updates are not recorded.
+func (m *Manager) SetStore(st storage.Store) {
+	m.store = st
+}
+
+// SetPolicy applies machine policy to the manager. With lockdown enabled
+// every install, update, downgrade and uninstall is refused here, so a
+// caller that reaches the manager through a new code path cannot bypass
+// the policy.
+func (m *Manager) SetPolicy(p config.PolicyConfig) {
+	m.policy = p
+}
+
+// checkPolicy returns a locked_down error when lockdown policy is active.
+func (m *Manager) checkPolicy() error {
+	if !m.policy.Lockdown {
+		return nil
+	}
+	msg := m.policy.LockdownMessage
+	if msg == "" {
+		msg = config.DefaultLockdownMessage
 	}
+	return errcode.Errorf(errcode.CodeLockedDown, "%s", msg)
 }
 
-// Install installs an agent using the specified method.
+// FetchInstallScript downloads and caches the script behind a piped
+// curl|sh install command so it can be reviewed before running. It fails
+// for methods whose command does not pipe a script into a shell.
+func (m *Manager) FetchInstallScript(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*providers.ScriptAudit, error) {
+	return m.native.FetchInstallScript(ctx, agentDef, method)
+}
+
+// Install installs an agent using the specified method. Configured
+// pre_install hooks run first and abort the install when they fail. After
+// the provider completes, the binary is verified by running the agent's
+// smoke test or version command; a verification failure returns the result
+// alongside a *VerificationError carrying the captured output. post_install
+// hook failures are recorded in the audit log but do not fail the install.
 func (m *Manager) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*providers.Result, error) {
+	if err := m.checkPolicy(); err != nil {
+		return nil, err
+	}
+	method, err := m.expandMethod(method, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := m.runHooks(ctx, HookPreInstall, agentDef, "", ""); err != nil {
+		return nil, err
+	}
+	startedAt := time.Now()
+	result, retries, err := m.runWithRetry(ctx, method.Method, func() (*providers.Result, error) {
+		return m.dispatchInstall(ctx, agentDef, method, force)
+	})
+	m.recordUpdate(ctx, nil, agentDef, method, "", startedAt, result, retries, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verifyResult(ctx, agentDef, result); err != nil {
+		return result, err
+	}
+	result.ShadowedBy = ShadowedBy(agentDef.Detection.Executables, result.ExecutablePath)
+	_ = m.runHooks(ctx, HookPostInstall, agentDef, "", versionString(result.Version))
+	return result, nil
+}
+
+// dispatchInstall routes the install to the provider for the method.
+func (m *Manager) dispatchInstall(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*providers.Result, error) {
 	switch method.Method {
 	case "npm":
 		if !m.npm.IsAvailable() {
-			return nil, fmt.Errorf("npm is not available")
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "npm is not available")
 		}
 		return m.npm.Install(ctx, agentDef, method, force)
 
 	case "pip", "pipx", "uv":
 		if !m.pip.IsAvailable() {
-			return nil, fmt.Errorf("pip/pipx/uv is not available")
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "pip/pipx/uv is not available")
 		}
 		return m.pip.Install(ctx, agentDef, method, force)
 
 	case "brew":
 		if !m.brew.IsAvailable() {
-			return nil, fmt.Errorf("brew is not available")
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "brew is not available")
 		}
 		return m.brew.Install(ctx, agentDef, method, force)
 
 	case "native", "curl", "binary":
 		return m.native.Install(ctx, agentDef, method, force)
 
+	case "extension":
+		if !m.extension.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "code CLI is not available")
+		}
+		return m.extension.Install(ctx, agentDef, method, force)
+
+	case "mise":
+		if !m.mise.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "mise is not available")
+		}
+		return m.mise.Install(ctx, agentDef, method, force)
+
+	case "flatpak":
+		if !m.flatpak.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "flatpak is not available")
+		}
+		return m.flatpak.Install(ctx, agentDef, method, force)
+
+	case "snap":
+		if !m.snap.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "snap is not available")
+		}
+		return m.snap.Install(ctx, agentDef, method, force)
+
+	case "dmg", "pkg", "msi", "exe":
+		if !m.download.SupportsMethod(method.Method) {
+			return nil, fmt.Errorf("%s installers are not supported on this platform", method.Method)
+		}
+		return m.download.Install(ctx, agentDef, method, force)
+
+	case "github":
+		return m.github.Install(ctx, agentDef, method, force)
+
 	default:
 		return nil, fmt.Errorf("unsupported install method: %s", method.Method)
 	}
 }
 
-// Update updates an installed agent.
+// Update updates an installed agent. Configured pre_update hooks run first
+// and abort the update when they fail. Like Install, the updated binary is
+// verified before the update is reported as successful, and post_update
+// hook failures are only recorded in the audit log.
 func (m *Manager) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*providers.Result, error) {
+	if err := m.checkPolicy(); err != nil {
+		return nil, err
+	}
+	method, err := m.expandMethod(method, "")
+	if err != nil {
+		return nil, err
+	}
+	fromVersion := ""
+	if inst != nil {
+		fromVersion = inst.InstalledVersion.String()
+	}
+	if err := m.runHooks(ctx, HookPreUpdate, agentDef, fromVersion, ""); err != nil {
+		return nil, err
+	}
+	startedAt := time.Now()
+	result, retries, err := m.runWithRetry(ctx, method.Method, func() (*providers.Result, error) {
+		return m.dispatchUpdate(ctx, inst, agentDef, method)
+	})
+	m.recordUpdate(ctx, inst, agentDef, method, fromVersion, startedAt, result, retries, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verifyResult(ctx, agentDef, result); err != nil {
+		return result, err
+	}
+	result.ShadowedBy = ShadowedBy(agentDef.Detection.Executables, result.ExecutablePath)
+	_ = m.runHooks(ctx, HookPostUpdate, agentDef, fromVersion, versionString(result.Version))
+	return result, nil
+}
+
+// recordUpdate stamps the installation with the update time and restart
+// hint from the catalog, and appends the outcome to the stored update
+// history when a store is attached.
+func (m *Manager) recordUpdate(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, fromVersion string, startedAt time.Time, result *providers.Result, retries int, updateErr error) {
+	completedAt := time.Now()
+	if updateErr == nil && inst != nil {
+		inst.UpdatedAt = &completedAt
+		inst.RestartPending = method.RestartRequired
+	}
+
+	if m.store == nil {
+		return
+	}
+	event := &storage.UpdateEvent{
+		AgentID:       agentDef.ID,
+		AgentName:     agentDef.Name,
+		InstallMethod: method.Method,
+		FromVersion:   fromVersion,
+		Status:        storage.UpdateStatusCompleted,
+		Retries:       retries,
+		StartedAt:     startedAt,
+		CompletedAt:   &completedAt,
+	}
+	if updateErr != nil {
+		event.Status = storage.UpdateStatusFailed
+		event.ErrorMessage = updateErr.Error()
+	} else {
+		event.ToVersion = versionString(result.Version)
+		event.Output = result.Output
+	}
+	_ = m.store.SaveUpdateEvent(ctx, event)
+}
+
+// dispatchUpdate routes the update to the provider for the method.
+func (m *Manager) dispatchUpdate(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*providers.Result, error) {
 	switch method.Method {
 	case "npm":
 		if !m.npm.IsAvailable() {
-			return nil, fmt.Errorf("npm is not available")
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "npm is not available")
 		}
 		return m.npm.Update(ctx, inst, agentDef, method)
 
 	case "pip", "pipx", "uv":
 		if !m.pip.IsAvailable() {
-			return nil, fmt.Errorf("pip/pipx/uv is not available")
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "pip/pipx/uv is not available")
 		}
 		return m.pip.Update(ctx, inst, agentDef, method)
 
 	case "brew":
 		if !m.brew.IsAvailable() {
-			return nil, fmt.Errorf("brew is not available")
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "brew is not available")
 		}
 		return m.brew.Update(ctx, inst, agentDef, method)
 
 	case "native", "curl", "binary":
 		return m.native.Update(ctx, inst, agentDef, method)
 
+	case "extension":
+		if !m.extension.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "code CLI is not available")
+		}
+		return m.extension.Update(ctx, inst, agentDef, method)
+
+	case "mise":
+		if !m.mise.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "mise is not available")
+		}
+		return m.mise.Update(ctx, inst, agentDef, method)
+
+	case "flatpak":
+		if !m.flatpak.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "flatpak is not available")
+		}
+		return m.flatpak.Update(ctx, inst, agentDef, method)
+
+	case "snap":
+		if !m.snap.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "snap is not available")
+		}
+		return m.snap.Update(ctx, inst, agentDef, method)
+
+	case "dmg", "pkg", "msi", "exe":
+		if !m.download.SupportsMethod(method.Method) {
+			return nil, fmt.Errorf("%s installers are not supported on this platform", method.Method)
+		}
+		return m.download.Update(ctx, inst, agentDef, method)
+
+	case "github":
+		return m.github.Update(ctx, inst, agentDef, method)
+
 	default:
 		return nil, fmt.Errorf("unsupported install method: %s", method.Method)
 	}
 }
 
+// BatchUpdateItem is one installation in a batched Update All run.
+type BatchUpdateItem struct {
+	Installation *agent.Installation
+	AgentDef     catalog.AgentDef
+	Method       catalog.InstallMethodDef
+}
+
+// BatchUpdateOutcome is the per-item result of UpdateBatch, in input order.
+type BatchUpdateOutcome struct {
+	Result *providers.Result
+	Err    error
+}
+
+// UpdateBatch updates a set of installations, issuing a single
+// `brew upgrade f1 f2 f3` for the brew-installed ones instead of one
+// brew run per agent. Everything else, including a lone brew item, goes
+// through the regular one-at-a-time Update path with its retry handling.
+// Hooks, verification, and update history still apply per agent.
+func (m *Manager) UpdateBatch(ctx context.Context, items []BatchUpdateItem) []BatchUpdateOutcome {
+	outcomes := make([]BatchUpdateOutcome, len(items))
+
+	// The batched brew path below goes straight to the provider, so the
+	// policy check cannot be left to Update alone.
+	if err := m.checkPolicy(); err != nil {
+		for i := range outcomes {
+			outcomes[i] = BatchUpdateOutcome{Err: err}
+		}
+		return outcomes
+	}
+
+	var brewIdx []int
+	for i, item := range items {
+		if item.Method.Method == "brew" && m.brew.IsAvailable() {
+			brewIdx = append(brewIdx, i)
+			continue
+		}
+		result, err := m.Update(ctx, item.Installation, item.AgentDef, item.Method)
+		outcomes[i] = BatchUpdateOutcome{Result: result, Err: err}
+	}
+
+	if len(brewIdx) == 1 {
+		i := brewIdx[0]
+		result, err := m.Update(ctx, items[i].Installation, items[i].AgentDef, items[i].Method)
+		outcomes[i] = BatchUpdateOutcome{Result: result, Err: err}
+		return outcomes
+	}
+	if len(brewIdx) == 0 {
+		return outcomes
+	}
+
+	// Run pre-update hooks per agent first; a hook failure drops that
+	// agent from the batch the same way it aborts a single update.
+	var batch []providers.BrewBatchItem
+	var batchIdx []int
+	for _, i := range brewIdx {
+		item := items[i]
+		method, err := m.expandMethod(item.Method, "")
+		if err != nil {
+			outcomes[i] = BatchUpdateOutcome{Err: err}
+			continue
+		}
+		fromVersion := ""
+		if item.Installation != nil {
+			fromVersion = item.Installation.InstalledVersion.String()
+		}
+		if err := m.runHooks(ctx, HookPreUpdate, item.AgentDef, fromVersion, ""); err != nil {
+			outcomes[i] = BatchUpdateOutcome{Err: err}
+			continue
+		}
+		batch = append(batch, providers.BrewBatchItem{
+			Installation: item.Installation,
+			AgentDef:     item.AgentDef,
+			Method:       method,
+		})
+		batchIdx = append(batchIdx, i)
+	}
+
+	startedAt := time.Now()
+	for j, out := range m.brew.UpdateBatch(ctx, batch) {
+		i := batchIdx[j]
+		item := items[i]
+		fromVersion := ""
+		if item.Installation != nil {
+			fromVersion = item.Installation.InstalledVersion.String()
+		}
+		m.recordUpdate(ctx, item.Installation, item.AgentDef, item.Method, fromVersion, startedAt, out.Result, 0, out.Err)
+		if out.Err == nil {
+			if err := m.verifyResult(ctx, item.AgentDef, out.Result); err != nil {
+				out.Err = err
+			} else {
+				_ = m.runHooks(ctx, HookPostUpdate, item.AgentDef, fromVersion, versionString(out.Result.Version))
+			}
+		}
+		outcomes[i] = BatchUpdateOutcome{Result: out.Result, Err: out.Err}
+	}
+
+	return outcomes
+}
+
+// Downgrade installs a specific older version of an installed agent.
+// The target version must be older than the currently installed version.
+func (m *Manager) Downgrade(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, target agent.Version) (*providers.Result, error) {
+	if err := m.checkPolicy(); err != nil {
+		return nil, err
+	}
+	if _, ok := agentDef.GetInstallMethod(method.Method); !ok {
+		return nil, fmt.Errorf("install method %q not defined for %s in catalog", method.Method, agentDef.ID)
+	}
+	if !target.IsOlderThan(inst.InstalledVersion) {
+		return nil, fmt.Errorf("target version %s is not older than installed version %s", target, inst.InstalledVersion)
+	}
+
+	method, err := m.expandMethod(method, target.String())
+	if err != nil {
+		return nil, err
+	}
+
+	fromVersion := inst.InstalledVersion.String()
+	startedAt := time.Now()
+	result, retries, err := m.runWithRetry(ctx, method.Method, func() (*providers.Result, error) {
+		return m.dispatchDowngrade(ctx, inst, agentDef, method, target)
+	})
+	m.recordUpdate(ctx, inst, agentDef, method, fromVersion, startedAt, result, retries, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.verifyResult(ctx, agentDef, result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// dispatchDowngrade routes the downgrade to the provider for the method.
+func (m *Manager) dispatchDowngrade(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, target agent.Version) (*providers.Result, error) {
+	switch method.Method {
+	case "npm":
+		if !m.npm.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "npm is not available")
+		}
+		return m.npm.Downgrade(ctx, inst, agentDef, method, target)
+
+	case "pip", "pipx", "uv":
+		if !m.pip.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "pip/pipx/uv is not available")
+		}
+		return m.pip.Downgrade(ctx, inst, agentDef, method, target)
+
+	case "brew":
+		if !m.brew.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "brew is not available")
+		}
+		return m.brew.Downgrade(ctx, inst, agentDef, method, target)
+
+	case "mise":
+		if !m.mise.IsAvailable() {
+			return nil, errcode.Errorf(errcode.CodeUnavailable, "mise is not available")
+		}
+		return m.mise.Downgrade(ctx, inst, agentDef, method, target)
+
+	default:
+		return nil, fmt.Errorf("downgrade not supported for method: %s", method.Method)
+	}
+}
+
 // Uninstall removes an installed agent.
 func (m *Manager) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	if err := m.checkPolicy(); err != nil {
+		return err
+	}
 	switch method.Method {
 	case "npm":
 		if !m.npm.IsAvailable() {
-			return fmt.Errorf("npm is not available")
+			return errcode.Errorf(errcode.CodeUnavailable, "npm is not available")
 		}
 		return m.npm.Uninstall(ctx, inst, method)
 
 	case "pip", "pipx", "uv":
 		if !m.pip.IsAvailable() {
-			return fmt.Errorf("pip/pipx/uv is not available")
+			return errcode.Errorf(errcode.CodeUnavailable, "pip/pipx/uv is not available")
 		}
 		return m.pip.Uninstall(ctx, inst, method)
 
 	case "brew":
 		if !m.brew.IsAvailable() {
-			return fmt.Errorf("brew is not available")
+			return errcode.Errorf(errcode.CodeUnavailable, "brew is not available")
 		}
 		return m.brew.Uninstall(ctx, inst, method)
 
 	case "native", "curl", "binary":
 		return m.native.Uninstall(ctx, inst, method)
 
+	case "extension":
+		if !m.extension.IsAvailable() {
+			return errcode.Errorf(errcode.CodeUnavailable, "code CLI is not available")
+		}
+		return m.extension.Uninstall(ctx, inst, method)
+
+	case "mise":
+		if !m.mise.IsAvailable() {
+			return errcode.Errorf(errcode.CodeUnavailable, "mise is not available")
+		}
+		return m.mise.Uninstall(ctx, inst, method)
+
+	case "flatpak":
+		if !m.flatpak.IsAvailable() {
+			return errcode.Errorf(errcode.CodeUnavailable, "flatpak is not available")
+		}
+		return m.flatpak.Uninstall(ctx, inst, method)
+
+	case "snap":
+		if !m.snap.IsAvailable() {
+			return errcode.Errorf(errcode.CodeUnavailable, "snap is not available")
+		}
+		return m.snap.Uninstall(ctx, inst, method)
+
+	case "dmg", "pkg", "msi", "exe":
+		if !m.download.SupportsMethod(method.Method) {
+			return fmt.Errorf("%s installers are not supported on this platform", method.Method)
+		}
+		return m.download.Uninstall(ctx, inst, method)
+
+	case "github":
+		return m.github.Uninstall(ctx, inst, method)
+
 	default:
 		return fmt.Errorf("unsupported install method: %s", method.Method)
 	}
@@ -147,6 +581,18 @@ func (m *Manager) GetAvailableMethods(agentDef catalog.AgentDef) []catalog.Insta
 			available = m.brew.IsAvailable()
 		case "native", "curl", "binary":
 			available = true
+		case "extension":
+			available = m.extension.IsAvailable()
+		case "mise":
+			available = m.mise.IsAvailable()
+		case "flatpak":
+			available = m.flatpak.IsAvailable()
+		case "snap":
+			available = m.snap.IsAvailable()
+		case "dmg", "pkg", "msi", "exe":
+			available = m.download.SupportsMethod(method.Method)
+		case "github":
+			available = m.github.IsAvailable()
 		}
 
 		if available {
@@ -168,29 +614,55 @@ func (m *Manager) IsMethodAvailable(method string) bool {
 		return m.brew.IsAvailable()
 	case "native", "curl", "binary":
 		return true
+	case "extension":
+		return m.extension.IsAvailable()
+	case "mise":
+		return m.mise.IsAvailable()
+	case "flatpak":
+		return m.flatpak.IsAvailable()
+	case "snap":
+		return m.snap.IsAvailable()
+	case "dmg", "pkg", "msi", "exe":
+		return m.download.SupportsMethod(method)
+	case "github":
+		return m.github.IsAvailable()
 	default:
 		return false
 	}
 }
 
-// GetLatestVersion returns the latest version available for an agent using the specified method.
+// GetLatestVersion returns the latest version available for an agent using
+// the specified method. Results are served from the resolver cache, which
+// coalesces concurrent lookups and throttles registry requests, so a full
+// update check does not hammer npm, PyPI, or the GitHub API.
 func (m *Manager) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	return m.versions.Get(ctx, method, m.resolveLatestVersion)
+}
+
+// VersionCacheStats reports how the resolver cache is performing.
+func (m *Manager) VersionCacheStats() VersionCacheStats {
+	return m.versions.Stats()
+}
+
+// resolveLatestVersion queries the registry behind an install method for
+// the newest available version.
+func (m *Manager) resolveLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
 	switch method.Method {
 	case "npm":
 		if !m.npm.IsAvailable() {
-			return agent.Version{}, fmt.Errorf("npm is not available")
+			return agent.Version{}, errcode.Errorf(errcode.CodeUnavailable, "npm is not available")
 		}
 		return m.npm.GetLatestVersion(ctx, method)
 
 	case "pip", "pipx", "uv":
 		if !m.pip.IsAvailable() {
-			return agent.Version{}, fmt.Errorf("pip/pipx/uv is not available")
+			return agent.Version{}, errcode.Errorf(errcode.CodeUnavailable, "pip/pipx/uv is not available")
 		}
 		return m.pip.GetLatestVersion(ctx, method)
 
 	case "brew":
 		if !m.brew.IsAvailable() {
-			return agent.Version{}, fmt.Errorf("brew is not available")
+			return agent.Version{}, errcode.Errorf(errcode.CodeUnavailable, "brew is not available")
 		}
 		return m.brew.GetLatestVersion(ctx, method)
 
@@ -198,6 +670,27 @@ func (m *Manager) GetLatestVersion(ctx context.Context, method catalog.InstallMe
 		// Native installs don't have a registry to check
 		return agent.Version{}, fmt.Errorf("version checking not supported for %s", method.Method)
 
+	case "mise":
+		if !m.mise.IsAvailable() {
+			return agent.Version{}, errcode.Errorf(errcode.CodeUnavailable, "mise is not available")
+		}
+		return m.mise.GetLatestVersion(ctx, method)
+
+	case "flatpak":
+		if !m.flatpak.IsAvailable() {
+			return agent.Version{}, errcode.Errorf(errcode.CodeUnavailable, "flatpak is not available")
+		}
+		return m.flatpak.GetLatestVersion(ctx, method)
+
+	case "snap":
+		if !m.snap.IsAvailable() {
+			return agent.Version{}, errcode.Errorf(errcode.CodeUnavailable, "snap is not available")
+		}
+		return m.snap.GetLatestVersion(ctx, method)
+
+	case "github":
+		return m.github.GetLatestVersion(ctx, method)
+
 	default:
 		return agent.Version{}, fmt.Errorf("unsupported install method: %s", method.Method)
 	}
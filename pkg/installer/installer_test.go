@@ -1,9 +1,13 @@
 package installer
 
 import (
+	"context"
 	"testing"
 
+	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
@@ -1163,3 +1167,43 @@ func TestManagerFieldsInitialized(t *testing.T) {
 		t.Error("platform ID should match")
 	}
 }
+
+func TestLockdownRefusesOperations(t *testing.T) {
+	p := platform.Current()
+	m := NewManager(p)
+	m.SetPolicy(config.PolicyConfig{Lockdown: true, LockdownMessage: "managed machine"})
+
+	agentDef := catalog.AgentDef{
+		ID:   "test-agent",
+		Name: "Test Agent",
+	}
+	method := catalog.InstallMethodDef{Method: "npm"}
+
+	if _, err := m.Install(context.Background(), agentDef, method, false); !errcode.Is(err, errcode.CodeLockedDown) {
+		t.Errorf("Install() error = %v, want locked_down", err)
+	}
+	if _, err := m.Update(context.Background(), nil, agentDef, method); !errcode.Is(err, errcode.CodeLockedDown) {
+		t.Errorf("Update() error = %v, want locked_down", err)
+	}
+	if _, err := m.Downgrade(context.Background(), &agent.Installation{}, agentDef, method, agent.Version{}); !errcode.Is(err, errcode.CodeLockedDown) {
+		t.Errorf("Downgrade() error = %v, want locked_down", err)
+	}
+	if err := m.Uninstall(context.Background(), nil, method); !errcode.Is(err, errcode.CodeLockedDown) {
+		t.Errorf("Uninstall() error = %v, want locked_down", err)
+	}
+
+	outcomes := m.UpdateBatch(context.Background(), []BatchUpdateItem{{AgentDef: agentDef, Method: method}})
+	if len(outcomes) != 1 || !errcode.Is(outcomes[0].Err, errcode.CodeLockedDown) {
+		t.Errorf("UpdateBatch() outcomes = %v, want locked_down", outcomes)
+	}
+
+	if err := m.checkPolicy(); err == nil || err.Error() != "managed machine" {
+		t.Errorf("checkPolicy() = %v, want the configured message", err)
+	}
+
+	// Clearing the policy restores normal operation
+	m.SetPolicy(config.PolicyConfig{})
+	if err := m.checkPolicy(); err != nil {
+		t.Errorf("checkPolicy() after clearing = %v, want nil", err)
+	}
+}
@@ -0,0 +1,61 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+)
+
+// MigrationTarget resolves a method's migration hint against the agent's
+// catalog entry. It returns the replacement method definition and true when
+// the method is deprecated and the replacement is actually defined.
+func MigrationTarget(agentDef catalog.AgentDef, method catalog.InstallMethodDef) (catalog.InstallMethodDef, bool) {
+	if method.MigrateTo == "" || method.MigrateTo == method.Method {
+		return catalog.InstallMethodDef{}, false
+	}
+	return agentDef.GetInstallMethod(method.MigrateTo)
+}
+
+// Migrate moves an installation from one install method to another: the
+// replacement method is installed and verified first, then the old
+// installation is removed. If removal fails the new installation is kept and
+// the error reports that the old one was left behind.
+func (m *Manager) Migrate(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, from, to catalog.InstallMethodDef) (*providers.Result, error) {
+	if from.Method == to.Method {
+		return nil, fmt.Errorf("cannot migrate %s: already installed via %s", agentDef.ID, to.Method)
+	}
+
+	result, err := m.Install(ctx, agentDef, to, true)
+	if err != nil {
+		return nil, fmt.Errorf("installing via %s: %w", to.Method, err)
+	}
+
+	if err := m.Uninstall(ctx, inst, from); err != nil {
+		return result, fmt.Errorf("installed via %s but removing the %s installation failed (remove it manually): %w", to.Method, from.Method, err)
+	}
+	return result, nil
+}
+
+// MigrateAgent moves an installation from a deprecated agent to the
+// catalog agent that replaces it (e.g. after a package rename): the
+// replacement is installed via the given method first, then the old
+// installation is removed. If removal fails the new installation is
+// kept and the error reports that the old one was left behind.
+func (m *Manager) MigrateAgent(ctx context.Context, inst *agent.Installation, from catalog.AgentDef, fromMethod catalog.InstallMethodDef, to catalog.AgentDef, toMethod catalog.InstallMethodDef) (*providers.Result, error) {
+	if from.ID == to.ID {
+		return nil, fmt.Errorf("cannot migrate %s: it is its own replacement", from.ID)
+	}
+
+	result, err := m.Install(ctx, to, toMethod, true)
+	if err != nil {
+		return nil, fmt.Errorf("installing %s: %w", to.ID, err)
+	}
+
+	if err := m.Uninstall(ctx, inst, fromMethod); err != nil {
+		return result, fmt.Errorf("installed %s but removing the %s installation failed (remove it manually): %w", to.ID, from.ID, err)
+	}
+	return result, nil
+}
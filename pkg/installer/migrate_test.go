@@ -0,0 +1,81 @@
+package installer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func migrationAgentDef() catalog.AgentDef {
+	return catalog.AgentDef{
+		ID:   "claude-code",
+		Name: "Claude Code",
+		InstallMethods: map[string]catalog.InstallMethodDef{
+			"npm": {
+				Method:        "npm",
+				Command:       "npm install -g @anthropic-ai/claude-code",
+				MigrateTo:     "native",
+				MigrateReason: "the npm distribution is deprecated",
+			},
+			"native": {
+				Method:  "native",
+				Command: "curl -fsSL https://example.com/install.sh | sh",
+			},
+		},
+	}
+}
+
+func TestMigrationTarget(t *testing.T) {
+	def := migrationAgentDef()
+
+	target, ok := MigrationTarget(def, def.InstallMethods["npm"])
+	if !ok {
+		t.Fatal("expected a migration target for the deprecated npm method")
+	}
+	if target.Method != "native" {
+		t.Errorf("target method = %q, want %q", target.Method, "native")
+	}
+
+	if _, ok := MigrationTarget(def, def.InstallMethods["native"]); ok {
+		t.Error("method without a hint should have no migration target")
+	}
+
+	// Hint pointing at an undefined method resolves to nothing
+	broken := def.InstallMethods["npm"]
+	broken.MigrateTo = "flatpak"
+	if _, ok := MigrationTarget(def, broken); ok {
+		t.Error("hint to an undefined method should have no migration target")
+	}
+
+	// A self-referencing hint is ignored
+	circular := def.InstallMethods["npm"]
+	circular.MigrateTo = "npm"
+	if _, ok := MigrationTarget(def, circular); ok {
+		t.Error("self-referencing hint should have no migration target")
+	}
+}
+
+func TestMigrateSameMethod(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+	def := migrationAgentDef()
+	inst := &agent.Installation{AgentID: def.ID, Method: agent.MethodNPM}
+
+	method := def.InstallMethods["npm"]
+	if _, err := mgr.Migrate(context.Background(), inst, def, method, method); err == nil {
+		t.Error("expected migrating to the same method to fail")
+	}
+}
+
+func TestMigrateAgentSelfReplacement(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+	def := migrationAgentDef()
+	inst := &agent.Installation{AgentID: def.ID, Method: agent.MethodNPM}
+
+	method := def.InstallMethods["npm"]
+	if _, err := mgr.MigrateAgent(context.Background(), inst, def, method, def, method); err == nil {
+		t.Error("expected migrating an agent to itself to fail")
+	}
+}
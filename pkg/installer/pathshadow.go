@@ -0,0 +1,54 @@
+package installer
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// ShadowedBy reports the binary that PATH resolves instead of the
+// installed executable, or "" when the installed binary is the one
+// found. This catches installs via a second method where an older
+// install earlier in PATH keeps winning, so the user still runs the
+// stale version.
+func ShadowedBy(names []string, execPath string) string {
+	if execPath == "" || len(names) == 0 {
+		return ""
+	}
+
+	// Prefer the name the install actually produced; fall back to the
+	// agent's primary executable name
+	name := names[0]
+	base := filepath.Base(execPath)
+	for _, candidate := range names {
+		if candidate == base {
+			name = candidate
+			break
+		}
+	}
+
+	resolved, err := exec.LookPath(name)
+	if err != nil {
+		return ""
+	}
+	if SamePath(resolved, execPath) {
+		return ""
+	}
+	return resolved
+}
+
+// SamePath reports whether two paths refer to the same file, following
+// symlinks (version managers and Homebrew link heavily).
+func SamePath(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		if rb, err := filepath.EvalSymlinks(b); err == nil && ra == rb {
+			return true
+		}
+	}
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
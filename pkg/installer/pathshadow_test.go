@@ -0,0 +1,68 @@
+package installer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeExecutable creates an executable file named name in dir.
+func writeExecutable(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("failed to write executable: %v", err)
+	}
+	return path
+}
+
+func TestShadowedBy(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+	shadowing := writeExecutable(t, first, "tool")
+	installed := writeExecutable(t, second, "tool")
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	if got := ShadowedBy([]string{"tool"}, installed); got != shadowing {
+		t.Errorf("ShadowedBy() = %q, want %q", got, shadowing)
+	}
+
+	// The binary PATH finds first is not shadowed
+	if got := ShadowedBy([]string{"tool"}, shadowing); got != "" {
+		t.Errorf("ShadowedBy() = %q for the winning binary, want empty", got)
+	}
+}
+
+func TestShadowedByNotOnPath(t *testing.T) {
+	installed := writeExecutable(t, t.TempDir(), "tool")
+	t.Setenv("PATH", t.TempDir())
+
+	if got := ShadowedBy([]string{"tool"}, installed); got != "" {
+		t.Errorf("ShadowedBy() = %q when the name is not on PATH, want empty", got)
+	}
+}
+
+func TestShadowedBySymlink(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+	installed := writeExecutable(t, second, "tool")
+	link := filepath.Join(first, "tool")
+	if err := os.Symlink(installed, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	// A symlink to the installed binary earlier in PATH is not shadowing
+	if got := ShadowedBy([]string{"tool"}, installed); got != "" {
+		t.Errorf("ShadowedBy() = %q for a symlink to the install, want empty", got)
+	}
+}
+
+func TestShadowedByEmptyInputs(t *testing.T) {
+	if got := ShadowedBy(nil, "/usr/bin/tool"); got != "" {
+		t.Errorf("ShadowedBy() = %q with no names, want empty", got)
+	}
+	if got := ShadowedBy([]string{"tool"}, ""); got != "" {
+		t.Errorf("ShadowedBy() = %q with no path, want empty", got)
+	}
+}
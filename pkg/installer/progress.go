@@ -0,0 +1,127 @@
+package installer
+
+import (
+	"context"
+	"sync"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+)
+
+// ProgressStage identifies a phase of an installer operation.
+type ProgressStage string
+
+const (
+	StagePreparing ProgressStage = "preparing"
+	StageRunning   ProgressStage = "running"
+	StageDone      ProgressStage = "done"
+	StageFailed    ProgressStage = "failed"
+)
+
+// ProgressEvent describes the progress of an installer operation.
+type ProgressEvent struct {
+	AgentID   string        `json:"agent_id"`
+	Operation string        `json:"operation"` // "install", "update", "uninstall", "downgrade"
+	Stage     ProgressStage `json:"stage"`
+	Fraction  float64       `json:"fraction"` // 0.0 to 1.0
+	Message   string        `json:"message,omitempty"`
+	LogLine   string        `json:"log_line,omitempty"`
+}
+
+// ProgressFunc receives progress events during installer operations.
+type ProgressFunc func(ProgressEvent)
+
+// SetProgressHandler registers a handler that receives progress events for
+// all operations run through this manager. Pass nil to remove the handler.
+func (m *Manager) SetProgressHandler(fn ProgressFunc) {
+	m.progressMu.Lock()
+	defer m.progressMu.Unlock()
+	m.progress = fn
+}
+
+// emitProgress delivers a progress event to the registered handler, if any.
+func (m *Manager) emitProgress(ev ProgressEvent) {
+	m.progressMu.RLock()
+	fn := m.progress
+	m.progressMu.RUnlock()
+	if fn != nil {
+		fn(ev)
+	}
+}
+
+// emitOperationProgress emits the standard preparing/done/failed events
+// around an operation and forwards the command output as log lines.
+func (m *Manager) emitOperationProgress(agentID, operation string, run func() (*providers.Result, error)) (*providers.Result, error) {
+	m.emitProgress(ProgressEvent{
+		AgentID:   agentID,
+		Operation: operation,
+		Stage:     StagePreparing,
+		Fraction:  0.0,
+	})
+	m.emitProgress(ProgressEvent{
+		AgentID:   agentID,
+		Operation: operation,
+		Stage:     StageRunning,
+		Fraction:  0.3,
+	})
+
+	result, err := run()
+	if err != nil {
+		m.emitProgress(ProgressEvent{
+			AgentID:   agentID,
+			Operation: operation,
+			Stage:     StageFailed,
+			Fraction:  1.0,
+			Message:   err.Error(),
+		})
+		return nil, err
+	}
+
+	if result != nil && result.Output != "" {
+		m.emitProgress(ProgressEvent{
+			AgentID:   agentID,
+			Operation: operation,
+			Stage:     StageRunning,
+			Fraction:  0.9,
+			LogLine:   result.Output,
+		})
+	}
+
+	m.emitProgress(ProgressEvent{
+		AgentID:   agentID,
+		Operation: operation,
+		Stage:     StageDone,
+		Fraction:  1.0,
+	})
+
+	return result, nil
+}
+
+// progressState holds the manager's progress handler.
+type progressState struct {
+	progressMu sync.RWMutex
+	progress   ProgressFunc
+}
+
+// InstallWithProgress installs an agent, emitting progress events.
+func (m *Manager) InstallWithProgress(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*providers.Result, error) {
+	return m.emitOperationProgress(agentDef.ID, "install", func() (*providers.Result, error) {
+		return m.Install(ctx, agentDef, method, force)
+	})
+}
+
+// UpdateWithProgress updates an agent, emitting progress events.
+func (m *Manager) UpdateWithProgress(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*providers.Result, error) {
+	return m.emitOperationProgress(agentDef.ID, "update", func() (*providers.Result, error) {
+		return m.Update(ctx, inst, agentDef, method)
+	})
+}
+
+// UninstallWithProgress uninstalls an agent, emitting progress events.
+func (m *Manager) UninstallWithProgress(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	_, err := m.emitOperationProgress(inst.AgentID, "uninstall", func() (*providers.Result, error) {
+		return nil, m.Uninstall(ctx, inst, method)
+	})
+	return err
+}
@@ -1,27 +1,35 @@
 package providers
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // BrewProvider handles Homebrew-based installations.
 type BrewProvider struct {
-	platform platform.Platform
+	platform   platform.Platform
+	installers config.InstallersConfig
+	runner     execx.Runner
 }
 
 // NewBrewProvider creates a new Homebrew provider.
 func NewBrewProvider(p platform.Platform) *BrewProvider {
-	return &BrewProvider{platform: p}
+	return &BrewProvider{platform: p, runner: execx.Default}
+}
+
+// SetInstallers applies tool overrides from the installers configuration.
+func (p *BrewProvider) SetInstallers(c config.InstallersConfig) {
+	p.installers = c
 }
 
 // Name returns the provider name.
@@ -36,7 +44,14 @@ func (p *BrewProvider) Method() agent.InstallMethod {
 
 // IsAvailable returns true if brew is available.
 func (p *BrewProvider) IsAvailable() bool {
-	return p.platform.ID() != platform.Windows && p.platform.IsExecutableInPath("brew")
+	if p.platform.ID() == platform.Windows {
+		return false
+	}
+	if brewCmd := p.installers.BrewCommand(); brewCmd != "brew" {
+		_, err := os.Stat(brewCmd)
+		return err == nil
+	}
+	return p.platform.IsExecutableInPath("brew")
 }
 
 // Install installs an agent via Homebrew.
@@ -48,6 +63,13 @@ func (p *BrewProvider) Install(ctx context.Context, agentDef catalog.AgentDef, m
 		return nil, fmt.Errorf("could not determine brew package name")
 	}
 
+	// Tap third-party repositories before installing from them
+	if tap := brewTap(method); tap != "" {
+		if err := p.ensureTapped(ctx, tap); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build install command
 	args := []string{"install"}
 	if isCask {
@@ -58,13 +80,10 @@ func (p *BrewProvider) Install(ctx context.Context, agentDef catalog.AgentDef, m
 	}
 	args = append(args, packageName)
 
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "brew", args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("brew install failed: %w\n%s%s", err, stderr.String(), FormatInstallError("brew", "install", stderr.String()))
+	res, err := p.runner.Run(ctx, p.installers.BrewCommand(), args,
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+	if err != nil {
+		return nil, fmt.Errorf("brew install failed: %w%s", err, FormatInstallError("brew", "install", res.Stderr))
 	}
 
 	// Get installed version
@@ -80,7 +99,7 @@ func (p *BrewProvider) Install(ctx context.Context, agentDef catalog.AgentDef, m
 		Version:        version,
 		ExecutablePath: execPath,
 		Duration:       time.Since(start),
-		Output:         stdout.String(),
+		Output:         res.Stdout,
 	}, nil
 }
 
@@ -102,15 +121,12 @@ func (p *BrewProvider) Update(ctx context.Context, inst *agent.Installation, age
 	}
 	args = append(args, packageName)
 
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "brew", args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
+	res, err := p.runner.Run(ctx, p.installers.BrewCommand(), args,
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+	if err != nil {
 		// brew upgrade returns error if already up to date
-		if !strings.Contains(stderr.String(), "already installed") {
-			return nil, fmt.Errorf("brew upgrade failed: %w\n%s%s", err, stderr.String(), FormatInstallError("brew", "upgrade", stderr.String()))
+		if !strings.Contains(res.Stderr, "already installed") {
+			return nil, fmt.Errorf("brew upgrade failed: %w%s", err, FormatInstallError("brew", "upgrade", res.Stderr))
 		}
 	}
 
@@ -124,12 +140,110 @@ func (p *BrewProvider) Update(ctx context.Context, inst *agent.Installation, age
 		FromVersion:    fromVersion,
 		Version:        toVersion,
 		Duration:       time.Since(start),
-		Output:         stdout.String(),
+		Output:         res.Stdout,
 		WasUpdated:     toVersion.IsNewerThan(fromVersion),
 		ExecutablePath: inst.ExecutablePath,
 	}, nil
 }
 
+// BrewBatchItem pairs an installation with its catalog definitions for
+// a batched upgrade.
+type BrewBatchItem struct {
+	Installation *agent.Installation
+	AgentDef     catalog.AgentDef
+	Method       catalog.InstallMethodDef
+}
+
+// BrewBatchOutcome is the per-item result of UpdateBatch, in input order.
+type BrewBatchOutcome struct {
+	Result *Result
+	Err    error
+}
+
+// UpdateBatch upgrades several brew-installed agents with a single
+// `brew upgrade f1 f2 f3` invocation instead of one brew run per agent,
+// so taps and the formula API are only resolved once. Casks cannot share
+// an invocation with formulae and are upgraded individually. A failure
+// for one formula is attributed to its own outcome without failing the
+// rest of the batch.
+func (p *BrewProvider) UpdateBatch(ctx context.Context, items []BrewBatchItem) []BrewBatchOutcome {
+	outcomes := make([]BrewBatchOutcome, len(items))
+
+	var formulaIdx []int
+	formulaNames := make([]string, 0, len(items))
+	for i, item := range items {
+		packageName, isCask := p.parseBrewPackage(item.Method)
+		if packageName == "" {
+			outcomes[i] = BrewBatchOutcome{Err: fmt.Errorf("could not determine brew package name")}
+			continue
+		}
+		if isCask {
+			result, err := p.Update(ctx, item.Installation, item.AgentDef, item.Method)
+			outcomes[i] = BrewBatchOutcome{Result: result, Err: err}
+			continue
+		}
+		formulaIdx = append(formulaIdx, i)
+		formulaNames = append(formulaNames, packageName)
+	}
+
+	if len(formulaIdx) == 0 {
+		return outcomes
+	}
+
+	start := time.Now()
+	args := append([]string{"upgrade"}, formulaNames...)
+
+	res, runErr := p.runner.Run(ctx, p.installers.BrewCommand(), args,
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+	duration := time.Since(start)
+
+	for j, i := range formulaIdx {
+		item := items[i]
+		packageName := formulaNames[j]
+		fromVersion := item.Installation.InstalledVersion
+		toVersion := p.getInstalledVersion(ctx, packageName, false)
+		upgraded := toVersion.IsNewerThan(fromVersion)
+
+		// A formula whose version did not advance during a failed run is
+		// the one the failure belongs to; formulae that did advance were
+		// upgraded before brew bailed out.
+		if runErr != nil && !upgraded && !strings.Contains(res.Stderr, "already installed") {
+			detail := brewErrorFor(res.Stderr, packageName)
+			if detail == "" {
+				detail = res.Stderr
+			}
+			outcomes[i] = BrewBatchOutcome{Err: fmt.Errorf("brew upgrade failed: %w\n%s%s", runErr, detail, FormatInstallError("brew", "upgrade", detail))}
+			continue
+		}
+
+		outcomes[i] = BrewBatchOutcome{Result: &Result{
+			AgentID:        item.AgentDef.ID,
+			AgentName:      item.AgentDef.Name,
+			Method:         agent.MethodBrew,
+			FromVersion:    fromVersion,
+			Version:        toVersion,
+			Duration:       duration,
+			Output:         res.Stdout,
+			WasUpdated:     upgraded,
+			ExecutablePath: item.Installation.ExecutablePath,
+		}}
+	}
+
+	return outcomes
+}
+
+// brewErrorFor extracts the stderr lines mentioning a formula, used to
+// attribute a per-formula failure inside a batched upgrade.
+func brewErrorFor(stderr, packageName string) string {
+	var lines []string
+	for _, line := range strings.Split(stderr, "\n") {
+		if strings.Contains(line, packageName) {
+			lines = append(lines, strings.TrimSpace(line))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
 // Uninstall removes a Homebrew-installed agent.
 func (p *BrewProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
 	packageName, isCask := p.parseBrewPackage(method)
@@ -143,17 +257,54 @@ func (p *BrewProvider) Uninstall(ctx context.Context, inst *agent.Installation,
 	}
 	args = append(args, packageName)
 
-	var stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "brew", args...)
-	cmd.Stderr = &stderr
+	_, err := p.runner.Run(ctx, p.installers.BrewCommand(), args,
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+	if err != nil {
+		return fmt.Errorf("brew uninstall failed: %w", err)
+	}
+
+	return nil
+}
 
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("brew uninstall failed: %w\n%s", err, stderr.String())
+// ensureTapped taps a third-party repository if it is not already tapped.
+func (p *BrewProvider) ensureTapped(ctx context.Context, tap string) error {
+	res, err := p.runner.Run(ctx, p.installers.BrewCommand(), []string{"tap"},
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+	if err == nil {
+		for _, line := range strings.Split(res.Stdout, "\n") {
+			if strings.EqualFold(strings.TrimSpace(line), tap) {
+				return nil
+			}
+		}
 	}
 
+	if _, err := p.runner.Run(ctx, p.installers.BrewCommand(), []string{"tap", tap},
+		execx.WithTimeout(p.installers.TimeoutFor("brew"))); err != nil {
+		return fmt.Errorf("brew tap %s failed: %w", tap, err)
+	}
 	return nil
 }
 
+// brewTap returns the third-party tap an install method references, if any.
+// Official homebrew/* taps are built in and never need tapping.
+func brewTap(method catalog.InstallMethodDef) string {
+	candidates := []string{method.Package}
+	candidates = append(candidates, strings.Fields(method.Command)...)
+
+	for _, candidate := range candidates {
+		segments := strings.Split(candidate, "/")
+		if len(segments) != 3 || strings.HasPrefix(candidate, "-") {
+			continue
+		}
+		if strings.EqualFold(segments[0], "homebrew") {
+			continue
+		}
+		return segments[0] + "/" + segments[1]
+	}
+
+	return ""
+}
+
 // parseBrewPackage extracts the package name and determines if it's a cask.
 func (p *BrewProvider) parseBrewPackage(method catalog.InstallMethodDef) (string, bool) {
 	packageName := method.Package
@@ -182,11 +333,12 @@ func (p *BrewProvider) getInstalledVersion(ctx context.Context, packageName stri
 	}
 	args = append(args, packageName)
 
-	cmd := exec.CommandContext(ctx, "brew", args...)
-	output, err := cmd.Output()
+	res, err := p.runner.Run(ctx, p.installers.BrewCommand(), args,
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
 	if err != nil {
 		return agent.Version{}
 	}
+	output := []byte(res.Stdout)
 
 	var result struct {
 		Formulae []struct {
@@ -227,11 +379,14 @@ func (p *BrewProvider) GetLatestVersion(ctx context.Context, method catalog.Inst
 	}
 	args = append(args, packageName)
 
-	cmd := exec.CommandContext(ctx, "brew", args...)
-	output, err := cmd.Output()
+	// brew's API-backed info command is occasionally flaky, so retry
+	// briefly before giving up
+	execResult, err := p.runner.Run(ctx, p.installers.BrewCommand(), args,
+		execx.WithRetries(2, time.Second))
 	if err != nil {
 		return agent.Version{}, fmt.Errorf("brew info failed: %w", err)
 	}
+	output := []byte(execResult.Stdout)
 
 	var result struct {
 		Formulae []struct {
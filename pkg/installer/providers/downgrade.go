@@ -0,0 +1,157 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
+)
+
+// Downgrade installs a specific older version of an npm package.
+func (p *NPMProvider) Downgrade(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, target agent.Version) (*Result, error) {
+	start := time.Now()
+
+	packageName := method.Package
+	if packageName == "" {
+		packageName = extractNPMPackage(method.Command)
+	}
+	if packageName == "" {
+		return nil, fmt.Errorf("could not determine npm package name")
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	res, err := p.runner.Run(ctx, p.installers.NPMCommand(),
+		[]string{"install", "-g", fmt.Sprintf("%s@%s", packageName, target.String())},
+		execx.WithEnv(p.installers.NPMEnv()...),
+		execx.WithTimeout(p.installers.TimeoutFor("npm")))
+	if err != nil {
+		return nil, fmt.Errorf("npm downgrade failed: %w%s", err, formatNPMPermissionHint(res.Stderr))
+	}
+
+	toVersion := p.getInstalledVersion(ctx, packageName)
+
+	return &Result{
+		AgentID:        agentDef.ID,
+		AgentName:      agentDef.Name,
+		Method:         agent.MethodNPM,
+		FromVersion:    fromVersion,
+		Version:        toVersion,
+		Duration:       time.Since(start),
+		Output:         res.Stdout,
+		ExecutablePath: inst.ExecutablePath,
+	}, nil
+}
+
+// Downgrade installs a specific older version of a pip/pipx/uv package.
+func (p *PipProvider) Downgrade(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, target agent.Version) (*Result, error) {
+	start := time.Now()
+
+	packageName := method.Package
+	if packageName == "" {
+		packageName = extractPipPackage(method.Command)
+	}
+	if packageName == "" {
+		return nil, fmt.Errorf("could not determine package name")
+	}
+
+	pinned := fmt.Sprintf("%s==%s", packageName, target.String())
+
+	var manager string
+	var args []string
+	switch method.Method {
+	case "pipx":
+		if !p.platform.IsExecutableInPath("pipx") {
+			return nil, fmt.Errorf("pipx is not installed")
+		}
+		manager = "pipx"
+		args = []string{"install", "--force", pinned}
+
+	case "uv":
+		if !p.platform.IsExecutableInPath("uv") {
+			return nil, fmt.Errorf("uv is not installed")
+		}
+		manager = "uv"
+		args = []string{"tool", "install", "--force", pinned}
+
+	default: // pip
+		manager = "pip3"
+		if !p.platform.IsExecutableInPath("pip3") {
+			manager = "pip"
+		}
+		if !p.platform.IsExecutableInPath(manager) {
+			return nil, fmt.Errorf("pip is not installed")
+		}
+		args = []string{"install", pinned}
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	res, err := p.runner.Run(ctx, manager, args,
+		execx.WithEnv(p.managerEnv(manager)...),
+		execx.WithTimeout(p.installers.TimeoutFor(manager)))
+	if err != nil {
+		return nil, fmt.Errorf("%s downgrade failed: %w%s", manager, err, FormatInstallError(manager, "downgrade", res.Stderr))
+	}
+
+	toVersion := p.getInstalledVersion(ctx, manager, packageName)
+
+	return &Result{
+		AgentID:        agentDef.ID,
+		AgentName:      agentDef.Name,
+		Method:         p.methodFromManager(manager),
+		FromVersion:    fromVersion,
+		Version:        toVersion,
+		Duration:       time.Since(start),
+		Output:         res.Stdout,
+		ExecutablePath: inst.ExecutablePath,
+	}, nil
+}
+
+// Downgrade installs a specific older version of a Homebrew formula.
+// Homebrew only supports versioned formulae (formula@version), so this
+// works when a versioned formula exists for the target major.minor.
+func (p *BrewProvider) Downgrade(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, target agent.Version) (*Result, error) {
+	start := time.Now()
+
+	formula, isCask := p.parseBrewPackage(method)
+	if formula == "" {
+		return nil, fmt.Errorf("could not determine brew formula name")
+	}
+	if isCask {
+		return nil, fmt.Errorf("downgrading casks is not supported")
+	}
+
+	fromVersion := inst.InstalledVersion
+	versioned := fmt.Sprintf("%s@%d.%d", formula, target.Major, target.Minor)
+
+	res, err := p.runner.Run(ctx, p.installers.BrewCommand(), []string{"install", versioned},
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+	if err != nil {
+		return nil, fmt.Errorf("brew downgrade failed (no versioned formula %s?): %w%s",
+			versioned, err, FormatInstallError("brew", "downgrade", res.Stderr))
+	}
+
+	// Link the versioned formula over the current one
+	_, _ = p.runner.Run(ctx, p.installers.BrewCommand(), []string{"link", "--overwrite", versioned},
+		execx.WithTimeout(p.installers.TimeoutFor("brew")))
+
+	toVersion := p.getInstalledVersion(ctx, versioned, false)
+	if toVersion.IsZero() {
+		toVersion = target
+	}
+
+	return &Result{
+		AgentID:        agentDef.ID,
+		AgentName:      agentDef.Name,
+		Method:         agent.MethodBrew,
+		FromVersion:    fromVersion,
+		Version:        toVersion,
+		Duration:       time.Since(start),
+		Output:         res.Stdout,
+		ExecutablePath: inst.ExecutablePath,
+	}, nil
+}
@@ -0,0 +1,512 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// DownloadProvider handles agents distributed as direct installer
+// downloads: DMG and PKG on macOS, MSI and EXE on Windows. Downloads are
+// checksum-verified when the catalog declares one, installs run silently
+// where the format allows it, and an install receipt is recorded so the
+// agent can be uninstalled later.
+type DownloadProvider struct {
+	platform platform.Platform
+
+	// receiptsDir overrides the receipt directory (for testing).
+	receiptsDir string
+}
+
+// NewDownloadProvider creates a new direct-download installer provider.
+func NewDownloadProvider(p platform.Platform) *DownloadProvider {
+	return &DownloadProvider{platform: p}
+}
+
+// Name returns the provider name.
+func (p *DownloadProvider) Name() string {
+	return "download"
+}
+
+// Method returns the install method this provider handles.
+func (p *DownloadProvider) Method() agent.InstallMethod {
+	return agent.MethodNative
+}
+
+// IsAvailable returns true if the current platform can run one of the
+// supported installer formats.
+func (p *DownloadProvider) IsAvailable() bool {
+	return p.platform.ID() == platform.Darwin || p.platform.ID() == platform.Windows
+}
+
+// SupportsMethod reports whether this provider handles the given method on
+// the current platform.
+func (p *DownloadProvider) SupportsMethod(method string) bool {
+	switch method {
+	case "dmg", "pkg":
+		return p.platform.ID() == platform.Darwin
+	case "msi", "exe":
+		return p.platform.ID() == platform.Windows
+	default:
+		return false
+	}
+}
+
+// installReceipt records what an installer put on the system so it can be
+// uninstalled later.
+type installReceipt struct {
+	AgentID     string    `json:"agent_id"`
+	Method      string    `json:"method"` // dmg, pkg, msi, exe
+	Version     string    `json:"version,omitempty"`
+	AppPath     string    `json:"app_path,omitempty"`     // dmg: installed bundle
+	ProductCode string    `json:"product_code,omitempty"` // msi: code for msiexec /x
+	InstalledAt time.Time `json:"installed_at"`
+}
+
+// Install downloads the installer, verifies its checksum, and runs it.
+func (p *DownloadProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error) {
+	start := time.Now()
+
+	url := downloadURL(method)
+	if url == "" {
+		return nil, fmt.Errorf("no download URL specified for %s", method.Method)
+	}
+
+	installerPath, err := p.download(ctx, url, downloadChecksum(method))
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(installerPath)
+
+	receipt := installReceipt{
+		AgentID:     agentDef.ID,
+		Method:      method.Method,
+		InstalledAt: time.Now().UTC(),
+	}
+
+	var output string
+	switch method.Method {
+	case "dmg":
+		output, receipt.AppPath, err = p.installDMG(ctx, installerPath, method)
+	case "pkg":
+		output, err = p.installPKG(ctx, installerPath)
+	case "msi":
+		output, err = p.installMSI(ctx, installerPath)
+		receipt.ProductCode = method.Metadata["product_code"]
+	case "exe":
+		output, err = p.installEXE(ctx, installerPath, method)
+	default:
+		return nil, fmt.Errorf("unsupported installer format: %s", method.Method)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s install failed: %w", method.Method, err)
+	}
+
+	version := p.installedVersion(ctx, agentDef, receipt)
+	receipt.Version = version.String()
+
+	if err := p.writeReceipt(receipt); err != nil {
+		return nil, fmt.Errorf("installed but failed to record receipt: %w", err)
+	}
+
+	return &Result{
+		AgentID:     agentDef.ID,
+		AgentName:   agentDef.Name,
+		Method:      agent.InstallMethod(method.Method),
+		Version:     version,
+		InstallPath: receipt.AppPath,
+		Duration:    time.Since(start),
+		Output:      output,
+	}, nil
+}
+
+// Update re-runs the installer, which replaces the existing installation.
+func (p *DownloadProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
+	fromVersion := inst.InstalledVersion
+
+	result, err := p.Install(ctx, agentDef, method, true)
+	if err != nil {
+		return nil, err
+	}
+
+	result.FromVersion = fromVersion
+	result.WasUpdated = result.Version.IsNewerThan(fromVersion)
+	return result, nil
+}
+
+// Uninstall removes the agent using its install receipt.
+func (p *DownloadProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	receipt, err := p.readReceipt(inst.AgentID, method.Method)
+	if err != nil {
+		return fmt.Errorf("no install receipt found: %w", err)
+	}
+
+	switch receipt.Method {
+	case "dmg":
+		if receipt.AppPath == "" {
+			return fmt.Errorf("receipt has no app path")
+		}
+		if err := os.RemoveAll(receipt.AppPath); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", receipt.AppPath, err)
+		}
+
+	case "pkg":
+		// pkg payloads scatter files; removing the app bundle (when known)
+		// is the best we can do without forgetting the receipt.
+		if receipt.AppPath != "" {
+			if err := os.RemoveAll(receipt.AppPath); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", receipt.AppPath, err)
+			}
+		}
+
+	case "msi":
+		if receipt.ProductCode == "" {
+			return fmt.Errorf("receipt has no MSI product code")
+		}
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, "msiexec", "/x", receipt.ProductCode, "/qn", "/norestart")
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("msiexec uninstall failed: %w\n%s", err, stderr.String())
+		}
+
+	case "exe":
+		if method.UninstallCmd == "" {
+			return fmt.Errorf("no uninstall command for EXE install")
+		}
+		var stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, p.platform.GetShell(), p.platform.GetShellArg(), method.UninstallCmd)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("uninstall command failed: %w\n%s", err, stderr.String())
+		}
+
+	default:
+		return fmt.Errorf("unsupported installer format: %s", receipt.Method)
+	}
+
+	return p.removeReceipt(inst.AgentID, receipt.Method)
+}
+
+// GetLatestVersion is not supported; direct downloads have no registry to
+// query.
+func (p *DownloadProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	return agent.Version{}, fmt.Errorf("version checking not supported for %s", method.Method)
+}
+
+// download fetches the installer to a temp file and verifies its SHA-256
+// checksum when one is declared.
+func (p *DownloadProvider) download(ctx context.Context, url, checksum string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid download URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("download failed: HTTP %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "agentmgr-installer-*"+filepath.Ext(url))
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("download failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err := verifyChecksum(hash.Sum(nil), checksum); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// verifyChecksum compares a computed SHA-256 digest against the expected
+// hex string. An empty expected checksum skips verification.
+func verifyChecksum(sum []byte, expected string) error {
+	if expected == "" {
+		return nil
+	}
+
+	expected = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(expected, "sha256:")))
+	actual := hex.EncodeToString(sum)
+	if actual != expected {
+		return fmt.Errorf("checksum mismatch: got %s, want %s", actual, expected)
+	}
+	return nil
+}
+
+// installDMG mounts a disk image, copies the app bundle into
+// /Applications, and detaches the image.
+func (p *DownloadProvider) installDMG(ctx context.Context, dmgPath string, method catalog.InstallMethodDef) (output, appPath string, err error) {
+	mountPoint, err := os.MkdirTemp("", "agentmgr-dmg-")
+	if err != nil {
+		return "", "", err
+	}
+	defer os.RemoveAll(mountPoint)
+
+	var stdout, stderr bytes.Buffer
+	attach := exec.CommandContext(ctx, "hdiutil", "attach", dmgPath, "-mountpoint", mountPoint, "-nobrowse", "-quiet")
+	attach.Stdout = &stdout
+	attach.Stderr = &stderr
+	if err := attach.Run(); err != nil {
+		return "", "", fmt.Errorf("hdiutil attach failed: %w\n%s", err, stderr.String())
+	}
+	defer exec.Command("hdiutil", "detach", mountPoint, "-quiet").Run()
+
+	appName := method.Metadata["app"]
+	if appName != "" && !strings.HasSuffix(appName, ".app") {
+		appName += ".app"
+	}
+	if appName == "" {
+		entries, err := os.ReadDir(mountPoint)
+		if err != nil {
+			return "", "", err
+		}
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".app") {
+				appName = entry.Name()
+				break
+			}
+		}
+	}
+	if appName == "" {
+		return "", "", fmt.Errorf("no app bundle found in disk image")
+	}
+
+	appPath = filepath.Join("/Applications", appName)
+	copyCmd := exec.CommandContext(ctx, "cp", "-R", filepath.Join(mountPoint, appName), appPath)
+	copyCmd.Stderr = &stderr
+	if err := copyCmd.Run(); err != nil {
+		return "", "", fmt.Errorf("failed to copy app bundle: %w\n%s", err, stderr.String())
+	}
+
+	return stdout.String(), appPath, nil
+}
+
+// installPKG runs the macOS package installer. Installing to / requires
+// elevated privileges; a CurrentUserHomeDirectory target is not attempted
+// because most agent pkgs do not support it.
+func (p *DownloadProvider) installPKG(ctx context.Context, pkgPath string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "installer", "-pkg", pkgPath, "-target", "/")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("installer failed (may require sudo): %w\n%s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// installMSI runs msiexec silently.
+func (p *DownloadProvider) installMSI(ctx context.Context, msiPath string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "msiexec", "/i", msiPath, "/qn", "/norestart")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("msiexec failed: %w\n%s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// installEXE runs an installer executable with its silent flag, defaulting
+// to the NSIS-style /S.
+func (p *DownloadProvider) installEXE(ctx context.Context, exePath string, method catalog.InstallMethodDef) (string, error) {
+	silentFlag := method.Metadata["silent_flag"]
+	if silentFlag == "" {
+		silentFlag = "/S"
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, exePath, silentFlag)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("installer failed: %w\n%s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// installedVersion determines the installed version: from the app bundle's
+// Info.plist on macOS, the Windows registry when a key is declared, or the
+// agent's version command as a fallback.
+func (p *DownloadProvider) installedVersion(ctx context.Context, agentDef catalog.AgentDef, receipt installReceipt) agent.Version {
+	if receipt.AppPath != "" {
+		if v := readBundleVersion(receipt.AppPath); !v.IsZero() {
+			return v
+		}
+	}
+
+	if p.platform.ID() == platform.Windows {
+		if key := agentDef.Metadata["registry_key"]; key != "" {
+			if v := queryRegistryVersion(ctx, key); !v.IsZero() {
+				return v
+			}
+		}
+	}
+
+	if agentDef.Detection.VersionCmd != "" {
+		output, err := exec.CommandContext(ctx, p.platform.GetShell(), p.platform.GetShellArg(), agentDef.Detection.VersionCmd).CombinedOutput()
+		if err == nil {
+			version, _ := agent.ParseVersion(extractVersionString(strings.TrimSpace(string(output))))
+			return version
+		}
+	}
+
+	return agent.Version{}
+}
+
+// readBundleVersion reads CFBundleShortVersionString from an app bundle's
+// XML Info.plist.
+func readBundleVersion(appPath string) agent.Version {
+	data, err := os.ReadFile(filepath.Join(appPath, "Contents", "Info.plist"))
+	if err != nil {
+		return agent.Version{}
+	}
+
+	marker := "<key>CFBundleShortVersionString</key>"
+	idx := bytes.Index(data, []byte(marker))
+	if idx < 0 {
+		return agent.Version{}
+	}
+
+	rest := string(data[idx+len(marker):])
+	start := strings.Index(rest, "<string>")
+	end := strings.Index(rest, "</string>")
+	if start < 0 || end < 0 || end <= start {
+		return agent.Version{}
+	}
+
+	version, _ := agent.ParseVersion(strings.TrimSpace(rest[start+len("<string>") : end]))
+	return version
+}
+
+// queryRegistryVersion reads DisplayVersion from an uninstall registry key.
+func queryRegistryVersion(ctx context.Context, key string) agent.Version {
+	output, err := exec.CommandContext(ctx, "reg", "query", key, "/v", "DisplayVersion").Output()
+	if err != nil {
+		return agent.Version{}
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] == "DisplayVersion" {
+			version, _ := agent.ParseVersion(fields[len(fields)-1])
+			return version
+		}
+	}
+	return agent.Version{}
+}
+
+// downloadURL returns the installer URL for an install method, preferring a
+// per-architecture URL (metadata key "url_<arch>", e.g. "url_arm64") matching
+// the host over the generic "url" entry.
+func downloadURL(method catalog.InstallMethodDef) string {
+	if v := archMetadata(method, "url"); v != "" {
+		return v
+	}
+	if strings.HasPrefix(method.Package, "http://") || strings.HasPrefix(method.Package, "https://") {
+		return method.Package
+	}
+	return ""
+}
+
+// downloadChecksum returns the expected checksum for the selected artifact,
+// with the same per-architecture preference as downloadURL.
+func downloadChecksum(method catalog.InstallMethodDef) string {
+	return archMetadata(method, "checksum")
+}
+
+// archMetadata looks up a metadata key, trying the host-architecture variant
+// ("<key>_<arch>") before falling back to the plain key.
+func archMetadata(method catalog.InstallMethodDef, key string) string {
+	if method.Metadata == nil {
+		return ""
+	}
+	if v := method.Metadata[key+"_"+agent.HostArchitecture()]; v != "" {
+		return v
+	}
+	return method.Metadata[key]
+}
+
+// receiptDir returns the directory install receipts are stored in.
+func (p *DownloadProvider) receiptDir() string {
+	if p.receiptsDir != "" {
+		return p.receiptsDir
+	}
+	return filepath.Join(p.platform.GetDataDir(), "receipts")
+}
+
+// receiptPath returns the receipt file path for an agent and method.
+func (p *DownloadProvider) receiptPath(agentID, method string) string {
+	return filepath.Join(p.receiptDir(), agentID+"-"+method+".json")
+}
+
+// writeReceipt persists an install receipt.
+func (p *DownloadProvider) writeReceipt(receipt installReceipt) error {
+	if err := os.MkdirAll(p.receiptDir(), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(receipt, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p.receiptPath(receipt.AgentID, receipt.Method), data, 0644)
+}
+
+// readReceipt loads the install receipt for an agent and method.
+func (p *DownloadProvider) readReceipt(agentID, method string) (*installReceipt, error) {
+	data, err := os.ReadFile(p.receiptPath(agentID, method))
+	if err != nil {
+		return nil, err
+	}
+
+	var receipt installReceipt
+	if err := json.Unmarshal(data, &receipt); err != nil {
+		return nil, err
+	}
+	return &receipt, nil
+}
+
+// removeReceipt deletes the install receipt for an agent and method.
+func (p *DownloadProvider) removeReceipt(agentID, method string) error {
+	err := os.Remove(p.receiptPath(agentID, method))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,148 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestVerifyChecksum(t *testing.T) {
+	sum := sha256.Sum256([]byte("installer contents"))
+	hexSum := "eef523368dce718bb9a2cb6df91baa07ef1669cdc6aee2e3525a0bb2e5b5a55f"
+
+	if err := verifyChecksum(sum[:], hexSum); err != nil {
+		t.Errorf("expected matching checksum to verify: %v", err)
+	}
+	if err := verifyChecksum(sum[:], "sha256:"+hexSum); err != nil {
+		t.Errorf("expected prefixed checksum to verify: %v", err)
+	}
+	if err := verifyChecksum(sum[:], ""); err != nil {
+		t.Errorf("expected empty checksum to skip verification: %v", err)
+	}
+	if err := verifyChecksum(sum[:], "deadbeef"); err == nil {
+		t.Error("expected mismatched checksum to fail")
+	}
+}
+
+func TestDownloadURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   catalog.InstallMethodDef
+		expected string
+	}{
+		{
+			name:     "url metadata",
+			method:   catalog.InstallMethodDef{Metadata: map[string]string{"url": "https://example.com/app.dmg"}},
+			expected: "https://example.com/app.dmg",
+		},
+		{
+			name:     "url in package field",
+			method:   catalog.InstallMethodDef{Package: "https://example.com/setup.msi"},
+			expected: "https://example.com/setup.msi",
+		},
+		{
+			name: "arch-specific url preferred",
+			method: catalog.InstallMethodDef{Metadata: map[string]string{
+				"url":                                  "https://example.com/app.dmg",
+				"url_" + agent.HostArchitecture():      "https://example.com/app-native.dmg",
+				"checksum_" + agent.HostArchitecture(): "abc123",
+			}},
+			expected: "https://example.com/app-native.dmg",
+		},
+		{
+			name:     "plain package name",
+			method:   catalog.InstallMethodDef{Package: "my-agent"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := downloadURL(tt.method); got != tt.expected {
+				t.Errorf("downloadURL() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDownloadChecksumArchPreference(t *testing.T) {
+	method := catalog.InstallMethodDef{Metadata: map[string]string{
+		"checksum":                             "generic",
+		"checksum_" + agent.HostArchitecture(): "native",
+	}}
+	if got := downloadChecksum(method); got != "native" {
+		t.Errorf("downloadChecksum() = %q, want %q", got, "native")
+	}
+	if got := downloadChecksum(catalog.InstallMethodDef{Metadata: map[string]string{"checksum": "generic"}}); got != "generic" {
+		t.Errorf("downloadChecksum() = %q, want %q", got, "generic")
+	}
+}
+
+func TestReceiptRoundtrip(t *testing.T) {
+	p := NewDownloadProvider(platform.Current())
+	p.receiptsDir = t.TempDir()
+
+	receipt := installReceipt{
+		AgentID:     "claude-desktop",
+		Method:      "dmg",
+		Version:     "1.4.2",
+		AppPath:     "/Applications/Claude Desktop.app",
+		InstalledAt: time.Now().UTC(),
+	}
+
+	if err := p.writeReceipt(receipt); err != nil {
+		t.Fatalf("writeReceipt failed: %v", err)
+	}
+
+	got, err := p.readReceipt("claude-desktop", "dmg")
+	if err != nil {
+		t.Fatalf("readReceipt failed: %v", err)
+	}
+	if got.AppPath != receipt.AppPath || got.Version != receipt.Version {
+		t.Errorf("readReceipt = %+v, want %+v", got, receipt)
+	}
+
+	if err := p.removeReceipt("claude-desktop", "dmg"); err != nil {
+		t.Fatalf("removeReceipt failed: %v", err)
+	}
+	if _, err := p.readReceipt("claude-desktop", "dmg"); err == nil {
+		t.Error("expected error reading removed receipt")
+	}
+
+	// Removing a missing receipt is not an error
+	if err := p.removeReceipt("claude-desktop", "dmg"); err != nil {
+		t.Errorf("removeReceipt on missing receipt: %v", err)
+	}
+}
+
+func TestDownloadProviderSupportsMethod(t *testing.T) {
+	p := NewDownloadProvider(platform.Current())
+
+	// The supported formats depend on the host platform, but unknown
+	// formats are never supported.
+	if p.SupportsMethod("deb") {
+		t.Error("expected deb to be unsupported")
+	}
+
+	switch platform.Current().ID() {
+	case platform.Darwin:
+		if !p.SupportsMethod("dmg") || !p.SupportsMethod("pkg") {
+			t.Error("expected dmg/pkg support on darwin")
+		}
+		if p.SupportsMethod("msi") {
+			t.Error("expected msi to be unsupported on darwin")
+		}
+	case platform.Windows:
+		if !p.SupportsMethod("msi") || !p.SupportsMethod("exe") {
+			t.Error("expected msi/exe support on windows")
+		}
+	default:
+		if p.SupportsMethod("dmg") || p.SupportsMethod("msi") {
+			t.Error("expected no installer support on linux")
+		}
+	}
+}
@@ -0,0 +1,156 @@
+// Package providers contains installation provider implementations.
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// ExtensionProvider handles VS Code extension installations via the
+// `code` CLI.
+type ExtensionProvider struct {
+	platform platform.Platform
+}
+
+// NewExtensionProvider creates a new editor extension provider.
+func NewExtensionProvider(p platform.Platform) *ExtensionProvider {
+	return &ExtensionProvider{platform: p}
+}
+
+// Name returns the provider name.
+func (p *ExtensionProvider) Name() string {
+	return "extension"
+}
+
+// Method returns the install method this provider handles.
+func (p *ExtensionProvider) Method() agent.InstallMethod {
+	return agent.MethodExtension
+}
+
+// IsAvailable returns true if the VS Code CLI is available.
+func (p *ExtensionProvider) IsAvailable() bool {
+	return p.platform.IsExecutableInPath("code")
+}
+
+// Install installs an extension via `code --install-extension`.
+func (p *ExtensionProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error) {
+	start := time.Now()
+
+	extensionID := method.Package
+	if extensionID == "" {
+		return nil, fmt.Errorf("could not determine extension ID")
+	}
+
+	args := []string{"--install-extension", extensionID}
+	if force {
+		args = append(args, "--force")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "code", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("extension install failed: %w\n%s", err, stderr.String())
+	}
+
+	version := p.getInstalledVersion(ctx, extensionID)
+
+	return &Result{
+		AgentID:   agentDef.ID,
+		AgentName: agentDef.Name,
+		Method:    agent.MethodExtension,
+		Version:   version,
+		Duration:  time.Since(start),
+		Output:    stdout.String(),
+	}, nil
+}
+
+// Update updates an installed extension. VS Code reinstalls the latest
+// version when --force is passed.
+func (p *ExtensionProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
+	start := time.Now()
+
+	extensionID := method.Package
+	if extensionID == "" {
+		return nil, fmt.Errorf("could not determine extension ID")
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "code", "--install-extension", extensionID, "--force")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("extension update failed: %w\n%s", err, stderr.String())
+	}
+
+	toVersion := p.getInstalledVersion(ctx, extensionID)
+
+	return &Result{
+		AgentID:     agentDef.ID,
+		AgentName:   agentDef.Name,
+		Method:      agent.MethodExtension,
+		FromVersion: fromVersion,
+		Version:     toVersion,
+		Duration:    time.Since(start),
+		Output:      stdout.String(),
+		WasUpdated:  toVersion.IsNewerThan(fromVersion),
+	}, nil
+}
+
+// Uninstall removes an installed extension.
+func (p *ExtensionProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	extensionID := method.Package
+	if extensionID == "" {
+		return fmt.Errorf("could not determine extension ID")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "code", "--uninstall-extension", extensionID)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("extension uninstall failed: %w\n%s", err, stderr.String())
+	}
+
+	return nil
+}
+
+// getInstalledVersion gets the installed version of an extension.
+func (p *ExtensionProvider) getInstalledVersion(ctx context.Context, extensionID string) agent.Version {
+	cmd := exec.CommandContext(ctx, "code", "--list-extensions", "--show-versions")
+	output, err := cmd.Output()
+	if err != nil {
+		return agent.Version{}
+	}
+
+	// Output format: publisher.name@version
+	prefix := strings.ToLower(extensionID) + "@"
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(strings.ToLower(line), prefix) {
+			versionStr := strings.TrimSpace(line[len(prefix):])
+			version, _ := agent.ParseVersion(versionStr)
+			return version
+		}
+	}
+
+	return agent.Version{}
+}
+
+// GetLatestVersion is not supported for extensions; the marketplace has no
+// simple CLI query for the latest version.
+func (p *ExtensionProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	return agent.Version{}, fmt.Errorf("version checking not supported for extensions")
+}
@@ -0,0 +1,175 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// FlatpakProvider handles Flatpak-based installations on Linux.
+type FlatpakProvider struct {
+	platform platform.Platform
+}
+
+// NewFlatpakProvider creates a new Flatpak provider.
+func NewFlatpakProvider(p platform.Platform) *FlatpakProvider {
+	return &FlatpakProvider{platform: p}
+}
+
+// Name returns the provider name.
+func (p *FlatpakProvider) Name() string {
+	return "flatpak"
+}
+
+// Method returns the install method this provider handles.
+func (p *FlatpakProvider) Method() agent.InstallMethod {
+	return agent.MethodFlatpak
+}
+
+// IsAvailable returns true if flatpak is available.
+func (p *FlatpakProvider) IsAvailable() bool {
+	return p.platform.ID() == platform.Linux && p.platform.IsExecutableInPath("flatpak")
+}
+
+// Install installs an application via flatpak, defaulting to the flathub
+// remote.
+func (p *FlatpakProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error) {
+	start := time.Now()
+
+	appID := method.Package
+	if appID == "" {
+		return nil, fmt.Errorf("could not determine flatpak application ID")
+	}
+
+	remote := "flathub"
+	if method.Metadata != nil && method.Metadata["remote"] != "" {
+		remote = method.Metadata["remote"]
+	}
+
+	args := []string{"install", "-y", "--noninteractive", remote, appID}
+	if force {
+		args = append(args, "--reinstall")
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "flatpak", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("flatpak install failed: %w\n%s", err, stderr.String())
+	}
+
+	return &Result{
+		AgentID:   agentDef.ID,
+		AgentName: agentDef.Name,
+		Method:    agent.MethodFlatpak,
+		Version:   p.getInstalledVersion(ctx, appID),
+		Duration:  time.Since(start),
+		Output:    stdout.String(),
+	}, nil
+}
+
+// Update updates a flatpak-installed application.
+func (p *FlatpakProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
+	start := time.Now()
+
+	appID := method.Package
+	if appID == "" {
+		return nil, fmt.Errorf("could not determine flatpak application ID")
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "flatpak", "update", "-y", "--noninteractive", appID)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("flatpak update failed: %w\n%s", err, stderr.String())
+	}
+
+	toVersion := p.getInstalledVersion(ctx, appID)
+
+	return &Result{
+		AgentID:     agentDef.ID,
+		AgentName:   agentDef.Name,
+		Method:      agent.MethodFlatpak,
+		FromVersion: fromVersion,
+		Version:     toVersion,
+		Duration:    time.Since(start),
+		Output:      stdout.String(),
+		WasUpdated:  toVersion.IsNewerThan(fromVersion),
+	}, nil
+}
+
+// Uninstall removes a flatpak-installed application.
+func (p *FlatpakProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	appID := method.Package
+	if appID == "" {
+		return fmt.Errorf("could not determine flatpak application ID")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "flatpak", "uninstall", "-y", "--noninteractive", appID)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("flatpak uninstall failed: %w\n%s", err, stderr.String())
+	}
+
+	return nil
+}
+
+// GetLatestVersion queries the remote version via `flatpak remote-info`.
+func (p *FlatpakProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	appID := method.Package
+	if appID == "" {
+		return agent.Version{}, fmt.Errorf("could not determine flatpak application ID")
+	}
+
+	remote := "flathub"
+	if method.Metadata != nil && method.Metadata["remote"] != "" {
+		remote = method.Metadata["remote"]
+	}
+
+	output, err := exec.CommandContext(ctx, "flatpak", "remote-info", remote, appID).Output()
+	if err != nil {
+		return agent.Version{}, fmt.Errorf("failed to get latest version: %w", err)
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Version:") {
+			return agent.ParseVersion(strings.TrimSpace(strings.TrimPrefix(line, "Version:")))
+		}
+	}
+
+	return agent.Version{}, fmt.Errorf("no version found for %s", appID)
+}
+
+// getInstalledVersion returns the installed version of a flatpak app.
+func (p *FlatpakProvider) getInstalledVersion(ctx context.Context, appID string) agent.Version {
+	output, err := exec.CommandContext(ctx, "flatpak", "info", appID).Output()
+	if err != nil {
+		return agent.Version{}
+	}
+
+	for _, line := range strings.Split(string(output), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "Version:") {
+			version, _ := agent.ParseVersion(strings.TrimSpace(strings.TrimPrefix(line, "Version:")))
+			return version
+		}
+	}
+
+	return agent.Version{}
+}
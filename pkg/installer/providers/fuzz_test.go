@@ -0,0 +1,26 @@
+package providers
+
+import (
+	"strings"
+	"testing"
+)
+
+func FuzzParseNPMListOutput(f *testing.F) {
+	seeds := []struct{ output, packageName string }{
+		{"/usr/lib\n└── @anthropic-ai/claude-code@1.0.24", "@anthropic-ai/claude-code"},
+		{"├── package@2.0.0\n├── other@1.0.0", "package"},
+		{"package@", "package"},
+		{"\x1b[33mpackage@1.2.3\x1b[0m", "package"},
+		{strings.Repeat("package@1.0.0\n", 10_000), "package"},
+		{"", ""},
+	}
+	for _, seed := range seeds {
+		f.Add(seed.output, seed.packageName)
+	}
+
+	f.Fuzz(func(t *testing.T, output, packageName string) {
+		version := parseNPMListOutput(output, packageName)
+		// Whatever comes back must be renderable without panicking.
+		_ = version.String()
+	})
+}
@@ -0,0 +1,636 @@
+package providers
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// githubAPIBase is the GitHub REST API endpoint releases are fetched from.
+const githubAPIBase = "https://api.github.com"
+
+// GitHubProvider installs agents published as GitHub release binaries:
+// it picks the release asset matching the host OS and architecture,
+// verifies its checksum, extracts the archive, and places the binary in
+// a managed bin directory. Updates compare release tags, so no package
+// registry is needed. The catalog's package field names the repository
+// ("owner/repo").
+type GitHubProvider struct {
+	platform platform.Platform
+
+	// apiBase overrides the GitHub API endpoint (for testing).
+	apiBase string
+	// binDirOverride overrides the managed bin directory (for testing).
+	binDirOverride string
+}
+
+// NewGitHubProvider creates a new GitHub Releases provider.
+func NewGitHubProvider(p platform.Platform) *GitHubProvider {
+	return &GitHubProvider{platform: p}
+}
+
+// Name returns the provider name.
+func (p *GitHubProvider) Name() string {
+	return "github"
+}
+
+// Method returns the install method this provider handles.
+func (p *GitHubProvider) Method() agent.InstallMethod {
+	return agent.MethodGitHub
+}
+
+// IsAvailable returns true; fetching releases only needs network access.
+func (p *GitHubProvider) IsAvailable() bool {
+	return true
+}
+
+// githubRelease is the subset of the GitHub release API response we use.
+type githubRelease struct {
+	TagName string        `json:"tag_name"`
+	Assets  []githubAsset `json:"assets"`
+}
+
+// githubAsset is a single downloadable file attached to a release.
+type githubAsset struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// Install downloads the matching release asset, verifies it, and places
+// the binary in the managed bin directory.
+func (p *GitHubProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error) {
+	start := time.Now()
+
+	repo, err := githubRepo(method)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := p.latestRelease(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	version, _ := agent.ParseVersion(strings.TrimPrefix(release.TagName, "v"))
+
+	execPath, err := p.installRelease(ctx, agentDef, method, release)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		AgentID:        agentDef.ID,
+		AgentName:      agentDef.Name,
+		Method:         agent.MethodGitHub,
+		Version:        version,
+		InstallPath:    filepath.Dir(execPath),
+		ExecutablePath: execPath,
+		Duration:       time.Since(start),
+		Output:         fmt.Sprintf("installed %s %s from %s", agentDef.ID, release.TagName, repo),
+	}, nil
+}
+
+// Update installs the latest release when its tag is newer than the
+// installed version; otherwise it reports the agent as up to date without
+// downloading anything.
+func (p *GitHubProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
+	start := time.Now()
+
+	repo, err := githubRepo(method)
+	if err != nil {
+		return nil, err
+	}
+
+	release, err := p.latestRelease(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, _ := agent.ParseVersion(strings.TrimPrefix(release.TagName, "v"))
+	fromVersion := inst.InstalledVersion
+
+	if !latest.IsNewerThan(fromVersion) {
+		return &Result{
+			AgentID:     agentDef.ID,
+			AgentName:   agentDef.Name,
+			Method:      agent.MethodGitHub,
+			Version:     fromVersion,
+			FromVersion: fromVersion,
+			Duration:    time.Since(start),
+			Output:      fmt.Sprintf("%s is already at the latest release %s", agentDef.ID, release.TagName),
+		}, nil
+	}
+
+	execPath, err := p.installRelease(ctx, agentDef, method, release)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		AgentID:        agentDef.ID,
+		AgentName:      agentDef.Name,
+		Method:         agent.MethodGitHub,
+		Version:        latest,
+		FromVersion:    fromVersion,
+		InstallPath:    filepath.Dir(execPath),
+		ExecutablePath: execPath,
+		Duration:       time.Since(start),
+		Output:         fmt.Sprintf("updated %s to %s from %s", agentDef.ID, release.TagName, repo),
+		WasUpdated:     true,
+	}, nil
+}
+
+// Uninstall removes the managed binary.
+func (p *GitHubProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	target := inst.ExecutablePath
+	if target == "" || filepath.Dir(target) != p.binDir() {
+		name, err := githubBinaryName(catalog.AgentDef{ID: inst.AgentID}, method)
+		if err != nil {
+			return err
+		}
+		target = filepath.Join(p.binDir(), p.withExeSuffix(name))
+	}
+
+	if err := os.Remove(target); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("binary not found at %s", target)
+		}
+		return fmt.Errorf("failed to remove %s: %w", target, err)
+	}
+	return nil
+}
+
+// GetLatestVersion returns the version of the latest release tag.
+func (p *GitHubProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	repo, err := githubRepo(method)
+	if err != nil {
+		return agent.Version{}, err
+	}
+
+	release, err := p.latestRelease(ctx, repo)
+	if err != nil {
+		return agent.Version{}, err
+	}
+
+	return agent.ParseVersion(strings.TrimPrefix(release.TagName, "v"))
+}
+
+// installRelease downloads the asset for this OS/arch, verifies its
+// checksum, extracts it, and places the binary in the managed bin dir.
+// It returns the installed binary's path.
+func (p *GitHubProvider) installRelease(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, release *githubRelease) (string, error) {
+	asset, err := p.selectAsset(method, release)
+	if err != nil {
+		return "", err
+	}
+
+	assetPath, err := p.downloadAsset(ctx, asset.BrowserDownloadURL, p.expectedChecksum(ctx, method, release, asset))
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(assetPath)
+
+	binaryName, err := githubBinaryName(agentDef, method)
+	if err != nil {
+		return "", err
+	}
+	binaryName = p.withExeSuffix(binaryName)
+
+	binDir := p.binDir()
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create bin directory: %w", err)
+	}
+
+	execPath := filepath.Join(binDir, binaryName)
+	if err := extractBinary(assetPath, asset.Name, binaryName, execPath); err != nil {
+		return "", err
+	}
+	return execPath, nil
+}
+
+// latestRelease fetches the latest release for a repository.
+func (p *GitHubProvider) latestRelease(ctx context.Context, repo string) (*githubRelease, error) {
+	base := p.apiBase
+	if base == "" {
+		base = githubAPIBase
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/releases/latest", base, repo)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid release URL: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch release: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("no releases found for %s", repo)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch release for %s: HTTP %d", repo, resp.StatusCode)
+	}
+
+	var release githubRelease
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return nil, fmt.Errorf("failed to decode release: %w", err)
+	}
+	if release.TagName == "" {
+		return nil, fmt.Errorf("release for %s has no tag", repo)
+	}
+	return &release, nil
+}
+
+// selectAsset picks the release asset for this OS and architecture. A
+// pattern declared in the catalog (metadata "asset", with OS- and
+// arch-specific variants) wins; without one, asset names are matched
+// against known OS and architecture spellings.
+func (p *GitHubProvider) selectAsset(method catalog.InstallMethodDef, release *githubRelease) (githubAsset, error) {
+	osID := string(p.platform.ID())
+	arch := agent.HostArchitecture()
+
+	if pattern := githubAssetPattern(method, osID, arch); pattern != "" {
+		pattern = expandAssetPattern(pattern, osID, arch, strings.TrimPrefix(release.TagName, "v"))
+		for _, asset := range release.Assets {
+			if ok, _ := filepath.Match(pattern, asset.Name); ok || asset.Name == pattern {
+				return asset, nil
+			}
+		}
+		return githubAsset{}, fmt.Errorf("no release asset matches %q in %s", pattern, release.TagName)
+	}
+
+	for _, asset := range release.Assets {
+		name := strings.ToLower(asset.Name)
+		if isChecksumAsset(name) {
+			continue
+		}
+		if matchesOS(name, osID) && matchesArch(name, arch) {
+			return asset, nil
+		}
+	}
+	return githubAsset{}, fmt.Errorf("no release asset found for %s/%s in %s", osID, arch, release.TagName)
+}
+
+// githubRepo returns the "owner/repo" slug from the method's package field.
+func githubRepo(method catalog.InstallMethodDef) (string, error) {
+	repo := strings.TrimSuffix(strings.TrimPrefix(method.Package, "https://github.com/"), "/")
+	if repo == "" || !strings.Contains(repo, "/") {
+		return "", fmt.Errorf("github method requires a package in owner/repo form, got %q", method.Package)
+	}
+	return repo, nil
+}
+
+// githubAssetPattern looks up the asset pattern for an OS and architecture,
+// preferring the most specific metadata key.
+func githubAssetPattern(method catalog.InstallMethodDef, osID, arch string) string {
+	if method.Metadata == nil {
+		return ""
+	}
+	for _, key := range []string{"asset_" + osID + "_" + arch, "asset_" + osID, "asset"} {
+		if v := method.Metadata[key]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// expandAssetPattern substitutes the {os}, {arch}, and {version}
+// placeholders in an asset pattern.
+func expandAssetPattern(pattern, osID, arch, version string) string {
+	r := strings.NewReplacer("{os}", osID, "{arch}", arch, "{version}", version)
+	return r.Replace(pattern)
+}
+
+// isChecksumAsset reports whether an asset name looks like a checksum,
+// signature, or SBOM file rather than a binary distribution.
+func isChecksumAsset(name string) bool {
+	for _, suffix := range []string{".txt", ".sha256", ".sha256sum", ".asc", ".sig", ".pem", ".sbom", ".json"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return strings.Contains(name, "checksum")
+}
+
+// matchesOS reports whether an asset name mentions the given OS,
+// accepting the common alternate spellings release pipelines use.
+func matchesOS(name, osID string) bool {
+	spellings := map[string][]string{
+		"darwin":  {"darwin", "macos", "mac os", "osx", "apple"},
+		"linux":   {"linux"},
+		"windows": {"windows", "win64", "win32"},
+	}
+	for _, s := range spellings[osID] {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesArch reports whether an asset name mentions the given
+// architecture, accepting the common alternate spellings.
+func matchesArch(name, arch string) bool {
+	spellings := map[string][]string{
+		agent.ArchAMD64: {"amd64", "x86_64", "x86-64", "x64"},
+		agent.ArchARM64: {"arm64", "aarch64"},
+	}
+	alts := spellings[arch]
+	if alts == nil {
+		alts = []string{arch}
+	}
+	for _, s := range alts {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	// Universal binaries serve any architecture
+	return strings.Contains(name, "universal")
+}
+
+// githubBinaryName returns the name the installed binary should have:
+// the catalog's "binary" metadata, the agent's first detection
+// executable, or the repository name.
+func githubBinaryName(agentDef catalog.AgentDef, method catalog.InstallMethodDef) (string, error) {
+	if name := method.Metadata["binary"]; name != "" {
+		return name, nil
+	}
+	if len(agentDef.Detection.Executables) > 0 {
+		return agentDef.Detection.Executables[0], nil
+	}
+	repo, err := githubRepo(method)
+	if err != nil {
+		return "", err
+	}
+	return repo[strings.LastIndex(repo, "/")+1:], nil
+}
+
+// expectedChecksum resolves the expected SHA-256 for an asset: a literal
+// checksum from the catalog wins; otherwise a checksums file attached to
+// the release (metadata "checksum_asset") is fetched and searched for the
+// asset's entry. An empty return skips verification.
+func (p *GitHubProvider) expectedChecksum(ctx context.Context, method catalog.InstallMethodDef, release *githubRelease, asset githubAsset) string {
+	if sum := archMetadata(method, "checksum"); sum != "" {
+		return sum
+	}
+
+	checksumName := method.Metadata["checksum_asset"]
+	if checksumName == "" {
+		return ""
+	}
+
+	for _, a := range release.Assets {
+		if ok, _ := filepath.Match(checksumName, a.Name); !ok && a.Name != checksumName {
+			continue
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.BrowserDownloadURL, nil)
+		if err != nil {
+			return ""
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil || resp.StatusCode != http.StatusOK {
+			if resp != nil {
+				resp.Body.Close()
+			}
+			return ""
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		if err != nil {
+			return ""
+		}
+		return checksumForAsset(string(data), asset.Name)
+	}
+	return ""
+}
+
+// checksumForAsset finds the hex digest for a file in sha256sum-style
+// checksums output ("<digest>  <filename>" per line).
+func checksumForAsset(checksums, assetName string) string {
+	for _, line := range strings.Split(checksums, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		// sha256sum marks binary-mode files with a leading '*'
+		if strings.TrimPrefix(fields[len(fields)-1], "*") == assetName {
+			return fields[0]
+		}
+	}
+	return ""
+}
+
+// downloadAsset fetches a release asset to a temp file and verifies its
+// SHA-256 checksum when one is known.
+func (p *GitHubProvider) downloadAsset(ctx context.Context, url, checksum string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid asset URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("asset download failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("asset download failed: HTTP %d", resp.StatusCode)
+	}
+
+	tmp, err := os.CreateTemp("", "agentmgr-github-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), resp.Body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return "", fmt.Errorf("asset download failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err := verifyChecksum(hash.Sum(nil), checksum); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// withExeSuffix appends .exe to a binary name on Windows.
+func (p *GitHubProvider) withExeSuffix(name string) string {
+	if p.platform.ID() == platform.Windows && !strings.HasSuffix(name, ".exe") {
+		return name + ".exe"
+	}
+	return name
+}
+
+// binDir returns the managed directory binaries are installed into.
+func (p *GitHubProvider) binDir() string {
+	if p.binDirOverride != "" {
+		return p.binDirOverride
+	}
+	return filepath.Join(p.platform.GetDataDir(), "bin")
+}
+
+// extractBinary extracts binaryName from a downloaded asset into destPath.
+// tar.gz and zip archives are searched for the binary; anything else is
+// treated as the binary itself.
+func extractBinary(assetPath, assetName, binaryName, destPath string) error {
+	name := strings.ToLower(assetName)
+	switch {
+	case strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+		return extractFromTarGz(assetPath, binaryName, destPath)
+	case strings.HasSuffix(name, ".zip"):
+		return extractFromZip(assetPath, binaryName, destPath)
+	case strings.HasSuffix(name, ".gz"):
+		return extractFromGz(assetPath, destPath)
+	default:
+		return copyBinary(assetPath, destPath)
+	}
+}
+
+// extractFromTarGz finds binaryName inside a gzipped tarball and writes it
+// to destPath.
+func extractFromTarGz(assetPath, binaryName, destPath string) error {
+	f, err := os.Open(assetPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg || !binaryNameMatches(hdr.Name, binaryName) {
+			continue
+		}
+		return writeBinary(destPath, tr)
+	}
+	return fmt.Errorf("binary %q not found in %s", binaryName, filepath.Base(assetPath))
+}
+
+// extractFromZip finds binaryName inside a zip archive and writes it to
+// destPath.
+func extractFromZip(assetPath, binaryName, destPath string) error {
+	zr, err := zip.OpenReader(assetPath)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer zr.Close()
+
+	for _, file := range zr.File {
+		if file.FileInfo().IsDir() || !binaryNameMatches(file.Name, binaryName) {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		err = writeBinary(destPath, rc)
+		rc.Close()
+		return err
+	}
+	return fmt.Errorf("binary %q not found in %s", binaryName, filepath.Base(assetPath))
+}
+
+// extractFromGz decompresses a single gzipped binary to destPath.
+func extractFromGz(assetPath, destPath string) error {
+	f, err := os.Open(assetPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read archive: %w", err)
+	}
+	defer gz.Close()
+
+	return writeBinary(destPath, gz)
+}
+
+// copyBinary copies a raw binary asset to destPath.
+func copyBinary(assetPath, destPath string) error {
+	f, err := os.Open(assetPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return writeBinary(destPath, f)
+}
+
+// binaryNameMatches reports whether an archive entry is the wanted binary,
+// ignoring leading directories and a Windows .exe suffix.
+func binaryNameMatches(entryName, binaryName string) bool {
+	base := filepath.Base(filepath.FromSlash(entryName))
+	return base == binaryName || base == binaryName+".exe"
+}
+
+// writeBinary writes binary contents to destPath with executable
+// permissions, replacing any previous binary atomically via a rename.
+func writeBinary(destPath string, r io.Reader) error {
+	tmp, err := os.CreateTemp(filepath.Dir(destPath), "."+filepath.Base(destPath)+"-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write binary: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), destPath); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to install binary: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package providers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"runtime"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestGitHubRepo(t *testing.T) {
+	tests := []struct {
+		pkg     string
+		want    string
+		wantErr bool
+	}{
+		{pkg: "owner/tool", want: "owner/tool"},
+		{pkg: "https://github.com/owner/tool", want: "owner/tool"},
+		{pkg: "tool", wantErr: true},
+		{pkg: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := githubRepo(catalog.InstallMethodDef{Package: tt.pkg})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("githubRepo(%q) expected error", tt.pkg)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("githubRepo(%q) error = %v", tt.pkg, err)
+		} else if got != tt.want {
+			t.Errorf("githubRepo(%q) = %q, want %q", tt.pkg, got, tt.want)
+		}
+	}
+}
+
+func TestGitHubSelectAssetByPattern(t *testing.T) {
+	p := NewGitHubProvider(platform.Current())
+	osID := string(platform.Current().ID())
+	arch := agent.HostArchitecture()
+
+	release := &githubRelease{
+		TagName: "v1.2.0",
+		Assets: []githubAsset{
+			{Name: "tool_1.2.0_checksums.txt"},
+			{Name: fmt.Sprintf("tool_1.2.0_%s_%s.tar.gz", osID, arch)},
+			{Name: "tool_1.2.0_other_arch.tar.gz"},
+		},
+	}
+
+	method := catalog.InstallMethodDef{Metadata: map[string]string{
+		"asset": "tool_{version}_{os}_{arch}.tar.gz",
+	}}
+	asset, err := p.selectAsset(method, release)
+	if err != nil {
+		t.Fatalf("selectAsset() error = %v", err)
+	}
+	want := fmt.Sprintf("tool_1.2.0_%s_%s.tar.gz", osID, arch)
+	if asset.Name != want {
+		t.Errorf("selectAsset() = %q, want %q", asset.Name, want)
+	}
+
+	// A pattern that matches nothing is an error
+	method.Metadata["asset"] = "missing-{version}.zip"
+	if _, err := p.selectAsset(method, release); err == nil {
+		t.Error("expected error for unmatched pattern")
+	}
+}
+
+func TestGitHubSelectAssetHeuristic(t *testing.T) {
+	p := NewGitHubProvider(platform.Current())
+	osID := string(platform.Current().ID())
+	arch := agent.HostArchitecture()
+
+	// Use the alternate spellings a release pipeline might pick
+	altOS := map[string]string{"darwin": "macos", "linux": "linux", "windows": "windows"}[osID]
+	altArch := map[string]string{"amd64": "x86_64", "arm64": "aarch64"}[arch]
+	if altArch == "" {
+		altArch = arch
+	}
+
+	release := &githubRelease{
+		TagName: "v2.0.0",
+		Assets: []githubAsset{
+			{Name: "tool_2.0.0_checksums.txt"},
+			{Name: fmt.Sprintf("tool-%s-%s.tar.gz", altOS, altArch)},
+		},
+	}
+
+	asset, err := p.selectAsset(catalog.InstallMethodDef{}, release)
+	if err != nil {
+		t.Fatalf("selectAsset() error = %v", err)
+	}
+	want := fmt.Sprintf("tool-%s-%s.tar.gz", altOS, altArch)
+	if asset.Name != want {
+		t.Errorf("selectAsset() = %q, want %q", asset.Name, want)
+	}
+}
+
+func TestChecksumForAsset(t *testing.T) {
+	checksums := "abc123  tool-linux-amd64.tar.gz\ndef456 *tool-darwin-arm64.tar.gz\n"
+
+	if got := checksumForAsset(checksums, "tool-linux-amd64.tar.gz"); got != "abc123" {
+		t.Errorf("checksumForAsset() = %q, want %q", got, "abc123")
+	}
+	if got := checksumForAsset(checksums, "tool-darwin-arm64.tar.gz"); got != "def456" {
+		t.Errorf("checksumForAsset() = %q, want %q", got, "def456")
+	}
+	if got := checksumForAsset(checksums, "missing.tar.gz"); got != "" {
+		t.Errorf("checksumForAsset() = %q, want empty", got)
+	}
+}
+
+// githubTestArchive builds a tar.gz archive containing a single binary.
+func githubTestArchive(t *testing.T, binaryName, contents string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{
+		Name: "dist/" + binaryName,
+		Mode: 0755,
+		Size: int64(len(contents)),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(contents)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGitHubInstallFromRelease(t *testing.T) {
+	binaryName := "mytool"
+	if runtime.GOOS == "windows" {
+		binaryName = "mytool.exe"
+	}
+	archive := githubTestArchive(t, binaryName, "#!/bin/sh\necho mytool\n")
+	sum := sha256.Sum256(archive)
+	assetName := "mytool_1.4.0.tar.gz"
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/mytool/releases/latest":
+			fmt.Fprintf(w, `{
+				"tag_name": "v1.4.0",
+				"assets": [
+					{"name": "checksums.txt", "browser_download_url": %q},
+					{"name": %q, "browser_download_url": %q}
+				]
+			}`, ts.URL+"/download/checksums.txt", assetName, ts.URL+"/download/"+assetName)
+		case "/download/checksums.txt":
+			fmt.Fprintf(w, "%s  %s\n", hex.EncodeToString(sum[:]), assetName)
+		case "/download/" + assetName:
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	p := NewGitHubProvider(platform.Current())
+	p.apiBase = ts.URL
+	p.binDirOverride = t.TempDir()
+
+	agentDef := catalog.AgentDef{ID: "mytool", Name: "My Tool"}
+	method := catalog.InstallMethodDef{
+		Method:  "github",
+		Package: "owner/mytool",
+		Metadata: map[string]string{
+			"asset":          "mytool_{version}.tar.gz",
+			"binary":         "mytool",
+			"checksum_asset": "checksums.txt",
+		},
+	}
+
+	result, err := p.Install(context.Background(), agentDef, method, false)
+	if err != nil {
+		t.Fatalf("Install() error = %v", err)
+	}
+
+	if result.Version.String() != "1.4.0" {
+		t.Errorf("Version = %q, want %q", result.Version.String(), "1.4.0")
+	}
+	if result.Method != agent.MethodGitHub {
+		t.Errorf("Method = %q, want %q", result.Method, agent.MethodGitHub)
+	}
+
+	data, err := os.ReadFile(result.ExecutablePath)
+	if err != nil {
+		t.Fatalf("reading installed binary: %v", err)
+	}
+	if !bytes.Contains(data, []byte("echo mytool")) {
+		t.Error("installed binary has unexpected contents")
+	}
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(result.ExecutablePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm()&0111 == 0 {
+			t.Error("installed binary is not executable")
+		}
+	}
+
+	// Uninstall removes the managed binary
+	inst := &agent.Installation{AgentID: "mytool", ExecutablePath: result.ExecutablePath}
+	if err := p.Uninstall(context.Background(), inst, method); err != nil {
+		t.Fatalf("Uninstall() error = %v", err)
+	}
+	if _, err := os.Stat(result.ExecutablePath); !os.IsNotExist(err) {
+		t.Error("binary should be removed after uninstall")
+	}
+}
+
+func TestGitHubInstallChecksumMismatch(t *testing.T) {
+	archive := githubTestArchive(t, "mytool", "contents")
+	assetName := "mytool_1.0.0.tar.gz"
+
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/mytool/releases/latest":
+			fmt.Fprintf(w, `{"tag_name": "v1.0.0", "assets": [{"name": %q, "browser_download_url": %q}]}`,
+				assetName, ts.URL+"/download/"+assetName)
+		case "/download/" + assetName:
+			w.Write(archive)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	p := NewGitHubProvider(platform.Current())
+	p.apiBase = ts.URL
+	p.binDirOverride = t.TempDir()
+
+	method := catalog.InstallMethodDef{
+		Method:  "github",
+		Package: "owner/mytool",
+		Metadata: map[string]string{
+			"asset":    assetName,
+			"binary":   "mytool",
+			"checksum": "deadbeef",
+		},
+	}
+
+	if _, err := p.Install(context.Background(), catalog.AgentDef{ID: "mytool"}, method, false); err == nil {
+		t.Fatal("expected checksum mismatch error")
+	}
+}
+
+func TestGitHubUpdateAlreadyLatest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/mytool/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"tag_name": "v1.4.0", "assets": []}`)
+	}))
+	defer ts.Close()
+
+	p := NewGitHubProvider(platform.Current())
+	p.apiBase = ts.URL
+	p.binDirOverride = t.TempDir()
+
+	installed, _ := agent.ParseVersion("1.4.0")
+	inst := &agent.Installation{AgentID: "mytool", InstalledVersion: installed}
+	method := catalog.InstallMethodDef{Method: "github", Package: "owner/mytool"}
+
+	result, err := p.Update(context.Background(), inst, catalog.AgentDef{ID: "mytool", Name: "My Tool"}, method)
+	if err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if result.WasUpdated {
+		t.Error("expected no update when already at the latest release")
+	}
+	if result.Version.String() != "1.4.0" {
+		t.Errorf("Version = %q, want %q", result.Version.String(), "1.4.0")
+	}
+}
+
+func TestGitHubGetLatestVersion(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"tag_name": "v3.2.1", "assets": []}`)
+	}))
+	defer ts.Close()
+
+	p := NewGitHubProvider(platform.Current())
+	p.apiBase = ts.URL
+
+	version, err := p.GetLatestVersion(context.Background(), catalog.InstallMethodDef{Package: "owner/mytool"})
+	if err != nil {
+		t.Fatalf("GetLatestVersion() error = %v", err)
+	}
+	if version.String() != "3.2.1" {
+		t.Errorf("GetLatestVersion() = %q, want %q", version.String(), "3.2.1")
+	}
+}
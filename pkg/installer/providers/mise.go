@@ -0,0 +1,190 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// MiseProvider handles installations managed by the mise version manager
+// via `mise use -g tool@version`.
+type MiseProvider struct {
+	platform platform.Platform
+}
+
+// NewMiseProvider creates a new mise provider.
+func NewMiseProvider(p platform.Platform) *MiseProvider {
+	return &MiseProvider{platform: p}
+}
+
+// Name returns the provider name.
+func (p *MiseProvider) Name() string {
+	return "mise"
+}
+
+// Method returns the install method this provider handles.
+func (p *MiseProvider) Method() agent.InstallMethod {
+	return agent.MethodMise
+}
+
+// IsAvailable returns true if the mise CLI is available.
+func (p *MiseProvider) IsAvailable() bool {
+	return p.platform.IsExecutableInPath("mise")
+}
+
+// Install installs the latest version of a tool via `mise use -g`.
+func (p *MiseProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error) {
+	start := time.Now()
+
+	tool := method.Package
+	if tool == "" {
+		return nil, fmt.Errorf("could not determine mise tool name")
+	}
+
+	output, err := p.use(ctx, tool, "latest")
+	if err != nil {
+		return nil, fmt.Errorf("mise install failed: %w", err)
+	}
+
+	version := p.currentVersion(ctx, tool)
+
+	return &Result{
+		AgentID:   agentDef.ID,
+		AgentName: agentDef.Name,
+		Method:    agent.MethodMise,
+		Version:   version,
+		Duration:  time.Since(start),
+		Output:    output,
+	}, nil
+}
+
+// Update moves a tool to the latest version via `mise use -g`.
+func (p *MiseProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
+	start := time.Now()
+
+	tool := method.Package
+	if tool == "" {
+		return nil, fmt.Errorf("could not determine mise tool name")
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	output, err := p.use(ctx, tool, "latest")
+	if err != nil {
+		return nil, fmt.Errorf("mise update failed: %w", err)
+	}
+
+	toVersion := p.currentVersion(ctx, tool)
+
+	return &Result{
+		AgentID:     agentDef.ID,
+		AgentName:   agentDef.Name,
+		Method:      agent.MethodMise,
+		FromVersion: fromVersion,
+		Version:     toVersion,
+		Duration:    time.Since(start),
+		Output:      output,
+		WasUpdated:  toVersion.IsNewerThan(fromVersion),
+	}, nil
+}
+
+// Downgrade pins a tool to a specific older version via `mise use -g`.
+func (p *MiseProvider) Downgrade(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef, target agent.Version) (*Result, error) {
+	start := time.Now()
+
+	tool := method.Package
+	if tool == "" {
+		return nil, fmt.Errorf("could not determine mise tool name")
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	output, err := p.use(ctx, tool, target.String())
+	if err != nil {
+		return nil, fmt.Errorf("mise downgrade failed: %w", err)
+	}
+
+	return &Result{
+		AgentID:     agentDef.ID,
+		AgentName:   agentDef.Name,
+		Method:      agent.MethodMise,
+		FromVersion: fromVersion,
+		Version:     target,
+		Duration:    time.Since(start),
+		Output:      output,
+	}, nil
+}
+
+// Uninstall removes a tool from the global mise config and deletes its
+// installed version.
+func (p *MiseProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	tool := method.Package
+	if tool == "" {
+		return fmt.Errorf("could not determine mise tool name")
+	}
+
+	// Remove the global pin first; failure here is not fatal since the
+	// tool may only be pinned in a local config.
+	_ = exec.CommandContext(ctx, "mise", "unuse", "-g", tool).Run()
+
+	spec := tool
+	if !inst.InstalledVersion.IsZero() {
+		spec = tool + "@" + inst.InstalledVersion.String()
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "mise", "uninstall", spec)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("mise uninstall failed: %w\n%s", err, stderr.String())
+	}
+
+	return nil
+}
+
+// GetLatestVersion queries the latest available version via `mise latest`.
+func (p *MiseProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	tool := method.Package
+	if tool == "" {
+		return agent.Version{}, fmt.Errorf("could not determine mise tool name")
+	}
+
+	output, err := exec.CommandContext(ctx, "mise", "latest", tool).Output()
+	if err != nil {
+		return agent.Version{}, fmt.Errorf("failed to get latest version: %w", err)
+	}
+
+	return agent.ParseVersion(strings.TrimSpace(string(output)))
+}
+
+// use runs `mise use -g tool@version`, installing the version if needed.
+func (p *MiseProvider) use(ctx context.Context, tool, version string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "mise", "use", "-g", tool+"@"+version)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w\n%s", err, stderr.String())
+	}
+	return stdout.String(), nil
+}
+
+// currentVersion returns the globally selected version of a tool.
+func (p *MiseProvider) currentVersion(ctx context.Context, tool string) agent.Version {
+	output, err := exec.CommandContext(ctx, "mise", "current", tool).Output()
+	if err != nil {
+		return agent.Version{}
+	}
+
+	version, _ := agent.ParseVersion(strings.TrimSpace(string(output)))
+	return version
+}
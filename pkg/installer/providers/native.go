@@ -1,27 +1,30 @@
 package providers
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // NativeProvider handles native binary installations (shell scripts, direct downloads).
 type NativeProvider struct {
 	platform platform.Platform
+	runner   execx.Runner
+
+	// cacheDirOverride overrides the install-script cache dir (for testing).
+	cacheDirOverride string
 }
 
 // NewNativeProvider creates a new native provider.
 func NewNativeProvider(p platform.Platform) *NativeProvider {
-	return &NativeProvider{platform: p}
+	return &NativeProvider{platform: p, runner: execx.Default}
 }
 
 // Name returns the provider name.
@@ -48,11 +51,18 @@ func (p *NativeProvider) Install(ctx context.Context, agentDef catalog.AgentDef,
 		return nil, fmt.Errorf("no install command specified")
 	}
 
+	// Piped curl|sh commands run from an audited cached copy instead
+	command, auditNote, err := p.prepareScriptCommand(ctx, agentDef, method, command)
+	if err != nil {
+		return nil, fmt.Errorf("native install failed: %w", err)
+	}
+
 	// Execute the install command
 	output, err := p.executeCommand(ctx, command)
 	if err != nil {
 		return nil, fmt.Errorf("native install failed: %w", err)
 	}
+	output = auditNote + output
 
 	// Get installed version
 	version := p.getInstalledVersion(ctx, agentDef)
@@ -86,11 +96,18 @@ func (p *NativeProvider) Update(ctx context.Context, inst *agent.Installation, a
 
 	fromVersion := inst.InstalledVersion
 
+	// Piped curl|sh commands run from an audited cached copy instead
+	command, auditNote, err := p.prepareScriptCommand(ctx, agentDef, method, command)
+	if err != nil {
+		return nil, fmt.Errorf("native update failed: %w", err)
+	}
+
 	// Execute the update command
 	output, err := p.executeCommand(ctx, command)
 	if err != nil {
 		return nil, fmt.Errorf("native update failed: %w", err)
 	}
+	output = auditNote + output
 
 	// Get new version
 	toVersion := p.getInstalledVersion(ctx, agentDef)
@@ -134,21 +151,19 @@ func (p *NativeProvider) Uninstall(ctx context.Context, inst *agent.Installation
 	return nil
 }
 
-// executeCommand runs a shell command.
+// executeCommand runs a shell command. Going through execx puts the
+// shell in its own process group, so cancelling the context tears down
+// everything the install script spawned, not just the shell itself.
 func (p *NativeProvider) executeCommand(ctx context.Context, command string) (string, error) {
 	shell := p.platform.GetShell()
 	shellArg := p.platform.GetShellArg()
 
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, shell, shellArg, command)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("%w\n%s", err, stderr.String())
+	res, err := p.runner.Run(ctx, shell, []string{shellArg, command})
+	if err != nil {
+		return "", err
 	}
 
-	return stdout.String(), nil
+	return res.Stdout, nil
 }
 
 // getInstalledVersion gets the installed version of an agent.
@@ -160,13 +175,12 @@ func (p *NativeProvider) getInstalledVersion(ctx context.Context, agentDef catal
 	shell := p.platform.GetShell()
 	shellArg := p.platform.GetShellArg()
 
-	cmd := exec.CommandContext(ctx, shell, shellArg, agentDef.Detection.VersionCmd)
-	output, err := cmd.CombinedOutput()
+	res, err := p.runner.Run(ctx, shell, []string{shellArg, agentDef.Detection.VersionCmd})
 	if err != nil {
 		return agent.Version{}
 	}
 
-	versionStr := strings.TrimSpace(string(output))
+	versionStr := strings.TrimSpace(res.Stdout + res.Stderr)
 
 	// Try common patterns
 	versionStr = extractVersionString(versionStr)
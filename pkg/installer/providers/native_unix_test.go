@@ -0,0 +1,85 @@
+//go:build !windows
+
+package providers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestNativeCommandCancellationKillsSpawnedTree(t *testing.T) {
+	pidFile := filepath.Join(t.TempDir(), "pid")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := NewNativeProvider(platform.Current())
+
+	// An install script that backgrounds a long-running child, the way
+	// curl|sh installers spawn downloaders and build steps. Killing only
+	// the shell would leave the child running.
+	done := make(chan error, 1)
+	go func() {
+		_, err := p.executeCommand(ctx, "sleep 60 & echo $! > "+pidFile+"; wait")
+		done <- err
+	}()
+
+	var pid int
+	deadline := time.Now().Add(5 * time.Second)
+	for pid == 0 {
+		if data, err := os.ReadFile(pidFile); err == nil {
+			if parsed, err := strconv.Atoi(strings.TrimSpace(string(data))); err == nil && parsed > 0 {
+				pid = parsed
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("spawned child pid never appeared")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("expected an error from the cancelled command")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancelled command did not return")
+	}
+
+	deadline = time.Now().Add(5 * time.Second)
+	for spawnedChildAlive(pid) {
+		if time.Now().After(deadline) {
+			t.Fatalf("spawned child %d survived cancellation", pid)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// spawnedChildAlive reports whether pid is still running; zombies
+// awaiting a reaper count as dead.
+func spawnedChildAlive(pid int) bool {
+	if err := syscall.Kill(pid, 0); err != nil {
+		return false
+	}
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		// No procfs (e.g. macOS); the signal reached something, assume alive
+		return true
+	}
+	// The state letter follows the parenthesised command name
+	if idx := strings.LastIndexByte(string(data), ')'); idx >= 0 && idx+2 < len(data) {
+		return data[idx+2] != 'Z'
+	}
+	return true
+}
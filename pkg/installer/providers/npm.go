@@ -2,26 +2,45 @@
 package providers
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os/exec"
+	"os"
 	"strings"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // NPMProvider handles npm-based installations.
 type NPMProvider struct {
-	platform platform.Platform
+	platform   platform.Platform
+	installers config.InstallersConfig
+	runner     execx.Runner
 }
 
 // NewNPMProvider creates a new NPM provider.
 func NewNPMProvider(p platform.Platform) *NPMProvider {
-	return &NPMProvider{platform: p}
+	return &NPMProvider{platform: p, runner: execx.Default}
+}
+
+// SetInstallers applies tool overrides from the installers configuration.
+func (p *NPMProvider) SetInstallers(c config.InstallersConfig) {
+	p.installers = c
+}
+
+// npmCommandFor returns the npm binary for an installation, preferring
+// the Node version manager binary it was detected under.
+func (p *NPMProvider) npmCommandFor(inst *agent.Installation) string {
+	if inst != nil && inst.Metadata != nil {
+		if path := inst.Metadata["npm_path"]; path != "" {
+			return path
+		}
+	}
+	return p.installers.NPMCommand()
 }
 
 // Name returns the provider name.
@@ -36,6 +55,10 @@ func (p *NPMProvider) Method() agent.InstallMethod {
 
 // IsAvailable returns true if npm is available.
 func (p *NPMProvider) IsAvailable() bool {
+	if npmCmd := p.installers.NPMCommand(); npmCmd != "npm" {
+		_, err := os.Stat(npmCmd)
+		return err == nil
+	}
 	return p.platform.IsExecutableInPath("npm")
 }
 
@@ -58,13 +81,11 @@ func (p *NPMProvider) Install(ctx context.Context, agentDef catalog.AgentDef, me
 	}
 	args = append(args, packageName)
 
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "npm", args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("npm install failed: %w\n%s%s", err, stderr.String(), formatNPMPermissionHint(stderr.String()))
+	res, err := p.runner.Run(ctx, p.installers.NPMCommand(), args,
+		execx.WithEnv(p.installers.NPMEnv()...),
+		execx.WithTimeout(p.installers.TimeoutFor("npm")))
+	if err != nil {
+		return nil, fmt.Errorf("npm install failed: %w%s", err, formatNPMPermissionHint(res.Stderr))
 	}
 
 	// Get installed version
@@ -80,7 +101,7 @@ func (p *NPMProvider) Install(ctx context.Context, agentDef catalog.AgentDef, me
 		Version:        version,
 		ExecutablePath: execPath,
 		Duration:       time.Since(start),
-		Output:         stdout.String(),
+		Output:         res.Stdout,
 	}, nil
 }
 
@@ -99,17 +120,17 @@ func (p *NPMProvider) Update(ctx context.Context, inst *agent.Installation, agen
 	fromVersion := inst.InstalledVersion
 
 	// Run update command
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "npm", "update", "-g", packageName)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
+	npmCmd := p.npmCommandFor(inst)
 
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("npm update failed: %w\n%s%s", err, stderr.String(), formatNPMPermissionHint(stderr.String()))
+	res, err := p.runner.Run(ctx, npmCmd, []string{"update", "-g", packageName},
+		execx.WithEnv(p.installers.NPMEnv()...),
+		execx.WithTimeout(p.installers.TimeoutFor("npm")))
+	if err != nil {
+		return nil, fmt.Errorf("npm update failed: %w%s", err, formatNPMPermissionHint(res.Stderr))
 	}
 
 	// Get new version
-	toVersion := p.getInstalledVersion(ctx, packageName)
+	toVersion := p.getInstalledVersionWith(ctx, npmCmd, packageName)
 
 	return &Result{
 		AgentID:        agentDef.ID,
@@ -118,7 +139,7 @@ func (p *NPMProvider) Update(ctx context.Context, inst *agent.Installation, agen
 		FromVersion:    fromVersion,
 		Version:        toVersion,
 		Duration:       time.Since(start),
-		Output:         stdout.String(),
+		Output:         res.Stdout,
 		WasUpdated:     toVersion.IsNewerThan(fromVersion),
 		ExecutablePath: inst.ExecutablePath,
 	}, nil
@@ -134,12 +155,11 @@ func (p *NPMProvider) Uninstall(ctx context.Context, inst *agent.Installation, m
 		return fmt.Errorf("could not determine npm package name")
 	}
 
-	var stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, "npm", "uninstall", "-g", packageName)
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("npm uninstall failed: %w\n%s%s", err, stderr.String(), formatNPMPermissionHint(stderr.String()))
+	res, err := p.runner.Run(ctx, p.npmCommandFor(inst), []string{"uninstall", "-g", packageName},
+		execx.WithEnv(p.installers.NPMEnv()...),
+		execx.WithTimeout(p.installers.TimeoutFor("npm")))
+	if err != nil {
+		return fmt.Errorf("npm uninstall failed: %w%s", err, formatNPMPermissionHint(res.Stderr))
 	}
 
 	return nil
@@ -147,28 +167,19 @@ func (p *NPMProvider) Uninstall(ctx context.Context, inst *agent.Installation, m
 
 // getInstalledVersion gets the installed version of an npm package.
 func (p *NPMProvider) getInstalledVersion(ctx context.Context, packageName string) agent.Version {
-	cmd := exec.CommandContext(ctx, "npm", "list", "-g", "--depth=0", packageName)
-	output, err := cmd.Output()
+	return p.getInstalledVersionWith(ctx, p.installers.NPMCommand(), packageName)
+}
+
+// getInstalledVersionWith gets the installed version using a specific npm binary.
+func (p *NPMProvider) getInstalledVersionWith(ctx context.Context, npmCmd, packageName string) agent.Version {
+	res, err := p.runner.Run(ctx, npmCmd, []string{"list", "-g", "--depth=0", packageName},
+		execx.WithEnv(p.installers.NPMEnv()...),
+		execx.WithTimeout(p.installers.TimeoutFor("npm")))
 	if err != nil {
 		return agent.Version{}
 	}
 
-	// Parse output to extract version
-	// Format: package@version
-	lines := strings.Split(string(output), "\n")
-	for _, line := range lines {
-		if strings.Contains(line, packageName+"@") {
-			// Extract version after @
-			parts := strings.Split(line, "@")
-			if len(parts) >= 2 {
-				versionStr := strings.TrimSpace(parts[len(parts)-1])
-				version, _ := agent.ParseVersion(versionStr)
-				return version
-			}
-		}
-	}
-
-	return agent.Version{}
+	return parseNPMListOutput(res.Stdout, packageName)
 }
 
 // findExecutable attempts to find the executable for an agent.
@@ -191,14 +202,18 @@ func (p *NPMProvider) GetLatestVersion(ctx context.Context, method catalog.Insta
 		return agent.Version{}, fmt.Errorf("could not determine npm package name")
 	}
 
-	// Use npm view to get the latest version
-	cmd := exec.CommandContext(ctx, "npm", "view", packageName, "version")
-	output, err := cmd.Output()
+	// Use npm view to get the latest version; registry lookups are
+	// occasionally flaky, so retry briefly before giving up
+	result, err := p.runner.Run(ctx, p.installers.NPMCommand(),
+		[]string{"view", packageName, "version"},
+		execx.WithEnv(p.installers.NPMEnv()...),
+		execx.WithTimeout(p.installers.TimeoutFor("npm")),
+		execx.WithRetries(2, time.Second))
 	if err != nil {
 		return agent.Version{}, fmt.Errorf("npm view failed: %w", err)
 	}
 
-	versionStr := strings.TrimSpace(string(output))
+	versionStr := strings.TrimSpace(result.Stdout)
 	version, err := agent.ParseVersion(versionStr)
 	if err != nil {
 		return agent.Version{}, fmt.Errorf("failed to parse version %q: %w", versionStr, err)
@@ -1,26 +1,45 @@
 package providers
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/execx"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
 // PipProvider handles pip/pipx/uv-based installations.
 type PipProvider struct {
-	platform platform.Platform
+	platform   platform.Platform
+	installers config.InstallersConfig
+	runner     execx.Runner
 }
 
 // NewPipProvider creates a new pip provider.
 func NewPipProvider(p platform.Platform) *PipProvider {
-	return &PipProvider{platform: p}
+	return &PipProvider{platform: p, runner: execx.Default}
+}
+
+// SetInstallers applies tool overrides from the installers configuration.
+func (p *PipProvider) SetInstallers(c config.InstallersConfig) {
+	p.installers = c
+}
+
+// managerEnv returns the environment overrides for a package manager.
+func (p *PipProvider) managerEnv(manager string) []string {
+	switch manager {
+	case "pipx":
+		return p.installers.PipxEnv()
+	case "uv":
+		return p.installers.UVEnv()
+	default:
+		return p.installers.PipEnv()
+	}
 }
 
 // Name returns the provider name.
@@ -50,13 +69,11 @@ func (p *PipProvider) Install(ctx context.Context, agentDef catalog.AgentDef, me
 		return nil, err
 	}
 
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, manager, args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("%s install failed: %w\n%s%s", manager, err, stderr.String(), FormatInstallError(manager, "install", stderr.String()))
+	res, err := p.runner.Run(ctx, manager, args,
+		execx.WithEnv(p.managerEnv(manager)...),
+		execx.WithTimeout(p.installers.TimeoutFor(manager)))
+	if err != nil {
+		return nil, fmt.Errorf("%s install failed: %w%s", manager, err, FormatInstallError(manager, "install", res.Stderr))
 	}
 
 	// Get installed version
@@ -72,7 +89,7 @@ func (p *PipProvider) Install(ctx context.Context, agentDef catalog.AgentDef, me
 		Version:        version,
 		ExecutablePath: execPath,
 		Duration:       time.Since(start),
-		Output:         stdout.String(),
+		Output:         res.Stdout,
 	}, nil
 }
 
@@ -80,20 +97,18 @@ func (p *PipProvider) Install(ctx context.Context, agentDef catalog.AgentDef, me
 func (p *PipProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
 	start := time.Now()
 
-	manager, args, packageName, err := p.buildUpdateCommand(method)
+	manager, args, packageName, err := p.buildUpdateCommand(method, inst.Method)
 	if err != nil {
 		return nil, err
 	}
 
 	fromVersion := inst.InstalledVersion
 
-	var stdout, stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, manager, args...)
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("%s update failed: %w\n%s%s", manager, err, stderr.String(), FormatInstallError(manager, "update", stderr.String()))
+	res, err := p.runner.Run(ctx, manager, args,
+		execx.WithEnv(p.managerEnv(manager)...),
+		execx.WithTimeout(p.installers.TimeoutFor(manager)))
+	if err != nil {
+		return nil, fmt.Errorf("%s update failed: %w%s", manager, err, FormatInstallError(manager, "update", res.Stderr))
 	}
 
 	// Get new version
@@ -106,7 +121,7 @@ func (p *PipProvider) Update(ctx context.Context, inst *agent.Installation, agen
 		FromVersion:    fromVersion,
 		Version:        toVersion,
 		Duration:       time.Since(start),
-		Output:         stdout.String(),
+		Output:         res.Stdout,
 		WasUpdated:     toVersion.IsNewerThan(fromVersion),
 		ExecutablePath: inst.ExecutablePath,
 	}, nil
@@ -114,25 +129,57 @@ func (p *PipProvider) Update(ctx context.Context, inst *agent.Installation, agen
 
 // Uninstall removes a pip/pipx/uv-installed agent.
 func (p *PipProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
-	manager, args, _, err := p.buildUninstallCommand(method)
+	manager, args, _, err := p.buildUninstallCommand(method, inst.Method)
 	if err != nil {
 		return err
 	}
 
-	var stderr bytes.Buffer
-	cmd := exec.CommandContext(ctx, manager, args...)
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("%s uninstall failed: %w\n%s", manager, err, stderr.String())
+	_, err = p.runner.Run(ctx, manager, args,
+		execx.WithEnv(p.managerEnv(manager)...),
+		execx.WithTimeout(p.installers.TimeoutFor(manager)))
+	if err != nil {
+		return fmt.Errorf("%s uninstall failed: %w", manager, err)
 	}
 
 	return nil
 }
 
+// resolveMethodName applies the configured pip isolation redirect. A
+// plain pip method is redirected to pipx or uv when installers.pip_isolation
+// selects one and that tool is on PATH; explicit pipx/uv methods are
+// left alone.
+func (p *PipProvider) resolveMethodName(methodName string) string {
+	if methodName != "pip" {
+		return methodName
+	}
+	switch p.installers.PipIsolation {
+	case config.PipIsolationPipx:
+		if p.platform.IsExecutableInPath("pipx") {
+			return "pipx"
+		}
+	case config.PipIsolationUV:
+		if p.platform.IsExecutableInPath("uv") {
+			return "uv"
+		}
+	}
+	return methodName
+}
+
+// effectiveMethodName returns the manager to use for an existing
+// installation. An installation recorded as pipx or uv keeps that
+// manager even when the catalog method is plain pip, so installs that
+// were redirected by installers.pip_isolation are updated and removed
+// with the tool that owns them.
+func effectiveMethodName(method catalog.InstallMethodDef, installed agent.InstallMethod) string {
+	if method.Method == "pip" && (installed == agent.MethodPipx || installed == agent.MethodUV) {
+		return string(installed)
+	}
+	return method.Method
+}
+
 // buildInstallCommand builds the install command for the appropriate package manager.
 func (p *PipProvider) buildInstallCommand(method catalog.InstallMethodDef, force bool) (string, []string, string, error) {
-	methodName := method.Method
+	methodName := p.resolveMethodName(method.Method)
 
 	packageName := method.Package
 	if packageName == "" {
@@ -183,8 +230,8 @@ func (p *PipProvider) buildInstallCommand(method catalog.InstallMethodDef, force
 }
 
 // buildUpdateCommand builds the update command for the appropriate package manager.
-func (p *PipProvider) buildUpdateCommand(method catalog.InstallMethodDef) (string, []string, string, error) {
-	methodName := method.Method
+func (p *PipProvider) buildUpdateCommand(method catalog.InstallMethodDef, installed agent.InstallMethod) (string, []string, string, error) {
+	methodName := effectiveMethodName(method, installed)
 
 	packageName := method.Package
 	if packageName == "" {
@@ -211,8 +258,8 @@ func (p *PipProvider) buildUpdateCommand(method catalog.InstallMethodDef) (strin
 }
 
 // buildUninstallCommand builds the uninstall command.
-func (p *PipProvider) buildUninstallCommand(method catalog.InstallMethodDef) (string, []string, string, error) {
-	methodName := method.Method
+func (p *PipProvider) buildUninstallCommand(method catalog.InstallMethodDef, installed agent.InstallMethod) (string, []string, string, error) {
+	methodName := effectiveMethodName(method, installed)
 
 	packageName := method.Package
 	if packageName == "" {
@@ -240,24 +287,27 @@ func (p *PipProvider) buildUninstallCommand(method catalog.InstallMethodDef) (st
 
 // getInstalledVersion gets the installed version of a package.
 func (p *PipProvider) getInstalledVersion(ctx context.Context, manager, packageName string) agent.Version {
-	var cmd *exec.Cmd
+	var name string
+	var args []string
 
 	switch manager {
 	case "pipx":
-		cmd = exec.CommandContext(ctx, "pipx", "list", "--json")
+		name, args = "pipx", []string{"list", "--json"}
 	case "uv":
-		cmd = exec.CommandContext(ctx, "uv", "tool", "list")
+		name, args = "uv", []string{"tool", "list"}
 	default:
-		cmd = exec.CommandContext(ctx, manager, "show", packageName)
+		name, args = manager, []string{"show", packageName}
 	}
 
-	output, err := cmd.Output()
+	res, err := p.runner.Run(ctx, name, args,
+		execx.WithEnv(p.managerEnv(manager)...),
+		execx.WithTimeout(p.installers.TimeoutFor(manager)))
 	if err != nil {
 		return agent.Version{}
 	}
 
 	// Parse version from output
-	versionStr := extractVersionFromPipOutput(string(output), packageName, manager)
+	versionStr := extractVersionFromPipOutput(res.Stdout, packageName, manager)
 	version, _ := agent.ParseVersion(versionStr)
 	return version
 }
@@ -303,14 +353,15 @@ func (p *PipProvider) GetLatestVersion(ctx context.Context, method catalog.Insta
 
 	case "uv":
 		// Use uv pip index versions
-		cmd := exec.CommandContext(ctx, "uv", "pip", "index", "versions", packageName)
-		output, err := cmd.Output()
+		res, err := p.runner.Run(ctx, "uv", []string{"pip", "index", "versions", packageName},
+			execx.WithEnv(p.installers.UVEnv()...),
+			execx.WithTimeout(p.installers.TimeoutFor("uv")))
 		if err != nil {
 			// Fallback to PyPI
 			return p.getLatestFromPyPI(ctx, packageName)
 		}
 		// Parse output: "packagename (x.y.z)"
-		outputStr := strings.TrimSpace(string(output))
+		outputStr := strings.TrimSpace(res.Stdout)
 		if idx := strings.Index(outputStr, "("); idx > 0 {
 			if endIdx := strings.Index(outputStr, ")"); endIdx > idx {
 				versionStr := outputStr[idx+1 : endIdx]
@@ -329,14 +380,15 @@ func (p *PipProvider) GetLatestVersion(ctx context.Context, method catalog.Insta
 		if !p.platform.IsExecutableInPath("pip3") {
 			manager = "pip"
 		}
-		cmd := exec.CommandContext(ctx, manager, "index", "versions", packageName)
-		output, err := cmd.Output()
+		res, err := p.runner.Run(ctx, manager, []string{"index", "versions", packageName},
+			execx.WithEnv(p.installers.PipEnv()...),
+			execx.WithTimeout(p.installers.TimeoutFor("pip")))
 		if err != nil {
 			// Fallback to PyPI API
 			return p.getLatestFromPyPI(ctx, packageName)
 		}
 		// Parse output: "packagename (x.y.z)"
-		outputStr := strings.TrimSpace(string(output))
+		outputStr := strings.TrimSpace(res.Stdout)
 		if idx := strings.Index(outputStr, "("); idx > 0 {
 			if endIdx := strings.Index(outputStr, ")"); endIdx > idx {
 				versionStr := outputStr[idx+1 : endIdx]
@@ -355,15 +407,15 @@ func (p *PipProvider) GetLatestVersion(ctx context.Context, method catalog.Insta
 func (p *PipProvider) getLatestFromPyPI(ctx context.Context, packageName string) (agent.Version, error) {
 	// Use curl to fetch from PyPI JSON API
 	url := fmt.Sprintf("https://pypi.org/pypi/%s/json", packageName)
-	cmd := exec.CommandContext(ctx, "curl", "-s", url)
-	output, err := cmd.Output()
+	res, err := p.runner.Run(ctx, "curl", []string{"-s", url},
+		execx.WithTimeout(p.installers.TimeoutFor("pip")))
 	if err != nil {
 		return agent.Version{}, fmt.Errorf("failed to fetch from PyPI: %w", err)
 	}
 
 	// Simple JSON parsing to extract version
 	// Look for "version": "x.y.z"
-	outputStr := string(output)
+	outputStr := res.Stdout
 	if idx := strings.Index(outputStr, `"version"`); idx > 0 {
 		rest := outputStr[idx:]
 		if colonIdx := strings.Index(rest, ":"); colonIdx > 0 {
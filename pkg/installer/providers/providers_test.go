@@ -7,6 +7,7 @@ import (
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
 	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
 	"github.com/kevinelliott/agentmgr/pkg/platform"
 )
 
@@ -23,17 +24,20 @@ func newMockPlatform() *mockPlatform {
 	}
 }
 
-func (m *mockPlatform) ID() platform.ID                                             { return m.id }
-func (m *mockPlatform) Architecture() string                                        { return "amd64" }
-func (m *mockPlatform) Name() string                                                { return "macOS" }
-func (m *mockPlatform) GetDataDir() string                                          { return "/tmp/data" }
-func (m *mockPlatform) GetConfigDir() string                                        { return "/tmp/config" }
-func (m *mockPlatform) GetCacheDir() string                                         { return "/tmp/cache" }
-func (m *mockPlatform) GetLogDir() string                                           { return "/tmp/log" }
-func (m *mockPlatform) GetIPCSocketPath() string                                    { return "/tmp/agentmgr.sock" }
-func (m *mockPlatform) EnableAutoStart(ctx context.Context) error                   { return nil }
-func (m *mockPlatform) DisableAutoStart(ctx context.Context) error                  { return nil }
-func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error)        { return false, nil }
+func (m *mockPlatform) ID() platform.ID                                      { return m.id }
+func (m *mockPlatform) Architecture() string                                 { return "amd64" }
+func (m *mockPlatform) Name() string                                         { return "macOS" }
+func (m *mockPlatform) GetDataDir() string                                   { return "/tmp/data" }
+func (m *mockPlatform) GetConfigDir() string                                 { return "/tmp/config" }
+func (m *mockPlatform) GetCacheDir() string                                  { return "/tmp/cache" }
+func (m *mockPlatform) GetLogDir() string                                    { return "/tmp/log" }
+func (m *mockPlatform) GetIPCSocketPath() string                             { return "/tmp/agentmgr.sock" }
+func (m *mockPlatform) EnableAutoStart(ctx context.Context) error            { return nil }
+func (m *mockPlatform) DisableAutoStart(ctx context.Context) error           { return nil }
+func (m *mockPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) { return false, nil }
+func (m *mockPlatform) GetAutoStartMethod(ctx context.Context) (platform.AutoStartMethod, error) {
+	return platform.AutoStartNone, nil
+}
 func (m *mockPlatform) FindExecutable(name string) (string, error)                  { return "", nil }
 func (m *mockPlatform) FindExecutables(name string) ([]string, error)               { return nil, nil }
 func (m *mockPlatform) IsExecutableInPath(name string) bool                         { return m.executables[name] != "" }
@@ -41,6 +45,8 @@ func (m *mockPlatform) GetPathDirs() []string
 func (m *mockPlatform) GetShell() string                                            { return "/bin/bash" }
 func (m *mockPlatform) GetShellArg() string                                         { return "-c" }
 func (m *mockPlatform) ShowNotification(title, message string) error                { return nil }
+func (m *mockPlatform) IsDoNotDisturbEnabled() bool                                 { return false }
+func (m *mockPlatform) IsElevated() bool                                            { return false }
 func (m *mockPlatform) ShowChangelogDialog(a, b, c, d string) platform.DialogResult { return 0 }
 
 // ========== NPM Provider Tests ==========
@@ -223,6 +229,81 @@ func TestPipProviderIsAvailable(t *testing.T) {
 	}
 }
 
+func TestPipProviderIsolationRedirect(t *testing.T) {
+	tests := []struct {
+		name        string
+		isolation   string
+		executables map[string]string
+		expected    string
+	}{
+		{"no isolation", "", map[string]string{"pip3": "/usr/bin/pip3"}, "pip3"},
+		{"pipx isolation", "pipx", map[string]string{"pip3": "x", "pipx": "/usr/local/bin/pipx"}, "pipx"},
+		{"uv isolation", "uv", map[string]string{"pip3": "x", "uv": "/usr/local/bin/uv"}, "uv"},
+		{"pipx isolation without pipx", "pipx", map[string]string{"pip3": "/usr/bin/pip3"}, "pip3"},
+		{"uv isolation without uv", "uv", map[string]string{"pip3": "/usr/bin/pip3"}, "pip3"},
+	}
+
+	method := catalog.InstallMethodDef{Method: "pip", Package: "aider-chat"}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plat := newMockPlatform()
+			plat.executables = tt.executables
+			provider := NewPipProvider(plat)
+			provider.SetInstallers(config.InstallersConfig{PipIsolation: tt.isolation})
+
+			manager, _, _, err := provider.buildInstallCommand(method, false)
+			if err != nil {
+				t.Fatalf("buildInstallCommand() error = %v", err)
+			}
+			if manager != tt.expected {
+				t.Errorf("buildInstallCommand() manager = %q, want %q", manager, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPipProviderIsolationLeavesExplicitMethods(t *testing.T) {
+	plat := newMockPlatform()
+	plat.executables = map[string]string{"pipx": "x", "uv": "/usr/local/bin/uv"}
+	provider := NewPipProvider(plat)
+	provider.SetInstallers(config.InstallersConfig{PipIsolation: "uv"})
+
+	method := catalog.InstallMethodDef{Method: "pipx", Package: "aider-chat"}
+	manager, _, _, err := provider.buildInstallCommand(method, false)
+	if err != nil {
+		t.Fatalf("buildInstallCommand() error = %v", err)
+	}
+	if manager != "pipx" {
+		t.Errorf("buildInstallCommand() manager = %q, want explicit pipx method untouched", manager)
+	}
+}
+
+func TestEffectiveMethodName(t *testing.T) {
+	pipMethod := catalog.InstallMethodDef{Method: "pip", Package: "aider-chat"}
+
+	tests := []struct {
+		name      string
+		method    catalog.InstallMethodDef
+		installed agent.InstallMethod
+		expected  string
+	}{
+		{"pip install stays pip", pipMethod, agent.MethodPip, "pip"},
+		{"redirected pipx install keeps pipx", pipMethod, agent.MethodPipx, "pipx"},
+		{"redirected uv install keeps uv", pipMethod, agent.MethodUV, "uv"},
+		{"explicit pipx method unchanged", catalog.InstallMethodDef{Method: "pipx"}, agent.MethodPipx, "pipx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := effectiveMethodName(tt.method, tt.installed)
+			if result != tt.expected {
+				t.Errorf("effectiveMethodName() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestExtractPipPackage(t *testing.T) {
 	tests := []struct {
 		command  string
@@ -901,7 +982,7 @@ func TestPipProviderBuildUpdateCommand(t *testing.T) {
 			plat.executables = tt.executables
 			provider := NewPipProvider(plat)
 
-			manager, args, pkg, err := provider.buildUpdateCommand(tt.method)
+			manager, args, pkg, err := provider.buildUpdateCommand(tt.method, agent.InstallMethod(tt.method.Method))
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -980,7 +1061,7 @@ func TestPipProviderBuildUninstallCommand(t *testing.T) {
 			plat.executables = tt.executables
 			provider := NewPipProvider(plat)
 
-			manager, args, pkg, err := provider.buildUninstallCommand(tt.method)
+			manager, args, pkg, err := provider.buildUninstallCommand(tt.method, agent.InstallMethod(tt.method.Method))
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
@@ -1254,3 +1335,102 @@ func TestResultFields(t *testing.T) {
 		t.Error("WasUpdated should be true")
 	}
 }
+
+// ========== Brew Tap Tests ==========
+
+func TestBrewTap(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   catalog.InstallMethodDef
+		expected string
+	}{
+		{
+			name:     "plain package",
+			method:   catalog.InstallMethodDef{Package: "aider"},
+			expected: "",
+		},
+		{
+			name:     "third-party tap in package",
+			method:   catalog.InstallMethodDef{Package: "someuser/tap/my-agent"},
+			expected: "someuser/tap",
+		},
+		{
+			name:     "official tap is skipped",
+			method:   catalog.InstallMethodDef{Package: "homebrew/cask/cursor"},
+			expected: "",
+		},
+		{
+			name:     "tap in command",
+			method:   catalog.InstallMethodDef{Command: "brew install someuser/tools/agent-cli"},
+			expected: "someuser/tools",
+		},
+		{
+			name:     "no tap in command",
+			method:   catalog.InstallMethodDef{Command: "brew install --cask cursor"},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := brewTap(tt.method); got != tt.expected {
+				t.Errorf("brewTap() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBrewErrorFor(t *testing.T) {
+	stderr := `Warning: unrelated notice
+Error: aider: failed to link
+Error: could not resolve goose formula
+`
+
+	tests := []struct {
+		name        string
+		packageName string
+		expected    string
+	}{
+		{"attributed lines", "aider", "Error: aider: failed to link"},
+		{"other formula", "goose", "Error: could not resolve goose formula"},
+		{"no mention", "cursor", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := brewErrorFor(stderr, tt.packageName); got != tt.expected {
+				t.Errorf("brewErrorFor(%q) = %q, want %q", tt.packageName, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSnapInfoChannel(t *testing.T) {
+	output := `name:      my-agent
+summary:   An example agent
+channels:
+  latest/stable:    1.2.3 2024-01-02 (1234) 12MB classic
+  latest/candidate: ^
+  latest/beta:      1.3.0 2024-01-10 (1250) 12MB classic
+  latest/edge:      --
+`
+
+	tests := []struct {
+		name     string
+		channel  string
+		expected string
+	}{
+		{"stable channel", "latest/stable", "1.2.3"},
+		{"beta channel", "latest/beta", "1.3.0"},
+		{"empty channel", "latest/edge", ""},
+		{"unknown channel", "latest/nightly", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseSnapInfoChannel(output, tt.channel); got != tt.expected {
+				t.Errorf("parseSnapInfoChannel() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package providers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// pipedScriptRE matches "curl ... URL ... | [sudo] sh" style install
+// commands, capturing the script URL, the shell it is piped into, and any
+// trailing shell arguments.
+var pipedScriptRE = regexp.MustCompile(`(?i)\b(?:curl|wget)\b[^|]*?(https?://[^\s|"']+)[^|]*\|\s*(?:sudo\s+)?(sh|bash|zsh)\b(.*)$`)
+
+// scriptSandboxProfile is the sandbox-exec profile install scripts run
+// under when the catalog opts a method into sandboxing: writes to system
+// locations are denied while the user's home, temp dirs, and /usr/local
+// stay available to the installer.
+const scriptSandboxProfile = `(version 1)(allow default)(deny file-write* (subpath "/System") (subpath "/Library") (subpath "/usr") (subpath "/bin") (subpath "/sbin"))(allow file-write* (subpath "/usr/local"))`
+
+// ScriptCommandURL extracts the script URL from a piped install command
+// such as "curl -fsSL https://example.com/install.sh | sh". It returns
+// false for commands that do not pipe a download into a shell.
+func ScriptCommandURL(command string) (string, bool) {
+	m := pipedScriptRE.FindStringSubmatch(command)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// ScriptAudit describes a downloaded install script and how it compares
+// to the version seen on the previous install.
+type ScriptAudit struct {
+	// URL is where the script was fetched from.
+	URL string
+	// Path is the cached copy on disk.
+	Path string
+	// Hash is the script's SHA-256 digest.
+	Hash string
+	// PreviousHash is the digest recorded on the last fetch, empty when
+	// the script has not been seen before.
+	PreviousHash string
+	// Diff lists lines that changed since the previous version.
+	Diff string
+}
+
+// FirstSeen reports whether this is the first time the script was fetched.
+func (a *ScriptAudit) FirstSeen() bool {
+	return a.PreviousHash == ""
+}
+
+// Changed reports whether the script differs from the previously seen
+// version.
+func (a *ScriptAudit) Changed() bool {
+	return a.PreviousHash != "" && a.PreviousHash != a.Hash
+}
+
+// Summary returns a short human-readable description of the audit for
+// inclusion in install output.
+func (a *ScriptAudit) Summary() string {
+	short := a.Hash
+	if len(short) > 12 {
+		short = short[:12]
+	}
+	switch {
+	case a.FirstSeen():
+		return fmt.Sprintf("install script %s (%s, first seen) cached at %s", a.URL, short, a.Path)
+	case a.Changed():
+		summary := fmt.Sprintf("install script %s changed since last seen (%s -> %s)", a.URL, a.PreviousHash[:12], short)
+		if a.Diff != "" {
+			summary += "\n" + a.Diff
+		}
+		return summary
+	default:
+		return fmt.Sprintf("install script %s (%s, unchanged)", a.URL, short)
+	}
+}
+
+// FetchInstallScript downloads the script behind a piped install command,
+// caches it under the script's hash, and compares it against the version
+// seen on the previous fetch. It fails for methods whose command does not
+// pipe a download into a shell.
+func (p *NativeProvider) FetchInstallScript(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*ScriptAudit, error) {
+	url, ok := ScriptCommandURL(method.Command)
+	if !ok {
+		return nil, fmt.Errorf("install method %q does not pipe a script into a shell", method.Method)
+	}
+	return p.fetchScript(ctx, scriptCacheKey(agentDef, method), url)
+}
+
+// fetchScript downloads a script, stores it in the cache keyed by its
+// hash, and records it as the latest seen version for the cache key.
+func (p *NativeProvider) fetchScript(ctx context.Context, key, url string) (*ScriptAudit, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid script URL: %w", err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download install script: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download install script: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download install script: %w", err)
+	}
+
+	sum := sha256.Sum256(body)
+	hash := hex.EncodeToString(sum[:])
+
+	dir := p.scriptCacheDir()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create script cache: %w", err)
+	}
+
+	audit := &ScriptAudit{
+		URL:  url,
+		Path: filepath.Join(dir, key+"-"+hash[:12]+".sh"),
+		Hash: hash,
+	}
+
+	if err := os.WriteFile(audit.Path, body, 0644); err != nil {
+		return nil, fmt.Errorf("failed to cache install script: %w", err)
+	}
+
+	pointer := filepath.Join(dir, key+".current")
+	if prev, err := os.ReadFile(pointer); err == nil {
+		audit.PreviousHash = strings.TrimSpace(string(prev))
+	}
+	if audit.Changed() {
+		prevPath := filepath.Join(dir, key+"-"+audit.PreviousHash[:12]+".sh")
+		if prevBody, err := os.ReadFile(prevPath); err == nil {
+			audit.Diff = diffScripts(string(prevBody), string(body))
+		}
+	}
+
+	if err := os.WriteFile(pointer, []byte(hash+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to record script hash: %w", err)
+	}
+
+	return audit, nil
+}
+
+// prepareScriptCommand rewrites a piped install command to run the
+// audited cached copy of the script instead of piping the download
+// straight into a shell. Commands that do not pipe a script are returned
+// unchanged. The returned note summarizes the audit for the install
+// output.
+func (p *NativeProvider) prepareScriptCommand(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, command string) (string, string, error) {
+	m := pipedScriptRE.FindStringSubmatch(command)
+	if m == nil {
+		return command, "", nil
+	}
+	url, shell, trailing := m[1], m[2], m[3]
+
+	audit, err := p.fetchScript(ctx, scriptCacheKey(agentDef, method), url)
+	if err != nil {
+		return "", "", err
+	}
+
+	run := fmt.Sprintf("%s '%s'", shell, audit.Path)
+	if args := scriptShellArgs(trailing); args != "" {
+		run += " " + args
+	}
+	if method.Metadata["sandbox"] == "true" {
+		run = p.sandboxCommand(run)
+	}
+
+	return run, audit.Summary() + "\n", nil
+}
+
+// sandboxCommand wraps a script invocation in the platform sandbox when
+// one is available; elsewhere the command runs unsandboxed.
+func (p *NativeProvider) sandboxCommand(command string) string {
+	if p.platform.ID() == platform.Darwin && p.platform.IsExecutableInPath("sandbox-exec") {
+		return fmt.Sprintf("sandbox-exec -p '%s' %s", scriptSandboxProfile, command)
+	}
+	return command
+}
+
+// scriptCacheKey names the cache entry for an agent's install script.
+func scriptCacheKey(agentDef catalog.AgentDef, method catalog.InstallMethodDef) string {
+	key := agentDef.ID
+	if key == "" {
+		key = "script"
+	}
+	if method.Method != "" {
+		key += "-" + method.Method
+	}
+	return key
+}
+
+// scriptShellArgs extracts the arguments forwarded to the script from the
+// text after the shell in a piped command, dropping the stdin markers
+// ("-s", "--") that only make sense when piping.
+func scriptShellArgs(trailing string) string {
+	fields := strings.Fields(trailing)
+	for len(fields) > 0 && (fields[0] == "-s" || fields[0] == "--") {
+		fields = fields[1:]
+	}
+	return strings.Join(fields, " ")
+}
+
+// scriptCacheDir returns the directory cached install scripts live in.
+func (p *NativeProvider) scriptCacheDir() string {
+	if p.cacheDirOverride != "" {
+		return p.cacheDirOverride
+	}
+	return filepath.Join(p.platform.GetCacheDir(), "install-scripts")
+}
+
+// diffScripts produces a coarse line diff between two script versions:
+// lines only in the previous version are prefixed "-", new lines "+". It
+// is a review hint, not a faithful unified diff.
+func diffScripts(prev, curr string) string {
+	prevLines := strings.Split(prev, "\n")
+	currLines := strings.Split(curr, "\n")
+
+	prevSet := make(map[string]bool, len(prevLines))
+	for _, line := range prevLines {
+		prevSet[line] = true
+	}
+	currSet := make(map[string]bool, len(currLines))
+	for _, line := range currLines {
+		currSet[line] = true
+	}
+
+	const maxLines = 40
+	var out []string
+	truncated := 0
+	add := func(prefix, line string) {
+		if len(out) >= maxLines {
+			truncated++
+			return
+		}
+		out = append(out, prefix+line)
+	}
+	for _, line := range prevLines {
+		if !currSet[line] {
+			add("- ", line)
+		}
+	}
+	for _, line := range currLines {
+		if !prevSet[line] {
+			add("+ ", line)
+		}
+	}
+	if truncated > 0 {
+		out = append(out, fmt.Sprintf("... (%d more changed lines)", truncated))
+	}
+	return strings.Join(out, "\n")
+}
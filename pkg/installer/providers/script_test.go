@@ -0,0 +1,180 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestScriptCommandURL(t *testing.T) {
+	tests := []struct {
+		command string
+		url     string
+		ok      bool
+	}{
+		{command: "curl -fsSL https://example.com/install.sh | sh", url: "https://example.com/install.sh", ok: true},
+		{command: "curl --proto '=https' -sSf https://sh.example.dev | sudo bash", url: "https://sh.example.dev", ok: true},
+		{command: "wget -qO- https://example.com/get | bash -s -- --yes", url: "https://example.com/get", ok: true},
+		{command: "npm install -g some-agent", ok: false},
+		{command: "curl -o file https://example.com/file.tar.gz", ok: false},
+		{command: "curl https://example.com/file.tar.gz | tar xz", ok: false},
+	}
+
+	for _, tt := range tests {
+		url, ok := ScriptCommandURL(tt.command)
+		if ok != tt.ok {
+			t.Errorf("ScriptCommandURL(%q) ok = %v, want %v", tt.command, ok, tt.ok)
+			continue
+		}
+		if ok && url != tt.url {
+			t.Errorf("ScriptCommandURL(%q) = %q, want %q", tt.command, url, tt.url)
+		}
+	}
+}
+
+func TestScriptShellArgs(t *testing.T) {
+	tests := []struct {
+		trailing string
+		want     string
+	}{
+		{trailing: "", want: ""},
+		{trailing: " -s -- --no-modify-path", want: "--no-modify-path"},
+		{trailing: " -s", want: ""},
+		{trailing: " -- --yes --quiet", want: "--yes --quiet"},
+	}
+
+	for _, tt := range tests {
+		if got := scriptShellArgs(tt.trailing); got != tt.want {
+			t.Errorf("scriptShellArgs(%q) = %q, want %q", tt.trailing, got, tt.want)
+		}
+	}
+}
+
+func TestDiffScripts(t *testing.T) {
+	prev := "line one\nline two\nline three"
+	curr := "line one\nline 2\nline three"
+
+	diff := diffScripts(prev, curr)
+	if !strings.Contains(diff, "- line two") {
+		t.Errorf("diff missing removed line: %q", diff)
+	}
+	if !strings.Contains(diff, "+ line 2") {
+		t.Errorf("diff missing added line: %q", diff)
+	}
+	if diffScripts(prev, prev) != "" {
+		t.Error("expected empty diff for identical scripts")
+	}
+}
+
+func TestFetchInstallScriptCachesAndDiffs(t *testing.T) {
+	script := "#!/bin/sh\necho install v1\n"
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, script)
+	}))
+	defer ts.Close()
+
+	p := NewNativeProvider(platform.Current())
+	p.cacheDirOverride = t.TempDir()
+
+	agentDef := catalog.AgentDef{ID: "mytool"}
+	method := catalog.InstallMethodDef{
+		Method:  "curl",
+		Command: fmt.Sprintf("curl -fsSL %s/install.sh | sh", ts.URL),
+	}
+
+	// First fetch caches the script
+	audit, err := p.FetchInstallScript(context.Background(), agentDef, method)
+	if err != nil {
+		t.Fatalf("FetchInstallScript() error = %v", err)
+	}
+	if !audit.FirstSeen() {
+		t.Error("expected first fetch to report first seen")
+	}
+	data, err := os.ReadFile(audit.Path)
+	if err != nil {
+		t.Fatalf("reading cached script: %v", err)
+	}
+	if string(data) != script {
+		t.Errorf("cached script = %q, want %q", data, script)
+	}
+
+	// Unchanged script on refetch
+	audit, err = p.FetchInstallScript(context.Background(), agentDef, method)
+	if err != nil {
+		t.Fatalf("FetchInstallScript() error = %v", err)
+	}
+	if audit.FirstSeen() || audit.Changed() {
+		t.Errorf("expected unchanged script, got FirstSeen=%v Changed=%v", audit.FirstSeen(), audit.Changed())
+	}
+
+	// A changed script is flagged with a diff
+	script = "#!/bin/sh\necho install v2\n"
+	audit, err = p.FetchInstallScript(context.Background(), agentDef, method)
+	if err != nil {
+		t.Fatalf("FetchInstallScript() error = %v", err)
+	}
+	if !audit.Changed() {
+		t.Fatal("expected changed script to be flagged")
+	}
+	if !strings.Contains(audit.Diff, "+ echo install v2") {
+		t.Errorf("diff missing new line: %q", audit.Diff)
+	}
+	if !strings.Contains(audit.Summary(), "changed since last seen") {
+		t.Errorf("summary = %q, want change notice", audit.Summary())
+	}
+}
+
+func TestFetchInstallScriptRejectsNonPipedCommand(t *testing.T) {
+	p := NewNativeProvider(platform.Current())
+	p.cacheDirOverride = t.TempDir()
+
+	method := catalog.InstallMethodDef{Method: "native", Command: "make install"}
+	if _, err := p.FetchInstallScript(context.Background(), catalog.AgentDef{ID: "x"}, method); err == nil {
+		t.Error("expected error for command without a piped script")
+	}
+}
+
+func TestPrepareScriptCommandRewrite(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "echo hi\n")
+	}))
+	defer ts.Close()
+
+	p := NewNativeProvider(platform.Current())
+	p.cacheDirOverride = t.TempDir()
+
+	agentDef := catalog.AgentDef{ID: "mytool"}
+	method := catalog.InstallMethodDef{Method: "curl"}
+	original := fmt.Sprintf("curl -fsSL %s/install.sh | bash -s -- --yes", ts.URL)
+
+	command, note, err := p.prepareScriptCommand(context.Background(), agentDef, method, original)
+	if err != nil {
+		t.Fatalf("prepareScriptCommand() error = %v", err)
+	}
+	if strings.Contains(command, ts.URL) {
+		t.Errorf("rewritten command still downloads: %q", command)
+	}
+	if !strings.HasPrefix(command, "bash '") {
+		t.Errorf("rewritten command = %q, want bash invocation of cached script", command)
+	}
+	if !strings.HasSuffix(command, " --yes") {
+		t.Errorf("rewritten command = %q, want forwarded --yes flag", command)
+	}
+	if note == "" {
+		t.Error("expected an audit note")
+	}
+
+	// Non-piped commands pass through untouched
+	command, note, err = p.prepareScriptCommand(context.Background(), agentDef, method, "make install")
+	if err != nil || command != "make install" || note != "" {
+		t.Errorf("prepareScriptCommand(make install) = (%q, %q, %v), want passthrough", command, note, err)
+	}
+}
@@ -0,0 +1,194 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// SnapProvider handles Snap-based installations on Linux.
+type SnapProvider struct {
+	platform platform.Platform
+}
+
+// NewSnapProvider creates a new Snap provider.
+func NewSnapProvider(p platform.Platform) *SnapProvider {
+	return &SnapProvider{platform: p}
+}
+
+// Name returns the provider name.
+func (p *SnapProvider) Name() string {
+	return "snap"
+}
+
+// Method returns the install method this provider handles.
+func (p *SnapProvider) Method() agent.InstallMethod {
+	return agent.MethodSnap
+}
+
+// IsAvailable returns true if snap is available.
+func (p *SnapProvider) IsAvailable() bool {
+	return p.platform.ID() == platform.Linux && p.platform.IsExecutableInPath("snap")
+}
+
+// Install installs a snap package.
+func (p *SnapProvider) Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error) {
+	start := time.Now()
+
+	name := method.Package
+	if name == "" {
+		return nil, fmt.Errorf("could not determine snap name")
+	}
+
+	args := []string{"install", name}
+	// Some snaps (notably editors) require classic confinement.
+	if method.Metadata != nil && method.Metadata["classic"] == "true" {
+		args = append(args, "--classic")
+	}
+	if method.Metadata != nil && method.Metadata["channel"] != "" {
+		args = append(args, "--channel", method.Metadata["channel"])
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "snap", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("snap install failed: %w\n%s", err, stderr.String())
+	}
+
+	return &Result{
+		AgentID:   agentDef.ID,
+		AgentName: agentDef.Name,
+		Method:    agent.MethodSnap,
+		Version:   p.getInstalledVersion(ctx, name),
+		Duration:  time.Since(start),
+		Output:    stdout.String(),
+	}, nil
+}
+
+// Update refreshes a snap-installed package.
+func (p *SnapProvider) Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error) {
+	start := time.Now()
+
+	name := method.Package
+	if name == "" {
+		return nil, fmt.Errorf("could not determine snap name")
+	}
+
+	fromVersion := inst.InstalledVersion
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "snap", "refresh", name)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		// `snap refresh` exits non-zero when there is nothing to refresh on
+		// some snapd versions; treat "no updates available" as success.
+		if !strings.Contains(stderr.String(), "no updates available") {
+			return nil, fmt.Errorf("snap refresh failed: %w\n%s", err, stderr.String())
+		}
+	}
+
+	toVersion := p.getInstalledVersion(ctx, name)
+
+	return &Result{
+		AgentID:     agentDef.ID,
+		AgentName:   agentDef.Name,
+		Method:      agent.MethodSnap,
+		FromVersion: fromVersion,
+		Version:     toVersion,
+		Duration:    time.Since(start),
+		Output:      stdout.String(),
+		WasUpdated:  toVersion.IsNewerThan(fromVersion),
+	}, nil
+}
+
+// Uninstall removes a snap-installed package.
+func (p *SnapProvider) Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error {
+	name := method.Package
+	if name == "" {
+		return fmt.Errorf("could not determine snap name")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "snap", "remove", name)
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("snap remove failed: %w\n%s", err, stderr.String())
+	}
+
+	return nil
+}
+
+// GetLatestVersion queries the store version via `snap info`.
+func (p *SnapProvider) GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error) {
+	name := method.Package
+	if name == "" {
+		return agent.Version{}, fmt.Errorf("could not determine snap name")
+	}
+
+	output, err := exec.CommandContext(ctx, "snap", "info", name).Output()
+	if err != nil {
+		return agent.Version{}, fmt.Errorf("failed to get latest version: %w", err)
+	}
+
+	channel := "latest/stable"
+	if method.Metadata != nil && method.Metadata["channel"] != "" {
+		channel = method.Metadata["channel"]
+	}
+
+	if version := parseSnapInfoChannel(string(output), channel); version != "" {
+		return agent.ParseVersion(version)
+	}
+
+	return agent.Version{}, fmt.Errorf("no version found for %s", name)
+}
+
+// getInstalledVersion returns the installed version of a snap.
+func (p *SnapProvider) getInstalledVersion(ctx context.Context, name string) agent.Version {
+	output, err := exec.CommandContext(ctx, "snap", "list", name).Output()
+	if err != nil {
+		return agent.Version{}
+	}
+
+	// Output is columnar with a header line: Name Version Rev ...
+	for i, line := range strings.Split(string(output), "\n") {
+		if i == 0 {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && strings.EqualFold(fields[0], name) {
+			version, _ := agent.ParseVersion(fields[1])
+			return version
+		}
+	}
+	return agent.Version{}
+}
+
+// parseSnapInfoChannel extracts the version published to a channel from
+// `snap info` output, e.g. "  latest/stable:  1.4.2  2024-01-02  (42)  ...".
+func parseSnapInfoChannel(output, channel string) string {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, channel+":") {
+			continue
+		}
+
+		fields := strings.Fields(strings.TrimPrefix(line, channel+":"))
+		if len(fields) > 0 && fields[0] != "--" {
+			return fields[0]
+		}
+	}
+	return ""
+}
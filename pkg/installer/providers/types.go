@@ -2,9 +2,54 @@
 package providers
 
 import (
+	"context"
+	"os"
+	"os/exec"
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// Provider is the interface the installation providers share. The
+// installer manager dispatches to concrete providers, but consumers that
+// want to plug in their own implementations can code against this.
+type Provider interface {
+	// Name returns the provider name (e.g., "npm", "brew").
+	Name() string
+
+	// Method returns the install method this provider handles.
+	Method() agent.InstallMethod
+
+	// IsAvailable returns true if the underlying tooling is present.
+	IsAvailable() bool
+
+	// Install installs an agent using this provider.
+	Install(ctx context.Context, agentDef catalog.AgentDef, method catalog.InstallMethodDef, force bool) (*Result, error)
+
+	// Update updates an installed agent.
+	Update(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, method catalog.InstallMethodDef) (*Result, error)
+
+	// Uninstall removes an installed agent.
+	Uninstall(ctx context.Context, inst *agent.Installation, method catalog.InstallMethodDef) error
+
+	// GetLatestVersion returns the newest version available.
+	GetLatestVersion(ctx context.Context, method catalog.InstallMethodDef) (agent.Version, error)
+}
+
+// Compile-time checks that the concrete providers satisfy Provider.
+// NativeProvider is excluded: native installs have no registry to query
+// for a latest version.
+var (
+	_ Provider = (*NPMProvider)(nil)
+	_ Provider = (*PipProvider)(nil)
+	_ Provider = (*BrewProvider)(nil)
+	_ Provider = (*ExtensionProvider)(nil)
+	_ Provider = (*MiseProvider)(nil)
+	_ Provider = (*FlatpakProvider)(nil)
+	_ Provider = (*SnapProvider)(nil)
+	_ Provider = (*DownloadProvider)(nil)
+	_ Provider = (*GitHubProvider)(nil)
 )
 
 // Result represents the result of an install or update operation.
@@ -19,4 +64,20 @@ type Result struct {
 	Duration       time.Duration
 	Output         string
 	WasUpdated     bool // For updates
+
+	// ShadowedBy is the path of a different binary that resolves first
+	// in PATH for the agent's executable name, so running the agent by
+	// name still hits the old install. Empty when PATH finds the new
+	// binary.
+	ShadowedBy string
+}
+
+// applyEnvOverrides appends environment overrides from the installers
+// configuration to a command, leaving the inherited environment
+// untouched when there are none.
+func applyEnvOverrides(cmd *exec.Cmd, overrides []string) {
+	if len(overrides) == 0 {
+		return
+	}
+	cmd.Env = append(os.Environ(), overrides...)
 }
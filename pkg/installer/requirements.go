@@ -0,0 +1,174 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// requirementCheckTimeout bounds each version probe so a hung tool cannot
+// stall the install flow.
+const requirementCheckTimeout = 5 * time.Second
+
+// RequirementStatus reports whether a single agent requirement is satisfied
+// on the local system.
+type RequirementStatus struct {
+	Requirement catalog.RequirementDef `json:"requirement"`
+	Installed   bool                   `json:"installed"`
+	Version     string                 `json:"version,omitempty"`
+	Satisfied   bool                   `json:"satisfied"`
+}
+
+// Summary returns a one-line human-readable description of the status,
+// including the fix hint when the requirement is not satisfied.
+func (s RequirementStatus) Summary() string {
+	req := s.Requirement
+	name := req.DisplayName()
+
+	switch {
+	case s.Satisfied && s.Version != "":
+		return fmt.Sprintf("%s %s (ok)", name, s.Version)
+	case s.Satisfied:
+		return fmt.Sprintf("%s (ok)", name)
+	case !s.Installed:
+		msg := fmt.Sprintf("%s: not installed", name)
+		if req.Fix != "" {
+			msg += " — " + req.Fix
+		}
+		return msg
+	default:
+		msg := fmt.Sprintf("%s: %s installed, %s or newer required", name, s.Version, req.MinVersion)
+		if req.Fix != "" {
+			msg += " — " + req.Fix
+		}
+		return msg
+	}
+}
+
+// CheckRequirements checks each of the agent's declared requirements against
+// the local system. Agents without requirements return nil.
+func (m *Manager) CheckRequirements(ctx context.Context, agentDef catalog.AgentDef) []RequirementStatus {
+	if len(agentDef.Requirements) == 0 {
+		return nil
+	}
+
+	statuses := make([]RequirementStatus, 0, len(agentDef.Requirements))
+	for _, req := range agentDef.Requirements {
+		statuses = append(statuses, checkRequirement(ctx, m.plat, req))
+	}
+	return statuses
+}
+
+// InstallRequirement runs the requirement's fix command, then re-checks the
+// requirement. It fails when no fix command is declared.
+func (m *Manager) InstallRequirement(ctx context.Context, req catalog.RequirementDef) (RequirementStatus, error) {
+	if req.FixCmd == "" {
+		return RequirementStatus{Requirement: req}, fmt.Errorf("no fix command declared for %s", req.DisplayName())
+	}
+
+	parts := strings.Fields(req.FixCmd)
+	cmd := exec.CommandContext(ctx, parts[0], parts[1:]...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return RequirementStatus{Requirement: req}, fmt.Errorf("failed to install %s: %w: %s",
+			req.DisplayName(), err, strings.TrimSpace(string(out)))
+	}
+
+	return checkRequirement(ctx, m.plat, req), nil
+}
+
+// UnmetRequirements filters statuses down to unsatisfied, non-optional
+// requirements.
+func UnmetRequirements(statuses []RequirementStatus) []RequirementStatus {
+	var unmet []RequirementStatus
+	for _, s := range statuses {
+		if !s.Satisfied && !s.Requirement.Optional {
+			unmet = append(unmet, s)
+		}
+	}
+	return unmet
+}
+
+// checkRequirement checks a single requirement: the command must exist on
+// PATH and, when a minimum version is declared, the installed version must
+// be at least that version. Versions that cannot be determined do not block.
+func checkRequirement(ctx context.Context, plat platform.Platform, req catalog.RequirementDef) RequirementStatus {
+	status := RequirementStatus{Requirement: req}
+
+	path, err := plat.FindExecutable(req.Command)
+	if err != nil || path == "" {
+		return status
+	}
+	status.Installed = true
+	status.Version = requirementVersion(ctx, path, req)
+
+	if req.MinVersion == "" {
+		status.Satisfied = true
+		return status
+	}
+
+	installed, err := agent.ParseVersion(status.Version)
+	if err != nil || !installed.IsSemver() {
+		// Give the benefit of the doubt when the version cannot be parsed;
+		// the install itself will surface any real incompatibility.
+		status.Satisfied = true
+		return status
+	}
+
+	minimum, err := agent.ParseVersion(req.MinVersion)
+	if err != nil {
+		status.Satisfied = true
+		return status
+	}
+
+	status.Satisfied = installed.Compare(minimum) >= 0
+	return status
+}
+
+// requirementVersion runs the requirement's version command and extracts a
+// version string from its output. Returns "" when no version is found.
+func requirementVersion(ctx context.Context, path string, req catalog.RequirementDef) string {
+	versionCmd := req.VersionCmd
+	if versionCmd == "" {
+		versionCmd = req.Command + " --version"
+	}
+
+	parts := strings.Fields(versionCmd)
+	if len(parts) == 0 {
+		return ""
+	}
+	// Use the resolved path so the probe matches the executable we found
+	parts[0] = path
+
+	cmdCtx, cancel := context.WithTimeout(ctx, requirementCheckTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(cmdCtx, parts[0], parts[1:]...).CombinedOutput()
+	if err != nil {
+		return ""
+	}
+
+	output := strings.TrimSpace(string(out))
+	if req.VersionRegex != "" {
+		re, err := regexp.Compile(req.VersionRegex)
+		if err == nil {
+			if matches := re.FindStringSubmatch(output); len(matches) > 1 {
+				return matches[1]
+			}
+		}
+		return ""
+	}
+
+	if matches := requirementVersionPattern.FindStringSubmatch(output); len(matches) > 1 {
+		return matches[1]
+	}
+	return ""
+}
+
+var requirementVersionPattern = regexp.MustCompile(`v?(\d+\.\d+(?:\.\d+)?(?:-[a-zA-Z0-9.]+)?)`)
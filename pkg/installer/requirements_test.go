@@ -0,0 +1,176 @@
+package installer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// writeVersionScript creates an executable that prints the given output.
+func writeVersionScript(t *testing.T, output string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tool")
+	script := "#!/bin/sh\necho \"" + output + "\"\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestCheckRequirementsMissingCommand(t *testing.T) {
+	plat := agentmgrtest.NewFakePlatform()
+	mgr := NewManager(plat)
+
+	agentDef := catalog.AgentDef{
+		Requirements: []catalog.RequirementDef{
+			{Name: "Node.js", Command: "node", MinVersion: "18.0.0", Fix: "Install Node.js from https://nodejs.org/"},
+		},
+	}
+
+	statuses := mgr.CheckRequirements(context.Background(), agentDef)
+	if len(statuses) != 1 {
+		t.Fatalf("statuses = %d, want 1", len(statuses))
+	}
+
+	status := statuses[0]
+	if status.Installed || status.Satisfied {
+		t.Errorf("status = %+v, want not installed and not satisfied", status)
+	}
+	if summary := status.Summary(); !strings.Contains(summary, "not installed") || !strings.Contains(summary, "nodejs.org") {
+		t.Errorf("Summary() = %q, want missing message with fix hint", summary)
+	}
+}
+
+func TestCheckRequirementsMinVersion(t *testing.T) {
+	script := writeVersionScript(t, "v22.4.1")
+
+	tests := []struct {
+		name       string
+		minVersion string
+		satisfied  bool
+	}{
+		{"meets minimum", "18.0.0", true},
+		{"below minimum", "23.0.0", false},
+		{"no minimum", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plat := agentmgrtest.NewFakePlatform().AddExecutable("node", script)
+			mgr := NewManager(plat)
+
+			agentDef := catalog.AgentDef{
+				Requirements: []catalog.RequirementDef{
+					{Name: "Node.js", Command: "node", MinVersion: tt.minVersion},
+				},
+			}
+
+			statuses := mgr.CheckRequirements(context.Background(), agentDef)
+			if len(statuses) != 1 {
+				t.Fatalf("statuses = %d, want 1", len(statuses))
+			}
+
+			status := statuses[0]
+			if !status.Installed {
+				t.Error("expected requirement to be installed")
+			}
+			if status.Version != "22.4.1" {
+				t.Errorf("Version = %q, want 22.4.1", status.Version)
+			}
+			if status.Satisfied != tt.satisfied {
+				t.Errorf("Satisfied = %v, want %v", status.Satisfied, tt.satisfied)
+			}
+		})
+	}
+}
+
+func TestCheckRequirementsCustomRegex(t *testing.T) {
+	script := writeVersionScript(t, "Python 3.12.1")
+
+	plat := agentmgrtest.NewFakePlatform().AddExecutable("python3", script)
+	mgr := NewManager(plat)
+
+	agentDef := catalog.AgentDef{
+		Requirements: []catalog.RequirementDef{
+			{
+				Name:         "Python",
+				Command:      "python3",
+				MinVersion:   "3.10.0",
+				VersionRegex: `Python (\d+\.\d+\.\d+)`,
+			},
+		},
+	}
+
+	statuses := mgr.CheckRequirements(context.Background(), agentDef)
+	if statuses[0].Version != "3.12.1" {
+		t.Errorf("Version = %q, want 3.12.1", statuses[0].Version)
+	}
+	if !statuses[0].Satisfied {
+		t.Error("expected 3.12.1 to satisfy >= 3.10.0")
+	}
+}
+
+func TestCheckRequirementsUnparsableVersionDoesNotBlock(t *testing.T) {
+	script := writeVersionScript(t, "no version here")
+
+	plat := agentmgrtest.NewFakePlatform().AddExecutable("git", script)
+	mgr := NewManager(plat)
+
+	agentDef := catalog.AgentDef{
+		Requirements: []catalog.RequirementDef{
+			{Name: "git", Command: "git", MinVersion: "2.0.0"},
+		},
+	}
+
+	statuses := mgr.CheckRequirements(context.Background(), agentDef)
+	if !statuses[0].Satisfied {
+		t.Error("expected unknown version to get the benefit of the doubt")
+	}
+}
+
+func TestCheckRequirementsEmpty(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+	if statuses := mgr.CheckRequirements(context.Background(), catalog.AgentDef{}); statuses != nil {
+		t.Errorf("statuses = %v, want nil for agent without requirements", statuses)
+	}
+}
+
+func TestUnmetRequirements(t *testing.T) {
+	statuses := []RequirementStatus{
+		{Requirement: catalog.RequirementDef{Name: "Node.js"}, Satisfied: true},
+		{Requirement: catalog.RequirementDef{Name: "git"}, Satisfied: false},
+		{Requirement: catalog.RequirementDef{Name: "jq", Optional: true}, Satisfied: false},
+	}
+
+	unmet := UnmetRequirements(statuses)
+	if len(unmet) != 1 || unmet[0].Requirement.Name != "git" {
+		t.Errorf("UnmetRequirements = %+v, want just git", unmet)
+	}
+}
+
+func TestInstallRequirementWithoutFixCmd(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+	if _, err := mgr.InstallRequirement(context.Background(), catalog.RequirementDef{Name: "Node.js"}); err == nil {
+		t.Error("expected error when no fix command is declared")
+	}
+}
+
+func TestRequirementStatusSummaryOutdated(t *testing.T) {
+	status := RequirementStatus{
+		Requirement: catalog.RequirementDef{Name: "Node.js", MinVersion: "18.0.0", Fix: "brew upgrade node"},
+		Installed:   true,
+		Version:     "16.20.0",
+	}
+
+	summary := status.Summary()
+	for _, want := range []string{"16.20.0", "18.0.0", "brew upgrade node"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Summary() = %q, want it to mention %q", summary, want)
+		}
+	}
+}
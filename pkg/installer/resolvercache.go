@@ -0,0 +1,175 @@
+package installer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// maxConcurrentResolves bounds how many registry lookups run at once so
+// a full update check does not open a request per agent simultaneously.
+const maxConcurrentResolves = 4
+
+// defaultResolverTTL is used for registries without a specific TTL.
+const defaultResolverTTL = 15 * time.Minute
+
+// resolverTTLs sets how long a resolved latest version stays fresh per
+// registry. GitHub gets the longest TTL because its unauthenticated rate
+// limit is the tightest; brew info is already served from a local API
+// cache so a shorter TTL is fine.
+var resolverTTLs = map[string]time.Duration{
+	"npm":    15 * time.Minute,
+	"pip":    15 * time.Minute,
+	"pipx":   15 * time.Minute,
+	"uv":     15 * time.Minute,
+	"brew":   10 * time.Minute,
+	"github": 30 * time.Minute,
+}
+
+// VersionCacheStats reports how the resolver cache is performing.
+type VersionCacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Coalesced uint64 `json:"coalesced"`
+	Entries   int    `json:"entries"`
+}
+
+// HitRate returns the fraction of lookups served without a registry
+// request, counting coalesced waits as hits.
+func (s VersionCacheStats) HitRate() float64 {
+	total := s.Hits + s.Misses + s.Coalesced
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits+s.Coalesced) / float64(total)
+}
+
+// versionCacheEntry is one cached resolution.
+type versionCacheEntry struct {
+	version   agent.Version
+	expiresAt time.Time
+}
+
+// versionCall is an in-flight resolution other lookups for the same key
+// wait on instead of issuing their own request.
+type versionCall struct {
+	done    chan struct{}
+	version agent.Version
+	err     error
+}
+
+// versionCache caches resolved latest versions with per-registry TTLs,
+// coalesces concurrent lookups for the same package, and bounds how many
+// registry requests run at once.
+type versionCache struct {
+	mu       sync.Mutex
+	entries  map[string]versionCacheEntry
+	inflight map[string]*versionCall
+	sem      chan struct{}
+	now      func() time.Time
+
+	hits      uint64
+	misses    uint64
+	coalesced uint64
+}
+
+// newVersionCache creates an empty resolver cache.
+func newVersionCache() *versionCache {
+	return &versionCache{
+		entries:  make(map[string]versionCacheEntry),
+		inflight: make(map[string]*versionCall),
+		sem:      make(chan struct{}, maxConcurrentResolves),
+		now:      time.Now,
+	}
+}
+
+// cacheKey identifies a resolution by registry and package so the same
+// package name under different methods does not collide.
+func cacheKey(method catalog.InstallMethodDef) string {
+	pkg := method.Package
+	if pkg == "" {
+		pkg = method.Command
+	}
+	return method.Method + ":" + pkg
+}
+
+// resolverTTL returns the freshness window for a registry.
+func resolverTTL(methodName string) time.Duration {
+	if ttl, ok := resolverTTLs[methodName]; ok {
+		return ttl
+	}
+	return defaultResolverTTL
+}
+
+// Get returns the cached latest version for a method, resolving it at
+// most once per TTL window. Concurrent callers for the same key share a
+// single resolution; failed resolutions are not cached.
+func (c *versionCache) Get(ctx context.Context, method catalog.InstallMethodDef, resolve func(context.Context, catalog.InstallMethodDef) (agent.Version, error)) (agent.Version, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	key := cacheKey(method)
+
+	c.mu.Lock()
+	if entry, ok := c.entries[key]; ok && c.now().Before(entry.expiresAt) {
+		c.hits++
+		c.mu.Unlock()
+		return entry.version, nil
+	}
+	if call, ok := c.inflight[key]; ok {
+		c.coalesced++
+		c.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.version, call.err
+		case <-ctx.Done():
+			return agent.Version{}, ctx.Err()
+		}
+	}
+	c.misses++
+	call := &versionCall{done: make(chan struct{})}
+	c.inflight[key] = call
+	c.mu.Unlock()
+
+	call.version, call.err = c.resolveLimited(ctx, method, resolve)
+
+	c.mu.Lock()
+	delete(c.inflight, key)
+	if call.err == nil {
+		c.entries[key] = versionCacheEntry{
+			version:   call.version,
+			expiresAt: c.now().Add(resolverTTL(method.Method)),
+		}
+	}
+	c.mu.Unlock()
+	close(call.done)
+
+	return call.version, call.err
+}
+
+// resolveLimited runs the resolver under the global concurrency limit.
+func (c *versionCache) resolveLimited(ctx context.Context, method catalog.InstallMethodDef, resolve func(context.Context, catalog.InstallMethodDef) (agent.Version, error)) (agent.Version, error) {
+	select {
+	case c.sem <- struct{}{}:
+	case <-ctx.Done():
+		return agent.Version{}, ctx.Err()
+	}
+	defer func() { <-c.sem }()
+
+	return resolve(ctx, method)
+}
+
+// Stats returns a snapshot of the cache counters.
+func (c *versionCache) Stats() VersionCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return VersionCacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Coalesced: c.coalesced,
+		Entries:   len(c.entries),
+	}
+}
@@ -0,0 +1,166 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func TestVersionCacheHit(t *testing.T) {
+	cache := newVersionCache()
+	method := catalog.InstallMethodDef{Method: "npm", Package: "@anthropic-ai/claude-code"}
+
+	calls := 0
+	resolve := func(ctx context.Context, m catalog.InstallMethodDef) (agent.Version, error) {
+		calls++
+		return agent.MustParseVersion("1.2.3"), nil
+	}
+
+	for i := 0; i < 3; i++ {
+		version, err := cache.Get(context.Background(), method, resolve)
+		if err != nil {
+			t.Fatalf("Get() error = %v", err)
+		}
+		if version.String() != "1.2.3" {
+			t.Errorf("Get() = %s, want 1.2.3", version)
+		}
+	}
+
+	if calls != 1 {
+		t.Errorf("resolver called %d times, want 1", calls)
+	}
+	stats := cache.Stats()
+	if stats.Hits != 2 || stats.Misses != 1 || stats.Entries != 1 {
+		t.Errorf("Stats() = %+v, want 2 hits, 1 miss, 1 entry", stats)
+	}
+}
+
+func TestVersionCacheTTLExpiry(t *testing.T) {
+	cache := newVersionCache()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	method := catalog.InstallMethodDef{Method: "github", Package: "owner/repo"}
+
+	calls := 0
+	resolve := func(ctx context.Context, m catalog.InstallMethodDef) (agent.Version, error) {
+		calls++
+		return agent.MustParseVersion(fmt.Sprintf("1.0.%d", calls)), nil
+	}
+
+	if _, err := cache.Get(context.Background(), method, resolve); err != nil {
+		t.Fatal(err)
+	}
+
+	// Still fresh just inside the TTL
+	now = now.Add(resolverTTL("github") - time.Second)
+	version, _ := cache.Get(context.Background(), method, resolve)
+	if version.String() != "1.0.1" || calls != 1 {
+		t.Errorf("fresh entry re-resolved: version=%s calls=%d", version, calls)
+	}
+
+	// Expired after the TTL
+	now = now.Add(2 * time.Second)
+	version, _ = cache.Get(context.Background(), method, resolve)
+	if version.String() != "1.0.2" || calls != 2 {
+		t.Errorf("expired entry not re-resolved: version=%s calls=%d", version, calls)
+	}
+}
+
+func TestVersionCacheCoalescing(t *testing.T) {
+	cache := newVersionCache()
+	method := catalog.InstallMethodDef{Method: "pip", Package: "aider-chat"}
+
+	var mu sync.Mutex
+	calls := 0
+	release := make(chan struct{})
+	resolve := func(ctx context.Context, m catalog.InstallMethodDef) (agent.Version, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		<-release
+		return agent.MustParseVersion("2.0.0"), nil
+	}
+
+	// First lookup blocks in the resolver; wait until it is in flight so
+	// the rest coalesce onto it
+	first := make(chan struct{})
+	go func() {
+		cache.Get(context.Background(), method, resolve)
+		close(first)
+	}()
+	for {
+		cache.mu.Lock()
+		inflight := len(cache.inflight)
+		cache.mu.Unlock()
+		if inflight == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			version, err := cache.Get(context.Background(), method, resolve)
+			if err != nil || version.String() != "2.0.0" {
+				t.Errorf("Get() = %s, %v", version, err)
+			}
+		}()
+	}
+
+	// Let the waiters attach before releasing the resolver
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	<-first
+
+	if calls != 1 {
+		t.Errorf("resolver called %d times, want 1", calls)
+	}
+	if stats := cache.Stats(); stats.Coalesced != 4 {
+		t.Errorf("Stats().Coalesced = %d, want 4", stats.Coalesced)
+	}
+}
+
+func TestVersionCacheErrorsNotCached(t *testing.T) {
+	cache := newVersionCache()
+	method := catalog.InstallMethodDef{Method: "npm", Package: "missing"}
+
+	calls := 0
+	resolve := func(ctx context.Context, m catalog.InstallMethodDef) (agent.Version, error) {
+		calls++
+		if calls == 1 {
+			return agent.Version{}, fmt.Errorf("registry unavailable")
+		}
+		return agent.MustParseVersion("3.0.0"), nil
+	}
+
+	if _, err := cache.Get(context.Background(), method, resolve); err == nil {
+		t.Fatal("expected error from first resolution")
+	}
+	version, err := cache.Get(context.Background(), method, resolve)
+	if err != nil || version.String() != "3.0.0" {
+		t.Errorf("Get() after failure = %s, %v; want fresh resolution", version, err)
+	}
+	if calls != 2 {
+		t.Errorf("resolver called %d times, want 2", calls)
+	}
+}
+
+func TestVersionCacheStatsHitRate(t *testing.T) {
+	stats := VersionCacheStats{Hits: 6, Misses: 2, Coalesced: 2}
+	if rate := stats.HitRate(); rate != 0.8 {
+		t.Errorf("HitRate() = %v, want 0.8", rate)
+	}
+	if rate := (VersionCacheStats{}).HitRate(); rate != 0 {
+		t.Errorf("HitRate() on empty stats = %v, want 0", rate)
+	}
+}
@@ -0,0 +1,124 @@
+package installer
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+)
+
+// defaultRetryBackoff is the delay before the first retry when no
+// backoff is configured; each further retry doubles the delay.
+const defaultRetryBackoff = 2 * time.Second
+
+// retryablePatterns lists lowercase substrings of provider errors and
+// command output that indicate a transient failure worth retrying.
+// Keys are provider families; the "" entry applies to every method.
+var retryablePatterns = map[string][]string{
+	"": {
+		"connection reset",
+		"connection refused",
+		"timed out",
+		"timeout",
+		"temporarily unavailable",
+		"temporary failure",
+		"tls handshake",
+		"could not resolve host",
+		"network is unreachable",
+		"503 service unavailable",
+	},
+	"npm": {
+		"econnreset",
+		"econnrefused",
+		"etimedout",
+		"eai_again",
+		"enotfound",
+		"socket hang up",
+		"network error",
+	},
+	"pip": {
+		"connectionerror",
+		"readtimeouterror",
+		"newconnectionerror",
+		"temporary failure in name resolution",
+	},
+	"brew": {
+		"curl: (6)",
+		"curl: (7)",
+		"curl: (28)",
+		"curl: (35)",
+		"curl: (56)",
+	},
+}
+
+// retryFamily maps an install method to its pattern family.
+func retryFamily(method string) string {
+	switch method {
+	case "npm":
+		return "npm"
+	case "pip", "pipx", "uv":
+		return "pip"
+	case "brew":
+		return "brew"
+	default:
+		return ""
+	}
+}
+
+// isRetryableFailure reports whether a failed install or update looks
+// like a transient network problem for the given method.
+func isRetryableFailure(method string, opErr error, result *providers.Result) bool {
+	if opErr == nil {
+		return false
+	}
+	text := strings.ToLower(opErr.Error())
+	if result != nil && result.Output != "" {
+		text += "\n" + strings.ToLower(result.Output)
+	}
+	for _, pattern := range retryablePatterns[""] {
+		if strings.Contains(text, pattern) {
+			return true
+		}
+	}
+	if family := retryFamily(method); family != "" {
+		for _, pattern := range retryablePatterns[family] {
+			if strings.Contains(text, pattern) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runWithRetry runs op, retrying transient failures with exponential
+// backoff according to the installers config. It returns the final
+// result, the number of retries performed, and the final error.
+func (m *Manager) runWithRetry(ctx context.Context, method string, op func() (*providers.Result, error)) (*providers.Result, int, error) {
+	attempts := m.installers.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := m.installers.RetryBackoff
+	if backoff <= 0 {
+		backoff = defaultRetryBackoff
+	}
+
+	var result *providers.Result
+	var err error
+	retries := 0
+	for attempt := 1; ; attempt++ {
+		result, err = op()
+		if err == nil || attempt >= attempts || !isRetryableFailure(method, err, result) {
+			return result, retries, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, retries, err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		retries++
+	}
+}
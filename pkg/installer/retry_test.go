@@ -0,0 +1,154 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+func TestIsRetryableFailure(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		err    error
+		output string
+		want   bool
+	}{
+		{
+			name:   "npm connection reset",
+			method: "npm",
+			err:    errors.New("npm install failed"),
+			output: "npm ERR! code ECONNRESET",
+			want:   true,
+		},
+		{
+			name:   "pip read timeout",
+			method: "pipx",
+			err:    errors.New("pipx install failed"),
+			output: "pip._vendor.urllib3.exceptions.ReadTimeoutError: HTTPSConnectionPool",
+			want:   true,
+		},
+		{
+			name:   "brew curl timeout",
+			method: "brew",
+			err:    errors.New("brew install failed"),
+			output: "curl: (28) Operation timed out",
+			want:   true,
+		},
+		{
+			name:   "generic dns failure any method",
+			method: "native",
+			err:    errors.New("could not resolve host: github.com"),
+			want:   true,
+		},
+		{
+			name:   "npm permission error is permanent",
+			method: "npm",
+			err:    errors.New("npm install failed"),
+			output: "npm ERR! code EACCES: permission denied",
+			want:   false,
+		},
+		{
+			name:   "package not found is permanent",
+			method: "pip",
+			err:    errors.New("No matching distribution found for nonsense"),
+			want:   false,
+		},
+		{
+			name:   "nil error",
+			method: "npm",
+			err:    nil,
+			want:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result *providers.Result
+			if tt.output != "" {
+				result = &providers.Result{Output: tt.output}
+			}
+			if got := isRetryableFailure(tt.method, tt.err, result); got != tt.want {
+				t.Errorf("isRetryableFailure() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunWithRetry(t *testing.T) {
+	m := NewManager(platform.Current())
+	m.SetInstallersConfig(config.InstallersConfig{
+		MaxAttempts:  3,
+		RetryBackoff: time.Millisecond,
+	})
+
+	// A transient failure that succeeds on the third attempt
+	calls := 0
+	result, retries, err := m.runWithRetry(context.Background(), "npm", func() (*providers.Result, error) {
+		calls++
+		if calls < 3 {
+			return nil, errors.New("connect ETIMEDOUT 104.16.0.1:443")
+		}
+		return &providers.Result{Output: "ok"}, nil
+	})
+	if err != nil {
+		t.Fatalf("runWithRetry() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if retries != 2 {
+		t.Errorf("retries = %d, want 2", retries)
+	}
+	if result == nil || result.Output != "ok" {
+		t.Error("runWithRetry() should return the successful result")
+	}
+
+	// A permanent failure is not retried
+	calls = 0
+	_, retries, err = m.runWithRetry(context.Background(), "npm", func() (*providers.Result, error) {
+		calls++
+		return nil, errors.New("npm ERR! code EACCES: permission denied")
+	})
+	if err == nil {
+		t.Fatal("runWithRetry() should return the permanent error")
+	}
+	if calls != 1 || retries != 0 {
+		t.Errorf("calls = %d, retries = %d, want 1 and 0", calls, retries)
+	}
+
+	// A transient failure that never recovers exhausts the attempts
+	calls = 0
+	_, retries, err = m.runWithRetry(context.Background(), "npm", func() (*providers.Result, error) {
+		calls++
+		return nil, errors.New("socket hang up")
+	})
+	if err == nil {
+		t.Fatal("runWithRetry() should return the final error")
+	}
+	if calls != 3 || retries != 2 {
+		t.Errorf("calls = %d, retries = %d, want 3 and 2", calls, retries)
+	}
+}
+
+func TestRunWithRetryDisabled(t *testing.T) {
+	m := NewManager(platform.Current())
+	m.SetInstallersConfig(config.InstallersConfig{MaxAttempts: 1})
+
+	calls := 0
+	_, retries, err := m.runWithRetry(context.Background(), "npm", func() (*providers.Result, error) {
+		calls++
+		return nil, errors.New("connect ETIMEDOUT 104.16.0.1:443")
+	})
+	if err == nil {
+		t.Fatal("runWithRetry() should return the error")
+	}
+	if calls != 1 || retries != 0 {
+		t.Errorf("calls = %d, retries = %d, want 1 and 0", calls, retries)
+	}
+}
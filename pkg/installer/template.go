@@ -0,0 +1,102 @@
+package installer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/platform"
+)
+
+// templateVarPattern matches template variable references like {version}.
+var templateVarPattern = regexp.MustCompile(`\{([a-z_]+)\}`)
+
+// templateUnsafeChars are shell metacharacters that must not appear in a
+// substituted value, since expanded commands are run through the shell.
+const templateUnsafeChars = "`$;&|<>(){}\"'\n"
+
+// expandMethod substitutes template variables in an install method's
+// command and metadata fields:
+//
+//	{version} - the target version of the operation, when one is known
+//	{arch}    - the host CPU architecture (x86_64, arm64)
+//	{os}      - the platform identifier (darwin, linux, windows)
+//	{prefix}  - the user-local installation prefix
+//
+// Definitions without template references pass through unchanged. A
+// reference to an unknown variable, or to {version} in an operation that
+// has no target version, is an error.
+func (m *Manager) expandMethod(method catalog.InstallMethodDef, version string) (catalog.InstallMethodDef, error) {
+	vars := map[string]string{
+		"version": version,
+		"arch":    agent.HostArchitecture(),
+		"os":      string(m.plat.ID()),
+		"prefix":  installPrefix(m.plat),
+	}
+
+	var err error
+	expand := func(field, value string) string {
+		if err != nil {
+			return value
+		}
+		expanded, expandErr := expandTemplate(value, vars)
+		if expandErr != nil {
+			err = fmt.Errorf("%s for %s: %w", field, method.Method, expandErr)
+		}
+		return expanded
+	}
+
+	method.Package = expand("package", method.Package)
+	method.Command = expand("command", method.Command)
+	method.UpdateCmd = expand("update_cmd", method.UpdateCmd)
+	method.UninstallCmd = expand("uninstall_cmd", method.UninstallCmd)
+
+	if len(method.Metadata) > 0 {
+		expanded := make(map[string]string, len(method.Metadata))
+		for k, v := range method.Metadata {
+			expanded[k] = expand("metadata."+k, v)
+		}
+		method.Metadata = expanded
+	}
+
+	return method, err
+}
+
+// expandTemplate replaces {name} references in s with their values,
+// validating each substituted value against shell metacharacters.
+func expandTemplate(s string, vars map[string]string) (string, error) {
+	if !strings.Contains(s, "{") {
+		return s, nil
+	}
+
+	var err error
+	expanded := templateVarPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		name := ref[1 : len(ref)-1]
+		value, ok := vars[name]
+		switch {
+		case !ok:
+			err = fmt.Errorf("unknown template variable %s", ref)
+		case value == "":
+			err = fmt.Errorf("template variable %s has no value in this operation", ref)
+		case strings.ContainsAny(value, templateUnsafeChars):
+			err = fmt.Errorf("template variable %s value %q contains unsafe characters", ref, value)
+		}
+		return value
+	})
+	return expanded, err
+}
+
+// installPrefix returns the user-local installation prefix substituted for
+// {prefix}: ~/.local on Unix-likes, the agentmgr data directory on Windows.
+func installPrefix(plat platform.Platform) string {
+	if plat.ID() != platform.Windows {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, ".local")
+		}
+	}
+	return plat.GetDataDir()
+}
@@ -0,0 +1,121 @@
+package installer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	vars := map[string]string{
+		"version": "1.2.3",
+		"arch":    "arm64",
+		"os":      "linux",
+		"prefix":  "/home/user/.local",
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr string
+	}{
+		{
+			name:  "no templates pass through",
+			input: "npm install -g @anthropic-ai/claude-code",
+			want:  "npm install -g @anthropic-ai/claude-code",
+		},
+		{
+			name:  "version and arch",
+			input: "npm install -g my-agent@{version} --arch={arch}",
+			want:  "npm install -g my-agent@1.2.3 --arch=arm64",
+		},
+		{
+			name:  "os and prefix",
+			input: "curl -o {prefix}/bin/agent https://example.com/{os}/agent",
+			want:  "curl -o /home/user/.local/bin/agent https://example.com/linux/agent",
+		},
+		{
+			name:    "unknown variable",
+			input:   "install {flavor}",
+			wantErr: "unknown template variable {flavor}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := expandTemplate(tt.input, vars)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expandTemplate() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expandTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandTemplateRejectsUnsafeValue(t *testing.T) {
+	vars := map[string]string{"version": "1.0.0; rm -rf /"}
+	if _, err := expandTemplate("install agent@{version}", vars); err == nil {
+		t.Error("expected unsafe value to be rejected")
+	}
+}
+
+func TestExpandTemplateEmptyValue(t *testing.T) {
+	vars := map[string]string{"version": ""}
+	if _, err := expandTemplate("install agent@{version}", vars); err == nil {
+		t.Error("expected reference to empty variable to fail")
+	}
+}
+
+func TestExpandMethod(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+	method := catalog.InstallMethodDef{
+		Method:  "binary",
+		Command: "curl -fsSL https://example.com/{os}/{arch}/agent -o agent",
+		Metadata: map[string]string{
+			"url": "https://example.com/{os}/{arch}/agent",
+		},
+	}
+
+	expanded, err := mgr.expandMethod(method, "2.0.0")
+	if err != nil {
+		t.Fatalf("expandMethod() error = %v", err)
+	}
+
+	arch := agent.HostArchitecture()
+	wantURL := "https://example.com/darwin/" + arch + "/agent"
+	if expanded.Metadata["url"] != wantURL {
+		t.Errorf("metadata url = %q, want %q", expanded.Metadata["url"], wantURL)
+	}
+	if !strings.Contains(expanded.Command, "/darwin/"+arch+"/") {
+		t.Errorf("command not expanded: %q", expanded.Command)
+	}
+
+	// Original method must not be mutated
+	if method.Metadata["url"] != "https://example.com/{os}/{arch}/agent" {
+		t.Errorf("original metadata mutated: %q", method.Metadata["url"])
+	}
+}
+
+func TestExpandMethodVersionWithoutTarget(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+	method := catalog.InstallMethodDef{
+		Method:  "npm",
+		Command: "npm install -g my-agent@{version}",
+	}
+
+	if _, err := mgr.expandMethod(method, ""); err == nil {
+		t.Error("expected {version} without a target version to fail")
+	}
+}
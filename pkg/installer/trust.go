@@ -0,0 +1,56 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// ScriptTrust records which install scripts the user has approved, so
+// piped curl|sh installs only prompt for consent on first use and again
+// when the script changes. Decisions are keyed per agent, method, and
+// script hash and persisted through the settings store; approving a new
+// hash replaces the previously trusted one.
+type ScriptTrust struct {
+	store storage.Store
+}
+
+// NewScriptTrust creates a trust recorder backed by the given store.
+func NewScriptTrust(store storage.Store) *ScriptTrust {
+	return &ScriptTrust{store: store}
+}
+
+// scriptTrustKey names the setting a trust decision is stored under.
+func scriptTrustKey(agentID, method string) string {
+	return "script_trust." + agentID + "." + method
+}
+
+// IsTrusted reports whether the user has already approved this exact
+// script for the agent and method. A hash that differs from the stored
+// one reads as untrusted, which forces a fresh prompt.
+func (t *ScriptTrust) IsTrusted(ctx context.Context, agentID, method, hash string) bool {
+	if t == nil || t.store == nil || hash == "" {
+		return false
+	}
+	stored, err := t.store.GetSetting(ctx, scriptTrustKey(agentID, method))
+	return err == nil && stored == hash
+}
+
+// Remember persists approval of the script hash for the agent and
+// method, replacing any previously trusted hash.
+func (t *ScriptTrust) Remember(ctx context.Context, agentID, method, hash string) error {
+	if t.store == nil {
+		return fmt.Errorf("no store attached")
+	}
+	return t.store.SetSetting(ctx, scriptTrustKey(agentID, method), hash)
+}
+
+// Revoke forgets the trust decision for the agent and method, so the
+// next install prompts again.
+func (t *ScriptTrust) Revoke(ctx context.Context, agentID, method string) error {
+	if t.store == nil {
+		return fmt.Errorf("no store attached")
+	}
+	return t.store.DeleteSetting(ctx, scriptTrustKey(agentID, method))
+}
@@ -0,0 +1,64 @@
+package installer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+)
+
+func TestScriptTrust(t *testing.T) {
+	ctx := context.Background()
+	trust := NewScriptTrust(agentmgrtest.NewFakeStore())
+
+	if trust.IsTrusted(ctx, "goose", "curl", "abc123") {
+		t.Error("unseen script should not be trusted")
+	}
+
+	if err := trust.Remember(ctx, "goose", "curl", "abc123"); err != nil {
+		t.Fatalf("Remember() error = %v", err)
+	}
+	if !trust.IsTrusted(ctx, "goose", "curl", "abc123") {
+		t.Error("remembered script should be trusted")
+	}
+
+	// A changed script hash forces a fresh prompt
+	if trust.IsTrusted(ctx, "goose", "curl", "def456") {
+		t.Error("changed script hash should not be trusted")
+	}
+
+	// Decisions are scoped to the agent and method
+	if trust.IsTrusted(ctx, "aider", "curl", "abc123") {
+		t.Error("trust should not carry over to another agent")
+	}
+	if trust.IsTrusted(ctx, "goose", "native", "abc123") {
+		t.Error("trust should not carry over to another method")
+	}
+
+	// Approving the new hash replaces the old decision
+	if err := trust.Remember(ctx, "goose", "curl", "def456"); err != nil {
+		t.Fatalf("Remember() error = %v", err)
+	}
+	if trust.IsTrusted(ctx, "goose", "curl", "abc123") {
+		t.Error("superseded script hash should no longer be trusted")
+	}
+
+	if err := trust.Revoke(ctx, "goose", "curl"); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	if trust.IsTrusted(ctx, "goose", "curl", "def456") {
+		t.Error("revoked script should not be trusted")
+	}
+}
+
+func TestScriptTrustWithoutStore(t *testing.T) {
+	ctx := context.Background()
+	trust := NewScriptTrust(nil)
+
+	if trust.IsTrusted(ctx, "goose", "curl", "abc123") {
+		t.Error("trust without a store should deny everything")
+	}
+	if err := trust.Remember(ctx, "goose", "curl", "abc123"); err == nil {
+		t.Error("Remember() without a store should fail")
+	}
+}
@@ -0,0 +1,146 @@
+package installer
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+)
+
+// verifyTimeout bounds the post-install smoke test so a hung binary cannot
+// stall the install flow indefinitely.
+const verifyTimeout = 30 * time.Second
+
+// VerificationError reports that an install or update completed but the
+// resulting binary failed its post-install verification.
+type VerificationError struct {
+	AgentID  string
+	Command  string
+	Output   string
+	Expected agent.Version
+	Actual   agent.Version
+	Err      error
+}
+
+func (e *VerificationError) Error() string {
+	if e.Err != nil {
+		msg := fmt.Sprintf("post-install verification failed for %s: %q: %v", e.AgentID, e.Command, e.Err)
+		if e.Output != "" {
+			msg += ": " + e.Output
+		}
+		return msg
+	}
+	return fmt.Sprintf("post-install verification failed for %s: installed version %s but %q reports %s",
+		e.AgentID, e.Expected, e.Command, e.Actual)
+}
+
+func (e *VerificationError) Unwrap() error { return e.Err }
+
+// verifyResult runs the agent's smoke test command (or, when none is
+// declared, its detection version command) against the freshly installed
+// executable. A nil error means the binary executed successfully and, when
+// the version command was used, reported the version that was installed.
+// Agents declaring neither command are not verified.
+func (m *Manager) verifyResult(ctx context.Context, agentDef catalog.AgentDef, result *providers.Result) error {
+	command := agentDef.SmokeTestCmd
+	checkVersion := false
+	if command == "" {
+		command = agentDef.Detection.VersionCmd
+		checkVersion = true
+	}
+	if command == "" {
+		return nil
+	}
+
+	parts := strings.Fields(command)
+	if len(parts) == 0 {
+		return nil
+	}
+
+	// Prefer the path the provider reported; fall back to a PATH lookup so
+	// verification still works for providers that only report a directory.
+	path := result.ExecutablePath
+	if path == "" {
+		if executable := agentDef.GetExecutable(); executable != "" {
+			found, err := m.plat.FindExecutable(executable)
+			if err != nil || found == "" {
+				return &VerificationError{
+					AgentID: agentDef.ID,
+					Command: command,
+					Err:     fmt.Errorf("executable %q not found after install", executable),
+				}
+			}
+			path = found
+		}
+	}
+	if path != "" {
+		parts[0] = path
+	}
+
+	cmdCtx, cancel := context.WithTimeout(ctx, verifyTimeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(cmdCtx, parts[0], parts[1:]...).CombinedOutput()
+	output := strings.TrimSpace(string(out))
+	if err != nil {
+		return &VerificationError{
+			AgentID: agentDef.ID,
+			Command: command,
+			Output:  output,
+			Err:     err,
+		}
+	}
+
+	if !checkVersion || result.Version.IsZero() || !result.Version.IsSemver() {
+		return nil
+	}
+
+	actual := reportedVersion(agentDef, output)
+	if actual.IsZero() || !actual.IsSemver() {
+		// The binary ran but its version output was unrecognizable; treat
+		// a working binary as verified rather than failing the install.
+		return nil
+	}
+	if !actual.Equals(result.Version) {
+		return &VerificationError{
+			AgentID:  agentDef.ID,
+			Command:  command,
+			Output:   output,
+			Expected: result.Version,
+			Actual:   actual,
+		}
+	}
+
+	return nil
+}
+
+// reportedVersion extracts the version an agent reports from its version
+// command output, honoring the agent's detection regex and version scheme.
+func reportedVersion(agentDef catalog.AgentDef, output string) agent.Version {
+	versionStr := output
+	if agentDef.Detection.VersionRegex != "" {
+		re, err := regexp.Compile(agentDef.Detection.VersionRegex)
+		if err != nil {
+			return agent.Version{}
+		}
+		matches := re.FindStringSubmatch(output)
+		if len(matches) < 2 {
+			return agent.Version{}
+		}
+		versionStr = matches[1]
+	} else if matches := requirementVersionPattern.FindStringSubmatch(output); len(matches) > 1 {
+		versionStr = matches[1]
+	}
+
+	v, err := agent.ParseVersionScheme(versionStr, agent.VersionScheme(agentDef.VersionScheme), agentDef.VersionPattern)
+	if err != nil {
+		return agent.Version{}
+	}
+	return v
+}
@@ -0,0 +1,155 @@
+package installer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/installer/providers"
+)
+
+// writeScript creates an executable shell script with the given body.
+func writeScript(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tool")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	return path
+}
+
+func TestVerifyResultVersionMatch(t *testing.T) {
+	script := writeScript(t, `echo "tool version 1.2.3"`)
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{
+		ID:        "test-agent",
+		Detection: catalog.DetectionDef{Executables: []string{"tool"}, VersionCmd: "tool --version"},
+	}
+	result := &providers.Result{
+		Version:        agent.MustParseVersion("1.2.3"),
+		ExecutablePath: script,
+	}
+
+	if err := mgr.verifyResult(context.Background(), agentDef, result); err != nil {
+		t.Errorf("verifyResult() = %v, want nil", err)
+	}
+}
+
+func TestVerifyResultVersionMismatch(t *testing.T) {
+	script := writeScript(t, `echo "tool version 1.0.0"`)
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{
+		ID:        "test-agent",
+		Detection: catalog.DetectionDef{Executables: []string{"tool"}, VersionCmd: "tool --version"},
+	}
+	result := &providers.Result{
+		Version:        agent.MustParseVersion("1.2.3"),
+		ExecutablePath: script,
+	}
+
+	err := mgr.verifyResult(context.Background(), agentDef, result)
+	var verr *VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("verifyResult() = %v, want *VerificationError", err)
+	}
+	if verr.Actual.String() != "1.0.0" {
+		t.Errorf("Actual = %s, want 1.0.0", verr.Actual)
+	}
+	if verr.Output == "" {
+		t.Error("expected captured output in the error")
+	}
+}
+
+func TestVerifyResultCommandFails(t *testing.T) {
+	script := writeScript(t, `echo "segfault"; exit 1`)
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{
+		ID:        "test-agent",
+		Detection: catalog.DetectionDef{Executables: []string{"tool"}, VersionCmd: "tool --version"},
+	}
+	result := &providers.Result{
+		Version:        agent.MustParseVersion("1.2.3"),
+		ExecutablePath: script,
+	}
+
+	err := mgr.verifyResult(context.Background(), agentDef, result)
+	var verr *VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("verifyResult() = %v, want *VerificationError", err)
+	}
+	if verr.Output != "segfault" {
+		t.Errorf("Output = %q, want captured command output", verr.Output)
+	}
+}
+
+func TestVerifyResultSmokeTest(t *testing.T) {
+	pass := writeScript(t, `exit 0`)
+	fail := writeScript(t, `echo "broken install"; exit 2`)
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{
+		ID:           "test-agent",
+		SmokeTestCmd: "tool doctor",
+		Detection:    catalog.DetectionDef{Executables: []string{"tool"}, VersionCmd: "tool --version"},
+	}
+
+	if err := mgr.verifyResult(context.Background(), agentDef, &providers.Result{ExecutablePath: pass}); err != nil {
+		t.Errorf("verifyResult() = %v, want nil for passing smoke test", err)
+	}
+
+	err := mgr.verifyResult(context.Background(), agentDef, &providers.Result{ExecutablePath: fail})
+	var verr *VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("verifyResult() = %v, want *VerificationError", err)
+	}
+}
+
+func TestVerifyResultExecutableMissing(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{
+		ID:        "test-agent",
+		Detection: catalog.DetectionDef{Executables: []string{"tool"}, VersionCmd: "tool --version"},
+	}
+
+	err := mgr.verifyResult(context.Background(), agentDef, &providers.Result{})
+	var verr *VerificationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("verifyResult() = %v, want *VerificationError", err)
+	}
+}
+
+func TestVerifyResultNoCommands(t *testing.T) {
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{ID: "test-agent"}
+	if err := mgr.verifyResult(context.Background(), agentDef, &providers.Result{}); err != nil {
+		t.Errorf("verifyResult() = %v, want nil when no verification command is declared", err)
+	}
+}
+
+func TestVerifyResultUnrecognizedVersionOutput(t *testing.T) {
+	script := writeScript(t, `echo "ready"`)
+	mgr := NewManager(agentmgrtest.NewFakePlatform())
+
+	agentDef := catalog.AgentDef{
+		ID:        "test-agent",
+		Detection: catalog.DetectionDef{Executables: []string{"tool"}, VersionCmd: "tool --version"},
+	}
+	result := &providers.Result{
+		Version:        agent.MustParseVersion("1.2.3"),
+		ExecutablePath: script,
+	}
+
+	if err := mgr.verifyResult(context.Background(), agentDef, result); err != nil {
+		t.Errorf("verifyResult() = %v, want nil when version output is unrecognizable", err)
+	}
+}
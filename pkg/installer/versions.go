@@ -0,0 +1,184 @@
+package installer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// ListAvailableVersions returns the versions available for installation via
+// the given method, newest first. Supported for npm, pip-family and brew.
+func (m *Manager) ListAvailableVersions(ctx context.Context, method catalog.InstallMethodDef) ([]agent.Version, error) {
+	var versions []agent.Version
+	var err error
+
+	switch method.Method {
+	case "npm":
+		if !m.npm.IsAvailable() {
+			return nil, fmt.Errorf("npm is not available")
+		}
+		versions, err = listNPMVersions(ctx, method)
+
+	case "pip", "pipx", "uv":
+		versions, err = listPyPIVersions(ctx, method)
+
+	case "brew":
+		if !m.brew.IsAvailable() {
+			return nil, fmt.Errorf("brew is not available")
+		}
+		versions, err = listBrewVersions(ctx, method)
+
+	default:
+		return nil, fmt.Errorf("version listing not supported for method: %s", method.Method)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Newest first
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].IsNewerThan(versions[j])
+	})
+
+	return versions, nil
+}
+
+// listNPMVersions queries the npm registry for all published versions.
+func listNPMVersions(ctx context.Context, method catalog.InstallMethodDef) ([]agent.Version, error) {
+	packageName := method.Package
+	if packageName == "" {
+		packageName = extractPackageFromCommand(method.Command)
+	}
+	if packageName == "" {
+		return nil, fmt.Errorf("could not determine npm package name")
+	}
+
+	output, err := exec.CommandContext(ctx, "npm", "view", packageName, "versions", "--json").Output()
+	if err != nil {
+		return nil, fmt.Errorf("npm view failed: %w", err)
+	}
+
+	var raw []string
+	if err := json.Unmarshal(output, &raw); err != nil {
+		// A package with a single version returns a bare string
+		var single string
+		if err := json.Unmarshal(output, &single); err != nil {
+			return nil, fmt.Errorf("failed to parse npm versions: %w", err)
+		}
+		raw = []string{single}
+	}
+
+	return parseVersionStrings(raw), nil
+}
+
+// listPyPIVersions queries the PyPI JSON API for all released versions.
+func listPyPIVersions(ctx context.Context, method catalog.InstallMethodDef) ([]agent.Version, error) {
+	packageName := method.Package
+	if packageName == "" {
+		packageName = extractPackageFromCommand(method.Command)
+	}
+	if packageName == "" {
+		return nil, fmt.Errorf("could not determine package name")
+	}
+
+	url := fmt.Sprintf("https://pypi.org/pypi/%s/json", packageName)
+	output, err := exec.CommandContext(ctx, "curl", "-s", url).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch from PyPI: %w", err)
+	}
+
+	var result struct {
+		Releases map[string]json.RawMessage `json:"releases"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse PyPI response: %w", err)
+	}
+
+	raw := make([]string, 0, len(result.Releases))
+	for version := range result.Releases {
+		raw = append(raw, version)
+	}
+
+	return parseVersionStrings(raw), nil
+}
+
+// listBrewVersions queries Homebrew for the stable and versioned formulae.
+// Homebrew only exposes the current stable version per formula.
+func listBrewVersions(ctx context.Context, method catalog.InstallMethodDef) ([]agent.Version, error) {
+	formula := method.Package
+	if formula == "" {
+		formula = extractPackageFromCommand(method.Command)
+	}
+	if formula == "" {
+		return nil, fmt.Errorf("could not determine brew formula name")
+	}
+
+	output, err := exec.CommandContext(ctx, "brew", "info", "--json=v2", formula).Output()
+	if err != nil {
+		return nil, fmt.Errorf("brew info failed: %w", err)
+	}
+
+	var result struct {
+		Formulae []struct {
+			Versions struct {
+				Stable string `json:"stable"`
+			} `json:"versions"`
+		} `json:"formulae"`
+	}
+	if err := json.Unmarshal(output, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse brew info: %w", err)
+	}
+
+	var raw []string
+	for _, f := range result.Formulae {
+		if f.Versions.Stable != "" {
+			raw = append(raw, f.Versions.Stable)
+		}
+	}
+
+	return parseVersionStrings(raw), nil
+}
+
+// parseVersionStrings parses version strings, skipping unparseable entries.
+func parseVersionStrings(raw []string) []agent.Version {
+	versions := make([]agent.Version, 0, len(raw))
+	for _, s := range raw {
+		v, err := agent.ParseVersion(s)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+// extractPackageFromCommand extracts the last non-flag token from an
+// install command, stripping any version specifier.
+func extractPackageFromCommand(command string) string {
+	parts := strings.Fields(command)
+	for i := len(parts) - 1; i >= 0; i-- {
+		part := parts[i]
+		if strings.HasPrefix(part, "-") {
+			continue
+		}
+		switch part {
+		case "install", "npm", "pip", "pip3", "pipx", "uv", "tool", "brew", "i":
+			continue
+		}
+		if idx := strings.Index(part, "=="); idx > 0 {
+			return part[:idx]
+		}
+		if idx := strings.LastIndex(part, "@"); idx > 0 {
+			return part[:idx]
+		}
+		return part
+	}
+	return ""
+}
@@ -0,0 +1,40 @@
+package installer
+
+import (
+	"testing"
+)
+
+func TestExtractPackageFromCommand(t *testing.T) {
+	tests := []struct {
+		command  string
+		expected string
+	}{
+		{"npm install -g @anthropic-ai/claude-code", "@anthropic-ai/claude-code"},
+		{"npm install -g package@1.2.3", "package"},
+		{"pip install aider-chat", "aider-chat"},
+		{"pip install aider-chat==0.50.0", "aider-chat"},
+		{"pipx install aider-chat", "aider-chat"},
+		{"uv tool install aider-chat", "aider-chat"},
+		{"brew install gh", "gh"},
+		{"npm install -g", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			result := extractPackageFromCommand(tt.command)
+			if result != tt.expected {
+				t.Errorf("extractPackageFromCommand(%q) = %q, want %q", tt.command, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseVersionStrings(t *testing.T) {
+	versions := parseVersionStrings([]string{"1.0.0", "2.1.3", "not a version at all"})
+	if len(versions) < 2 {
+		t.Fatalf("expected at least 2 parsed versions, got %d", len(versions))
+	}
+	if versions[0].Major != 1 || versions[1].Major != 2 {
+		t.Errorf("unexpected parse results: %v", versions)
+	}
+}
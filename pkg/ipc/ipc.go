@@ -8,16 +8,41 @@ import (
 	"io"
 	"net"
 	"os"
+	"os/user"
+	"strconv"
 	"sync"
 	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/errcode"
 )
 
 // Common errors
 var (
-	ErrNotConnected   = errors.New("not connected to server")
-	ErrServerClosed   = errors.New("server is closed")
-	ErrTimeout        = errors.New("operation timed out")
-	ErrInvalidMessage = errors.New("invalid message format")
+	ErrNotConnected    = errors.New("not connected to server")
+	ErrServerClosed    = errors.New("server is closed")
+	ErrTimeout         = errors.New("operation timed out")
+	ErrInvalidMessage  = errors.New("invalid message format")
+	ErrMessageTooLarge = errors.New("message exceeds size limit")
+)
+
+// errPeerCredUnsupported is returned by peerCredentials on platforms
+// that cannot report the connecting process's identity.
+var errPeerCredUnsupported = errors.New("peer credentials not supported on this platform")
+
+// Framing limits. A single message should comfortably fit a catalog or
+// agent list; anything larger is a misbehaving client, not a real
+// request, and gets its connection dropped rather than buffered.
+const (
+	// MaxMessageSize caps the size of one framed message on the wire.
+	MaxMessageSize = 1 << 20 // 1 MiB
+
+	// messageReadTimeout is how long a frame may take to fully arrive
+	// once its first byte has been read. Idle connections waiting for
+	// the next message are not subject to it.
+	messageReadTimeout = 30 * time.Second
+
+	// messageWriteTimeout bounds writing one message.
+	messageWriteTimeout = 10 * time.Second
 )
 
 // Handler processes incoming IPC messages.
@@ -44,6 +69,16 @@ type Server interface {
 	// SetHandler sets the message handler.
 	SetHandler(handler Handler)
 
+	// SetAllowGroup permits members of the named group to connect in
+	// addition to the owning user. Must be called before Start. An
+	// empty name clears the allowance.
+	SetAllowGroup(group string) error
+
+	// Broadcast pushes a notification to every connection that has
+	// subscribed to events. Send failures on individual connections are
+	// ignored; dead connections are cleaned up by their read loops.
+	Broadcast(msg *Message)
+
 	// IsRunning returns true if the server is running.
 	IsRunning() bool
 
@@ -68,24 +103,76 @@ type Client interface {
 	// Subscribe registers a callback for notifications.
 	Subscribe(callback func(*Message))
 
+	// Listen blocks, dispatching server-pushed notifications to the
+	// subscribed callbacks until the connection drops or the context is
+	// canceled (callers should Disconnect on cancellation to unblock the
+	// read). Send must not be used while listening.
+	Listen(ctx context.Context)
+
 	// IsConnected returns true if connected to the server.
 	IsConnected() bool
 }
 
 // connection wraps a net.Conn with message framing.
 type connection struct {
-	conn    net.Conn
-	encoder *json.Encoder
-	decoder *json.Decoder
-	mu      sync.Mutex
+	conn     net.Conn
+	reader   *frameReader
+	encoder  *json.Encoder
+	decoder  *json.Decoder
+	deadline time.Time // caller-imposed deadline, zero when unset
+	mu       sync.Mutex
+}
+
+// frameReader is the connection's read side. It counts bytes per frame
+// so an oversized message fails with ErrMessageTooLarge instead of being
+// buffered without bound, and arms a read deadline once a frame starts
+// so a half-sent message cannot stall the connection forever.
+type frameReader struct {
+	conn     net.Conn
+	n        int64     // bytes consumed in the current frame
+	deadline time.Time // caller-imposed deadline, zero when unset
+}
+
+func (r *frameReader) Read(p []byte) (int, error) {
+	if r.n >= MaxMessageSize {
+		return 0, ErrMessageTooLarge
+	}
+	if rem := MaxMessageSize - r.n; int64(len(p)) > rem {
+		p = p[:rem]
+	}
+	if r.n == 0 {
+		// Idle between frames: only the caller's deadline applies.
+		_ = r.conn.SetReadDeadline(r.deadline)
+	}
+	n, err := r.conn.Read(p)
+	if n > 0 && r.n == 0 {
+		// First byte of a frame: the remainder must arrive promptly.
+		d := time.Now().Add(messageReadTimeout)
+		if !r.deadline.IsZero() && r.deadline.Before(d) {
+			d = r.deadline
+		}
+		_ = r.conn.SetReadDeadline(d)
+	}
+	r.n += int64(n)
+	return n, err
+}
+
+// reset marks the current frame complete so the next read starts a new
+// byte count. The decoder may already have buffered the start of the
+// next frame, so the limit is approximate by up to one read, but still
+// bounded.
+func (r *frameReader) reset() {
+	r.n = 0
 }
 
 // newConnection creates a new connection wrapper.
 func newConnection(conn net.Conn) *connection {
+	reader := &frameReader{conn: conn}
 	return &connection{
 		conn:    conn,
+		reader:  reader,
 		encoder: json.NewEncoder(conn),
-		decoder: json.NewDecoder(conn),
+		decoder: json.NewDecoder(reader),
 	}
 }
 
@@ -93,15 +180,23 @@ func newConnection(conn net.Conn) *connection {
 func (c *connection) Send(msg *Message) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
+	d := time.Now().Add(messageWriteTimeout)
+	if !c.deadline.IsZero() && c.deadline.Before(d) {
+		d = c.deadline
+	}
+	_ = c.conn.SetWriteDeadline(d)
 	return c.encoder.Encode(msg)
 }
 
-// Receive receives a message from the connection.
+// Receive receives a message from the connection. After a framing error
+// (oversized frame, stalled frame, malformed JSON) the decoder is
+// desynchronized and the connection must be closed.
 func (c *connection) Receive() (*Message, error) {
 	var msg Message
 	if err := c.decoder.Decode(&msg); err != nil {
 		return nil, err
 	}
+	c.reader.reset()
 	return &msg, nil
 }
 
@@ -110,9 +205,14 @@ func (c *connection) Close() error {
 	return c.conn.Close()
 }
 
-// SetDeadline sets read and write deadlines.
+// SetDeadline sets a caller-imposed deadline for reads and writes,
+// layered under the per-message limits.
 func (c *connection) SetDeadline(t time.Time) error {
-	return c.conn.SetDeadline(t)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.deadline = t
+	c.reader.deadline = t
+	return nil
 }
 
 // unixServer implements Server using Unix sockets.
@@ -121,8 +221,9 @@ type unixServer struct {
 	listener   net.Listener
 	handler    Handler
 	running    bool
+	allowGID   int // additional group allowed to connect, -1 when unset
 	mu         sync.RWMutex
-	conns      map[*connection]bool
+	conns      map[*connection]bool // value marks connections subscribed to notifications
 	connsMu    sync.Mutex
 	done       chan struct{}
 }
@@ -131,11 +232,35 @@ type unixServer struct {
 func NewUnixServer(socketPath string) Server {
 	return &unixServer{
 		socketPath: socketPath,
+		allowGID:   -1,
 		conns:      make(map[*connection]bool),
 		done:       make(chan struct{}),
 	}
 }
 
+// SetAllowGroup permits members of the named group to connect in
+// addition to the owning user. Peer credentials only expose the
+// connecting process's effective primary group, so members must have it
+// as their primary group (e.g. via newgrp or a service's Group= setting).
+func (s *unixServer) SetAllowGroup(group string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if group == "" {
+		s.allowGID = -1
+		return nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return fmt.Errorf("failed to resolve allow group %q: %w", group, err)
+	}
+	gid, err := strconv.Atoi(g.Gid)
+	if err != nil {
+		return fmt.Errorf("unexpected gid %q for group %q", g.Gid, group)
+	}
+	s.allowGID = gid
+	return nil
+}
+
 // cleanupStaleSocket removes the socket file if it exists but no server is listening.
 // This handles cases where the server crashed or was forcefully killed.
 func (s *unixServer) cleanupStaleSocket() error {
@@ -181,6 +306,14 @@ func (s *unixServer) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Restrict the socket to its owner before accepting connections;
+	// peer credential checks are the second layer.
+	if err := os.Chmod(s.socketPath, 0o600); err != nil {
+		listener.Close()
+		s.mu.Unlock()
+		return fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+
 	s.listener = listener
 	s.running = true
 	s.done = make(chan struct{})
@@ -209,15 +342,40 @@ func (s *unixServer) acceptLoop(ctx context.Context) {
 			continue
 		}
 
+		if !s.authorizedPeer(conn) {
+			conn.Close()
+			continue
+		}
+
 		c := newConnection(conn)
 		s.connsMu.Lock()
-		s.conns[c] = true
+		s.conns[c] = false
 		s.connsMu.Unlock()
 
 		go s.handleConnection(ctx, c)
 	}
 }
 
+// authorizedPeer reports whether the connecting process may talk to the
+// server: the server's own user, root, or a member of the group set via
+// SetAllowGroup.
+func (s *unixServer) authorizedPeer(conn net.Conn) bool {
+	uid, gid, err := peerCredentials(conn)
+	if err != nil {
+		// Platforms without peer credentials fall back to the 0600
+		// socket mode as the only barrier; elsewhere a failed lookup
+		// is a denial.
+		return errors.Is(err, errPeerCredUnsupported)
+	}
+	if uid == uint32(os.Getuid()) || uid == 0 {
+		return true
+	}
+	s.mu.RLock()
+	allowGID := s.allowGID
+	s.mu.RUnlock()
+	return allowGID >= 0 && gid == uint32(allowGID)
+}
+
 // handleConnection processes messages from a single connection.
 func (s *unixServer) handleConnection(ctx context.Context, conn *connection) {
 	defer func() {
@@ -241,6 +399,32 @@ func (s *unixServer) handleConnection(ctx context.Context, conn *connection) {
 			if err == io.EOF || errors.Is(err, net.ErrClosed) {
 				return
 			}
+			// The stream is desynchronized after an oversized, stalled
+			// or malformed frame: report why, then drop the connection.
+			code := "invalid_message"
+			var netErr net.Error
+			switch {
+			case errors.Is(err, ErrMessageTooLarge):
+				code = "message_too_large"
+			case errors.As(err, &netErr) && netErr.Timeout():
+				code = "read_timeout"
+			}
+			errMsg, _ := NewMessage(MessageTypeError, ErrorResponse{
+				Code:    code,
+				Message: err.Error(),
+			})
+			_ = conn.Send(errMsg)
+			return
+		}
+
+		// Subscriptions are a connection-level concern handled here, so
+		// Broadcast knows which connections want pushed notifications
+		if msg.Type == MessageTypeSubscribe {
+			s.connsMu.Lock()
+			s.conns[conn] = true
+			s.connsMu.Unlock()
+			resp, _ := NewMessage(MessageTypeSuccess, nil)
+			_ = conn.Send(resp)
 			continue
 		}
 
@@ -251,8 +435,14 @@ func (s *unixServer) handleConnection(ctx context.Context, conn *connection) {
 		if handler != nil {
 			resp, err := handler.HandleMessage(ctx, msg)
 			if err != nil {
+				// Handlers returning a coded error keep their code on
+				// the wire; anything else is a generic handler failure
+				code := "handler_error"
+				if c := errcode.Of(err); c != "" {
+					code = string(c)
+				}
 				errMsg, _ := NewMessage(MessageTypeError, ErrorResponse{
-					Code:    "handler_error",
+					Code:    code,
 					Message: err.Error(),
 				})
 				_ = conn.Send(errMsg)
@@ -266,6 +456,17 @@ func (s *unixServer) handleConnection(ctx context.Context, conn *connection) {
 	}
 }
 
+// Broadcast pushes a message to every subscribed connection.
+func (s *unixServer) Broadcast(msg *Message) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	for conn, subscribed := range s.conns {
+		if subscribed {
+			_ = conn.Send(msg)
+		}
+	}
+}
+
 // Stop gracefully shuts down the server.
 func (s *unixServer) Stop(ctx context.Context) error {
 	s.mu.Lock()
@@ -349,12 +550,18 @@ func (c *unixClient) Connect(ctx context.Context) error {
 	c.connected = true
 
 	// Note: We don't start listenForNotifications here because it conflicts
-	// with the synchronous Send/Receive pattern. If async notifications are
-	// needed, they should be handled separately.
+	// with the synchronous Send/Receive pattern. Clients that want pushed
+	// notifications subscribe and then call Listen explicitly.
 
 	return nil
 }
 
+// Listen blocks, dispatching pushed notifications to subscribers until
+// the connection drops.
+func (c *unixClient) Listen(ctx context.Context) {
+	c.listenForNotifications(ctx)
+}
+
 // listenForNotifications listens for server-pushed notifications.
 func (c *unixClient) listenForNotifications(ctx context.Context) {
 	for {
@@ -374,13 +581,13 @@ func (c *unixClient) listenForNotifications(ctx context.Context) {
 
 		msg, err := conn.Receive()
 		if err != nil {
-			if err == io.EOF || errors.Is(err, net.ErrClosed) {
-				c.mu.Lock()
-				c.connected = false
-				c.mu.Unlock()
-				return
-			}
-			continue
+			// Any receive error leaves the decoder desynchronized, so
+			// the connection is done whether the server hung up or a
+			// frame was oversized or malformed.
+			c.mu.Lock()
+			c.connected = false
+			c.mu.Unlock()
+			return
 		}
 
 		// Dispatch to subscribers
@@ -392,10 +599,6 @@ func (c *unixClient) listenForNotifications(ctx context.Context) {
 	}
 }
 
-// Silence unused warning - kept for future async notification support.
-// See comment in Connect() at line 351-353.
-var _ = (*unixClient).listenForNotifications
-
 // Disconnect closes the connection.
 func (c *unixClient) Disconnect() error {
 	c.mu.Lock()
@@ -2,10 +2,14 @@ package ipc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net"
 	"os"
+	"os/user"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -1290,6 +1294,7 @@ func TestMessageTypeStrings(t *testing.T) {
 		{MessageTypeRefreshCatalog, "refresh_catalog"},
 		{MessageTypeCheckUpdates, "check_updates"},
 		{MessageTypeGetStatus, "get_status"},
+		{MessageTypeReloadConfig, "reload_config"},
 		{MessageTypeShutdown, "shutdown"},
 		{MessageTypeSuccess, "success"},
 		{MessageTypeError, "error"},
@@ -1566,3 +1571,278 @@ func TestServerAcceptLoopClosedListener(t *testing.T) {
 		t.Error("Server should not be running")
 	}
 }
+
+func TestUnixServerBroadcast(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	server := NewUnixServer(socketPath)
+	ctx := context.Background()
+
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop(context.Background())
+
+	time.Sleep(50 * time.Millisecond)
+
+	// Subscribed client should receive broadcasts
+	subscriber := NewUnixClient(socketPath)
+	if err := subscriber.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer subscriber.Disconnect()
+
+	subMsg, _ := NewMessage(MessageTypeSubscribe, nil)
+	resp, err := subscriber.Send(ctx, subMsg)
+	if err != nil {
+		t.Fatalf("Send(subscribe) error = %v", err)
+	}
+	if resp.Type != MessageTypeSuccess {
+		t.Fatalf("Subscribe response type = %q, want %q", resp.Type, MessageTypeSuccess)
+	}
+
+	received := make(chan *Message, 1)
+	subscriber.Subscribe(func(msg *Message) {
+		received <- msg
+	})
+	go subscriber.Listen(ctx)
+
+	// Unsubscribed client should not receive broadcasts
+	bystander := NewUnixClient(socketPath).(*unixClient)
+	if err := bystander.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer bystander.Disconnect()
+
+	time.Sleep(50 * time.Millisecond)
+
+	event, _ := NewMessage(MessageTypeAgentUpdated, AgentEventNotification{
+		AgentID:   "claude-code",
+		AgentName: "Claude Code",
+		ToVersion: "2.0.0",
+	})
+	server.Broadcast(event)
+
+	select {
+	case msg := <-received:
+		if msg.Type != MessageTypeAgentUpdated {
+			t.Errorf("Broadcast type = %q, want %q", msg.Type, MessageTypeAgentUpdated)
+		}
+		var payload AgentEventNotification
+		if err := msg.DecodePayload(&payload); err != nil {
+			t.Fatalf("DecodePayload() error = %v", err)
+		}
+		if payload.AgentID != "claude-code" {
+			t.Errorf("AgentID = %q, want %q", payload.AgentID, "claude-code")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Subscribed client did not receive broadcast")
+	}
+
+	// A normal request on the unsubscribed connection still pairs its
+	// response correctly (no stray broadcast was queued ahead of it)
+	status, _ := NewMessage(MessageTypeGetStatus, nil)
+	server.SetHandler(HandlerFunc(func(ctx context.Context, msg *Message) (*Message, error) {
+		return NewMessage(MessageTypeSuccess, StatusResponse{Running: true})
+	}))
+	resp, err = bystander.Send(ctx, status)
+	if err != nil {
+		t.Fatalf("Send(get_status) error = %v", err)
+	}
+	if resp.Type != MessageTypeSuccess {
+		t.Errorf("Response type = %q, want %q", resp.Type, MessageTypeSuccess)
+	}
+}
+
+func TestOversizedFrameClosesConnection(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	server := NewUnixServer(socketPath)
+	server.SetHandler(HandlerFunc(func(ctx context.Context, msg *Message) (*Message, error) {
+		return NewMessage(MessageTypeSuccess, nil)
+	}))
+
+	ctx := context.Background()
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop(ctx)
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	// One frame just over the limit; valid JSON so only the size check
+	// can reject it.
+	msg, err := NewMessage(MessageTypeGetStatus, map[string]string{
+		"padding": strings.Repeat("a", MaxMessageSize),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.NewEncoder(conn).Encode(msg); err != nil {
+		t.Fatalf("writing oversized frame: %v", err)
+	}
+
+	var resp Message
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		t.Fatalf("reading error response: %v", err)
+	}
+	if resp.Type != MessageTypeError {
+		t.Errorf("Response type = %q, want %q", resp.Type, MessageTypeError)
+	}
+	var errResp ErrorResponse
+	if err := resp.DecodePayload(&errResp); err != nil {
+		t.Fatalf("DecodePayload() error = %v", err)
+	}
+	if errResp.Code != "message_too_large" {
+		t.Errorf("Code = %q, want %q", errResp.Code, "message_too_large")
+	}
+
+	// The server drops the connection after a protocol error.
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.Error("expected connection to be closed after oversized frame")
+	}
+}
+
+func TestMalformedFrameClosesConnection(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	server := NewUnixServer(socketPath)
+	ctx := context.Background()
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop(ctx)
+
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("{not json}\n")); err != nil {
+		t.Fatalf("writing malformed frame: %v", err)
+	}
+
+	var resp Message
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		t.Fatalf("reading error response: %v", err)
+	}
+	if resp.Type != MessageTypeError {
+		t.Errorf("Response type = %q, want %q", resp.Type, MessageTypeError)
+	}
+	var errResp ErrorResponse
+	if err := resp.DecodePayload(&errResp); err != nil {
+		t.Fatalf("DecodePayload() error = %v", err)
+	}
+	if errResp.Code != "invalid_message" {
+		t.Errorf("Code = %q, want %q", errResp.Code, "invalid_message")
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err == nil {
+		t.Error("expected connection to be closed after malformed frame")
+	}
+}
+
+func TestReceiveOversizedFrame(t *testing.T) {
+	serverEnd, clientEnd := net.Pipe()
+	defer serverEnd.Close()
+	defer clientEnd.Close()
+
+	serverConn := newConnection(serverEnd)
+
+	go func() {
+		// Raw write so nothing on the sending side truncates the frame;
+		// the reader stops consuming at the limit, so close afterwards
+		// to unblock any remaining write.
+		payload := []byte(`{"type":"get_status","payload":"` + strings.Repeat("a", MaxMessageSize) + `"}`)
+		clientEnd.Write(payload)
+		clientEnd.Close()
+	}()
+
+	_, err := serverConn.Receive()
+	if !errors.Is(err, ErrMessageTooLarge) {
+		t.Errorf("Receive() error = %v, want ErrMessageTooLarge", err)
+	}
+}
+
+func TestSocketPermissions(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	server := NewUnixServer(socketPath)
+	ctx := context.Background()
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop(ctx)
+
+	info, err := os.Stat(socketPath)
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("socket mode = %o, want 600", perm)
+	}
+}
+
+func TestSetAllowGroup(t *testing.T) {
+	server := NewUnixServer(filepath.Join(t.TempDir(), "test.sock"))
+
+	if err := server.SetAllowGroup("no-such-group-for-agentmgr-test"); err == nil {
+		t.Error("SetAllowGroup() with unknown group should fail")
+	}
+
+	// The current user's primary group must resolve; skip if the
+	// environment has no group database entry for it.
+	g, err := user.LookupGroupId(strconv.Itoa(os.Getgid()))
+	if err != nil {
+		t.Skipf("cannot resolve current group: %v", err)
+	}
+	if err := server.SetAllowGroup(g.Name); err != nil {
+		t.Errorf("SetAllowGroup(%q) error = %v", g.Name, err)
+	}
+	if err := server.SetAllowGroup(""); err != nil {
+		t.Errorf("SetAllowGroup(\"\") error = %v", err)
+	}
+}
+
+func TestSameUserConnectionAllowed(t *testing.T) {
+	tmpDir := t.TempDir()
+	socketPath := filepath.Join(tmpDir, "test.sock")
+
+	server := NewUnixServer(socketPath)
+	server.SetHandler(HandlerFunc(func(ctx context.Context, msg *Message) (*Message, error) {
+		return NewMessage(MessageTypeSuccess, nil)
+	}))
+	ctx := context.Background()
+	if err := server.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer server.Stop(ctx)
+
+	client := NewUnixClient(socketPath)
+	if err := client.Connect(ctx); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	defer client.Disconnect()
+
+	sendCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	msg, _ := NewMessage(MessageTypeGetStatus, nil)
+	resp, err := client.Send(sendCtx, msg)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if resp.Type != MessageTypeSuccess {
+		t.Errorf("Response type = %q, want %q", resp.Type, MessageTypeSuccess)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build darwin
+
+package ipc
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentials returns the effective UID and GID of the process on
+// the other end of a Unix socket connection, via LOCAL_PEERCRED.
+func peerCredentials(conn net.Conn) (uid, gid uint32, err error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, 0, fmt.Errorf("not a unix socket connection: %T", conn)
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var cred *unix.Xucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+	}); err != nil {
+		return 0, 0, err
+	}
+	if credErr != nil {
+		return 0, 0, credErr
+	}
+
+	// The first group is the effective GID.
+	gid = ^uint32(0)
+	if cred.Ngroups > 0 {
+		gid = cred.Groups[0]
+	}
+	return cred.Uid, gid, nil
+}
@@ -0,0 +1,37 @@
+//go:build linux
+
+package ipc
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+// peerCredentials returns the effective UID and GID of the process on
+// the other end of a Unix socket connection, via SO_PEERCRED.
+func peerCredentials(conn net.Conn) (uid, gid uint32, err error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return 0, 0, fmt.Errorf("not a unix socket connection: %T", conn)
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var cred *unix.Ucred
+	var credErr error
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return 0, 0, err
+	}
+	if credErr != nil {
+		return 0, 0, credErr
+	}
+
+	return cred.Uid, cred.Gid, nil
+}
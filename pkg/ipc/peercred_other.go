@@ -0,0 +1,11 @@
+//go:build !linux && !darwin
+
+package ipc
+
+import "net"
+
+// peerCredentials is unavailable on this platform; the server falls
+// back to the socket file mode for access control.
+func peerCredentials(conn net.Conn) (uid, gid uint32, err error) {
+	return 0, 0, errPeerCredUnsupported
+}
@@ -6,6 +6,9 @@ import (
 	"time"
 
 	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/detector"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
 )
 
 // MessageType defines the type of IPC message.
@@ -13,15 +16,26 @@ type MessageType string
 
 const (
 	// Request types
-	MessageTypeListAgents     MessageType = "list_agents"
-	MessageTypeGetAgent       MessageType = "get_agent"
-	MessageTypeInstallAgent   MessageType = "install_agent"
-	MessageTypeUpdateAgent    MessageType = "update_agent"
-	MessageTypeUninstallAgent MessageType = "uninstall_agent"
-	MessageTypeRefreshCatalog MessageType = "refresh_catalog"
-	MessageTypeCheckUpdates   MessageType = "check_updates"
-	MessageTypeGetStatus      MessageType = "get_status"
-	MessageTypeShutdown       MessageType = "shutdown"
+	MessageTypeListAgents         MessageType = "list_agents"
+	MessageTypeGetAgent           MessageType = "get_agent"
+	MessageTypeInstallAgent       MessageType = "install_agent"
+	MessageTypeUpdateAgent        MessageType = "update_agent"
+	MessageTypeUninstallAgent     MessageType = "uninstall_agent"
+	MessageTypeRefreshCatalog     MessageType = "refresh_catalog"
+	MessageTypeCheckUpdates       MessageType = "check_updates"
+	MessageTypeGetStatus          MessageType = "get_status"
+	MessageTypeRefreshWidget      MessageType = "refresh_widget"
+	MessageTypeOpenURL            MessageType = "open_url"
+	MessageTypeListMCPServers     MessageType = "list_mcp_servers"
+	MessageTypeListJobs           MessageType = "list_jobs"
+	MessageTypeGetJob             MessageType = "get_job"
+	MessageTypeStartDetection     MessageType = "start_detection"
+	MessageTypeGetDetectionStatus MessageType = "get_detection_status"
+	MessageTypeSubscribe          MessageType = "subscribe"
+	MessageTypeReloadConfig       MessageType = "reload_config"
+	MessageTypeGetConfig          MessageType = "get_config"
+	MessageTypeSetConfig          MessageType = "set_config"
+	MessageTypeShutdown           MessageType = "shutdown"
 
 	// Response types
 	MessageTypeSuccess  MessageType = "success"
@@ -33,6 +47,7 @@ const (
 	MessageTypeAgentInstalled  MessageType = "agent_installed"
 	MessageTypeAgentUpdated    MessageType = "agent_updated"
 	MessageTypeAgentRemoved    MessageType = "agent_removed"
+	MessageTypeConfigChanged   MessageType = "config_changed"
 )
 
 // Message represents an IPC message between CLI and helper.
@@ -82,6 +97,12 @@ type GetAgentRequest struct {
 	Key string `json:"key"`
 }
 
+// OpenURLRequest is the payload for open_url requests carrying an
+// agentmgr:// deep link for the helper to act on.
+type OpenURLRequest struct {
+	URL string `json:"url"`
+}
+
 // InstallAgentRequest is the payload for install_agent requests.
 type InstallAgentRequest struct {
 	AgentID string              `json:"agent_id"`
@@ -99,6 +120,44 @@ type UninstallAgentRequest struct {
 	Key string `json:"key"`
 }
 
+// ListMCPServersRequest is the payload for list_mcp_servers requests.
+// An empty AgentID requests servers for all agents with known configs.
+type ListMCPServersRequest struct {
+	AgentID string `json:"agent_id,omitempty"`
+}
+
+// ListJobsRequest is the payload for list_jobs requests. An empty state
+// requests jobs in any state.
+type ListJobsRequest struct {
+	State string `json:"state,omitempty"`
+	Limit int    `json:"limit,omitempty"`
+}
+
+// GetJobRequest is the payload for get_job requests.
+type GetJobRequest struct {
+	ID string `json:"id"`
+}
+
+// GetConfigRequest is the payload for get_config requests. An empty Key
+// requests the whole configuration.
+type GetConfigRequest struct {
+	Key string `json:"key,omitempty"`
+}
+
+// SetConfigRequest is the payload for set_config requests. The key must
+// be on the config.MutableKeys whitelist.
+type SetConfigRequest struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// GetDetectionStatusRequest is the payload for get_detection_status
+// requests. An empty RunID selects the active run, falling back to the
+// most recently finished one.
+type GetDetectionStatusRequest struct {
+	RunID string `json:"run_id,omitempty"`
+}
+
 // Response payloads
 
 // ListAgentsResponse is the payload for list_agents responses.
@@ -139,6 +198,39 @@ type StatusResponse struct {
 	LastUpdateCheck    time.Time `json:"last_update_check"`
 }
 
+// ListJobsResponse is the payload for list_jobs responses.
+type ListJobsResponse struct {
+	Jobs  []storage.Job `json:"jobs"`
+	Total int           `json:"total"`
+}
+
+// GetJobResponse is the payload for get_job responses.
+type GetJobResponse struct {
+	Job *storage.Job `json:"job,omitempty"`
+}
+
+// StartDetectionResponse is the payload for start_detection responses.
+// AlreadyRunning is true when the returned run ID names a run that was
+// already in flight rather than a newly started one.
+type StartDetectionResponse struct {
+	RunID          string `json:"run_id"`
+	AlreadyRunning bool   `json:"already_running"`
+}
+
+// DetectionStatusResponse is the payload for get_detection_status
+// responses, mirroring the coordinator's run snapshot.
+type DetectionStatusResponse struct {
+	Status detector.RunStatus `json:"status"`
+}
+
+// GetConfigResponse is the payload for get_config responses. Either the
+// whole config or a single key/value pair is set, matching the request.
+type GetConfigResponse struct {
+	Config *config.Config `json:"config,omitempty"`
+	Key    string         `json:"key,omitempty"`
+	Value  interface{}    `json:"value,omitempty"`
+}
+
 // ErrorResponse is the payload for error responses.
 type ErrorResponse struct {
 	Code    string `json:"code"`
@@ -155,6 +247,23 @@ type ProgressResponse struct {
 
 // Notification payloads
 
+// AgentEventNotification is pushed to subscribed connections for
+// agent_installed, agent_updated, and agent_removed notifications.
+type AgentEventNotification struct {
+	AgentID     string `json:"agent_id"`
+	AgentName   string `json:"agent_name"`
+	Method      string `json:"method,omitempty"`
+	FromVersion string `json:"from_version,omitempty"`
+	ToVersion   string `json:"to_version,omitempty"`
+}
+
+// ConfigChangedNotification is pushed to subscribed connections after a
+// successful set_config.
+type ConfigChangedNotification struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value,omitempty"`
+}
+
 // UpdateAvailableNotification is sent when an update is detected.
 type UpdateAvailableNotification struct {
 	AgentID     string `json:"agent_id"`
@@ -5,8 +5,10 @@ package ipc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"net"
+	"os/user"
 	"sync"
 	"time"
 
@@ -19,8 +21,9 @@ type namedPipeServer struct {
 	listener net.Listener
 	handler  Handler
 	running  bool
+	allowSID string // additional group SID allowed to connect, empty when unset
 	mu       sync.RWMutex
-	conns    map[*connection]bool
+	conns    map[*connection]bool // value marks connections subscribed to notifications
 	connsMu  sync.Mutex
 	done     chan struct{}
 }
@@ -34,6 +37,39 @@ func NewNamedPipeServer(pipeName string) Server {
 	}
 }
 
+// SetAllowGroup permits members of the named group to connect in
+// addition to the owning user, by granting the group's SID in the
+// pipe's security descriptor.
+func (s *namedPipeServer) SetAllowGroup(group string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if group == "" {
+		s.allowSID = ""
+		return nil
+	}
+	g, err := user.LookupGroup(group)
+	if err != nil {
+		return fmt.Errorf("failed to resolve allow group %q: %w", group, err)
+	}
+	s.allowSID = g.Gid // Gid is the group's SID on Windows
+	return nil
+}
+
+// securityDescriptor builds the pipe's ACL in SDDL form: full access
+// for SYSTEM and the owning user (the equivalent of the 0600 socket
+// mode on Unix), plus the allow group when one is configured. D:P
+// denies everyone not explicitly granted.
+func (s *namedPipeServer) securityDescriptor() string {
+	sd := "D:P(A;;GA;;;SY)"
+	if u, err := user.Current(); err == nil {
+		sd += "(A;;GA;;;" + u.Uid + ")"
+	}
+	if s.allowSID != "" {
+		sd += "(A;;GA;;;" + s.allowSID + ")"
+	}
+	return sd
+}
+
 // Start begins listening for connections.
 func (s *namedPipeServer) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -43,7 +79,7 @@ func (s *namedPipeServer) Start(ctx context.Context) error {
 	}
 
 	config := &winio.PipeConfig{
-		SecurityDescriptor: "",
+		SecurityDescriptor: s.securityDescriptor(),
 		MessageMode:        false,
 		InputBufferSize:    65536,
 		OutputBufferSize:   65536,
@@ -85,7 +121,7 @@ func (s *namedPipeServer) acceptLoop(ctx context.Context) {
 
 		c := newConnection(conn)
 		s.connsMu.Lock()
-		s.conns[c] = true
+		s.conns[c] = false
 		s.connsMu.Unlock()
 
 		go s.handleConnection(ctx, c)
@@ -118,6 +154,17 @@ func (s *namedPipeServer) handleConnection(ctx context.Context, conn *connection
 			continue
 		}
 
+		// Subscriptions are a connection-level concern handled here, so
+		// Broadcast knows which connections want pushed notifications
+		if msg.Type == MessageTypeSubscribe {
+			s.connsMu.Lock()
+			s.conns[conn] = true
+			s.connsMu.Unlock()
+			resp, _ := NewMessage(MessageTypeSuccess, nil)
+			conn.Send(resp)
+			continue
+		}
+
 		s.mu.RLock()
 		handler := s.handler
 		s.mu.RUnlock()
@@ -140,6 +187,17 @@ func (s *namedPipeServer) handleConnection(ctx context.Context, conn *connection
 	}
 }
 
+// Broadcast pushes a message to every subscribed connection.
+func (s *namedPipeServer) Broadcast(msg *Message) {
+	s.connsMu.Lock()
+	defer s.connsMu.Unlock()
+	for conn, subscribed := range s.conns {
+		if subscribed {
+			conn.Send(msg)
+		}
+	}
+}
+
 // Stop gracefully shuts down the server.
 func (s *namedPipeServer) Stop(ctx context.Context) error {
 	s.mu.Lock()
@@ -219,11 +277,19 @@ func (c *namedPipeClient) Connect(ctx context.Context) error {
 	c.conn = newConnection(conn)
 	c.connected = true
 
-	go c.listenForNotifications(ctx)
+	// Note: We don't start listenForNotifications here because it conflicts
+	// with the synchronous Send/Receive pattern. Clients that want pushed
+	// notifications subscribe and then call Listen explicitly.
 
 	return nil
 }
 
+// Listen blocks, dispatching pushed notifications to subscribers until
+// the connection drops.
+func (c *namedPipeClient) Listen(ctx context.Context) {
+	c.listenForNotifications(ctx)
+}
+
 // listenForNotifications listens for server-pushed notifications.
 func (c *namedPipeClient) listenForNotifications(ctx context.Context) {
 	for {
@@ -0,0 +1,272 @@
+// Package jobs tracks install, update, downgrade, and uninstall
+// operations as persistent jobs. Every operation gets an ID and a state
+// machine (queued, running, succeeded, failed, cancelled) persisted in
+// storage, so progress windows and the CLI can attach to an operation and
+// interrupted work can be requeued after a helper restart.
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// Job types.
+const (
+	TypeInstall   = "install"
+	TypeUpdate    = "update"
+	TypeDowngrade = "downgrade"
+	TypeUninstall = "uninstall"
+)
+
+// Runner executes a job and returns its output. The manager owns the
+// state transitions; runners only do the work.
+type Runner func(ctx context.Context, job *storage.Job) (output string, err error)
+
+// Manager owns the job queue. Jobs are persisted through the store on
+// every transition so their state survives restarts.
+type Manager struct {
+	store  storage.Store
+	runner Runner
+
+	mu   sync.Mutex
+	seq  int
+	wake chan struct{}
+}
+
+// NewManager creates a job manager backed by the given store. The runner
+// is invoked for each queued job in turn.
+func NewManager(store storage.Store, runner Runner) *Manager {
+	return &Manager{
+		store:  store,
+		runner: runner,
+		wake:   make(chan struct{}, 1),
+	}
+}
+
+// Start requeues jobs interrupted by a previous shutdown and runs the
+// worker loop until the context is cancelled.
+func (m *Manager) Start(ctx context.Context) error {
+	if _, err := m.Recover(ctx); err != nil {
+		return err
+	}
+
+	go m.work(ctx)
+	return nil
+}
+
+// Recover requeues jobs that were running when the previous process
+// stopped, returning how many were requeued.
+func (m *Manager) Recover(ctx context.Context) (int, error) {
+	running, err := m.store.ListJobs(ctx, storage.JobStateRunning, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list interrupted jobs: %w", err)
+	}
+
+	for _, job := range running {
+		job.State = storage.JobStateQueued
+		job.StartedAt = nil
+		if err := m.store.SaveJob(ctx, job); err != nil {
+			return 0, fmt.Errorf("failed to requeue job %s: %w", job.ID, err)
+		}
+	}
+
+	return len(running), nil
+}
+
+// Enqueue persists a new queued job and signals the worker. The job's
+// ID, state, and creation time are assigned here.
+func (m *Manager) Enqueue(ctx context.Context, job *storage.Job) error {
+	job.ID = m.nextID()
+	job.State = storage.JobStateQueued
+	job.CreatedAt = time.Now()
+
+	if err := m.store.SaveJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to enqueue job: %w", err)
+	}
+
+	select {
+	case m.wake <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Get retrieves a job by ID, or nil if it does not exist.
+func (m *Manager) Get(ctx context.Context, id string) (*storage.Job, error) {
+	return m.store.GetJob(ctx, id)
+}
+
+// List returns jobs, newest first, optionally filtered by state.
+func (m *Manager) List(ctx context.Context, state storage.JobState, limit int) ([]*storage.Job, error) {
+	return m.store.ListJobs(ctx, state, limit)
+}
+
+// Cancel marks a queued job as cancelled. Running jobs cannot be
+// cancelled; their runner owns them until they finish.
+func (m *Manager) Cancel(ctx context.Context, id string) error {
+	job, err := m.store.GetJob(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return fmt.Errorf("job %s not found", id)
+	}
+	if job.State != storage.JobStateQueued {
+		return fmt.Errorf("job %s is %s, only queued jobs can be cancelled", id, job.State)
+	}
+
+	now := time.Now()
+	job.State = storage.JobStateCancelled
+	job.FinishedAt = &now
+	return m.store.SaveJob(ctx, job)
+}
+
+// Wait polls until the job reaches a terminal state or the context is
+// cancelled, returning the final job record.
+func (m *Manager) Wait(ctx context.Context, id string) (*storage.Job, error) {
+	return Wait(ctx, m.store, id)
+}
+
+// Wait polls the store until the job reaches a terminal state or the
+// context is cancelled. It is usable without a manager, e.g. by a CLI
+// attaching to a job the helper is running.
+func Wait(ctx context.Context, store storage.Store, id string) (*storage.Job, error) {
+	ticker := time.NewTicker(250 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		job, err := store.GetJob(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if job == nil {
+			return nil, fmt.Errorf("job %s not found", id)
+		}
+		if job.State.IsTerminal() {
+			return job, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Track runs an operation synchronously while persisting its lifecycle
+// as a job, for callers that execute work in-process rather than through
+// a queue. The finished job is returned alongside the operation's error.
+func Track(ctx context.Context, store storage.Store, job *storage.Job, run func(ctx context.Context) (string, error)) (*storage.Job, error) {
+	now := time.Now()
+	job.ID = newID(&now)
+	job.State = storage.JobStateRunning
+	job.CreatedAt = now
+	job.StartedAt = &now
+
+	if err := store.SaveJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	output, runErr := run(ctx)
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Output = output
+	if runErr != nil {
+		job.State = storage.JobStateFailed
+		job.Error = runErr.Error()
+	} else {
+		job.State = storage.JobStateSucceeded
+	}
+
+	if err := store.SaveJob(ctx, job); err != nil {
+		return job, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	return job, runErr
+}
+
+// work runs queued jobs oldest-first until the context is cancelled.
+func (m *Manager) work(ctx context.Context) {
+	for {
+		m.runQueued(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.wake:
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// runQueued drains the queue, running each job to a terminal state.
+func (m *Manager) runQueued(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		queued, err := m.store.ListJobs(ctx, storage.JobStateQueued, 0)
+		if err != nil || len(queued) == 0 {
+			return
+		}
+
+		// ListJobs returns newest first; run the oldest
+		job := queued[len(queued)-1]
+		if !m.runJob(ctx, job) {
+			// Couldn't transition the job; back off until the next pass
+			// instead of spinning on it
+			return
+		}
+	}
+}
+
+// runJob transitions one job through running to a terminal state,
+// reporting whether the transition out of the queue was recorded.
+func (m *Manager) runJob(ctx context.Context, job *storage.Job) bool {
+	now := time.Now()
+	job.State = storage.JobStateRunning
+	job.StartedAt = &now
+	if err := m.store.SaveJob(ctx, job); err != nil {
+		return false
+	}
+
+	output, runErr := m.runner(ctx, job)
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Output = output
+	if runErr != nil {
+		job.State = storage.JobStateFailed
+		job.Error = runErr.Error()
+	} else {
+		job.State = storage.JobStateSucceeded
+	}
+
+	// Best effort; the next recovery pass requeues the job if this fails
+	_ = m.store.SaveJob(ctx, job)
+	return true
+}
+
+// nextID generates a unique job ID, disambiguating same-instant jobs
+// with a sequence number.
+func (m *Manager) nextID() string {
+	m.mu.Lock()
+	m.seq++
+	seq := m.seq
+	m.mu.Unlock()
+
+	return fmt.Sprintf("%s-%d", time.Now().Format("20060102150405"), seq)
+}
+
+// newID generates a job ID for untracked, in-process jobs.
+func newID(t *time.Time) string {
+	return t.Format("20060102150405.000000000")
+}
@@ -0,0 +1,186 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+func TestManagerRunsQueuedJobs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store := agentmgrtest.NewFakeStore()
+	ran := make(chan string, 2)
+	m := NewManager(store, func(ctx context.Context, job *storage.Job) (string, error) {
+		ran <- job.AgentID
+		if job.AgentID == "bad" {
+			return "", fmt.Errorf("install failed")
+		}
+		return "done", nil
+	})
+
+	if err := m.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	good := &storage.Job{Type: TypeInstall, AgentID: "claude-code", Method: "npm"}
+	if err := m.Enqueue(ctx, good); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	bad := &storage.Job{Type: TypeInstall, AgentID: "bad", Method: "npm"}
+	if err := m.Enqueue(ctx, bad); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+
+	if good.ID == "" || good.ID == bad.ID {
+		t.Fatalf("expected distinct job IDs, got %q and %q", good.ID, bad.ID)
+	}
+
+	waitCtx, waitCancel := context.WithTimeout(ctx, 5*time.Second)
+	defer waitCancel()
+
+	final, err := m.Wait(waitCtx, good.ID)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if final.State != storage.JobStateSucceeded || final.Output != "done" {
+		t.Errorf("job = %+v, want succeeded with output", final)
+	}
+	if final.StartedAt == nil || final.FinishedAt == nil {
+		t.Error("expected started and finished timestamps")
+	}
+
+	final, err = m.Wait(waitCtx, bad.ID)
+	if err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if final.State != storage.JobStateFailed || final.Error != "install failed" {
+		t.Errorf("job = %+v, want failed", final)
+	}
+
+	if len(ran) != 2 {
+		t.Errorf("runner invocations = %d, want 2", len(ran))
+	}
+}
+
+func TestRecoverRequeuesRunningJobs(t *testing.T) {
+	ctx := context.Background()
+	store := agentmgrtest.NewFakeStore()
+
+	started := time.Now()
+	interrupted := &storage.Job{
+		ID:        "stale-1",
+		Type:      TypeUpdate,
+		AgentID:   "claude-code",
+		State:     storage.JobStateRunning,
+		CreatedAt: started,
+		StartedAt: &started,
+	}
+	finished := &storage.Job{
+		ID:         "done-1",
+		Type:       TypeUpdate,
+		AgentID:    "aider",
+		State:      storage.JobStateSucceeded,
+		CreatedAt:  started,
+		FinishedAt: &started,
+	}
+	for _, job := range []*storage.Job{interrupted, finished} {
+		if err := store.SaveJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := NewManager(store, nil)
+	requeued, err := m.Recover(ctx)
+	if err != nil {
+		t.Fatalf("Recover: %v", err)
+	}
+	if requeued != 1 {
+		t.Errorf("requeued = %d, want 1", requeued)
+	}
+
+	job, _ := store.GetJob(ctx, "stale-1")
+	if job.State != storage.JobStateQueued || job.StartedAt != nil {
+		t.Errorf("job = %+v, want requeued", job)
+	}
+
+	job, _ = store.GetJob(ctx, "done-1")
+	if job.State != storage.JobStateSucceeded {
+		t.Errorf("finished job state = %s, should be untouched", job.State)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx := context.Background()
+	store := agentmgrtest.NewFakeStore()
+	m := NewManager(store, nil)
+
+	queued := &storage.Job{ID: "q-1", Type: TypeInstall, AgentID: "claude-code", State: storage.JobStateQueued, CreatedAt: time.Now()}
+	if err := store.SaveJob(ctx, queued); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m.Cancel(ctx, "q-1"); err != nil {
+		t.Fatalf("Cancel: %v", err)
+	}
+	job, _ := store.GetJob(ctx, "q-1")
+	if job.State != storage.JobStateCancelled || job.FinishedAt == nil {
+		t.Errorf("job = %+v, want cancelled", job)
+	}
+
+	if err := m.Cancel(ctx, "q-1"); err == nil {
+		t.Error("expected error cancelling a terminal job")
+	}
+	if err := m.Cancel(ctx, "missing"); err == nil {
+		t.Error("expected error cancelling a missing job")
+	}
+}
+
+func TestTrack(t *testing.T) {
+	ctx := context.Background()
+	store := agentmgrtest.NewFakeStore()
+
+	job, err := Track(ctx, store, &storage.Job{Type: TypeUninstall, AgentID: "claude-code"}, func(ctx context.Context) (string, error) {
+		return "removed", nil
+	})
+	if err != nil {
+		t.Fatalf("Track: %v", err)
+	}
+	if job.State != storage.JobStateSucceeded || job.Output != "removed" {
+		t.Errorf("job = %+v, want succeeded", job)
+	}
+
+	saved, _ := store.GetJob(ctx, job.ID)
+	if saved == nil || saved.State != storage.JobStateSucceeded {
+		t.Errorf("persisted job = %+v", saved)
+	}
+
+	job, err = Track(ctx, store, &storage.Job{Type: TypeUninstall, AgentID: "claude-code"}, func(ctx context.Context) (string, error) {
+		return "", fmt.Errorf("boom")
+	})
+	if err == nil {
+		t.Fatal("expected operation error to propagate")
+	}
+	if job.State != storage.JobStateFailed || job.Error != "boom" {
+		t.Errorf("job = %+v, want failed", job)
+	}
+}
+
+func TestJobStateIsTerminal(t *testing.T) {
+	for state, want := range map[storage.JobState]bool{
+		storage.JobStateQueued:    false,
+		storage.JobStateRunning:   false,
+		storage.JobStateSucceeded: true,
+		storage.JobStateFailed:    true,
+		storage.JobStateCancelled: true,
+	} {
+		if got := state.IsTerminal(); got != want {
+			t.Errorf("IsTerminal(%s) = %v, want %v", state, got, want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Manager reads and safely edits MCP server configurations.
+type Manager struct {
+	locations []ConfigLocation
+}
+
+// NewManager creates a new MCP configuration manager using the known
+// config locations for the current platform.
+func NewManager() *Manager {
+	return &Manager{locations: KnownLocations()}
+}
+
+// NewManagerWithLocations creates a manager with custom config locations.
+// Primarily useful for testing.
+func NewManagerWithLocations(locations []ConfigLocation) *Manager {
+	return &Manager{locations: locations}
+}
+
+// Locations returns all configured locations.
+func (m *Manager) Locations() []ConfigLocation {
+	return m.locations
+}
+
+// ListServers returns the MCP servers configured in the given location.
+func (m *Manager) ListServers(loc ConfigLocation) ([]Server, error) {
+	doc, err := m.readConfig(loc)
+	if err != nil {
+		return nil, err
+	}
+
+	serversRaw, ok := doc[loc.serversKey()]
+	if !ok {
+		return nil, nil
+	}
+
+	var serverMap map[string]serverEntry
+	if err := json.Unmarshal(serversRaw, &serverMap); err != nil {
+		return nil, fmt.Errorf("failed to parse %s in %s: %w", loc.serversKey(), loc.Path, err)
+	}
+
+	servers := make([]Server, 0, len(serverMap))
+	for name, entry := range serverMap {
+		servers = append(servers, Server{
+			Name:     name,
+			Command:  entry.Command,
+			Args:     entry.Args,
+			Env:      entry.Env,
+			Disabled: entry.Disabled,
+		})
+	}
+
+	sort.Slice(servers, func(i, j int) bool {
+		return servers[i].Name < servers[j].Name
+	})
+
+	return servers, nil
+}
+
+// ListAll returns servers from every location that exists on disk,
+// keyed by the location's agent ID.
+func (m *Manager) ListAll() (map[string][]Server, error) {
+	result := make(map[string][]Server)
+	for _, loc := range m.locations {
+		if !loc.Exists() {
+			continue
+		}
+		servers, err := m.ListServers(loc)
+		if err != nil {
+			continue // Unparseable configs are skipped, not fatal
+		}
+		result[loc.AgentID] = servers
+	}
+	return result, nil
+}
+
+// AddServer adds a server entry to the given location's config.
+// A backup of the original file is written before modification.
+func (m *Manager) AddServer(loc ConfigLocation, server Server) error {
+	if server.Name == "" {
+		return fmt.Errorf("server name is required")
+	}
+	if server.Command == "" {
+		return fmt.Errorf("server command is required")
+	}
+
+	return m.modifyServers(loc, func(servers map[string]serverEntry) error {
+		if _, exists := servers[server.Name]; exists {
+			return fmt.Errorf("server %q already configured in %s", server.Name, loc.Path)
+		}
+		servers[server.Name] = serverEntry{
+			Command:  server.Command,
+			Args:     server.Args,
+			Env:      server.Env,
+			Disabled: server.Disabled,
+		}
+		return nil
+	})
+}
+
+// RemoveServer removes a server entry from the given location's config.
+func (m *Manager) RemoveServer(loc ConfigLocation, name string) error {
+	return m.modifyServers(loc, func(servers map[string]serverEntry) error {
+		if _, exists := servers[name]; !exists {
+			return fmt.Errorf("server %q not found in %s", name, loc.Path)
+		}
+		delete(servers, name)
+		return nil
+	})
+}
+
+// ToggleServer enables or disables a server entry without removing it.
+func (m *Manager) ToggleServer(loc ConfigLocation, name string, disabled bool) error {
+	return m.modifyServers(loc, func(servers map[string]serverEntry) error {
+		entry, exists := servers[name]
+		if !exists {
+			return fmt.Errorf("server %q not found in %s", name, loc.Path)
+		}
+		entry.Disabled = disabled
+		servers[name] = entry
+		return nil
+	})
+}
+
+// serverEntry is the on-disk shape of a server in the config JSON.
+// The name is the map key, so it is not part of the entry itself.
+type serverEntry struct {
+	Command  string            `json:"command"`
+	Args     []string          `json:"args,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	Disabled bool              `json:"disabled,omitempty"`
+}
+
+// serversKey returns the JSON key holding servers, defaulting to "mcpServers".
+func (l ConfigLocation) serversKey() string {
+	if l.ServersKey != "" {
+		return l.ServersKey
+	}
+	return "mcpServers"
+}
+
+// readConfig reads the config file as a generic JSON document, preserving
+// keys that agentmgr does not manage.
+func (m *Manager) readConfig(loc ConfigLocation) (map[string]json.RawMessage, error) {
+	data, err := os.ReadFile(loc.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]json.RawMessage{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", loc.Path, err)
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", loc.Path, err)
+	}
+	return doc, nil
+}
+
+// modifyServers applies a mutation to the server map and writes the config
+// back atomically, backing up the original first.
+func (m *Manager) modifyServers(loc ConfigLocation, fn func(map[string]serverEntry) error) error {
+	doc, err := m.readConfig(loc)
+	if err != nil {
+		return err
+	}
+
+	servers := make(map[string]serverEntry)
+	if raw, ok := doc[loc.serversKey()]; ok {
+		if err := json.Unmarshal(raw, &servers); err != nil {
+			return fmt.Errorf("failed to parse %s in %s: %w", loc.serversKey(), loc.Path, err)
+		}
+	}
+
+	if err := fn(servers); err != nil {
+		return err
+	}
+
+	serversRaw, err := json.Marshal(servers)
+	if err != nil {
+		return err
+	}
+	doc[loc.serversKey()] = serversRaw
+
+	output, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := m.backup(loc); err != nil {
+		return err
+	}
+
+	return writeFileAtomic(loc.Path, append(output, '\n'))
+}
+
+// backup copies the existing config file to a timestamped backup.
+func (m *Manager) backup(loc ConfigLocation) error {
+	data, err := os.ReadFile(loc.Path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // Nothing to back up
+		}
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.bak-%s", loc.Path, time.Now().Format("20060102150405"))
+	return os.WriteFile(backupPath, data, 0o600)
+}
+
+// writeFileAtomic writes data to path via a temp file and rename.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
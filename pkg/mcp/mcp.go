@@ -0,0 +1,107 @@
+// Package mcp provides detection and management of MCP (Model Context
+// Protocol) server configurations used by AI agents.
+package mcp
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// Server represents a configured MCP server entry.
+type Server struct {
+	Name     string            `json:"name"`
+	Command  string            `json:"command"`
+	Args     []string          `json:"args,omitempty"`
+	Env      map[string]string `json:"env,omitempty"`
+	Disabled bool              `json:"disabled,omitempty"`
+}
+
+// ConfigLocation describes where an agent stores its MCP configuration.
+type ConfigLocation struct {
+	// AgentID is the catalog agent ID this config belongs to, if known.
+	AgentID string `json:"agent_id"`
+
+	// Name is a human-friendly name for the consuming application.
+	Name string `json:"name"`
+
+	// Path is the absolute path to the JSON config file.
+	Path string `json:"path"`
+
+	// ServersKey is the JSON key holding the server map (usually "mcpServers").
+	ServersKey string `json:"servers_key"`
+}
+
+// Exists returns true if the config file exists on disk.
+func (l ConfigLocation) Exists() bool {
+	info, err := os.Stat(l.Path)
+	return err == nil && !info.IsDir()
+}
+
+// KnownLocations returns the MCP config locations for known agents on the
+// current platform. Only conventions are returned; callers should check
+// Exists before reading.
+func KnownLocations() []ConfigLocation {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	var claudeDesktop string
+	switch runtime.GOOS {
+	case "darwin":
+		claudeDesktop = filepath.Join(home, "Library", "Application Support", "Claude", "claude_desktop_config.json")
+	case "windows":
+		appData := os.Getenv("APPDATA")
+		if appData == "" {
+			appData = filepath.Join(home, "AppData", "Roaming")
+		}
+		claudeDesktop = filepath.Join(appData, "Claude", "claude_desktop_config.json")
+	default:
+		claudeDesktop = filepath.Join(home, ".config", "Claude", "claude_desktop_config.json")
+	}
+
+	return []ConfigLocation{
+		{
+			AgentID:    "claude-desktop",
+			Name:       "Claude Desktop",
+			Path:       claudeDesktop,
+			ServersKey: "mcpServers",
+		},
+		{
+			AgentID:    "claude-code",
+			Name:       "Claude Code",
+			Path:       filepath.Join(home, ".claude.json"),
+			ServersKey: "mcpServers",
+		},
+		{
+			AgentID:    "cursor",
+			Name:       "Cursor",
+			Path:       filepath.Join(home, ".cursor", "mcp.json"),
+			ServersKey: "mcpServers",
+		},
+		{
+			AgentID:    "windsurf",
+			Name:       "Windsurf",
+			Path:       filepath.Join(home, ".codeium", "windsurf", "mcp_config.json"),
+			ServersKey: "mcpServers",
+		},
+		{
+			AgentID:    "continue",
+			Name:       "Continue",
+			Path:       filepath.Join(home, ".continue", "config.json"),
+			ServersKey: "mcpServers",
+		},
+	}
+}
+
+// DetectConfigs returns the known config locations that exist on disk.
+func DetectConfigs() []ConfigLocation {
+	var found []ConfigLocation
+	for _, loc := range KnownLocations() {
+		if loc.Exists() {
+			found = append(found, loc)
+		}
+	}
+	return found
+}
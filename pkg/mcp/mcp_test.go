@@ -0,0 +1,191 @@
+package mcp
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testLocation(t *testing.T) ConfigLocation {
+	t.Helper()
+	dir := t.TempDir()
+	return ConfigLocation{
+		AgentID:    "test-agent",
+		Name:       "Test Agent",
+		Path:       filepath.Join(dir, "mcp.json"),
+		ServersKey: "mcpServers",
+	}
+}
+
+func TestAddAndListServers(t *testing.T) {
+	loc := testLocation(t)
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+
+	server := Server{
+		Name:    "filesystem",
+		Command: "npx",
+		Args:    []string{"-y", "@modelcontextprotocol/server-filesystem", "/tmp"},
+	}
+
+	if err := m.AddServer(loc, server); err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	servers, err := m.ListServers(loc)
+	if err != nil {
+		t.Fatalf("ListServers failed: %v", err)
+	}
+	if len(servers) != 1 {
+		t.Fatalf("expected 1 server, got %d", len(servers))
+	}
+	if servers[0].Name != "filesystem" {
+		t.Errorf("expected name filesystem, got %s", servers[0].Name)
+	}
+	if servers[0].Command != "npx" {
+		t.Errorf("expected command npx, got %s", servers[0].Command)
+	}
+}
+
+func TestAddDuplicateServer(t *testing.T) {
+	loc := testLocation(t)
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+
+	server := Server{Name: "dup", Command: "cmd"}
+	if err := m.AddServer(loc, server); err != nil {
+		t.Fatalf("first AddServer failed: %v", err)
+	}
+	if err := m.AddServer(loc, server); err == nil {
+		t.Error("expected error adding duplicate server")
+	}
+}
+
+func TestAddServerValidation(t *testing.T) {
+	loc := testLocation(t)
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+
+	if err := m.AddServer(loc, Server{Command: "cmd"}); err == nil {
+		t.Error("expected error for missing name")
+	}
+	if err := m.AddServer(loc, Server{Name: "x"}); err == nil {
+		t.Error("expected error for missing command")
+	}
+}
+
+func TestRemoveServer(t *testing.T) {
+	loc := testLocation(t)
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+
+	if err := m.AddServer(loc, Server{Name: "gone", Command: "cmd"}); err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	if err := m.RemoveServer(loc, "gone"); err != nil {
+		t.Fatalf("RemoveServer failed: %v", err)
+	}
+
+	servers, err := m.ListServers(loc)
+	if err != nil {
+		t.Fatalf("ListServers failed: %v", err)
+	}
+	if len(servers) != 0 {
+		t.Errorf("expected 0 servers, got %d", len(servers))
+	}
+
+	if err := m.RemoveServer(loc, "missing"); err == nil {
+		t.Error("expected error removing missing server")
+	}
+}
+
+func TestToggleServer(t *testing.T) {
+	loc := testLocation(t)
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+
+	if err := m.AddServer(loc, Server{Name: "srv", Command: "cmd"}); err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+	if err := m.ToggleServer(loc, "srv", true); err != nil {
+		t.Fatalf("ToggleServer failed: %v", err)
+	}
+
+	servers, _ := m.ListServers(loc)
+	if len(servers) != 1 || !servers[0].Disabled {
+		t.Error("expected server to be disabled")
+	}
+
+	if err := m.ToggleServer(loc, "srv", false); err != nil {
+		t.Fatalf("ToggleServer failed: %v", err)
+	}
+	servers, _ = m.ListServers(loc)
+	if len(servers) != 1 || servers[0].Disabled {
+		t.Error("expected server to be enabled")
+	}
+}
+
+func TestModifyPreservesUnmanagedKeys(t *testing.T) {
+	loc := testLocation(t)
+	original := `{"theme": "dark", "mcpServers": {}}`
+	if err := os.WriteFile(loc.Path, []byte(original), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+	if err := m.AddServer(loc, Server{Name: "srv", Command: "cmd"}); err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	data, err := os.ReadFile(loc.Path)
+	if err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("config is not valid JSON: %v", err)
+	}
+	if _, ok := doc["theme"]; !ok {
+		t.Error("expected unmanaged key to be preserved")
+	}
+}
+
+func TestModifyCreatesBackup(t *testing.T) {
+	loc := testLocation(t)
+	if err := os.WriteFile(loc.Path, []byte(`{"mcpServers": {}}`), 0o600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	m := NewManagerWithLocations([]ConfigLocation{loc})
+	if err := m.AddServer(loc, Server{Name: "srv", Command: "cmd"}); err != nil {
+		t.Fatalf("AddServer failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(filepath.Dir(loc.Path))
+	if err != nil {
+		t.Fatalf("failed to read dir: %v", err)
+	}
+
+	foundBackup := false
+	for _, e := range entries {
+		if strings.Contains(e.Name(), ".bak-") {
+			foundBackup = true
+		}
+	}
+	if !foundBackup {
+		t.Error("expected a backup file to be created")
+	}
+}
+
+func TestKnownLocations(t *testing.T) {
+	locations := KnownLocations()
+	if len(locations) == 0 {
+		t.Fatal("expected at least one known location")
+	}
+	for _, loc := range locations {
+		if loc.AgentID == "" {
+			t.Errorf("location %s has no agent ID", loc.Path)
+		}
+		if loc.Path == "" {
+			t.Errorf("location %s has no path", loc.AgentID)
+		}
+	}
+}
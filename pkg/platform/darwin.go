@@ -4,6 +4,7 @@ package platform
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
@@ -66,18 +67,36 @@ func (d *darwinPlatform) EnableAutoStart(ctx context.Context) error {
 
 	// Create directory if needed
 	if err := os.MkdirAll(filepath.Dir(plistPath), 0755); err != nil {
-		return fmt.Errorf("failed to create LaunchAgents directory: %w", err)
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartLaunchAgent,
+			Path:        filepath.Dir(plistPath),
+			Remediation: "Check that ~/Library/LaunchAgents exists and is writable.",
+			Err:         err,
+		}
 	}
 
 	// Write plist file
 	if err := os.WriteFile(plistPath, []byte(plistContent), 0644); err != nil {
-		return fmt.Errorf("failed to write plist: %w", err)
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartLaunchAgent,
+			Path:        plistPath,
+			Remediation: "Check permissions on ~/Library/LaunchAgents.",
+			Err:         err,
+		}
 	}
 
 	// Load the agent
 	cmd := exec.CommandContext(ctx, "launchctl", "load", plistPath)
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed to load launch agent: %w", err)
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartLaunchAgent,
+			Path:        plistPath,
+			Remediation: "Run 'launchctl load " + plistPath + "' in a terminal to see the launchd error.",
+			Err:         err,
+		}
 	}
 
 	return nil
@@ -91,12 +110,29 @@ func (d *darwinPlatform) DisableAutoStart(ctx context.Context) error {
 
 	// Remove plist file
 	if err := os.Remove(plistPath); err != nil && !os.IsNotExist(err) {
-		return fmt.Errorf("failed to remove plist: %w", err)
+		return &AutoStartError{
+			Op:          "disable",
+			Method:      AutoStartLaunchAgent,
+			Path:        plistPath,
+			Remediation: "Delete " + plistPath + " manually.",
+			Err:         err,
+		}
 	}
 
 	return nil
 }
 
+func (d *darwinPlatform) GetAutoStartMethod(ctx context.Context) (AutoStartMethod, error) {
+	enabled, err := d.IsAutoStartEnabled(ctx)
+	if err != nil {
+		return AutoStartNone, err
+	}
+	if enabled {
+		return AutoStartLaunchAgent, nil
+	}
+	return AutoStartNone, nil
+}
+
 func (d *darwinPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) {
 	plistPath := d.getLaunchAgentPath()
 	_, err := os.Stat(plistPath)
@@ -140,6 +176,9 @@ func (d *darwinPlatform) generateLaunchAgentPlist() string {
 func (d *darwinPlatform) FindExecutable(name string) (string, error) {
 	path, err := exec.LookPath(name)
 	if err != nil {
+		if shellPath, ok := lookInLoginShellPath(name); ok {
+			return shellPath, nil
+		}
 		return "", fmt.Errorf("executable %q not found: %w", name, err)
 	}
 	return path, nil
@@ -170,7 +209,7 @@ func (d *darwinPlatform) IsExecutableInPath(name string) bool {
 
 func (d *darwinPlatform) GetPathDirs() []string {
 	pathEnv := os.Getenv("PATH")
-	return strings.Split(pathEnv, ":")
+	return appendLoginShellDirs(strings.Split(pathEnv, ":"))
 }
 
 func (d *darwinPlatform) GetShell() string {
@@ -192,6 +231,36 @@ func (d *darwinPlatform) ShowNotification(title, message string) error {
 	return exec.Command("osascript", "-e", script).Run()
 }
 
+// IsDoNotDisturbEnabled reports whether a Focus mode is active by checking
+// the assertion records Notification Center maintains (Monterey and later).
+func (d *darwinPlatform) IsDoNotDisturbEnabled() bool {
+	data, err := os.ReadFile(filepath.Join(d.homeDir(), "Library", "DoNotDisturb", "DB", "Assertions.json"))
+	if err != nil {
+		return false
+	}
+
+	var assertions struct {
+		Data []struct {
+			StoreAssertionRecords []json.RawMessage `json:"storeAssertionRecords"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &assertions); err != nil {
+		return false
+	}
+
+	for _, entry := range assertions.Data {
+		if len(entry.StoreAssertionRecords) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// IsElevated reports whether the process runs as root.
+func (d *darwinPlatform) IsElevated() bool {
+	return os.Geteuid() == 0
+}
+
 func (d *darwinPlatform) ShowChangelogDialog(agentName, fromVer, toVer, changelog string) DialogResult {
 	// Use osascript to show a dialog
 	script := fmt.Sprintf(`
@@ -93,6 +93,20 @@ func (l *linuxPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error) {
 	return l.isXDGEnabled()
 }
 
+func (l *linuxPlatform) GetAutoStartMethod(ctx context.Context) (AutoStartMethod, error) {
+	if l.hasSystemd() {
+		if enabled, _ := l.isSystemdEnabled(ctx); enabled {
+			return AutoStartSystemd, nil
+		}
+	}
+	if enabled, err := l.isXDGEnabled(); err != nil {
+		return AutoStartNone, err
+	} else if enabled {
+		return AutoStartXDG, nil
+	}
+	return AutoStartNone, nil
+}
+
 func (l *linuxPlatform) hasSystemd() bool {
 	_, err := exec.LookPath("systemctl")
 	return err == nil
@@ -101,7 +115,13 @@ func (l *linuxPlatform) hasSystemd() bool {
 func (l *linuxPlatform) enableSystemdAutoStart(ctx context.Context) error {
 	serviceDir := l.getSystemdUserDir()
 	if err := os.MkdirAll(serviceDir, 0755); err != nil {
-		return err
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartSystemd,
+			Path:        serviceDir,
+			Remediation: "Check that " + serviceDir + " exists and is writable.",
+			Err:         err,
+		}
 	}
 
 	servicePath := filepath.Join(serviceDir, "agentmgr-helper.service")
@@ -120,15 +140,36 @@ WantedBy=default.target
 `
 
 	if err := os.WriteFile(servicePath, []byte(serviceContent), 0644); err != nil {
-		return err
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartSystemd,
+			Path:        servicePath,
+			Remediation: "Check permissions on " + serviceDir + ".",
+			Err:         err,
+		}
 	}
 
 	// Reload and enable
 	exec.CommandContext(ctx, "systemctl", "--user", "daemon-reload").Run()
 	if err := exec.CommandContext(ctx, "systemctl", "--user", "enable", "agentmgr-helper.service").Run(); err != nil {
-		return err
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartSystemd,
+			Path:        servicePath,
+			Remediation: "Run 'systemctl --user enable agentmgr-helper.service' to see the systemd error.",
+			Err:         err,
+		}
 	}
-	return exec.CommandContext(ctx, "systemctl", "--user", "start", "agentmgr-helper.service").Run()
+	if err := exec.CommandContext(ctx, "systemctl", "--user", "start", "agentmgr-helper.service").Run(); err != nil {
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartSystemd,
+			Path:        servicePath,
+			Remediation: "Run 'systemctl --user status agentmgr-helper.service' to see why it failed to start.",
+			Err:         err,
+		}
+	}
+	return nil
 }
 
 func (l *linuxPlatform) disableSystemdAutoStart(ctx context.Context) error {
@@ -157,7 +198,13 @@ func (l *linuxPlatform) getSystemdUserDir() string {
 func (l *linuxPlatform) enableXDGAutoStart() error {
 	autostartDir := l.getXDGAutostartDir()
 	if err := os.MkdirAll(autostartDir, 0755); err != nil {
-		return err
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartXDG,
+			Path:        autostartDir,
+			Remediation: "Check that " + autostartDir + " exists and is writable.",
+			Err:         err,
+		}
 	}
 
 	desktopEntry := `[Desktop Entry]
@@ -170,13 +217,28 @@ X-GNOME-Autostart-enabled=true
 `
 
 	desktopPath := filepath.Join(autostartDir, "agentmgr-helper.desktop")
-	return os.WriteFile(desktopPath, []byte(desktopEntry), 0644)
+	if err := os.WriteFile(desktopPath, []byte(desktopEntry), 0644); err != nil {
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartXDG,
+			Path:        desktopPath,
+			Remediation: "Check permissions on " + autostartDir + ".",
+			Err:         err,
+		}
+	}
+	return nil
 }
 
 func (l *linuxPlatform) disableXDGAutoStart() error {
 	desktopPath := filepath.Join(l.getXDGAutostartDir(), "agentmgr-helper.desktop")
 	if err := os.Remove(desktopPath); err != nil && !os.IsNotExist(err) {
-		return err
+		return &AutoStartError{
+			Op:          "disable",
+			Method:      AutoStartXDG,
+			Path:        desktopPath,
+			Remediation: "Delete " + desktopPath + " manually.",
+			Err:         err,
+		}
 	}
 	return nil
 }
@@ -201,6 +263,9 @@ func (l *linuxPlatform) getXDGAutostartDir() string {
 func (l *linuxPlatform) FindExecutable(name string) (string, error) {
 	path, err := exec.LookPath(name)
 	if err != nil {
+		if shellPath, ok := lookInLoginShellPath(name); ok {
+			return shellPath, nil
+		}
 		return "", fmt.Errorf("executable %q not found: %w", name, err)
 	}
 	return path, nil
@@ -231,7 +296,7 @@ func (l *linuxPlatform) IsExecutableInPath(name string) bool {
 
 func (l *linuxPlatform) GetPathDirs() []string {
 	pathEnv := os.Getenv("PATH")
-	return strings.Split(pathEnv, ":")
+	return appendLoginShellDirs(strings.Split(pathEnv, ":"))
 }
 
 func (l *linuxPlatform) GetShell() string {
@@ -258,6 +323,25 @@ func (l *linuxPlatform) ShowNotification(title, message string) error {
 	return fmt.Errorf("no notification system available")
 }
 
+// IsDoNotDisturbEnabled reports whether the desktop's do-not-disturb mode
+// is active. GNOME exposes this through the show-banners setting; other
+// desktops are reported as not in do-not-disturb.
+func (l *linuxPlatform) IsDoNotDisturbEnabled() bool {
+	if _, err := exec.LookPath("gsettings"); err != nil {
+		return false
+	}
+	out, err := exec.Command("gsettings", "get", "org.gnome.desktop.notifications", "show-banners").Output()
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(out)) == "false"
+}
+
+// IsElevated reports whether the process runs as root.
+func (l *linuxPlatform) IsElevated() bool {
+	return os.Geteuid() == 0
+}
+
 func (l *linuxPlatform) ShowChangelogDialog(agentName, fromVer, toVer, changelog string) DialogResult {
 	// Try zenity first
 	if _, err := exec.LookPath("zenity"); err == nil {
@@ -0,0 +1,103 @@
+package platform
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Detection strategies resolve executables through the process PATH,
+// which misses tools only added to PATH in interactive shell rc files
+// (pyenv shims, nvm-managed Node versions). When enabled, the login
+// shell is probed once for its PATH and the extra directories augment
+// GetPathDirs. The probe runs the user's shell, so it is opt-in and
+// should stay off in untrusted environments.
+var (
+	loginShellMu      sync.Mutex
+	loginShellEnabled bool
+
+	loginShellOnce sync.Once
+	loginShellDirs []string
+)
+
+// SetLoginShellPath enables or disables the login-shell PATH probe.
+// It applies process-wide; callers set it once from the detection config.
+func SetLoginShellPath(enabled bool) {
+	loginShellMu.Lock()
+	defer loginShellMu.Unlock()
+	loginShellEnabled = enabled
+}
+
+// loginShellPathEnabled reports whether the probe is active.
+func loginShellPathEnabled() bool {
+	loginShellMu.Lock()
+	defer loginShellMu.Unlock()
+	return loginShellEnabled
+}
+
+// appendLoginShellDirs augments PATH directories with those reported by
+// the login shell, skipping ones already present. It returns dirs
+// unchanged when the probe is disabled.
+func appendLoginShellDirs(dirs []string) []string {
+	if !loginShellPathEnabled() {
+		return dirs
+	}
+
+	seen := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		seen[dir] = true
+	}
+	for _, dir := range probeLoginShellPath() {
+		if !seen[dir] {
+			dirs = append(dirs, dir)
+			seen[dir] = true
+		}
+	}
+	return dirs
+}
+
+// lookInLoginShellPath searches the login-shell PATH entries for an
+// executable, as a fallback when exec.LookPath misses.
+func lookInLoginShellPath(name string) (string, bool) {
+	if !loginShellPathEnabled() {
+		return "", false
+	}
+	for _, dir := range probeLoginShellPath() {
+		fullPath := filepath.Join(dir, name)
+		if info, err := os.Stat(fullPath); err == nil && !info.IsDir() && info.Mode()&0111 != 0 {
+			return fullPath, true
+		}
+	}
+	return "", false
+}
+
+// probeLoginShellPath runs the user's shell as a login shell once and
+// caches the PATH it reports. Failures cache an empty result rather
+// than re-running the shell on every detection pass.
+func probeLoginShellPath() []string {
+	loginShellOnce.Do(func() {
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		out, err := exec.CommandContext(ctx, shell, "-lc", "echo $PATH").Output()
+		if err != nil {
+			return
+		}
+
+		for _, dir := range strings.Split(strings.TrimSpace(string(out)), ":") {
+			if dir != "" {
+				loginShellDirs = append(loginShellDirs, dir)
+			}
+		}
+	})
+	return loginShellDirs
+}
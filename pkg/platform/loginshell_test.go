@@ -0,0 +1,63 @@
+package platform
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestAppendLoginShellDirsDisabled(t *testing.T) {
+	SetLoginShellPath(false)
+
+	dirs := appendLoginShellDirs([]string{"/usr/bin", "/usr/local/bin"})
+	if len(dirs) != 2 {
+		t.Errorf("dirs = %v, want unchanged when the probe is disabled", dirs)
+	}
+}
+
+func TestLoginShellPathProbe(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("login shell probe is not used on windows")
+	}
+
+	// Stub shell that reports one extra PATH entry
+	extraDir := t.TempDir()
+	shell := filepath.Join(t.TempDir(), "fakeshell")
+	script := fmt.Sprintf("#!/bin/sh\necho \"/usr/bin:%s\"\n", extraDir)
+	if err := os.WriteFile(shell, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("SHELL", shell)
+
+	SetLoginShellPath(true)
+	defer SetLoginShellPath(false)
+
+	// Reset the probe cache so this test's stub shell is consulted
+	loginShellOnce = sync.Once{}
+	loginShellDirs = nil
+	defer func() {
+		loginShellOnce = sync.Once{}
+		loginShellDirs = nil
+	}()
+
+	dirs := appendLoginShellDirs([]string{"/usr/bin"})
+	if len(dirs) != 2 || dirs[1] != extraDir {
+		t.Errorf("dirs = %v, want /usr/bin plus %s without duplicates", dirs, extraDir)
+	}
+
+	// An executable in the extra dir is found by the fallback lookup
+	tool := filepath.Join(extraDir, "pyenv-shimmed-tool")
+	if err := os.WriteFile(tool, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path, ok := lookInLoginShellPath("pyenv-shimmed-tool")
+	if !ok || path != tool {
+		t.Errorf("lookInLoginShellPath() = %q, %v, want %q", path, ok, tool)
+	}
+	if _, ok := lookInLoginShellPath("no-such-tool"); ok {
+		t.Error("lookInLoginShellPath() found a nonexistent tool")
+	}
+}
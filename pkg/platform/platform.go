@@ -39,6 +39,7 @@ type Platform interface {
 	EnableAutoStart(ctx context.Context) error
 	DisableAutoStart(ctx context.Context) error
 	IsAutoStartEnabled(ctx context.Context) (bool, error)
+	GetAutoStartMethod(ctx context.Context) (AutoStartMethod, error)
 
 	// Executables
 	FindExecutable(name string) (string, error)
@@ -50,13 +51,86 @@ type Platform interface {
 	GetShell() string
 	GetShellArg() string
 
+	// Privileges
+	// IsElevated reports whether the process runs with administrator or
+	// root privileges, as required for system-wide installations.
+	IsElevated() bool
+
 	// Notifications
 	ShowNotification(title, message string) error
+	// IsDoNotDisturbEnabled reports whether the OS do-not-disturb or
+	// Focus mode is currently active. Platforms that cannot determine
+	// the state report false.
+	IsDoNotDisturbEnabled() bool
 
 	// Dialogs
 	ShowChangelogDialog(agentName, fromVer, toVer, changelog string) DialogResult
 }
 
+// AutoStartMethod describes how auto-start was registered.
+type AutoStartMethod string
+
+const (
+	// AutoStartNone means auto-start is not registered.
+	AutoStartNone AutoStartMethod = "none"
+	// AutoStartLaunchAgent is a macOS LaunchAgent plist.
+	AutoStartLaunchAgent AutoStartMethod = "launch_agent"
+	// AutoStartSystemd is a systemd user service.
+	AutoStartSystemd AutoStartMethod = "systemd"
+	// AutoStartXDG is an XDG autostart .desktop entry.
+	AutoStartXDG AutoStartMethod = "xdg_autostart"
+	// AutoStartRegistry is a Windows registry Run key.
+	AutoStartRegistry AutoStartMethod = "registry"
+)
+
+// DisplayName returns a human-readable name for the method.
+func (m AutoStartMethod) DisplayName() string {
+	switch m {
+	case AutoStartLaunchAgent:
+		return "LaunchAgent"
+	case AutoStartSystemd:
+		return "systemd user service"
+	case AutoStartXDG:
+		return "XDG autostart entry"
+	case AutoStartRegistry:
+		return "registry run key"
+	case AutoStartNone:
+		return "not registered"
+	default:
+		return string(m)
+	}
+}
+
+// AutoStartError is a structured error from an auto-start operation,
+// carrying enough context for the UI to suggest a remediation.
+type AutoStartError struct {
+	// Op is the failed operation: "enable", "disable", or "status".
+	Op string
+	// Method is the registration mechanism involved.
+	Method AutoStartMethod
+	// Path is the file or registry key involved, if any.
+	Path string
+	// Remediation is a short user-facing suggestion.
+	Remediation string
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *AutoStartError) Error() string {
+	msg := "auto-start " + e.Op + " failed"
+	if e.Method != "" && e.Method != AutoStartNone {
+		msg += " (" + e.Method.DisplayName() + ")"
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *AutoStartError) Unwrap() error {
+	return e.Err
+}
+
 // DialogResult represents the result of a dialog interaction.
 type DialogResult int
 
@@ -2,6 +2,7 @@ package platform
 
 import (
 	"context"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -445,6 +446,58 @@ func TestIsAutoStartEnabled(t *testing.T) {
 	_ = enabled
 }
 
+func TestGetAutoStartMethod(t *testing.T) {
+	plat := Current()
+	ctx := context.Background()
+
+	method, err := plat.GetAutoStartMethod(ctx)
+	if err != nil {
+		t.Logf("GetAutoStartMethod returned error (may be expected): %v", err)
+	}
+	if method != AutoStartNone && method.DisplayName() == string(method) {
+		t.Errorf("AutoStartMethod %q has no display name", method)
+	}
+}
+
+func TestAutoStartMethodDisplayName(t *testing.T) {
+	tests := []struct {
+		method   AutoStartMethod
+		expected string
+	}{
+		{AutoStartNone, "not registered"},
+		{AutoStartLaunchAgent, "LaunchAgent"},
+		{AutoStartSystemd, "systemd user service"},
+		{AutoStartXDG, "XDG autostart entry"},
+		{AutoStartRegistry, "registry run key"},
+		{AutoStartMethod("custom"), "custom"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.method.DisplayName(); got != tt.expected {
+			t.Errorf("DisplayName(%q) = %q, want %q", tt.method, got, tt.expected)
+		}
+	}
+}
+
+func TestAutoStartError(t *testing.T) {
+	underlying := errors.New("permission denied")
+	err := &AutoStartError{
+		Op:          "enable",
+		Method:      AutoStartLaunchAgent,
+		Path:        "/tmp/test.plist",
+		Remediation: "Check file permissions.",
+		Err:         underlying,
+	}
+
+	msg := err.Error()
+	if !strings.Contains(msg, "enable") || !strings.Contains(msg, "permission denied") {
+		t.Errorf("Error() = %q, missing op or cause", msg)
+	}
+	if !errors.Is(err, underlying) {
+		t.Error("errors.Is should match the wrapped error")
+	}
+}
+
 func TestIDString(t *testing.T) {
 	tests := []struct {
 		id       ID
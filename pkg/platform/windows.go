@@ -75,7 +75,13 @@ func (w *windowsPlatform) EnableAutoStart(ctx context.Context) error {
 		registry.ALL_ACCESS,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to open registry key: %w", err)
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartRegistry,
+			Path:        `HKCU\Software\Microsoft\Windows\CurrentVersion\Run`,
+			Remediation: "Check that your account can write to the HKCU Run key.",
+			Err:         err,
+		}
 	}
 	defer key.Close()
 
@@ -93,7 +99,16 @@ func (w *windowsPlatform) EnableAutoStart(ctx context.Context) error {
 		}
 	}
 
-	return key.SetStringValue("AgentManager", exePath)
+	if err := key.SetStringValue("AgentManager", exePath); err != nil {
+		return &AutoStartError{
+			Op:          "enable",
+			Method:      AutoStartRegistry,
+			Path:        `HKCU\Software\Microsoft\Windows\CurrentVersion\Run`,
+			Remediation: "Check that your account can write to the HKCU Run key.",
+			Err:         err,
+		}
+	}
+	return nil
 }
 
 func (w *windowsPlatform) DisableAutoStart(ctx context.Context) error {
@@ -103,13 +118,25 @@ func (w *windowsPlatform) DisableAutoStart(ctx context.Context) error {
 		registry.ALL_ACCESS,
 	)
 	if err != nil {
-		return fmt.Errorf("failed to open registry key: %w", err)
+		return &AutoStartError{
+			Op:          "disable",
+			Method:      AutoStartRegistry,
+			Path:        `HKCU\Software\Microsoft\Windows\CurrentVersion\Run`,
+			Remediation: "Check that your account can write to the HKCU Run key.",
+			Err:         err,
+		}
 	}
 	defer key.Close()
 
 	err = key.DeleteValue("AgentManager")
 	if err != nil && err != registry.ErrNotExist {
-		return fmt.Errorf("failed to delete registry value: %w", err)
+		return &AutoStartError{
+			Op:          "disable",
+			Method:      AutoStartRegistry,
+			Path:        `HKCU\Software\Microsoft\Windows\CurrentVersion\Run`,
+			Remediation: "Remove the AgentManager value from the HKCU Run key manually.",
+			Err:         err,
+		}
 	}
 	return nil
 }
@@ -135,32 +162,78 @@ func (w *windowsPlatform) IsAutoStartEnabled(ctx context.Context) (bool, error)
 	return true, nil
 }
 
+func (w *windowsPlatform) GetAutoStartMethod(ctx context.Context) (AutoStartMethod, error) {
+	enabled, err := w.IsAutoStartEnabled(ctx)
+	if err != nil {
+		return AutoStartNone, err
+	}
+	if enabled {
+		return AutoStartRegistry, nil
+	}
+	return AutoStartNone, nil
+}
+
+// executableExtensions are the extensions tried when a name is given
+// without one: real binaries plus the .cmd/.bat shims npm creates for
+// globally installed packages.
+var executableExtensions = []string{".exe", ".cmd", ".bat"}
+
+// hasExecutableExtension reports whether the name already ends in one of
+// the recognized executable extensions.
+func hasExecutableExtension(name string) bool {
+	ext := strings.ToLower(filepath.Ext(name))
+	for _, e := range executableExtensions {
+		if ext == e {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *windowsPlatform) FindExecutable(name string) (string, error) {
-	// Add .exe if not present
-	if !strings.HasSuffix(strings.ToLower(name), ".exe") {
-		name = name + ".exe"
+	// exec.LookPath honors PATHEXT, so a bare name resolves to .exe as
+	// well as the .cmd/.bat shims npm installs
+	if path, err := exec.LookPath(name); err == nil {
+		return path, nil
 	}
 
-	path, err := exec.LookPath(name)
-	if err != nil {
-		return "", fmt.Errorf("executable %q not found: %w", name, err)
+	// Installers register apps that are not on PATH under App Paths
+	if path, ok := findViaAppPaths(name); ok {
+		return path, nil
 	}
-	return path, nil
+
+	return "", fmt.Errorf("executable %q not found", name)
 }
 
 func (w *windowsPlatform) FindExecutables(name string) ([]string, error) {
-	// Add .exe if not present
-	if !strings.HasSuffix(strings.ToLower(name), ".exe") {
-		name = name + ".exe"
+	candidates := []string{name}
+	if !hasExecutableExtension(name) {
+		candidates = candidates[:0]
+		for _, ext := range executableExtensions {
+			candidates = append(candidates, name+ext)
+		}
 	}
 
 	var paths []string
-	pathDirs := w.GetPathDirs()
+	for _, dir := range w.GetPathDirs() {
+		for _, candidate := range candidates {
+			fullPath := filepath.Join(dir, candidate)
+			if info, err := os.Stat(fullPath); err == nil && !info.IsDir() {
+				paths = append(paths, fullPath)
+			}
+		}
+	}
 
-	for _, dir := range pathDirs {
-		fullPath := filepath.Join(dir, name)
-		if info, err := os.Stat(fullPath); err == nil && !info.IsDir() {
-			paths = append(paths, fullPath)
+	if path, ok := findViaAppPaths(name); ok {
+		found := false
+		for _, p := range paths {
+			if strings.EqualFold(p, path) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			paths = append(paths, path)
 		}
 	}
 
@@ -172,16 +245,74 @@ func (w *windowsPlatform) FindExecutables(name string) ([]string, error) {
 }
 
 func (w *windowsPlatform) IsExecutableInPath(name string) bool {
-	if !strings.HasSuffix(strings.ToLower(name), ".exe") {
-		name = name + ".exe"
-	}
 	_, err := exec.LookPath(name)
 	return err == nil
 }
 
 func (w *windowsPlatform) GetPathDirs() []string {
-	pathEnv := os.Getenv("PATH")
-	return strings.Split(pathEnv, ";")
+	dirs := strings.Split(os.Getenv("PATH"), ";")
+
+	// Include directories agents commonly land in but that are not
+	// always on PATH
+	seen := make(map[string]bool, len(dirs))
+	for _, dir := range dirs {
+		seen[strings.ToLower(dir)] = true
+	}
+	for _, dir := range extraWindowsPathDirs() {
+		if seen[strings.ToLower(dir)] {
+			continue
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+			seen[strings.ToLower(dir)] = true
+		}
+	}
+
+	return dirs
+}
+
+// extraWindowsPathDirs lists well-known install locations to search in
+// addition to PATH: the WindowsApps alias directory, the per-user npm
+// prefix, and per-user Python Scripts directories.
+func extraWindowsPathDirs() []string {
+	var dirs []string
+	if localAppData := os.Getenv("LOCALAPPDATA"); localAppData != "" {
+		dirs = append(dirs, filepath.Join(localAppData, "Microsoft", "WindowsApps"))
+	}
+	if appData := os.Getenv("APPDATA"); appData != "" {
+		dirs = append(dirs, filepath.Join(appData, "npm"))
+		if matches, err := filepath.Glob(filepath.Join(appData, "Python", "Python*", "Scripts")); err == nil {
+			dirs = append(dirs, matches...)
+		}
+	}
+	return dirs
+}
+
+// findViaAppPaths resolves an executable through the App Paths registry
+// keys, checking the per-user hive before the machine-wide one.
+func findViaAppPaths(name string) (string, bool) {
+	if !strings.HasSuffix(strings.ToLower(name), ".exe") {
+		name = name + ".exe"
+	}
+	keyPath := `SOFTWARE\Microsoft\Windows\CurrentVersion\App Paths\` + name
+
+	for _, root := range []registry.Key{registry.CURRENT_USER, registry.LOCAL_MACHINE} {
+		key, err := registry.OpenKey(root, keyPath, registry.QUERY_VALUE)
+		if err != nil {
+			continue
+		}
+		path, _, err := key.GetStringValue("")
+		key.Close()
+		if err != nil || path == "" {
+			continue
+		}
+		path = strings.Trim(path, `"`)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, true
+		}
+	}
+
+	return "", false
 }
 
 func (w *windowsPlatform) GetShell() string {
@@ -227,6 +358,24 @@ $toast = [Windows.UI.Notifications.ToastNotification]::new($xml)
 	return exec.Command("powershell", "-Command", script).Run()
 }
 
+// IsDoNotDisturbEnabled reports whether Focus Assist is active. Windows
+// does not expose a supported query for the Focus Assist state, so it is
+// always reported as inactive.
+func (w *windowsPlatform) IsDoNotDisturbEnabled() bool {
+	return false
+}
+
+// IsElevated reports whether the process runs with administrator rights.
+// Only elevated processes can open the raw physical drive device.
+func (w *windowsPlatform) IsElevated() bool {
+	f, err := os.Open(`\\.\PHYSICALDRIVE0`)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
 func (w *windowsPlatform) ShowChangelogDialog(agentName, fromVer, toVer, changelog string) DialogResult {
 	// Use PowerShell to show a dialog
 	message := fmt.Sprintf("%s Update Available\n\n%s → %s\n\n%s",
@@ -0,0 +1,136 @@
+// Package prefs stores per-agent user preferences — favorites and hidden
+// agents — through the storage settings API so they survive restarts and
+// are shared between the CLI and the tray application.
+package prefs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// settingKey is the storage settings key holding the preferences JSON.
+const settingKey = "agent_prefs"
+
+// AgentPrefs holds the persisted per-agent preferences. Favorite agents
+// are pinned to the top of the tray submenu; hidden agents are excluded
+// from the tray and update notifications but remain detectable;
+// subscribed agents have their release notes included in the helper's
+// Atom feed.
+type AgentPrefs struct {
+	Favorites  []string `json:"favorites,omitempty"`
+	Hidden     []string `json:"hidden,omitempty"`
+	Subscribed []string `json:"subscribed,omitempty"`
+}
+
+// IsFavorite reports whether the agent is marked as a favorite.
+func (p AgentPrefs) IsFavorite(agentID string) bool {
+	return contains(p.Favorites, agentID)
+}
+
+// IsHidden reports whether the agent is marked as hidden.
+func (p AgentPrefs) IsHidden(agentID string) bool {
+	return contains(p.Hidden, agentID)
+}
+
+// IsSubscribed reports whether the agent's release notes are subscribed.
+func (p AgentPrefs) IsSubscribed(agentID string) bool {
+	return contains(p.Subscribed, agentID)
+}
+
+// Manager reads and writes agent preferences in a store.
+type Manager struct {
+	store storage.Store
+}
+
+// NewManager creates a preferences manager backed by the given store.
+// A nil store yields empty preferences and makes writes fail.
+func NewManager(store storage.Store) *Manager {
+	return &Manager{store: store}
+}
+
+// Get returns the stored preferences. Missing or unreadable entries
+// yield empty preferences rather than an error: a lost favorite marker
+// is never worth failing an operation over.
+func (m *Manager) Get(ctx context.Context) AgentPrefs {
+	var p AgentPrefs
+	if m.store == nil {
+		return p
+	}
+	value, err := m.store.GetSetting(ctx, settingKey)
+	if err != nil || value == "" {
+		return p
+	}
+	_ = json.Unmarshal([]byte(value), &p)
+	return p
+}
+
+// SetFavorite marks or unmarks an agent as a favorite.
+func (m *Manager) SetFavorite(ctx context.Context, agentID string, favorite bool) error {
+	return m.update(ctx, func(p *AgentPrefs) {
+		p.Favorites = setMembership(p.Favorites, agentID, favorite)
+	})
+}
+
+// SetHidden marks or unmarks an agent as hidden.
+func (m *Manager) SetHidden(ctx context.Context, agentID string, hidden bool) error {
+	return m.update(ctx, func(p *AgentPrefs) {
+		p.Hidden = setMembership(p.Hidden, agentID, hidden)
+	})
+}
+
+// SetSubscribed subscribes or unsubscribes an agent's release notes.
+func (m *Manager) SetSubscribed(ctx context.Context, agentID string, subscribed bool) error {
+	return m.update(ctx, func(p *AgentPrefs) {
+		p.Subscribed = setMembership(p.Subscribed, agentID, subscribed)
+	})
+}
+
+// update applies a mutation to the stored preferences and writes them back.
+func (m *Manager) update(ctx context.Context, mutate func(*AgentPrefs)) error {
+	if m.store == nil {
+		return fmt.Errorf("no store available for preferences")
+	}
+	p := m.Get(ctx)
+	mutate(&p)
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+	if err := m.store.SetSetting(ctx, settingKey, string(data)); err != nil {
+		return fmt.Errorf("failed to save preferences: %w", err)
+	}
+	return nil
+}
+
+// setMembership adds or removes an ID from a sorted membership list.
+func setMembership(list []string, agentID string, member bool) []string {
+	if member == contains(list, agentID) {
+		return list
+	}
+	if member {
+		list = append(list, agentID)
+		sort.Strings(list)
+		return list
+	}
+	filtered := list[:0]
+	for _, id := range list {
+		if id != agentID {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered
+}
+
+// contains reports whether the list includes the ID.
+func contains(list []string, agentID string) bool {
+	for _, id := range list {
+		if id == agentID {
+			return true
+		}
+	}
+	return false
+}
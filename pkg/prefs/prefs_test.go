@@ -0,0 +1,103 @@
+package prefs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+)
+
+func TestFavorites(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewManager(agentmgrtest.NewFakeStore())
+
+	if mgr.Get(ctx).IsFavorite("claude-code") {
+		t.Error("IsFavorite() = true before marking")
+	}
+
+	if err := mgr.SetFavorite(ctx, "claude-code", true); err != nil {
+		t.Fatalf("SetFavorite() error = %v", err)
+	}
+	if err := mgr.SetFavorite(ctx, "aider", true); err != nil {
+		t.Fatalf("SetFavorite() error = %v", err)
+	}
+
+	p := mgr.Get(ctx)
+	if !p.IsFavorite("claude-code") || !p.IsFavorite("aider") {
+		t.Errorf("favorites = %v, want both marked", p.Favorites)
+	}
+
+	// Marking twice must not duplicate the entry
+	if err := mgr.SetFavorite(ctx, "aider", true); err != nil {
+		t.Fatalf("SetFavorite() error = %v", err)
+	}
+	if got := len(mgr.Get(ctx).Favorites); got != 2 {
+		t.Errorf("favorites count = %d, want 2", got)
+	}
+
+	if err := mgr.SetFavorite(ctx, "claude-code", false); err != nil {
+		t.Fatalf("SetFavorite() error = %v", err)
+	}
+	if mgr.Get(ctx).IsFavorite("claude-code") {
+		t.Error("IsFavorite() = true after unmarking")
+	}
+}
+
+func TestHidden(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewManager(agentmgrtest.NewFakeStore())
+
+	if err := mgr.SetHidden(ctx, "goose", true); err != nil {
+		t.Fatalf("SetHidden() error = %v", err)
+	}
+	p := mgr.Get(ctx)
+	if !p.IsHidden("goose") {
+		t.Error("IsHidden() = false after marking")
+	}
+	if p.IsFavorite("goose") {
+		t.Error("hiding an agent must not mark it as a favorite")
+	}
+
+	if err := mgr.SetHidden(ctx, "goose", false); err != nil {
+		t.Fatalf("SetHidden() error = %v", err)
+	}
+	if mgr.Get(ctx).IsHidden("goose") {
+		t.Error("IsHidden() = true after unmarking")
+	}
+}
+
+func TestSubscribed(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewManager(agentmgrtest.NewFakeStore())
+
+	if err := mgr.SetSubscribed(ctx, "aider", true); err != nil {
+		t.Fatalf("SetSubscribed() error = %v", err)
+	}
+	p := mgr.Get(ctx)
+	if !p.IsSubscribed("aider") {
+		t.Error("IsSubscribed() = false after subscribing")
+	}
+	if p.IsFavorite("aider") || p.IsHidden("aider") {
+		t.Error("subscribing must not touch other preferences")
+	}
+
+	if err := mgr.SetSubscribed(ctx, "aider", false); err != nil {
+		t.Fatalf("SetSubscribed() error = %v", err)
+	}
+	if mgr.Get(ctx).IsSubscribed("aider") {
+		t.Error("IsSubscribed() = true after unsubscribing")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	ctx := context.Background()
+	mgr := NewManager(nil)
+
+	p := mgr.Get(ctx)
+	if len(p.Favorites) != 0 || len(p.Hidden) != 0 {
+		t.Errorf("Get() with nil store = %+v, want empty", p)
+	}
+	if err := mgr.SetFavorite(ctx, "aider", true); err == nil {
+		t.Error("SetFavorite() with nil store expected error")
+	}
+}
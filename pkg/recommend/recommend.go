@@ -0,0 +1,263 @@
+// Package recommend analyzes the detected agent environment and produces
+// prioritized suggestions — outdated versions, duplicate installs,
+// insecure curl installs, and catalog agents matching the user's stack —
+// together with an aggregate environment score.
+package recommend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// Severity ranks how much a recommendation matters.
+type Severity string
+
+// Severity levels, from most to least pressing. Info entries are
+// suggestions rather than problems and do not affect the score.
+const (
+	SeverityHigh   Severity = "high"
+	SeverityMedium Severity = "medium"
+	SeverityLow    Severity = "low"
+	SeverityInfo   Severity = "info"
+)
+
+// Action types for one-click fixes.
+const (
+	ActionInstall   = "install"
+	ActionUpdate    = "update"
+	ActionUninstall = "uninstall"
+	ActionMigrate   = "migrate"
+)
+
+// maxSuggestedAgents caps how many not-yet-installed agents are suggested
+// so the list stays actionable.
+const maxSuggestedAgents = 3
+
+// Action describes the one-click fix for a recommendation, in terms the
+// installer already understands.
+type Action struct {
+	Type    string `json:"type"`
+	AgentID string `json:"agent_id"`
+	// Method is the install method the action targets. For migrations it
+	// is the method to migrate to; for uninstalls the method to remove.
+	Method string `json:"method,omitempty"`
+}
+
+// Recommendation is a single prioritized suggestion.
+type Recommendation struct {
+	// ID is stable across runs for the same underlying finding, so
+	// clients can deduplicate or dismiss entries.
+	ID       string   `json:"id"`
+	Severity Severity `json:"severity"`
+	Title    string   `json:"title"`
+	Detail   string   `json:"detail"`
+	// Action is the one-click fix, nil when none applies.
+	Action *Action `json:"action,omitempty"`
+}
+
+// Report is the result of analyzing an environment.
+type Report struct {
+	// Score grades the environment from 0 (neglected) to 100 (healthy).
+	Score           int              `json:"score"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// Analyze inspects the detected installations against the catalog and
+// returns scored, prioritized recommendations for the given platform.
+func Analyze(installations []*agent.Installation, defs []catalog.AgentDef, platformID string) Report {
+	defMap := make(map[string]catalog.AgentDef, len(defs))
+	for _, def := range defs {
+		defMap[def.ID] = def
+	}
+
+	byAgent := make(map[string][]*agent.Installation)
+	for _, inst := range installations {
+		if inst.ConfiguredOnly {
+			continue
+		}
+		byAgent[inst.AgentID] = append(byAgent[inst.AgentID], inst)
+	}
+
+	var recs []Recommendation
+	recs = append(recs, outdatedRecommendations(byAgent)...)
+	recs = append(recs, duplicateRecommendations(byAgent)...)
+	recs = append(recs, curlRecommendations(byAgent, defMap, platformID)...)
+	recs = append(recs, missingAgentRecommendations(byAgent, defs, platformID)...)
+
+	sortRecommendations(recs)
+
+	return Report{
+		Score:           score(recs),
+		Recommendations: recs,
+	}
+}
+
+// outdatedRecommendations suggests updating installations with a newer
+// version available.
+func outdatedRecommendations(byAgent map[string][]*agent.Installation) []Recommendation {
+	var recs []Recommendation
+	for agentID, insts := range byAgent {
+		for _, inst := range insts {
+			if !inst.HasUpdate() {
+				continue
+			}
+			recs = append(recs, Recommendation{
+				ID:       "update:" + inst.Key(),
+				Severity: SeverityMedium,
+				Title:    fmt.Sprintf("Update %s", inst.AgentName),
+				Detail: fmt.Sprintf("%s %s is installed via %s but %s is available.",
+					inst.AgentName, inst.InstalledVersion.String(), inst.Method, inst.LatestVersion.String()),
+				Action: &Action{Type: ActionUpdate, AgentID: agentID, Method: string(inst.Method)},
+			})
+			break // one update suggestion per agent is enough
+		}
+	}
+	return recs
+}
+
+// duplicateRecommendations suggests removing redundant installations of
+// the same agent through different methods.
+func duplicateRecommendations(byAgent map[string][]*agent.Installation) []Recommendation {
+	var recs []Recommendation
+	for agentID, insts := range byAgent {
+		if len(insts) < 2 {
+			continue
+		}
+
+		// Keep the newest installation and suggest removing the rest
+		keep := insts[0]
+		for _, inst := range insts[1:] {
+			if keep.InstalledVersion.IsOlderThan(inst.InstalledVersion) {
+				keep = inst
+			}
+		}
+		for _, inst := range insts {
+			if inst == keep {
+				continue
+			}
+			recs = append(recs, Recommendation{
+				ID:       "duplicate:" + inst.Key(),
+				Severity: SeverityLow,
+				Title:    fmt.Sprintf("Remove duplicate %s install", inst.AgentName),
+				Detail: fmt.Sprintf("%s is installed via both %s and %s; keeping only the %s install avoids version confusion.",
+					inst.AgentName, keep.Method, inst.Method, keep.Method),
+				Action: &Action{Type: ActionUninstall, AgentID: agentID, Method: string(inst.Method)},
+			})
+		}
+	}
+	return recs
+}
+
+// curlRecommendations suggests migrating curl-script installations to a
+// package manager that can verify and cleanly update the agent.
+func curlRecommendations(byAgent map[string][]*agent.Installation, defMap map[string]catalog.AgentDef, platformID string) []Recommendation {
+	var recs []Recommendation
+	for agentID, insts := range byAgent {
+		for _, inst := range insts {
+			if inst.Method != agent.InstallMethodCurl {
+				continue
+			}
+			def, ok := defMap[agentID]
+			if !ok {
+				continue
+			}
+			target := ""
+			for _, method := range def.GetSupportedMethods(platformID) {
+				if method.Method != string(agent.InstallMethodCurl) {
+					target = method.Method
+					break
+				}
+			}
+			if target == "" {
+				continue
+			}
+			recs = append(recs, Recommendation{
+				ID:       "curl:" + inst.Key(),
+				Severity: SeverityMedium,
+				Title:    fmt.Sprintf("Reinstall %s via %s", inst.AgentName, target),
+				Detail: fmt.Sprintf("%s was installed with a curl script, which cannot be verified or cleanly updated; %s manages both.",
+					inst.AgentName, target),
+				Action: &Action{Type: ActionMigrate, AgentID: agentID, Method: target},
+			})
+		}
+	}
+	return recs
+}
+
+// missingAgentRecommendations suggests catalog agents in the categories
+// the user already works in but has not installed yet.
+func missingAgentRecommendations(byAgent map[string][]*agent.Installation, defs []catalog.AgentDef, platformID string) []Recommendation {
+	if len(byAgent) == 0 {
+		return nil
+	}
+
+	// The user's stack is the categories of their installed agents
+	stack := make(map[catalog.AgentCategory]bool)
+	for _, def := range defs {
+		if _, installed := byAgent[def.ID]; installed {
+			stack[def.CategoryOrDefault()] = true
+		}
+	}
+
+	var recs []Recommendation
+	for _, def := range defs {
+		if len(recs) == maxSuggestedAgents {
+			break
+		}
+		if _, installed := byAgent[def.ID]; installed {
+			continue
+		}
+		if !stack[def.CategoryOrDefault()] {
+			continue
+		}
+		methods := def.GetSupportedMethods(platformID)
+		if len(methods) == 0 {
+			continue
+		}
+		recs = append(recs, Recommendation{
+			ID:       "missing:" + def.ID,
+			Severity: SeverityInfo,
+			Title:    fmt.Sprintf("Try %s", def.Name),
+			Detail: fmt.Sprintf("%s is a %s agent like others you have installed. %s",
+				def.Name, strings.ToLower(def.CategoryOrDefault().DisplayName()), def.Description),
+			Action: &Action{Type: ActionInstall, AgentID: def.ID, Method: methods[0].Method},
+		})
+	}
+	return recs
+}
+
+// sortRecommendations orders by severity, then title for a stable list.
+func sortRecommendations(recs []Recommendation) {
+	rank := map[Severity]int{SeverityHigh: 0, SeverityMedium: 1, SeverityLow: 2, SeverityInfo: 3}
+	sort.SliceStable(recs, func(i, j int) bool {
+		if rank[recs[i].Severity] != rank[recs[j].Severity] {
+			return rank[recs[i].Severity] < rank[recs[j].Severity]
+		}
+		return recs[i].Title < recs[j].Title
+	})
+}
+
+// score grades the environment: a clean setup scores 100, and each
+// finding subtracts points by severity down to a floor of 0. Info
+// suggestions are not problems and subtract nothing.
+func score(recs []Recommendation) int {
+	total := 100
+	for _, rec := range recs {
+		switch rec.Severity {
+		case SeverityHigh:
+			total -= 15
+		case SeverityMedium:
+			total -= 10
+		case SeverityLow:
+			total -= 3
+		}
+	}
+	if total < 0 {
+		total = 0
+	}
+	return total
+}
@@ -0,0 +1,153 @@
+package recommend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func testDefs() []catalog.AgentDef {
+	return []catalog.AgentDef{
+		{
+			ID: "claude-code", Name: "Claude Code", Category: "coding",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"npm":  {Method: "npm", Platforms: []string{"darwin", "linux"}},
+				"curl": {Method: "curl", Platforms: []string{"darwin", "linux"}},
+			},
+		},
+		{
+			ID: "aider", Name: "Aider", Category: "coding", Description: "AI pair programming in your terminal.",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"pipx": {Method: "pipx", Platforms: []string{"darwin", "linux"}},
+			},
+		},
+		{
+			ID: "goose", Name: "Goose", Category: "devops",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"brew": {Method: "brew", Platforms: []string{"darwin"}},
+			},
+		},
+	}
+}
+
+func installation(agentID, name, method, version string) *agent.Installation {
+	return &agent.Installation{
+		AgentID:          agentID,
+		AgentName:        name,
+		Method:           agent.InstallMethod(method),
+		InstalledVersion: agent.MustParseVersion(version),
+	}
+}
+
+func findRec(t *testing.T, report Report, idPrefix string) Recommendation {
+	t.Helper()
+	for _, rec := range report.Recommendations {
+		if strings.HasPrefix(rec.ID, idPrefix) {
+			return rec
+		}
+	}
+	t.Fatalf("no recommendation with ID prefix %q in %+v", idPrefix, report.Recommendations)
+	return Recommendation{}
+}
+
+func TestAnalyzeCleanEnvironment(t *testing.T) {
+	insts := []*agent.Installation{installation("claude-code", "Claude Code", "npm", "2.0.0")}
+
+	report := Analyze(insts, testDefs(), "linux")
+
+	if report.Score != 100 {
+		t.Errorf("Score = %d, want 100 for a clean environment", report.Score)
+	}
+	for _, rec := range report.Recommendations {
+		if rec.Severity != SeverityInfo {
+			t.Errorf("unexpected %s recommendation in clean environment: %s", rec.Severity, rec.Title)
+		}
+	}
+}
+
+func TestAnalyzeOutdated(t *testing.T) {
+	inst := installation("claude-code", "Claude Code", "npm", "1.0.0")
+	latest := agent.MustParseVersion("2.0.0")
+	inst.LatestVersion = &latest
+
+	report := Analyze([]*agent.Installation{inst}, testDefs(), "linux")
+
+	rec := findRec(t, report, "update:")
+	if rec.Severity != SeverityMedium {
+		t.Errorf("severity = %s, want medium", rec.Severity)
+	}
+	if rec.Action == nil || rec.Action.Type != ActionUpdate || rec.Action.AgentID != "claude-code" {
+		t.Errorf("action = %+v, want update of claude-code", rec.Action)
+	}
+	if report.Score >= 100 {
+		t.Errorf("Score = %d, want below 100 with findings", report.Score)
+	}
+}
+
+func TestAnalyzeDuplicates(t *testing.T) {
+	insts := []*agent.Installation{
+		installation("claude-code", "Claude Code", "npm", "2.0.0"),
+		installation("claude-code", "Claude Code", "brew", "1.5.0"),
+	}
+
+	report := Analyze(insts, testDefs(), "linux")
+
+	rec := findRec(t, report, "duplicate:")
+	if rec.Action == nil || rec.Action.Type != ActionUninstall {
+		t.Fatalf("action = %+v, want uninstall", rec.Action)
+	}
+	// The older brew install should be the one removed
+	if rec.Action.Method != "brew" {
+		t.Errorf("uninstall method = %q, want brew (older install)", rec.Action.Method)
+	}
+}
+
+func TestAnalyzeCurlInstall(t *testing.T) {
+	insts := []*agent.Installation{installation("claude-code", "Claude Code", "curl", "2.0.0")}
+
+	report := Analyze(insts, testDefs(), "linux")
+
+	rec := findRec(t, report, "curl:")
+	if rec.Action == nil || rec.Action.Type != ActionMigrate || rec.Action.Method != "npm" {
+		t.Errorf("action = %+v, want migrate to npm", rec.Action)
+	}
+}
+
+func TestAnalyzeMissingAgents(t *testing.T) {
+	insts := []*agent.Installation{installation("claude-code", "Claude Code", "npm", "2.0.0")}
+
+	report := Analyze(insts, testDefs(), "linux")
+
+	// Aider shares the coding category; Goose does not match the stack
+	// and has no linux install method anyway
+	rec := findRec(t, report, "missing:")
+	if rec.ID != "missing:aider" {
+		t.Errorf("suggested agent = %s, want missing:aider", rec.ID)
+	}
+	if rec.Action == nil || rec.Action.Type != ActionInstall || rec.Action.Method != "pipx" {
+		t.Errorf("action = %+v, want install via pipx", rec.Action)
+	}
+	for _, r := range report.Recommendations {
+		if r.ID == "missing:goose" {
+			t.Error("goose suggested despite not matching the installed stack")
+		}
+	}
+}
+
+func TestAnalyzeOrdering(t *testing.T) {
+	outdated := installation("claude-code", "Claude Code", "npm", "1.0.0")
+	latest := agent.MustParseVersion("2.0.0")
+	outdated.LatestVersion = &latest
+	insts := []*agent.Installation{outdated}
+
+	report := Analyze(insts, testDefs(), "linux")
+
+	if len(report.Recommendations) < 2 {
+		t.Fatalf("recommendations = %d, want at least the update and a suggestion", len(report.Recommendations))
+	}
+	if report.Recommendations[0].Severity == SeverityInfo {
+		t.Error("info suggestion sorted before actual findings")
+	}
+}
@@ -0,0 +1,192 @@
+// Package sbom exports detected agent installations as software bills of
+// materials in CycloneDX or SPDX JSON, for security teams that track agent
+// inventories with standard tooling.
+package sbom
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+// Format identifies an SBOM output format.
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// ParseFormat validates a format name.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case FormatCycloneDX, FormatSPDX:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unsupported SBOM format %q (supported: cyclonedx, spdx)", s)
+	}
+}
+
+// Export renders the installations as an SBOM document in the given format.
+// Agent definitions supply package names for package URLs; entries missing
+// from the map fall back to the agent ID.
+func Export(format Format, installations []*agent.Installation, agentDefs map[string]catalog.AgentDef, toolVersion string) ([]byte, error) {
+	switch format {
+	case FormatCycloneDX:
+		return json.MarshalIndent(cycloneDXDocument(installations, agentDefs, toolVersion), "", "  ")
+	case FormatSPDX:
+		return json.MarshalIndent(spdxDocument(installations, agentDefs, toolVersion), "", "  ")
+	default:
+		return nil, fmt.Errorf("unsupported SBOM format %q", format)
+	}
+}
+
+// PackageURL builds a purl (package URL) for an installation, mapping the
+// install method to the matching purl type. Methods without a registered
+// purl type use the generic type.
+func PackageURL(inst *agent.Installation, def catalog.AgentDef) string {
+	name := inst.AgentID
+	if methodDef, ok := def.GetInstallMethod(string(inst.Method)); ok && methodDef.Package != "" {
+		name = methodDef.Package
+	}
+
+	var purlType string
+	switch inst.Method {
+	case agent.InstallMethodNPM:
+		purlType = "npm"
+	case agent.InstallMethodPip, agent.InstallMethodPipx, agent.InstallMethodUV:
+		purlType = "pypi"
+	case agent.InstallMethodBrew:
+		purlType = "brew"
+	default:
+		purlType = "generic"
+	}
+
+	purl := "pkg:" + purlType + "/" + escapePurlName(name)
+	if version := inst.InstalledVersion.String(); version != "" {
+		purl += "@" + url.PathEscape(version)
+	}
+	return purl
+}
+
+// escapePurlName percent-encodes a package name per the purl spec, keeping
+// the namespace separator intact (e.g. @scope/name for npm).
+func escapePurlName(name string) string {
+	segments := strings.Split(name, "/")
+	for i, seg := range segments {
+		segments[i] = url.PathEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// serialNumber generates a random urn:uuid serial for CycloneDX documents.
+func serialNumber() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "urn:uuid:00000000-0000-0000-0000-000000000000"
+	}
+	// Version 4 UUID
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("urn:uuid:%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
+// cycloneDXDocument builds a CycloneDX 1.5 BOM.
+func cycloneDXDocument(installations []*agent.Installation, agentDefs map[string]catalog.AgentDef, toolVersion string) map[string]interface{} {
+	components := make([]map[string]interface{}, 0, len(installations))
+	for _, inst := range installations {
+		purl := PackageURL(inst, agentDefs[inst.AgentID])
+
+		properties := []map[string]string{
+			{"name": "agentmgr:install_method", "value": string(inst.Method)},
+		}
+		if inst.ExecutablePath != "" {
+			properties = append(properties, map[string]string{
+				"name": "agentmgr:executable_path", "value": inst.ExecutablePath,
+			})
+		}
+		if inst.InstallPath != "" {
+			properties = append(properties, map[string]string{
+				"name": "agentmgr:install_path", "value": inst.InstallPath,
+			})
+		}
+
+		component := map[string]interface{}{
+			"type":       "application",
+			"bom-ref":    purl,
+			"name":       inst.AgentName,
+			"purl":       purl,
+			"properties": properties,
+		}
+		if version := inst.InstalledVersion.String(); version != "" {
+			component["version"] = version
+		}
+		components = append(components, component)
+	}
+
+	return map[string]interface{}{
+		"bomFormat":    "CycloneDX",
+		"specVersion":  "1.5",
+		"serialNumber": serialNumber(),
+		"version":      1,
+		"metadata": map[string]interface{}{
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"tools": []map[string]string{
+				{"vendor": "agentmgr", "name": "agentmgr", "version": toolVersion},
+			},
+		},
+		"components": components,
+	}
+}
+
+// spdxDocument builds an SPDX 2.3 document.
+func spdxDocument(installations []*agent.Installation, agentDefs map[string]catalog.AgentDef, toolVersion string) map[string]interface{} {
+	packages := make([]map[string]interface{}, 0, len(installations))
+	relationships := make([]map[string]string, 0, len(installations))
+
+	for i, inst := range installations {
+		spdxID := fmt.Sprintf("SPDXRef-Package-%d", i+1)
+		pkg := map[string]interface{}{
+			"SPDXID":           spdxID,
+			"name":             inst.AgentName,
+			"downloadLocation": "NOASSERTION",
+			"externalRefs": []map[string]string{
+				{
+					"referenceCategory": "PACKAGE-MANAGER",
+					"referenceType":     "purl",
+					"referenceLocator":  PackageURL(inst, agentDefs[inst.AgentID]),
+				},
+			},
+		}
+		if version := inst.InstalledVersion.String(); version != "" {
+			pkg["versionInfo"] = version
+		}
+		packages = append(packages, pkg)
+
+		relationships = append(relationships, map[string]string{
+			"spdxElementId":      "SPDXRef-DOCUMENT",
+			"relatedSpdxElement": spdxID,
+			"relationshipType":   "DESCRIBES",
+		})
+	}
+
+	return map[string]interface{}{
+		"spdxVersion":       "SPDX-2.3",
+		"dataLicense":       "CC0-1.0",
+		"SPDXID":            "SPDXRef-DOCUMENT",
+		"name":              "agentmgr-agent-inventory",
+		"documentNamespace": "https://github.com/kevinelliott/agentmgr/sbom/" + serialNumber(),
+		"creationInfo": map[string]interface{}{
+			"created":  time.Now().UTC().Format(time.RFC3339),
+			"creators": []string{"Tool: agentmgr-" + toolVersion},
+		},
+		"packages":      packages,
+		"relationships": relationships,
+	}
+}
@@ -0,0 +1,247 @@
+package sbom
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+)
+
+func testInstallations() []*agent.Installation {
+	return []*agent.Installation{
+		{
+			AgentID:          "claude-code",
+			AgentName:        "Claude Code",
+			Method:           agent.InstallMethodNPM,
+			InstalledVersion: agent.MustParseVersion("1.2.3"),
+			ExecutablePath:   "/usr/local/bin/claude",
+		},
+		{
+			AgentID:          "aider",
+			AgentName:        "Aider",
+			Method:           agent.InstallMethodPipx,
+			InstalledVersion: agent.MustParseVersion("0.50.0"),
+			ExecutablePath:   "/home/user/.local/bin/aider",
+		},
+	}
+}
+
+func testAgentDefs() map[string]catalog.AgentDef {
+	return map[string]catalog.AgentDef{
+		"claude-code": {
+			ID:   "claude-code",
+			Name: "Claude Code",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"npm": {Method: "npm", Package: "@anthropic-ai/claude-code"},
+			},
+		},
+		"aider": {
+			ID:   "aider",
+			Name: "Aider",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"pipx": {Method: "pipx", Package: "aider-chat"},
+			},
+		},
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Format
+		wantErr bool
+	}{
+		{"cyclonedx", FormatCycloneDX, false},
+		{"spdx", FormatSPDX, false},
+		{"xml", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseFormat(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseFormat(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestPackageURL(t *testing.T) {
+	defs := testAgentDefs()
+
+	tests := []struct {
+		name string
+		inst *agent.Installation
+		def  catalog.AgentDef
+		want string
+	}{
+		{
+			name: "npm scoped package",
+			inst: testInstallations()[0],
+			def:  defs["claude-code"],
+			want: "pkg:npm/@anthropic-ai/claude-code@1.2.3",
+		},
+		{
+			name: "pipx maps to pypi",
+			inst: testInstallations()[1],
+			def:  defs["aider"],
+			want: "pkg:pypi/aider-chat@0.50.0",
+		},
+		{
+			name: "unknown method falls back to generic",
+			inst: &agent.Installation{
+				AgentID:          "some-tool",
+				Method:           agent.InstallMethodNative,
+				InstalledVersion: agent.MustParseVersion("2.0.0"),
+			},
+			want: "pkg:generic/some-tool@2.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PackageURL(tt.inst, tt.def); got != tt.want {
+				t.Errorf("PackageURL() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportCycloneDX(t *testing.T) {
+	data, err := Export(FormatCycloneDX, testInstallations(), testAgentDefs(), "1.0.0")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var doc struct {
+		BOMFormat    string `json:"bomFormat"`
+		SpecVersion  string `json:"specVersion"`
+		SerialNumber string `json:"serialNumber"`
+		Components   []struct {
+			Type       string `json:"type"`
+			Name       string `json:"name"`
+			Version    string `json:"version"`
+			Purl       string `json:"purl"`
+			Properties []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"properties"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if doc.BOMFormat != "CycloneDX" {
+		t.Errorf("bomFormat = %q, want CycloneDX", doc.BOMFormat)
+	}
+	if doc.SpecVersion != "1.5" {
+		t.Errorf("specVersion = %q, want 1.5", doc.SpecVersion)
+	}
+	if !strings.HasPrefix(doc.SerialNumber, "urn:uuid:") {
+		t.Errorf("serialNumber = %q, want urn:uuid: prefix", doc.SerialNumber)
+	}
+	if len(doc.Components) != 2 {
+		t.Fatalf("components = %d, want 2", len(doc.Components))
+	}
+
+	claude := doc.Components[0]
+	if claude.Name != "Claude Code" || claude.Version != "1.2.3" {
+		t.Errorf("component = %s@%s, want Claude Code@1.2.3", claude.Name, claude.Version)
+	}
+	if claude.Purl != "pkg:npm/@anthropic-ai/claude-code@1.2.3" {
+		t.Errorf("purl = %q", claude.Purl)
+	}
+
+	props := make(map[string]string)
+	for _, p := range claude.Properties {
+		props[p.Name] = p.Value
+	}
+	if props["agentmgr:install_method"] != "npm" {
+		t.Errorf("install_method property = %q, want npm", props["agentmgr:install_method"])
+	}
+	if props["agentmgr:executable_path"] != "/usr/local/bin/claude" {
+		t.Errorf("executable_path property = %q", props["agentmgr:executable_path"])
+	}
+}
+
+func TestExportSPDX(t *testing.T) {
+	data, err := Export(FormatSPDX, testInstallations(), testAgentDefs(), "1.0.0")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var doc struct {
+		SPDXVersion string `json:"spdxVersion"`
+		DataLicense string `json:"dataLicense"`
+		SPDXID      string `json:"SPDXID"`
+		Packages    []struct {
+			SPDXID       string `json:"SPDXID"`
+			Name         string `json:"name"`
+			VersionInfo  string `json:"versionInfo"`
+			ExternalRefs []struct {
+				ReferenceType    string `json:"referenceType"`
+				ReferenceLocator string `json:"referenceLocator"`
+			} `json:"externalRefs"`
+		} `json:"packages"`
+		Relationships []struct {
+			SpdxElementID      string `json:"spdxElementId"`
+			RelatedSpdxElement string `json:"relatedSpdxElement"`
+			RelationshipType   string `json:"relationshipType"`
+		} `json:"relationships"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if doc.SPDXVersion != "SPDX-2.3" {
+		t.Errorf("spdxVersion = %q, want SPDX-2.3", doc.SPDXVersion)
+	}
+	if doc.DataLicense != "CC0-1.0" {
+		t.Errorf("dataLicense = %q, want CC0-1.0", doc.DataLicense)
+	}
+	if len(doc.Packages) != 2 {
+		t.Fatalf("packages = %d, want 2", len(doc.Packages))
+	}
+	if len(doc.Relationships) != 2 {
+		t.Fatalf("relationships = %d, want 2", len(doc.Relationships))
+	}
+
+	aider := doc.Packages[1]
+	if aider.Name != "Aider" || aider.VersionInfo != "0.50.0" {
+		t.Errorf("package = %s@%s, want Aider@0.50.0", aider.Name, aider.VersionInfo)
+	}
+	if len(aider.ExternalRefs) != 1 || aider.ExternalRefs[0].ReferenceType != "purl" {
+		t.Fatalf("externalRefs = %+v, want one purl ref", aider.ExternalRefs)
+	}
+	if aider.ExternalRefs[0].ReferenceLocator != "pkg:pypi/aider-chat@0.50.0" {
+		t.Errorf("purl = %q", aider.ExternalRefs[0].ReferenceLocator)
+	}
+
+	rel := doc.Relationships[0]
+	if rel.SpdxElementID != "SPDXRef-DOCUMENT" || rel.RelationshipType != "DESCRIBES" {
+		t.Errorf("relationship = %+v", rel)
+	}
+}
+
+func TestExportEmptyInventory(t *testing.T) {
+	data, err := Export(FormatCycloneDX, nil, nil, "1.0.0")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	var doc struct {
+		Components []interface{} `json:"components"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if len(doc.Components) != 0 {
+		t.Errorf("components = %d, want 0", len(doc.Components))
+	}
+}
@@ -0,0 +1,251 @@
+// Package shim maintains small wrapper scripts ("shims") for agent
+// executables in a managed bin directory. A shim records each invocation,
+// warns when the installed version violates a configured pin, and forwards
+// to the real executable — so the PATH entry stays stable while the
+// underlying install method or version changes.
+package shim
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+// markerPrefix identifies files in the bin directory that agentmgr manages.
+// The full marker line is "<prefix><agent-id> -> <target>".
+const markerPrefix = "# agentmgr shim for "
+
+// Shim describes one managed wrapper script.
+type Shim struct {
+	AgentID string `json:"agent_id"`
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+}
+
+// Manager creates and removes shims in the managed bin directory.
+type Manager struct {
+	binDir string
+}
+
+// NewManager creates a shim manager rooted in the given data directory.
+func NewManager(dataDir string) *Manager {
+	return &Manager{binDir: filepath.Join(dataDir, "shims")}
+}
+
+// BinDir returns the managed bin directory. Users add it to PATH ahead of
+// the real install locations.
+func (m *Manager) BinDir() string {
+	return m.binDir
+}
+
+// Enable writes a shim for the installation's executable. The pinned
+// constraint, when set and violated by the installed version, is surfaced
+// as a warning each time the shim runs. Re-enabling an agent overwrites
+// its shim, which is how switching install methods works: the shim name
+// stays the same while the target changes.
+func (m *Manager) Enable(inst *agent.Installation, pinned string) error {
+	if inst.ExecutablePath == "" {
+		return fmt.Errorf("no executable path known for %s", inst.AgentID)
+	}
+	if err := os.MkdirAll(m.usageDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create shim directory: %w", err)
+	}
+
+	pinWarning := ""
+	if pinned != "" {
+		if c, err := agent.ParseConstraint(pinned); err == nil && !c.Matches(inst.InstalledVersion) {
+			pinWarning = fmt.Sprintf("agentmgr: %s %s violates pin %s",
+				inst.AgentID, inst.InstalledVersion, pinned)
+		}
+	}
+
+	name := shimName(inst.ExecutablePath)
+	script := m.renderShim(inst.AgentID, inst.ExecutablePath, pinWarning)
+	if err := os.WriteFile(filepath.Join(m.binDir, name), []byte(script), 0o755); err != nil {
+		return fmt.Errorf("failed to write shim: %w", err)
+	}
+	return nil
+}
+
+// Disable removes the agent's shims. Usage history is kept.
+func (m *Manager) Disable(agentID string) error {
+	shims, err := m.List()
+	if err != nil {
+		return err
+	}
+	for _, s := range shims {
+		if s.AgentID != agentID {
+			continue
+		}
+		if err := os.Remove(filepath.Join(m.binDir, s.Name)); err != nil {
+			return fmt.Errorf("failed to remove shim: %w", err)
+		}
+	}
+	return nil
+}
+
+// IsEnabled reports whether the agent has a shim installed.
+func (m *Manager) IsEnabled(agentID string) bool {
+	shims, err := m.List()
+	if err != nil {
+		return false
+	}
+	for _, s := range shims {
+		if s.AgentID == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the managed shims, identified by the marker line each shim
+// carries. Unmanaged files in the bin directory are ignored.
+func (m *Manager) List() ([]Shim, error) {
+	entries, err := os.ReadDir(m.binDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var shims []Shim
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(m.binDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		agentID, target, ok := parseMarker(string(data))
+		if !ok {
+			continue
+		}
+		shims = append(shims, Shim{AgentID: agentID, Name: entry.Name(), Target: target})
+	}
+	return shims, nil
+}
+
+// Sync regenerates the shims for all currently enabled agents against the
+// given installations, repointing targets after installs, updates, or
+// method switches. Shims whose agent is no longer installed are removed.
+func (m *Manager) Sync(installations []*agent.Installation, pinnedFor func(agentID string) string) error {
+	shims, err := m.List()
+	if err != nil {
+		return err
+	}
+
+	byAgent := make(map[string]*agent.Installation, len(installations))
+	for _, inst := range installations {
+		byAgent[inst.AgentID] = inst
+	}
+
+	for _, s := range shims {
+		inst, ok := byAgent[s.AgentID]
+		if !ok {
+			if err := os.Remove(filepath.Join(m.binDir, s.Name)); err != nil {
+				return err
+			}
+			continue
+		}
+		pinned := ""
+		if pinnedFor != nil {
+			pinned = pinnedFor(s.AgentID)
+		}
+		if err := m.Enable(inst, pinned); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastUsed returns the time of the agent's most recent shim invocation.
+func (m *Manager) LastUsed(agentID string) (time.Time, bool) {
+	data, err := os.ReadFile(m.usageLog(agentID))
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	lines := strings.Fields(strings.TrimSpace(string(data)))
+	if len(lines) == 0 {
+		return time.Time{}, false
+	}
+	epoch, err := strconv.ParseInt(lines[len(lines)-1], 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(epoch, 0), true
+}
+
+// usageDir is where shims append their invocation records.
+func (m *Manager) usageDir() string {
+	return filepath.Join(m.binDir, ".usage")
+}
+
+// usageLog is the invocation record file for one agent.
+func (m *Manager) usageLog(agentID string) string {
+	return filepath.Join(m.usageDir(), agentID+".log")
+}
+
+// renderShim produces the wrapper script for the platform shell.
+func (m *Manager) renderShim(agentID, target, pinWarning string) string {
+	if runtime.GOOS == "windows" {
+		var b strings.Builder
+		b.WriteString("@echo off\r\n")
+		b.WriteString("rem " + strings.TrimPrefix(markerLine(agentID, target), "# ") + "\r\n")
+		if pinWarning != "" {
+			b.WriteString("echo " + pinWarning + " 1>&2\r\n")
+		}
+		b.WriteString("\"" + target + "\" %*\r\n")
+		return b.String()
+	}
+
+	var b strings.Builder
+	b.WriteString("#!/bin/sh\n")
+	b.WriteString(markerLine(agentID, target) + "\n")
+	b.WriteString("# Managed by agentmgr — do not edit\n")
+	b.WriteString("date +%s >> \"" + m.usageLog(agentID) + "\" 2>/dev/null\n")
+	if pinWarning != "" {
+		b.WriteString("echo \"" + pinWarning + "\" >&2\n")
+	}
+	b.WriteString("exec \"" + target + "\" \"$@\"\n")
+	return b.String()
+}
+
+// markerLine formats the marker identifying a managed shim.
+func markerLine(agentID, target string) string {
+	return markerPrefix + agentID + " -> " + target
+}
+
+// parseMarker extracts the agent ID and target from a shim's marker line.
+func parseMarker(content string) (agentID, target string, ok bool) {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		marker := line
+		if strings.HasPrefix(marker, "rem ") {
+			marker = "# " + strings.TrimPrefix(marker, "rem ")
+		}
+		if !strings.HasPrefix(marker, markerPrefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(marker, markerPrefix)
+		parts := strings.SplitN(rest, " -> ", 2)
+		if len(parts) != 2 {
+			return "", "", false
+		}
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
+
+// shimName derives the shim file name from the target executable.
+func shimName(target string) string {
+	return filepath.Base(target)
+}
@@ -0,0 +1,179 @@
+package shim
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+func testInstallation(t *testing.T, version string) *agent.Installation {
+	t.Helper()
+	target := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(target, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("failed to write target: %v", err)
+	}
+	return &agent.Installation{
+		AgentID:          "claude-code",
+		AgentName:        "Claude Code",
+		Method:           agent.InstallMethodNPM,
+		InstalledVersion: agent.MustParseVersion(version),
+		ExecutablePath:   target,
+	}
+}
+
+func TestEnableListDisable(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	inst := testInstallation(t, "1.2.3")
+
+	if err := mgr.Enable(inst, ""); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	if !mgr.IsEnabled("claude-code") {
+		t.Error("IsEnabled() = false after Enable()")
+	}
+
+	shims, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(shims) != 1 {
+		t.Fatalf("List() = %d shims, want 1", len(shims))
+	}
+	if shims[0].AgentID != "claude-code" || shims[0].Name != "claude" || shims[0].Target != inst.ExecutablePath {
+		t.Errorf("List()[0] = %+v", shims[0])
+	}
+
+	if err := mgr.Disable("claude-code"); err != nil {
+		t.Fatalf("Disable() error = %v", err)
+	}
+	if mgr.IsEnabled("claude-code") {
+		t.Error("IsEnabled() = true after Disable()")
+	}
+}
+
+func TestEnableWithoutExecutablePath(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	inst := &agent.Installation{AgentID: "claude-code"}
+
+	if err := mgr.Enable(inst, ""); err == nil {
+		t.Error("Enable() error = nil, want failure for missing executable path")
+	}
+}
+
+func TestShimScriptContents(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	inst := testInstallation(t, "2.0.0")
+
+	if err := mgr.Enable(inst, "~1.0"); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(mgr.BinDir(), "claude"))
+	if err != nil {
+		t.Fatalf("shim not written: %v", err)
+	}
+	script := string(data)
+
+	if !strings.Contains(script, `exec "`+inst.ExecutablePath+`" "$@"`) {
+		t.Errorf("shim does not forward to target:\n%s", script)
+	}
+	if !strings.Contains(script, "violates pin ~1.0") {
+		t.Errorf("shim does not warn about violated pin:\n%s", script)
+	}
+}
+
+func TestShimScriptNoPinWarningWhenSatisfied(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	inst := testInstallation(t, "1.2.3")
+
+	if err := mgr.Enable(inst, "~1.2"); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(mgr.BinDir(), "claude"))
+	if err != nil {
+		t.Fatalf("shim not written: %v", err)
+	}
+	if strings.Contains(string(data), "violates pin") {
+		t.Errorf("shim warns although the pin is satisfied:\n%s", data)
+	}
+}
+
+func TestLastUsed(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+
+	if _, ok := mgr.LastUsed("claude-code"); ok {
+		t.Error("LastUsed() = ok for agent without usage history")
+	}
+
+	if err := os.MkdirAll(mgr.usageDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().Unix()
+	log := strconv.FormatInt(now-3600, 10) + "\n" + strconv.FormatInt(now, 10) + "\n"
+	if err := os.WriteFile(mgr.usageLog("claude-code"), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := mgr.LastUsed("claude-code")
+	if !ok {
+		t.Fatal("LastUsed() = not ok, want recorded time")
+	}
+	if got.Unix() != now {
+		t.Errorf("LastUsed() = %v, want most recent entry %v", got.Unix(), now)
+	}
+}
+
+func TestSyncRepointsAndRemoves(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+
+	oldInst := testInstallation(t, "1.0.0")
+	if err := mgr.Enable(oldInst, ""); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+
+	// Same agent now installed elsewhere (e.g. after a method switch)
+	newInst := testInstallation(t, "1.1.0")
+	if err := mgr.Sync([]*agent.Installation{newInst}, nil); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	shims, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(shims) != 1 || shims[0].Target != newInst.ExecutablePath {
+		t.Errorf("Sync() did not repoint shim: %+v", shims)
+	}
+
+	// Agent uninstalled: its shim goes away
+	if err := mgr.Sync(nil, nil); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if mgr.IsEnabled("claude-code") {
+		t.Error("Sync() kept shim for uninstalled agent")
+	}
+}
+
+func TestListIgnoresUnmanagedFiles(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+	if err := os.MkdirAll(mgr.BinDir(), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mgr.BinDir(), "stray"), []byte("#!/bin/sh\necho hi\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	shims, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(shims) != 0 {
+		t.Errorf("List() = %+v, want unmanaged files ignored", shims)
+	}
+}
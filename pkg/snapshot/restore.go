@@ -0,0 +1,223 @@
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/installer"
+)
+
+// ActionType categorizes a restore plan step.
+type ActionType string
+
+const (
+	ActionInstall   ActionType = "install"
+	ActionUninstall ActionType = "uninstall"
+	ActionDowngrade ActionType = "downgrade"
+	ActionUpdate    ActionType = "update"
+)
+
+// Action is one step of a restore plan.
+type Action struct {
+	Type        ActionType `json:"type"`
+	AgentID     string     `json:"agent_id"`
+	AgentName   string     `json:"agent_name"`
+	Method      string     `json:"install_method"`
+	FromVersion string     `json:"from_version,omitempty"`
+	ToVersion   string     `json:"to_version,omitempty"`
+}
+
+// String returns a human-readable description of the action.
+func (a Action) String() string {
+	switch a.Type {
+	case ActionInstall:
+		return fmt.Sprintf("install %s (%s) %s", a.AgentID, a.Method, a.ToVersion)
+	case ActionUninstall:
+		return fmt.Sprintf("uninstall %s (%s) %s", a.AgentID, a.Method, a.FromVersion)
+	case ActionDowngrade:
+		return fmt.Sprintf("downgrade %s (%s) %s -> %s", a.AgentID, a.Method, a.FromVersion, a.ToVersion)
+	case ActionUpdate:
+		return fmt.Sprintf("update %s (%s) %s -> %s", a.AgentID, a.Method, a.FromVersion, a.ToVersion)
+	default:
+		return fmt.Sprintf("%s %s (%s)", a.Type, a.AgentID, a.Method)
+	}
+}
+
+// ComputePlan diffs a snapshot against the currently detected installations
+// and returns the actions needed to bring the environment back to the
+// snapshot state. Agents present in the snapshot but not installed are
+// installed; installed agents absent from the snapshot are uninstalled;
+// agents installed at a newer version than the snapshot are downgraded.
+// Agents installed at an older version are updated, which brings them to
+// the latest available version — package managers cannot reliably target
+// an arbitrary newer version otherwise.
+func ComputePlan(snap *Snapshot, current []*agent.Installation) []Action {
+	type stateKey struct{ agentID, method string }
+
+	installed := make(map[stateKey]*agent.Installation, len(current))
+	for _, inst := range current {
+		installed[stateKey{inst.AgentID, string(inst.Method)}] = inst
+	}
+
+	var plan []Action
+	inSnapshot := make(map[stateKey]bool, len(snap.Agents))
+
+	for _, state := range snap.Agents {
+		key := stateKey{state.AgentID, state.Method}
+		inSnapshot[key] = true
+
+		inst, ok := installed[key]
+		if !ok {
+			plan = append(plan, Action{
+				Type:      ActionInstall,
+				AgentID:   state.AgentID,
+				AgentName: state.AgentName,
+				Method:    state.Method,
+				ToVersion: state.Version,
+			})
+			continue
+		}
+
+		target, err := agent.ParseVersion(state.Version)
+		if err != nil || target.Equals(inst.InstalledVersion) {
+			continue
+		}
+
+		actionType := ActionUpdate
+		if target.IsOlderThan(inst.InstalledVersion) {
+			actionType = ActionDowngrade
+		}
+		plan = append(plan, Action{
+			Type:        actionType,
+			AgentID:     state.AgentID,
+			AgentName:   state.AgentName,
+			Method:      state.Method,
+			FromVersion: inst.InstalledVersion.String(),
+			ToVersion:   state.Version,
+		})
+	}
+
+	for key, inst := range installed {
+		if inSnapshot[key] {
+			continue
+		}
+		plan = append(plan, Action{
+			Type:        ActionUninstall,
+			AgentID:     inst.AgentID,
+			AgentName:   inst.AgentName,
+			Method:      string(inst.Method),
+			FromVersion: inst.InstalledVersion.String(),
+		})
+	}
+
+	sortPlan(plan)
+	return plan
+}
+
+// sortPlan orders a plan deterministically: uninstalls first, then by agent.
+func sortPlan(plan []Action) {
+	order := map[ActionType]int{
+		ActionUninstall: 0,
+		ActionDowngrade: 1,
+		ActionUpdate:    2,
+		ActionInstall:   3,
+	}
+	sort.SliceStable(plan, func(i, j int) bool {
+		if order[plan[i].Type] != order[plan[j].Type] {
+			return order[plan[i].Type] < order[plan[j].Type]
+		}
+		return plan[i].AgentID < plan[j].AgentID
+	})
+}
+
+// Restore executes a restore plan through the installer manager. It returns
+// the executed plan; if some actions fail the remaining actions still run
+// and the failures are joined into the returned error.
+func Restore(ctx context.Context, plan []Action, current []*agent.Installation, cat *catalog.Catalog, instMgr *installer.Manager) error {
+	installed := make(map[string]*agent.Installation, len(current))
+	for _, inst := range current {
+		installed[inst.AgentID+":"+string(inst.Method)] = inst
+	}
+
+	var failures []string
+	for _, action := range plan {
+		if err := executeAction(ctx, action, installed, cat, instMgr); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", action, err))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("failed to execute %d action(s):\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// executeAction runs one plan step via the installer manager.
+func executeAction(ctx context.Context, action Action, installed map[string]*agent.Installation, cat *catalog.Catalog, instMgr *installer.Manager) error {
+	agentDef, ok := cat.GetAgent(action.AgentID)
+	if !ok {
+		return fmt.Errorf("agent not found in catalog")
+	}
+
+	methodDef, ok := agentDef.GetInstallMethod(action.Method)
+	if !ok {
+		return fmt.Errorf("install method %q not defined in catalog", action.Method)
+	}
+
+	inst := installed[action.AgentID+":"+action.Method]
+
+	switch action.Type {
+	case ActionInstall:
+		result, err := instMgr.Install(ctx, agentDef, methodDef, false)
+		if err != nil {
+			return err
+		}
+		// The provider installs the latest version; downgrade afterwards
+		// if the snapshot pinned an older one.
+		target, perr := agent.ParseVersion(action.ToVersion)
+		got := result.Version
+		if perr == nil && target.IsOlderThan(got) {
+			fresh := &agent.Installation{
+				AgentID:          agentDef.ID,
+				AgentName:        agentDef.Name,
+				Method:           agent.InstallMethod(action.Method),
+				InstalledVersion: got,
+			}
+			if _, err := instMgr.Downgrade(ctx, fresh, agentDef, methodDef, target); err != nil {
+				return fmt.Errorf("installed %s but failed to downgrade to %s: %w", result.Version, action.ToVersion, err)
+			}
+		}
+		return nil
+
+	case ActionUninstall:
+		if inst == nil {
+			return fmt.Errorf("installation not found")
+		}
+		return instMgr.Uninstall(ctx, inst, methodDef)
+
+	case ActionDowngrade:
+		if inst == nil {
+			return fmt.Errorf("installation not found")
+		}
+		target, err := agent.ParseVersion(action.ToVersion)
+		if err != nil {
+			return fmt.Errorf("invalid target version %q: %w", action.ToVersion, err)
+		}
+		_, err = instMgr.Downgrade(ctx, inst, agentDef, methodDef, target)
+		return err
+
+	case ActionUpdate:
+		if inst == nil {
+			return fmt.Errorf("installation not found")
+		}
+		_, err := instMgr.Update(ctx, inst, agentDef, methodDef)
+		return err
+
+	default:
+		return fmt.Errorf("unknown action type: %s", action.Type)
+	}
+}
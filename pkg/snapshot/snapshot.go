@@ -0,0 +1,225 @@
+// Package snapshot captures the full agent environment — installed agents
+// with their versions and install methods, version pins, and per-agent
+// configuration overrides — into timestamped archives stored locally, and
+// restores the environment to a snapshot by computing and executing an
+// install/uninstall/downgrade plan.
+package snapshot
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+// manifestName is the manifest file stored inside each snapshot archive.
+const manifestName = "snapshot.json"
+
+// AgentState records one installed agent at snapshot time.
+type AgentState struct {
+	AgentID   string `json:"agent_id"`
+	AgentName string `json:"agent_name"`
+	Method    string `json:"install_method"`
+	Version   string `json:"version"`
+	IsGlobal  bool   `json:"is_global,omitempty"`
+}
+
+// Snapshot is the captured state of the agent environment.
+type Snapshot struct {
+	ID        string    `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Agents lists the installed agents with their versions and methods.
+	Agents []AgentState `json:"agents"`
+
+	// AgentConfigs preserves per-agent configuration overrides, including
+	// pinned versions and preferred install methods.
+	AgentConfigs map[string]config.AgentConfig `json:"agent_configs,omitempty"`
+}
+
+// Manager creates, lists, and reads snapshot archives under a data directory.
+type Manager struct {
+	dir string
+}
+
+// NewManager creates a snapshot manager storing archives in the "snapshots"
+// subdirectory of the given data directory.
+func NewManager(dataDir string) *Manager {
+	return &Manager{dir: filepath.Join(dataDir, "snapshots")}
+}
+
+// Dir returns the directory snapshots are stored in.
+func (m *Manager) Dir() string {
+	return m.dir
+}
+
+// Create captures the given installations and per-agent configuration into
+// a new timestamped snapshot archive.
+func (m *Manager) Create(installations []*agent.Installation, cfg *config.Config) (*Snapshot, error) {
+	now := time.Now().UTC()
+	snap := &Snapshot{
+		ID:        now.Format("20060102-150405"),
+		CreatedAt: now,
+	}
+
+	for _, inst := range installations {
+		snap.Agents = append(snap.Agents, AgentState{
+			AgentID:   inst.AgentID,
+			AgentName: inst.AgentName,
+			Method:    string(inst.Method),
+			Version:   inst.InstalledVersion.String(),
+			IsGlobal:  inst.IsGlobal,
+		})
+	}
+	sort.Slice(snap.Agents, func(i, j int) bool {
+		if snap.Agents[i].AgentID != snap.Agents[j].AgentID {
+			return snap.Agents[i].AgentID < snap.Agents[j].AgentID
+		}
+		return snap.Agents[i].Method < snap.Agents[j].Method
+	})
+
+	if cfg != nil && len(cfg.Agents) > 0 {
+		snap.AgentConfigs = make(map[string]config.AgentConfig, len(cfg.Agents))
+		for id, agentCfg := range cfg.Agents {
+			snap.AgentConfigs[id] = agentCfg
+		}
+	}
+
+	if err := m.write(snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// List returns all stored snapshots, newest first.
+func (m *Manager) List() ([]*Snapshot, error) {
+	entries, err := os.ReadDir(m.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshots directory: %w", err)
+	}
+
+	var snapshots []*Snapshot
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".tar.gz") {
+			continue
+		}
+		snap, err := m.read(filepath.Join(m.dir, entry.Name()))
+		if err != nil {
+			continue // Skip unreadable archives rather than failing the list
+		}
+		snapshots = append(snapshots, snap)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].CreatedAt.After(snapshots[j].CreatedAt)
+	})
+	return snapshots, nil
+}
+
+// Get returns the snapshot with the given ID.
+func (m *Manager) Get(id string) (*Snapshot, error) {
+	snap, err := m.read(m.archivePath(id))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("snapshot %q not found", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// archivePath returns the archive file path for a snapshot ID.
+func (m *Manager) archivePath(id string) string {
+	return filepath.Join(m.dir, id+".tar.gz")
+}
+
+// write stores a snapshot as a gzipped tar archive containing the manifest.
+func (m *Manager) write(snap *Snapshot) error {
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create snapshots directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	f, err := os.Create(m.archivePath(snap.ID))
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot archive: %w", err)
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	tw := tar.NewWriter(gzw)
+
+	header := &tar.Header{
+		Name:    manifestName,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: snap.CreatedAt,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write snapshot archive: %w", err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write snapshot archive: %w", err)
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize snapshot archive: %w", err)
+	}
+	if err := gzw.Close(); err != nil {
+		return fmt.Errorf("failed to finalize snapshot archive: %w", err)
+	}
+	return f.Close()
+}
+
+// read loads a snapshot manifest from an archive file.
+func (m *Manager) read(path string) (*Snapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+	}
+	defer gzr.Close()
+
+	tr := tar.NewReader(gzr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read snapshot archive: %w", err)
+		}
+		if header.Name != manifestName {
+			continue
+		}
+
+		var snap Snapshot
+		if err := json.NewDecoder(tr).Decode(&snap); err != nil {
+			return nil, fmt.Errorf("failed to decode snapshot manifest: %w", err)
+		}
+		return &snap, nil
+	}
+
+	return nil, fmt.Errorf("snapshot archive %s has no manifest", filepath.Base(path))
+}
@@ -0,0 +1,157 @@
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func mustVersion(t *testing.T, s string) agent.Version {
+	t.Helper()
+	v, err := agent.ParseVersion(s)
+	if err != nil {
+		t.Fatalf("failed to parse version %q: %v", s, err)
+	}
+	return v
+}
+
+func testInstallation(t *testing.T, agentID, method, version string) *agent.Installation {
+	t.Helper()
+	return &agent.Installation{
+		AgentID:          agentID,
+		AgentName:        agentID,
+		Method:           agent.InstallMethod(method),
+		InstalledVersion: mustVersion(t, version),
+	}
+}
+
+func TestCreateAndGetRoundtrip(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+
+	cfg := config.Default()
+	cfg.Agents = map[string]config.AgentConfig{
+		"aider": {PinnedVersion: "1.2.3", PreferredMethod: "pipx"},
+	}
+
+	installations := []*agent.Installation{
+		testInstallation(t, "aider", "pipx", "1.2.3"),
+		testInstallation(t, "claude-code", "npm", "2.0.0"),
+	}
+
+	created, err := mgr.Create(installations, cfg)
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("expected a snapshot ID")
+	}
+
+	got, err := mgr.Get(created.ID)
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	if len(got.Agents) != 2 {
+		t.Fatalf("expected 2 agents, got %d", len(got.Agents))
+	}
+	// Agents are sorted by ID, then method
+	if got.Agents[0].AgentID != "aider" || got.Agents[0].Version != "1.2.3" {
+		t.Errorf("unexpected first agent: %+v", got.Agents[0])
+	}
+	if got.Agents[1].AgentID != "claude-code" || got.Agents[1].Method != "npm" {
+		t.Errorf("unexpected second agent: %+v", got.Agents[1])
+	}
+
+	agentCfg, ok := got.AgentConfigs["aider"]
+	if !ok {
+		t.Fatal("expected per-agent config for aider")
+	}
+	if agentCfg.PinnedVersion != "1.2.3" || agentCfg.PreferredMethod != "pipx" {
+		t.Errorf("unexpected agent config: %+v", agentCfg)
+	}
+}
+
+func TestGetMissingSnapshot(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+
+	if _, err := mgr.Get("20240101-000000"); err == nil {
+		t.Fatal("expected error for missing snapshot")
+	}
+}
+
+func TestListEmpty(t *testing.T) {
+	mgr := NewManager(t.TempDir())
+
+	snapshots, err := mgr.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(snapshots) != 0 {
+		t.Fatalf("expected no snapshots, got %d", len(snapshots))
+	}
+}
+
+func TestComputePlan(t *testing.T) {
+	snap := &Snapshot{
+		ID: "test",
+		Agents: []AgentState{
+			{AgentID: "aider", Method: "pipx", Version: "1.0.0"},
+			{AgentID: "claude-code", Method: "npm", Version: "2.0.0"},
+			{AgentID: "goose", Method: "brew", Version: "0.9.0"},
+			{AgentID: "unchanged", Method: "npm", Version: "3.0.0"},
+		},
+	}
+
+	current := []*agent.Installation{
+		testInstallation(t, "aider", "pipx", "1.5.0"),      // Newer than snapshot: downgrade
+		testInstallation(t, "goose", "brew", "0.8.0"),      // Older than snapshot: update
+		testInstallation(t, "unchanged", "npm", "3.0.0"),   // Matches: no action
+		testInstallation(t, "extra-agent", "npm", "1.0.0"), // Not in snapshot: uninstall
+	}
+
+	plan := ComputePlan(snap, current)
+	if len(plan) != 4 {
+		t.Fatalf("expected 4 actions, got %d: %v", len(plan), plan)
+	}
+
+	// Plan is ordered: uninstalls, downgrades, updates, installs
+	expected := []struct {
+		actionType ActionType
+		agentID    string
+	}{
+		{ActionUninstall, "extra-agent"},
+		{ActionDowngrade, "aider"},
+		{ActionUpdate, "goose"},
+		{ActionInstall, "claude-code"},
+	}
+
+	for i, want := range expected {
+		if plan[i].Type != want.actionType || plan[i].AgentID != want.agentID {
+			t.Errorf("action %d = %s %s, want %s %s",
+				i, plan[i].Type, plan[i].AgentID, want.actionType, want.agentID)
+		}
+	}
+
+	if plan[1].FromVersion != "1.5.0" || plan[1].ToVersion != "1.0.0" {
+		t.Errorf("downgrade versions = %s -> %s, want 1.5.0 -> 1.0.0",
+			plan[1].FromVersion, plan[1].ToVersion)
+	}
+}
+
+func TestComputePlanNoChanges(t *testing.T) {
+	snap := &Snapshot{
+		ID: "test",
+		Agents: []AgentState{
+			{AgentID: "aider", Method: "pipx", Version: "1.0.0"},
+		},
+	}
+
+	current := []*agent.Installation{
+		testInstallation(t, "aider", "pipx", "1.0.0"),
+	}
+
+	if plan := ComputePlan(snap, current); len(plan) != 0 {
+		t.Fatalf("expected empty plan, got %v", plan)
+	}
+}
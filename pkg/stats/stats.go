@@ -0,0 +1,200 @@
+// Package stats aggregates opt-in usage statistics: which agents are
+// used most, how quickly updates are applied after release, and roughly
+// how much manual effort automatic updates have saved. Everything is
+// computed locally from the usage tracker and update history; nothing
+// leaves the machine.
+package stats
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+	"github.com/kevinelliott/agentmgr/pkg/usage"
+)
+
+// historyLimit caps how many update events per agent feed the report.
+const historyLimit = 50
+
+// minutesSavedPerUpdate approximates the manual effort one applied
+// update saves — finding the release, running the package manager and
+// verifying the result — used for the time-saved figure.
+const minutesSavedPerUpdate = 5
+
+// ReleaseSource looks up published releases for update-lag calculation.
+// catalog.Manager satisfies it; a nil source skips lag figures.
+type ReleaseSource interface {
+	GetReleases(ctx context.Context, agentID string, limit int) ([]catalog.Release, error)
+}
+
+// AgentStats summarizes one agent's usage and update behavior.
+type AgentStats struct {
+	AgentID   string     `json:"agent_id"`
+	AgentName string     `json:"agent_name"`
+	LastUsed  *time.Time `json:"last_used,omitempty"`
+
+	// Updates counts completed updates recorded in the history.
+	Updates int `json:"updates"`
+
+	// UpdateLag is the average time between a release being published
+	// and the update being applied, across updates with a known release
+	// date. Zero when no lag could be determined.
+	UpdateLag time.Duration `json:"update_lag,omitempty"`
+}
+
+// Report is the aggregated statistics view.
+type Report struct {
+	GeneratedAt time.Time    `json:"generated_at"`
+	Agents      []AgentStats `json:"agents"`
+
+	// TotalUpdates sums the completed updates across all agents.
+	TotalUpdates int `json:"total_updates"`
+
+	// TimeSaved estimates the manual effort the applied updates saved.
+	TimeSaved time.Duration `json:"time_saved"`
+}
+
+// Aggregator builds statistics reports from the store's usage and
+// update history.
+type Aggregator struct {
+	store    storage.Store
+	releases ReleaseSource
+}
+
+// NewAggregator creates an aggregator. The release source may be nil, in
+// which case update-lag figures are omitted.
+func NewAggregator(store storage.Store, releases ReleaseSource) *Aggregator {
+	return &Aggregator{store: store, releases: releases}
+}
+
+// Report aggregates statistics for the given installations, most
+// recently used agents first.
+func (a *Aggregator) Report(ctx context.Context, installations []*agent.Installation) *Report {
+	usage.NewTracker(a.store).Sample(ctx, installations)
+
+	report := &Report{GeneratedAt: time.Now()}
+	seen := make(map[string]bool)
+
+	for _, inst := range installations {
+		if seen[inst.AgentID] {
+			continue
+		}
+		seen[inst.AgentID] = true
+
+		s := AgentStats{
+			AgentID:   inst.AgentID,
+			AgentName: inst.AgentName,
+			LastUsed:  inst.LastUsed,
+		}
+		s.Updates, s.UpdateLag = a.updateFigures(ctx, inst.AgentID)
+		report.TotalUpdates += s.Updates
+		report.Agents = append(report.Agents, s)
+	}
+
+	report.TimeSaved = time.Duration(report.TotalUpdates) * minutesSavedPerUpdate * time.Minute
+
+	// Most recently used first; never-used agents sink to the bottom
+	sort.SliceStable(report.Agents, func(i, j int) bool {
+		ti, tj := report.Agents[i].LastUsed, report.Agents[j].LastUsed
+		switch {
+		case ti == nil:
+			return false
+		case tj == nil:
+			return true
+		default:
+			return ti.After(*tj)
+		}
+	})
+
+	return report
+}
+
+// updateFigures counts completed updates for an agent and averages the
+// lag between release publication and update completion.
+func (a *Aggregator) updateFigures(ctx context.Context, agentID string) (int, time.Duration) {
+	if a.store == nil {
+		return 0, 0
+	}
+	events, err := a.store.GetUpdateHistory(ctx, agentID, historyLimit)
+	if err != nil {
+		return 0, 0
+	}
+
+	published := a.releaseDates(ctx, agentID)
+
+	var updates int
+	var lagTotal time.Duration
+	var lagged int
+	for _, event := range events {
+		if event.Kind() != "update" || event.Status != storage.UpdateStatusCompleted || event.CompletedAt == nil {
+			continue
+		}
+		updates++
+
+		releasedAt, ok := published[event.ToVersion]
+		if !ok || releasedAt.IsZero() {
+			continue
+		}
+		if lag := event.CompletedAt.Sub(releasedAt); lag > 0 {
+			lagTotal += lag
+			lagged++
+		}
+	}
+
+	if lagged == 0 {
+		return updates, 0
+	}
+	return updates, lagTotal / time.Duration(lagged)
+}
+
+// releaseDates maps an agent's release versions to their publish times.
+func (a *Aggregator) releaseDates(ctx context.Context, agentID string) map[string]time.Time {
+	if a.releases == nil {
+		return nil
+	}
+	releases, err := a.releases.GetReleases(ctx, agentID, historyLimit)
+	if err != nil {
+		return nil
+	}
+	dates := make(map[string]time.Time, len(releases))
+	for _, release := range releases {
+		dates[release.Version] = release.PublishedAt
+	}
+	return dates
+}
+
+// CSV renders the report as CSV, one row per agent.
+func (r *Report) CSV() ([]byte, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+
+	if err := w.Write([]string{"agent_id", "agent_name", "last_used", "updates", "avg_update_lag_hours"}); err != nil {
+		return nil, err
+	}
+	for _, s := range r.Agents {
+		lastUsed := ""
+		if s.LastUsed != nil && !s.LastUsed.IsZero() {
+			lastUsed = s.LastUsed.UTC().Format(time.RFC3339)
+		}
+		lag := ""
+		if s.UpdateLag > 0 {
+			lag = fmt.Sprintf("%.1f", s.UpdateLag.Hours())
+		}
+		row := []string{s.AgentID, s.AgentName, lastUsed, fmt.Sprintf("%d", s.Updates), lag}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return []byte(b.String()), nil
+}
@@ -0,0 +1,129 @@
+package stats
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// fakeReleases serves canned release dates for lag calculation.
+type fakeReleases struct {
+	releases map[string][]catalog.Release
+}
+
+func (f *fakeReleases) GetReleases(ctx context.Context, agentID string, limit int) ([]catalog.Release, error) {
+	return f.releases[agentID], nil
+}
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	store := agentmgrtest.NewFakeStore()
+
+	released := time.Date(2026, 8, 1, 0, 0, 0, 0, time.UTC)
+	completed := released.Add(48 * time.Hour)
+	if err := store.SaveUpdateEvent(ctx, &storage.UpdateEvent{
+		AgentID:     "aider",
+		AgentName:   "Aider",
+		FromVersion: "1.0.0",
+		ToVersion:   "1.1.0",
+		Status:      storage.UpdateStatusCompleted,
+		StartedAt:   completed.Add(-time.Minute),
+		CompletedAt: &completed,
+	}); err != nil {
+		t.Fatalf("SaveUpdateEvent() error = %v", err)
+	}
+
+	releases := &fakeReleases{releases: map[string][]catalog.Release{
+		"aider": {{Version: "1.1.0", PublishedAt: released}},
+	}}
+
+	installations := []*agent.Installation{
+		{AgentID: "aider", AgentName: "Aider"},
+		{AgentID: "goose", AgentName: "Goose"},
+	}
+
+	report := NewAggregator(store, releases).Report(ctx, installations)
+
+	if len(report.Agents) != 2 {
+		t.Fatalf("agents = %d, want 2", len(report.Agents))
+	}
+	if report.TotalUpdates != 1 {
+		t.Errorf("TotalUpdates = %d, want 1", report.TotalUpdates)
+	}
+	if report.TimeSaved != minutesSavedPerUpdate*time.Minute {
+		t.Errorf("TimeSaved = %v, want %v", report.TimeSaved, minutesSavedPerUpdate*time.Minute)
+	}
+
+	var aider *AgentStats
+	for i := range report.Agents {
+		if report.Agents[i].AgentID == "aider" {
+			aider = &report.Agents[i]
+		}
+	}
+	if aider == nil {
+		t.Fatal("aider missing from report")
+	}
+	if aider.Updates != 1 {
+		t.Errorf("aider updates = %d, want 1", aider.Updates)
+	}
+	if aider.UpdateLag != 48*time.Hour {
+		t.Errorf("aider update lag = %v, want 48h", aider.UpdateLag)
+	}
+}
+
+func TestReportSortsByLastUsed(t *testing.T) {
+	ctx := context.Background()
+	older := time.Now().Add(-48 * time.Hour)
+	newer := time.Now().Add(-time.Hour)
+
+	installations := []*agent.Installation{
+		{AgentID: "goose", AgentName: "Goose"},
+		{AgentID: "aider", AgentName: "Aider", LastUsed: &older},
+		{AgentID: "claude-code", AgentName: "Claude Code", LastUsed: &newer},
+	}
+
+	report := NewAggregator(nil, nil).Report(ctx, installations)
+
+	got := []string{report.Agents[0].AgentID, report.Agents[1].AgentID, report.Agents[2].AgentID}
+	want := []string{"claude-code", "aider", "goose"}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("sort order = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestReportCSV(t *testing.T) {
+	used := time.Date(2026, 8, 20, 10, 0, 0, 0, time.UTC)
+	report := &Report{
+		Agents: []AgentStats{
+			{AgentID: "aider", AgentName: "Aider", LastUsed: &used, Updates: 3, UpdateLag: 36 * time.Hour},
+			{AgentID: "goose", AgentName: "Goose"},
+		},
+	}
+
+	out, err := report.CSV()
+	if err != nil {
+		t.Fatalf("CSV() error = %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("csv lines = %d, want 3:\n%s", len(lines), out)
+	}
+	if lines[0] != "agent_id,agent_name,last_used,updates,avg_update_lag_hours" {
+		t.Errorf("header = %q", lines[0])
+	}
+	if lines[1] != "aider,Aider,2026-08-20T10:00:00Z,3,36.0" {
+		t.Errorf("aider row = %q", lines[1])
+	}
+	if lines[2] != "goose,Goose,,0," {
+		t.Errorf("goose row = %q", lines[2])
+	}
+}
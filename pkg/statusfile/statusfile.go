@@ -0,0 +1,85 @@
+// Package statusfile publishes a small machine-readable summary of agent
+// status to a file on disk, so statusbars (waybar, polybar, SketchyBar)
+// and shell prompts can show agent counts without talking to the IPC
+// socket. The file is rewritten on every refresh and is safe to poll.
+package statusfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+// Status is the content of the status file. Consumers parse this from
+// shell scripts, so fields are only ever added, never renamed or removed.
+type Status struct {
+	// Agents is the number of detected agent installations.
+	Agents int `json:"agents"`
+
+	// UpdatesAvailable counts installations with a newer version available.
+	UpdatesAvailable int `json:"updates_available"`
+
+	// LastCheck is when updates were last checked; zero when no check
+	// has run yet.
+	LastCheck time.Time `json:"last_check"`
+
+	// GeneratedAt is when the file was written.
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Build summarizes the given installations into a status snapshot.
+func Build(installations []*agent.Installation, lastCheck time.Time) Status {
+	status := Status{
+		Agents:      len(installations),
+		LastCheck:   lastCheck,
+		GeneratedAt: time.Now(),
+	}
+	for _, inst := range installations {
+		if inst.HasUpdate() {
+			status.UpdatesAvailable++
+		}
+	}
+	return status
+}
+
+// DefaultPath returns the well-known status file location inside the
+// data directory.
+func DefaultPath(dataDir string) string {
+	return filepath.Join(dataDir, "status.json")
+}
+
+// Write renders the status as JSON and writes it via a temp file and
+// rename, so pollers never observe a partially written file. A leading
+// "~" in path expands to the user's home directory.
+func Write(path string, status Status) error {
+	path = expandPath(path)
+
+	data, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// expandPath expands a leading "~" to the user's home directory.
+func expandPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
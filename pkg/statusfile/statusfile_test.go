@@ -0,0 +1,101 @@
+package statusfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+func TestBuild(t *testing.T) {
+	current := agent.MustParseVersion("1.0.0")
+	newer := agent.MustParseVersion("2.0.0")
+
+	installations := []*agent.Installation{
+		{AgentID: "claude-code", InstalledVersion: current, LatestVersion: &newer},
+		{AgentID: "aider", InstalledVersion: current, LatestVersion: &current},
+		{AgentID: "goose", InstalledVersion: current},
+	}
+
+	lastCheck := time.Now().Add(-time.Hour)
+	status := Build(installations, lastCheck)
+
+	if status.Agents != 3 {
+		t.Errorf("Agents = %d, want 3", status.Agents)
+	}
+	if status.UpdatesAvailable != 1 {
+		t.Errorf("UpdatesAvailable = %d, want 1", status.UpdatesAvailable)
+	}
+	if !status.LastCheck.Equal(lastCheck) {
+		t.Errorf("LastCheck = %v, want %v", status.LastCheck, lastCheck)
+	}
+	if status.GeneratedAt.IsZero() {
+		t.Error("GeneratedAt should be set")
+	}
+}
+
+func TestBuildEmpty(t *testing.T) {
+	status := Build(nil, time.Time{})
+	if status.Agents != 0 || status.UpdatesAvailable != 0 {
+		t.Errorf("empty build = %+v, want zero counts", status)
+	}
+}
+
+func TestWriteRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bar", "status.json")
+	status := Status{
+		Agents:           5,
+		UpdatesAvailable: 2,
+		LastCheck:        time.Now().Truncate(time.Second),
+		GeneratedAt:      time.Now().Truncate(time.Second),
+	}
+
+	if err := Write(path, status); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading status file: %v", err)
+	}
+
+	var got Status
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("status file is not valid JSON: %v", err)
+	}
+	if got.Agents != 5 || got.UpdatesAvailable != 2 {
+		t.Errorf("round-trip = %+v, want %+v", got, status)
+	}
+	if !got.LastCheck.Equal(status.LastCheck) {
+		t.Errorf("LastCheck = %v, want %v", got.LastCheck, status.LastCheck)
+	}
+
+	// No temp file should be left behind
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("temp file left behind after write")
+	}
+}
+
+func TestWriteExpandsHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home) // os.UserHomeDir on Windows
+
+	if err := Write("~/status.json", Build(nil, time.Time{})); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(home, "status.json")); err != nil {
+		t.Errorf("status file not written under home: %v", err)
+	}
+}
+
+func TestDefaultPath(t *testing.T) {
+	got := DefaultPath(filepath.Join("data", "agentmgr"))
+	want := filepath.Join("data", "agentmgr", "status.json")
+	if got != want {
+		t.Errorf("DefaultPath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,36 @@
+package statusfile
+
+import (
+	"fmt"
+	"time"
+)
+
+// WidgetGroupID is the macOS App Group shared between the helper and a
+// WidgetKit extension or Shortcuts action, which read the status plist
+// from the group container.
+const WidgetGroupID = "group.net.kevinelliott.agentmgr"
+
+// plistDate formats a time the way property list <date> elements expect.
+func plistDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// WidgetPlist renders the status as an XML property list, the format
+// macOS widgets and Shortcuts read natively.
+func WidgetPlist(status Status) []byte {
+	return []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+    <key>Agents</key>
+    <integer>%d</integer>
+    <key>UpdatesAvailable</key>
+    <integer>%d</integer>
+    <key>LastCheck</key>
+    <date>%s</date>
+    <key>GeneratedAt</key>
+    <date>%s</date>
+</dict>
+</plist>
+`, status.Agents, status.UpdatesAvailable, plistDate(status.LastCheck), plistDate(status.GeneratedAt)))
+}
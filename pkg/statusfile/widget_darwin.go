@@ -0,0 +1,34 @@
+package statusfile
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// WidgetPath returns the status plist location inside the shared App
+// Group container.
+func WidgetPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Library", "Group Containers", WidgetGroupID, "status.plist"), nil
+}
+
+// WriteWidget publishes the status plist to the App Group container so
+// widgets and shortcuts can read it. Written via temp file and rename so
+// readers never see a partial plist.
+func WriteWidget(status Status) error {
+	path, err := WidgetPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, WidgetPlist(status), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
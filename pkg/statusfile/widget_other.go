@@ -0,0 +1,8 @@
+//go:build !darwin
+
+package statusfile
+
+// WriteWidget is a no-op on platforms without WidgetKit.
+func WriteWidget(Status) error {
+	return nil
+}
@@ -0,0 +1,38 @@
+package statusfile
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWidgetPlist(t *testing.T) {
+	status := Status{
+		Agents:           6,
+		UpdatesAvailable: 2,
+		LastCheck:        time.Date(2026, 8, 1, 12, 30, 0, 0, time.UTC),
+		GeneratedAt:      time.Date(2026, 8, 1, 12, 31, 0, 0, time.UTC),
+	}
+
+	plist := string(WidgetPlist(status))
+
+	for _, want := range []string{
+		`<plist version="1.0">`,
+		"<key>Agents</key>\n    <integer>6</integer>",
+		"<key>UpdatesAvailable</key>\n    <integer>2</integer>",
+		"<key>LastCheck</key>\n    <date>2026-08-01T12:30:00Z</date>",
+		"<key>GeneratedAt</key>\n    <date>2026-08-01T12:31:00Z</date>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("plist missing %q:\n%s", want, plist)
+		}
+	}
+}
+
+func TestPlistDateConvertsToUTC(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	got := plistDate(time.Date(2026, 8, 1, 14, 0, 0, 0, loc))
+	if got != "2026-08-01T12:00:00Z" {
+		t.Errorf("plistDate() = %q, want %q", got, "2026-08-01T12:00:00Z")
+	}
+}
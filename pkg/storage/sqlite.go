@@ -6,6 +6,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/mattn/go-sqlite3"
@@ -17,6 +19,12 @@ import (
 type SQLiteStore struct {
 	db     *sql.DB
 	dbPath string
+
+	// writeMu serializes writes from this process. SQLite allows only
+	// one writer at a time, and without this concurrent helper, CLI and
+	// REST writers would trip "database is locked" errors even in WAL
+	// mode.
+	writeMu sync.Mutex
 }
 
 // NewSQLiteStore creates a new SQLite store at the given path.
@@ -29,7 +37,7 @@ func NewSQLiteStore(dataDir string) (*SQLiteStore, error) {
 
 // Initialize opens the database and runs migrations.
 func (s *SQLiteStore) Initialize(ctx context.Context) error {
-	db, err := sql.Open("sqlite3", s.dbPath+"?_journal_mode=WAL&_foreign_keys=ON")
+	db, err := sql.Open("sqlite3", s.dbPath+"?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=ON")
 	if err != nil {
 		return fmt.Errorf("failed to open database: %w", err)
 	}
@@ -128,6 +136,25 @@ func (s *SQLiteStore) migrate(ctx context.Context) error {
 			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
 			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		)`,
+
+		// Jobs table
+		`CREATE TABLE IF NOT EXISTS jobs (
+			id TEXT PRIMARY KEY,
+			type TEXT NOT NULL,
+			agent_id TEXT NOT NULL,
+			agent_name TEXT NOT NULL DEFAULT '',
+			install_method TEXT NOT NULL DEFAULT '',
+			version TEXT NOT NULL DEFAULT '',
+			state TEXT NOT NULL DEFAULT 'queued',
+			error_message TEXT NOT NULL DEFAULT '',
+			output TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP NOT NULL,
+			started_at TIMESTAMP,
+			finished_at TIMESTAMP
+		)`,
+
+		// Index on state for queue and recovery scans
+		`CREATE INDEX IF NOT EXISTS idx_jobs_state ON jobs(state)`,
 	}
 
 	for _, migration := range migrations {
@@ -136,11 +163,26 @@ func (s *SQLiteStore) migrate(ctx context.Context) error {
 		}
 	}
 
+	// Additive column migrations; SQLite has no ADD COLUMN IF NOT EXISTS,
+	// so duplicate-column errors from re-runs are ignored
+	alters := []string{
+		`ALTER TABLE update_events ADD COLUMN output TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE update_events ADD COLUMN retries INTEGER NOT NULL DEFAULT 0`,
+	}
+	for _, alter := range alters {
+		if _, err := s.db.ExecContext(ctx, alter); err != nil && !strings.Contains(err.Error(), "duplicate column") {
+			return fmt.Errorf("migration failed: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // SaveInstallation saves or updates an installation record.
 func (s *SQLiteStore) SaveInstallation(ctx context.Context, inst *agent.Installation) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	record := FromInstallation(inst)
 
 	metadataJSON, err := json.Marshal(record.Metadata)
@@ -300,6 +342,9 @@ func (s *SQLiteStore) ListInstallations(ctx context.Context, filter *agent.Filte
 
 // DeleteInstallation removes an installation record.
 func (s *SQLiteStore) DeleteInstallation(ctx context.Context, key string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	result, err := s.db.ExecContext(ctx, "DELETE FROM installations WHERE key = ?", key)
 	if err != nil {
 		return fmt.Errorf("failed to delete installation: %w", err)
@@ -318,17 +363,20 @@ func (s *SQLiteStore) DeleteInstallation(ctx context.Context, key string) error
 
 // SaveUpdateEvent records an update event.
 func (s *SQLiteStore) SaveUpdateEvent(ctx context.Context, event *UpdateEvent) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	if event.ID == 0 {
 		// Insert new event
 		query := `
 			INSERT INTO update_events (
 				agent_id, agent_name, install_method, from_version, to_version,
-				status, error_message, started_at, completed_at
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+				status, error_message, output, retries, started_at, completed_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		`
 		result, err := s.db.ExecContext(ctx, query,
 			event.AgentID, event.AgentName, event.InstallMethod, event.FromVersion, event.ToVersion,
-			event.Status, event.ErrorMessage, event.StartedAt, event.CompletedAt,
+			event.Status, event.ErrorMessage, event.Output, event.Retries, event.StartedAt, event.CompletedAt,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to save update event: %w", err)
@@ -343,11 +391,11 @@ func (s *SQLiteStore) SaveUpdateEvent(ctx context.Context, event *UpdateEvent) e
 		// Update existing event
 		query := `
 			UPDATE update_events SET
-				status = ?, error_message = ?, completed_at = ?
+				status = ?, error_message = ?, output = ?, retries = ?, completed_at = ?
 			WHERE id = ?
 		`
 		_, err := s.db.ExecContext(ctx, query,
-			event.Status, event.ErrorMessage, event.CompletedAt, event.ID,
+			event.Status, event.ErrorMessage, event.Output, event.Retries, event.CompletedAt, event.ID,
 		)
 		if err != nil {
 			return fmt.Errorf("failed to update event: %w", err)
@@ -361,7 +409,7 @@ func (s *SQLiteStore) SaveUpdateEvent(ctx context.Context, event *UpdateEvent) e
 func (s *SQLiteStore) GetUpdateHistory(ctx context.Context, agentID string, limit int) ([]*UpdateEvent, error) {
 	query := `
 		SELECT id, agent_id, agent_name, install_method, from_version, to_version,
-			status, error_message, started_at, completed_at
+			status, error_message, output, retries, started_at, completed_at
 		FROM update_events
 		WHERE agent_id = ?
 		ORDER BY started_at DESC
@@ -382,7 +430,7 @@ func (s *SQLiteStore) GetUpdateHistory(ctx context.Context, agentID string, limi
 		err := rows.Scan(
 			&event.ID, &event.AgentID, &event.AgentName, &event.InstallMethod,
 			&event.FromVersion, &event.ToVersion, &event.Status, &event.ErrorMessage,
-			&event.StartedAt, &completedAt,
+			&event.Output, &event.Retries, &event.StartedAt, &completedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan update event: %w", err)
@@ -404,6 +452,9 @@ func (s *SQLiteStore) GetUpdateHistory(ctx context.Context, agentID string, limi
 
 // SaveCatalogCache stores the catalog cache.
 func (s *SQLiteStore) SaveCatalogCache(ctx context.Context, data []byte, etag string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	query := `
 		INSERT INTO catalog_cache (id, data, etag, cached_at)
 		VALUES (1, ?, ?, ?)
@@ -459,6 +510,9 @@ func (s *SQLiteStore) GetSetting(ctx context.Context, key string) (string, error
 
 // SetSetting stores a setting value.
 func (s *SQLiteStore) SetSetting(ctx context.Context, key, value string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	query := `
 		INSERT INTO settings (key, value) VALUES (?, ?)
 		ON CONFLICT(key) DO UPDATE SET
@@ -476,6 +530,9 @@ func (s *SQLiteStore) SetSetting(ctx context.Context, key, value string) error {
 
 // DeleteSetting removes a setting.
 func (s *SQLiteStore) DeleteSetting(ctx context.Context, key string) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	_, err := s.db.ExecContext(ctx, "DELETE FROM settings WHERE key = ?", key)
 	if err != nil {
 		return fmt.Errorf("failed to delete setting: %w", err)
@@ -485,6 +542,9 @@ func (s *SQLiteStore) DeleteSetting(ctx context.Context, key string) error {
 
 // SaveDetectionCache stores the detected agents cache.
 func (s *SQLiteStore) SaveDetectionCache(ctx context.Context, installations []*agent.Installation) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	// Convert installations to records for JSON serialization
 	records := make([]*InstallationRecord, 0, len(installations))
 	for _, inst := range installations {
@@ -543,6 +603,9 @@ func (s *SQLiteStore) GetDetectionCache(ctx context.Context) ([]*agent.Installat
 
 // ClearDetectionCache removes the detection cache.
 func (s *SQLiteStore) ClearDetectionCache(ctx context.Context) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
 	_, err := s.db.ExecContext(ctx, "DELETE FROM detection_cache WHERE id = 1")
 	if err != nil {
 		return fmt.Errorf("failed to clear detection cache: %w", err)
@@ -582,3 +645,121 @@ func (s *SQLiteStore) GetLastUpdateCheckTime(ctx context.Context) (time.Time, er
 	}
 	return time.Parse(time.RFC3339, val)
 }
+
+// SaveJob inserts or updates a job record.
+func (s *SQLiteStore) SaveJob(ctx context.Context, job *Job) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	query := `
+		INSERT INTO jobs (
+			id, type, agent_id, agent_name, install_method, version,
+			state, error_message, output, created_at, started_at, finished_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET
+			state = excluded.state,
+			error_message = excluded.error_message,
+			output = excluded.output,
+			started_at = excluded.started_at,
+			finished_at = excluded.finished_at
+	`
+
+	_, err := s.db.ExecContext(ctx, query,
+		job.ID, job.Type, job.AgentID, job.AgentName, job.Method, job.Version,
+		string(job.State), job.Error, job.Output, job.CreatedAt, job.StartedAt, job.FinishedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+
+	return nil
+}
+
+// GetJob retrieves a job by ID, or nil if it does not exist.
+func (s *SQLiteStore) GetJob(ctx context.Context, id string) (*Job, error) {
+	query := `
+		SELECT id, type, agent_id, agent_name, install_method, version,
+			state, error_message, output, created_at, started_at, finished_at
+		FROM jobs
+		WHERE id = ?
+	`
+
+	job, err := scanJob(s.db.QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	return job, nil
+}
+
+// ListJobs returns jobs, newest first, optionally filtered by state. A
+// limit of zero or less returns all matching jobs.
+func (s *SQLiteStore) ListJobs(ctx context.Context, state JobState, limit int) ([]*Job, error) {
+	query := `
+		SELECT id, type, agent_id, agent_name, install_method, version,
+			state, error_message, output, created_at, started_at, finished_at
+		FROM jobs
+	`
+	var args []interface{}
+
+	if state != "" {
+		query += " WHERE state = ?"
+		args = append(args, string(state))
+	}
+
+	query += " ORDER BY created_at DESC, id DESC"
+
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan job: %w", err)
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// scanJob reads one job row from a row scanner.
+func scanJob(row interface{ Scan(...interface{}) error }) (*Job, error) {
+	var job Job
+	var state string
+	var startedAt, finishedAt sql.NullTime
+
+	err := row.Scan(
+		&job.ID, &job.Type, &job.AgentID, &job.AgentName, &job.Method, &job.Version,
+		&state, &job.Error, &job.Output, &job.CreatedAt, &startedAt, &finishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	job.State = JobState(state)
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+
+	return &job, nil
+}
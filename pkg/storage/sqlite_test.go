@@ -2,8 +2,10 @@ package storage
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -614,3 +616,82 @@ func TestGetSettingNotFound(t *testing.T) {
 		t.Errorf("value should be empty for nonexistent key, got %q", value)
 	}
 }
+
+func TestConcurrentAccess(t *testing.T) {
+	store, cleanup := setupTestStore(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	now := time.Now()
+
+	// Hammer the store from concurrent writers and readers the way the
+	// helper, CLI and REST handlers do; every operation must succeed
+	// without "database is locked" errors.
+	const workers = 8
+	const iterations = 25
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, workers*iterations*3)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				inst := &agent.Installation{
+					AgentID:          fmt.Sprintf("agent-%d", w),
+					AgentName:        fmt.Sprintf("Agent %d", w),
+					Method:           agent.InstallMethodNPM,
+					InstalledVersion: agent.MustParseVersion("1.0.0"),
+					ExecutablePath:   fmt.Sprintf("/usr/local/bin/agent-%d", w),
+					DetectedAt:       now,
+					LastChecked:      now,
+				}
+				if err := store.SaveInstallation(ctx, inst); err != nil {
+					errCh <- err
+				}
+
+				event := &UpdateEvent{
+					AgentID:       fmt.Sprintf("agent-%d", w),
+					AgentName:     fmt.Sprintf("Agent %d", w),
+					InstallMethod: "npm",
+					FromVersion:   "1.0.0",
+					ToVersion:     "1.0.1",
+					Status:        UpdateStatusCompleted,
+					StartedAt:     now,
+				}
+				if err := store.SaveUpdateEvent(ctx, event); err != nil {
+					errCh <- err
+				}
+
+				if err := store.SaveDetectionCache(ctx, []*agent.Installation{inst}); err != nil {
+					errCh <- err
+				}
+
+				if _, err := store.GetUpdateHistory(ctx, fmt.Sprintf("agent-%d", w), 5); err != nil {
+					errCh <- err
+				}
+				if _, _, err := store.GetDetectionCache(ctx); err != nil {
+					errCh <- err
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(errCh)
+
+	for err := range errCh {
+		t.Errorf("concurrent operation failed: %v", err)
+	}
+
+	// The data written under contention must be intact
+	for w := 0; w < workers; w++ {
+		history, err := store.GetUpdateHistory(ctx, fmt.Sprintf("agent-%d", w), iterations*2)
+		if err != nil {
+			t.Fatalf("GetUpdateHistory() error = %v", err)
+		}
+		if len(history) != iterations {
+			t.Errorf("agent-%d history count = %d, want %d", w, len(history), iterations)
+		}
+	}
+}
@@ -42,6 +42,11 @@ type Store interface {
 	GetSetting(ctx context.Context, key string) (string, error)
 	SetSetting(ctx context.Context, key, value string) error
 	DeleteSetting(ctx context.Context, key string) error
+
+	// Job operations
+	SaveJob(ctx context.Context, job *Job) error
+	GetJob(ctx context.Context, id string) (*Job, error)
+	ListJobs(ctx context.Context, state JobState, limit int) ([]*Job, error)
 }
 
 // UpdateEvent represents a recorded update event.
@@ -54,10 +59,35 @@ type UpdateEvent struct {
 	ToVersion     string
 	Status        UpdateStatus
 	ErrorMessage  string
+	Output        string
+	Retries       int
 	StartedAt     time.Time
 	CompletedAt   *time.Time
 }
 
+// Kind classifies the event as an install, update or rollback based on
+// the recorded versions.
+func (e *UpdateEvent) Kind() string {
+	if e.FromVersion == "" {
+		return "install"
+	}
+	from, errFrom := agent.ParseVersion(e.FromVersion)
+	to, errTo := agent.ParseVersion(e.ToVersion)
+	if errFrom == nil && errTo == nil && to.IsOlderThan(from) {
+		return "rollback"
+	}
+	return "update"
+}
+
+// Duration returns how long the event took, or zero when it never
+// completed.
+func (e *UpdateEvent) Duration() time.Duration {
+	if e.CompletedAt == nil {
+		return 0
+	}
+	return e.CompletedAt.Sub(e.StartedAt)
+}
+
 // UpdateStatus represents the status of an update.
 type UpdateStatus string
 
@@ -69,6 +99,43 @@ const (
 	UpdateStatusCancelled UpdateStatus = "cancelled"
 )
 
+// Job represents a tracked install, update, downgrade, or uninstall
+// operation with persistent state.
+type Job struct {
+	ID         string     `json:"id"`
+	Type       string     `json:"type"`
+	AgentID    string     `json:"agent_id"`
+	AgentName  string     `json:"agent_name"`
+	Method     string     `json:"method"`
+	Version    string     `json:"version,omitempty"`
+	State      JobState   `json:"state"`
+	Error      string     `json:"error,omitempty"`
+	Output     string     `json:"output,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// JobState represents the state of a job in its lifecycle.
+type JobState string
+
+const (
+	JobStateQueued    JobState = "queued"
+	JobStateRunning   JobState = "running"
+	JobStateSucceeded JobState = "succeeded"
+	JobStateFailed    JobState = "failed"
+	JobStateCancelled JobState = "cancelled"
+)
+
+// IsTerminal reports whether the state is final.
+func (s JobState) IsTerminal() bool {
+	switch s {
+	case JobStateSucceeded, JobStateFailed, JobStateCancelled:
+		return true
+	}
+	return false
+}
+
 // InstallationRecord represents a stored installation record.
 type InstallationRecord struct {
 	Key              string
@@ -112,6 +179,7 @@ func (r *InstallationRecord) ToInstallation() *agent.Installation {
 		InstallPath:      r.InstallPath,
 		DetectedAt:       r.FirstDetectedAt,
 		LastChecked:      r.LastCheckedAt,
+		UpdatedAt:        r.LastUpdatedAt,
 		Metadata:         r.Metadata,
 	}
 }
@@ -134,6 +202,7 @@ func FromInstallation(inst *agent.Installation) *InstallationRecord {
 		InstallPath:      inst.InstallPath,
 		FirstDetectedAt:  inst.DetectedAt,
 		LastCheckedAt:    inst.LastChecked,
+		LastUpdatedAt:    inst.UpdatedAt,
 		Metadata:         inst.Metadata,
 	}
 }
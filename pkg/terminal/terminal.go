@@ -0,0 +1,123 @@
+// Package terminal opens a terminal window running a shell command. It
+// picks the user's preferred emulator when one is configured and
+// otherwise auto-detects a supported one for the platform.
+package terminal
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+// cmdPlaceholder is the token in an argument template that expands to
+// the shell command the terminal should run.
+const cmdPlaceholder = "{cmd}"
+
+// Launcher describes one supported terminal emulator: the executable
+// to look up and the argument template used to run a command in a new
+// window. Each cmdPlaceholder in Args expands to the command string.
+type Launcher struct {
+	Name string
+	Exec string
+	Args []string
+}
+
+// appleScript builds an osascript launcher for a macOS terminal app.
+func appleScript(name, script string) Launcher {
+	return Launcher{Name: name, Exec: "osascript", Args: []string{"-e", script}}
+}
+
+// Launchers returns the supported terminal emulators for a platform,
+// ordered by detection preference.
+func Launchers(platformID string) []Launcher {
+	switch platformID {
+	case "darwin":
+		return []Launcher{
+			appleScript("terminal", `tell application "Terminal"
+	activate
+	do script "{cmd}"
+end tell`),
+			appleScript("iterm2", `tell application "iTerm"
+	activate
+	create window with default profile
+	tell current session of current window
+		write text "{cmd}"
+	end tell
+end tell`),
+			{Name: "kitty", Exec: "kitty", Args: []string{"sh", "-c", "{cmd}"}},
+			{Name: "wezterm", Exec: "wezterm", Args: []string{"start", "--", "sh", "-c", "{cmd}"}},
+		}
+	case "linux":
+		return []Launcher{
+			{Name: "gnome-terminal", Exec: "gnome-terminal", Args: []string{"--", "sh", "-c", "{cmd}"}},
+			{Name: "konsole", Exec: "konsole", Args: []string{"-e", "sh", "-c", "{cmd}"}},
+			{Name: "xfce4-terminal", Exec: "xfce4-terminal", Args: []string{"-e", "{cmd}"}},
+			{Name: "kitty", Exec: "kitty", Args: []string{"sh", "-c", "{cmd}"}},
+			{Name: "wezterm", Exec: "wezterm", Args: []string{"start", "--", "sh", "-c", "{cmd}"}},
+			{Name: "xterm", Exec: "xterm", Args: []string{"-e", "sh", "-c", "{cmd}"}},
+		}
+	case "windows":
+		return []Launcher{
+			{Name: "wt", Exec: "wt", Args: []string{"cmd", "/k", "{cmd}"}},
+			{Name: "cmd", Exec: "cmd", Args: []string{"/c", "start", "cmd", "/k", "{cmd}"}},
+		}
+	default:
+		return nil
+	}
+}
+
+// Find resolves the launcher to use. A configured preferred terminal
+// wins: a known name uses its template (with cfg.Args overriding it
+// when set), any other name is treated as the executable itself. With
+// no preference, the first supported emulator found on PATH is used.
+func Find(cfg config.TerminalConfig, platformID string) (Launcher, error) {
+	launchers := Launchers(platformID)
+
+	if cfg.Preferred != "" {
+		launcher := Launcher{Name: cfg.Preferred, Exec: cfg.Preferred, Args: []string{"-e", "{cmd}"}}
+		for _, known := range launchers {
+			if known.Name == cfg.Preferred {
+				launcher = known
+				break
+			}
+		}
+		if len(cfg.Args) > 0 {
+			launcher.Args = cfg.Args
+		}
+		if _, err := exec.LookPath(launcher.Exec); err != nil {
+			return Launcher{}, fmt.Errorf("preferred terminal %s not found: %w", cfg.Preferred, err)
+		}
+		return launcher, nil
+	}
+
+	for _, launcher := range launchers {
+		if _, err := exec.LookPath(launcher.Exec); err == nil {
+			return launcher, nil
+		}
+	}
+	return Launcher{}, fmt.Errorf("no supported terminal emulator found")
+}
+
+// CommandArgs expands the launcher's argument template for a command.
+func (l Launcher) CommandArgs(command string) []string {
+	args := make([]string, len(l.Args))
+	for i, arg := range l.Args {
+		args[i] = strings.ReplaceAll(arg, cmdPlaceholder, command)
+	}
+	return args
+}
+
+// Open starts the terminal running the given command and releases the
+// process so the window outlives the caller.
+func (l Launcher) Open(command string) error {
+	cmd := exec.Command(l.Exec, l.CommandArgs(command)...) //nolint:gosec // G204: commands come from the trusted catalog or our own binary
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	if cmd.Process != nil {
+		_ = cmd.Process.Release()
+	}
+	return nil
+}
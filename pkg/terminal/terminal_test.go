@@ -0,0 +1,72 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func TestLaunchersPerPlatform(t *testing.T) {
+	for _, platformID := range []string{"darwin", "linux", "windows"} {
+		if len(Launchers(platformID)) == 0 {
+			t.Errorf("no launchers for %s", platformID)
+		}
+	}
+	if Launchers("plan9") != nil {
+		t.Error("expected no launchers for an unsupported platform")
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	launcher := Launcher{Exec: "term", Args: []string{"-e", "sh", "-c", "{cmd}"}}
+
+	args := launcher.CommandArgs("agentmgr tui")
+	if len(args) != 4 || args[3] != "agentmgr tui" {
+		t.Errorf("CommandArgs() = %v", args)
+	}
+
+	// The template itself must not be modified
+	if launcher.Args[3] != "{cmd}" {
+		t.Errorf("template was modified: %v", launcher.Args)
+	}
+}
+
+func TestFindPreferredCustom(t *testing.T) {
+	// An unknown preferred name is treated as the executable itself;
+	// "sh" stands in for a terminal we have no template for.
+	cfg := config.TerminalConfig{Preferred: "sh", Args: []string{"-c", "{cmd}"}}
+
+	launcher, err := Find(cfg, "linux")
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if launcher.Exec != "sh" {
+		t.Errorf("Exec = %q, want %q", launcher.Exec, "sh")
+	}
+	if launcher.Args[1] != "{cmd}" {
+		t.Errorf("Args = %v, want the configured template", launcher.Args)
+	}
+}
+
+func TestFindPreferredMissing(t *testing.T) {
+	cfg := config.TerminalConfig{Preferred: "no-such-terminal-emulator"}
+
+	if _, err := Find(cfg, "linux"); err == nil {
+		t.Error("expected error for missing preferred terminal")
+	} else if !strings.Contains(err.Error(), "no-such-terminal-emulator") {
+		t.Errorf("error = %v, want it to name the terminal", err)
+	}
+}
+
+func TestFindPreferredArgsOverride(t *testing.T) {
+	cfg := config.TerminalConfig{Preferred: "xterm", Args: []string{"-hold", "-e", "{cmd}"}}
+
+	launcher, err := Find(cfg, "linux")
+	if err != nil {
+		t.Skip("xterm not installed")
+	}
+	if len(launcher.Args) != 3 || launcher.Args[0] != "-hold" {
+		t.Errorf("Args = %v, want the configured override", launcher.Args)
+	}
+}
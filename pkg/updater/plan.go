@@ -0,0 +1,223 @@
+// Package updater builds update plans: which installed agents would be
+// updated by Update All, from and to which versions, and what stands in
+// the way. Plans are shown for confirmation before updating and exposed
+// over the REST API for automation.
+package updater
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+	"github.com/kevinelliott/agentmgr/pkg/installer"
+)
+
+// changelogSummaryLines caps how many changelog lines make it into a plan
+// item's summary.
+const changelogSummaryLines = 3
+
+// PlanItem describes one agent the plan would update, or why it cannot be
+// updated.
+type PlanItem struct {
+	AgentID     string `json:"agent_id"`
+	AgentName   string `json:"agent_name"`
+	Method      string `json:"method"`
+	FromVersion string `json:"from_version"`
+	ToVersion   string `json:"to_version"`
+
+	// Changelog is a short summary of the changes between the two
+	// versions, when a changelog source is available.
+	Changelog string `json:"changelog,omitempty"`
+
+	// EstimatedBytes approximates the download size using the size of
+	// the currently installed executable. Zero when unknown.
+	EstimatedBytes int64 `json:"estimated_bytes,omitempty"`
+
+	// Blocked items have a newer version available that a pin or policy
+	// prevents installing; they are listed so conflicts are visible but
+	// are skipped when the plan is executed.
+	Blocked       bool   `json:"blocked,omitempty"`
+	BlockedReason string `json:"blocked_reason,omitempty"`
+
+	// Deprecated marks agents the catalog has sunset; ReplacedBy names
+	// the suggested replacement agent, when one is defined.
+	Deprecated bool   `json:"deprecated,omitempty"`
+	ReplacedBy string `json:"replaced_by,omitempty"`
+}
+
+// Plan is the result of previewing Update All.
+type Plan struct {
+	GeneratedAt time.Time  `json:"generated_at"`
+	Items       []PlanItem `json:"items"`
+
+	// TotalEstimatedBytes sums the estimated sizes of the updatable items.
+	TotalEstimatedBytes int64 `json:"total_estimated_bytes"`
+
+	// RequiresConfirmation reflects the updates.confirm_update_all
+	// setting so callers know whether to prompt before executing.
+	RequiresConfirmation bool `json:"requires_confirmation"`
+}
+
+// Updatable returns the items the plan would actually update, excluding
+// blocked ones.
+func (p *Plan) Updatable() []PlanItem {
+	var items []PlanItem
+	for _, item := range p.Items {
+		if !item.Blocked {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// Planner builds update plans from detected installations.
+type Planner struct {
+	cfg       *config.Config
+	catalog   *catalog.Manager
+	installer *installer.Manager
+}
+
+// NewPlanner creates a planner. The catalog manager is used for changelog
+// summaries and the installer for latest-version lookups; either may be
+// nil, in which case the corresponding plan fields are left empty.
+func NewPlanner(cfg *config.Config, cat *catalog.Manager, inst *installer.Manager) *Planner {
+	return &Planner{cfg: cfg, catalog: cat, installer: inst}
+}
+
+// Plan previews what Update All would do for the given installations.
+// Installations whose LatestVersion is already populated are used as-is;
+// otherwise the latest version is looked up from the install method's
+// registry.
+func (p *Planner) Plan(ctx context.Context, installations []*agent.Installation, agentDefs map[string]catalog.AgentDef) (*Plan, error) {
+	plan := &Plan{
+		GeneratedAt:          time.Now(),
+		RequiresConfirmation: p.cfg.Updates.ConfirmUpdateAll,
+	}
+
+	for _, inst := range installations {
+		agentDef, ok := agentDefs[inst.AgentID]
+		if !ok {
+			continue
+		}
+		methodDef, ok := agentDef.GetInstallMethod(string(inst.Method))
+		if !ok {
+			continue
+		}
+
+		latest, err := p.latestVersion(ctx, inst, agentDef, methodDef)
+		if err != nil || !latest.IsNewerThan(inst.InstalledVersion) {
+			continue
+		}
+
+		item := PlanItem{
+			AgentID:        inst.AgentID,
+			AgentName:      inst.AgentName,
+			Method:         string(inst.Method),
+			FromVersion:    inst.InstalledVersion.String(),
+			ToVersion:      latest.String(),
+			EstimatedBytes: executableSize(inst.ExecutablePath),
+			Deprecated:     agentDef.Deprecated,
+			ReplacedBy:     agentDef.ReplacedBy,
+		}
+
+		if reason, blocked := p.pinConflict(inst.AgentID, latest); blocked {
+			item.Blocked = true
+			item.BlockedReason = reason
+		} else {
+			plan.TotalEstimatedBytes += item.EstimatedBytes
+		}
+
+		item.Changelog = p.changelogSummary(ctx, inst.AgentID, inst.InstalledVersion, latest)
+		plan.Items = append(plan.Items, item)
+	}
+
+	return plan, nil
+}
+
+// latestVersion returns the newest version available for the installation,
+// preferring the value detection already resolved.
+func (p *Planner) latestVersion(ctx context.Context, inst *agent.Installation, agentDef catalog.AgentDef, methodDef catalog.InstallMethodDef) (agent.Version, error) {
+	if inst.LatestVersion != nil {
+		return *inst.LatestVersion, nil
+	}
+	if p.installer == nil {
+		return agent.Version{}, fmt.Errorf("no installer available for version lookup")
+	}
+
+	latest, err := p.installer.GetLatestVersion(ctx, methodDef)
+	if err != nil {
+		return agent.Version{}, err
+	}
+	if agentDef.VersionScheme != "" {
+		if parsed, err := agent.ParseVersionScheme(latest.String(), agent.VersionScheme(agentDef.VersionScheme), agentDef.VersionPattern); err == nil {
+			latest = parsed
+		}
+	}
+	return latest, nil
+}
+
+// pinConflict reports whether the agent's pinned version constraint blocks
+// updating to the given version.
+func (p *Planner) pinConflict(agentID string, v agent.Version) (string, bool) {
+	pinned := p.cfg.GetPinnedVersion(agentID)
+	if pinned == "" {
+		return "", false
+	}
+
+	constraint, err := agent.ParseConstraint(pinned)
+	if err != nil {
+		// An unparseable pin should not block updates
+		return "", false
+	}
+	if constraint.Matches(v) {
+		return "", false
+	}
+	return fmt.Sprintf("pinned to %s", pinned), true
+}
+
+// changelogSummary fetches the changelog between two versions and reduces
+// it to a few lines. Returns "" when no changelog is available.
+func (p *Planner) changelogSummary(ctx context.Context, agentID string, from, to agent.Version) string {
+	if p.catalog == nil {
+		return ""
+	}
+	changelog, err := p.catalog.GetChangelog(ctx, agentID, from, to)
+	if err != nil {
+		return ""
+	}
+	return summarize(changelog)
+}
+
+// summarize keeps the first few non-empty lines of a changelog.
+func summarize(changelog string) string {
+	var lines []string
+	for _, line := range strings.Split(changelog, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+		if len(lines) == changelogSummaryLines {
+			break
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// executableSize returns the size of the installed executable, used as a
+// rough download-size estimate. Zero when the path cannot be inspected.
+func executableSize(path string) int64 {
+	if path == "" {
+		return 0
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return 0
+	}
+	return info.Size()
+}
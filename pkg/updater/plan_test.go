@@ -0,0 +1,140 @@
+package updater
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/config"
+)
+
+func testAgentDefs() map[string]catalog.AgentDef {
+	return map[string]catalog.AgentDef{
+		"claude-code": {
+			ID:   "claude-code",
+			Name: "Claude Code",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"npm": {Method: "npm", Platforms: []string{"darwin", "linux"}},
+			},
+		},
+	}
+}
+
+func installationWithUpdate(t *testing.T, from, to string) *agent.Installation {
+	t.Helper()
+	latest := agent.MustParseVersion(to)
+	return &agent.Installation{
+		AgentID:          "claude-code",
+		AgentName:        "Claude Code",
+		Method:           agent.MethodNPM,
+		InstalledVersion: agent.MustParseVersion(from),
+		LatestVersion:    &latest,
+	}
+}
+
+func TestPlannerPlan(t *testing.T) {
+	planner := NewPlanner(config.Default(), nil, nil)
+
+	inst := installationWithUpdate(t, "1.0.0", "1.2.0")
+	plan, err := planner.Plan(context.Background(), []*agent.Installation{inst}, testAgentDefs())
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+
+	if len(plan.Items) != 1 {
+		t.Fatalf("items = %d, want 1", len(plan.Items))
+	}
+	item := plan.Items[0]
+	if item.FromVersion != "1.0.0" || item.ToVersion != "1.2.0" {
+		t.Errorf("item versions = %s -> %s, want 1.0.0 -> 1.2.0", item.FromVersion, item.ToVersion)
+	}
+	if item.Blocked {
+		t.Error("item should not be blocked without a pin")
+	}
+	if !plan.RequiresConfirmation {
+		t.Error("default config should require confirmation")
+	}
+}
+
+func TestPlannerPlanSkipsUpToDate(t *testing.T) {
+	planner := NewPlanner(config.Default(), nil, nil)
+
+	latest := agent.MustParseVersion("1.0.0")
+	inst := &agent.Installation{
+		AgentID:          "claude-code",
+		Method:           agent.MethodNPM,
+		InstalledVersion: agent.MustParseVersion("1.0.0"),
+		LatestVersion:    &latest,
+	}
+
+	plan, err := planner.Plan(context.Background(), []*agent.Installation{inst}, testAgentDefs())
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if len(plan.Items) != 0 {
+		t.Errorf("items = %d, want 0 for an up-to-date agent", len(plan.Items))
+	}
+}
+
+func TestPlannerPlanPinConflict(t *testing.T) {
+	cfg := config.Default()
+	cfg.Agents = map[string]config.AgentConfig{
+		"claude-code": {PinnedVersion: "~1.0"},
+	}
+	planner := NewPlanner(cfg, nil, nil)
+
+	inst := installationWithUpdate(t, "1.0.0", "2.0.0")
+	plan, err := planner.Plan(context.Background(), []*agent.Installation{inst}, testAgentDefs())
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+
+	if len(plan.Items) != 1 {
+		t.Fatalf("items = %d, want the conflict to be listed", len(plan.Items))
+	}
+	item := plan.Items[0]
+	if !item.Blocked || item.BlockedReason == "" {
+		t.Errorf("item = %+v, want blocked with a reason", item)
+	}
+	if len(plan.Updatable()) != 0 {
+		t.Error("blocked items must not be updatable")
+	}
+	if plan.TotalEstimatedBytes != 0 {
+		t.Error("blocked items must not count toward the download estimate")
+	}
+}
+
+func TestPlannerPlanEstimatesSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "claude")
+	if err := os.WriteFile(path, make([]byte, 1024), 0o755); err != nil {
+		t.Fatalf("failed to write executable: %v", err)
+	}
+
+	planner := NewPlanner(config.Default(), nil, nil)
+	inst := installationWithUpdate(t, "1.0.0", "1.1.0")
+	inst.ExecutablePath = path
+
+	plan, err := planner.Plan(context.Background(), []*agent.Installation{inst}, testAgentDefs())
+	if err != nil {
+		t.Fatalf("Plan() error = %v", err)
+	}
+	if plan.Items[0].EstimatedBytes != 1024 {
+		t.Errorf("EstimatedBytes = %d, want 1024", plan.Items[0].EstimatedBytes)
+	}
+	if plan.TotalEstimatedBytes != 1024 {
+		t.Errorf("TotalEstimatedBytes = %d, want 1024", plan.TotalEstimatedBytes)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	changelog := "# 1.2.0\n\n- Fix crash on startup\n- Add new flag\n- Improve logging\n- More things\n"
+	summary := summarize(changelog)
+
+	want := "# 1.2.0\n- Fix crash on startup\n- Add new flag"
+	if summary != want {
+		t.Errorf("summarize() = %q, want %q", summary, want)
+	}
+}
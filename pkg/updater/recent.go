@@ -0,0 +1,67 @@
+package updater
+
+import (
+	"context"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// historyLookback caps how many update-history events are inspected per
+// agent when looking for the most recent completed update.
+const historyLookback = 10
+
+// AnnotateUpdateState fills UpdatedAt and RestartPending on the given
+// installations from the stored update history and the catalog's restart
+// hints. Detection produces fresh Installation values, so this is how the
+// "recently updated" and "restart pending" state survives re-detection.
+func AnnotateUpdateState(ctx context.Context, st storage.Store, installations []*agent.Installation, agentDefs map[string]catalog.AgentDef) {
+	if st == nil {
+		return
+	}
+
+	for _, inst := range installations {
+		if inst.UpdatedAt != nil {
+			continue
+		}
+
+		history, err := st.GetUpdateHistory(ctx, inst.AgentID, historyLookback)
+		if err != nil {
+			continue
+		}
+		event := latestCompleted(history, string(inst.Method))
+		if event == nil {
+			continue
+		}
+
+		updatedAt := event.StartedAt
+		if event.CompletedAt != nil {
+			updatedAt = *event.CompletedAt
+		}
+		inst.UpdatedAt = &updatedAt
+
+		// Only a recent update can still be waiting on a restart; older
+		// ones predate the current session of whatever hosts the agent
+		if time.Since(updatedAt) > agent.RecentUpdateWindow {
+			continue
+		}
+		if agentDef, ok := agentDefs[inst.AgentID]; ok {
+			if methodDef, ok := agentDef.GetInstallMethod(string(inst.Method)); ok {
+				inst.RestartPending = methodDef.RestartRequired
+			}
+		}
+	}
+}
+
+// latestCompleted returns the newest completed event for the given install
+// method, or nil when the history has none.
+func latestCompleted(history []*storage.UpdateEvent, method string) *storage.UpdateEvent {
+	for _, event := range history {
+		if event.Status == storage.UpdateStatusCompleted && event.InstallMethod == method {
+			return event
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package updater
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/agentmgrtest"
+	"github.com/kevinelliott/agentmgr/pkg/catalog"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+func restartAgentDefs() map[string]catalog.AgentDef {
+	return map[string]catalog.AgentDef{
+		"claude-code": {
+			ID:   "claude-code",
+			Name: "Claude Code",
+			InstallMethods: map[string]catalog.InstallMethodDef{
+				"extension": {Method: "extension", RestartRequired: true, RestartTarget: "VS Code"},
+				"npm":       {Method: "npm"},
+			},
+		},
+	}
+}
+
+func saveUpdateEvent(t *testing.T, st storage.Store, method string, completedAt time.Time, status storage.UpdateStatus) {
+	t.Helper()
+	err := st.SaveUpdateEvent(context.Background(), &storage.UpdateEvent{
+		AgentID:       "claude-code",
+		AgentName:     "Claude Code",
+		InstallMethod: method,
+		FromVersion:   "1.0.0",
+		ToVersion:     "1.1.0",
+		Status:        status,
+		StartedAt:     completedAt.Add(-time.Minute),
+		CompletedAt:   &completedAt,
+	})
+	if err != nil {
+		t.Fatalf("SaveUpdateEvent() error = %v", err)
+	}
+}
+
+func TestAnnotateUpdateStateRecentUpdate(t *testing.T) {
+	st := agentmgrtest.NewFakeStore()
+	updatedAt := time.Now().Add(-time.Hour)
+	saveUpdateEvent(t, st, "extension", updatedAt, storage.UpdateStatusCompleted)
+
+	inst := &agent.Installation{AgentID: "claude-code", Method: agent.InstallMethod("extension")}
+	AnnotateUpdateState(context.Background(), st, []*agent.Installation{inst}, restartAgentDefs())
+
+	if inst.UpdatedAt == nil || !inst.UpdatedAt.Equal(updatedAt) {
+		t.Errorf("UpdatedAt = %v, want %v", inst.UpdatedAt, updatedAt)
+	}
+	if !inst.RecentlyUpdated() {
+		t.Error("RecentlyUpdated() = false for an update an hour ago")
+	}
+	if !inst.RestartPending {
+		t.Error("RestartPending = false for a restart-required method")
+	}
+}
+
+func TestAnnotateUpdateStateOldUpdate(t *testing.T) {
+	st := agentmgrtest.NewFakeStore()
+	saveUpdateEvent(t, st, "extension", time.Now().Add(-48*time.Hour), storage.UpdateStatusCompleted)
+
+	inst := &agent.Installation{AgentID: "claude-code", Method: agent.InstallMethod("extension")}
+	AnnotateUpdateState(context.Background(), st, []*agent.Installation{inst}, restartAgentDefs())
+
+	if inst.UpdatedAt == nil {
+		t.Fatal("UpdatedAt not set from update history")
+	}
+	if inst.RecentlyUpdated() {
+		t.Error("RecentlyUpdated() = true for a two-day-old update")
+	}
+	if inst.RestartPending {
+		t.Error("RestartPending = true for an update outside the recent window")
+	}
+}
+
+func TestAnnotateUpdateStateIgnoresOtherMethodsAndFailures(t *testing.T) {
+	st := agentmgrtest.NewFakeStore()
+	saveUpdateEvent(t, st, "npm", time.Now(), storage.UpdateStatusCompleted)
+	saveUpdateEvent(t, st, "extension", time.Now(), storage.UpdateStatusFailed)
+
+	inst := &agent.Installation{AgentID: "claude-code", Method: agent.InstallMethod("extension")}
+	AnnotateUpdateState(context.Background(), st, []*agent.Installation{inst}, restartAgentDefs())
+
+	if inst.UpdatedAt != nil {
+		t.Errorf("UpdatedAt = %v, want nil without a completed update for the method", inst.UpdatedAt)
+	}
+	if inst.RestartPending {
+		t.Error("RestartPending = true without a completed update")
+	}
+}
+
+func TestAnnotateUpdateStateNoRestartForPlainMethod(t *testing.T) {
+	st := agentmgrtest.NewFakeStore()
+	saveUpdateEvent(t, st, "npm", time.Now(), storage.UpdateStatusCompleted)
+
+	inst := &agent.Installation{AgentID: "claude-code", Method: agent.MethodNPM}
+	AnnotateUpdateState(context.Background(), st, []*agent.Installation{inst}, restartAgentDefs())
+
+	if inst.UpdatedAt == nil {
+		t.Fatal("UpdatedAt not set from update history")
+	}
+	if inst.RestartPending {
+		t.Error("RestartPending = true for a method without a restart hint")
+	}
+}
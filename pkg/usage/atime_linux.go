@@ -0,0 +1,18 @@
+//go:build linux
+
+package usage
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// accessTime returns the access time from the underlying stat structure.
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(stat.Atim.Sec, stat.Atim.Nsec), true
+}
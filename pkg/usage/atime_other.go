@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package usage
+
+import (
+	"os"
+	"time"
+)
+
+// accessTime is not available on this platform; callers fall back to mtime.
+func accessTime(info os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
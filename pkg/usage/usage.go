@@ -0,0 +1,124 @@
+// Package usage tracks when installed agents were last used, based on
+// filesystem access-time sampling of their executables.
+package usage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+	"github.com/kevinelliott/agentmgr/pkg/storage"
+)
+
+// settingKeyPrefix prefixes the storage settings keys for last-used times.
+const settingKeyPrefix = "usage.last_used."
+
+// Tracker records and retrieves last-used timestamps for installations.
+type Tracker struct {
+	store storage.Store
+}
+
+// NewTracker creates a usage tracker backed by the given store.
+// A nil store disables persistence; sampling still works in-memory.
+func NewTracker(store storage.Store) *Tracker {
+	return &Tracker{store: store}
+}
+
+// Sample inspects the executables of the given installations, updates their
+// LastUsed field from filesystem timestamps, and persists the newest
+// observation per installation.
+func (t *Tracker) Sample(ctx context.Context, installations []*agent.Installation) {
+	for _, inst := range installations {
+		if inst.ExecutablePath == "" {
+			continue
+		}
+
+		observed := lastUsedTime(inst.ExecutablePath)
+		stored := t.lastRecorded(ctx, inst.Key())
+
+		// Keep the newest of the stored and the freshly observed times.
+		// Access times can be reset by backups or package managers, so a
+		// previously recorded later time wins.
+		best := observed
+		if stored.After(best) {
+			best = stored
+		}
+
+		if best.IsZero() {
+			continue
+		}
+
+		inst.LastUsed = &best
+
+		if t.store != nil && observed.After(stored) {
+			_ = t.store.SetSetting(ctx, settingKey(inst.Key()), observed.Format(time.RFC3339))
+		}
+	}
+}
+
+// LastUsed returns the recorded last-used time for an installation key.
+func (t *Tracker) LastUsed(ctx context.Context, key string) (time.Time, bool) {
+	recorded := t.lastRecorded(ctx, key)
+	return recorded, !recorded.IsZero()
+}
+
+// lastRecorded reads the persisted last-used time, if any.
+func (t *Tracker) lastRecorded(ctx context.Context, key string) time.Time {
+	if t.store == nil {
+		return time.Time{}
+	}
+
+	value, err := t.store.GetSetting(ctx, settingKey(key))
+	if err != nil || value == "" {
+		return time.Time{}
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+	return parsed
+}
+
+// settingKey builds the storage settings key for an installation.
+func settingKey(installationKey string) string {
+	return fmt.Sprintf("%s%s", settingKeyPrefix, installationKey)
+}
+
+// lastUsedTime returns the best-effort last-used time for an executable.
+// On platforms with access times this is the atime; otherwise the mtime.
+func lastUsedTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+
+	if atime, ok := accessTime(info); ok {
+		return atime
+	}
+	return info.ModTime()
+}
+
+// FormatRelative formats a last-used time as a short human-friendly string
+// ("3d ago", "2h ago", "never").
+func FormatRelative(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "never"
+	}
+
+	d := time.Since(*t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	default:
+		return t.Format("2006-01-02")
+	}
+}
@@ -0,0 +1,81 @@
+package usage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kevinelliott/agentmgr/pkg/agent"
+)
+
+func TestSampleSetsLastUsed(t *testing.T) {
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "fake-agent")
+	if err := os.WriteFile(exe, []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatalf("failed to write executable: %v", err)
+	}
+
+	inst := &agent.Installation{
+		AgentID:        "fake",
+		Method:         agent.MethodBinary,
+		ExecutablePath: exe,
+	}
+
+	tracker := NewTracker(nil)
+	tracker.Sample(context.Background(), []*agent.Installation{inst})
+
+	if inst.LastUsed == nil {
+		t.Fatal("expected LastUsed to be set")
+	}
+	if time.Since(*inst.LastUsed) > time.Hour {
+		t.Errorf("expected recent last-used time, got %v", inst.LastUsed)
+	}
+}
+
+func TestSampleSkipsMissingExecutable(t *testing.T) {
+	inst := &agent.Installation{
+		AgentID:        "gone",
+		Method:         agent.MethodBinary,
+		ExecutablePath: "/nonexistent/path/agent",
+	}
+
+	tracker := NewTracker(nil)
+	tracker.Sample(context.Background(), []*agent.Installation{inst})
+
+	if inst.LastUsed != nil {
+		t.Errorf("expected nil LastUsed for missing executable, got %v", inst.LastUsed)
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-30 * time.Second)
+	minutes := now.Add(-5 * time.Minute)
+	hours := now.Add(-3 * time.Hour)
+	days := now.Add(-4 * 24 * time.Hour)
+	old := now.Add(-90 * 24 * time.Hour)
+
+	tests := []struct {
+		name     string
+		input    *time.Time
+		expected string
+	}{
+		{"nil", nil, "never"},
+		{"just now", &recent, "just now"},
+		{"minutes", &minutes, "5m ago"},
+		{"hours", &hours, "3h ago"},
+		{"days", &days, "4d ago"},
+		{"old", &old, old.Format("2006-01-02")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FormatRelative(tt.input)
+			if result != tt.expected {
+				t.Errorf("FormatRelative() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}